@@ -6,11 +6,15 @@ import (
 	"net/http"
 	"strconv"
 	"time"
+	// the time zones of the reports have to load on hosts without zoneinfo
+	_ "time/tzdata"
 	"workout-tracker-api/internal/cache"
 	"workout-tracker-api/internal/database"
 	"workout-tracker-api/internal/handler"
+	"workout-tracker-api/internal/mailer"
 	"workout-tracker-api/internal/middleware"
 	"workout-tracker-api/internal/repository"
+	"workout-tracker-api/internal/scheduler"
 	"workout-tracker-api/internal/service"
 	"workout-tracker-api/internal/util"
 	"workout-tracker-api/internal/util/auth"
@@ -21,7 +25,6 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
-	"github.com/golang-jwt/jwt/v5"
 )
 
 func Server() {
@@ -66,20 +69,112 @@ func Server() {
 	exerciseRepo := repository.NewExerRepository(db)
 
 	exercisePlanRepo := repository.NewEPRepository(db)
+	performedSetRepo := repository.NewPSRepository(db)
+	scheduleRepo := repository.NewScheduleRepository(db)
+	templateRepo := repository.NewTemplateRepository(db)
+	personalRecordRepo := repository.NewPRRepository(db)
+	reportRepo := repository.NewReportRepository(db)
+	refreshTokenRepo := repository.NewRTRepository(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	passwordResetRepo := repository.NewPasswordResetRepository(db)
+	emailVerificationRepo := repository.NewEmailVerificationRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	twoFactorRepo := repository.NewTwoFactorRepository(db)
+	adminRepo := repository.NewAdminRepository(db)
+	accessTokenRepo := repository.NewAccessTokenRepository(db)
+	unitOfWork := repository.NewUnitOfWork(db)
 	//  initialize services
-	jwtService := auth.NewJWTService(jwt.SigningMethodES256, jwtCache, envVars.JWT.SecretKey)
+	jwtKeys, err := auth.LoadKeySet(envVars.JWT.SigningKeyFile, envVars.JWT.SigningKeyId, envVars.JWT.SecretKey, envVars.JWT.VerifyKeyFiles)
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+	jwtService := auth.NewJWTService(jwtKeys, jwtCache, envVars.JWT.AccessTokenTTL)
 	passwordHasher := encrypt.NewHashService()
+	mail, err := mailer.New(mailer.Config{
+		Driver: envVars.Mail.Driver,
+		From:   envVars.Mail.From,
+		SMTP: mailer.SMTPConfig{
+			Host:     envVars.Mail.SMTPHost,
+			Port:     envVars.Mail.SMTPPort,
+			Username: envVars.Mail.SMTPUsername,
+			Password: envVars.Mail.SMTPPassword,
+		},
+		FilePath: envVars.Mail.FilePath,
+	})
+	if err != nil {
+		log.Fatalf("Failed to set up mailer: %v", err)
+	}
+
+	refreshTokenService := service.NewRTService(refreshTokenRepo, userRepo, sessionRepo, unitOfWork, envVars.JWT.RefreshTokenTTL)
+	sessionService := service.NewSessionService(sessionRepo, refreshTokenRepo, unitOfWork)
+	loginGuard := service.NewLoginGuard(jwtCache, auditRepo, userRepo, service.LoginGuardConfig{
+		AccountThreshold: envVars.Login.AccountThreshold,
+		IPThreshold:      envVars.Login.IPThreshold,
+		BaseLockout:      envVars.Login.BaseLockout,
+		MaxLockout:       envVars.Login.MaxLockout,
+		FailureWindow:    envVars.Login.FailureWindow,
+	})
+	verificationPolicy := service.VerificationPolicy(envVars.EmailVerificationPolicy)
+	twoFactorService := service.NewTwoFactorService(twoFactorRepo, userRepo, unitOfWork, passwordHasher, jwtCache, loginGuard, service.TwoFactorConfig{
+		Issuer:       envVars.TwoFactor.Issuer,
+		ChallengeTTL: envVars.TwoFactor.ChallengeTTL,
+		MaxAttempts:  envVars.TwoFactor.MaxAttempts,
+	})
+	userService := service.NewUserService(userRepo, passwordResetRepo, emailVerificationRepo, unitOfWork, sessionService, twoFactorService, loginGuard, passwordHasher, mail, service.UserConfig{
+		PasswordResetTTL:   envVars.PasswordResetTTL,
+		VerificationTTL:    envVars.EmailVerificationTTL,
+		VerificationPolicy: verificationPolicy,
+	})
+	personalRecordService := service.NewPRService(woroutRepo, exercisePlanRepo, performedSetRepo, personalRecordRepo)
+	workoutService := service.NewWPService(woroutRepo, exercisePlanRepo, exerciseRepo, unitOfWork, personalRecordService)
+	exerciseService := service.NewExerciseService(exerciseRepo, unitOfWork)
+	reportService := service.NewReportService(woroutRepo, reportRepo, userRepo, exerciseRepo, service.MuscleBalanceConfig{
+		PushPullRatio:   envVars.Balance.PushPullRatio,
+		ChestBackRatio:  envVars.Balance.ChestBackRatio,
+		UpperLowerRatio: envVars.Balance.UpperLowerRatio,
+	})
+	performedSetService := service.NewPSService(performedSetRepo, exercisePlanRepo)
+	scheduleService := service.NewScheduleService(scheduleRepo, woroutRepo, exercisePlanRepo, exerciseRepo, unitOfWork)
+	templateService := service.NewTemplateService(templateRepo, exerciseRepo, workoutService)
+	accessTokenService := service.NewAccessTokenService(accessTokenRepo, userRepo)
+	adminService := service.NewAdminService(userRepo, adminRepo, auditRepo, unitOfWork, sessionService)
+	exportService := service.NewExportService(woroutRepo, exercisePlanRepo, userRepo)
+	accountDeletionService := service.NewAccountDeletionService(userRepo, accessTokenRepo, auditRepo, unitOfWork, sessionService, twoFactorService, loginGuard, passwordHasher, service.AccountDeletionConfig{
+		GracePeriod: envVars.AccountDeletionGracePeriod,
+	})
 
-	userService := service.NewUserService(userRepo, passwordHasher)
-	workoutService := service.NewWPService(woroutRepo, exercisePlanRepo)
-	exerciseService := service.NewExerciseService(exerciseRepo)
-	reportService := service.NewReportService(woroutRepo)
+	// the configured emails keep the admin role, an empty list leaves the roles as they are
+	if err := adminService.GrantAdmins(context.Background(), envVars.AdminEmails); err != nil {
+		log.Printf("Failed to grant admin role to ADMIN_EMAILS: %v", err)
+	}
+
+	//  background jobs
+	missedScheduler := scheduler.NewMissedScheduler(woroutRepo, jwtCache, scheduler.NewSystemClock(), scheduler.MissedConfig{
+		GracePeriod: envVars.Scheduler.MissedGracePeriod,
+		Interval:    envVars.Scheduler.MissedCheckInterval,
+	})
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	go missedScheduler.Start(schedulerCtx)
+	deletionScheduler := scheduler.NewDeletionScheduler(accountDeletionService, jwtCache, scheduler.NewSystemClock(), scheduler.DeletionConfig{
+		Interval: envVars.Scheduler.DeletionCheckInterval,
+	})
+	go deletionScheduler.Start(schedulerCtx)
 
 	//  initialize handler
-	userHandler := handler.NewUserHandler(userService, workoutService, jwtService)
+	userHandler := handler.NewUserHandler(userService, workoutService, jwtService, refreshTokenService, sessionService, twoFactorService)
 	wokoutHanlder := handler.NewWorkoutHandler(workoutService)
 	exerciseHandler := handler.NewExerciseHandler(exerciseService)
-	reportHandler := handler.NewReportHandler(reportService)
+	reportHandler := handler.NewReportHandler(reportService, personalRecordService)
+	performedSetHandler := handler.NewPerformedSetHandler(workoutService, performedSetService)
+	scheduleHandler := handler.NewScheduleHandler(scheduleService)
+	templateHandler := handler.NewTemplateHandler(workoutService, templateService)
+	jobHandler := handler.NewJobHandler(missedScheduler)
+	sessionHandler := handler.NewSessionHandler(sessionService, jwtService)
+	adminHandler := handler.NewAdminHandler(loginGuard, adminService, exerciseService, jwtService)
+	accessTokenHandler := handler.NewAccessTokenHandler(accessTokenService)
+	accountDeletionHandler := handler.NewAccountDeletionHandler(accountDeletionService, jwtService)
+	exportHandler := handler.NewExportHandler(exportService)
 
 	// setup router
 	apiHandler := handler.NewAPIHandler(
@@ -87,19 +182,35 @@ func Server() {
 		wokoutHanlder,
 		exerciseHandler,
 		reportHandler,
+		performedSetHandler,
+		scheduleHandler,
+		templateHandler,
+		jobHandler,
+		sessionHandler,
+		adminHandler,
+		accessTokenHandler,
+		accountDeletionHandler,
+		exportHandler,
 	)
 
 	r := chi.NewRouter()
 
 	r.Use(chimiddleware.RequestID)
-	r.Use(chimiddleware.RealIP)                    // Get client IP
-	r.Use(chimiddleware.Logger)                    // Log request details
-	r.Use(chimiddleware.Recoverer)                 // Recover from panics
-	r.Use(chimiddleware.Timeout(60 * time.Second)) // Set a global timeout
+	r.Use(chimiddleware.RealIP)    // Get client IP
+	r.Use(chimiddleware.Logger)    // Log request details
+	r.Use(chimiddleware.Recoverer) // Recover from panics
+
+	// every route but the export gets this timeout, the export has its own longer one
+	timeout := chimiddleware.Timeout(60 * time.Second)
+
+	// JWKS lives at the server root where JWT libraries look for it
+	r.With(timeout).Get("/.well-known/jwks.json", apiHandler.GetJwks)
 
 	r.Route("/workout-tracker/v1", func(r chi.Router) {
 		// Public routes group
 		r.Group(func(r chi.Router) {
+			r.Use(timeout)
+
 			wrapper := api.ServerInterfaceWrapper{
 				Handler: apiHandler,
 				ErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
@@ -109,10 +220,18 @@ func Server() {
 			}
 			r.Post("/user/signup", wrapper.SignupUser)
 			r.Post("/user/login", wrapper.LoginUser)
+			r.Post("/user/login/2fa", wrapper.CompleteTwoFactorLogin)
+			r.Post("/user/token/refresh", wrapper.RefreshUserToken)
+			r.Post("/user/password/reset/request", wrapper.RequestPasswordReset)
+			r.Post("/user/password/reset", wrapper.ResetUserPassword)
+			r.Post("/user/verify-email", wrapper.VerifyUserEmail)
+			r.Post("/user/verify-email/resend", wrapper.ResendVerificationEmail)
+			r.Post("/user/deletion/cancel", wrapper.CancelUserDeletion)
 		})
 
-		// Protected routes group with JWT middleware
+		// Account routes only take the JWTs of login sessions
 		r.Group(func(r chi.Router) {
+			r.Use(timeout)
 			r.Use(middleware.JWTAuthMiddleware(jwtService))
 
 			wrapper := api.ServerInterfaceWrapper{
@@ -124,17 +243,135 @@ func Server() {
 			}
 			r.Post("/user/logout", wrapper.LogoutUser)
 			r.Get("/user/status", wrapper.GetUserStatus)
-			r.Get("/workouts", wrapper.ListWorkoutPlans)
-			r.Post("/workouts", wrapper.CreateWorkoutPlan)
-			r.Get("/workouts/{workoutId}", wrapper.GetWorkoutPlanById)
-			r.Delete("/workouts/{workoutId}", wrapper.DeleteWorkoutPlanById)
-			r.Put("/workouts/{workoutId}/complete", wrapper.CompleteWorkoutPlanById)
-			r.Put("/workouts/{workoutId}/schedule", wrapper.ScheduleWorkoutPlanById)
-			r.Put("/workouts/{workoutId}/update-exercise-plans", wrapper.UpdateExercisePlansInWorkoutPlan)
-			r.Get("/exercises", wrapper.ListExercises)
-			r.Get("/exercises/{exerciseId}", wrapper.GetExerciseById)
-			r.Get("/report/progress", wrapper.ReportProgress)
+			r.Put("/user/preferences", wrapper.UpdateUserPreferences)
+			r.Put("/user/password", wrapper.ChangeUserPassword)
+			r.Get("/user/sessions", wrapper.ListUserSessions)
+			r.Delete("/user/sessions", wrapper.RevokeAllUserSessions)
+			r.Delete("/user/sessions/{sessionId}", wrapper.RevokeUserSession)
+			r.Post("/user/2fa/enroll", wrapper.EnrollTwoFactor)
+			r.Post("/user/2fa/confirm", wrapper.ConfirmTwoFactor)
+			r.Delete("/user/2fa", wrapper.DisableTwoFactor)
+			r.Delete("/user", wrapper.DeleteUser)
+
+			// with the limited policy unverified users only reach their account routes
+			r.Group(func(r chi.Router) {
+				if verificationPolicy == service.VerificationLimited {
+					r.Use(middleware.RequireVerifiedEmail())
+				}
+
+				r.Get("/user/tokens", wrapper.ListAccessTokens)
+				r.Post("/user/tokens", wrapper.CreateAccessToken)
+				r.Get("/user/tokens/{tokenId}", wrapper.GetAccessToken)
+				r.Put("/user/tokens/{tokenId}", wrapper.UpdateAccessToken)
+				r.Delete("/user/tokens/{tokenId}", wrapper.DeleteAccessToken)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequireRole(string(service.RoleAdmin)))
+
+				r.Get("/admin/users", wrapper.ListUsers)
+				r.Put("/admin/users/{userId}/disable", wrapper.DisableUser)
+				r.Put("/admin/users/{userId}/enable", wrapper.EnableUser)
+				r.Put("/admin/users/{userId}/role", wrapper.UpdateUserRole)
+				r.Post("/admin/users/{userId}/unlock", wrapper.UnlockUserAccount)
+				r.Get("/admin/stats", wrapper.GetSystemStats)
+				r.Post("/admin/exercises", wrapper.CreateCatalogExercise)
+				r.Put("/admin/exercises/{exerciseId}", wrapper.UpdateCatalogExercise)
+				r.Delete("/admin/exercises/{exerciseId}", wrapper.DeleteCatalogExercise)
+				r.Post("/jobs/missed-workouts", wrapper.TriggerMissedWorkouts)
+			})
+		})
+
+		// Data routes also take personal access tokens, every route has to sit under a scope
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.AccessTokenAuthMiddleware(jwtService, accessTokenService))
+			if verificationPolicy == service.VerificationLimited {
+				r.Use(middleware.RequireVerifiedEmail())
+			}
+
+			wrapper := api.ServerInterfaceWrapper{
+				Handler: apiHandler,
+				ErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+					log.Printf("API error: %v", err)
+					helper.SendErrorResponse(w, err)
+				},
+			}
+
+			r.Group(func(r chi.Router) {
+				r.Use(timeout)
+				r.Use(middleware.RequireScope(service.ScopeWorkoutsRead))
+
+				r.Get("/workouts", wrapper.ListWorkoutPlans)
+				r.Get("/workouts/{workoutId}", wrapper.GetWorkoutPlanById)
+				r.Get("/workouts/{workoutId}/exercise-plans/{exercisePlanId}/sets", wrapper.ListPerformedSets)
+				r.Get("/schedules", wrapper.ListSchedules)
+				r.Post("/schedules/preview", wrapper.PreviewSchedule)
+				r.Get("/schedules/{scheduleId}", wrapper.GetScheduleById)
+				r.Get("/templates", wrapper.ListTemplates)
+				r.Get("/templates/{templateId}", wrapper.GetTemplateById)
+			})
+
+			// the export streams the whole history and would be cut off by the global timeout
+			r.Group(func(r chi.Router) {
+				r.Use(chimiddleware.Timeout(envVars.ExportTimeout))
+				r.Use(middleware.RequireScope(service.ScopeWorkoutsRead))
+
+				r.Get("/export/workouts", wrapper.ExportWorkouts)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(timeout)
+				r.Use(middleware.RequireScope(service.ScopeWorkoutsWrite))
+
+				r.Post("/workouts", wrapper.CreateWorkoutPlan)
+				r.Delete("/workouts/{workoutId}", wrapper.DeleteWorkoutPlanById)
+				r.Put("/workouts/{workoutId}/complete", wrapper.CompleteWorkoutPlanById)
+				r.Put("/workouts/{workoutId}/schedule", wrapper.ScheduleWorkoutPlanById)
+				r.Put("/workouts/{workoutId}/update-exercise-plans", wrapper.UpdateExercisePlansInWorkoutPlan)
+				r.Post("/workouts/{workoutId}/exercise-plans", wrapper.AddExercisePlan)
+				r.Delete("/workouts/{workoutId}/exercise-plans/{exercisePlanId}", wrapper.RemoveExercisePlan)
+				r.Put("/workouts/{workoutId}/exercise-plans/{exercisePlanId}/move", wrapper.MoveExercisePlan)
+				r.Post("/workouts/{workoutId}/exercise-plans/{exercisePlanId}/sets", wrapper.LogPerformedSet)
+				r.Put("/workouts/{workoutId}/exercise-plans/{exercisePlanId}/sets/{setId}", wrapper.UpdatePerformedSet)
+				r.Delete("/workouts/{workoutId}/exercise-plans/{exercisePlanId}/sets/{setId}", wrapper.DeletePerformedSet)
+				r.Post("/workouts/{workoutId}/save-as-template", wrapper.SaveWorkoutAsTemplate)
+				r.Post("/schedules", wrapper.CreateSchedule)
+				r.Put("/schedules/{scheduleId}/cancel", wrapper.CancelSchedule)
+				r.Put("/schedules/{scheduleId}/occurrences/{workoutId}", wrapper.UpdateScheduleOccurrence)
+				r.Post("/templates", wrapper.CreateTemplate)
+				r.Put("/templates/{templateId}", wrapper.UpdateTemplate)
+				r.Delete("/templates/{templateId}", wrapper.DeleteTemplateById)
+				r.Post("/templates/{templateId}/instantiate", wrapper.InstantiateTemplate)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(timeout)
+				r.Use(middleware.RequireScope(service.ScopeExercisesRead))
+
+				r.Get("/exercises", wrapper.ListExercises)
+				r.Get("/exercises/{exerciseId}", wrapper.GetExerciseById)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(timeout)
+				r.Use(middleware.RequireScope(service.ScopeExercisesWrite))
+
+				r.Post("/exercises", wrapper.CreateExercise)
+				r.Put("/exercises/{exerciseId}", wrapper.UpdateExercise)
+				r.Delete("/exercises/{exerciseId}", wrapper.DeleteExercise)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(timeout)
+				r.Use(middleware.RequireScope(service.ScopeReportsRead))
 
+				r.Get("/report/progress", wrapper.ReportProgress)
+				r.Get("/report/volume", wrapper.ReportVolume)
+				r.Get("/report/consistency", wrapper.ReportConsistency)
+				r.Get("/report/exercises/{exerciseId}/strength", wrapper.ReportStrength)
+				r.Get("/report/muscle-balance", wrapper.ReportMuscleBalance)
+				r.Get("/report/personal-records", wrapper.ReportPersonalRecords)
+			})
 		})
 
 	})
@@ -3,6 +3,7 @@ package apperrors
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // Common application-level errors
@@ -14,6 +15,7 @@ var (
 	ErrUnauthorized        = errors.New("unauthorized access")
 	ErrForbidden           = errors.New("access forbidden")
 	ErrForeignKeyViolation = errors.New("foreign key not found")
+	ErrTooManyRequests     = errors.New("too many requests")
 )
 
 type ValidationField string
@@ -26,6 +28,13 @@ const (
 	INVALID_DATE     ValidationField = "INVALID_DATE"
 	INVALID_SETTING  ValidationField = "INVALID_SETTING"
 	INVALID_INPUT    ValidationField = "INVALID_INPUT"
+	INVALID_TOKEN    ValidationField = "INVALID_TOKEN"
+	// INVALID_CREDENTIALS does not tell an unknown email from a wrong password
+	INVALID_CREDENTIALS ValidationField = "INVALID_CREDENTIALS"
+	// INVALID_CODE is a wrong, expired or already used two-factor code
+	INVALID_CODE ValidationField = "INVALID_CODE"
+	// INVALID_SCOPE is an unknown or missing personal access token scope
+	INVALID_SCOPE ValidationField = "INVALID_SCOPE"
 )
 
 type ValidationError struct {
@@ -41,13 +50,42 @@ func NewValidationError(field ValidationField, message string) *ValidationError
 	return &ValidationError{Field: field, Message: message}
 }
 
+// LockoutError refuses a request until RetryAfter has passed, it matches ErrTooManyRequests
+type LockoutError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockoutError) Error() string {
+	return fmt.Sprintf("too many failed attempts, retry in %s", e.RetryAfter.Round(time.Second))
+}
+
+func (e *LockoutError) Unwrap() error {
+	return ErrTooManyRequests
+}
+
+// TokenReuseError refuses a refresh token that was already exchanged, SessionId is the session that
+// was revoked because of it. It matches ErrUnauthorized
+type TokenReuseError struct {
+	SessionId string
+}
+
+func (e *TokenReuseError) Error() string {
+	return "refresh token was already used, its session is revoked"
+}
+
+func (e *TokenReuseError) Unwrap() error {
+	return ErrUnauthorized
+}
+
 type ErrorCode string
 
 const (
-	NOT_FOUND      ErrorCode = "NOT_FOUND"
-	ALREADY_EXISTS ErrorCode = "ALREADY_EXISTS"
-	UNAUTHORIZED   ErrorCode = "UNAUTHORIZED"
-	FORBIDDEN      ErrorCode = "FORBIDDEN"
-	INTERNAL_ERROR ErrorCode = "INTERNAL_ERROR"
-	BAD_REQUEST    ErrorCode = "BAD_REQUEST"
+	NOT_FOUND             ErrorCode = "NOT_FOUND"
+	ALREADY_EXISTS        ErrorCode = "ALREADY_EXISTS"
+	UNAUTHORIZED          ErrorCode = "UNAUTHORIZED"
+	FORBIDDEN             ErrorCode = "FORBIDDEN"
+	INTERNAL_ERROR        ErrorCode = "INTERNAL_ERROR"
+	BAD_REQUEST           ErrorCode = "BAD_REQUEST"
+	FOREIGN_KEY_VIOLATION ErrorCode = "FOREIGN_KEY_VIOLATION"
+	TOO_MANY_REQUESTS     ErrorCode = "TOO_MANY_REQUESTS"
 )
@@ -14,6 +14,9 @@ type CacheInterface interface {
 	GetCache(ctx context.Context, key string) (string, error)
 	ExistCache(ctx context.Context, key string) (bool, error)
 	CleanCache(ctx context.Context, key string) error
+	LockCache(ctx context.Context, key string, value string, expiration time.Duration) (bool, error)
+	UnlockCache(ctx context.Context, key string, value string) error
+	IncrCache(ctx context.Context, key string, expiration time.Duration) (int64, error)
 }
 
 type RedisCache struct {
@@ -76,6 +79,49 @@ func (r *RedisCache) CleanCache(ctx context.Context, key string) error {
 	return nil
 }
 
+// lock cache, set only when the key does not exist yet. Report whether the lock is taken by this call
+func (r *RedisCache) LockCache(ctx context.Context, key string, value string, expiration time.Duration) (bool, error) {
+	ok, err := r.rdb.SetNX(ctx, key, value, expiration).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to lock cache: %w", err)
+	}
+
+	return ok, nil
+}
+
+// unlockScript deletes the key only while it still holds the value, a lock that expired and was
+// taken by someone else is left alone
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// unlock cache, release a lock taken with LockCache and the same value
+func (r *RedisCache) UnlockCache(ctx context.Context, key string, value string) error {
+	if err := unlockScript.Run(ctx, r.rdb, []string{key}, value).Err(); err != nil {
+		return fmt.Errorf("failed to unlock cache: %w", err)
+	}
+
+	return nil
+}
+
+// incr cache, count up a key. The expiration starts with the first increment and is not extended,
+// so the count covers a fixed window
+func (r *RedisCache) IncrCache(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.ExpireNX(ctx, key, expiration)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment cache: %w", err)
+	}
+
+	return incr.Val(), nil
+}
+
 func NewRedisClient(ctx context.Context, redisAddr string) (*redis.Client, error) {
 	redisClient := redis.NewClient(&redis.Options{
 		Addr: redisAddr,
@@ -8,6 +8,18 @@ CREATE TABLE IF NOT EXISTS users (
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
 
+-- weight unit reports are converted to
+ALTER TABLE users ADD COLUMN IF NOT EXISTS preferred_unit VARCHAR(10) NOT NULL DEFAULT 'kg' CHECK(preferred_unit IN (
+    'kg',
+    'lbs'
+));
+
+-- IANA time zone the days, weeks and months of the reports follow
+ALTER TABLE users ADD COLUMN IF NOT EXISTS time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- set when the user opens the verification token mailed at signup
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP WITH TIME ZONE;
+
 -- exercises
 CREATE TABLE IF NOT EXISTS exercises (
     id SERIAL PRIMARY KEY,
@@ -24,6 +36,25 @@ CREATE TABLE IF NOT EXISTS exercises (
     ))
 );
 
+-- custom exercises belong to a user, seeded ones have no owner
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS owner_id INTEGER REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS idx_exercises_owner ON exercises(owner_id);
+
+-- exercise search: equipment filter and trigram index for fuzzy name matching
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS equipment VARCHAR(20) NOT NULL DEFAULT 'other' CHECK(equipment IN (
+    'barbell',
+    'dumbbell',
+    'machine',
+    'cable',
+    'bodyweight',
+    'kettlebell',
+    'band',
+    'other'
+));
+CREATE INDEX IF NOT EXISTS idx_exercises_name_trgm ON exercises USING GIN (lower(name) gin_trgm_ops);
+
 
 -- workout_plans
 CREATE TABLE IF NOT EXISTS workout_plans (
@@ -50,3 +81,211 @@ CREATE TABLE IF NOT EXISTS exercise_plans (
         'other'
     ))
 );
+
+ALTER TABLE exercise_plans ADD COLUMN IF NOT EXISTS position INT NOT NULL DEFAULT 0;
+
+-- number exercise plans created before ordering existed in insertion order
+UPDATE exercise_plans SET position = ordered.rn
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY workout_plan_id ORDER BY id) AS rn FROM exercise_plans
+) ordered
+WHERE exercise_plans.id = ordered.id AND exercise_plans.position = 0;
+
+-- performed_sets
+CREATE TABLE IF NOT EXISTS performed_sets (
+    id SERIAL PRIMARY KEY,
+    exercise_plan_id INTEGER REFERENCES exercise_plans(id) ON DELETE CASCADE NOT NULL,
+    set_number INT NOT NULL,
+    repetitions INT NOT NULL,
+    weights FLOAT NOT NULL,
+    weight_unit VARCHAR(20) NOT NULL CHECK(weight_unit IN (
+        'kg',
+        'lbs',
+        'other'
+    )),
+    rpe FLOAT CHECK(rpe IS NULL OR (rpe >= 1 AND rpe <= 10)),
+    completed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (exercise_plan_id, set_number)
+);
+
+-- workout_schedules
+CREATE TABLE IF NOT EXISTS workout_schedules (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) NOT NULL,
+    frequency VARCHAR(20) NOT NULL CHECK(frequency IN (
+        'daily',
+        'weekly',
+        'monthly'
+    )),
+    repeat_interval INT NOT NULL DEFAULT 1 CHECK(repeat_interval > 0),
+    weekdays VARCHAR(30),
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    until_date TIMESTAMP WITH TIME ZONE,
+    occurrence_count INT,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE workout_plans ADD COLUMN IF NOT EXISTS schedule_id INTEGER REFERENCES workout_schedules(id) ON DELETE SET NULL;
+
+-- workout_templates
+CREATE TABLE IF NOT EXISTS workout_templates (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- template_exercises
+CREATE TABLE IF NOT EXISTS template_exercises (
+    id SERIAL PRIMARY KEY,
+    template_id INTEGER REFERENCES workout_templates(id) ON DELETE CASCADE NOT NULL,
+    position INT NOT NULL,
+    exercise_id INTEGER REFERENCES exercises(id) NOT NULL,
+    sets INT NOT NULL,
+    repetitions INT NOT NULL,
+    weights FLOAT NOT NULL,
+    weight_unit VARCHAR(20) NOT NULL CHECK(weight_unit IN (
+        'kg',
+        'lbs',
+        'other'
+    )),
+    UNIQUE (template_id, position)
+);
+
+-- personal_records: one row per record broken, so the rows of a user and exercise form the PR history
+CREATE TABLE IF NOT EXISTS personal_records (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    exercise_id INTEGER REFERENCES exercises(id) ON DELETE CASCADE NOT NULL,
+    workout_plan_id INTEGER REFERENCES workout_plans(id) ON DELETE CASCADE NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK(kind IN (
+        'weight',
+        'reps',
+        'estimated_1rm',
+        'volume'
+    )),
+    value FLOAT NOT NULL,
+    weight_kg FLOAT,
+    previous_value FLOAT,
+    achieved_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_personal_records_user_exercise ON personal_records(user_id, exercise_id);
+
+-- sessions: one row per login, last_jti is the id of the latest access token issued for it
+CREATE TABLE IF NOT EXISTS sessions (
+    id UUID PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    last_jti VARCHAR(64),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS idx_sessions_user ON sessions(user_id);
+
+-- refresh_tokens: only the sha256 of a token is stored. The family is the session the token was
+-- issued for, every rotation adds a row to it so a reused token can revoke the whole family.
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    family_id UUID REFERENCES sessions(id) ON DELETE CASCADE NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family ON refresh_tokens(family_id);
+
+-- password_reset_tokens: single use, only the sha256 of a token is stored
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user ON password_reset_tokens(user_id);
+
+-- email_verification_tokens: single use, only the sha256 of a token is stored
+CREATE TABLE IF NOT EXISTS email_verification_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_email_verification_tokens_user ON email_verification_tokens(user_id);
+
+-- audit_log: security relevant events. Rows outlive the users they mention, user_id is the
+-- account the event is about and actor_id the user who caused it, when that is someone else.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id SERIAL PRIMARY KEY,
+    event VARCHAR(50) NOT NULL,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    actor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    detail TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_audit_log_user ON audit_log(user_id, created_at);
+
+-- user_totp: the TOTP secret of a user, two-factor login is on once it is confirmed.
+-- last_used_step keeps a code from being accepted twice.
+CREATE TABLE IF NOT EXISTS user_totp (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    last_used_step BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- totp_recovery_codes: single use codes for a lost authenticator, stored as bcrypt hashes
+CREATE TABLE IF NOT EXISTS totp_recovery_codes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    code_hash VARCHAR(255) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_totp_recovery_codes_user ON totp_recovery_codes(user_id);
+
+-- roles: admins manage the exercise catalog and the users
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'user' CHECK(role IN (
+    'user',
+    'admin'
+));
+
+-- set while an admin keeps the user from logging in
+ALTER TABLE users ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMP WITH TIME ZONE;
+
+-- personal_access_tokens: long lived tokens for scripts, limited to their scopes. Only the
+-- SHA-256 of the token is stored.
+CREATE TABLE IF NOT EXISTS personal_access_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_personal_access_tokens_user ON personal_access_tokens(user_id);
+
+-- set when the user asked to delete the account: logins stop right away and the data is deleted
+-- once the time has passed, unless the user cancels before
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_due_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS idx_users_deletion_due_at ON users(deletion_due_at) WHERE deletion_due_at IS NOT NULL;
+
+-- exports page through a user's workout plans by scheduled date
+CREATE INDEX IF NOT EXISTS idx_workout_plans_user_scheduled ON workout_plans(user_id, scheduled_date, id);
+CREATE INDEX IF NOT EXISTS idx_exercise_plans_workout ON exercise_plans(workout_plan_id);
@@ -0,0 +1,238 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util"
+	"workout-tracker-api/internal/util/helper"
+	"workout-tracker-api/pkg/api"
+)
+
+type AccessTokenHandler struct {
+	AccessTokenService service.AccessTokenServiceInterface
+}
+
+func NewAccessTokenHandler(ats service.AccessTokenServiceInterface) *AccessTokenHandler {
+	return &AccessTokenHandler{
+		AccessTokenService: ats,
+	}
+}
+
+// ListAccessTokens
+func (h *AccessTokenHandler) ListAccessTokens(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	tokenList, err := h.AccessTokenService.ListTokens(r.Context(), userInfo.Id)
+	if err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to fetch access tokens: %w", err))
+		return
+	}
+
+	tokens := []api.AccessToken{}
+	for _, t := range tokenList {
+		tokens = append(tokens, *toAPIAccessToken(&t))
+	}
+
+	response := api.Success{
+		Code:    api.FETCH,
+		Message: "successfully fetch access tokens",
+		Payload: &map[string]any{
+			"accessTokens": tokens,
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// CreateAccessToken answers the only response that contains the token
+func (h *AccessTokenHandler) CreateAccessToken(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	var req api.CreateAccessTokenJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding create access token request: %v", err)
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	created, err := h.AccessTokenService.CreateToken(r.Context(), service.AccessTokenCreate{
+		UserId:    userInfo.Id,
+		Name:      req.Name,
+		Scopes:    toServiceScopes(req.Scopes),
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+		helper.SendErrorResponse(w, fmt.Errorf("failed to create access token: %w", err))
+		return
+	}
+
+	accessToken := toAPIAccessToken(&created.AccessToken)
+	accessToken.Token = &created.Token
+
+	response := api.Success{
+		Code:    api.CREATED,
+		Message: "successfully create access token",
+		Payload: &map[string]any{
+			"accessToken": accessToken,
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusCreated, &response)
+}
+
+// GetAccessToken
+func (h *AccessTokenHandler) GetAccessToken(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	tokenId, err := pathID(w, r, "tokenId")
+	if err != nil {
+		return
+	}
+
+	token, err := h.AccessTokenService.GetToken(r.Context(), userInfo.Id, tokenId)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+		helper.SendErrorResponse(w, fmt.Errorf("failed to fetch access token: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.FETCH,
+		Message: "successfully fetch access token",
+		Payload: &map[string]any{
+			"accessToken": toAPIAccessToken(token),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// UpdateAccessToken
+func (h *AccessTokenHandler) UpdateAccessToken(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	tokenId, err := pathID(w, r, "tokenId")
+	if err != nil {
+		return
+	}
+
+	var req api.UpdateAccessTokenJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding update access token request: %v", err)
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	token, err := h.AccessTokenService.UpdateToken(r.Context(), service.AccessTokenUpdate{
+		Id:     tokenId,
+		UserId: userInfo.Id,
+		Name:   req.Name,
+		Scopes: toServiceScopes(req.Scopes),
+	})
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) || errors.Is(err, apperrors.ErrNotFound) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+		helper.SendErrorResponse(w, fmt.Errorf("failed to update access token: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.UPDATE,
+		Message: "successfully update access token",
+		Payload: &map[string]any{
+			"accessToken": toAPIAccessToken(token),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// DeleteAccessToken
+func (h *AccessTokenHandler) DeleteAccessToken(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	tokenId, err := pathID(w, r, "tokenId")
+	if err != nil {
+		return
+	}
+
+	if err := h.AccessTokenService.DeleteToken(r.Context(), userInfo.Id, tokenId); err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+		helper.SendErrorResponse(w, fmt.Errorf("failed to delete access token: %w", err))
+		return
+	}
+
+	helper.SendSuccessResponse(w, http.StatusNoContent, nil)
+}
+
+func toServiceScopes(scopes []api.AccessTokenScope) []service.Scope {
+	values := make([]service.Scope, 0, len(scopes))
+	for _, scope := range scopes {
+		values = append(values, service.Scope(scope))
+	}
+	return values
+}
+
+func toAPIAccessToken(t *service.AccessToken) *api.AccessToken {
+	if t == nil {
+		return nil
+	}
+
+	scopes := make([]api.AccessTokenScope, 0, len(t.Scopes))
+	for _, scope := range t.Scopes {
+		scopes = append(scopes, api.AccessTokenScope(scope))
+	}
+
+	return &api.AccessToken{
+		Id:         util.IntTo64(t.Id),
+		Name:       &t.Name,
+		Scopes:     &scopes,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		CreatedAt:  &t.CreatedAt,
+	}
+}
@@ -0,0 +1,246 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/handler"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util/helper"
+	"workout-tracker-api/pkg/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAccessTokenService struct {
+	mock.Mock
+}
+
+func (m *MockAccessTokenService) CreateToken(ctx context.Context, data service.AccessTokenCreate) (*service.CreatedAccessToken, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.CreatedAccessToken), args.Error(1)
+}
+
+func (m *MockAccessTokenService) ListTokens(ctx context.Context, userId int) ([]service.AccessToken, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.AccessToken), args.Error(1)
+}
+
+func (m *MockAccessTokenService) GetToken(ctx context.Context, userId int, id int) (*service.AccessToken, error) {
+	args := m.Called(ctx, userId, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.AccessToken), args.Error(1)
+}
+
+func (m *MockAccessTokenService) UpdateToken(ctx context.Context, data service.AccessTokenUpdate) (*service.AccessToken, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.AccessToken), args.Error(1)
+}
+
+func (m *MockAccessTokenService) DeleteToken(ctx context.Context, userId int, id int) error {
+	args := m.Called(ctx, userId, id)
+	return args.Error(0)
+}
+
+func (m *MockAccessTokenService) Authenticate(ctx context.Context, token string) (*service.AccessTokenOwner, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.AccessTokenOwner), args.Error(1)
+}
+
+func newAccessTokenRequest(method string, target string, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	return req.WithContext(helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: 7}))
+}
+
+func TestAccessTokenHandler_CreateAccessToken(t *testing.T) {
+	t.Run("returns the token once", func(t *testing.T) {
+		mockTokenService := new(MockAccessTokenService)
+		handlerObj := handler.NewAccessTokenHandler(mockTokenService)
+
+		expiresAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockTokenService.On("CreateToken", mock.Anything, service.AccessTokenCreate{
+			UserId:    7,
+			Name:      "sync script",
+			Scopes:    []service.Scope{service.ScopeWorkoutsRead, service.ScopeWorkoutsWrite},
+			ExpiresAt: &expiresAt,
+		}).Return(&service.CreatedAccessToken{
+			AccessToken: service.AccessToken{Id: 1, Name: "sync script", Scopes: []service.Scope{service.ScopeWorkoutsRead, service.ScopeWorkoutsWrite}, ExpiresAt: &expiresAt},
+			Token:       "wtp_secret",
+		}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.CreateAccessToken(rr, newAccessTokenRequest(http.MethodPost, "/user/tokens",
+			`{"name":"sync script","scopes":["workouts:read","workouts:write"],"expiresAt":"2026-01-01T00:00:00Z"}`))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var resp api.Success
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, api.CREATED, resp.Code)
+		accessToken := (*resp.Payload)["accessToken"].(map[string]any)
+		assert.Equal(t, "wtp_secret", accessToken["token"])
+		assert.Equal(t, []any{"workouts:read", "workouts:write"}, accessToken["scopes"])
+		assert.Nil(t, accessToken["lastUsedAt"])
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("unknown scope", func(t *testing.T) {
+		mockTokenService := new(MockAccessTokenService)
+		handlerObj := handler.NewAccessTokenHandler(mockTokenService)
+
+		mockTokenService.On("CreateToken", mock.Anything, mock.Anything).
+			Return(nil, apperrors.NewValidationError(apperrors.INVALID_SCOPE, "unknown scope 'admin'")).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.CreateAccessToken(rr, newAccessTokenRequest(http.MethodPost, "/user/tokens", `{"name":"script","scopes":["admin"]}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		var resp api.Error
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, string(apperrors.INVALID_SCOPE), resp.Code)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		mockTokenService := new(MockAccessTokenService)
+		handlerObj := handler.NewAccessTokenHandler(mockTokenService)
+
+		rr := httptest.NewRecorder()
+		handlerObj.CreateAccessToken(rr, newAccessTokenRequest(http.MethodPost, "/user/tokens", `{"name":`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockTokenService.AssertNotCalled(t, "CreateToken", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAccessTokenHandler_ListAccessTokens(t *testing.T) {
+	mockTokenService := new(MockAccessTokenService)
+	handlerObj := handler.NewAccessTokenHandler(mockTokenService)
+
+	usedAt := time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC)
+	mockTokenService.On("ListTokens", mock.Anything, 7).Return([]service.AccessToken{
+		{Id: 2, Name: "reports", Scopes: []service.Scope{service.ScopeReportsRead}},
+		{Id: 1, Name: "sync script", Scopes: []service.Scope{service.ScopeWorkoutsRead}, LastUsedAt: &usedAt},
+	}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	handlerObj.ListAccessTokens(rr, newAccessTokenRequest(http.MethodGet, "/user/tokens", ""))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp api.Success
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	tokens := (*resp.Payload)["accessTokens"].([]any)
+	assert.Len(t, tokens, 2)
+	assert.NotContains(t, tokens[0].(map[string]any), "token")
+	assert.Equal(t, "2025-06-02T08:00:00Z", tokens[1].(map[string]any)["lastUsedAt"])
+}
+
+func TestAccessTokenHandler_UpdateAccessToken(t *testing.T) {
+	newRequest := func(tokenId string, body string) *http.Request {
+		req := newAccessTokenRequest(http.MethodPut, "/user/tokens/"+tokenId, body)
+		req.SetPathValue("tokenId", tokenId)
+		return req
+	}
+
+	t.Run("changes name and scopes", func(t *testing.T) {
+		mockTokenService := new(MockAccessTokenService)
+		handlerObj := handler.NewAccessTokenHandler(mockTokenService)
+
+		mockTokenService.On("UpdateToken", mock.Anything, service.AccessTokenUpdate{Id: 1, UserId: 7, Name: "read only", Scopes: []service.Scope{service.ScopeWorkoutsRead}}).
+			Return(&service.AccessToken{Id: 1, Name: "read only", Scopes: []service.Scope{service.ScopeWorkoutsRead}}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.UpdateAccessToken(rr, newRequest("1", `{"name":"read only","scopes":["workouts:read"]}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("token of another user", func(t *testing.T) {
+		mockTokenService := new(MockAccessTokenService)
+		handlerObj := handler.NewAccessTokenHandler(mockTokenService)
+
+		mockTokenService.On("UpdateToken", mock.Anything, mock.Anything).Return(nil, apperrors.ErrNotFound).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.UpdateAccessToken(rr, newRequest("9", `{"name":"mine","scopes":["workouts:read"]}`))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestAccessTokenHandler_GetAndDeleteAccessToken(t *testing.T) {
+	newRequest := func(method string, tokenId string) *http.Request {
+		req := newAccessTokenRequest(method, "/user/tokens/"+tokenId, "")
+		req.SetPathValue("tokenId", tokenId)
+		return req
+	}
+
+	t.Run("get", func(t *testing.T) {
+		mockTokenService := new(MockAccessTokenService)
+		handlerObj := handler.NewAccessTokenHandler(mockTokenService)
+
+		mockTokenService.On("GetToken", mock.Anything, 7, 1).Return(&service.AccessToken{Id: 1, Name: "sync script"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.GetAccessToken(rr, newRequest(http.MethodGet, "1"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		mockTokenService := new(MockAccessTokenService)
+		handlerObj := handler.NewAccessTokenHandler(mockTokenService)
+
+		mockTokenService.On("DeleteToken", mock.Anything, 7, 1).Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.DeleteAccessToken(rr, newRequest(http.MethodDelete, "1"))
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("delete unknown token", func(t *testing.T) {
+		mockTokenService := new(MockAccessTokenService)
+		handlerObj := handler.NewAccessTokenHandler(mockTokenService)
+
+		mockTokenService.On("DeleteToken", mock.Anything, 7, 9).Return(apperrors.ErrNotFound).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.DeleteAccessToken(rr, newRequest(http.MethodDelete, "9"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("delete service error", func(t *testing.T) {
+		mockTokenService := new(MockAccessTokenService)
+		handlerObj := handler.NewAccessTokenHandler(mockTokenService)
+
+		mockTokenService.On("DeleteToken", mock.Anything, 7, 1).Return(errors.New("delete failed")).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.DeleteAccessToken(rr, newRequest(http.MethodDelete, "1"))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util/auth"
+	"workout-tracker-api/internal/util/helper"
+	"workout-tracker-api/pkg/api"
+)
+
+type AccountDeletionHandler struct {
+	AccountDeletionService service.AccountDeletionServiceInterface
+	TokenService           auth.TokenInterface
+}
+
+func NewAccountDeletionHandler(ads service.AccountDeletionServiceInterface, ts auth.TokenInterface) *AccountDeletionHandler {
+	return &AccountDeletionHandler{
+		AccountDeletionService: ads,
+		TokenService:           ts,
+	}
+}
+
+// DeleteUser schedules the deletion of the account and logs the user out everywhere
+func (h *AccountDeletionHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	var req api.DeleteUserJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Password == "" {
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	input := service.AccountDeletionRequest{
+		Password:  req.Password,
+		IPAddress: clientIP(r),
+	}
+	if req.Code != nil {
+		input.Code = *req.Code
+	}
+
+	scheduled, err := h.AccountDeletionService.RequestDeletion(r.Context(), userInfo.Id, input)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) || errors.Is(err, apperrors.ErrTooManyRequests) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+		if errors.Is(err, apperrors.ErrNotFound) {
+			helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+			return
+		}
+		helper.SendErrorResponse(w, fmt.Errorf("failed to delete account: %w", err))
+		return
+	}
+
+	if err := revokeSessionTokens(r.Context(), h.TokenService, scheduled.RevokedSessions); err != nil {
+		helper.SendErrorResponse(w, err)
+		return
+	}
+
+	response := api.Success{
+		Code:    api.UPDATE,
+		Message: "account deletion scheduled",
+		Payload: &map[string]any{
+			"deletion": api.AccountDeletion{DeleteAt: &scheduled.DeleteAt},
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// CancelUserDeletion takes the credentials, the user has no access token while the deletion is pending
+func (h *AccountDeletionHandler) CancelUserDeletion(w http.ResponseWriter, r *http.Request) {
+	var req api.CancelUserDeletionJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	err := h.AccountDeletionService.CancelDeletion(r.Context(), service.UserLogin{
+		Email:     string(req.Email),
+		Password:  req.Password,
+		IPAddress: clientIP(r),
+	})
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) || errors.Is(err, apperrors.ErrNotFound) || errors.Is(err, apperrors.ErrTooManyRequests) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+		helper.SendErrorResponse(w, fmt.Errorf("failed to cancel account deletion: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.UPDATE,
+		Message: "account deletion cancelled",
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
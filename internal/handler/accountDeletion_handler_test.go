@@ -0,0 +1,164 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/handler"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/pkg/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAccountDeletionService struct {
+	mock.Mock
+}
+
+func (m *MockAccountDeletionService) RequestDeletion(ctx context.Context, userId int, input service.AccountDeletionRequest) (*service.ScheduledDeletion, error) {
+	args := m.Called(ctx, userId, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ScheduledDeletion), args.Error(1)
+}
+
+func (m *MockAccountDeletionService) CancelDeletion(ctx context.Context, input service.UserLogin) error {
+	args := m.Called(ctx, input)
+	return args.Error(0)
+}
+
+func (m *MockAccountDeletionService) PurgeDueAccounts(ctx context.Context, now time.Time) (int, error) {
+	args := m.Called(ctx, now)
+	return args.Int(0), args.Error(1)
+}
+
+func TestAccountDeletionHandler_DeleteUser(t *testing.T) {
+	t.Run("schedules the deletion and revokes the access tokens", func(t *testing.T) {
+		mockDeletionService := new(MockAccountDeletionService)
+		mockTokenService := new(MockTokenService)
+		handlerObj := handler.NewAccountDeletionHandler(mockDeletionService, mockTokenService)
+
+		deleteAt := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+		mockDeletionService.On("RequestDeletion", mock.Anything, 7, service.AccountDeletionRequest{
+			Password:  "password123",
+			Code:      "123456",
+			IPAddress: "192.0.2.1",
+		}).Return(&service.ScheduledDeletion{DeleteAt: deleteAt, RevokedSessions: []string{"session-1"}}, nil).Once()
+		mockTokenService.On("RevokeSession", mock.Anything, "session-1").Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.DeleteUser(rr, newAccessTokenRequest(http.MethodDelete, "/user", `{"password":"password123","code":"123456"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp api.Success
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, api.UPDATE, resp.Code)
+		deletion := (*resp.Payload)["deletion"].(map[string]any)
+		assert.Equal(t, "2025-08-01T12:00:00Z", deletion["deleteAt"])
+		mockDeletionService.AssertExpectations(t)
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("missing password", func(t *testing.T) {
+		mockDeletionService := new(MockAccountDeletionService)
+		handlerObj := handler.NewAccountDeletionHandler(mockDeletionService, new(MockTokenService))
+
+		rr := httptest.NewRecorder()
+		handlerObj.DeleteUser(rr, newAccessTokenRequest(http.MethodDelete, "/user", `{}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockDeletionService.AssertNotCalled(t, "RequestDeletion", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		mockDeletionService := new(MockAccountDeletionService)
+		mockTokenService := new(MockTokenService)
+		handlerObj := handler.NewAccountDeletionHandler(mockDeletionService, mockTokenService)
+
+		mockDeletionService.On("RequestDeletion", mock.Anything, 7, mock.Anything).
+			Return(nil, apperrors.NewValidationError(apperrors.INVALID_PASSWORD, "password is incorrect")).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.DeleteUser(rr, newAccessTokenRequest(http.MethodDelete, "/user", `{"password":"wrong"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		var resp api.Error
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, string(apperrors.INVALID_PASSWORD), resp.Code)
+		mockTokenService.AssertNotCalled(t, "RevokeSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unauthorized if no user in context", func(t *testing.T) {
+		mockDeletionService := new(MockAccountDeletionService)
+		handlerObj := handler.NewAccountDeletionHandler(mockDeletionService, new(MockTokenService))
+
+		rr := httptest.NewRecorder()
+		handlerObj.DeleteUser(rr, httptest.NewRequest(http.MethodDelete, "/user", strings.NewReader(`{"password":"password123"}`)))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestAccountDeletionHandler_CancelUserDeletion(t *testing.T) {
+	newRequest := func() *http.Request {
+		return httptest.NewRequest(http.MethodPost, "/user/deletion/cancel", strings.NewReader(`{"email":"test@example.com","password":"password123"}`))
+	}
+	login := service.UserLogin{Email: "test@example.com", Password: "password123", IPAddress: "192.0.2.1"}
+
+	t.Run("success", func(t *testing.T) {
+		mockDeletionService := new(MockAccountDeletionService)
+		handlerObj := handler.NewAccountDeletionHandler(mockDeletionService, new(MockTokenService))
+
+		mockDeletionService.On("CancelDeletion", mock.Anything, login).Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.CancelUserDeletion(rr, newRequest())
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockDeletionService.AssertExpectations(t)
+	})
+
+	t.Run("nothing to cancel", func(t *testing.T) {
+		mockDeletionService := new(MockAccountDeletionService)
+		handlerObj := handler.NewAccountDeletionHandler(mockDeletionService, new(MockTokenService))
+
+		mockDeletionService.On("CancelDeletion", mock.Anything, login).Return(apperrors.ErrNotFound).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.CancelUserDeletion(rr, newRequest())
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("locked out", func(t *testing.T) {
+		mockDeletionService := new(MockAccountDeletionService)
+		handlerObj := handler.NewAccountDeletionHandler(mockDeletionService, new(MockTokenService))
+
+		mockDeletionService.On("CancelDeletion", mock.Anything, login).Return(&apperrors.LockoutError{RetryAfter: time.Minute}).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.CancelUserDeletion(rr, newRequest())
+
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mockDeletionService := new(MockAccountDeletionService)
+		handlerObj := handler.NewAccountDeletionHandler(mockDeletionService, new(MockTokenService))
+
+		mockDeletionService.On("CancelDeletion", mock.Anything, login).Return(errors.New("db down")).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.CancelUserDeletion(rr, newRequest())
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
@@ -0,0 +1,318 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util"
+	"workout-tracker-api/internal/util/auth"
+	"workout-tracker-api/internal/util/helper"
+	"workout-tracker-api/pkg/api"
+
+	"github.com/oapi-codegen/runtime/types"
+)
+
+type AdminHandler struct {
+	LoginGuard      service.LoginGuardInterface
+	AdminService    service.AdminServiceInterface
+	ExerciseService service.ExerciseServiceInterface
+	TokenService    auth.TokenInterface
+}
+
+func NewAdminHandler(lg service.LoginGuardInterface, as service.AdminServiceInterface, es service.ExerciseServiceInterface, ts auth.TokenInterface) *AdminHandler {
+	return &AdminHandler{
+		LoginGuard:      lg,
+		AdminService:    as,
+		ExerciseService: es,
+		TokenService:    ts,
+	}
+}
+
+// UnlockUserAccount lifts a login lockout before it runs out
+func (h *AdminHandler) UnlockUserAccount(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	userId, err := strconv.Atoi(r.PathValue("userId"))
+	if err != nil {
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_ID, "user id is not valid"))
+		return
+	}
+
+	if err := h.LoginGuard.UnlockAccount(r.Context(), userInfo.Id, userId); err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+		helper.SendErrorResponse(w, fmt.Errorf("failed to unlock account: %w", err))
+		return
+	}
+
+	log.Printf("Admin %d unlocked the login of user %d", userInfo.Id, userId)
+	helper.SendSuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// ListUsers
+func (h *AdminHandler) ListUsers(w http.ResponseWriter, r *http.Request, params api.ListUsersParams) {
+	query := service.UserListQuery{}
+	if params.Email != nil {
+		query.Email = *params.Email
+	}
+	if params.Limit != nil {
+		query.Limit = *params.Limit
+	}
+	if params.Offset != nil {
+		query.Offset = *params.Offset
+	}
+
+	userList, err := h.AdminService.ListUsers(r.Context(), query)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+		helper.SendErrorResponse(w, fmt.Errorf("failed to fetch users: %w", err))
+		return
+	}
+
+	users := []api.AdminUser{}
+	for _, u := range userList {
+		users = append(users, *toAPIAdminUser(&u))
+	}
+
+	response := api.Success{
+		Code:    api.FETCH,
+		Message: "successfully fetch users",
+		Payload: &map[string]any{
+			"users": users,
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// DisableUser blocks the logins of a user and ends the sessions they have open
+func (h *AdminHandler) DisableUser(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	userId, err := pathID(w, r, "userId")
+	if err != nil {
+		return
+	}
+
+	revoked, err := h.AdminService.DisableUser(r.Context(), userInfo.Id, userId)
+	if err != nil {
+		sendAdminError(w, err, "failed to disable user")
+		return
+	}
+
+	if err := revokeSessionTokens(r.Context(), h.TokenService, revoked); err != nil {
+		helper.SendErrorResponse(w, err)
+		return
+	}
+
+	log.Printf("Admin %d disabled user %d", userInfo.Id, userId)
+	helper.SendSuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// EnableUser
+func (h *AdminHandler) EnableUser(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	userId, err := pathID(w, r, "userId")
+	if err != nil {
+		return
+	}
+
+	if err := h.AdminService.EnableUser(r.Context(), userInfo.Id, userId); err != nil {
+		sendAdminError(w, err, "failed to enable user")
+		return
+	}
+
+	log.Printf("Admin %d enabled user %d", userInfo.Id, userId)
+	helper.SendSuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// UpdateUserRole
+func (h *AdminHandler) UpdateUserRole(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	userId, err := pathID(w, r, "userId")
+	if err != nil {
+		return
+	}
+
+	var req api.UpdateUserRoleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding update user role request: %v", err)
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	if err := h.AdminService.SetUserRole(r.Context(), userInfo.Id, userId, service.Role(req.Role)); err != nil {
+		sendAdminError(w, err, "failed to update user role")
+		return
+	}
+
+	log.Printf("Admin %d set the role of user %d to %s", userInfo.Id, userId, req.Role)
+	helper.SendSuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// GetSystemStats
+func (h *AdminHandler) GetSystemStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.AdminService.GetSystemStats(r.Context())
+	if err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to fetch system stats: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.FETCH,
+		Message: "successfully fetch system stats",
+		Payload: &map[string]any{
+			"stats": stats,
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// CreateCatalogExercise adds a global exercise, visible to every user
+func (h *AdminHandler) CreateCatalogExercise(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateCatalogExerciseJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding create catalog exercise request: %v", err)
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	input := service.ExerciseCreate{
+		Name:        req.Name,
+		MuscleGroup: service.MuscleGroup(req.MuscleGroup),
+	}
+	if req.Description != nil {
+		input.Description = *req.Description
+	}
+	if req.Equipment != nil {
+		input.Equipment = service.Equipment(*req.Equipment)
+	}
+
+	exer, err := h.ExerciseService.CreateCatalogExercise(r.Context(), input)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to create exercise: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.CREATED,
+		Message: "successfully create exercise",
+		Payload: &map[string]any{
+			"exercise": toAPIExercise(exer),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusCreated, &response)
+}
+
+// UpdateCatalogExercise
+func (h *AdminHandler) UpdateCatalogExercise(w http.ResponseWriter, r *http.Request) {
+	existing, err := catalogExercise(w, r, h.ExerciseService)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	updateExercise(w, r, h.ExerciseService, existing)
+}
+
+// DeleteCatalogExercise
+func (h *AdminHandler) DeleteCatalogExercise(w http.ResponseWriter, r *http.Request) {
+	existing, err := catalogExercise(w, r, h.ExerciseService)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	deleteExercise(w, r, h.ExerciseService, existing)
+}
+
+// catalogExercise fetches a global exercise, custom exercises stay with their owner and are not found here
+func catalogExercise(w http.ResponseWriter, r *http.Request, exerciseService service.ExerciseServiceInterface) (*service.Exercise, error) {
+	exerId, err := pathID(w, r, "exerciseId")
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := exerciseService.GetExerciseById(r.Context(), exerId)
+	if err != nil {
+		helper.SendErrorResponse(w, apperrors.ErrNotFound)
+		return nil, fmt.Errorf("error fetching catalog exercise %d: %w", exerId, err)
+	}
+
+	if existing.IsCustom() {
+		helper.SendErrorResponse(w, apperrors.ErrNotFound)
+		return nil, fmt.Errorf("exercise %d is not part of the catalog", exerId)
+	}
+
+	return existing, nil
+}
+
+// sendAdminError passes on the errors the admin service returns on purpose and wraps the others
+func sendAdminError(w http.ResponseWriter, err error, message string) {
+	var validationErr *apperrors.ValidationError
+	if errors.As(err, &validationErr) || errors.Is(err, apperrors.ErrNotFound) {
+		helper.SendErrorResponse(w, err)
+		return
+	}
+	helper.SendErrorResponse(w, fmt.Errorf("%s: %w", message, err))
+}
+
+func toAPIAdminUser(u *service.User) *api.AdminUser {
+	if u == nil {
+		return nil
+	}
+
+	role := api.Role(u.Role)
+
+	return &api.AdminUser{
+		Id:            util.IntTo64(u.Id),
+		Name:          &u.Name,
+		Email:         (*types.Email)(&u.Email),
+		Role:          &role,
+		EmailVerified: &u.EmailVerified,
+		Disabled:      &u.Disabled,
+		DeletionDueAt: u.DeletionDueAt,
+		CreatedAt:     &u.CreatedAt,
+	}
+}
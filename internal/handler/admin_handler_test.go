@@ -0,0 +1,353 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/handler"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util/helper"
+	"workout-tracker-api/pkg/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLoginGuard struct {
+	mock.Mock
+}
+
+func (m *MockLoginGuard) CheckLogin(ctx context.Context, attempt service.LoginAttempt) error {
+	args := m.Called(ctx, attempt)
+	return args.Error(0)
+}
+
+func (m *MockLoginGuard) RecordFailure(ctx context.Context, attempt service.LoginAttempt) error {
+	args := m.Called(ctx, attempt)
+	return args.Error(0)
+}
+
+func (m *MockLoginGuard) RecordSuccess(ctx context.Context, attempt service.LoginAttempt) error {
+	args := m.Called(ctx, attempt)
+	return args.Error(0)
+}
+
+func (m *MockLoginGuard) UnlockAccount(ctx context.Context, actorId int, userId int) error {
+	args := m.Called(ctx, actorId, userId)
+	return args.Error(0)
+}
+
+type MockAdminService struct {
+	mock.Mock
+}
+
+func (m *MockAdminService) ListUsers(ctx context.Context, query service.UserListQuery) ([]service.User, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.User), args.Error(1)
+}
+
+func (m *MockAdminService) DisableUser(ctx context.Context, actorId int, userId int) ([]string, error) {
+	args := m.Called(ctx, actorId, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockAdminService) EnableUser(ctx context.Context, actorId int, userId int) error {
+	args := m.Called(ctx, actorId, userId)
+	return args.Error(0)
+}
+
+func (m *MockAdminService) SetUserRole(ctx context.Context, actorId int, userId int, role service.Role) error {
+	args := m.Called(ctx, actorId, userId, role)
+	return args.Error(0)
+}
+
+func (m *MockAdminService) GetSystemStats(ctx context.Context) (*service.SystemStats, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.SystemStats), args.Error(1)
+}
+
+func (m *MockAdminService) GrantAdmins(ctx context.Context, emails []string) error {
+	args := m.Called(ctx, emails)
+	return args.Error(0)
+}
+
+func TestAdminHandler_UnlockUserAccount(t *testing.T) {
+	const adminID = 1
+
+	newRequest := func(userId string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/admin/users/"+userId+"/unlock", nil)
+		req.SetPathValue("userId", userId)
+		return req.WithContext(helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: adminID}))
+	}
+
+	t.Run("unlocks the account", func(t *testing.T) {
+		mockGuard := new(MockLoginGuard)
+		handlerObj := handler.NewAdminHandler(mockGuard, nil, nil, nil)
+		mockGuard.On("UnlockAccount", mock.Anything, adminID, 7).Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.UnlockUserAccount(rr, newRequest("7"))
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		mockGuard.AssertExpectations(t)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		mockGuard := new(MockLoginGuard)
+		handlerObj := handler.NewAdminHandler(mockGuard, nil, nil, nil)
+		mockGuard.On("UnlockAccount", mock.Anything, adminID, 9).Return(apperrors.ErrNotFound).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.UnlockUserAccount(rr, newRequest("9"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		mockGuard := new(MockLoginGuard)
+		handlerObj := handler.NewAdminHandler(mockGuard, nil, nil, nil)
+
+		rr := httptest.NewRecorder()
+		handlerObj.UnlockUserAccount(rr, newRequest("abc"))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockGuard.AssertNotCalled(t, "UnlockAccount", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("cache error", func(t *testing.T) {
+		mockGuard := new(MockLoginGuard)
+		handlerObj := handler.NewAdminHandler(mockGuard, nil, nil, nil)
+		mockGuard.On("UnlockAccount", mock.Anything, adminID, 7).Return(errors.New("redis down")).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.UnlockUserAccount(rr, newRequest("7"))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func newAdminRequest(method string, target string, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	return req.WithContext(helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: 1, Role: string(service.RoleAdmin)}))
+}
+
+func TestAdminHandler_ListUsers(t *testing.T) {
+	t.Run("lists a page of users", func(t *testing.T) {
+		mockAdminService := new(MockAdminService)
+		handlerObj := handler.NewAdminHandler(nil, mockAdminService, nil, nil)
+
+		email := "example"
+		limit := 2
+		mockAdminService.On("ListUsers", mock.Anything, service.UserListQuery{Email: email, Limit: limit}).Return([]service.User{
+			{Id: 1, Email: "admin@example.com", Role: service.RoleAdmin, EmailVerified: true},
+			{Id: 2, Email: "john@example.com", Role: service.RoleUser, Disabled: true},
+		}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.ListUsers(rr, newAdminRequest(http.MethodGet, "/admin/users", ""), api.ListUsersParams{Email: &email, Limit: &limit})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp api.Success
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		users := (*resp.Payload)["users"].([]any)
+		assert.Len(t, users, 2)
+		assert.Equal(t, "admin", users[0].(map[string]any)["role"])
+		assert.Equal(t, true, users[1].(map[string]any)["disabled"])
+		mockAdminService.AssertExpectations(t)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		mockAdminService := new(MockAdminService)
+		handlerObj := handler.NewAdminHandler(nil, mockAdminService, nil, nil)
+
+		limit := 500
+		mockAdminService.On("ListUsers", mock.Anything, mock.Anything).
+			Return(nil, apperrors.NewValidationError(apperrors.INVALID_SETTING, "limit must be between 1 and 100")).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.ListUsers(rr, newAdminRequest(http.MethodGet, "/admin/users", ""), api.ListUsersParams{Limit: &limit})
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestAdminHandler_DisableUser(t *testing.T) {
+	newRequest := func(userId string) *http.Request {
+		req := newAdminRequest(http.MethodPut, "/admin/users/"+userId+"/disable", "")
+		req.SetPathValue("userId", userId)
+		return req
+	}
+
+	t.Run("disables the user and revokes the access tokens", func(t *testing.T) {
+		mockAdminService := new(MockAdminService)
+		mockTokenService := new(MockTokenService)
+		handlerObj := handler.NewAdminHandler(nil, mockAdminService, nil, mockTokenService)
+
+		mockAdminService.On("DisableUser", mock.Anything, 1, 7).Return([]string{"session-1"}, nil).Once()
+		mockTokenService.On("RevokeSession", mock.Anything, "session-1").Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.DisableUser(rr, newRequest("7"))
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		mockAdminService.AssertExpectations(t)
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("own account", func(t *testing.T) {
+		mockAdminService := new(MockAdminService)
+		mockTokenService := new(MockTokenService)
+		handlerObj := handler.NewAdminHandler(nil, mockAdminService, nil, mockTokenService)
+
+		mockAdminService.On("DisableUser", mock.Anything, 1, 1).
+			Return(nil, apperrors.NewValidationError(apperrors.INVALID_ID, "admins can not disable their own account")).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.DisableUser(rr, newRequest("1"))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockTokenService.AssertNotCalled(t, "RevokeSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		mockAdminService := new(MockAdminService)
+		handlerObj := handler.NewAdminHandler(nil, mockAdminService, nil, new(MockTokenService))
+
+		mockAdminService.On("DisableUser", mock.Anything, 1, 9).Return(nil, apperrors.ErrNotFound).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.DisableUser(rr, newRequest("9"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestAdminHandler_UpdateUserRole(t *testing.T) {
+	t.Run("changes the role", func(t *testing.T) {
+		mockAdminService := new(MockAdminService)
+		handlerObj := handler.NewAdminHandler(nil, mockAdminService, nil, nil)
+
+		mockAdminService.On("SetUserRole", mock.Anything, 1, 7, service.RoleAdmin).Return(nil).Once()
+
+		req := newAdminRequest(http.MethodPut, "/admin/users/7/role", `{"role":"admin"}`)
+		req.SetPathValue("userId", "7")
+		rr := httptest.NewRecorder()
+		handlerObj.UpdateUserRole(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		mockAdminService.AssertExpectations(t)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		mockAdminService := new(MockAdminService)
+		handlerObj := handler.NewAdminHandler(nil, mockAdminService, nil, nil)
+
+		req := newAdminRequest(http.MethodPut, "/admin/users/7/role", `{"role":`)
+		req.SetPathValue("userId", "7")
+		rr := httptest.NewRecorder()
+		handlerObj.UpdateUserRole(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockAdminService.AssertNotCalled(t, "SetUserRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAdminHandler_GetSystemStats(t *testing.T) {
+	mockAdminService := new(MockAdminService)
+	handlerObj := handler.NewAdminHandler(nil, mockAdminService, nil, nil)
+
+	mockAdminService.On("GetSystemStats", mock.Anything).Return(&service.SystemStats{Users: 10, Admins: 1}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	handlerObj.GetSystemStats(rr, newAdminRequest(http.MethodGet, "/admin/stats", ""))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp api.Success
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	stats := (*resp.Payload)["stats"].(map[string]any)
+	assert.Equal(t, float64(10), stats["users"])
+	assert.Equal(t, float64(1), stats["admins"])
+}
+
+func TestAdminHandler_CatalogExercises(t *testing.T) {
+	t.Run("creates a global exercise", func(t *testing.T) {
+		mockExerciseService := new(MockExerciseService)
+		handlerObj := handler.NewAdminHandler(nil, nil, mockExerciseService, nil)
+
+		mockExerciseService.On("CreateCatalogExercise", mock.Anything, service.ExerciseCreate{Name: "Hip Thrust", MuscleGroup: "legs"}).
+			Return(&service.Exercise{Id: 50, Name: "Hip Thrust", MuscleGroup: "legs"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.CreateCatalogExercise(rr, newAdminRequest(http.MethodPost, "/admin/exercises", `{"name":"Hip Thrust","muscleGroup":"legs"}`))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var resp api.Success
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		exercise := (*resp.Payload)["exercise"].(map[string]any)
+		assert.NotContains(t, exercise, "ownerId")
+		mockExerciseService.AssertExpectations(t)
+	})
+
+	t.Run("updates a global exercise", func(t *testing.T) {
+		mockExerciseService := new(MockExerciseService)
+		handlerObj := handler.NewAdminHandler(nil, nil, mockExerciseService, nil)
+
+		mockExerciseService.On("GetExerciseById", mock.Anything, 3).Return(&service.Exercise{Id: 3, Name: "Squat", MuscleGroup: "legs", Equipment: "barbell"}, nil).Once()
+		mockExerciseService.On("UpdateExercise", mock.Anything, service.ExerciseUpdate{Id: 3, Name: "Back Squat", MuscleGroup: "legs", Equipment: "barbell"}).
+			Return(&service.Exercise{Id: 3, Name: "Back Squat", MuscleGroup: "legs", Equipment: "barbell"}, nil).Once()
+
+		req := newAdminRequest(http.MethodPut, "/admin/exercises/3", `{"name":"Back Squat","muscleGroup":"legs"}`)
+		req.SetPathValue("exerciseId", "3")
+		rr := httptest.NewRecorder()
+		handlerObj.UpdateCatalogExercise(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockExerciseService.AssertExpectations(t)
+	})
+
+	t.Run("custom exercises are not part of the catalog", func(t *testing.T) {
+		mockExerciseService := new(MockExerciseService)
+		handlerObj := handler.NewAdminHandler(nil, nil, mockExerciseService, nil)
+
+		ownerId := 7
+		mockExerciseService.On("GetExerciseById", mock.Anything, 60).Return(&service.Exercise{Id: 60, Name: "My Curl", OwnerId: &ownerId}, nil).Once()
+
+		req := newAdminRequest(http.MethodDelete, "/admin/exercises/60", "")
+		req.SetPathValue("exerciseId", "60")
+		rr := httptest.NewRecorder()
+		handlerObj.DeleteCatalogExercise(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockExerciseService.AssertNotCalled(t, "DeleteExercise", mock.Anything, mock.Anything)
+	})
+
+	t.Run("deletes a global exercise", func(t *testing.T) {
+		mockExerciseService := new(MockExerciseService)
+		handlerObj := handler.NewAdminHandler(nil, nil, mockExerciseService, nil)
+
+		mockExerciseService.On("GetExerciseById", mock.Anything, 3).Return(&service.Exercise{Id: 3, Name: "Squat"}, nil).Once()
+		mockExerciseService.On("DeleteExercise", mock.Anything, 3).Return(false, nil).Once()
+
+		req := newAdminRequest(http.MethodDelete, "/admin/exercises/3", "")
+		req.SetPathValue("exerciseId", "3")
+		rr := httptest.NewRecorder()
+		handlerObj.DeleteCatalogExercise(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		mockExerciseService.AssertExpectations(t)
+	})
+}
@@ -7,10 +7,36 @@ import (
 )
 
 type APIhandler struct {
-	UserHandler     *UserHandler
-	WorkoutHandler  *WorkoutHandler
-	ExerciseHandler *ExerciseHandler
-	ReportHandler   *ReportHandler
+	UserHandler         *UserHandler
+	WorkoutHandler      *WorkoutHandler
+	ExerciseHandler     *ExerciseHandler
+	ReportHandler       *ReportHandler
+	PerformedSetHandler *PerformedSetHandler
+	ScheduleHandler     *ScheduleHandler
+	TemplateHandler     *TemplateHandler
+	JobHandler          *JobHandler
+	SessionHandler      *SessionHandler
+	AdminHandler        *AdminHandler
+	AccessTokenHandler  *AccessTokenHandler
+	DeletionHandler     *AccountDeletionHandler
+	ExportHandler       *ExportHandler
+}
+
+// AddExercisePlan implements api.ServerInterface.
+func (a *APIhandler) AddExercisePlan(w http.ResponseWriter, r *http.Request, workoutId int64) {
+	r.SetPathValue("workoutId", strconv.Itoa(int(workoutId)))
+	a.WorkoutHandler.AddExercisePlan(w, r)
+}
+
+// CancelSchedule implements api.ServerInterface.
+func (a *APIhandler) CancelSchedule(w http.ResponseWriter, r *http.Request, scheduleId int64) {
+	r.SetPathValue("scheduleId", strconv.Itoa(int(scheduleId)))
+	a.ScheduleHandler.CancelSchedule(w, r)
+}
+
+// ChangeUserPassword implements api.ServerInterface.
+func (a *APIhandler) ChangeUserPassword(w http.ResponseWriter, r *http.Request) {
+	a.UserHandler.ChangeUserPassword(w, r)
 }
 
 // CompleteWorkoutPlanById implements api.ServerInterface.
@@ -19,23 +45,80 @@ func (a *APIhandler) CompleteWorkoutPlanById(w http.ResponseWriter, r *http.Requ
 	a.WorkoutHandler.CompleteWorkoutPlanById(w, r)
 }
 
+// CreateExercise implements api.ServerInterface.
+func (a *APIhandler) CreateExercise(w http.ResponseWriter, r *http.Request) {
+	a.ExerciseHandler.CreateExercise(w, r)
+}
+
+// CreateSchedule implements api.ServerInterface.
+func (a *APIhandler) CreateSchedule(w http.ResponseWriter, r *http.Request) {
+	a.ScheduleHandler.CreateSchedule(w, r)
+}
+
+// CreateTemplate implements api.ServerInterface.
+func (a *APIhandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	a.TemplateHandler.CreateTemplate(w, r)
+}
+
 // CreateWorkoutPlan implements api.ServerInterface.
 func (a *APIhandler) CreateWorkoutPlan(w http.ResponseWriter, r *http.Request) {
 	a.WorkoutHandler.CreateWorkoutPlan(w, r)
 }
 
+// DeleteExercise implements api.ServerInterface.
+func (a *APIhandler) DeleteExercise(w http.ResponseWriter, r *http.Request, exerciseId int64) {
+	r.SetPathValue("exerciseId", strconv.Itoa(int(exerciseId)))
+	a.ExerciseHandler.DeleteExercise(w, r)
+}
+
+// DeleteTemplateById implements api.ServerInterface.
+func (a *APIhandler) DeleteTemplateById(w http.ResponseWriter, r *http.Request, templateId int64) {
+	r.SetPathValue("templateId", strconv.Itoa(int(templateId)))
+	a.TemplateHandler.DeleteTemplateById(w, r)
+}
+
 // DeleteWorkoutPlanById implements api.ServerInterface.
 func (a *APIhandler) DeleteWorkoutPlanById(w http.ResponseWriter, r *http.Request, workoutId int64) {
 	r.SetPathValue("workoutId", strconv.Itoa(int(workoutId)))
 	a.WorkoutHandler.DeleteWoroutPlanById(w, r)
 }
 
+// DeletePerformedSet implements api.ServerInterface.
+func (a *APIhandler) DeletePerformedSet(w http.ResponseWriter, r *http.Request, workoutId int64, exercisePlanId int64, setId int64) {
+	r.SetPathValue("workoutId", strconv.Itoa(int(workoutId)))
+	r.SetPathValue("exercisePlanId", strconv.Itoa(int(exercisePlanId)))
+	r.SetPathValue("setId", strconv.Itoa(int(setId)))
+	a.PerformedSetHandler.DeletePerformedSet(w, r)
+}
+
+// ExportWorkouts implements api.ServerInterface.
+func (a *APIhandler) ExportWorkouts(w http.ResponseWriter, r *http.Request, params api.ExportWorkoutsParams) {
+	a.ExportHandler.ExportWorkouts(w, r, params)
+}
+
 // GetExerciseById implements api.ServerInterface.
 func (a *APIhandler) GetExerciseById(w http.ResponseWriter, r *http.Request, exerciseId int64) {
 	r.SetPathValue("exerciseId", strconv.Itoa(int(exerciseId)))
 	a.ExerciseHandler.GetExerciseByID(w, r)
 }
 
+// GetScheduleById implements api.ServerInterface.
+func (a *APIhandler) GetScheduleById(w http.ResponseWriter, r *http.Request, scheduleId int64) {
+	r.SetPathValue("scheduleId", strconv.Itoa(int(scheduleId)))
+	a.ScheduleHandler.GetScheduleById(w, r)
+}
+
+// GetTemplateById implements api.ServerInterface.
+func (a *APIhandler) GetTemplateById(w http.ResponseWriter, r *http.Request, templateId int64) {
+	r.SetPathValue("templateId", strconv.Itoa(int(templateId)))
+	a.TemplateHandler.GetTemplateById(w, r)
+}
+
+// GetJwks implements api.ServerInterface.
+func (a *APIhandler) GetJwks(w http.ResponseWriter, r *http.Request) {
+	a.UserHandler.GetJwks(w, r)
+}
+
 // GetUserStatus implements api.ServerInterface.
 func (a *APIhandler) GetUserStatus(w http.ResponseWriter, r *http.Request) {
 	a.UserHandler.GetUserStatus(w, r)
@@ -47,9 +130,32 @@ func (a *APIhandler) GetWorkoutPlanById(w http.ResponseWriter, r *http.Request,
 	a.WorkoutHandler.GetWorkoutPlanById(w, r)
 }
 
+// InstantiateTemplate implements api.ServerInterface.
+func (a *APIhandler) InstantiateTemplate(w http.ResponseWriter, r *http.Request, templateId int64) {
+	r.SetPathValue("templateId", strconv.Itoa(int(templateId)))
+	a.TemplateHandler.InstantiateTemplate(w, r)
+}
+
 // ListExercises implements api.ServerInterface.
-func (a *APIhandler) ListExercises(w http.ResponseWriter, r *http.Request) {
-	a.ExerciseHandler.ListExercises(w, r)
+func (a *APIhandler) ListExercises(w http.ResponseWriter, r *http.Request, params api.ListExercisesParams) {
+	a.ExerciseHandler.ListExercises(w, r, params)
+}
+
+// ListPerformedSets implements api.ServerInterface.
+func (a *APIhandler) ListPerformedSets(w http.ResponseWriter, r *http.Request, workoutId int64, exercisePlanId int64) {
+	r.SetPathValue("workoutId", strconv.Itoa(int(workoutId)))
+	r.SetPathValue("exercisePlanId", strconv.Itoa(int(exercisePlanId)))
+	a.PerformedSetHandler.ListPerformedSets(w, r)
+}
+
+// ListSchedules implements api.ServerInterface.
+func (a *APIhandler) ListSchedules(w http.ResponseWriter, r *http.Request) {
+	a.ScheduleHandler.ListSchedules(w, r)
+}
+
+// ListTemplates implements api.ServerInterface.
+func (a *APIhandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	a.TemplateHandler.ListTemplates(w, r)
 }
 
 // ListWorkoutPlans implements api.ServerInterface.
@@ -58,6 +164,13 @@ func (a *APIhandler) ListWorkoutPlans(w http.ResponseWriter, r *http.Request, pa
 	a.WorkoutHandler.ListWorkoutPlans(w, r)
 }
 
+// LogPerformedSet implements api.ServerInterface.
+func (a *APIhandler) LogPerformedSet(w http.ResponseWriter, r *http.Request, workoutId int64, exercisePlanId int64) {
+	r.SetPathValue("workoutId", strconv.Itoa(int(workoutId)))
+	r.SetPathValue("exercisePlanId", strconv.Itoa(int(exercisePlanId)))
+	a.PerformedSetHandler.LogPerformedSet(w, r)
+}
+
 // LoginUser implements api.ServerInterface.
 func (a *APIhandler) LoginUser(w http.ResponseWriter, r *http.Request) {
 	a.UserHandler.LoginUser(w, r)
@@ -68,9 +181,65 @@ func (a *APIhandler) LogoutUser(w http.ResponseWriter, r *http.Request) {
 	a.UserHandler.LogoutUser(w, r)
 }
 
+// MoveExercisePlan implements api.ServerInterface.
+func (a *APIhandler) MoveExercisePlan(w http.ResponseWriter, r *http.Request, workoutId int64, exercisePlanId int64) {
+	r.SetPathValue("workoutId", strconv.Itoa(int(workoutId)))
+	r.SetPathValue("exercisePlanId", strconv.Itoa(int(exercisePlanId)))
+	a.WorkoutHandler.MoveExercisePlan(w, r)
+}
+
+// PreviewSchedule implements api.ServerInterface.
+func (a *APIhandler) PreviewSchedule(w http.ResponseWriter, r *http.Request) {
+	a.ScheduleHandler.PreviewSchedule(w, r)
+}
+
+// RefreshUserToken implements api.ServerInterface.
+func (a *APIhandler) RefreshUserToken(w http.ResponseWriter, r *http.Request) {
+	a.UserHandler.RefreshUserToken(w, r)
+}
+
+// RemoveExercisePlan implements api.ServerInterface.
+func (a *APIhandler) RemoveExercisePlan(w http.ResponseWriter, r *http.Request, workoutId int64, exercisePlanId int64) {
+	r.SetPathValue("workoutId", strconv.Itoa(int(workoutId)))
+	r.SetPathValue("exercisePlanId", strconv.Itoa(int(exercisePlanId)))
+	a.WorkoutHandler.RemoveExercisePlan(w, r)
+}
+
+// ReportMuscleBalance implements api.ServerInterface.
+func (a *APIhandler) ReportMuscleBalance(w http.ResponseWriter, r *http.Request, params api.ReportMuscleBalanceParams) {
+	a.ReportHandler.ReportMuscleBalance(w, r, params)
+}
+
+// ReportPersonalRecords implements api.ServerInterface.
+func (a *APIhandler) ReportPersonalRecords(w http.ResponseWriter, r *http.Request, params api.ReportPersonalRecordsParams) {
+	a.ReportHandler.ReportPersonalRecords(w, r, params)
+}
+
 // ReportProgress implements api.ServerInterface.
-func (a *APIhandler) ReportProgress(w http.ResponseWriter, r *http.Request) {
-	a.ReportHandler.ReportProgress(w, r)
+func (a *APIhandler) ReportProgress(w http.ResponseWriter, r *http.Request, params api.ReportProgressParams) {
+	a.ReportHandler.ReportProgress(w, r, params)
+}
+
+// ReportConsistency implements api.ServerInterface.
+func (a *APIhandler) ReportConsistency(w http.ResponseWriter, r *http.Request, params api.ReportConsistencyParams) {
+	a.ReportHandler.ReportConsistency(w, r, params)
+}
+
+// ReportStrength implements api.ServerInterface.
+func (a *APIhandler) ReportStrength(w http.ResponseWriter, r *http.Request, exerciseId int64, params api.ReportStrengthParams) {
+	r.SetPathValue("exerciseId", strconv.Itoa(int(exerciseId)))
+	a.ReportHandler.ReportStrength(w, r, params)
+}
+
+// ReportVolume implements api.ServerInterface.
+func (a *APIhandler) ReportVolume(w http.ResponseWriter, r *http.Request, params api.ReportVolumeParams) {
+	a.ReportHandler.ReportVolume(w, r, params)
+}
+
+// SaveWorkoutAsTemplate implements api.ServerInterface.
+func (a *APIhandler) SaveWorkoutAsTemplate(w http.ResponseWriter, r *http.Request, workoutId int64) {
+	r.SetPathValue("workoutId", strconv.Itoa(int(workoutId)))
+	a.TemplateHandler.SaveWorkoutAsTemplate(w, r)
 }
 
 // ScheduleWorkoutPlanById implements api.ServerInterface.
@@ -84,6 +253,17 @@ func (a *APIhandler) SignupUser(w http.ResponseWriter, r *http.Request) {
 	a.UserHandler.SignupUser(w, r)
 }
 
+// TriggerMissedWorkouts implements api.ServerInterface.
+func (a *APIhandler) TriggerMissedWorkouts(w http.ResponseWriter, r *http.Request) {
+	a.JobHandler.TriggerMissedWorkouts(w, r)
+}
+
+// UpdateExercise implements api.ServerInterface.
+func (a *APIhandler) UpdateExercise(w http.ResponseWriter, r *http.Request, exerciseId int64) {
+	r.SetPathValue("exerciseId", strconv.Itoa(int(exerciseId)))
+	a.ExerciseHandler.UpdateExercise(w, r)
+}
+
 // UpdateExercisePlansInWorkoutPlan implements api.ServerInterface.
 func (a *APIhandler) UpdateExercisePlansInWorkoutPlan(w http.ResponseWriter, r *http.Request, workoutId int64) {
 
@@ -91,16 +271,205 @@ func (a *APIhandler) UpdateExercisePlansInWorkoutPlan(w http.ResponseWriter, r *
 	a.WorkoutHandler.UpdateExercisePlansInWorkoutPlan(w, r)
 }
 
+// UpdateScheduleOccurrence implements api.ServerInterface.
+func (a *APIhandler) UpdateScheduleOccurrence(w http.ResponseWriter, r *http.Request, scheduleId int64, workoutId int64) {
+	r.SetPathValue("scheduleId", strconv.Itoa(int(scheduleId)))
+	r.SetPathValue("workoutId", strconv.Itoa(int(workoutId)))
+	a.ScheduleHandler.UpdateScheduleOccurrence(w, r)
+}
+
+// UpdateTemplate implements api.ServerInterface.
+func (a *APIhandler) UpdateTemplate(w http.ResponseWriter, r *http.Request, templateId int64) {
+	r.SetPathValue("templateId", strconv.Itoa(int(templateId)))
+	a.TemplateHandler.UpdateTemplate(w, r)
+}
+
+// UpdatePerformedSet implements api.ServerInterface.
+func (a *APIhandler) UpdatePerformedSet(w http.ResponseWriter, r *http.Request, workoutId int64, exercisePlanId int64, setId int64) {
+	r.SetPathValue("workoutId", strconv.Itoa(int(workoutId)))
+	r.SetPathValue("exercisePlanId", strconv.Itoa(int(exercisePlanId)))
+	r.SetPathValue("setId", strconv.Itoa(int(setId)))
+	a.PerformedSetHandler.UpdatePerformedSet(w, r)
+}
+
+// ListUserSessions implements api.ServerInterface.
+func (a *APIhandler) ListUserSessions(w http.ResponseWriter, r *http.Request) {
+	a.SessionHandler.ListUserSessions(w, r)
+}
+
+// RequestPasswordReset implements api.ServerInterface.
+func (a *APIhandler) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	a.UserHandler.RequestPasswordReset(w, r)
+}
+
+// ResetUserPassword implements api.ServerInterface.
+func (a *APIhandler) ResetUserPassword(w http.ResponseWriter, r *http.Request) {
+	a.UserHandler.ResetUserPassword(w, r)
+}
+
+// DeleteUser implements api.ServerInterface.
+func (a *APIhandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	a.DeletionHandler.DeleteUser(w, r)
+}
+
+// CancelUserDeletion implements api.ServerInterface.
+func (a *APIhandler) CancelUserDeletion(w http.ResponseWriter, r *http.Request) {
+	a.DeletionHandler.CancelUserDeletion(w, r)
+}
+
+// ListAccessTokens implements api.ServerInterface.
+func (a *APIhandler) ListAccessTokens(w http.ResponseWriter, r *http.Request) {
+	a.AccessTokenHandler.ListAccessTokens(w, r)
+}
+
+// CreateAccessToken implements api.ServerInterface.
+func (a *APIhandler) CreateAccessToken(w http.ResponseWriter, r *http.Request) {
+	a.AccessTokenHandler.CreateAccessToken(w, r)
+}
+
+// GetAccessToken implements api.ServerInterface.
+func (a *APIhandler) GetAccessToken(w http.ResponseWriter, r *http.Request, tokenId int64) {
+	r.SetPathValue("tokenId", strconv.Itoa(int(tokenId)))
+	a.AccessTokenHandler.GetAccessToken(w, r)
+}
+
+// UpdateAccessToken implements api.ServerInterface.
+func (a *APIhandler) UpdateAccessToken(w http.ResponseWriter, r *http.Request, tokenId int64) {
+	r.SetPathValue("tokenId", strconv.Itoa(int(tokenId)))
+	a.AccessTokenHandler.UpdateAccessToken(w, r)
+}
+
+// DeleteAccessToken implements api.ServerInterface.
+func (a *APIhandler) DeleteAccessToken(w http.ResponseWriter, r *http.Request, tokenId int64) {
+	r.SetPathValue("tokenId", strconv.Itoa(int(tokenId)))
+	a.AccessTokenHandler.DeleteAccessToken(w, r)
+}
+
+// ListUsers implements api.ServerInterface.
+func (a *APIhandler) ListUsers(w http.ResponseWriter, r *http.Request, params api.ListUsersParams) {
+	a.AdminHandler.ListUsers(w, r, params)
+}
+
+// DisableUser implements api.ServerInterface.
+func (a *APIhandler) DisableUser(w http.ResponseWriter, r *http.Request, userId int64) {
+	r.SetPathValue("userId", strconv.Itoa(int(userId)))
+	a.AdminHandler.DisableUser(w, r)
+}
+
+// EnableUser implements api.ServerInterface.
+func (a *APIhandler) EnableUser(w http.ResponseWriter, r *http.Request, userId int64) {
+	r.SetPathValue("userId", strconv.Itoa(int(userId)))
+	a.AdminHandler.EnableUser(w, r)
+}
+
+// UpdateUserRole implements api.ServerInterface.
+func (a *APIhandler) UpdateUserRole(w http.ResponseWriter, r *http.Request, userId int64) {
+	r.SetPathValue("userId", strconv.Itoa(int(userId)))
+	a.AdminHandler.UpdateUserRole(w, r)
+}
+
+// GetSystemStats implements api.ServerInterface.
+func (a *APIhandler) GetSystemStats(w http.ResponseWriter, r *http.Request) {
+	a.AdminHandler.GetSystemStats(w, r)
+}
+
+// CreateCatalogExercise implements api.ServerInterface.
+func (a *APIhandler) CreateCatalogExercise(w http.ResponseWriter, r *http.Request) {
+	a.AdminHandler.CreateCatalogExercise(w, r)
+}
+
+// UpdateCatalogExercise implements api.ServerInterface.
+func (a *APIhandler) UpdateCatalogExercise(w http.ResponseWriter, r *http.Request, exerciseId int64) {
+	r.SetPathValue("exerciseId", strconv.Itoa(int(exerciseId)))
+	a.AdminHandler.UpdateCatalogExercise(w, r)
+}
+
+// DeleteCatalogExercise implements api.ServerInterface.
+func (a *APIhandler) DeleteCatalogExercise(w http.ResponseWriter, r *http.Request, exerciseId int64) {
+	r.SetPathValue("exerciseId", strconv.Itoa(int(exerciseId)))
+	a.AdminHandler.DeleteCatalogExercise(w, r)
+}
+
+// UnlockUserAccount implements api.ServerInterface.
+func (a *APIhandler) UnlockUserAccount(w http.ResponseWriter, r *http.Request, userId int64) {
+	r.SetPathValue("userId", strconv.Itoa(int(userId)))
+	a.AdminHandler.UnlockUserAccount(w, r)
+}
+
+// VerifyUserEmail implements api.ServerInterface.
+func (a *APIhandler) VerifyUserEmail(w http.ResponseWriter, r *http.Request) {
+	a.UserHandler.VerifyUserEmail(w, r)
+}
+
+// ResendVerificationEmail implements api.ServerInterface.
+func (a *APIhandler) ResendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	a.UserHandler.ResendVerificationEmail(w, r)
+}
+
+// RevokeAllUserSessions implements api.ServerInterface.
+func (a *APIhandler) RevokeAllUserSessions(w http.ResponseWriter, r *http.Request) {
+	a.SessionHandler.RevokeAllUserSessions(w, r)
+}
+
+// RevokeUserSession implements api.ServerInterface.
+func (a *APIhandler) RevokeUserSession(w http.ResponseWriter, r *http.Request, sessionId string) {
+	r.SetPathValue("sessionId", sessionId)
+	a.SessionHandler.RevokeUserSession(w, r)
+}
+
+// CompleteTwoFactorLogin implements api.ServerInterface.
+func (a *APIhandler) CompleteTwoFactorLogin(w http.ResponseWriter, r *http.Request) {
+	a.UserHandler.CompleteTwoFactorLogin(w, r)
+}
+
+// ConfirmTwoFactor implements api.ServerInterface.
+func (a *APIhandler) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	a.UserHandler.ConfirmTwoFactor(w, r)
+}
+
+// DisableTwoFactor implements api.ServerInterface.
+func (a *APIhandler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	a.UserHandler.DisableTwoFactor(w, r)
+}
+
+// EnrollTwoFactor implements api.ServerInterface.
+func (a *APIhandler) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	a.UserHandler.EnrollTwoFactor(w, r)
+}
+
+// UpdateUserPreferences implements api.ServerInterface.
+func (a *APIhandler) UpdateUserPreferences(w http.ResponseWriter, r *http.Request) {
+	a.UserHandler.UpdateUserPreferences(w, r)
+}
+
 func NewAPIHandler(
 	userH *UserHandler,
 	workoutH *WorkoutHandler,
 	exerciseH *ExerciseHandler,
 	reportH *ReportHandler,
+	performedSetH *PerformedSetHandler,
+	scheduleH *ScheduleHandler,
+	templateH *TemplateHandler,
+	jobH *JobHandler,
+	sessionH *SessionHandler,
+	adminH *AdminHandler,
+	accessTokenH *AccessTokenHandler,
+	deletionH *AccountDeletionHandler,
+	exportH *ExportHandler,
 ) api.ServerInterface {
 	return &APIhandler{
-		UserHandler:     userH,
-		WorkoutHandler:  workoutH,
-		ExerciseHandler: exerciseH,
-		ReportHandler:   reportH,
+		UserHandler:         userH,
+		WorkoutHandler:      workoutH,
+		ExerciseHandler:     exerciseH,
+		ReportHandler:       reportH,
+		PerformedSetHandler: performedSetH,
+		ScheduleHandler:     scheduleH,
+		TemplateHandler:     templateH,
+		JobHandler:          jobH,
+		SessionHandler:      sessionH,
+		AdminHandler:        adminH,
+		AccessTokenHandler:  accessTokenH,
+		DeletionHandler:     deletionH,
+		ExportHandler:       exportH,
 	}
 }
@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"strconv"
 	"workout-tracker-api/internal/apperrors"
@@ -22,17 +25,50 @@ func NewExerciseHandler(es service.ExerciseServiceInterface) *ExerciseHandler {
 }
 
 // ListExercises
-func (ec *ExerciseHandler) ListExercises(w http.ResponseWriter, r *http.Request) {
-	exerciseList, err := ec.ExerciseService.ListExercises(r.Context())
+func (ec *ExerciseHandler) ListExercises(w http.ResponseWriter, r *http.Request, params api.ListExercisesParams) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
 
+	query := service.ExerciseQuery{UserId: userInfo.Id}
+	if params.Q != nil {
+		query.Name = *params.Q
+	}
+	if params.MuscleGroup != nil {
+		for _, mg := range *params.MuscleGroup {
+			query.MuscleGroups = append(query.MuscleGroups, service.MuscleGroup(mg))
+		}
+	}
+	if params.Equipment != nil {
+		for _, eq := range *params.Equipment {
+			query.Equipment = append(query.Equipment, service.Equipment(eq))
+		}
+	}
+	if params.Cursor != nil {
+		query.Cursor = *params.Cursor
+	}
+	if params.Limit != nil {
+		query.Limit = *params.Limit
+	}
+
+	page, err := ec.ExerciseService.ListExercises(r.Context(), query)
 	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
 		helper.SendErrorResponse(w, fmt.Errorf("failed to fetch exercises: %w", err))
 		return
 	}
 
 	var exercises []api.Exercise
 
-	for _, exer := range exerciseList {
+	for _, exer := range page.Exercises {
 		apiExer := toAPIExercise(&exer)
 		exercises = append(exercises, *apiExer)
 	}
@@ -41,7 +77,8 @@ func (ec *ExerciseHandler) ListExercises(w http.ResponseWriter, r *http.Request)
 		Code:    api.FETCH,
 		Message: "successfully fetch exercises",
 		Payload: &map[string]any{
-			"exercises": exercises,
+			"exercises":  exercises,
+			"nextCursor": page.NextCursor,
 		},
 	}
 
@@ -69,6 +106,19 @@ func (ec *ExerciseHandler) GetExerciseByID(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// custom exercises are private to their owner
+	if exer.IsCustom() {
+		userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+		if !ok {
+			helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+			return
+		}
+		if *exer.OwnerId != userInfo.Id {
+			helper.SendErrorResponse(w, apperrors.ErrForbidden)
+			return
+		}
+	}
+
 	exericse := toAPIExercise(exer)
 
 	response := api.Success{
@@ -83,15 +133,202 @@ func (ec *ExerciseHandler) GetExerciseByID(w http.ResponseWriter, r *http.Reques
 
 }
 
+// CreateExercise
+func (ec *ExerciseHandler) CreateExercise(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateExerciseJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding create exercise request: %v", err)
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	input := service.ExerciseCreate{
+		OwnerId:     userInfo.Id,
+		Name:        req.Name,
+		MuscleGroup: service.MuscleGroup(req.MuscleGroup),
+	}
+	if req.Description != nil {
+		input.Description = *req.Description
+	}
+	if req.Equipment != nil {
+		input.Equipment = service.Equipment(*req.Equipment)
+	}
+
+	exer, err := ec.ExerciseService.CreateExercise(r.Context(), input)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to create exercise: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.CREATED,
+		Message: "successfully create exercise",
+		Payload: &map[string]any{
+			"exercise": toAPIExercise(exer),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusCreated, &response)
+}
+
+// UpdateExercise
+func (ec *ExerciseHandler) UpdateExercise(w http.ResponseWriter, r *http.Request) {
+	existing, err := exerciseAuth(w, r, ec.ExerciseService)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	updateExercise(w, r, ec.ExerciseService, existing)
+}
+
+// DeleteExercise
+func (ec *ExerciseHandler) DeleteExercise(w http.ResponseWriter, r *http.Request) {
+	existing, err := exerciseAuth(w, r, ec.ExerciseService)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	deleteExercise(w, r, ec.ExerciseService, existing)
+}
+
+// exerciseAuth only lets the owner of a custom exercise through, global exercises can not be changed by users
+func exerciseAuth(w http.ResponseWriter, r *http.Request, exerciseService service.ExerciseServiceInterface) (*service.Exercise, error) {
+	exerId, err := pathID(w, r, "exerciseId")
+	if err != nil {
+		return nil, err
+	}
+
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		err := fmt.Errorf("failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return nil, err
+	}
+
+	existing, err := exerciseService.GetExerciseById(r.Context(), exerId)
+	if err != nil {
+		err := fmt.Errorf("error fetching exercise %d for operation by user %d", exerId, userInfo.Id)
+		helper.SendErrorResponse(w, apperrors.ErrNotFound)
+		return nil, err
+	}
+
+	if !existing.IsCustom() || *existing.OwnerId != userInfo.Id {
+		err := fmt.Errorf("unauthorized attempt: User %d tried to operate exercise %d", userInfo.Id, exerId)
+		helper.SendErrorResponse(w, apperrors.ErrForbidden)
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// updateExercise applies the request body to an exercise the caller is allowed to change
+func updateExercise(w http.ResponseWriter, r *http.Request, exerciseService service.ExerciseServiceInterface, existing *service.Exercise) {
+	var req api.UpdateExerciseJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding update exercise request: %v", err)
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	input := service.ExerciseUpdate{
+		Id:          existing.Id,
+		Name:        req.Name,
+		Description: existing.Description,
+		MuscleGroup: service.MuscleGroup(req.MuscleGroup),
+		Equipment:   existing.Equipment,
+	}
+	if req.Description != nil {
+		input.Description = *req.Description
+	}
+	if req.Equipment != nil {
+		input.Equipment = service.Equipment(*req.Equipment)
+	}
+
+	exer, err := exerciseService.UpdateExercise(r.Context(), input)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to update exercise: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.UPDATE,
+		Message: "successfully update exercise",
+		Payload: &map[string]any{
+			"exercise": toAPIExercise(exer),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// deleteExercise removes an exercise the caller is allowed to change, or archives it while it is in use
+func deleteExercise(w http.ResponseWriter, r *http.Request, exerciseService service.ExerciseServiceInterface, existing *service.Exercise) {
+	archived, err := exerciseService.DeleteExercise(r.Context(), existing.Id)
+	if err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to delete exercise: %w", err))
+		return
+	}
+
+	if !archived {
+		helper.SendSuccessResponse(w, http.StatusNoContent, nil)
+		return
+	}
+
+	exer, err := exerciseService.GetExerciseById(r.Context(), existing.Id)
+	if err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to fetch archived exercise: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.UPDATE,
+		Message: "exercise is still in use and was archived",
+		Payload: &map[string]any{
+			"exercise": toAPIExercise(exer),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
 func toAPIExercise(serviceExers *service.Exercise) *api.Exercise {
 	if serviceExers == nil {
 		return nil
 	}
 
-	return &api.Exercise{
+	exercise := &api.Exercise{
 		Description: &serviceExers.Description,
 		Id:          util.IntTo64(serviceExers.Id),
 		MuscleGroup: (*api.MuscleGroup)(&serviceExers.MuscleGroup),
+		Equipment:   (*api.Equipment)(&serviceExers.Equipment),
 		Name:        &serviceExers.Name,
+		ArchivedAt:  serviceExers.ArchivedAt,
 	}
+
+	if serviceExers.OwnerId != nil {
+		exercise.OwnerId = util.IntTo64(*serviceExers.OwnerId)
+	}
+
+	return exercise
 }
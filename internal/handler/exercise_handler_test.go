@@ -1,6 +1,7 @@
 package handler_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -8,8 +9,11 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
+	"workout-tracker-api/internal/apperrors"
 	"workout-tracker-api/internal/handler"
 	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util/helper"
 	"workout-tracker-api/pkg/api"
 
 	"github.com/stretchr/testify/assert"
@@ -27,15 +31,43 @@ func (m *MockExerciseService) GetExerciseById(ctx context.Context, id int) (*ser
 	}
 	return args.Get(0).(*service.Exercise), args.Error(1)
 }
-func (m *MockExerciseService) ListExercises(ctx context.Context) ([]service.Exercise, error) {
-	args := m.Called(ctx)
+func (m *MockExerciseService) ListExercises(ctx context.Context, query service.ExerciseQuery) (*service.ExercisePage, error) {
+	args := m.Called(ctx, query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]service.Exercise), args.Error(1)
+	return args.Get(0).(*service.ExercisePage), args.Error(1)
+}
+func (m *MockExerciseService) CreateExercise(ctx context.Context, data service.ExerciseCreate) (*service.Exercise, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.Exercise), args.Error(1)
+}
+func (m *MockExerciseService) UpdateExercise(ctx context.Context, data service.ExerciseUpdate) (*service.Exercise, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.Exercise), args.Error(1)
+}
+func (m *MockExerciseService) CreateCatalogExercise(ctx context.Context, data service.ExerciseCreate) (*service.Exercise, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.Exercise), args.Error(1)
+}
+
+func (m *MockExerciseService) DeleteExercise(ctx context.Context, id int) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
 }
 
 func TestExerciseHandler(t *testing.T) {
+	testUserID := 5
+	otherUserID := 6
 	nonexisExercise := 999
 	exercise1 := service.Exercise{
 		Id:          1,
@@ -50,20 +82,38 @@ func TestExerciseHandler(t *testing.T) {
 		Description: "not hard, trust me",
 		MuscleGroup: service.Legs,
 	}
+	customExercise := service.Exercise{
+		Id:          3,
+		Name:        "Sled Push",
+		Description: "my own",
+		MuscleGroup: service.Legs,
+		OwnerId:     &testUserID,
+	}
+	otherExercise := service.Exercise{
+		Id:          4,
+		Name:        "Secret Curl",
+		MuscleGroup: service.Arms,
+		OwnerId:     &otherUserID,
+	}
 	mockExercises := []service.Exercise{
 		exercise1, exercise2,
 	}
 
+	withUser := func(req *http.Request) *http.Request {
+		ctx := context.WithValue(req.Context(), helper.UserContextKey, &helper.UserInfo{Id: testUserID})
+		return req.WithContext(ctx)
+	}
+
 	t.Run("ListExercises", func(t *testing.T) {
 		t.Run("successful non empty exercises 200", func(t *testing.T) {
 			mockService := new(MockExerciseService)
-			mockService.On("ListExercises", mock.Anything).Return(mockExercises, nil).Once()
+			mockService.On("ListExercises", mock.Anything, service.ExerciseQuery{UserId: testUserID}).Return(&service.ExercisePage{Exercises: mockExercises}, nil).Once()
 			h := handler.NewExerciseHandler(mockService)
 
-			req := httptest.NewRequest(http.MethodGet, "/exercises", nil)
+			req := withUser(httptest.NewRequest(http.MethodGet, "/exercises", nil))
 			rr := httptest.NewRecorder()
 
-			h.ListExercises(rr, req)
+			h.ListExercises(rr, req, api.ListExercisesParams{})
 
 			assert.Equal(t, http.StatusOK, rr.Code)
 			var resp api.Success
@@ -79,13 +129,13 @@ func TestExerciseHandler(t *testing.T) {
 
 		t.Run("successful empty exercises 200", func(t *testing.T) {
 			mockService := new(MockExerciseService)
-			mockService.On("ListExercises", mock.Anything).Return([]service.Exercise{}, nil).Once()
+			mockService.On("ListExercises", mock.Anything, service.ExerciseQuery{UserId: testUserID}).Return(&service.ExercisePage{}, nil).Once()
 			h := handler.NewExerciseHandler(mockService)
 
-			req := httptest.NewRequest(http.MethodGet, "/exercises", nil)
+			req := withUser(httptest.NewRequest(http.MethodGet, "/exercises", nil))
 			rr := httptest.NewRecorder()
 
-			h.ListExercises(rr, req)
+			h.ListExercises(rr, req, api.ListExercisesParams{})
 
 			assert.Equal(t, http.StatusOK, rr.Code)
 			var resp api.Success
@@ -99,17 +149,76 @@ func TestExerciseHandler(t *testing.T) {
 
 		t.Run("fail internal err 500", func(t *testing.T) {
 			mockService := new(MockExerciseService)
-			mockService.On("ListExercises", mock.Anything).Return(nil, errors.New("db error")).Once()
+			mockService.On("ListExercises", mock.Anything, service.ExerciseQuery{UserId: testUserID}).Return(nil, errors.New("db error")).Once()
 			h := handler.NewExerciseHandler(mockService)
 
-			req := httptest.NewRequest(http.MethodGet, "/exercises", nil)
+			req := withUser(httptest.NewRequest(http.MethodGet, "/exercises", nil))
 			rr := httptest.NewRecorder()
 
-			h.ListExercises(rr, req)
+			h.ListExercises(rr, req, api.ListExercisesParams{})
 
 			assert.Equal(t, http.StatusInternalServerError, rr.Code)
 			mockService.AssertExpectations(t)
 		})
+
+		t.Run("search parameters and next cursor 200", func(t *testing.T) {
+			q := "squ"
+			muscleGroups := []api.MuscleGroup{api.Legs, api.Glutes}
+			equipment := []api.Equipment{api.EquipmentBarbell}
+			limit := 1
+			nextCursor := "next-page"
+
+			mockService := new(MockExerciseService)
+			mockService.On("ListExercises", mock.Anything, service.ExerciseQuery{
+				UserId:       testUserID,
+				Name:         q,
+				MuscleGroups: []service.MuscleGroup{service.Legs, service.Glutes},
+				Equipment:    []service.Equipment{service.Barbell},
+				Limit:        limit,
+			}).Return(&service.ExercisePage{Exercises: []service.Exercise{exercise2}, NextCursor: &nextCursor}, nil).Once()
+			h := handler.NewExerciseHandler(mockService)
+
+			req := withUser(httptest.NewRequest(http.MethodGet, "/exercises?q=squ&muscleGroup=legs&muscleGroup=glutes&equipment=barbell&limit=1", nil))
+			rr := httptest.NewRecorder()
+
+			h.ListExercises(rr, req, api.ListExercisesParams{Q: &q, MuscleGroup: &muscleGroups, Equipment: &equipment, Limit: &limit})
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			var resp api.Success
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, nextCursor, (*resp.Payload)["nextCursor"])
+			mockService.AssertExpectations(t)
+		})
+
+		t.Run("invalid cursor 400", func(t *testing.T) {
+			cursor := "broken"
+			mockService := new(MockExerciseService)
+			mockService.On("ListExercises", mock.Anything, service.ExerciseQuery{UserId: testUserID, Cursor: cursor}).
+				Return(nil, apperrors.NewValidationError(apperrors.INVALID_INPUT, "cursor is not valid")).Once()
+			h := handler.NewExerciseHandler(mockService)
+
+			req := withUser(httptest.NewRequest(http.MethodGet, "/exercises?cursor=broken", nil))
+			rr := httptest.NewRecorder()
+
+			h.ListExercises(rr, req, api.ListExercisesParams{Cursor: &cursor})
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+
+		t.Run("fail without user 401", func(t *testing.T) {
+			mockService := new(MockExerciseService)
+			h := handler.NewExerciseHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/exercises", nil)
+			rr := httptest.NewRecorder()
+
+			h.ListExercises(rr, req, api.ListExercisesParams{})
+
+			assert.Equal(t, http.StatusUnauthorized, rr.Code)
+			mockService.AssertNotCalled(t, "ListExercises", mock.Anything, mock.Anything)
+		})
 	})
 
 	t.Run("GetExerciseByID", func(t *testing.T) {
@@ -191,5 +300,185 @@ func TestExerciseHandler(t *testing.T) {
 			assert.Equal(t, http.StatusNotFound, rr.Code)
 			mockService.AssertExpectations(t)
 		})
+
+		t.Run("owner gets custom exercise 200", func(t *testing.T) {
+			mockService := new(MockExerciseService)
+			mockService.On("GetExerciseById", mock.Anything, customExercise.Id).Return(&customExercise, nil).Once()
+			h := handler.NewExerciseHandler(mockService)
+
+			req := withUser(httptest.NewRequest(http.MethodGet, "/exercises/3", nil))
+			req.SetPathValue("exerciseId", strconv.Itoa(customExercise.Id))
+			rr := httptest.NewRecorder()
+
+			h.GetExerciseByID(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+
+		t.Run("custom exercise of another user 403", func(t *testing.T) {
+			mockService := new(MockExerciseService)
+			mockService.On("GetExerciseById", mock.Anything, otherExercise.Id).Return(&otherExercise, nil).Once()
+			h := handler.NewExerciseHandler(mockService)
+
+			req := withUser(httptest.NewRequest(http.MethodGet, "/exercises/4", nil))
+			req.SetPathValue("exerciseId", strconv.Itoa(otherExercise.Id))
+			rr := httptest.NewRecorder()
+
+			h.GetExerciseByID(rr, req)
+
+			assert.Equal(t, http.StatusForbidden, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	})
+
+	t.Run("CreateExercise", func(t *testing.T) {
+		t.Run("successfully create exercise 201", func(t *testing.T) {
+			mockService := new(MockExerciseService)
+			mockService.On("CreateExercise", mock.Anything, service.ExerciseCreate{
+				OwnerId:     testUserID,
+				Name:        customExercise.Name,
+				Description: customExercise.Description,
+				MuscleGroup: customExercise.MuscleGroup,
+			}).Return(&customExercise, nil).Once()
+			h := handler.NewExerciseHandler(mockService)
+
+			description := customExercise.Description
+			body, _ := json.Marshal(api.CreateExerciseJSONRequestBody{
+				Name:        customExercise.Name,
+				Description: &description,
+				MuscleGroup: api.MuscleGroup(customExercise.MuscleGroup),
+			})
+			req := withUser(httptest.NewRequest(http.MethodPost, "/exercises", bytes.NewBuffer(body)))
+			rr := httptest.NewRecorder()
+
+			h.CreateExercise(rr, req)
+
+			assert.Equal(t, http.StatusCreated, rr.Code)
+			var resp api.Success
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, api.CREATED, resp.Code)
+			ex, ok := (*resp.Payload)["exercise"].(map[string]any)
+			assert.True(t, ok)
+			assert.Equal(t, float64(testUserID), ex["ownerId"])
+			mockService.AssertExpectations(t)
+		})
+
+		t.Run("validation error 400", func(t *testing.T) {
+			mockService := new(MockExerciseService)
+			mockService.On("CreateExercise", mock.Anything, mock.Anything).
+				Return(nil, apperrors.NewValidationError(apperrors.INVALID_SETTING, "invalid muscle group 'neck'")).Once()
+			h := handler.NewExerciseHandler(mockService)
+
+			body, _ := json.Marshal(api.CreateExerciseJSONRequestBody{Name: "Neck Roll", MuscleGroup: "neck"})
+			req := withUser(httptest.NewRequest(http.MethodPost, "/exercises", bytes.NewBuffer(body)))
+			rr := httptest.NewRecorder()
+
+			h.CreateExercise(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	})
+
+	t.Run("UpdateExercise", func(t *testing.T) {
+		t.Run("owner updates custom exercise 200", func(t *testing.T) {
+			mockService := new(MockExerciseService)
+			mockService.On("GetExerciseById", mock.Anything, customExercise.Id).Return(&customExercise, nil).Once()
+			mockService.On("UpdateExercise", mock.Anything, service.ExerciseUpdate{
+				Id:          customExercise.Id,
+				Name:        "Sled Drag",
+				Description: customExercise.Description,
+				MuscleGroup: service.Legs,
+			}).Return(&customExercise, nil).Once()
+			h := handler.NewExerciseHandler(mockService)
+
+			body, _ := json.Marshal(api.UpdateExerciseJSONRequestBody{Name: "Sled Drag", MuscleGroup: api.MuscleGroup(service.Legs)})
+			req := withUser(httptest.NewRequest(http.MethodPut, "/exercises/3", bytes.NewBuffer(body)))
+			req.SetPathValue("exerciseId", strconv.Itoa(customExercise.Id))
+			rr := httptest.NewRecorder()
+
+			h.UpdateExercise(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+
+		t.Run("global exercise can not be updated 403", func(t *testing.T) {
+			mockService := new(MockExerciseService)
+			mockService.On("GetExerciseById", mock.Anything, exercise1.Id).Return(&exercise1, nil).Once()
+			h := handler.NewExerciseHandler(mockService)
+
+			body, _ := json.Marshal(api.UpdateExerciseJSONRequestBody{Name: "Mine now", MuscleGroup: api.MuscleGroup(service.Chest)})
+			req := withUser(httptest.NewRequest(http.MethodPut, "/exercises/1", bytes.NewBuffer(body)))
+			req.SetPathValue("exerciseId", strconv.Itoa(exercise1.Id))
+			rr := httptest.NewRecorder()
+
+			h.UpdateExercise(rr, req)
+
+			assert.Equal(t, http.StatusForbidden, rr.Code)
+			mockService.AssertNotCalled(t, "UpdateExercise", mock.Anything, mock.Anything)
+		})
+	})
+
+	t.Run("DeleteExercise", func(t *testing.T) {
+		t.Run("unused exercise deleted 204", func(t *testing.T) {
+			mockService := new(MockExerciseService)
+			mockService.On("GetExerciseById", mock.Anything, customExercise.Id).Return(&customExercise, nil).Once()
+			mockService.On("DeleteExercise", mock.Anything, customExercise.Id).Return(false, nil).Once()
+			h := handler.NewExerciseHandler(mockService)
+
+			req := withUser(httptest.NewRequest(http.MethodDelete, "/exercises/3", nil))
+			req.SetPathValue("exerciseId", strconv.Itoa(customExercise.Id))
+			rr := httptest.NewRecorder()
+
+			h.DeleteExercise(rr, req)
+
+			assert.Equal(t, http.StatusNoContent, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+
+		t.Run("referenced exercise archived 200", func(t *testing.T) {
+			archivedAt := time.Now()
+			archived := customExercise
+			archived.ArchivedAt = &archivedAt
+
+			mockService := new(MockExerciseService)
+			mockService.On("GetExerciseById", mock.Anything, customExercise.Id).Return(&customExercise, nil).Once()
+			mockService.On("DeleteExercise", mock.Anything, customExercise.Id).Return(true, nil).Once()
+			mockService.On("GetExerciseById", mock.Anything, customExercise.Id).Return(&archived, nil).Once()
+			h := handler.NewExerciseHandler(mockService)
+
+			req := withUser(httptest.NewRequest(http.MethodDelete, "/exercises/3", nil))
+			req.SetPathValue("exerciseId", strconv.Itoa(customExercise.Id))
+			rr := httptest.NewRecorder()
+
+			h.DeleteExercise(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			var resp api.Success
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			ex, ok := (*resp.Payload)["exercise"].(map[string]any)
+			assert.True(t, ok)
+			assert.NotNil(t, ex["archivedAt"])
+			mockService.AssertExpectations(t)
+		})
+
+		t.Run("exercise of another user 403", func(t *testing.T) {
+			mockService := new(MockExerciseService)
+			mockService.On("GetExerciseById", mock.Anything, otherExercise.Id).Return(&otherExercise, nil).Once()
+			h := handler.NewExerciseHandler(mockService)
+
+			req := withUser(httptest.NewRequest(http.MethodDelete, "/exercises/4", nil))
+			req.SetPathValue("exerciseId", strconv.Itoa(otherExercise.Id))
+			rr := httptest.NewRecorder()
+
+			h.DeleteExercise(rr, req)
+
+			assert.Equal(t, http.StatusForbidden, rr.Code)
+			mockService.AssertNotCalled(t, "DeleteExercise", mock.Anything, mock.Anything)
+		})
 	})
 }
@@ -0,0 +1,320 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util"
+	"workout-tracker-api/internal/util/helper"
+	"workout-tracker-api/internal/util/xlsx"
+	"workout-tracker-api/pkg/api"
+)
+
+// exportColumns are the columns of the csv and xlsx exports, one row per exercise plan
+var exportColumns = []string{
+	"workout_id",
+	"scheduled_date",
+	"status",
+	"comment",
+	"exercise_plan_id",
+	"position",
+	"exercise_id",
+	"exercise_name",
+	"muscle_group",
+	"sets",
+	"repetitions",
+	"weights",
+	"weight_unit",
+}
+
+type ExportHandler struct {
+	ExportService service.ExportServiceInterface
+}
+
+func NewExportHandler(es service.ExportServiceInterface) *ExportHandler {
+	return &ExportHandler{
+		ExportService: es,
+	}
+}
+
+// ExportWorkouts streams the workout history as a file download. Errors before the first byte
+// are sent as usual; after that the status is gone, so the connection is aborted instead and the
+// client sees an incomplete download rather than a file that looks whole.
+func (h *ExportHandler) ExportWorkouts(w http.ResponseWriter, r *http.Request, params api.ExportWorkoutsParams) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	contentType, ok := exportContentTypes[params.Format]
+	if !ok {
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_SETTING, "format must be csv, jsonl or xlsx"))
+		return
+	}
+
+	query := service.ExportQuery{UserId: userInfo.Id}
+	if params.From != nil {
+		query.From = *params.From
+	}
+	if params.To != nil {
+		query.To = *params.To
+	}
+
+	export, err := h.ExportService.ExportWorkouts(r.Context(), query)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to export workout plans: %w", err))
+		return
+	}
+
+	filename := fmt.Sprintf("workouts-%s.%s", time.Now().UTC().Format("20060102"), params.Format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeWorkouts(r.Context(), w, params.Format, export); err != nil {
+		log.Printf("Failed to export workout plans of user id '%v': %v", userInfo.Id, err)
+		panic(http.ErrAbortHandler)
+	}
+}
+
+var exportContentTypes = map[api.ExportFormat]string{
+	api.Csv:   "text/csv; charset=utf-8",
+	api.Jsonl: "application/x-ndjson",
+	api.Xlsx:  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// workoutWriter encodes the exported workout plans in one file format
+type workoutWriter interface {
+	Write(workout *api.ExportedWorkout) error
+	// Flush sends the workout plans written so far
+	Flush() error
+	// Close finishes the file
+	Close() error
+}
+
+// writeWorkouts writes the export page by page and flushes after each page, so the download
+// starts before the last page is read
+func writeWorkouts(ctx context.Context, w http.ResponseWriter, format api.ExportFormat, export service.WorkoutExport) error {
+	writer, err := newWorkoutWriter(w, format)
+	if err != nil {
+		return err
+	}
+
+	controller := http.NewResponseController(w)
+	for {
+		page, err := export.Next(ctx)
+		if err != nil {
+			return err
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		for i := range page {
+			if err := writer.Write(toAPIExportedWorkout(&page[i])); err != nil {
+				return fmt.Errorf("failed to write workout plan: %w", err)
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			return fmt.Errorf("failed to write workout plans: %w", err)
+		}
+		// not every ResponseWriter can flush, the data then goes out when the handler returns
+		if err := controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return fmt.Errorf("failed to send workout plans: %w", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to finish export: %w", err)
+	}
+	return nil
+}
+
+func newWorkoutWriter(w io.Writer, format api.ExportFormat) (workoutWriter, error) {
+	switch format {
+	case api.Csv:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(exportColumns); err != nil {
+			return nil, err
+		}
+		return &csvWorkoutWriter{csv: writer}, nil
+	case api.Jsonl:
+		return &jsonlWorkoutWriter{encoder: json.NewEncoder(w)}, nil
+	case api.Xlsx:
+		writer, err := xlsx.NewWriter(w, "Workouts")
+		if err != nil {
+			return nil, err
+		}
+		if err := writer.WriteHeader(exportColumns...); err != nil {
+			return nil, err
+		}
+		return &xlsxWorkoutWriter{xlsx: writer}, nil
+	}
+	return nil, fmt.Errorf("unknown export format '%s'", format)
+}
+
+type csvWorkoutWriter struct {
+	csv *csv.Writer
+}
+
+func (c *csvWorkoutWriter) Write(workout *api.ExportedWorkout) error {
+	for _, row := range exportRows(workout) {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			record[i] = csvCell(cell)
+		}
+		if err := c.csv.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *csvWorkoutWriter) Flush() error {
+	c.csv.Flush()
+	return c.csv.Error()
+}
+
+func (c *csvWorkoutWriter) Close() error {
+	return c.Flush()
+}
+
+// csvCell formats a cell of exportRows. Text starting like a formula gets a leading quote, a
+// spreadsheet would otherwise run a comment or an exercise name the user typed.
+func csvCell(cell any) string {
+	switch v := cell.(type) {
+	case nil:
+		return ""
+	case string:
+		if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+			return "'" + v
+		}
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	}
+	return fmt.Sprint(cell)
+}
+
+type jsonlWorkoutWriter struct {
+	encoder *json.Encoder
+}
+
+// Write puts the workout plan on a line of its own, Encode ends it with a newline
+func (j *jsonlWorkoutWriter) Write(workout *api.ExportedWorkout) error {
+	return j.encoder.Encode(workout)
+}
+
+func (j *jsonlWorkoutWriter) Flush() error {
+	return nil
+}
+
+func (j *jsonlWorkoutWriter) Close() error {
+	return nil
+}
+
+type xlsxWorkoutWriter struct {
+	xlsx *xlsx.Writer
+}
+
+func (x *xlsxWorkoutWriter) Write(workout *api.ExportedWorkout) error {
+	for _, row := range exportRows(workout) {
+		if err := x.xlsx.WriteRow(row...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *xlsxWorkoutWriter) Flush() error {
+	return x.xlsx.Flush()
+}
+
+func (x *xlsxWorkoutWriter) Close() error {
+	return x.xlsx.Close()
+}
+
+// exportRows lays the workout plan out in exportColumns, one row per exercise plan. A workout
+// plan without exercise plans still gets a row, with the exercise columns empty.
+func exportRows(workout *api.ExportedWorkout) [][]any {
+	var comment any
+	if workout.Comment != nil {
+		comment = *workout.Comment
+	}
+	prefix := []any{int(*workout.Id), *workout.ScheduledDate, string(*workout.Status), comment}
+
+	if len(*workout.ExercisePlans) == 0 {
+		return [][]any{append(prefix, make([]any, len(exportColumns)-len(prefix))...)}
+	}
+
+	rows := make([][]any, 0, len(*workout.ExercisePlans))
+	for _, ep := range *workout.ExercisePlans {
+		row := append(append(make([]any, 0, len(exportColumns)), prefix...),
+			int(*ep.Id),
+			*ep.Position,
+			int(*ep.ExerciseId),
+			*ep.ExerciseName,
+			string(*ep.MuscleGroup),
+			*ep.Sets,
+			*ep.Repetitions,
+			*ep.Weights,
+			string(*ep.WeightUnit),
+		)
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func toAPIExportedWorkout(workout *service.ExportedWorkout) *api.ExportedWorkout {
+	status := api.WorkoutPlanStatus(workout.Status)
+	exercisePlans := make([]api.ExportedExercisePlan, 0, len(workout.ExercisePlans))
+	for _, ep := range workout.ExercisePlans {
+		muscleGroup := api.MuscleGroup(ep.MuscleGroup)
+		weightUnit := api.WeightUnit(ep.WeightUnit)
+		exercisePlans = append(exercisePlans, api.ExportedExercisePlan{
+			Id:           util.IntTo64(ep.Id),
+			ExerciseId:   util.IntTo64(ep.ExerciseId),
+			ExerciseName: &ep.ExerciseName,
+			MuscleGroup:  &muscleGroup,
+			Position:     &ep.Position,
+			Sets:         &ep.Sets,
+			Repetitions:  &ep.Repetitions,
+			Weights:      &ep.Weights,
+			WeightUnit:   &weightUnit,
+		})
+	}
+
+	return &api.ExportedWorkout{
+		Id:            util.IntTo64(workout.Id),
+		Status:        &status,
+		ScheduledDate: &workout.ScheduledDate,
+		Comment:       workout.Comment,
+		CreatedAt:     &workout.CreatedAt,
+		UpdatedAt:     &workout.UpdatedAt,
+		ExercisePlans: &exercisePlans,
+	}
+}
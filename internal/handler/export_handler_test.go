@@ -0,0 +1,224 @@
+package handler_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/handler"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util/helper"
+	"workout-tracker-api/pkg/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockExportService implements service.ExportServiceInterface
+type MockExportService struct {
+	mock.Mock
+}
+
+func (m *MockExportService) ExportWorkouts(ctx context.Context, query service.ExportQuery) (service.WorkoutExport, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(service.WorkoutExport), args.Error(1)
+}
+
+// pagedExport hands out its pages in order, then fails with err when it is set
+type pagedExport struct {
+	pages [][]service.ExportedWorkout
+	err   error
+}
+
+func (p *pagedExport) Next(ctx context.Context) ([]service.ExportedWorkout, error) {
+	if len(p.pages) == 0 {
+		return nil, p.err
+	}
+	page := p.pages[0]
+	p.pages = p.pages[1:]
+	return page, nil
+}
+
+func TestExportHandler_ExportWorkouts(t *testing.T) {
+	const testUserID = 42
+	hongKong, _ := time.LoadLocation("Asia/Hong_Kong")
+	scheduled := time.Date(2025, 5, 5, 18, 30, 0, 0, hongKong)
+	comment := "=felt heavy"
+
+	newExport := func() *pagedExport {
+		return &pagedExport{pages: [][]service.ExportedWorkout{
+			{{
+				Id:            1,
+				Status:        service.COMPLETED,
+				ScheduledDate: scheduled,
+				Comment:       &comment,
+				CreatedAt:     scheduled,
+				UpdatedAt:     scheduled,
+				ExercisePlans: []service.ExportedExercisePlan{
+					{Id: 10, ExerciseId: 3, ExerciseName: "Squat", MuscleGroup: service.Legs, Position: 1, Sets: 5, Repetitions: 5, Weights: 102.5, WeightUnit: service.KG},
+					{Id: 11, ExerciseId: 4, ExerciseName: "Bench Press, paused", MuscleGroup: service.Chest, Position: 2, Sets: 3, Repetitions: 8, Weights: 60, WeightUnit: service.KG},
+				},
+			}},
+			{{Id: 2, Status: service.PENDING, ScheduledDate: scheduled.AddDate(0, 0, 2), CreatedAt: scheduled, UpdatedAt: scheduled}},
+		}}
+	}
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/export/workouts", nil)
+		ctx := helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: testUserID})
+		return req.WithContext(ctx)
+	}
+
+	t.Run("csv has a row per exercise plan", func(t *testing.T) {
+		mockService := new(MockExportService)
+		handlerObj := handler.NewExportHandler(mockService)
+
+		from := "2025-05-01"
+		mockService.On("ExportWorkouts", mock.Anything, service.ExportQuery{UserId: testUserID, From: from}).Return(newExport(), nil).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.ExportWorkouts(rr, newRequest(), api.ExportWorkoutsParams{Format: api.Csv, From: &from})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Regexp(t, `^attachment; filename=workouts-\d{8}\.csv$`, rr.Header().Get("Content-Disposition"))
+
+		records, err := csv.NewReader(rr.Body).ReadAll()
+		assert.NoError(t, err)
+		if assert.Len(t, records, 4) {
+			assert.Equal(t, "workout_id", records[0][0])
+			assert.Equal(t, []string{"1", "2025-05-05T18:30:00+08:00", "completed", "'=felt heavy", "10", "1", "3", "Squat", "legs", "5", "5", "102.5", "kg"}, records[1])
+			assert.Equal(t, "Bench Press, paused", records[2][7])
+			assert.Equal(t, []string{"2", "2025-05-07T18:30:00+08:00", "pending", "", "", "", "", "", "", "", "", "", ""}, records[3])
+		}
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("jsonl has a workout plan per line", func(t *testing.T) {
+		mockService := new(MockExportService)
+		handlerObj := handler.NewExportHandler(mockService)
+		mockService.On("ExportWorkouts", mock.Anything, service.ExportQuery{UserId: testUserID}).Return(newExport(), nil).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.ExportWorkouts(rr, newRequest(), api.ExportWorkoutsParams{Format: api.Jsonl})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/x-ndjson", rr.Header().Get("Content-Type"))
+		assert.Regexp(t, `\.jsonl$`, rr.Header().Get("Content-Disposition"))
+
+		lines := strings.Split(strings.TrimSuffix(rr.Body.String(), "\n"), "\n")
+		if assert.Len(t, lines, 2) {
+			var first map[string]any
+			assert.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+			assert.Equal(t, "2025-05-05T18:30:00+08:00", first["scheduledDate"])
+			plans := first["exercisePlans"].([]any)
+			assert.Equal(t, "Squat", plans[0].(map[string]any)["exerciseName"])
+			assert.Equal(t, "legs", plans[0].(map[string]any)["muscleGroup"])
+
+			var second map[string]any
+			assert.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+			assert.Nil(t, second["comment"])
+			assert.Equal(t, []any{}, second["exercisePlans"])
+		}
+	})
+
+	t.Run("xlsx is a workbook", func(t *testing.T) {
+		mockService := new(MockExportService)
+		handlerObj := handler.NewExportHandler(mockService)
+		mockService.On("ExportWorkouts", mock.Anything, service.ExportQuery{UserId: testUserID}).Return(newExport(), nil).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.ExportWorkouts(rr, newRequest(), api.ExportWorkoutsParams{Format: api.Xlsx})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", rr.Header().Get("Content-Type"))
+		assert.Regexp(t, `\.xlsx$`, rr.Header().Get("Content-Disposition"))
+
+		body := rr.Body.Bytes()
+		archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		if !assert.NoError(t, err) {
+			return
+		}
+		var sheet string
+		for _, f := range archive.File {
+			if f.Name == "xl/worksheets/sheet1.xml" {
+				rc, _ := f.Open()
+				content, _ := io.ReadAll(rc)
+				rc.Close()
+				sheet = string(content)
+			}
+		}
+		assert.Contains(t, sheet, `<c r="H2" t="inlineStr"><is><t xml:space="preserve">Squat</t></is></c>`)
+		// written as is, an inline string is never run as a formula
+		assert.Contains(t, sheet, `=felt heavy`)
+		assert.Equal(t, 4, strings.Count(sheet, "<row "))
+	})
+
+	t.Run("unknown format returns 400", func(t *testing.T) {
+		mockService := new(MockExportService)
+		handlerObj := handler.NewExportHandler(mockService)
+
+		rr := httptest.NewRecorder()
+		handlerObj.ExportWorkouts(rr, newRequest(), api.ExportWorkoutsParams{Format: "pdf"})
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Empty(t, rr.Header().Get("Content-Disposition"))
+		mockService.AssertNotCalled(t, "ExportWorkouts", mock.Anything, mock.Anything)
+	})
+
+	t.Run("validation error returns 400", func(t *testing.T) {
+		mockService := new(MockExportService)
+		handlerObj := handler.NewExportHandler(mockService)
+
+		from, to := "2025-05-10", "2025-05-01"
+		mockService.On("ExportWorkouts", mock.Anything, service.ExportQuery{UserId: testUserID, From: from, To: to}).
+			Return(nil, apperrors.NewValidationError(apperrors.INVALID_DATE, "to must be after from")).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.ExportWorkouts(rr, newRequest(), api.ExportWorkoutsParams{Format: api.Csv, From: &from, To: &to})
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Empty(t, rr.Header().Get("Content-Disposition"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("unauthorized if no user in context", func(t *testing.T) {
+		mockService := new(MockExportService)
+		handlerObj := handler.NewExportHandler(mockService)
+
+		rr := httptest.NewRecorder()
+		handlerObj.ExportWorkouts(rr, httptest.NewRequest(http.MethodGet, "/export/workouts", nil), api.ExportWorkoutsParams{Format: api.Csv})
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockService.AssertNotCalled(t, "ExportWorkouts", mock.Anything, mock.Anything)
+	})
+
+	t.Run("error while streaming aborts the response", func(t *testing.T) {
+		mockService := new(MockExportService)
+		handlerObj := handler.NewExportHandler(mockService)
+
+		export := newExport()
+		export.err = errors.New("db error")
+		export.pages = export.pages[:1]
+		mockService.On("ExportWorkouts", mock.Anything, mock.AnythingOfType("service.ExportQuery")).Return(export, nil).Once()
+
+		rr := httptest.NewRecorder()
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			handlerObj.ExportWorkouts(rr, newRequest(), api.ExportWorkoutsParams{Format: api.Csv})
+		})
+		// the first page was already sent
+		assert.Contains(t, rr.Body.String(), "Squat")
+	})
+}
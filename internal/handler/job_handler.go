@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/scheduler"
+	"workout-tracker-api/internal/util/helper"
+	"workout-tracker-api/pkg/api"
+)
+
+type JobHandler struct {
+	MissedScheduler scheduler.MissedSchedulerInterface
+}
+
+func NewJobHandler(ms scheduler.MissedSchedulerInterface) *JobHandler {
+	return &JobHandler{
+		MissedScheduler: ms,
+	}
+}
+
+// TriggerMissedWorkouts runs the missed workout job right away, skipping the leader lock since the update is idempotent
+func (h *JobHandler) TriggerMissedWorkouts(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	result, err := h.MissedScheduler.MarkMissed(r.Context())
+	if err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to run missed workout job: %w", err))
+		return
+	}
+
+	log.Printf("User %d triggered missed workout job, marked %d workout plans", userInfo.Id, result.Marked)
+
+	response := api.Success{
+		Code:    api.UPDATE,
+		Message: "successfully run missed workout job",
+		Payload: &map[string]any{
+			"job": toAPIMissedJob(result),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+func toAPIMissedJob(result *scheduler.MissedResult) *api.MissedWorkoutsJob {
+	if result == nil {
+		return nil
+	}
+
+	cutoff := result.Cutoff
+	marked := result.Marked
+
+	return &api.MissedWorkoutsJob{
+		Cutoff: &cutoff,
+		Marked: &marked,
+	}
+}
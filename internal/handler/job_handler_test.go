@@ -0,0 +1,103 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"workout-tracker-api/internal/handler"
+	"workout-tracker-api/internal/scheduler"
+	"workout-tracker-api/internal/util/helper"
+	"workout-tracker-api/pkg/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMissedScheduler implements scheduler.MissedSchedulerInterface
+type MockMissedScheduler struct {
+	mock.Mock
+}
+
+func (m *MockMissedScheduler) Start(ctx context.Context) {
+	m.Called(ctx)
+}
+
+func (m *MockMissedScheduler) RunOnce(ctx context.Context) (*scheduler.MissedResult, bool, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(*scheduler.MissedResult), args.Bool(1), args.Error(2)
+}
+
+func (m *MockMissedScheduler) MarkMissed(ctx context.Context) (*scheduler.MissedResult, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*scheduler.MissedResult), args.Error(1)
+}
+
+func TestJobHandler_TriggerMissedWorkouts(t *testing.T) {
+	const testUserID = 42
+
+	t.Run("successfully run job", func(t *testing.T) {
+		mockScheduler := new(MockMissedScheduler)
+		handlerObj := handler.NewJobHandler(mockScheduler)
+
+		cutoff := time.Date(2025, 3, 9, 12, 0, 0, 0, time.UTC)
+		mockScheduler.On("MarkMissed", mock.Anything).Return(&scheduler.MissedResult{Cutoff: cutoff, Marked: 3}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/jobs/missed-workouts", nil)
+		ctx := helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: testUserID})
+		req = req.WithContext(ctx)
+		rr := httptest.NewRecorder()
+
+		handlerObj.TriggerMissedWorkouts(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp api.Success
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Equal(t, api.UPDATE, resp.Code)
+		jobPayload, ok := (*resp.Payload)["job"].(map[string]any)
+		assert.True(t, ok)
+		assert.Equal(t, float64(3), jobPayload["marked"])
+		assert.Equal(t, cutoff.Format(time.RFC3339), jobPayload["cutoff"])
+		mockScheduler.AssertExpectations(t)
+	})
+
+	t.Run("unauthorized if no user in context", func(t *testing.T) {
+		mockScheduler := new(MockMissedScheduler)
+		handlerObj := handler.NewJobHandler(mockScheduler)
+
+		req := httptest.NewRequest(http.MethodPost, "/jobs/missed-workouts", nil)
+		rr := httptest.NewRecorder()
+
+		handlerObj.TriggerMissedWorkouts(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockScheduler.AssertNotCalled(t, "MarkMissed")
+	})
+
+	t.Run("job error returns 500", func(t *testing.T) {
+		mockScheduler := new(MockMissedScheduler)
+		handlerObj := handler.NewJobHandler(mockScheduler)
+
+		mockScheduler.On("MarkMissed", mock.Anything).Return(nil, errors.New("db error")).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/jobs/missed-workouts", nil)
+		ctx := helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: testUserID})
+		req = req.WithContext(ctx)
+		rr := httptest.NewRecorder()
+
+		handlerObj.TriggerMissedWorkouts(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		mockScheduler.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util"
+	"workout-tracker-api/internal/util/helper"
+	"workout-tracker-api/pkg/api"
+)
+
+type PerformedSetHandler struct {
+	WorkoutService      service.WorkoutServiceInterface
+	PerformedSetService service.PerformedSetServiceInterface
+}
+
+func NewPerformedSetHandler(ws service.WorkoutServiceInterface, pss service.PerformedSetServiceInterface) *PerformedSetHandler {
+	return &PerformedSetHandler{
+		WorkoutService:      ws,
+		PerformedSetService: pss,
+	}
+}
+
+// ListPerformedSets
+func (h *PerformedSetHandler) ListPerformedSets(w http.ResponseWriter, r *http.Request) {
+	wpId, err := doubleAuth(w, r, h.WorkoutService)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	epId, err := pathID(w, r, "exercisePlanId")
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	epLog, err := h.PerformedSetService.ListPerformedSets(r.Context(), wpId, epId)
+	if err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to fetch performed sets: %w", err))
+		return
+	}
+
+	var performedSets []api.PerformedSet
+	for _, ps := range epLog.PerformedSets {
+		apiPS := toAPIPerformedSet(&ps)
+		performedSets = append(performedSets, *apiPS)
+	}
+
+	response := api.Success{
+		Code:    api.FETCH,
+		Message: "successfully fetch performed sets",
+		Payload: &map[string]any{
+			"exercisePlan":  toAPIExercisePlan(&epLog.ExercisePlan),
+			"performedSets": performedSets,
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// LogPerformedSet
+func (h *PerformedSetHandler) LogPerformedSet(w http.ResponseWriter, r *http.Request) {
+	wpId, err := doubleAuth(w, r, h.WorkoutService)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	epId, err := pathID(w, r, "exercisePlanId")
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	var req api.LogPerformedSetJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding performed set request: %v", err)
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	ps, err := h.PerformedSetService.LogPerformedSet(r.Context(), wpId, epId, service.PerformedSetCreate{
+		SetNumber:   req.SetNumber,
+		Repetitions: req.Repetitions,
+		Weights:     req.Weights,
+		WeightUnit:  service.WeightUnit(req.WeightUnit),
+		RPE:         req.Rpe,
+		CompletedAt: req.CompletedAt,
+	})
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to log performed set: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.CREATED,
+		Message: "successfully log performed set",
+		Payload: &map[string]any{
+			"performedSet": toAPIPerformedSet(ps),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusCreated, &response)
+}
+
+// UpdatePerformedSet
+func (h *PerformedSetHandler) UpdatePerformedSet(w http.ResponseWriter, r *http.Request) {
+	wpId, err := doubleAuth(w, r, h.WorkoutService)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	epId, err := pathID(w, r, "exercisePlanId")
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	setId, err := pathID(w, r, "setId")
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	var req api.UpdatePerformedSetJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding performed set request: %v", err)
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	ps, err := h.PerformedSetService.UpdatePerformedSet(r.Context(), wpId, epId, service.PerformedSetUpdate{
+		Id:          setId,
+		Repetitions: req.Repetitions,
+		Weights:     req.Weights,
+		WeightUnit:  service.WeightUnit(req.WeightUnit),
+		RPE:         req.Rpe,
+		CompletedAt: req.CompletedAt,
+	})
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to update performed set: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.UPDATE,
+		Message: "successfully update performed set",
+		Payload: &map[string]any{
+			"performedSet": toAPIPerformedSet(ps),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// DeletePerformedSet
+func (h *PerformedSetHandler) DeletePerformedSet(w http.ResponseWriter, r *http.Request) {
+	wpId, err := doubleAuth(w, r, h.WorkoutService)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	epId, err := pathID(w, r, "exercisePlanId")
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	setId, err := pathID(w, r, "setId")
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	err = h.PerformedSetService.DeletePerformedSet(r.Context(), wpId, epId, setId)
+	if err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to delete performed set: %w", err))
+		return
+	}
+
+	helper.SendSuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// pathID reads a numeric id from the request path, answering the request itself when it is missing or invalid
+func pathID(w http.ResponseWriter, r *http.Request, name string) (int, error) {
+	value := r.PathValue(name)
+	if value == "" {
+		err := apperrors.NewValidationError(apperrors.INVALID_INPUT, fmt.Sprintf("%s not set in path", name))
+		helper.SendErrorResponse(w, err)
+		return -1, err
+	}
+
+	id, err := strconv.Atoi(value)
+	if err != nil {
+		err := apperrors.NewValidationError(apperrors.INVALID_ID, fmt.Sprintf("%s not valid", name))
+		helper.SendErrorResponse(w, err)
+		return -1, err
+	}
+
+	return id, nil
+}
+
+func toAPIPerformedSet(ps *service.PerformedSet) *api.PerformedSet {
+	if ps == nil {
+		return nil
+	}
+
+	completedAt := ps.CompletedAt
+
+	return &api.PerformedSet{
+		Id:             util.IntTo64(ps.Id),
+		ExercisePlanId: util.IntTo64(ps.ExercisePlanId),
+		SetNumber:      &ps.SetNumber,
+		Repetitions:    &ps.Repetitions,
+		Weights:        &ps.Weights,
+		WeightUnit:     (*api.WeightUnit)(&ps.WeightUnit),
+		Rpe:            ps.RPE,
+		CompletedAt:    &completedAt,
+	}
+}
@@ -0,0 +1,314 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/handler"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util/helper"
+	"workout-tracker-api/pkg/api"
+)
+
+// MockPerformedSetService is a mock implementation of service.PerformedSetServiceInterface
+type MockPerformedSetService struct {
+	mock.Mock
+}
+
+func (m *MockPerformedSetService) LogPerformedSet(ctx context.Context, workoutId int, exercisePlanId int, data service.PerformedSetCreate) (*service.PerformedSet, error) {
+	args := m.Called(ctx, workoutId, exercisePlanId, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.PerformedSet), args.Error(1)
+}
+
+func (m *MockPerformedSetService) ListPerformedSets(ctx context.Context, workoutId int, exercisePlanId int) (*service.ExercisePlanLog, error) {
+	args := m.Called(ctx, workoutId, exercisePlanId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ExercisePlanLog), args.Error(1)
+}
+
+func (m *MockPerformedSetService) UpdatePerformedSet(ctx context.Context, workoutId int, exercisePlanId int, data service.PerformedSetUpdate) (*service.PerformedSet, error) {
+	args := m.Called(ctx, workoutId, exercisePlanId, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.PerformedSet), args.Error(1)
+}
+
+func (m *MockPerformedSetService) DeletePerformedSet(ctx context.Context, workoutId int, exercisePlanId int, setId int) error {
+	args := m.Called(ctx, workoutId, exercisePlanId, setId)
+	return args.Error(0)
+}
+
+func TestPerformedSetHandler(t *testing.T) {
+	testUserID := 123
+	workoutID := 1
+	exercisePlanID := 5
+	setID := 2
+	completedAt := time.Now().UTC().Truncate(time.Second)
+
+	existingWorkout := &service.WorkoutPlan{
+		Id:     workoutID,
+		UserId: testUserID,
+		Status: service.PENDING,
+	}
+
+	createRequest := func(method string, url string, withSetID bool, body []byte) *http.Request {
+		req := httptest.NewRequest(method, url, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.SetPathValue("workoutId", strconv.Itoa(workoutID))
+		req.SetPathValue("exercisePlanId", strconv.Itoa(exercisePlanID))
+		if withSetID {
+			req.SetPathValue("setId", strconv.Itoa(setID))
+		}
+
+		userInfo := helper.UserInfo{
+			Email: "test@example.com",
+			Name:  "Test User",
+			Id:    testUserID,
+		}
+		ctx := context.WithValue(req.Context(), helper.UserContextKey, &userInfo)
+		return req.WithContext(ctx)
+	}
+
+	setsURL := fmt.Sprintf("/workouts/%d/exercise-plans/%d/sets", workoutID, exercisePlanID)
+	setURL := fmt.Sprintf("%s/%d", setsURL, setID)
+
+	t.Run("ListPerformedSets", func(t *testing.T) {
+		t.Run("Successful listing", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			mockPSService := new(MockPerformedSetService)
+			psHandler := handler.NewPerformedSetHandler(mockWorkoutService, mockPSService)
+
+			mockWorkoutService.On("GetWorkoutById", mock.Anything, workoutID).Return(existingWorkout, nil).Once()
+			mockPSService.On("ListPerformedSets", mock.Anything, workoutID, exercisePlanID).Return(&service.ExercisePlanLog{
+				ExercisePlan: service.ExercisePlan{Id: exercisePlanID, ExerciseId: 10, WorkoutPlanId: workoutID, Sets: 3, Repetitions: 10, Weights: 50, WeightUnit: service.KG},
+				PerformedSets: []service.PerformedSet{
+					{Id: 1, ExercisePlanId: exercisePlanID, SetNumber: 1, Repetitions: 10, Weights: 50, WeightUnit: service.KG, CompletedAt: completedAt},
+				},
+			}, nil).Once()
+
+			req := createRequest(http.MethodGet, setsURL, false, nil)
+			rr := httptest.NewRecorder()
+
+			psHandler.ListPerformedSets(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			var resp api.Success
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, api.FETCH, resp.Code)
+			assert.Contains(t, *resp.Payload, "exercisePlan")
+			assert.Len(t, (*resp.Payload)["performedSets"], 1)
+			mockPSService.AssertExpectations(t)
+		})
+
+		t.Run("Forbidden (user does not own workout)", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			mockPSService := new(MockPerformedSetService)
+			psHandler := handler.NewPerformedSetHandler(mockWorkoutService, mockPSService)
+
+			mockWorkoutService.On("GetWorkoutById", mock.Anything, workoutID).Return(&service.WorkoutPlan{Id: workoutID, UserId: 9999}, nil).Once()
+
+			req := createRequest(http.MethodGet, setsURL, false, nil)
+			rr := httptest.NewRecorder()
+
+			psHandler.ListPerformedSets(rr, req)
+
+			assert.Equal(t, http.StatusForbidden, rr.Code)
+			mockPSService.AssertNotCalled(t, "ListPerformedSets")
+		})
+	})
+
+	t.Run("LogPerformedSet", func(t *testing.T) {
+		rpe := float32(8)
+		reqBody := api.LogPerformedSetJSONRequestBody{
+			Repetitions: 10,
+			Weights:     50,
+			WeightUnit:  api.WeightUnitKg,
+			Rpe:         &rpe,
+		}
+		serviceInput := service.PerformedSetCreate{
+			Repetitions: 10,
+			Weights:     50,
+			WeightUnit:  service.KG,
+			RPE:         &rpe,
+		}
+
+		t.Run("Successful log", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			mockPSService := new(MockPerformedSetService)
+			psHandler := handler.NewPerformedSetHandler(mockWorkoutService, mockPSService)
+
+			mockWorkoutService.On("GetWorkoutById", mock.Anything, workoutID).Return(existingWorkout, nil).Once()
+			mockPSService.On("LogPerformedSet", mock.Anything, workoutID, exercisePlanID, serviceInput).Return(&service.PerformedSet{
+				Id: 1, ExercisePlanId: exercisePlanID, SetNumber: 1, Repetitions: 10, Weights: 50, WeightUnit: service.KG, RPE: &rpe, CompletedAt: completedAt,
+			}, nil).Once()
+
+			body, _ := json.Marshal(reqBody)
+			req := createRequest(http.MethodPost, setsURL, false, body)
+			rr := httptest.NewRecorder()
+
+			psHandler.LogPerformedSet(rr, req)
+
+			assert.Equal(t, http.StatusCreated, rr.Code)
+			var resp api.Success
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, api.CREATED, resp.Code)
+			assert.Equal(t, "successfully log performed set", resp.Message)
+			mockPSService.AssertExpectations(t)
+		})
+
+		t.Run("Invalid JSON body", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			mockPSService := new(MockPerformedSetService)
+			psHandler := handler.NewPerformedSetHandler(mockWorkoutService, mockPSService)
+
+			mockWorkoutService.On("GetWorkoutById", mock.Anything, workoutID).Return(existingWorkout, nil).Once()
+
+			req := createRequest(http.MethodPost, setsURL, false, []byte(`{"repetitions": "ten"}`))
+			rr := httptest.NewRecorder()
+
+			psHandler.LogPerformedSet(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			var resp api.Error
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, string(apperrors.INVALID_INPUT), resp.Code)
+			mockPSService.AssertNotCalled(t, "LogPerformedSet")
+		})
+
+		t.Run("Exercise plan not in workout", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			mockPSService := new(MockPerformedSetService)
+			psHandler := handler.NewPerformedSetHandler(mockWorkoutService, mockPSService)
+
+			mockWorkoutService.On("GetWorkoutById", mock.Anything, workoutID).Return(existingWorkout, nil).Once()
+			mockPSService.On("LogPerformedSet", mock.Anything, workoutID, exercisePlanID, serviceInput).Return(nil, apperrors.ErrNotFound).Once()
+
+			body, _ := json.Marshal(reqBody)
+			req := createRequest(http.MethodPost, setsURL, false, body)
+			rr := httptest.NewRecorder()
+
+			psHandler.LogPerformedSet(rr, req)
+
+			assert.Equal(t, http.StatusNotFound, rr.Code)
+			var resp api.Error
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, string(apperrors.NOT_FOUND), resp.Code)
+		})
+	})
+
+	t.Run("UpdatePerformedSet", func(t *testing.T) {
+		t.Run("Successful update", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			mockPSService := new(MockPerformedSetService)
+			psHandler := handler.NewPerformedSetHandler(mockWorkoutService, mockPSService)
+
+			reqBody := api.UpdatePerformedSetJSONRequestBody{
+				Repetitions: 8,
+				Weights:     110,
+				WeightUnit:  api.WeightUnitLbs,
+			}
+			serviceInput := service.PerformedSetUpdate{
+				Id:          setID,
+				Repetitions: 8,
+				Weights:     110,
+				WeightUnit:  service.LBS,
+			}
+
+			mockWorkoutService.On("GetWorkoutById", mock.Anything, workoutID).Return(existingWorkout, nil).Once()
+			mockPSService.On("UpdatePerformedSet", mock.Anything, workoutID, exercisePlanID, serviceInput).Return(&service.PerformedSet{
+				Id: setID, ExercisePlanId: exercisePlanID, SetNumber: 2, Repetitions: 8, Weights: 110, WeightUnit: service.LBS, CompletedAt: completedAt,
+			}, nil).Once()
+
+			body, _ := json.Marshal(reqBody)
+			req := createRequest(http.MethodPut, setURL, true, body)
+			rr := httptest.NewRecorder()
+
+			psHandler.UpdatePerformedSet(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			var resp api.Success
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, api.UPDATE, resp.Code)
+			mockPSService.AssertExpectations(t)
+		})
+
+		t.Run("Invalid set id", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			mockPSService := new(MockPerformedSetService)
+			psHandler := handler.NewPerformedSetHandler(mockWorkoutService, mockPSService)
+
+			mockWorkoutService.On("GetWorkoutById", mock.Anything, workoutID).Return(existingWorkout, nil).Once()
+
+			req := createRequest(http.MethodPut, setURL, false, []byte(`{}`))
+			req.SetPathValue("setId", "abc")
+			rr := httptest.NewRecorder()
+
+			psHandler.UpdatePerformedSet(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			var resp api.Error
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, string(apperrors.INVALID_ID), resp.Code)
+			mockPSService.AssertNotCalled(t, "UpdatePerformedSet")
+		})
+	})
+
+	t.Run("DeletePerformedSet", func(t *testing.T) {
+		t.Run("Successful deletion", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			mockPSService := new(MockPerformedSetService)
+			psHandler := handler.NewPerformedSetHandler(mockWorkoutService, mockPSService)
+
+			mockWorkoutService.On("GetWorkoutById", mock.Anything, workoutID).Return(existingWorkout, nil).Once()
+			mockPSService.On("DeletePerformedSet", mock.Anything, workoutID, exercisePlanID, setID).Return(nil).Once()
+
+			req := createRequest(http.MethodDelete, setURL, true, nil)
+			rr := httptest.NewRecorder()
+
+			psHandler.DeletePerformedSet(rr, req)
+
+			assert.Equal(t, http.StatusNoContent, rr.Code)
+			mockPSService.AssertExpectations(t)
+		})
+
+		t.Run("Set not found", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			mockPSService := new(MockPerformedSetService)
+			psHandler := handler.NewPerformedSetHandler(mockWorkoutService, mockPSService)
+
+			mockWorkoutService.On("GetWorkoutById", mock.Anything, workoutID).Return(existingWorkout, nil).Once()
+			mockPSService.On("DeletePerformedSet", mock.Anything, workoutID, exercisePlanID, setID).Return(apperrors.ErrNotFound).Once()
+
+			req := createRequest(http.MethodDelete, setURL, true, nil)
+			rr := httptest.NewRecorder()
+
+			psHandler.DeletePerformedSet(rr, req)
+
+			assert.Equal(t, http.StatusNotFound, rr.Code)
+			mockPSService.AssertExpectations(t)
+		})
+	})
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -9,19 +10,23 @@ import (
 	"workout-tracker-api/internal/util"
 	"workout-tracker-api/internal/util/helper"
 	"workout-tracker-api/pkg/api"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 type ReportHandler struct {
 	ReportService service.ReportServiceInterface
+	PRService     service.PersonalRecordServiceInterface
 }
 
-func NewReportHandler(rs service.ReportServiceInterface) *ReportHandler {
+func NewReportHandler(rs service.ReportServiceInterface, prs service.PersonalRecordServiceInterface) *ReportHandler {
 	return &ReportHandler{
 		ReportService: rs,
+		PRService:     prs,
 	}
 }
 
-func (rc *ReportHandler) ReportProgress(w http.ResponseWriter, r *http.Request) {
+func (rc *ReportHandler) ReportProgress(w http.ResponseWriter, r *http.Request, params api.ReportProgressParams) {
 	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
 
 	if !ok {
@@ -31,9 +36,17 @@ func (rc *ReportHandler) ReportProgress(w http.ResponseWriter, r *http.Request)
 
 	}
 
-	serviceProgress, err := rc.ReportService.Progress(r.Context(), userInfo.Id)
+	serviceProgress, err := rc.ReportService.Progress(r.Context(), service.ProgressQuery{
+		UserId:      userInfo.Id,
+		ReportRange: reportRange(params.From, params.To, params.CompareTo),
+	})
 
 	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
 
 		helper.SendErrorResponse(w, fmt.Errorf("failed to fetch progress: %w", err))
 		return
@@ -53,12 +66,552 @@ func (rc *ReportHandler) ReportProgress(w http.ResponseWriter, r *http.Request)
 
 }
 
+func (rc *ReportHandler) ReportPersonalRecords(w http.ResponseWriter, r *http.Request, params api.ReportPersonalRecordsParams) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	var exerciseId *int
+	if params.ExerciseId != nil {
+		if *params.ExerciseId <= 0 {
+			helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_ID, "not a valid exercise id"))
+			return
+		}
+		id := int(*params.ExerciseId)
+		exerciseId = &id
+	}
+
+	records, err := rc.PRService.ListPersonalRecords(r.Context(), userInfo.Id, exerciseId)
+	if err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to fetch personal records: %w", err))
+		return
+	}
+
+	apiRecords := make([]api.PersonalRecord, 0, len(records))
+	for _, pr := range records {
+		apiRecords = append(apiRecords, toAPIPersonalRecord(pr))
+	}
+
+	response := api.Success{
+		Code:    api.FETCH,
+		Message: "successfully fetch personal records",
+		Payload: &map[string]interface{}{
+			"personalRecords": apiRecords,
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+func (rc *ReportHandler) ReportVolume(w http.ResponseWriter, r *http.Request, params api.ReportVolumeParams) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	query := service.VolumeQuery{
+		UserId:      userInfo.Id,
+		ReportRange: reportRange(&params.From, &params.To, params.CompareTo),
+	}
+	if params.Bucket != nil {
+		query.Bucket = service.Bucket(*params.Bucket)
+	}
+	if params.Unit != nil {
+		unit := service.WeightUnit(*params.Unit)
+		query.Unit = &unit
+	}
+
+	report, err := rc.ReportService.Volume(r.Context(), query)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to fetch volume report: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.FETCH,
+		Message: "successfully fetch volume report",
+		Payload: &map[string]interface{}{
+			"volume": toAPIVolumeReport(report),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+func (rc *ReportHandler) ReportConsistency(w http.ResponseWriter, r *http.Request, params api.ReportConsistencyParams) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	query := service.ConsistencyQuery{
+		UserId:      userInfo.Id,
+		ReportRange: reportRange(params.From, params.To, params.CompareTo),
+	}
+	if params.WeeklyTarget != nil {
+		if *params.WeeklyTarget <= 0 {
+			helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_SETTING, "weekly target must be between 1 and 21"))
+			return
+		}
+		query.WeeklyTarget = *params.WeeklyTarget
+	}
+
+	report, err := rc.ReportService.Consistency(r.Context(), query)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to fetch consistency report: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.FETCH,
+		Message: "successfully fetch consistency report",
+		Payload: &map[string]interface{}{
+			"consistency": toAPIConsistencyReport(report),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+func (rc *ReportHandler) ReportStrength(w http.ResponseWriter, r *http.Request, params api.ReportStrengthParams) {
+	exerciseId, err := pathID(w, r, "exerciseId")
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	query := service.StrengthQuery{
+		UserId:      userInfo.Id,
+		ExerciseId:  exerciseId,
+		ReportRange: reportRange(params.From, params.To, params.CompareTo),
+	}
+	if params.Formula != nil {
+		query.Formula = service.OneRepMaxFormula(*params.Formula)
+	}
+	if params.Unit != nil {
+		unit := service.WeightUnit(*params.Unit)
+		query.Unit = &unit
+	}
+	if params.TrendWeeks != nil {
+		if *params.TrendWeeks <= 0 {
+			helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_SETTING, "trend weeks must be between 1 and 104"))
+			return
+		}
+		query.TrendWeeks = *params.TrendWeeks
+	}
+
+	report, err := rc.ReportService.Strength(r.Context(), query)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) || errors.Is(err, apperrors.ErrNotFound) || errors.Is(err, apperrors.ErrForbidden) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to fetch strength report: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.FETCH,
+		Message: "successfully fetch strength report",
+		Payload: &map[string]interface{}{
+			"strength": toAPIStrengthReport(report),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+func (rc *ReportHandler) ReportMuscleBalance(w http.ResponseWriter, r *http.Request, params api.ReportMuscleBalanceParams) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	query := service.MuscleBalanceQuery{
+		UserId:      userInfo.Id,
+		ReportRange: reportRange(params.From, params.To, params.CompareTo),
+	}
+	if params.Unit != nil {
+		unit := service.WeightUnit(*params.Unit)
+		query.Unit = &unit
+	}
+
+	report, err := rc.ReportService.MuscleBalance(r.Context(), query)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to fetch muscle balance report: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.FETCH,
+		Message: "successfully fetch muscle balance report",
+		Payload: &map[string]interface{}{
+			"muscleBalance": toAPIMuscleBalanceReport(report),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+func toAPIMuscleBalanceReport(report *service.MuscleBalanceReport) *api.MuscleBalanceReport {
+	if report == nil {
+		return nil
+	}
+
+	muscleGroups := make([]api.MuscleGroupBalance, 0, len(report.MuscleGroups))
+	for _, mg := range report.MuscleGroups {
+		muscleGroup := api.MuscleGroup(mg.MuscleGroup)
+		muscleGroups = append(muscleGroups, api.MuscleGroupBalance{
+			MuscleGroup:  &muscleGroup,
+			Current:      toAPIVolumeTotals(mg.Current),
+			Previous:     toAPIVolumeTotals(mg.Previous),
+			SetsChange:   toAPIDelta(&mg.SetsChange),
+			VolumeChange: toAPIDelta(&mg.VolumeChange),
+		})
+	}
+
+	ratios := make([]api.BalanceRatio, 0, len(report.Ratios))
+	for _, ratio := range report.Ratios {
+		name := api.BalancePair(ratio.Name)
+		ratios = append(ratios, api.BalanceRatio{
+			Name:          &name,
+			LeftSets:      &ratio.LeftSets,
+			RightSets:     &ratio.RightSets,
+			Ratio:         ratio.Ratio,
+			PreviousRatio: ratio.PreviousRatio,
+			MaxRatio:      &ratio.MaxRatio,
+			Imbalanced:    &ratio.Imbalanced,
+		})
+	}
+
+	unit := api.ReportUnit(report.Unit)
+	return &api.MuscleBalanceReport{
+		From:         &report.From,
+		To:           &report.To,
+		Comparison:   toAPIComparedPeriod(report.Comparison),
+		Unit:         &unit,
+		MuscleGroups: &muscleGroups,
+		Ratios:       &ratios,
+	}
+}
+
+// reportRange passes the range on as it was sent, the service reads it in the user's time zone
+func reportRange(from *string, to *string, compareTo *api.ReportCompareTo) service.ReportRange {
+	var result service.ReportRange
+	if from != nil {
+		result.From = *from
+	}
+	if to != nil {
+		result.To = *to
+	}
+	if compareTo != nil {
+		result.CompareTo = service.CompareTo(*compareTo)
+	}
+	return result
+}
+
+func toAPIComparedPeriod(period service.ComparedPeriod) *api.ComparedPeriod {
+	compareTo := api.ReportCompareTo(period.CompareTo)
+	return &api.ComparedPeriod{
+		CompareTo: &compareTo,
+		From:      &period.From,
+		To:        &period.To,
+	}
+}
+
+func toAPIDelta(delta *service.Delta) *api.Delta {
+	if delta == nil {
+		return nil
+	}
+	return &api.Delta{
+		Previous: &delta.Previous,
+		Change:   &delta.Change,
+		Percent:  delta.Percent,
+	}
+}
+
+func toAPIVolumeTotals(totals service.VolumeTotals) *api.VolumeTotals {
+	return &api.VolumeTotals{
+		Sets:        &totals.Sets,
+		Repetitions: &totals.Repetitions,
+		Volume:      &totals.Volume,
+	}
+}
+
+func toAPIStrengthReport(report *service.StrengthReport) *api.StrengthReport {
+	if report == nil {
+		return nil
+	}
+
+	points := make([]api.StrengthPoint, 0, len(report.Points))
+	for _, point := range report.Points {
+		points = append(points, toAPIStrengthPoint(point))
+	}
+
+	formula := api.OneRepMaxFormula(report.Formula)
+	unit := api.ReportUnit(report.Unit)
+	result := &api.StrengthReport{
+		ExerciseId: util.IntTo64(report.ExerciseId),
+		Name:       &report.Name,
+		From:       &report.From,
+		To:         &report.To,
+		Formula:    &formula,
+		Unit:       &unit,
+		Points:     &points,
+	}
+	if report.Best != nil {
+		best := toAPIStrengthPoint(*report.Best)
+		result.Best = &best
+	}
+	if report.Trend != nil {
+		result.Trend = &api.StrengthTrend{
+			Weeks:         &report.Trend.Weeks,
+			Points:        &report.Trend.Points,
+			SlopePerWeek:  &report.Trend.SlopePerWeek,
+			ChangePercent: &report.Trend.ChangePercent,
+		}
+	}
+	if report.Comparison != nil {
+		period := toAPIComparedPeriod(report.Comparison.ComparedPeriod)
+		result.Comparison = &api.StrengthComparison{
+			CompareTo:              period.CompareTo,
+			From:                   period.From,
+			To:                     period.To,
+			Workouts:               toAPIDelta(&report.Comparison.Workouts),
+			BestEstimatedOneRepMax: toAPIDelta(report.Comparison.BestEstimatedOneRepMax),
+		}
+	}
+	return result
+}
+
+func toAPIStrengthPoint(point service.StrengthPoint) api.StrengthPoint {
+	return api.StrengthPoint{
+		Date:               &point.Date,
+		WorkoutPlanId:      util.IntTo64(point.WorkoutPlanId),
+		Weight:             &point.Weight,
+		Repetitions:        &point.Repetitions,
+		EstimatedOneRepMax: &point.EstimatedOneRepMax,
+		RollingBest:        &point.RollingBest,
+	}
+}
+
+func toAPIConsistencyReport(report *service.ConsistencyReport) *api.ConsistencyReport {
+	if report == nil {
+		return nil
+	}
+
+	weeks := make([]api.ConsistencyWeek, 0, len(report.Weeks))
+	for _, week := range report.Weeks {
+		weeks = append(weeks, api.ConsistencyWeek{
+			Start:     &openapi_types.Date{Time: week.Start},
+			Scheduled: &week.Scheduled,
+			Completed: &week.Completed,
+			Missed:    &week.Missed,
+			Pending:   &week.Pending,
+			Rate:      week.Rate,
+			TargetMet: &week.TargetMet,
+		})
+	}
+
+	months := make([]api.AdherencePeriod, 0, len(report.Months))
+	for _, month := range report.Months {
+		months = append(months, api.AdherencePeriod{
+			Start:     &openapi_types.Date{Time: month.Start},
+			Scheduled: &month.Scheduled,
+			Completed: &month.Completed,
+			Missed:    &month.Missed,
+			Pending:   &month.Pending,
+			Rate:      month.Rate,
+		})
+	}
+
+	days := make([]api.ConsistencyDay, 0, len(report.Days))
+	for _, day := range report.Days {
+		days = append(days, api.ConsistencyDay{
+			Date:      &openapi_types.Date{Time: day.Date},
+			Completed: &day.Completed,
+		})
+	}
+
+	return &api.ConsistencyReport{
+		From:          &report.From,
+		To:            &report.To,
+		TimeZone:      &report.TimeZone,
+		WeeklyTarget:  &report.WeeklyTarget,
+		CurrentStreak: &report.CurrentStreak,
+		LongestStreak: &report.LongestStreak,
+		Adherence: &api.AdherenceTotals{
+			Scheduled: &report.Adherence.Scheduled,
+			Completed: &report.Adherence.Completed,
+			Missed:    &report.Adherence.Missed,
+			Pending:   &report.Adherence.Pending,
+			Rate:      report.Adherence.Rate,
+		},
+		Weeks:      &weeks,
+		Months:     &months,
+		Days:       &days,
+		Comparison: toAPIConsistencyComparison(report.Comparison),
+	}
+}
+
+func toAPIConsistencyComparison(comparison *service.ConsistencyComparison) *api.ConsistencyComparison {
+	if comparison == nil {
+		return nil
+	}
+	period := toAPIComparedPeriod(comparison.ComparedPeriod)
+	return &api.ConsistencyComparison{
+		CompareTo: period.CompareTo,
+		From:      period.From,
+		To:        period.To,
+		Scheduled: toAPIDelta(&comparison.Scheduled),
+		Completed: toAPIDelta(&comparison.Completed),
+		Missed:    toAPIDelta(&comparison.Missed),
+		Rate:      toAPIDelta(comparison.Rate),
+	}
+}
+
+func toAPIVolumeReport(report *service.VolumeReport) *api.VolumeReport {
+	if report == nil {
+		return nil
+	}
+
+	periods := make([]api.VolumePeriod, 0, len(report.Periods))
+	for _, period := range report.Periods {
+		exercises := make([]api.ExerciseVolume, 0, len(period.Exercises))
+		for _, ex := range period.Exercises {
+			muscleGroup := api.MuscleGroup(ex.MuscleGroup)
+			exercises = append(exercises, api.ExerciseVolume{
+				ExerciseId:  util.IntTo64(ex.ExerciseId),
+				Name:        &ex.Name,
+				MuscleGroup: &muscleGroup,
+				Sets:        &ex.Sets,
+				Repetitions: &ex.Repetitions,
+				Volume:      &ex.Volume,
+			})
+		}
+
+		muscleGroups := make([]api.MuscleGroupVolume, 0, len(period.MuscleGroups))
+		for _, mg := range period.MuscleGroups {
+			muscleGroup := api.MuscleGroup(mg.MuscleGroup)
+			muscleGroups = append(muscleGroups, api.MuscleGroupVolume{
+				MuscleGroup: &muscleGroup,
+				Sets:        &mg.Sets,
+				Repetitions: &mg.Repetitions,
+				Volume:      &mg.Volume,
+			})
+		}
+
+		periods = append(periods, api.VolumePeriod{
+			Start:        &period.Start,
+			Sets:         &period.Sets,
+			Repetitions:  &period.Repetitions,
+			Volume:       &period.Volume,
+			Exercises:    &exercises,
+			MuscleGroups: &muscleGroups,
+		})
+	}
+
+	bucket := api.VolumeBucket(report.Bucket)
+	unit := api.ReportUnit(report.Unit)
+	result := &api.VolumeReport{
+		From:     &report.From,
+		To:       &report.To,
+		TimeZone: &report.TimeZone,
+		Bucket:   &bucket,
+		Unit:     &unit,
+		Totals:   toAPIVolumeTotals(report.Totals),
+		Periods:  &periods,
+	}
+	if report.Comparison != nil {
+		period := toAPIComparedPeriod(report.Comparison.ComparedPeriod)
+		result.Comparison = &api.VolumeComparison{
+			CompareTo:   period.CompareTo,
+			From:        period.From,
+			To:          period.To,
+			Sets:        toAPIDelta(&report.Comparison.Sets),
+			Repetitions: toAPIDelta(&report.Comparison.Repetitions),
+			Volume:      toAPIDelta(&report.Comparison.Volume),
+		}
+	}
+	return result
+}
+
+func toAPIPersonalRecord(pr service.PersonalRecord) api.PersonalRecord {
+	kind := api.PersonalRecordKind(pr.Kind)
+	value := pr.Value
+	achievedAt := pr.AchievedAt
+	return api.PersonalRecord{
+		Id:            util.IntTo64(pr.Id),
+		ExerciseId:    util.IntTo64(pr.ExerciseId),
+		WorkoutPlanId: util.IntTo64(pr.WorkoutPlanId),
+		Kind:          &kind,
+		Value:         &value,
+		WeightKg:      pr.WeightKg,
+		PreviousValue: pr.PreviousValue,
+		AchievedAt:    &achievedAt,
+	}
+}
+
 func toAPIProgress(progress *service.ProgressStatus) *api.Progress {
 	if progress == nil {
 		return nil
 	}
-	return &api.Progress{
+	result := &api.Progress{
+		From:              progress.From,
+		To:                progress.To,
 		CompletedWorkouts: util.IntTo64(progress.CompleteWorkouts),
 		TotalWorkouts:     util.IntTo64(progress.TotalWorkouts),
 	}
+	if progress.Comparison != nil {
+		period := toAPIComparedPeriod(progress.Comparison.ComparedPeriod)
+		result.Comparison = &api.ProgressComparison{
+			CompareTo:         period.CompareTo,
+			From:              period.From,
+			To:                period.To,
+			CompletedWorkouts: toAPIDelta(&progress.Comparison.CompletedWorkouts),
+			TotalWorkouts:     toAPIDelta(&progress.Comparison.TotalWorkouts),
+		}
+	}
+	return result
 }
@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+	"workout-tracker-api/internal/apperrors"
 	"workout-tracker-api/internal/handler"
 	"workout-tracker-api/internal/service"
 	"workout-tracker-api/internal/util/helper"
@@ -21,26 +23,79 @@ type MockReportService struct {
 	mock.Mock
 }
 
-func (m *MockReportService) Progress(ctx context.Context, userID int) (*service.ProgressStatus, error) {
-	args := m.Called(ctx, userID)
+func (m *MockReportService) Progress(ctx context.Context, query service.ProgressQuery) (*service.ProgressStatus, error) {
+	args := m.Called(ctx, query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*service.ProgressStatus), args.Error(1)
 }
 
+func (m *MockReportService) Volume(ctx context.Context, query service.VolumeQuery) (*service.VolumeReport, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.VolumeReport), args.Error(1)
+}
+
+func (m *MockReportService) Consistency(ctx context.Context, query service.ConsistencyQuery) (*service.ConsistencyReport, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ConsistencyReport), args.Error(1)
+}
+
+func (m *MockReportService) Strength(ctx context.Context, query service.StrengthQuery) (*service.StrengthReport, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.StrengthReport), args.Error(1)
+}
+
+func (m *MockReportService) MuscleBalance(ctx context.Context, query service.MuscleBalanceQuery) (*service.MuscleBalanceReport, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.MuscleBalanceReport), args.Error(1)
+}
+
+// MockPersonalRecordService implements service.PersonalRecordServiceInterface
+type MockPersonalRecordService struct {
+	mock.Mock
+}
+
+func (m *MockPersonalRecordService) DetectPersonalRecords(ctx context.Context, workoutId int) ([]service.PersonalRecord, error) {
+	args := m.Called(ctx, workoutId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.PersonalRecord), args.Error(1)
+}
+
+func (m *MockPersonalRecordService) ListPersonalRecords(ctx context.Context, userId int, exerciseId *int) ([]service.PersonalRecord, error) {
+	args := m.Called(ctx, userId, exerciseId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.PersonalRecord), args.Error(1)
+}
+
 func TestReportHandler_ReportProgress(t *testing.T) {
 	const testUserID = 42
 
 	t.Run("successfully fetch progress", func(t *testing.T) {
 		mockService := new(MockReportService)
-		handlerObj := handler.NewReportHandler(mockService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
 
 		progress := &service.ProgressStatus{
 			CompleteWorkouts: 5,
 			TotalWorkouts:    10,
 		}
-		mockService.On("Progress", mock.Anything, testUserID).Return(progress, nil).Once()
+		mockService.On("Progress", mock.Anything, service.ProgressQuery{UserId: testUserID}).Return(progress, nil).Once()
 
 		// Add user info to context
 		req := httptest.NewRequest(http.MethodGet, "/report/progress", nil)
@@ -48,7 +103,7 @@ func TestReportHandler_ReportProgress(t *testing.T) {
 		req = req.WithContext(ctx)
 		rr := httptest.NewRecorder()
 
-		handlerObj.ReportProgress(rr, req)
+		handlerObj.ReportProgress(rr, req, api.ReportProgressParams{})
 
 		assert.Equal(t, http.StatusOK, rr.Code)
 		var resp api.Success
@@ -63,14 +118,74 @@ func TestReportHandler_ReportProgress(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("range and comparison are passed through", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+		to := from.AddDate(0, 0, 14)
+		percent := 50.0
+		mockService.On("Progress", mock.Anything, service.ProgressQuery{
+			UserId:      testUserID,
+			ReportRange: service.ReportRange{From: "2025-05-01", To: "2025-05-15", CompareTo: service.PREVIOUS},
+		}).Return(&service.ProgressStatus{
+			From:             &from,
+			To:               &to,
+			CompleteWorkouts: 3,
+			TotalWorkouts:    4,
+			Comparison: &service.ProgressComparison{
+				ComparedPeriod:    service.ComparedPeriod{CompareTo: service.PREVIOUS, From: from.AddDate(0, 0, -14), To: from},
+				CompletedWorkouts: service.Delta{Previous: 2, Change: 1, Percent: &percent},
+				TotalWorkouts:     service.Delta{Previous: 4, Change: 0},
+			},
+		}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/report/progress", nil)
+		ctx := helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: testUserID})
+		rr := httptest.NewRecorder()
+
+		fromParam, toParam := "2025-05-01", "2025-05-15"
+		compareTo := api.Previous
+		handlerObj.ReportProgress(rr, req.WithContext(ctx), api.ReportProgressParams{From: &fromParam, To: &toParam, CompareTo: &compareTo})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp api.Success
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		progressPayload := (*resp.Payload)["progress"].(map[string]any)
+		assert.Equal(t, "2025-05-01T00:00:00Z", progressPayload["from"])
+		comparison := progressPayload["comparison"].(map[string]any)
+		assert.Equal(t, "previous", comparison["compareTo"])
+		assert.Equal(t, "2025-04-17T00:00:00Z", comparison["from"])
+		assert.Equal(t, map[string]any{"previous": 2.0, "change": 1.0, "percent": 50.0}, comparison["completedWorkouts"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("validation error returns 400", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		mockService.On("Progress", mock.Anything, service.ProgressQuery{UserId: testUserID, ReportRange: service.ReportRange{To: "2025-05-15"}}).
+			Return(nil, apperrors.NewValidationError(apperrors.INVALID_DATE, "from must be set with to or compareTo")).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/report/progress", nil)
+		ctx := helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: testUserID})
+		rr := httptest.NewRecorder()
+
+		toParam := "2025-05-15"
+		handlerObj.ReportProgress(rr, req.WithContext(ctx), api.ReportProgressParams{To: &toParam})
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
 	t.Run("unauthorized if no user in context", func(t *testing.T) {
 		mockService := new(MockReportService)
-		handlerObj := handler.NewReportHandler(mockService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/report/progress", nil)
 		rr := httptest.NewRecorder()
 
-		handlerObj.ReportProgress(rr, req)
+		handlerObj.ReportProgress(rr, req, api.ReportProgressParams{})
 
 		assert.Equal(t, http.StatusUnauthorized, rr.Code)
 		mockService.AssertNotCalled(t, "Progress")
@@ -78,16 +193,550 @@ func TestReportHandler_ReportProgress(t *testing.T) {
 
 	t.Run("service error returns 500", func(t *testing.T) {
 		mockService := new(MockReportService)
-		handlerObj := handler.NewReportHandler(mockService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
 
-		mockService.On("Progress", mock.Anything, testUserID).Return(nil, errors.New("db error")).Once()
+		mockService.On("Progress", mock.Anything, service.ProgressQuery{UserId: testUserID}).Return(nil, errors.New("db error")).Once()
 
 		req := httptest.NewRequest(http.MethodGet, "/report/progress", nil)
 		ctx := helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: testUserID})
 		req = req.WithContext(ctx)
 		rr := httptest.NewRecorder()
 
-		handlerObj.ReportProgress(rr, req)
+		handlerObj.ReportProgress(rr, req, api.ReportProgressParams{})
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestReportHandler_ReportPersonalRecords(t *testing.T) {
+	const testUserID = 42
+	achievedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/report/personal-records", nil)
+		ctx := helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: testUserID})
+		return req.WithContext(ctx)
+	}
+
+	t.Run("successfully fetch personal records of an exercise", func(t *testing.T) {
+		mockPRService := new(MockPersonalRecordService)
+		handlerObj := handler.NewReportHandler(new(MockReportService), mockPRService)
+
+		weightKg, previous := 80.0, 6.0
+		exerciseID := 10
+		mockPRService.On("ListPersonalRecords", mock.Anything, testUserID, &exerciseID).Return([]service.PersonalRecord{
+			{Id: 2, ExerciseId: 10, WorkoutPlanId: 3, Kind: service.PR_REPS, Value: 8, WeightKg: &weightKg, PreviousValue: &previous, AchievedAt: achievedAt},
+			{Id: 1, ExerciseId: 10, WorkoutPlanId: 2, Kind: service.PR_WEIGHT, Value: 100, AchievedAt: achievedAt},
+		}, nil).Once()
+
+		exerciseParam := int64(10)
+		rr := httptest.NewRecorder()
+		handlerObj.ReportPersonalRecords(rr, newRequest(), api.ReportPersonalRecordsParams{ExerciseId: &exerciseParam})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp api.Success
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Equal(t, api.FETCH, resp.Code)
+		records, ok := (*resp.Payload)["personalRecords"].([]any)
+		assert.True(t, ok)
+		assert.Len(t, records, 2)
+		first := records[0].(map[string]any)
+		assert.Equal(t, "reps", first["kind"])
+		assert.Equal(t, float64(8), first["value"])
+		assert.Equal(t, float64(80), first["weightKg"])
+		assert.Equal(t, float64(6), first["previousValue"])
+		assert.Nil(t, records[1].(map[string]any)["weightKg"])
+		mockPRService.AssertExpectations(t)
+	})
+
+	t.Run("empty list when there are no records", func(t *testing.T) {
+		mockPRService := new(MockPersonalRecordService)
+		handlerObj := handler.NewReportHandler(new(MockReportService), mockPRService)
+
+		mockPRService.On("ListPersonalRecords", mock.Anything, testUserID, (*int)(nil)).Return(nil, nil).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.ReportPersonalRecords(rr, newRequest(), api.ReportPersonalRecordsParams{})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp api.Success
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+		assert.Equal(t, []any{}, (*resp.Payload)["personalRecords"])
+		mockPRService.AssertExpectations(t)
+	})
+
+	t.Run("invalid exercise id returns 400", func(t *testing.T) {
+		mockPRService := new(MockPersonalRecordService)
+		handlerObj := handler.NewReportHandler(new(MockReportService), mockPRService)
+
+		exerciseParam := int64(0)
+		rr := httptest.NewRecorder()
+		handlerObj.ReportPersonalRecords(rr, newRequest(), api.ReportPersonalRecordsParams{ExerciseId: &exerciseParam})
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockPRService.AssertNotCalled(t, "ListPersonalRecords")
+	})
+
+	t.Run("unauthorized if no user in context", func(t *testing.T) {
+		mockPRService := new(MockPersonalRecordService)
+		handlerObj := handler.NewReportHandler(new(MockReportService), mockPRService)
+
+		req := httptest.NewRequest(http.MethodGet, "/report/personal-records", nil)
+		rr := httptest.NewRecorder()
+		handlerObj.ReportPersonalRecords(rr, req, api.ReportPersonalRecordsParams{})
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockPRService.AssertNotCalled(t, "ListPersonalRecords")
+	})
+
+	t.Run("service error returns 500", func(t *testing.T) {
+		mockPRService := new(MockPersonalRecordService)
+		handlerObj := handler.NewReportHandler(new(MockReportService), mockPRService)
+
+		mockPRService.On("ListPersonalRecords", mock.Anything, testUserID, (*int)(nil)).Return(nil, errors.New("db error")).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.ReportPersonalRecords(rr, newRequest(), api.ReportPersonalRecordsParams{})
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		mockPRService.AssertExpectations(t)
+	})
+}
+
+func TestReportHandler_ReportVolume(t *testing.T) {
+	const testUserID = 42
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	fromParam, toParam := "2025-05-01T00:00:00Z", "2025-06-01T00:00:00Z"
+	week := time.Date(2025, 5, 5, 0, 0, 0, 0, time.UTC)
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/report/volume", nil)
+		ctx := helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: testUserID})
+		return req.WithContext(ctx)
+	}
+
+	t.Run("successfully fetch volume report", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		lbs := service.LBS
+		mockService.On("Volume", mock.Anything, service.VolumeQuery{UserId: testUserID, ReportRange: service.ReportRange{From: fromParam, To: toParam, CompareTo: service.PREVIOUS_YEAR}, Bucket: service.MONTH, Unit: &lbs}).Return(&service.VolumeReport{
+			From:     from,
+			To:       to,
+			TimeZone: "UTC",
+			Bucket:   service.MONTH,
+			Unit:     service.LBS,
+			Totals:   service.VolumeTotals{Sets: 3, Repetitions: 30, Volume: 3306.93},
+			Comparison: &service.VolumeComparison{
+				ComparedPeriod: service.ComparedPeriod{CompareTo: service.PREVIOUS_YEAR, From: from.AddDate(-1, 0, 0), To: to.AddDate(-1, 0, 0)},
+				Sets:           service.Delta{Previous: 0, Change: 3},
+			},
+			Periods: []service.VolumePeriod{{
+				Start:        week,
+				VolumeTotals: service.VolumeTotals{Sets: 3, Repetitions: 30, Volume: 3306.93},
+				Exercises: []service.ExerciseVolume{
+					{ExerciseId: 2, Name: "Bench Press", MuscleGroup: service.Chest, VolumeTotals: service.VolumeTotals{Sets: 3, Repetitions: 30, Volume: 3306.93}},
+				},
+				MuscleGroups: []service.MuscleGroupVolume{
+					{MuscleGroup: service.Chest, VolumeTotals: service.VolumeTotals{Sets: 3, Repetitions: 30, Volume: 3306.93}},
+				},
+			}},
+		}, nil).Once()
+
+		bucket := api.Month
+		unit := api.ReportUnitLbs
+		compareTo := api.PreviousYear
+		rr := httptest.NewRecorder()
+		handlerObj.ReportVolume(rr, newRequest(), api.ReportVolumeParams{From: fromParam, To: toParam, CompareTo: &compareTo, Bucket: &bucket, Unit: &unit})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp api.Success
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Equal(t, api.FETCH, resp.Code)
+		volume, ok := (*resp.Payload)["volume"].(map[string]any)
+		assert.True(t, ok)
+		assert.Equal(t, "lbs", volume["unit"])
+		assert.Equal(t, 3306.93, volume["totals"].(map[string]any)["volume"])
+		comparison := volume["comparison"].(map[string]any)
+		assert.Equal(t, "previous_year", comparison["compareTo"])
+		assert.Equal(t, map[string]any{"previous": 0.0, "change": 3.0, "percent": nil}, comparison["sets"])
+		periods := volume["periods"].([]any)
+		assert.Len(t, periods, 1)
+		period := periods[0].(map[string]any)
+		assert.Equal(t, 3306.93, period["volume"])
+		exercises := period["exercises"].([]any)
+		assert.Equal(t, "Bench Press", exercises[0].(map[string]any)["name"])
+		muscleGroups := period["muscleGroups"].([]any)
+		assert.Equal(t, "chest", muscleGroups[0].(map[string]any)["muscleGroup"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("validation error returns 400", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		mockService.On("Volume", mock.Anything, service.VolumeQuery{UserId: testUserID, ReportRange: service.ReportRange{From: toParam, To: fromParam}}).
+			Return(nil, apperrors.NewValidationError(apperrors.INVALID_DATE, "to must be after from")).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.ReportVolume(rr, newRequest(), api.ReportVolumeParams{From: toParam, To: fromParam})
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("unauthorized if no user in context", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/report/volume", nil)
+		rr := httptest.NewRecorder()
+		handlerObj.ReportVolume(rr, req, api.ReportVolumeParams{From: fromParam, To: toParam})
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockService.AssertNotCalled(t, "Volume")
+	})
+
+	t.Run("service error returns 500", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		mockService.On("Volume", mock.Anything, mock.AnythingOfType("service.VolumeQuery")).Return(nil, errors.New("db error")).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.ReportVolume(rr, newRequest(), api.ReportVolumeParams{From: fromParam, To: toParam})
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestReportHandler_ReportConsistency(t *testing.T) {
+	const testUserID = 42
+	hongKong, _ := time.LoadLocation("Asia/Hong_Kong")
+	week := time.Date(2025, 5, 5, 0, 0, 0, 0, hongKong)
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/report/consistency", nil)
+		ctx := helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: testUserID})
+		return req.WithContext(ctx)
+	}
+
+	t.Run("successfully fetch consistency report", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		rate := 0.75
+		mockService.On("Consistency", mock.Anything, service.ConsistencyQuery{UserId: testUserID, WeeklyTarget: 4}).Return(&service.ConsistencyReport{
+			From:          week,
+			To:            week.AddDate(0, 0, 7),
+			TimeZone:      "Asia/Hong_Kong",
+			WeeklyTarget:  4,
+			CurrentStreak: 0,
+			LongestStreak: 0,
+			Adherence:     service.AdherenceTotals{Scheduled: 4, Completed: 3, Missed: 1, Rate: &rate},
+			Weeks: []service.ConsistencyWeek{{
+				AdherencePeriod: service.AdherencePeriod{Start: week, AdherenceTotals: service.AdherenceTotals{Scheduled: 4, Completed: 3, Missed: 1, Rate: &rate}},
+			}},
+			Months: []service.AdherencePeriod{
+				{Start: week, AdherenceTotals: service.AdherenceTotals{Scheduled: 4, Completed: 3, Missed: 1, Rate: &rate}},
+			},
+			Days: []service.ConsistencyDay{{Date: week, Completed: 3}},
+		}, nil).Once()
+
+		target := 4
+		rr := httptest.NewRecorder()
+		handlerObj.ReportConsistency(rr, newRequest(), api.ReportConsistencyParams{WeeklyTarget: &target})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp api.Success
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Equal(t, api.FETCH, resp.Code)
+		consistency, ok := (*resp.Payload)["consistency"].(map[string]any)
+		assert.True(t, ok)
+		assert.Equal(t, "Asia/Hong_Kong", consistency["timeZone"])
+		assert.Equal(t, "2025-05-05T00:00:00+08:00", consistency["from"])
+		weeks := consistency["weeks"].([]any)
+		assert.Equal(t, "2025-05-05", weeks[0].(map[string]any)["start"])
+		assert.Equal(t, 0.75, weeks[0].(map[string]any)["rate"])
+		assert.Equal(t, false, weeks[0].(map[string]any)["targetMet"])
+		days := consistency["days"].([]any)
+		assert.Equal(t, map[string]any{"date": "2025-05-05", "completed": 3.0}, days[0])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("weekly target of zero returns 400", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		target := 0
+		rr := httptest.NewRecorder()
+		handlerObj.ReportConsistency(rr, newRequest(), api.ReportConsistencyParams{WeeklyTarget: &target})
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertNotCalled(t, "Consistency", mock.Anything, mock.Anything)
+	})
+
+	t.Run("validation error returns 400", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		from, to := "2025-05-12", "2025-05-05"
+		mockService.On("Consistency", mock.Anything, service.ConsistencyQuery{UserId: testUserID, ReportRange: service.ReportRange{From: from, To: to}}).
+			Return(nil, apperrors.NewValidationError(apperrors.INVALID_DATE, "to must be after from")).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.ReportConsistency(rr, newRequest(), api.ReportConsistencyParams{From: &from, To: &to})
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("unauthorized if no user in context", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		rr := httptest.NewRecorder()
+		handlerObj.ReportConsistency(rr, httptest.NewRequest(http.MethodGet, "/report/consistency", nil), api.ReportConsistencyParams{})
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("service error returns 500", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		mockService.On("Consistency", mock.Anything, mock.AnythingOfType("service.ConsistencyQuery")).Return(nil, errors.New("db error")).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.ReportConsistency(rr, newRequest(), api.ReportConsistencyParams{})
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestReportHandler_ReportStrength(t *testing.T) {
+	const testUserID = 42
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	fromParam, toParam := "2025-01-01", "2025-03-01"
+
+	newRequest := func(exerciseId string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/report/exercises/"+exerciseId+"/strength", nil)
+		req.SetPathValue("exerciseId", exerciseId)
+		ctx := helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: testUserID})
+		return req.WithContext(ctx)
+	}
+
+	t.Run("successfully fetch strength report", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		lbs := service.LBS
+		point := service.StrengthPoint{Date: from, WorkoutPlanId: 4, Weight: 242.51, Repetitions: 5, EstimatedOneRepMax: 282.93, RollingBest: 282.93}
+		mockService.On("Strength", mock.Anything, service.StrengthQuery{
+			UserId:      testUserID,
+			ExerciseId:  2,
+			ReportRange: service.ReportRange{From: fromParam, To: toParam},
+			Formula:     service.LOMBARDI,
+			Unit:        &lbs,
+			TrendWeeks:  4,
+		}).Return(&service.StrengthReport{
+			ExerciseId: 2,
+			Name:       "Bench Press",
+			From:       from,
+			To:         to,
+			Formula:    service.LOMBARDI,
+			Unit:       service.LBS,
+			Best:       &point,
+			Points:     []service.StrengthPoint{point},
+			Trend:      &service.StrengthTrend{Weeks: 4, Points: 2, SlopePerWeek: 5.87, ChangePercent: 4.1},
+		}, nil).Once()
+
+		formula := api.Lombardi
+		unit := api.ReportUnitLbs
+		trendWeeks := 4
+		rr := httptest.NewRecorder()
+		handlerObj.ReportStrength(rr, newRequest("2"), api.ReportStrengthParams{From: &fromParam, To: &toParam, Formula: &formula, Unit: &unit, TrendWeeks: &trendWeeks})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp api.Success
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Equal(t, api.FETCH, resp.Code)
+		strength, ok := (*resp.Payload)["strength"].(map[string]any)
+		assert.True(t, ok)
+		assert.Equal(t, "lombardi", strength["formula"])
+		assert.Equal(t, 282.93, strength["best"].(map[string]any)["estimatedOneRepMax"])
+		assert.Len(t, strength["points"].([]any), 1)
+		assert.Equal(t, 4.1, strength["trend"].(map[string]any)["changePercent"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid exercise id returns 400", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		rr := httptest.NewRecorder()
+		handlerObj.ReportStrength(rr, newRequest("abc"), api.ReportStrengthParams{})
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertNotCalled(t, "Strength", mock.Anything, mock.Anything)
+	})
+
+	t.Run("custom exercise of another user returns 403", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		mockService.On("Strength", mock.Anything, service.StrengthQuery{UserId: testUserID, ExerciseId: 9}).Return(nil, apperrors.ErrForbidden).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.ReportStrength(rr, newRequest("9"), api.ReportStrengthParams{})
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("unknown exercise returns 404", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		mockService.On("Strength", mock.Anything, service.StrengthQuery{UserId: testUserID, ExerciseId: 99}).Return(nil, apperrors.ErrNotFound).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.ReportStrength(rr, newRequest("99"), api.ReportStrengthParams{})
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("validation error returns 400", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		formula := api.OneRepMaxFormula("wathan")
+		mockService.On("Strength", mock.Anything, service.StrengthQuery{UserId: testUserID, ExerciseId: 2, Formula: "wathan"}).
+			Return(nil, apperrors.NewValidationError(apperrors.INVALID_SETTING, "formula must be epley, brzycki or lombardi")).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.ReportStrength(rr, newRequest("2"), api.ReportStrengthParams{Formula: &formula})
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("service error returns 500", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		mockService.On("Strength", mock.Anything, mock.AnythingOfType("service.StrengthQuery")).Return(nil, errors.New("db error")).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.ReportStrength(rr, newRequest("2"), api.ReportStrengthParams{})
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestReportHandler_ReportMuscleBalance(t *testing.T) {
+	const testUserID = 42
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 5, 29, 0, 0, 0, 0, time.UTC)
+	fromParam, toParam := "2025-05-01", "2025-05-29"
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/report/muscle-balance", nil)
+		ctx := helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: testUserID})
+		return req.WithContext(ctx)
+	}
+
+	t.Run("successfully fetch muscle balance report", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		change, volumeChange := 20.0, 50.0
+		ratio := 2.0
+		kg := service.KG
+		mockService.On("MuscleBalance", mock.Anything, service.MuscleBalanceQuery{UserId: testUserID, ReportRange: service.ReportRange{From: fromParam, To: toParam}, Unit: &kg}).Return(&service.MuscleBalanceReport{
+			From:       from,
+			To:         to,
+			Comparison: service.ComparedPeriod{CompareTo: service.PREVIOUS, From: from.AddDate(0, 0, -28), To: from},
+			Unit:       service.KG,
+			MuscleGroups: []service.MuscleGroupBalance{{
+				MuscleGroup:  service.Chest,
+				Current:      service.VolumeTotals{Sets: 12, Repetitions: 96, Volume: 6000},
+				Previous:     service.VolumeTotals{Sets: 10, Repetitions: 80, Volume: 4000},
+				SetsChange:   service.Delta{Previous: 10, Change: 2, Percent: &change},
+				VolumeChange: service.Delta{Previous: 4000, Change: 2000, Percent: &volumeChange},
+			}},
+			Ratios: []service.BalanceRatio{
+				{Name: service.PushPull, LeftSets: 18, RightSets: 9, Ratio: &ratio, MaxRatio: 1.5, Imbalanced: true},
+			},
+		}, nil).Once()
+
+		unit := api.ReportUnitKg
+		rr := httptest.NewRecorder()
+		handlerObj.ReportMuscleBalance(rr, newRequest(), api.ReportMuscleBalanceParams{From: &fromParam, To: &toParam, Unit: &unit})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp api.Success
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		balance, ok := (*resp.Payload)["muscleBalance"].(map[string]any)
+		assert.True(t, ok)
+		comparison := balance["comparison"].(map[string]any)
+		assert.Equal(t, "previous", comparison["compareTo"])
+		assert.Equal(t, "2025-04-03T00:00:00Z", comparison["from"])
+		group := balance["muscleGroups"].([]any)[0].(map[string]any)
+		assert.Equal(t, "chest", group["muscleGroup"])
+		assert.Equal(t, 6000.0, group["current"].(map[string]any)["volume"])
+		assert.Equal(t, 20.0, group["setsChange"].(map[string]any)["percent"])
+		assert.Equal(t, 2000.0, group["volumeChange"].(map[string]any)["change"])
+		pair := balance["ratios"].([]any)[0].(map[string]any)
+		assert.Equal(t, "push_pull", pair["name"])
+		assert.Equal(t, true, pair["imbalanced"])
+		assert.Nil(t, pair["previousRatio"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("validation error returns 400", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		mockService.On("MuscleBalance", mock.Anything, service.MuscleBalanceQuery{UserId: testUserID, ReportRange: service.ReportRange{From: toParam, To: fromParam}}).
+			Return(nil, apperrors.NewValidationError(apperrors.INVALID_DATE, "to must be after from")).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.ReportMuscleBalance(rr, newRequest(), api.ReportMuscleBalanceParams{From: &toParam, To: &fromParam})
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("unauthorized if no user in context", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/report/muscle-balance", nil)
+		rr := httptest.NewRecorder()
+		handlerObj.ReportMuscleBalance(rr, req, api.ReportMuscleBalanceParams{})
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockService.AssertNotCalled(t, "MuscleBalance")
+	})
+
+	t.Run("service error returns 500", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handlerObj := handler.NewReportHandler(mockService, nil)
+
+		mockService.On("MuscleBalance", mock.Anything, service.MuscleBalanceQuery{UserId: testUserID}).Return(nil, errors.New("db error")).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.ReportMuscleBalance(rr, newRequest(), api.ReportMuscleBalanceParams{})
 
 		assert.Equal(t, http.StatusInternalServerError, rr.Code)
 		mockService.AssertExpectations(t)
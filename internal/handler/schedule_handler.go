@@ -0,0 +1,347 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util"
+	"workout-tracker-api/internal/util/helper"
+	"workout-tracker-api/pkg/api"
+)
+
+type ScheduleHandler struct {
+	ScheduleService service.ScheduleServiceInterface
+}
+
+func NewScheduleHandler(ss service.ScheduleServiceInterface) *ScheduleHandler {
+	return &ScheduleHandler{
+		ScheduleService: ss,
+	}
+}
+
+// ListSchedules
+func (h *ScheduleHandler) ListSchedules(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	wsList, err := h.ScheduleService.ListSchedules(r.Context(), userInfo.Id)
+	if err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to fetch workout schedules: %w", err))
+		return
+	}
+
+	var schedules []api.WorkoutSchedule
+	for _, ws := range wsList {
+		apiWS := toAPISchedule(&ws)
+		schedules = append(schedules, *apiWS)
+	}
+
+	response := api.Success{
+		Code:    api.FETCH,
+		Message: "successfully fetch workout schedules",
+		Payload: &map[string]any{
+			"schedules": schedules,
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// CreateSchedule
+func (h *ScheduleHandler) CreateSchedule(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateScheduleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding creating request: %v", err)
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	var input = service.WorkoutScheduleCreate{
+		UserId:     userInfo.Id,
+		Recurrence: toServiceRule(&req.Recurrence),
+	}
+
+	if req.ExercisePlans != nil {
+		for _, ep := range *req.ExercisePlans {
+			createEP := toServiceCreateEP(&ep)
+			input.ExercisePlans = append(input.ExercisePlans, *createEP)
+		}
+	}
+
+	ws, err := h.ScheduleService.CreateSchedule(r.Context(), input)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("error creating workout schedule: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.CREATED,
+		Message: "successfully create workout schedule",
+		Payload: &map[string]any{
+			"schedule": toAPISchedule(ws),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusCreated, &response)
+}
+
+// PreviewSchedule
+func (h *ScheduleHandler) PreviewSchedule(w http.ResponseWriter, r *http.Request) {
+	var req api.PreviewScheduleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding preview request: %v", err)
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	rule := toServiceRule(&req)
+	occurrences, err := h.ScheduleService.PreviewSchedule(r.Context(), rule)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to preview workout schedule: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.FETCH,
+		Message: "successfully preview workout schedule",
+		Payload: &map[string]any{
+			"rrule":       rule.String(),
+			"occurrences": occurrences,
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// GetScheduleById
+func (h *ScheduleHandler) GetScheduleById(w http.ResponseWriter, r *http.Request) {
+	ws, err := scheduleAuth(w, r, h.ScheduleService)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	response := api.Success{
+		Code:    api.FETCH,
+		Message: "successfully fetch workout schedule",
+		Payload: &map[string]any{
+			"schedule": toAPISchedule(ws),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// CancelSchedule
+func (h *ScheduleHandler) CancelSchedule(w http.ResponseWriter, r *http.Request) {
+	ws, err := scheduleAuth(w, r, h.ScheduleService)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	// the body is optional, cancelling from now on by default
+	var req api.CancelScheduleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		log.Printf("Error decoding cancel request: %v", err)
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	cancelled, err := h.ScheduleService.CancelSchedule(r.Context(), ws.Id, req.From)
+	if err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to cancel workout schedule: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.UPDATE,
+		Message: "successfully cancel workout schedule",
+		Payload: &map[string]any{
+			"schedule": toAPISchedule(cancelled),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// UpdateScheduleOccurrence
+func (h *ScheduleHandler) UpdateScheduleOccurrence(w http.ResponseWriter, r *http.Request) {
+	ws, err := scheduleAuth(w, r, h.ScheduleService)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	wpId, err := pathID(w, r, "workoutId")
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	var req api.UpdateScheduleOccurrenceJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding occurrence request: %v", err)
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	input := service.OccurrenceUpdate{
+		Scope:         service.OccurrenceScope(req.Scope),
+		ScheduledDate: req.ScheduledDate,
+	}
+
+	if req.ExercisePlans != nil {
+		input.ExercisePlans = []service.ExercisePlanCreate{}
+		for _, ep := range *req.ExercisePlans {
+			createEP := toServiceCreateEP(&ep)
+			input.ExercisePlans = append(input.ExercisePlans, *createEP)
+		}
+	}
+
+	updated, err := h.ScheduleService.UpdateOccurrence(r.Context(), ws.Id, wpId, input)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to update occurrence: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.UPDATE,
+		Message: "successfully update occurrence",
+		Payload: &map[string]any{
+			"schedule": toAPISchedule(updated),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// scheduleAuth makes sure the schedule in the path belongs to the user, like doubleAuth does for workout plans
+func scheduleAuth(w http.ResponseWriter, r *http.Request, scheduleService service.ScheduleServiceInterface) (*service.WorkoutSchedule, error) {
+	wsId, err := pathID(w, r, "scheduleId")
+	if err != nil {
+		return nil, err
+	}
+
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		err := fmt.Errorf("failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return nil, err
+	}
+
+	existingWS, err := scheduleService.GetScheduleById(r.Context(), wsId)
+	if err != nil {
+		err := fmt.Errorf("error fetching workout schedule %d for operation by user %d", wsId, userInfo.Id)
+		helper.SendErrorResponse(w, apperrors.ErrNotFound)
+		return nil, err
+	}
+
+	if existingWS.UserId != userInfo.Id {
+		err := fmt.Errorf("unauthorized attempt: User %d tried to operate workout schedule %d", userInfo.Id, wsId)
+		helper.SendErrorResponse(w, apperrors.ErrForbidden)
+		return nil, err
+	}
+
+	return existingWS, nil
+}
+
+func toServiceRule(apiRule *api.RecurrenceRule) service.RecurrenceRule {
+	rule := service.RecurrenceRule{
+		Frequency: service.Frequency(apiRule.Frequency),
+		Interval:  apiRule.Interval,
+		StartDate: apiRule.StartDate,
+		Until:     apiRule.Until,
+		Count:     apiRule.Count,
+	}
+
+	if apiRule.Weekdays != nil {
+		for _, day := range *apiRule.Weekdays {
+			rule.Weekdays = append(rule.Weekdays, service.Weekday(day))
+		}
+	}
+
+	return rule
+}
+
+func toAPISchedule(ws *service.WorkoutSchedule) *api.WorkoutSchedule {
+	if ws == nil {
+		return nil
+	}
+
+	createdAt := ws.CreatedAt
+	updatedAt := ws.UpdatedAt
+	rrule := ws.Recurrence.String()
+
+	var weekdays []api.Weekday
+	for _, day := range ws.Recurrence.Weekdays {
+		weekdays = append(weekdays, api.Weekday(day))
+	}
+
+	var until *time.Time
+	if ws.Recurrence.Until != nil {
+		value := *ws.Recurrence.Until
+		until = &value
+	}
+
+	recurrence := api.RecurrenceRule{
+		Frequency: api.Frequency(ws.Recurrence.Frequency),
+		Interval:  ws.Recurrence.Interval,
+		StartDate: ws.Recurrence.StartDate,
+		Until:     until,
+		Count:     ws.Recurrence.Count,
+		Weekdays:  &weekdays,
+	}
+
+	var workoutPlans []api.WorkoutPlan
+	for _, wp := range ws.Workouts {
+		apiWP := toAPIWorkout(&wp)
+		workoutPlans = append(workoutPlans, *apiWP)
+	}
+
+	return &api.WorkoutSchedule{
+		Id:           util.IntTo64(ws.Id),
+		UserId:       util.IntTo64(ws.UserId),
+		Recurrence:   &recurrence,
+		Rrule:        &rrule,
+		CancelledAt:  ws.CancelledAt,
+		CreatedAt:    &createdAt,
+		UpdatedAt:    &updatedAt,
+		WorkoutPlans: &workoutPlans,
+	}
+}
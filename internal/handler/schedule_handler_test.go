@@ -0,0 +1,311 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/handler"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util/helper"
+	"workout-tracker-api/pkg/api"
+)
+
+// MockScheduleService is a mock implementation of service.ScheduleServiceInterface
+type MockScheduleService struct {
+	mock.Mock
+}
+
+func (m *MockScheduleService) CreateSchedule(ctx context.Context, data service.WorkoutScheduleCreate) (*service.WorkoutSchedule, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutSchedule), args.Error(1)
+}
+
+func (m *MockScheduleService) PreviewSchedule(ctx context.Context, rule service.RecurrenceRule) ([]time.Time, error) {
+	args := m.Called(ctx, rule)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]time.Time), args.Error(1)
+}
+
+func (m *MockScheduleService) GetScheduleById(ctx context.Context, id int) (*service.WorkoutSchedule, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutSchedule), args.Error(1)
+}
+
+func (m *MockScheduleService) ListSchedules(ctx context.Context, userId int) ([]service.WorkoutSchedule, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.WorkoutSchedule), args.Error(1)
+}
+
+func (m *MockScheduleService) CancelSchedule(ctx context.Context, id int, from *time.Time) (*service.WorkoutSchedule, error) {
+	args := m.Called(ctx, id, from)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutSchedule), args.Error(1)
+}
+
+func (m *MockScheduleService) UpdateOccurrence(ctx context.Context, scheduleId int, workoutId int, data service.OccurrenceUpdate) (*service.WorkoutSchedule, error) {
+	args := m.Called(ctx, scheduleId, workoutId, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutSchedule), args.Error(1)
+}
+
+func TestScheduleHandler(t *testing.T) {
+	testUserID := 123
+	scheduleID := 1
+	startDate := time.Date(2025, 6, 2, 7, 0, 0, 0, time.UTC)
+	count := 3
+
+	existingSchedule := &service.WorkoutSchedule{
+		Id:     scheduleID,
+		UserId: testUserID,
+		Recurrence: service.RecurrenceRule{
+			Frequency: service.DAILY,
+			Interval:  1,
+			StartDate: startDate,
+			Count:     &count,
+		},
+	}
+
+	createRequest := func(method string, url string, pathValues map[string]string, body []byte) *http.Request {
+		req := httptest.NewRequest(method, url, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		for name, value := range pathValues {
+			req.SetPathValue(name, value)
+		}
+
+		userInfo := helper.UserInfo{
+			Email: "test@example.com",
+			Name:  "Test User",
+			Id:    testUserID,
+		}
+		ctx := context.WithValue(req.Context(), helper.UserContextKey, &userInfo)
+		return req.WithContext(ctx)
+	}
+
+	schedulePath := map[string]string{"scheduleId": strconv.Itoa(scheduleID)}
+
+	t.Run("CreateSchedule", func(t *testing.T) {
+		t.Run("Successful creation", func(t *testing.T) {
+			mockSService := new(MockScheduleService)
+			sHandler := handler.NewScheduleHandler(mockSService)
+
+			weekdays := []api.Weekday{api.MO, api.TH}
+			reqBody := api.CreateScheduleJSONRequestBody{
+				Recurrence: api.RecurrenceRule{
+					Frequency: api.Weekly,
+					Interval:  1,
+					Weekdays:  &weekdays,
+					StartDate: startDate,
+					Count:     &count,
+				},
+			}
+			body, _ := json.Marshal(reqBody)
+
+			mockSService.On("CreateSchedule", mock.Anything, service.WorkoutScheduleCreate{
+				UserId: testUserID,
+				Recurrence: service.RecurrenceRule{
+					Frequency: service.WEEKLY,
+					Interval:  1,
+					Weekdays:  []service.Weekday{service.MO, service.TH},
+					StartDate: startDate,
+					Count:     &count,
+				},
+			}).Return(existingSchedule, nil).Once()
+
+			req := createRequest(http.MethodPost, "/schedules", nil, body)
+			rr := httptest.NewRecorder()
+
+			sHandler.CreateSchedule(rr, req)
+
+			assert.Equal(t, http.StatusCreated, rr.Code)
+			var resp api.Success
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, api.CREATED, resp.Code)
+			assert.Contains(t, *resp.Payload, "schedule")
+			mockSService.AssertExpectations(t)
+		})
+
+		t.Run("Validation error", func(t *testing.T) {
+			mockSService := new(MockScheduleService)
+			sHandler := handler.NewScheduleHandler(mockSService)
+
+			body, _ := json.Marshal(api.CreateScheduleJSONRequestBody{
+				Recurrence: api.RecurrenceRule{Frequency: api.Daily, Interval: 1, StartDate: startDate},
+			})
+
+			mockSService.On("CreateSchedule", mock.Anything, mock.AnythingOfType("service.WorkoutScheduleCreate")).
+				Return(nil, apperrors.NewValidationError(apperrors.INVALID_SETTING, "either until or count must be set")).Once()
+
+			req := createRequest(http.MethodPost, "/schedules", nil, body)
+			rr := httptest.NewRecorder()
+
+			sHandler.CreateSchedule(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			var resp api.Error
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, string(apperrors.INVALID_SETTING), resp.Code)
+		})
+	})
+
+	t.Run("PreviewSchedule", func(t *testing.T) {
+		mockSService := new(MockScheduleService)
+		sHandler := handler.NewScheduleHandler(mockSService)
+
+		body, _ := json.Marshal(api.PreviewScheduleJSONRequestBody{
+			Frequency: api.Daily,
+			Interval:  1,
+			StartDate: startDate,
+			Count:     &count,
+		})
+
+		mockSService.On("PreviewSchedule", mock.Anything, existingSchedule.Recurrence).Return([]time.Time{
+			startDate, startDate.AddDate(0, 0, 1), startDate.AddDate(0, 0, 2),
+		}, nil).Once()
+
+		req := createRequest(http.MethodPost, "/schedules/preview", nil, body)
+		rr := httptest.NewRecorder()
+
+		sHandler.PreviewSchedule(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp api.Success
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Equal(t, "FREQ=DAILY;INTERVAL=1;COUNT=3", (*resp.Payload)["rrule"])
+		assert.Len(t, (*resp.Payload)["occurrences"], 3)
+		mockSService.AssertExpectations(t)
+	})
+
+	t.Run("GetScheduleById", func(t *testing.T) {
+		t.Run("Forbidden (user does not own schedule)", func(t *testing.T) {
+			mockSService := new(MockScheduleService)
+			sHandler := handler.NewScheduleHandler(mockSService)
+
+			mockSService.On("GetScheduleById", mock.Anything, scheduleID).Return(&service.WorkoutSchedule{Id: scheduleID, UserId: 9999}, nil).Once()
+
+			req := createRequest(http.MethodGet, "/schedules/1", schedulePath, nil)
+			rr := httptest.NewRecorder()
+
+			sHandler.GetScheduleById(rr, req)
+
+			assert.Equal(t, http.StatusForbidden, rr.Code)
+		})
+
+		t.Run("Not found", func(t *testing.T) {
+			mockSService := new(MockScheduleService)
+			sHandler := handler.NewScheduleHandler(mockSService)
+
+			mockSService.On("GetScheduleById", mock.Anything, scheduleID).Return(nil, apperrors.ErrNotFound).Once()
+
+			req := createRequest(http.MethodGet, "/schedules/1", schedulePath, nil)
+			rr := httptest.NewRecorder()
+
+			sHandler.GetScheduleById(rr, req)
+
+			assert.Equal(t, http.StatusNotFound, rr.Code)
+		})
+	})
+
+	t.Run("CancelSchedule", func(t *testing.T) {
+		t.Run("Without body cancels from now on", func(t *testing.T) {
+			mockSService := new(MockScheduleService)
+			sHandler := handler.NewScheduleHandler(mockSService)
+
+			cancelledAt := time.Now().UTC().Truncate(time.Second)
+			cancelled := *existingSchedule
+			cancelled.CancelledAt = &cancelledAt
+
+			mockSService.On("GetScheduleById", mock.Anything, scheduleID).Return(existingSchedule, nil).Once()
+			mockSService.On("CancelSchedule", mock.Anything, scheduleID, (*time.Time)(nil)).Return(&cancelled, nil).Once()
+
+			req := createRequest(http.MethodPut, "/schedules/1/cancel", schedulePath, nil)
+			rr := httptest.NewRecorder()
+
+			sHandler.CancelSchedule(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			var resp api.Success
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, api.UPDATE, resp.Code)
+			mockSService.AssertExpectations(t)
+		})
+	})
+
+	t.Run("UpdateScheduleOccurrence", func(t *testing.T) {
+		workoutID := 11
+		occurrencePath := map[string]string{"scheduleId": strconv.Itoa(scheduleID), "workoutId": strconv.Itoa(workoutID)}
+
+		t.Run("Successful update", func(t *testing.T) {
+			mockSService := new(MockScheduleService)
+			sHandler := handler.NewScheduleHandler(mockSService)
+
+			newDate := startDate.AddDate(0, 0, 1).Add(time.Hour)
+			body, _ := json.Marshal(api.UpdateScheduleOccurrenceJSONRequestBody{
+				Scope:         api.Following,
+				ScheduledDate: &newDate,
+			})
+
+			mockSService.On("GetScheduleById", mock.Anything, scheduleID).Return(existingSchedule, nil).Once()
+			mockSService.On("UpdateOccurrence", mock.Anything, scheduleID, workoutID, service.OccurrenceUpdate{
+				Scope:         service.FOLLOWING_OCCURRENCES,
+				ScheduledDate: &newDate,
+			}).Return(&service.WorkoutSchedule{Id: 2, UserId: testUserID, Recurrence: existingSchedule.Recurrence}, nil).Once()
+
+			req := createRequest(http.MethodPut, "/schedules/1/occurrences/11", occurrencePath, body)
+			rr := httptest.NewRecorder()
+
+			sHandler.UpdateScheduleOccurrence(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			var resp api.Success
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, api.UPDATE, resp.Code)
+			mockSService.AssertExpectations(t)
+		})
+
+		t.Run("Invalid workout id", func(t *testing.T) {
+			mockSService := new(MockScheduleService)
+			sHandler := handler.NewScheduleHandler(mockSService)
+
+			mockSService.On("GetScheduleById", mock.Anything, scheduleID).Return(existingSchedule, nil).Once()
+
+			req := createRequest(http.MethodPut, "/schedules/1/occurrences/abc",
+				map[string]string{"scheduleId": strconv.Itoa(scheduleID), "workoutId": "abc"}, []byte(`{"scope":"this"}`))
+			rr := httptest.NewRecorder()
+
+			sHandler.UpdateScheduleOccurrence(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			mockSService.AssertNotCalled(t, "UpdateOccurrence")
+		})
+	})
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util/auth"
+	"workout-tracker-api/internal/util/helper"
+	"workout-tracker-api/pkg/api"
+)
+
+type SessionHandler struct {
+	SessionService service.SessionServiceInterface
+	TokenService   auth.TokenInterface
+}
+
+func NewSessionHandler(ss service.SessionServiceInterface, ts auth.TokenInterface) *SessionHandler {
+	return &SessionHandler{
+		SessionService: ss,
+		TokenService:   ts,
+	}
+}
+
+// ListUserSessions
+func (h *SessionHandler) ListUserSessions(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	var currentId string
+	if jti, ok := helper.GetJTIFromContext(r.Context()); ok {
+		currentId = jti.SessionId
+	}
+
+	sessionList, err := h.SessionService.ListSessions(r.Context(), userInfo.Id)
+	if err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to fetch sessions: %w", err))
+		return
+	}
+
+	sessions := []api.Session{}
+	for _, s := range sessionList {
+		sessions = append(sessions, *toAPISession(&s, currentId))
+	}
+
+	response := api.Success{
+		Code:    api.FETCH,
+		Message: "successfully fetch sessions",
+		Payload: &map[string]any{
+			"sessions": sessions,
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// RevokeUserSession
+func (h *SessionHandler) RevokeUserSession(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	sessionId := r.PathValue("sessionId")
+	if sessionId == "" {
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "missing session id"))
+		return
+	}
+
+	if err := h.SessionService.RevokeSession(r.Context(), userInfo.Id, sessionId); err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+		helper.SendErrorResponse(w, fmt.Errorf("failed to revoke session: %w", err))
+		return
+	}
+
+	// access tokens of the session are still valid until they expire, unless they are marked
+	if err := h.TokenService.RevokeSession(r.Context(), sessionId); err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to revoke session tokens: %w", err))
+		return
+	}
+
+	helper.SendSuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// RevokeAllUserSessions logs the user out everywhere
+func (h *SessionHandler) RevokeAllUserSessions(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	revoked, err := h.SessionService.RevokeAllSessions(r.Context(), userInfo.Id)
+	if err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to revoke sessions: %w", err))
+		return
+	}
+
+	if err := revokeSessionTokens(r.Context(), h.TokenService, revoked); err != nil {
+		helper.SendErrorResponse(w, err)
+		return
+	}
+
+	helper.SendSuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// revokeSessionTokens marks sessions revoked in the database as revoked for the access tokens too
+func revokeSessionTokens(ctx context.Context, ts auth.TokenInterface, sessionIds []string) error {
+	for _, sessionId := range sessionIds {
+		if err := ts.RevokeSession(ctx, sessionId); err != nil {
+			return fmt.Errorf("failed to revoke session tokens: %w", err)
+		}
+	}
+	return nil
+}
+
+func toAPISession(s *service.Session, currentId string) *api.Session {
+	if s == nil {
+		return nil
+	}
+
+	id := s.Id
+	userAgent := s.UserAgent
+	ipAddress := s.IPAddress
+	createdAt := s.CreatedAt
+	lastSeenAt := s.LastSeenAt
+	current := s.Id == currentId
+
+	var lastJti *string
+	if s.LastJTI != "" {
+		value := s.LastJTI
+		lastJti = &value
+	}
+
+	return &api.Session{
+		Id:         &id,
+		UserAgent:  &userAgent,
+		IpAddress:  &ipAddress,
+		LastJti:    lastJti,
+		CreatedAt:  &createdAt,
+		LastSeenAt: &lastSeenAt,
+		Current:    &current,
+	}
+}
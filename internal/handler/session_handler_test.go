@@ -0,0 +1,166 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/handler"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util/helper"
+	"workout-tracker-api/pkg/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSessionHandler_ListUserSessions(t *testing.T) {
+	const testUserID = 7
+
+	t.Run("marks the current session", func(t *testing.T) {
+		mockSessionService := new(MockSessionService)
+		handlerObj := handler.NewSessionHandler(mockSessionService, new(MockTokenService))
+
+		now := time.Date(2025, 5, 2, 8, 0, 0, 0, time.UTC)
+		mockSessionService.On("ListSessions", mock.Anything, testUserID).Return([]service.Session{
+			{Id: "session-1", UserAgent: "curl/8.0", IPAddress: "203.0.113.7", LastJTI: "jti-1", CreatedAt: now, LastSeenAt: now},
+			{Id: "session-2", UserAgent: "Safari", IPAddress: "198.51.100.2", CreatedAt: now, LastSeenAt: now},
+		}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/user/sessions", nil)
+		ctx := helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: testUserID})
+		ctx = helper.SetJTIToContext(ctx, &helper.JTIInfo{Id: "jti-2", SessionId: "session-2"})
+		req = req.WithContext(ctx)
+		rr := httptest.NewRecorder()
+
+		handlerObj.ListUserSessions(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp api.Success
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Equal(t, api.FETCH, resp.Code)
+		sessions, ok := (*resp.Payload)["sessions"].([]any)
+		assert.True(t, ok)
+		assert.Len(t, sessions, 2)
+		first := sessions[0].(map[string]any)
+		second := sessions[1].(map[string]any)
+		assert.Equal(t, "session-1", first["id"])
+		assert.Equal(t, "jti-1", first["lastJti"])
+		assert.Equal(t, false, first["current"])
+		assert.Equal(t, true, second["current"])
+		assert.NotContains(t, second, "lastJti")
+		mockSessionService.AssertExpectations(t)
+	})
+
+	t.Run("unauthorized if no user in context", func(t *testing.T) {
+		mockSessionService := new(MockSessionService)
+		handlerObj := handler.NewSessionHandler(mockSessionService, new(MockTokenService))
+
+		req := httptest.NewRequest(http.MethodGet, "/user/sessions", nil)
+		rr := httptest.NewRecorder()
+
+		handlerObj.ListUserSessions(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockSessionService.AssertNotCalled(t, "ListSessions", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSessionHandler_RevokeUserSession(t *testing.T) {
+	const testUserID = 7
+
+	newRequest := func(sessionId string) *http.Request {
+		req := httptest.NewRequest(http.MethodDelete, "/user/sessions/"+sessionId, nil)
+		req.SetPathValue("sessionId", sessionId)
+		return req.WithContext(helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: testUserID}))
+	}
+
+	t.Run("revokes the session and its access tokens", func(t *testing.T) {
+		mockSessionService := new(MockSessionService)
+		mockTokenService := new(MockTokenService)
+		handlerObj := handler.NewSessionHandler(mockSessionService, mockTokenService)
+
+		mockSessionService.On("RevokeSession", mock.Anything, testUserID, "session-1").Return(nil).Once()
+		mockTokenService.On("RevokeSession", mock.Anything, "session-1").Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.RevokeUserSession(rr, newRequest("session-1"))
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		mockSessionService.AssertExpectations(t)
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockSessionService := new(MockSessionService)
+		mockTokenService := new(MockTokenService)
+		handlerObj := handler.NewSessionHandler(mockSessionService, mockTokenService)
+
+		mockSessionService.On("RevokeSession", mock.Anything, testUserID, "session-9").Return(apperrors.ErrNotFound).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.RevokeUserSession(rr, newRequest("session-9"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockTokenService.AssertNotCalled(t, "RevokeSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("cache error", func(t *testing.T) {
+		mockSessionService := new(MockSessionService)
+		mockTokenService := new(MockTokenService)
+		handlerObj := handler.NewSessionHandler(mockSessionService, mockTokenService)
+
+		mockSessionService.On("RevokeSession", mock.Anything, testUserID, "session-1").Return(nil).Once()
+		mockTokenService.On("RevokeSession", mock.Anything, "session-1").Return(errors.New("redis down")).Once()
+
+		rr := httptest.NewRecorder()
+		handlerObj.RevokeUserSession(rr, newRequest("session-1"))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestSessionHandler_RevokeAllUserSessions(t *testing.T) {
+	const testUserID = 7
+
+	t.Run("logs out everywhere", func(t *testing.T) {
+		mockSessionService := new(MockSessionService)
+		mockTokenService := new(MockTokenService)
+		handlerObj := handler.NewSessionHandler(mockSessionService, mockTokenService)
+
+		mockSessionService.On("RevokeAllSessions", mock.Anything, testUserID).Return([]string{"session-1", "session-2"}, nil).Once()
+		mockTokenService.On("RevokeSession", mock.Anything, "session-1").Return(nil).Once()
+		mockTokenService.On("RevokeSession", mock.Anything, "session-2").Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/user/sessions", nil)
+		req = req.WithContext(helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: testUserID}))
+		rr := httptest.NewRecorder()
+
+		handlerObj.RevokeAllUserSessions(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		mockSessionService.AssertExpectations(t)
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mockSessionService := new(MockSessionService)
+		mockTokenService := new(MockTokenService)
+		handlerObj := handler.NewSessionHandler(mockSessionService, mockTokenService)
+
+		mockSessionService.On("RevokeAllSessions", mock.Anything, testUserID).Return(nil, errors.New("update failed")).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/user/sessions", nil)
+		req = req.WithContext(helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: testUserID}))
+		rr := httptest.NewRecorder()
+
+		handlerObj.RevokeAllUserSessions(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		mockTokenService.AssertNotCalled(t, "RevokeSession", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,348 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util"
+	"workout-tracker-api/internal/util/helper"
+	"workout-tracker-api/pkg/api"
+)
+
+type TemplateHandler struct {
+	WorkoutService  service.WorkoutServiceInterface
+	TemplateService service.TemplateServiceInterface
+}
+
+func NewTemplateHandler(ws service.WorkoutServiceInterface, ts service.TemplateServiceInterface) *TemplateHandler {
+	return &TemplateHandler{
+		WorkoutService:  ws,
+		TemplateService: ts,
+	}
+}
+
+// ListTemplates
+func (h *TemplateHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	wtList, err := h.TemplateService.ListTemplates(r.Context(), userInfo.Id)
+	if err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to fetch workout templates: %w", err))
+		return
+	}
+
+	var templates []api.WorkoutTemplate
+	for _, wt := range wtList {
+		apiWT := toAPITemplate(&wt)
+		templates = append(templates, *apiWT)
+	}
+
+	response := api.Success{
+		Code:    api.FETCH,
+		Message: "successfully fetch workout templates",
+		Payload: &map[string]any{
+			"templates": templates,
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// CreateTemplate
+func (h *TemplateHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	var req api.CreateTemplateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding creating request: %v", err)
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	var input = service.WorkoutTemplateCreate{
+		UserId:        userInfo.Id,
+		Name:          req.Name,
+		Description:   req.Description,
+		ExercisePlans: toServiceCreateEPs(req.ExercisePlans),
+	}
+
+	wt, err := h.TemplateService.CreateTemplate(r.Context(), input)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("error creating workout template: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.CREATED,
+		Message: "successfully create workout template",
+		Payload: &map[string]any{
+			"template": toAPITemplate(wt),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusCreated, &response)
+}
+
+// GetTemplateById
+func (h *TemplateHandler) GetTemplateById(w http.ResponseWriter, r *http.Request) {
+	wt, err := templateAuth(w, r, h.TemplateService)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	response := api.Success{
+		Code:    api.FETCH,
+		Message: "successfully fetch workout template",
+		Payload: &map[string]any{
+			"template": toAPITemplate(wt),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// UpdateTemplate
+func (h *TemplateHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	wt, err := templateAuth(w, r, h.TemplateService)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	var req api.UpdateTemplateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding updating request: %v", err)
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	input := service.WorkoutTemplateUpdate{
+		Id:          wt.Id,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	if req.ExercisePlans != nil {
+		input.ExercisePlans = toServiceCreateEPs(*req.ExercisePlans)
+	}
+
+	updatedWT, err := h.TemplateService.UpdateTemplate(r.Context(), input)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to update workout template: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.UPDATE,
+		Message: "successfully update workout template",
+		Payload: &map[string]any{
+			"template": toAPITemplate(updatedWT),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// DeleteTemplateById
+func (h *TemplateHandler) DeleteTemplateById(w http.ResponseWriter, r *http.Request) {
+	wt, err := templateAuth(w, r, h.TemplateService)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	if err := h.TemplateService.DeleteTemplateById(r.Context(), wt.Id); err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to delete workout template: %w", err))
+		return
+	}
+
+	helper.SendSuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// InstantiateTemplate
+func (h *TemplateHandler) InstantiateTemplate(w http.ResponseWriter, r *http.Request) {
+	wt, err := templateAuth(w, r, h.TemplateService)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	var req api.InstantiateTemplateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding instantiate request: %v", err)
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	var scheduledDate = &req.ScheduledDate
+	if req.ScheduledDate.IsZero() {
+		scheduledDate = nil
+	}
+
+	wp, err := h.TemplateService.InstantiateTemplate(r.Context(), wt.Id, scheduledDate)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("error creating workout plan from template: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.CREATED,
+		Message: "successfully create workout plan from template",
+		Payload: &map[string]any{
+			"workoutPlan": toAPIWorkout(wp),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusCreated, &response)
+}
+
+// SaveWorkoutAsTemplate
+func (h *TemplateHandler) SaveWorkoutAsTemplate(w http.ResponseWriter, r *http.Request) {
+	wpId, err := doubleAuth(w, r, h.WorkoutService)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	var req api.SaveWorkoutAsTemplateJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding save as template request: %v", err)
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	wt, err := h.TemplateService.SaveWorkoutAsTemplate(r.Context(), wpId, req.Name, req.Description)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to save workout plan as template: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.CREATED,
+		Message: "successfully save workout plan as template",
+		Payload: &map[string]any{
+			"template": toAPITemplate(wt),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusCreated, &response)
+}
+
+// templateAuth makes sure the template in the path belongs to the user, like doubleAuth does for workout plans
+func templateAuth(w http.ResponseWriter, r *http.Request, templateService service.TemplateServiceInterface) (*service.WorkoutTemplate, error) {
+	wtId, err := pathID(w, r, "templateId")
+	if err != nil {
+		return nil, err
+	}
+
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		err := fmt.Errorf("failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return nil, err
+	}
+
+	existingWT, err := templateService.GetTemplateById(r.Context(), wtId)
+	if err != nil {
+		err := fmt.Errorf("error fetching workout template %d for operation by user %d", wtId, userInfo.Id)
+		helper.SendErrorResponse(w, apperrors.ErrNotFound)
+		return nil, err
+	}
+
+	if existingWT.UserId != userInfo.Id {
+		err := fmt.Errorf("unauthorized attempt: User %d tried to operate workout template %d", userInfo.Id, wtId)
+		helper.SendErrorResponse(w, apperrors.ErrForbidden)
+		return nil, err
+	}
+
+	return existingWT, nil
+}
+
+func toServiceCreateEPs(apiEPs []api.CreateExercisePlan) []service.ExercisePlanCreate {
+	createEPs := []service.ExercisePlanCreate{}
+	for _, ep := range apiEPs {
+		createEP := toServiceCreateEP(&ep)
+		createEPs = append(createEPs, *createEP)
+	}
+
+	return createEPs
+}
+
+func toAPICreateEP(ep *service.ExercisePlanCreate) *api.CreateExercisePlan {
+	if ep == nil {
+		return nil
+	}
+
+	return &api.CreateExercisePlan{
+		ExerciseId:  util.IntTo64(ep.ExerciseId),
+		Repetitions: &ep.Repetitions,
+		Sets:        &ep.Sets,
+		Weights:     &ep.Weights,
+		WeightUnit:  (*api.WeightUnit)(&ep.WeightUnit),
+	}
+}
+
+func toAPITemplate(wt *service.WorkoutTemplate) *api.WorkoutTemplate {
+	if wt == nil {
+		return nil
+	}
+
+	createdAt := wt.CreatedAt
+	updatedAt := wt.UpdatedAt
+	name := wt.Name
+
+	var exercisePlans []api.CreateExercisePlan
+	for _, ep := range wt.ExercisePlans {
+		apiEP := toAPICreateEP(&ep)
+		exercisePlans = append(exercisePlans, *apiEP)
+	}
+
+	return &api.WorkoutTemplate{
+		Id:            util.IntTo64(wt.Id),
+		UserId:        util.IntTo64(wt.UserId),
+		Name:          &name,
+		Description:   wt.Description,
+		CreatedAt:     &createdAt,
+		UpdatedAt:     &updatedAt,
+		ExercisePlans: &exercisePlans,
+	}
+}
@@ -0,0 +1,315 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/handler"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util/helper"
+	"workout-tracker-api/pkg/api"
+)
+
+// MockTemplateService is a mock implementation of service.TemplateServiceInterface
+type MockTemplateService struct {
+	mock.Mock
+}
+
+func (m *MockTemplateService) CreateTemplate(ctx context.Context, data service.WorkoutTemplateCreate) (*service.WorkoutTemplate, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutTemplate), args.Error(1)
+}
+
+func (m *MockTemplateService) GetTemplateById(ctx context.Context, id int) (*service.WorkoutTemplate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutTemplate), args.Error(1)
+}
+
+func (m *MockTemplateService) ListTemplates(ctx context.Context, userId int) ([]service.WorkoutTemplate, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.WorkoutTemplate), args.Error(1)
+}
+
+func (m *MockTemplateService) UpdateTemplate(ctx context.Context, data service.WorkoutTemplateUpdate) (*service.WorkoutTemplate, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutTemplate), args.Error(1)
+}
+
+func (m *MockTemplateService) DeleteTemplateById(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTemplateService) InstantiateTemplate(ctx context.Context, id int, scheduledDate *time.Time) (*service.WorkoutPlan, error) {
+	args := m.Called(ctx, id, scheduledDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutPlan), args.Error(1)
+}
+
+func (m *MockTemplateService) SaveWorkoutAsTemplate(ctx context.Context, workoutId int, name string, description *string) (*service.WorkoutTemplate, error) {
+	args := m.Called(ctx, workoutId, name, description)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutTemplate), args.Error(1)
+}
+
+func TestTemplateHandler(t *testing.T) {
+	testUserID := 123
+	templateID := 1
+	now := time.Now().UTC().Truncate(time.Second)
+
+	epsCreate := []service.ExercisePlanCreate{
+		{ExerciseId: 3, Sets: 5, Repetitions: 5, Weights: 100, WeightUnit: service.KG},
+	}
+	existingTemplate := &service.WorkoutTemplate{
+		Id:            templateID,
+		UserId:        testUserID,
+		Name:          "Leg day",
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		ExercisePlans: epsCreate,
+	}
+
+	createRequest := func(method string, url string, pathValues map[string]string, body []byte) *http.Request {
+		req := httptest.NewRequest(method, url, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		for name, value := range pathValues {
+			req.SetPathValue(name, value)
+		}
+
+		userInfo := helper.UserInfo{
+			Email: "test@example.com",
+			Name:  "Test User",
+			Id:    testUserID,
+		}
+		ctx := context.WithValue(req.Context(), helper.UserContextKey, &userInfo)
+		return req.WithContext(ctx)
+	}
+
+	templatePath := map[string]string{"templateId": strconv.Itoa(templateID)}
+
+	exerciseId := int64(3)
+	sets, reps := 5, 5
+	weights := float32(100)
+	unit := api.WeightUnitKg
+	apiEPs := []api.CreateExercisePlan{{ExerciseId: &exerciseId, Sets: &sets, Repetitions: &reps, Weights: &weights, WeightUnit: &unit}}
+
+	t.Run("CreateTemplate", func(t *testing.T) {
+		t.Run("Successful creation", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			mockTService := new(MockTemplateService)
+			tHandler := handler.NewTemplateHandler(mockWorkoutService, mockTService)
+
+			body, _ := json.Marshal(api.CreateTemplateJSONRequestBody{Name: "Leg day", ExercisePlans: apiEPs})
+
+			mockTService.On("CreateTemplate", mock.Anything, service.WorkoutTemplateCreate{
+				UserId:        testUserID,
+				Name:          "Leg day",
+				ExercisePlans: epsCreate,
+			}).Return(existingTemplate, nil).Once()
+
+			req := createRequest(http.MethodPost, "/templates", nil, body)
+			rr := httptest.NewRecorder()
+
+			tHandler.CreateTemplate(rr, req)
+
+			assert.Equal(t, http.StatusCreated, rr.Code)
+			var resp api.Success
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, api.CREATED, resp.Code)
+			assert.Contains(t, *resp.Payload, "template")
+			mockTService.AssertExpectations(t)
+		})
+
+		t.Run("Validation error", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			mockTService := new(MockTemplateService)
+			tHandler := handler.NewTemplateHandler(mockWorkoutService, mockTService)
+
+			body, _ := json.Marshal(api.CreateTemplateJSONRequestBody{Name: "", ExercisePlans: apiEPs})
+
+			mockTService.On("CreateTemplate", mock.Anything, mock.AnythingOfType("service.WorkoutTemplateCreate")).
+				Return(nil, apperrors.NewValidationError(apperrors.INVALID_NAME, "Set the name length between 1 and 100")).Once()
+
+			req := createRequest(http.MethodPost, "/templates", nil, body)
+			rr := httptest.NewRecorder()
+
+			tHandler.CreateTemplate(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			var resp api.Error
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, string(apperrors.INVALID_NAME), resp.Code)
+		})
+	})
+
+	t.Run("GetTemplateById", func(t *testing.T) {
+		t.Run("Successful get", func(t *testing.T) {
+			mockTService := new(MockTemplateService)
+			tHandler := handler.NewTemplateHandler(new(MockWorkoutService), mockTService)
+
+			mockTService.On("GetTemplateById", mock.Anything, templateID).Return(existingTemplate, nil).Once()
+
+			req := createRequest(http.MethodGet, "/templates/1", templatePath, nil)
+			rr := httptest.NewRecorder()
+
+			tHandler.GetTemplateById(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			var resp api.Success
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, api.FETCH, resp.Code)
+			template := (*resp.Payload)["template"].(map[string]any)
+			assert.Equal(t, "Leg day", template["name"])
+			assert.Len(t, template["exercisePlans"], 1)
+		})
+
+		t.Run("Forbidden (user does not own template)", func(t *testing.T) {
+			mockTService := new(MockTemplateService)
+			tHandler := handler.NewTemplateHandler(new(MockWorkoutService), mockTService)
+
+			mockTService.On("GetTemplateById", mock.Anything, templateID).Return(&service.WorkoutTemplate{Id: templateID, UserId: 9999}, nil).Once()
+
+			req := createRequest(http.MethodGet, "/templates/1", templatePath, nil)
+			rr := httptest.NewRecorder()
+
+			tHandler.GetTemplateById(rr, req)
+
+			assert.Equal(t, http.StatusForbidden, rr.Code)
+		})
+	})
+
+	t.Run("DeleteTemplateById", func(t *testing.T) {
+		mockTService := new(MockTemplateService)
+		tHandler := handler.NewTemplateHandler(new(MockWorkoutService), mockTService)
+
+		mockTService.On("GetTemplateById", mock.Anything, templateID).Return(existingTemplate, nil).Once()
+		mockTService.On("DeleteTemplateById", mock.Anything, templateID).Return(nil).Once()
+
+		req := createRequest(http.MethodDelete, "/templates/1", templatePath, nil)
+		rr := httptest.NewRecorder()
+
+		tHandler.DeleteTemplateById(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		mockTService.AssertExpectations(t)
+	})
+
+	t.Run("InstantiateTemplate", func(t *testing.T) {
+		scheduledDate := time.Date(2025, 6, 2, 7, 0, 0, 0, time.UTC)
+
+		t.Run("Successful instantiate", func(t *testing.T) {
+			mockTService := new(MockTemplateService)
+			tHandler := handler.NewTemplateHandler(new(MockWorkoutService), mockTService)
+
+			body, _ := json.Marshal(api.InstantiateTemplateJSONRequestBody{ScheduledDate: scheduledDate})
+
+			mockTService.On("GetTemplateById", mock.Anything, templateID).Return(existingTemplate, nil).Once()
+			mockTService.On("InstantiateTemplate", mock.Anything, templateID, &scheduledDate).Return(&service.WorkoutPlan{
+				Id: 10, UserId: testUserID, Status: service.PENDING, ScheduledDate: scheduledDate,
+				ExercisePlans: []service.ExercisePlan{{Id: 100, ExerciseId: 3, WorkoutPlanId: 10, Sets: 5, Repetitions: 5, Weights: 100, WeightUnit: service.KG}},
+			}, nil).Once()
+
+			req := createRequest(http.MethodPost, "/templates/1/instantiate", templatePath, body)
+			rr := httptest.NewRecorder()
+
+			tHandler.InstantiateTemplate(rr, req)
+
+			assert.Equal(t, http.StatusCreated, rr.Code)
+			var resp api.Success
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, api.CREATED, resp.Code)
+			assert.Contains(t, *resp.Payload, "workoutPlan")
+			mockTService.AssertExpectations(t)
+		})
+
+		t.Run("Missing scheduled date", func(t *testing.T) {
+			mockTService := new(MockTemplateService)
+			tHandler := handler.NewTemplateHandler(new(MockWorkoutService), mockTService)
+
+			mockTService.On("GetTemplateById", mock.Anything, templateID).Return(existingTemplate, nil).Once()
+			mockTService.On("InstantiateTemplate", mock.Anything, templateID, (*time.Time)(nil)).
+				Return(nil, apperrors.NewValidationError(apperrors.INVALID_DATE, "not valid scheduled date, date is not set")).Once()
+
+			req := createRequest(http.MethodPost, "/templates/1/instantiate", templatePath, []byte(`{}`))
+			rr := httptest.NewRecorder()
+
+			tHandler.InstantiateTemplate(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			var resp api.Error
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, string(apperrors.INVALID_DATE), resp.Code)
+		})
+	})
+
+	t.Run("SaveWorkoutAsTemplate", func(t *testing.T) {
+		workoutID := 10
+		workoutPath := map[string]string{"workoutId": strconv.Itoa(workoutID)}
+
+		t.Run("Successful save", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			mockTService := new(MockTemplateService)
+			tHandler := handler.NewTemplateHandler(mockWorkoutService, mockTService)
+
+			body, _ := json.Marshal(api.SaveWorkoutAsTemplateJSONRequestBody{Name: "Leg day"})
+
+			mockWorkoutService.On("GetWorkoutById", mock.Anything, workoutID).Return(&service.WorkoutPlan{Id: workoutID, UserId: testUserID}, nil).Once()
+			mockTService.On("SaveWorkoutAsTemplate", mock.Anything, workoutID, "Leg day", (*string)(nil)).Return(existingTemplate, nil).Once()
+
+			req := createRequest(http.MethodPost, "/workouts/10/save-as-template", workoutPath, body)
+			rr := httptest.NewRecorder()
+
+			tHandler.SaveWorkoutAsTemplate(rr, req)
+
+			assert.Equal(t, http.StatusCreated, rr.Code)
+			mockTService.AssertExpectations(t)
+		})
+
+		t.Run("Forbidden (user does not own workout)", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			mockTService := new(MockTemplateService)
+			tHandler := handler.NewTemplateHandler(mockWorkoutService, mockTService)
+
+			mockWorkoutService.On("GetWorkoutById", mock.Anything, workoutID).Return(&service.WorkoutPlan{Id: workoutID, UserId: 9999}, nil).Once()
+
+			req := createRequest(http.MethodPost, "/workouts/10/save-as-template", workoutPath, []byte(`{"name":"Leg day"}`))
+			rr := httptest.NewRecorder()
+
+			tHandler.SaveWorkoutAsTemplate(rr, req)
+
+			assert.Equal(t, http.StatusForbidden, rr.Code)
+			mockTService.AssertNotCalled(t, "SaveWorkoutAsTemplate")
+		})
+	})
+}
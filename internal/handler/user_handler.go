@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"workout-tracker-api/internal/apperrors"
 	"workout-tracker-api/internal/service"
@@ -13,20 +14,28 @@ import (
 	"workout-tracker-api/internal/util/helper"
 	"workout-tracker-api/pkg/api"
 
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/oapi-codegen/runtime/types"
 )
 
 type UserHandler struct {
-	UserService    service.UserServiceInterface
-	WorkoutService service.WorkoutServiceInterface
-	TokenService   auth.TokenInterface
+	UserService      service.UserServiceInterface
+	WorkoutService   service.WorkoutServiceInterface
+	TokenService     auth.TokenInterface
+	RefreshService   service.RefreshTokenServiceInterface
+	SessionService   service.SessionServiceInterface
+	TwoFactorService service.TwoFactorServiceInterface
 }
 
-func NewUserHandler(us service.UserServiceInterface, ws service.WorkoutServiceInterface, ts auth.TokenInterface) *UserHandler {
+func NewUserHandler(us service.UserServiceInterface, ws service.WorkoutServiceInterface, ts auth.TokenInterface, rs service.RefreshTokenServiceInterface, ss service.SessionServiceInterface, tfs service.TwoFactorServiceInterface) *UserHandler {
 	return &UserHandler{
-		UserService:    us,
-		WorkoutService: ws,
-		TokenService:   ts,
+		UserService:      us,
+		WorkoutService:   ws,
+		TokenService:     ts,
+		RefreshService:   rs,
+		SessionService:   ss,
+		TwoFactorService: tfs,
 	}
 }
 
@@ -44,7 +53,7 @@ func (h *UserHandler) SignupUser(w http.ResponseWriter, r *http.Request) {
 	_, err := h.UserService.SignupUser(ctx, service.UserSignup{
 		Name:     req.Name,
 		Email:    string(req.Email),
-		Password: *req.Password,
+		Password: req.Password,
 	})
 	if err != nil {
 
@@ -75,19 +84,16 @@ func (h *UserHandler) LoginUser(w http.ResponseWriter, r *http.Request) {
 
 	// Map API request to service layer input
 	serviceLogin := service.UserLogin{
-		Email:    string(loginRequest.Email),
-		Password: loginRequest.Password,
+		Email:     string(loginRequest.Email),
+		Password:  loginRequest.Password,
+		IPAddress: clientIP(r),
 	}
 
 	// Call the service layer
 	user, err := h.UserService.LoginUser(r.Context(), serviceLogin)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrNotFound) {
-			helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_EMAIL, "the email is not registered"))
-			return
-		}
 		var ValidationErr *apperrors.ValidationError
-		if errors.As(err, &ValidationErr) {
+		if errors.As(err, &ValidationErr) || errors.Is(err, apperrors.ErrForbidden) || errors.Is(err, apperrors.ErrTooManyRequests) {
 			helper.SendErrorResponse(w, err)
 			return
 		}
@@ -97,13 +103,81 @@ func (h *UserHandler) LoginUser(w http.ResponseWriter, r *http.Request) {
 
 	}
 
+	// with 2FA the password only earns a challenge, the tokens come from CompleteTwoFactorLogin
+	enabled, err := h.TwoFactorService.IsEnabled(r.Context(), user.Id)
+	if err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to check two-factor authentication: %w", err))
+		return
+	}
+	if enabled {
+		challenge, err := h.TwoFactorService.CreateChallenge(r.Context(), user.Id)
+		if err != nil {
+			helper.SendErrorResponse(w, fmt.Errorf("failed to create login challenge: %w", err))
+			return
+		}
+
+		helper.SendSuccessResponse(w, http.StatusOK, &api.Success{
+			Code:    api.FETCH,
+			Message: "two-factor code required",
+			Payload: &map[string]any{
+				"twoFactorRequired": true,
+				"challengeToken":    challenge,
+			},
+		})
+		return
+	}
+
+	h.startLogin(w, r, user)
+}
+
+// CompleteTwoFactorLogin handles POST /user/login/2fa requests.
+func (h *UserHandler) CompleteTwoFactorLogin(w http.ResponseWriter, r *http.Request) {
+	var req api.CompleteTwoFactorLoginJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ChallengeToken == "" || req.Code == "" {
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	user, err := h.TwoFactorService.CompleteChallenge(r.Context(), req.ChallengeToken, req.Code, clientIP(r))
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) || errors.Is(err, apperrors.ErrUnauthorized) || errors.Is(err, apperrors.ErrTooManyRequests) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+		helper.SendErrorResponse(w, fmt.Errorf("failed to complete two-factor login: %w", err))
+		return
+	}
+
+	h.startLogin(w, r, user)
+}
+
+// startLogin opens a session for the authenticated user and responds with its tokens
+func (h *UserHandler) startLogin(w http.ResponseWriter, r *http.Request, user *service.User) {
+	// every login is a session, its id goes into the tokens so it can be revoked later
+	jti := uuid.New().String()
+	session, err := h.SessionService.StartSession(r.Context(), service.SessionStart{
+		UserId:    user.Id,
+		UserAgent: r.UserAgent(),
+		IPAddress: clientIP(r),
+		JTI:       jti,
+	})
+	if err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to start session after login: %w", err))
+		return
+	}
+
 	// Generate JWT token upon successful login
 	token, err := h.TokenService.GenerateToken(auth.Claims{
 		Payload: auth.Payload{
-			Id:    &user.Id,
-			Email: user.Email,
-			Name:  user.Name,
+			Id:            &user.Id,
+			Email:         user.Email,
+			Name:          user.Name,
+			SessionId:     session.Id,
+			EmailVerified: user.EmailVerified,
+			Role:          string(user.Role),
 		},
+		RegisteredClaims: jwt.RegisteredClaims{ID: jti},
 	})
 
 	if err != nil {
@@ -111,19 +185,95 @@ func (h *UserHandler) LoginUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	refresh, err := h.RefreshService.IssueRefreshToken(r.Context(), user.Id, session.Id)
+	if err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to issue refresh token after login: %w", err))
+		return
+	}
+
 	var accessToken api.UserToken = token
+	var refreshToken api.UserToken = refresh
 
 	response := api.Success{
 		Code:    api.FETCH,
 		Message: "successfull login",
 		Payload: &map[string]any{
-			"accessToken": accessToken,
+			"accessToken":  accessToken,
+			"refreshToken": refreshToken,
 		},
 	}
 	// Map service user to API response struct
 	helper.SendSuccessResponse(w, http.StatusOK, &response)
 }
 
+// RefreshUserToken handles POST /user/token/refresh requests.
+func (h *UserHandler) RefreshUserToken(w http.ResponseWriter, r *http.Request) {
+	var req api.RefreshUserTokenJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	rotated, err := h.RefreshService.RotateRefreshToken(r.Context(), req.RefreshToken)
+	if err != nil {
+		// the session is revoked, its access tokens have to stop working as well
+		var reuseErr *apperrors.TokenReuseError
+		if errors.As(err, &reuseErr) {
+			if err := h.TokenService.RevokeSession(r.Context(), reuseErr.SessionId); err != nil {
+				log.Printf("Failed to revoke tokens of session '%s' after refresh token reuse: %v", reuseErr.SessionId, err)
+			}
+			helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+			return
+		}
+
+		if errors.Is(err, apperrors.ErrUnauthorized) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+		helper.SendErrorResponse(w, fmt.Errorf("failed to refresh token: %w", err))
+		return
+	}
+
+	jti := uuid.New().String()
+	token, err := h.TokenService.GenerateToken(auth.Claims{
+		Payload: auth.Payload{
+			Id:            &rotated.User.Id,
+			Email:         rotated.User.Email,
+			Name:          rotated.User.Name,
+			SessionId:     rotated.SessionId,
+			EmailVerified: rotated.User.EmailVerified,
+			Role:          string(rotated.User.Role),
+		},
+		RegisteredClaims: jwt.RegisteredClaims{ID: jti},
+	})
+	if err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to generate token after refresh: %w", err))
+		return
+	}
+
+	if err := h.SessionService.TouchSession(r.Context(), rotated.SessionId, jti); err != nil {
+		// the session was revoked while the refresh was running
+		if errors.Is(err, apperrors.ErrNotFound) {
+			helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+			return
+		}
+		helper.SendErrorResponse(w, fmt.Errorf("failed to update session after refresh: %w", err))
+		return
+	}
+
+	var accessToken api.UserToken = token
+	var refreshToken api.UserToken = rotated.RefreshToken
+
+	helper.SendSuccessResponse(w, http.StatusOK, &api.Success{
+		Code:    api.FETCH,
+		Message: "successfully refresh token",
+		Payload: &map[string]any{
+			"accessToken":  accessToken,
+			"refreshToken": refreshToken,
+		},
+	})
+}
+
 // LogoutUser handles POST /user/logout requests.
 func (h *UserHandler) LogoutUser(w http.ResponseWriter, r *http.Request) {
 	jti, ok := helper.GetJTIFromContext(r.Context())
@@ -133,6 +283,27 @@ func (h *UserHandler) LogoutUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// end the session of the token, which revokes its refresh tokens as well
+	if jti != nil && jti.SessionId != "" {
+		userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+		if !ok {
+			log.Printf("Failed to get user info from context")
+			helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+			return
+		}
+
+		err := h.SessionService.RevokeSession(r.Context(), userInfo.Id, jti.SessionId)
+		if err != nil && !errors.Is(err, apperrors.ErrNotFound) {
+			helper.SendErrorResponse(w, fmt.Errorf("failed to revoke session: %w", err))
+			return
+		}
+
+		if err := h.TokenService.RevokeSession(r.Context(), jti.SessionId); err != nil {
+			helper.SendErrorResponse(w, fmt.Errorf("failed to revoke session tokens: %w", err))
+			return
+		}
+	}
+
 	if jti != nil {
 		err := h.TokenService.BlacklistToken(r.Context(), jti.Id, jti.ExpirationTime)
 		if err != nil {
@@ -193,3 +364,318 @@ func (h *UserHandler) GetUserStatus(w http.ResponseWriter, r *http.Request) {
 	})
 
 }
+
+// UpdateUserPreferences handles PUT /user/preferences requests.
+func (h *UserHandler) UpdateUserPreferences(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	var req api.UpdateUserPreferencesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	input := service.UserPreferences{
+		PreferredUnit: service.WeightUnit(req.PreferredUnit),
+	}
+	if req.TimeZone != nil {
+		input.TimeZone = *req.TimeZone
+	}
+
+	preferences, err := h.UserService.UpdatePreferences(r.Context(), userInfo.Id, input)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) || errors.Is(err, apperrors.ErrNotFound) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to update user preferences: %w", err))
+		return
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &api.Success{
+		Code:    api.UPDATE,
+		Message: "successfully update user preferences",
+		Payload: &map[string]interface{}{
+			"preferences": api.UserPreferences{
+				PreferredUnit: api.ReportUnit(preferences.PreferredUnit),
+				TimeZone:      &preferences.TimeZone,
+			},
+		},
+	})
+}
+
+// ChangeUserPassword ends every session of the user, the client has to log in again
+func (h *UserHandler) ChangeUserPassword(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	var req api.ChangeUserPasswordJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	revoked, err := h.UserService.ChangePassword(r.Context(), userInfo.Id, service.UserPasswordChange{
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+		IPAddress:       clientIP(r),
+	})
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) || errors.Is(err, apperrors.ErrTooManyRequests) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+		if errors.Is(err, apperrors.ErrNotFound) {
+			helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to change password: %w", err))
+		return
+	}
+
+	if err := revokeSessionTokens(r.Context(), h.TokenService, revoked); err != nil {
+		helper.SendErrorResponse(w, err)
+		return
+	}
+
+	helper.SendSuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// RequestPasswordReset answers the same way whether the email is registered or not
+func (h *UserHandler) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var req api.RequestPasswordResetJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email == "" {
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	if err := h.UserService.RequestPasswordReset(r.Context(), string(req.Email)); err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to request password reset: %w", err))
+		return
+	}
+
+	helper.SendSuccessResponse(w, http.StatusAccepted, nil)
+}
+
+// ResetUserPassword
+func (h *UserHandler) ResetUserPassword(w http.ResponseWriter, r *http.Request) {
+	var req api.ResetUserPasswordJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	revoked, err := h.UserService.ResetPassword(r.Context(), service.UserPasswordReset{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+	})
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to reset password: %w", err))
+		return
+	}
+
+	if err := revokeSessionTokens(r.Context(), h.TokenService, revoked); err != nil {
+		helper.SendErrorResponse(w, err)
+		return
+	}
+
+	helper.SendSuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// VerifyUserEmail handles POST /user/verify-email requests.
+func (h *UserHandler) VerifyUserEmail(w http.ResponseWriter, r *http.Request) {
+	var req api.VerifyUserEmailJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	if err := h.UserService.VerifyEmail(r.Context(), req.Token); err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to verify email: %w", err))
+		return
+	}
+
+	helper.SendSuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// ResendVerificationEmail answers the same way for unknown and already verified emails
+func (h *UserHandler) ResendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	var req api.ResendVerificationEmailJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email == "" {
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	if err := h.UserService.ResendVerification(r.Context(), string(req.Email)); err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to resend verification email: %w", err))
+		return
+	}
+
+	helper.SendSuccessResponse(w, http.StatusAccepted, nil)
+}
+
+// EnrollTwoFactor handles POST /user/2fa/enroll requests.
+func (h *UserHandler) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	enrollment, err := h.TwoFactorService.Enroll(r.Context(), userInfo.Id)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrAlreadyExists) || errors.Is(err, apperrors.ErrNotFound) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+		helper.SendErrorResponse(w, fmt.Errorf("failed to enroll two-factor authentication: %w", err))
+		return
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &api.Success{
+		Code:    api.CREATED,
+		Message: "successfully enroll two-factor authentication",
+		Payload: &map[string]any{
+			"enrollment": api.TwoFactorEnrollment{
+				Secret:     &enrollment.Secret,
+				OtpauthUri: &enrollment.URI,
+			},
+		},
+	})
+}
+
+// ConfirmTwoFactor handles POST /user/2fa/confirm requests, the recovery codes are only sent here
+func (h *UserHandler) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	var req api.ConfirmTwoFactorJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	recoveryCodes, err := h.TwoFactorService.Confirm(r.Context(), userInfo.Id, req.Code)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) || errors.Is(err, apperrors.ErrNotFound) || errors.Is(err, apperrors.ErrAlreadyExists) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+		helper.SendErrorResponse(w, fmt.Errorf("failed to confirm two-factor authentication: %w", err))
+		return
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &api.Success{
+		Code:    api.UPDATE,
+		Message: "successfully enable two-factor authentication",
+		Payload: &map[string]any{
+			"recoveryCodes": recoveryCodes,
+		},
+	})
+}
+
+// DisableTwoFactor handles DELETE /user/2fa requests.
+func (h *UserHandler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+	if !ok {
+		log.Printf("Failed to get user info from context")
+		helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+		return
+	}
+
+	var req api.DisableTwoFactorJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	if err := h.TwoFactorService.Disable(r.Context(), userInfo.Id, req.Code); err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) || errors.Is(err, apperrors.ErrNotFound) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+		helper.SendErrorResponse(w, fmt.Errorf("failed to disable two-factor authentication: %w", err))
+		return
+	}
+
+	helper.SendSuccessResponse(w, http.StatusNoContent, nil)
+}
+
+// GetJwks handles GET /.well-known/jwks.json requests. The key set is sent as it is, not in the
+// success envelope, so standard JWT libraries can read it.
+func (h *UserHandler) GetJwks(w http.ResponseWriter, r *http.Request) {
+	jwks := h.TokenService.JWKS()
+
+	keys := make([]api.JWK, 0, len(jwks.Keys))
+	for _, key := range jwks.Keys {
+		keys = append(keys, toAPIJWK(key))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(api.JWKSet{Keys: &keys})
+}
+
+func toAPIJWK(key auth.JWK) api.JWK {
+	optional := func(value string) *string {
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+
+	return api.JWK{
+		Kty: &key.Kty,
+		Kid: &key.Kid,
+		Use: &key.Use,
+		Alg: &key.Alg,
+		Crv: optional(key.Crv),
+		X:   optional(key.X),
+		Y:   optional(key.Y),
+		N:   optional(key.N),
+		E:   optional(key.E),
+	}
+}
+
+// clientIP is the address chimiddleware.RealIP put in RemoteAddr, without the port when the
+// request came in directly
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -51,6 +52,45 @@ func (m *MockUserService) GetUser(ctx context.Context, email string) (*service.U
 	return args.Get(0).(*service.User), args.Error(1)
 }
 
+func (m *MockUserService) UpdatePreferences(ctx context.Context, userId int, input service.UserPreferences) (*service.UserPreferences, error) {
+	args := m.Called(ctx, userId, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.UserPreferences), args.Error(1)
+}
+
+func (m *MockUserService) ChangePassword(ctx context.Context, userId int, input service.UserPasswordChange) ([]string, error) {
+	args := m.Called(ctx, userId, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockUserService) RequestPasswordReset(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *MockUserService) ResetPassword(ctx context.Context, input service.UserPasswordReset) ([]string, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockUserService) VerifyEmail(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockUserService) ResendVerification(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
 type MockUserWorkoutService struct {
 	mock.Mock
 }
@@ -95,6 +135,30 @@ func (m *MockUserWorkoutService) UpdateExercisePlans(ctx context.Context, workou
 	return args.Get(0).(*service.WorkoutPlan), args.Error(1)
 }
 
+func (m *MockUserWorkoutService) AddExercisePlan(ctx context.Context, workoutID int, data service.ExercisePlanCreate, position int) (*service.WorkoutPlan, error) {
+	args := m.Called(ctx, workoutID, data, position)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutPlan), args.Error(1)
+}
+
+func (m *MockUserWorkoutService) RemoveExercisePlan(ctx context.Context, workoutID int, exercisePlanId int) (*service.WorkoutPlan, error) {
+	args := m.Called(ctx, workoutID, exercisePlanId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutPlan), args.Error(1)
+}
+
+func (m *MockUserWorkoutService) MoveExercisePlan(ctx context.Context, workoutID int, exercisePlanId int, position int) (*service.WorkoutPlan, error) {
+	args := m.Called(ctx, workoutID, exercisePlanId, position)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutPlan), args.Error(1)
+}
+
 func (m *MockUserWorkoutService) DeleteWorkoutById(ctx context.Context, id int) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -140,14 +204,136 @@ func (m *MockTokenService) CheckBlacklist(ctx context.Context, jti string) (bool
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockTokenService) JWKS() auth.JWKSet {
+	args := m.Called()
+	return args.Get(0).(auth.JWKSet)
+}
+
+func (m *MockTokenService) RevokeSession(ctx context.Context, sessionId string) error {
+	args := m.Called(ctx, sessionId)
+	return args.Error(0)
+}
+
+func (m *MockTokenService) CheckSessionRevoked(ctx context.Context, sessionId string) (bool, error) {
+	args := m.Called(ctx, sessionId)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockRefreshTokenService struct {
+	mock.Mock
+}
+
+func (m *MockRefreshTokenService) IssueRefreshToken(ctx context.Context, userId int, sessionId string) (string, error) {
+	args := m.Called(ctx, userId, sessionId)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockRefreshTokenService) RotateRefreshToken(ctx context.Context, token string) (*service.RotatedRefreshToken, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.RotatedRefreshToken), args.Error(1)
+}
+
+type MockSessionService struct {
+	mock.Mock
+}
+
+func (m *MockSessionService) StartSession(ctx context.Context, input service.SessionStart) (*service.Session, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.Session), args.Error(1)
+}
+
+func (m *MockSessionService) TouchSession(ctx context.Context, sessionId string, jti string) error {
+	args := m.Called(ctx, sessionId, jti)
+	return args.Error(0)
+}
+
+func (m *MockSessionService) ListSessions(ctx context.Context, userId int) ([]service.Session, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.Session), args.Error(1)
+}
+
+func (m *MockSessionService) RevokeSession(ctx context.Context, userId int, sessionId string) error {
+	args := m.Called(ctx, userId, sessionId)
+	return args.Error(0)
+}
+
+func (m *MockSessionService) RevokeAllSessions(ctx context.Context, userId int) ([]string, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+type MockTwoFactorService struct {
+	mock.Mock
+}
+
+func (m *MockTwoFactorService) Enroll(ctx context.Context, userId int) (*service.TOTPEnrollment, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.TOTPEnrollment), args.Error(1)
+}
+
+func (m *MockTwoFactorService) Confirm(ctx context.Context, userId int, code string) ([]string, error) {
+	args := m.Called(ctx, userId, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTwoFactorService) Disable(ctx context.Context, userId int, code string) error {
+	args := m.Called(ctx, userId, code)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorService) VerifyCode(ctx context.Context, userId int, code string) error {
+	args := m.Called(ctx, userId, code)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorService) IsEnabled(ctx context.Context, userId int) (bool, error) {
+	args := m.Called(ctx, userId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTwoFactorService) CreateChallenge(ctx context.Context, userId int) (string, error) {
+	args := m.Called(ctx, userId)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTwoFactorService) CompleteChallenge(ctx context.Context, token string, code string, ipAddress string) (*service.User, error) {
+	args := m.Called(ctx, token, code, ipAddress)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.User), args.Error(1)
+}
+
 // --- Test Suite ---
 
 func TestUserHandler(t *testing.T) {
 	mockUserService := new(MockUserService)
 	mockWorkoutService := new(MockUserWorkoutService)
 	mockTokenService := new(MockTokenService)
+	mockRefreshService := new(MockRefreshTokenService)
+	mockSessionService := new(MockSessionService)
+	mockTwoFactorService := new(MockTwoFactorService)
+	mockTwoFactorService.On("IsEnabled", mock.Anything, mock.Anything).Return(false, nil)
 
-	userHandler := handler.NewUserHandler(mockUserService, mockWorkoutService, mockTokenService)
+	userHandler := handler.NewUserHandler(mockUserService, mockWorkoutService, mockTokenService, mockRefreshService, mockSessionService, mockTwoFactorService)
 
 	// --- Test SignupUser ---
 	t.Run("SignupUser - Success", func(t *testing.T) {
@@ -228,27 +414,32 @@ func TestUserHandler(t *testing.T) {
 		reqBody := `{"email": "user@example.com", "password": "correctpassword"}`
 		req := httptest.NewRequest(http.MethodPost, "/user/login", bytes.NewBufferString(reqBody))
 		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "curl/8.0")
+		req.RemoteAddr = "203.0.113.7:52100"
 		rr := httptest.NewRecorder()
 
 		expectedUserServiceLoginInput := service.UserLogin{
-			Email:    "user@example.com",
-			Password: "correctpassword",
+			Email:     "user@example.com",
+			Password:  "correctpassword",
+			IPAddress: "203.0.113.7",
 		}
 		returnedUser := &service.User{
-			Id:    mockId,
-			Email: "user@example.com",
-			Name:  "Test User",
+			Id:            mockId,
+			Email:         "user@example.com",
+			Name:          "Test User",
+			EmailVerified: true,
 		}
 		mockUserService.On("LoginUser", mock.Anything, expectedUserServiceLoginInput).Return(returnedUser, nil).Once()
 
-		expectedTokenClaims := auth.Claims{
-			Payload: auth.Payload{
-				Id:    &mockId,
-				Email: returnedUser.Email,
-				Name:  returnedUser.Name,
-			},
-		}
-		mockTokenService.On("GenerateToken", expectedTokenClaims).Return("mock_jwt_token", nil).Once()
+		var started service.SessionStart
+		mockSessionService.On("StartSession", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { started = args.Get(1).(service.SessionStart) }).
+			Return(&service.Session{Id: "session-1"}, nil).Once()
+		mockTokenService.On("GenerateToken", mock.MatchedBy(func(claims auth.Claims) bool {
+			return *claims.Id == mockId && claims.Email == returnedUser.Email && claims.Name == returnedUser.Name &&
+				claims.SessionId == "session-1" && claims.ID == started.JTI && claims.EmailVerified
+		})).Return("mock_jwt_token", nil).Once()
+		mockRefreshService.On("IssueRefreshToken", mock.Anything, mockId, "session-1").Return("mock_refresh_token", nil).Once()
 
 		userHandler.LoginUser(rr, req)
 
@@ -259,45 +450,77 @@ func TestUserHandler(t *testing.T) {
 		assert.Equal(t, api.FETCH, resp.Code)
 		assert.Equal(t, "successfull login", resp.Message)
 		assert.Contains(t, (*resp.Payload)["accessToken"].(string), "mock_jwt_token") // Check token in payload
+		assert.Equal(t, "mock_refresh_token", (*resp.Payload)["refreshToken"])
+		assert.Equal(t, "curl/8.0", started.UserAgent)
+		assert.Equal(t, "203.0.113.7", started.IPAddress)
+		assert.NotEmpty(t, started.JTI)
 		mockUserService.AssertExpectations(t)
+		mockSessionService.AssertExpectations(t)
 		mockTokenService.AssertExpectations(t)
+		mockRefreshService.AssertExpectations(t)
+	})
+
+	t.Run("LoginUser - Refresh Token Error", func(t *testing.T) {
+		reqBody := `{"email": "user@example.com", "password": "correctpassword"}`
+		req := httptest.NewRequest(http.MethodPost, "/user/login", bytes.NewBufferString(reqBody))
+		rr := httptest.NewRecorder()
+
+		mockUserService.On("LoginUser", mock.Anything, mock.Anything).Return(&service.User{Id: 3, Email: "user@example.com"}, nil).Once()
+		mockSessionService.On("StartSession", mock.Anything, mock.Anything).Return(&service.Session{Id: "session-3"}, nil).Once()
+		mockTokenService.On("GenerateToken", mock.Anything).Return("mock_jwt_token", nil).Once()
+		mockRefreshService.On("IssueRefreshToken", mock.Anything, 3, "session-3").Return("", errors.New("insert failed")).Once()
+
+		userHandler.LoginUser(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		mockRefreshService.AssertExpectations(t)
 	})
 
-	t.Run("LoginUser - Invalid Credentials (User Not Found)", func(t *testing.T) {
-		reqBody := `{"email": "nonexistent@example.com", "password": "anypassword"}`
+	t.Run("LoginUser - Session Error", func(t *testing.T) {
+		reqBody := `{"email": "user@example.com", "password": "correctpassword"}`
 		req := httptest.NewRequest(http.MethodPost, "/user/login", bytes.NewBufferString(reqBody))
-		req.Header.Set("Content-Type", "application/json")
 		rr := httptest.NewRecorder()
 
-		expectedUserServiceLoginInput := service.UserLogin{
-			Email:    "nonexistent@example.com",
-			Password: "anypassword",
-		}
-		mockUserService.On("LoginUser", mock.Anything, expectedUserServiceLoginInput).Return(nil, apperrors.ErrNotFound).Once()
+		mockUserService.On("LoginUser", mock.Anything, mock.Anything).Return(&service.User{Id: 4, Email: "user@example.com"}, nil).Once()
+		mockSessionService.On("StartSession", mock.Anything, mock.Anything).Return(nil, errors.New("insert failed")).Once()
 
 		userHandler.LoginUser(rr, req)
 
-		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		mockSessionService.AssertExpectations(t)
+	})
+
+	t.Run("LoginUser - Unverified Email Refused", func(t *testing.T) {
+		reqBody := `{"email": "user@example.com", "password": "correctpassword"}`
+		req := httptest.NewRequest(http.MethodPost, "/user/login", bytes.NewBufferString(reqBody))
+		rr := httptest.NewRecorder()
+
+		mockUserService.On("LoginUser", mock.Anything, mock.Anything).
+			Return(nil, fmt.Errorf("%w: email is not verified", apperrors.ErrForbidden)).Once()
+
+		userHandler.LoginUser(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
 		var resp api.Error
 		err := json.NewDecoder(rr.Body).Decode(&resp)
 		assert.NoError(t, err)
-		assert.Equal(t, string(apperrors.INVALID_EMAIL), resp.Code)
-		assert.Contains(t, resp.Message, "the email is not registered")
-		mockUserService.AssertExpectations(t)
-		mockTokenService.AssertNotCalled(t, "GenerateToken")
+		assert.Equal(t, string(apperrors.FORBIDDEN), resp.Code)
 	})
 
-	t.Run("LoginUser - Invalid Credentials (Incorrect Password)", func(t *testing.T) {
+	t.Run("LoginUser - Invalid Credentials", func(t *testing.T) {
 		reqBody := `{"email": "user@example.com", "password": "wrongpassword"}`
 		req := httptest.NewRequest(http.MethodPost, "/user/login", bytes.NewBufferString(reqBody))
 		req.Header.Set("Content-Type", "application/json")
 		rr := httptest.NewRecorder()
 
+		// httptest requests come from 192.0.2.1
 		expectedUserServiceLoginInput := service.UserLogin{
-			Email:    "user@example.com",
-			Password: "wrongpassword",
+			Email:     "user@example.com",
+			Password:  "wrongpassword",
+			IPAddress: "192.0.2.1",
 		}
-		mockUserService.On("LoginUser", mock.Anything, expectedUserServiceLoginInput).Return(nil, apperrors.NewValidationError(apperrors.INVALID_PASSWORD, "invalid password")).Once()
+		mockUserService.On("LoginUser", mock.Anything, expectedUserServiceLoginInput).
+			Return(nil, apperrors.NewValidationError(apperrors.INVALID_CREDENTIALS, "invalid email or password")).Once()
 
 		userHandler.LoginUser(rr, req)
 
@@ -305,12 +528,30 @@ func TestUserHandler(t *testing.T) {
 		var resp api.Error
 		err := json.NewDecoder(rr.Body).Decode(&resp)
 		assert.NoError(t, err)
-		assert.Equal(t, string(apperrors.INVALID_PASSWORD), resp.Code)
-		assert.Contains(t, resp.Message, "invalid password")
+		assert.Equal(t, string(apperrors.INVALID_CREDENTIALS), resp.Code)
+		assert.Contains(t, resp.Message, "invalid email or password")
 		mockUserService.AssertExpectations(t)
 		mockTokenService.AssertNotCalled(t, "GenerateToken")
 	})
 
+	t.Run("LoginUser - Locked Out", func(t *testing.T) {
+		reqBody := `{"email": "user@example.com", "password": "anypassword"}`
+		req := httptest.NewRequest(http.MethodPost, "/user/login", bytes.NewBufferString(reqBody))
+		rr := httptest.NewRecorder()
+
+		mockUserService.On("LoginUser", mock.Anything, mock.Anything).
+			Return(nil, &apperrors.LockoutError{RetryAfter: 90*time.Second + time.Millisecond}).Once()
+
+		userHandler.LoginUser(rr, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.Equal(t, "91", rr.Header().Get("Retry-After"))
+		var resp api.Error
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Equal(t, string(apperrors.TOO_MANY_REQUESTS), resp.Code)
+	})
+
 	t.Run("LoginUser - Token Generation Error", func(t *testing.T) {
 		reqBody := `{"email": "user@example.com", "password": "correctpassword"}`
 		req := httptest.NewRequest(http.MethodPost, "/user/login", bytes.NewBufferString(reqBody))
@@ -323,6 +564,7 @@ func TestUserHandler(t *testing.T) {
 			Name:  "Test User",
 		}
 		mockUserService.On("LoginUser", mock.Anything, mock.Anything).Return(returnedUser, nil).Once()
+		mockSessionService.On("StartSession", mock.Anything, mock.Anything).Return(&service.Session{Id: "session-10"}, nil).Once()
 		mockTokenService.On("GenerateToken", mock.Anything).Return("", errors.New("token generation failed")).Once()
 
 		userHandler.LoginUser(rr, req)
@@ -395,6 +637,164 @@ func TestUserHandler(t *testing.T) {
 		mockTokenService.AssertExpectations(t)
 	})
 
+	t.Run("LogoutUser - Ends The Session", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/user/logout", nil)
+		rr := httptest.NewRecorder()
+
+		testJTI := helper.JTIInfo{
+			Id:             "test-jti-789",
+			SessionId:      "session-1",
+			ExpirationTime: time.Now().Add(time.Hour).UTC(),
+		}
+		ctx := context.WithValue(req.Context(), helper.JTIContextKey, &testJTI)
+		req = req.WithContext(helper.SetUserInfoToContext(ctx, &helper.UserInfo{Id: 7}))
+
+		mockSessionService.On("RevokeSession", mock.Anything, 7, "session-1").Return(nil).Once()
+		mockTokenService.On("RevokeSession", mock.Anything, "session-1").Return(nil).Once()
+		mockTokenService.On("BlacklistToken", mock.Anything, testJTI.Id, testJTI.ExpirationTime).Return(nil).Once()
+
+		userHandler.LogoutUser(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		mockSessionService.AssertExpectations(t)
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("LogoutUser - Session Already Revoked", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/user/logout", nil)
+		rr := httptest.NewRecorder()
+
+		testJTI := helper.JTIInfo{
+			Id:             "test-jti-790",
+			SessionId:      "session-2",
+			ExpirationTime: time.Now().Add(time.Hour).UTC(),
+		}
+		ctx := context.WithValue(req.Context(), helper.JTIContextKey, &testJTI)
+		req = req.WithContext(helper.SetUserInfoToContext(ctx, &helper.UserInfo{Id: 7}))
+
+		mockSessionService.On("RevokeSession", mock.Anything, 7, "session-2").Return(apperrors.ErrNotFound).Once()
+		mockTokenService.On("RevokeSession", mock.Anything, "session-2").Return(nil).Once()
+		mockTokenService.On("BlacklistToken", mock.Anything, testJTI.Id, testJTI.ExpirationTime).Return(nil).Once()
+
+		userHandler.LogoutUser(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		mockSessionService.AssertExpectations(t)
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("LogoutUser - Session Revoke Error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/user/logout", nil)
+		rr := httptest.NewRecorder()
+
+		testJTI := helper.JTIInfo{
+			Id:             "test-jti-791",
+			SessionId:      "session-3",
+			ExpirationTime: time.Now().Add(time.Hour).UTC(),
+		}
+		ctx := context.WithValue(req.Context(), helper.JTIContextKey, &testJTI)
+		req = req.WithContext(helper.SetUserInfoToContext(ctx, &helper.UserInfo{Id: 7}))
+
+		mockSessionService.On("RevokeSession", mock.Anything, 7, "session-3").Return(errors.New("update failed")).Once()
+
+		userHandler.LogoutUser(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		mockTokenService.AssertNotCalled(t, "BlacklistToken", mock.Anything, "test-jti-791", mock.Anything)
+		mockSessionService.AssertExpectations(t)
+	})
+
+	// --- Test RefreshUserToken ---
+	t.Run("RefreshUserToken - Success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/user/token/refresh", bytes.NewBufferString(`{"refreshToken": "refresh-1"}`))
+		rr := httptest.NewRecorder()
+		userId := 7
+
+		mockRefreshService.On("RotateRefreshToken", mock.Anything, "refresh-1").Return(&service.RotatedRefreshToken{
+			User:         service.User{Id: userId, Name: "Test User", Email: "user@example.com"},
+			SessionId:    "session-1",
+			RefreshToken: "refresh-2",
+		}, nil).Once()
+		var issuedJTI string
+		mockTokenService.On("GenerateToken", mock.MatchedBy(func(claims auth.Claims) bool {
+			issuedJTI = claims.ID
+			return *claims.Id == userId && claims.Email == "user@example.com" && claims.SessionId == "session-1" && claims.ID != ""
+		})).Return("new_jwt_token", nil).Once()
+		mockSessionService.On("TouchSession", mock.Anything, "session-1", mock.Anything).
+			Run(func(args mock.Arguments) { assert.Equal(t, issuedJTI, args.String(2)) }).
+			Return(nil).Once()
+
+		userHandler.RefreshUserToken(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp api.Success
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Equal(t, api.FETCH, resp.Code)
+		assert.Equal(t, "new_jwt_token", (*resp.Payload)["accessToken"])
+		assert.Equal(t, "refresh-2", (*resp.Payload)["refreshToken"])
+		mockRefreshService.AssertExpectations(t)
+		mockTokenService.AssertExpectations(t)
+		mockSessionService.AssertExpectations(t)
+	})
+
+	t.Run("RefreshUserToken - Session Revoked Meanwhile", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/user/token/refresh", bytes.NewBufferString(`{"refreshToken": "refresh-3"}`))
+		rr := httptest.NewRecorder()
+
+		mockRefreshService.On("RotateRefreshToken", mock.Anything, "refresh-3").Return(&service.RotatedRefreshToken{
+			User:         service.User{Id: 7},
+			SessionId:    "session-2",
+			RefreshToken: "refresh-4",
+		}, nil).Once()
+		mockTokenService.On("GenerateToken", mock.Anything).Return("new_jwt_token", nil).Once()
+		mockSessionService.On("TouchSession", mock.Anything, "session-2", mock.Anything).Return(apperrors.ErrNotFound).Once()
+
+		userHandler.RefreshUserToken(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockSessionService.AssertExpectations(t)
+	})
+
+	t.Run("RefreshUserToken - Reused Or Invalid Token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/user/token/refresh", bytes.NewBufferString(`{"refreshToken": "refresh-1"}`))
+		rr := httptest.NewRecorder()
+
+		mockRefreshService.On("RotateRefreshToken", mock.Anything, "refresh-1").Return(nil, apperrors.ErrUnauthorized).Once()
+
+		userHandler.RefreshUserToken(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		var resp api.Error
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Equal(t, string(apperrors.UNAUTHORIZED), resp.Code)
+		mockRefreshService.AssertExpectations(t)
+	})
+
+	t.Run("RefreshUserToken - Reused Token Revokes The Session", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/user/token/refresh", bytes.NewBufferString(`{"refreshToken": "refresh-5"}`))
+		rr := httptest.NewRecorder()
+
+		mockRefreshService.On("RotateRefreshToken", mock.Anything, "refresh-5").Return(nil, &apperrors.TokenReuseError{SessionId: "session-3"}).Once()
+		mockTokenService.On("RevokeSession", mock.Anything, "session-3").Return(nil).Once()
+
+		userHandler.RefreshUserToken(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockRefreshService.AssertExpectations(t)
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("RefreshUserToken - Missing Token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/user/token/refresh", bytes.NewBufferString(`{}`))
+		rr := httptest.NewRecorder()
+
+		userHandler.RefreshUserToken(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
 	// --- Test GetUserStatus ---
 	t.Run("GetUserStatus - Success", func(t *testing.T) {
 		mockId := 10
@@ -523,4 +923,496 @@ func TestUserHandler(t *testing.T) {
 		mockUserService.AssertExpectations(t)
 		mockWorkoutService.AssertExpectations(t)
 	})
+
+	// --- Test UpdateUserPreferences ---
+	t.Run("UpdateUserPreferences - Success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/user/preferences", bytes.NewBufferString(`{"preferredUnit": "lbs", "timeZone": "Europe/Berlin"}`))
+		req = req.WithContext(helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: 30}))
+		rr := httptest.NewRecorder()
+
+		mockUserService.On("UpdatePreferences", mock.Anything, 30, service.UserPreferences{PreferredUnit: service.LBS, TimeZone: "Europe/Berlin"}).
+			Return(&service.UserPreferences{PreferredUnit: service.LBS, TimeZone: "Europe/Berlin"}, nil).Once()
+
+		userHandler.UpdateUserPreferences(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp api.Success
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Equal(t, api.UPDATE, resp.Code)
+		preferences, ok := (*resp.Payload)["preferences"].(map[string]any)
+		assert.True(t, ok)
+		assert.Equal(t, "lbs", preferences["preferredUnit"])
+		assert.Equal(t, "Europe/Berlin", preferences["timeZone"])
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("UpdateUserPreferences - Invalid Unit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/user/preferences", bytes.NewBufferString(`{"preferredUnit": "other"}`))
+		req = req.WithContext(helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: 30}))
+		rr := httptest.NewRecorder()
+
+		mockUserService.On("UpdatePreferences", mock.Anything, 30, service.UserPreferences{PreferredUnit: service.OTHER}).
+			Return(nil, apperrors.NewValidationError(apperrors.INVALID_SETTING, "preferred unit must be kg or lbs")).Once()
+
+		userHandler.UpdateUserPreferences(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("UpdateUserPreferences - No User Info in Context", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/user/preferences", bytes.NewBufferString(`{"preferredUnit": "kg"}`))
+		rr := httptest.NewRecorder()
+
+		userHandler.UpdateUserPreferences(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	// --- Test GetJwks ---
+	t.Run("GetJwks - Success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+		rr := httptest.NewRecorder()
+
+		mockTokenService.On("JWKS").Return(auth.JWKSet{Keys: []auth.JWK{
+			{Kty: "EC", Kid: "current", Use: "sig", Alg: "ES256", Crv: "P-256", X: "x-coordinate", Y: "y-coordinate"},
+			{Kty: "OKP", Kid: "retired", Use: "sig", Alg: "EdDSA", Crv: "Ed25519", X: "public-key"},
+		}}).Once()
+
+		userHandler.GetJwks(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp map[string][]map[string]any
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Len(t, resp["keys"], 2)
+		assert.Equal(t, "current", resp["keys"][0]["kid"])
+		assert.Equal(t, "P-256", resp["keys"][0]["crv"])
+		assert.NotContains(t, resp["keys"][1], "y")
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("GetJwks - HMAC Only", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+		rr := httptest.NewRecorder()
+
+		mockTokenService.On("JWKS").Return(auth.JWKSet{Keys: []auth.JWK{}}).Once()
+
+		userHandler.GetJwks(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"keys": []}`, rr.Body.String())
+		mockTokenService.AssertExpectations(t)
+	})
+}
+
+func TestUserHandler_ChangeUserPassword(t *testing.T) {
+	newHandler := func() (*handler.UserHandler, *MockUserService, *MockTokenService) {
+		mockUserService := new(MockUserService)
+		mockTokenService := new(MockTokenService)
+		return handler.NewUserHandler(mockUserService, nil, mockTokenService, nil, nil, nil), mockUserService, mockTokenService
+	}
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPut, "/user/password", bytes.NewBufferString(body))
+		return req.WithContext(helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: 7}))
+	}
+
+	t.Run("Success ends every session", func(t *testing.T) {
+		userHandler, mockUserService, mockTokenService := newHandler()
+		mockUserService.On("ChangePassword", mock.Anything, 7, service.UserPasswordChange{CurrentPassword: "oldpassword", NewPassword: "newpassword", IPAddress: "192.0.2.1"}).
+			Return([]string{"session-1", "session-2"}, nil).Once()
+		mockTokenService.On("RevokeSession", mock.Anything, "session-1").Return(nil).Once()
+		mockTokenService.On("RevokeSession", mock.Anything, "session-2").Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		userHandler.ChangeUserPassword(rr, newRequest(`{"currentPassword": "oldpassword", "newPassword": "newpassword"}`))
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		mockUserService.AssertExpectations(t)
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("Wrong current password", func(t *testing.T) {
+		userHandler, mockUserService, mockTokenService := newHandler()
+		mockUserService.On("ChangePassword", mock.Anything, 7, mock.Anything).
+			Return(nil, apperrors.NewValidationError(apperrors.INVALID_PASSWORD, "current password is incorrect")).Once()
+
+		rr := httptest.NewRecorder()
+		userHandler.ChangeUserPassword(rr, newRequest(`{"currentPassword": "guess", "newPassword": "newpassword"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		var resp api.Error
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Equal(t, string(apperrors.INVALID_PASSWORD), resp.Code)
+		mockTokenService.AssertNotCalled(t, "RevokeSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Locked out after too many wrong passwords", func(t *testing.T) {
+		userHandler, mockUserService, mockTokenService := newHandler()
+		mockUserService.On("ChangePassword", mock.Anything, 7, mock.Anything).
+			Return(nil, &apperrors.LockoutError{RetryAfter: time.Minute}).Once()
+
+		rr := httptest.NewRecorder()
+		userHandler.ChangeUserPassword(rr, newRequest(`{"currentPassword": "guess", "newPassword": "newpassword"}`))
+
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		mockTokenService.AssertNotCalled(t, "RevokeSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("No User Info in Context", func(t *testing.T) {
+		userHandler, mockUserService, _ := newHandler()
+		req := httptest.NewRequest(http.MethodPut, "/user/password", bytes.NewBufferString(`{}`))
+
+		rr := httptest.NewRecorder()
+		userHandler.ChangeUserPassword(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockUserService.AssertNotCalled(t, "ChangePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserHandler_RequestPasswordReset(t *testing.T) {
+	t.Run("Accepted", func(t *testing.T) {
+		mockUserService := new(MockUserService)
+		userHandler := handler.NewUserHandler(mockUserService, nil, nil, nil, nil, nil)
+		mockUserService.On("RequestPasswordReset", mock.Anything, "john@example.com").Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/user/password/reset/request", bytes.NewBufferString(`{"email": "john@example.com"}`))
+		rr := httptest.NewRecorder()
+		userHandler.RequestPasswordReset(rr, req)
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Missing email", func(t *testing.T) {
+		mockUserService := new(MockUserService)
+		userHandler := handler.NewUserHandler(mockUserService, nil, nil, nil, nil, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/user/password/reset/request", bytes.NewBufferString(`{}`))
+		rr := httptest.NewRecorder()
+		userHandler.RequestPasswordReset(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockUserService.AssertNotCalled(t, "RequestPasswordReset", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Mailer error", func(t *testing.T) {
+		mockUserService := new(MockUserService)
+		userHandler := handler.NewUserHandler(mockUserService, nil, nil, nil, nil, nil)
+		mockUserService.On("RequestPasswordReset", mock.Anything, "john@example.com").Return(errors.New("connection refused")).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/user/password/reset/request", bytes.NewBufferString(`{"email": "john@example.com"}`))
+		rr := httptest.NewRecorder()
+		userHandler.RequestPasswordReset(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestUserHandler_ResetUserPassword(t *testing.T) {
+	t.Run("Success ends every session", func(t *testing.T) {
+		mockUserService := new(MockUserService)
+		mockTokenService := new(MockTokenService)
+		userHandler := handler.NewUserHandler(mockUserService, nil, mockTokenService, nil, nil, nil)
+		mockUserService.On("ResetPassword", mock.Anything, service.UserPasswordReset{Token: "reset-token", NewPassword: "newpassword"}).
+			Return([]string{"session-1"}, nil).Once()
+		mockTokenService.On("RevokeSession", mock.Anything, "session-1").Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/user/password/reset", bytes.NewBufferString(`{"token": "reset-token", "newPassword": "newpassword"}`))
+		rr := httptest.NewRecorder()
+		userHandler.ResetUserPassword(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		mockUserService.AssertExpectations(t)
+		mockTokenService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		mockUserService := new(MockUserService)
+		userHandler := handler.NewUserHandler(mockUserService, nil, new(MockTokenService), nil, nil, nil)
+		mockUserService.On("ResetPassword", mock.Anything, mock.Anything).
+			Return(nil, apperrors.NewValidationError(apperrors.INVALID_TOKEN, "reset token is invalid or expired")).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/user/password/reset", bytes.NewBufferString(`{"token": "stale", "newPassword": "newpassword"}`))
+		rr := httptest.NewRecorder()
+		userHandler.ResetUserPassword(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		var resp api.Error
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Equal(t, string(apperrors.INVALID_TOKEN), resp.Code)
+	})
+}
+
+func TestUserHandler_VerifyUserEmail(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockUserService := new(MockUserService)
+		userHandler := handler.NewUserHandler(mockUserService, nil, nil, nil, nil, nil)
+		mockUserService.On("VerifyEmail", mock.Anything, "verify-token").Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/user/verify-email", bytes.NewBufferString(`{"token": "verify-token"}`))
+		rr := httptest.NewRecorder()
+		userHandler.VerifyUserEmail(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		mockUserService := new(MockUserService)
+		userHandler := handler.NewUserHandler(mockUserService, nil, nil, nil, nil, nil)
+		mockUserService.On("VerifyEmail", mock.Anything, "stale").
+			Return(apperrors.NewValidationError(apperrors.INVALID_TOKEN, "verification token is invalid or expired")).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/user/verify-email", bytes.NewBufferString(`{"token": "stale"}`))
+		rr := httptest.NewRecorder()
+		userHandler.VerifyUserEmail(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		var resp api.Error
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Equal(t, string(apperrors.INVALID_TOKEN), resp.Code)
+	})
+}
+
+func TestUserHandler_ResendVerificationEmail(t *testing.T) {
+	t.Run("Accepted", func(t *testing.T) {
+		mockUserService := new(MockUserService)
+		userHandler := handler.NewUserHandler(mockUserService, nil, nil, nil, nil, nil)
+		mockUserService.On("ResendVerification", mock.Anything, "john@example.com").Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/user/verify-email/resend", bytes.NewBufferString(`{"email": "john@example.com"}`))
+		rr := httptest.NewRecorder()
+		userHandler.ResendVerificationEmail(rr, req)
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		mockUserService.AssertExpectations(t)
+	})
+
+	t.Run("Missing email", func(t *testing.T) {
+		mockUserService := new(MockUserService)
+		userHandler := handler.NewUserHandler(mockUserService, nil, nil, nil, nil, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/user/verify-email/resend", bytes.NewBufferString(`{}`))
+		rr := httptest.NewRecorder()
+		userHandler.ResendVerificationEmail(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockUserService.AssertNotCalled(t, "ResendVerification", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserHandler_TwoFactorLogin(t *testing.T) {
+	newHandler := func() (*handler.UserHandler, *MockUserService, *MockTwoFactorService, *MockSessionService, *MockTokenService, *MockRefreshTokenService) {
+		mockUserService := new(MockUserService)
+		mockTwoFactorService := new(MockTwoFactorService)
+		mockSessionService := new(MockSessionService)
+		mockTokenService := new(MockTokenService)
+		mockRefreshService := new(MockRefreshTokenService)
+		userHandler := handler.NewUserHandler(mockUserService, nil, mockTokenService, mockRefreshService, mockSessionService, mockTwoFactorService)
+		return userHandler, mockUserService, mockTwoFactorService, mockSessionService, mockTokenService, mockRefreshService
+	}
+
+	t.Run("password login returns a challenge instead of tokens", func(t *testing.T) {
+		userHandler, mockUserService, mockTwoFactorService, mockSessionService, _, _ := newHandler()
+
+		mockUserService.On("LoginUser", mock.Anything, mock.Anything).Return(&service.User{Id: 3, Email: "user@example.com"}, nil).Once()
+		mockTwoFactorService.On("IsEnabled", mock.Anything, 3).Return(true, nil).Once()
+		mockTwoFactorService.On("CreateChallenge", mock.Anything, 3).Return("challenge-token", nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/user/login", bytes.NewBufferString(`{"email": "user@example.com", "password": "correctpassword"}`))
+		rr := httptest.NewRecorder()
+		userHandler.LoginUser(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp api.Success
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Equal(t, true, (*resp.Payload)["twoFactorRequired"])
+		assert.Equal(t, "challenge-token", (*resp.Payload)["challengeToken"])
+		assert.NotContains(t, *resp.Payload, "accessToken")
+		mockSessionService.AssertNotCalled(t, "StartSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("a valid code completes the login", func(t *testing.T) {
+		userHandler, _, mockTwoFactorService, mockSessionService, mockTokenService, mockRefreshService := newHandler()
+
+		mockTwoFactorService.On("CompleteChallenge", mock.Anything, "challenge-token", "123456", "192.0.2.1").
+			Return(&service.User{Id: 3, Email: "user@example.com", EmailVerified: true}, nil).Once()
+		mockSessionService.On("StartSession", mock.Anything, mock.MatchedBy(func(in service.SessionStart) bool {
+			return in.UserId == 3
+		})).Return(&service.Session{Id: "session-3"}, nil).Once()
+		mockTokenService.On("GenerateToken", mock.MatchedBy(func(claims auth.Claims) bool {
+			return *claims.Id == 3 && claims.SessionId == "session-3" && claims.EmailVerified
+		})).Return("mock_jwt_token", nil).Once()
+		mockRefreshService.On("IssueRefreshToken", mock.Anything, 3, "session-3").Return("mock_refresh_token", nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/user/login/2fa", bytes.NewBufferString(`{"challengeToken": "challenge-token", "code": "123456"}`))
+		rr := httptest.NewRecorder()
+		userHandler.CompleteTwoFactorLogin(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp api.Success
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Equal(t, "mock_jwt_token", (*resp.Payload)["accessToken"])
+		assert.Equal(t, "mock_refresh_token", (*resp.Payload)["refreshToken"])
+		mockTokenService.AssertExpectations(t)
+		mockRefreshService.AssertExpectations(t)
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		userHandler, _, mockTwoFactorService, _, _, _ := newHandler()
+
+		mockTwoFactorService.On("CompleteChallenge", mock.Anything, "challenge-token", "000000", "192.0.2.1").
+			Return(nil, apperrors.NewValidationError(apperrors.INVALID_CODE, "invalid or already used authentication code")).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/user/login/2fa", bytes.NewBufferString(`{"challengeToken": "challenge-token", "code": "000000"}`))
+		rr := httptest.NewRecorder()
+		userHandler.CompleteTwoFactorLogin(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		var resp api.Error
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Equal(t, string(apperrors.INVALID_CODE), resp.Code)
+	})
+
+	t.Run("expired challenge", func(t *testing.T) {
+		userHandler, _, mockTwoFactorService, _, _, _ := newHandler()
+
+		mockTwoFactorService.On("CompleteChallenge", mock.Anything, "old-token", "123456", "192.0.2.1").Return(nil, apperrors.ErrUnauthorized).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/user/login/2fa", bytes.NewBufferString(`{"challengeToken": "old-token", "code": "123456"}`))
+		rr := httptest.NewRecorder()
+		userHandler.CompleteTwoFactorLogin(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("locked out after too many wrong codes", func(t *testing.T) {
+		userHandler, _, mockTwoFactorService, mockSessionService, _, _ := newHandler()
+
+		mockTwoFactorService.On("CompleteChallenge", mock.Anything, "challenge-token", "123456", "192.0.2.1").
+			Return(nil, &apperrors.LockoutError{RetryAfter: time.Minute}).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/user/login/2fa", bytes.NewBufferString(`{"challengeToken": "challenge-token", "code": "123456"}`))
+		rr := httptest.NewRecorder()
+		userHandler.CompleteTwoFactorLogin(rr, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		mockSessionService.AssertNotCalled(t, "StartSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("missing code", func(t *testing.T) {
+		userHandler, _, mockTwoFactorService, _, _, _ := newHandler()
+
+		req := httptest.NewRequest(http.MethodPost, "/user/login/2fa", bytes.NewBufferString(`{"challengeToken": "challenge-token"}`))
+		rr := httptest.NewRecorder()
+		userHandler.CompleteTwoFactorLogin(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockTwoFactorService.AssertNotCalled(t, "CompleteChallenge", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserHandler_TwoFactorSettings(t *testing.T) {
+	const testUserID = 7
+
+	withUser := func(req *http.Request) *http.Request {
+		return req.WithContext(helper.SetUserInfoToContext(req.Context(), &helper.UserInfo{Id: testUserID}))
+	}
+
+	t.Run("enroll", func(t *testing.T) {
+		mockTwoFactorService := new(MockTwoFactorService)
+		userHandler := handler.NewUserHandler(nil, nil, nil, nil, nil, mockTwoFactorService)
+
+		mockTwoFactorService.On("Enroll", mock.Anything, testUserID).
+			Return(&service.TOTPEnrollment{Secret: "SECRET", URI: "otpauth://totp/x"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		userHandler.EnrollTwoFactor(rr, withUser(httptest.NewRequest(http.MethodPost, "/user/2fa/enroll", nil)))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp api.Success
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		enrollment := (*resp.Payload)["enrollment"].(map[string]any)
+		assert.Equal(t, "SECRET", enrollment["secret"])
+		assert.Equal(t, "otpauth://totp/x", enrollment["otpauthUri"])
+	})
+
+	t.Run("enroll when already enabled", func(t *testing.T) {
+		mockTwoFactorService := new(MockTwoFactorService)
+		userHandler := handler.NewUserHandler(nil, nil, nil, nil, nil, mockTwoFactorService)
+
+		mockTwoFactorService.On("Enroll", mock.Anything, testUserID).
+			Return(nil, fmt.Errorf("%w: two-factor authentication is already enabled", apperrors.ErrAlreadyExists)).Once()
+
+		rr := httptest.NewRecorder()
+		userHandler.EnrollTwoFactor(rr, withUser(httptest.NewRequest(http.MethodPost, "/user/2fa/enroll", nil)))
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("confirm returns the recovery codes", func(t *testing.T) {
+		mockTwoFactorService := new(MockTwoFactorService)
+		userHandler := handler.NewUserHandler(nil, nil, nil, nil, nil, mockTwoFactorService)
+
+		mockTwoFactorService.On("Confirm", mock.Anything, testUserID, "123456").Return([]string{"abcde-fghij", "klmno-pqrst"}, nil).Once()
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/user/2fa/confirm", bytes.NewBufferString(`{"code": "123456"}`))
+		userHandler.ConfirmTwoFactor(rr, withUser(req))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var resp api.Success
+		err := json.NewDecoder(rr.Body).Decode(&resp)
+		assert.NoError(t, err)
+		assert.Equal(t, []any{"abcde-fghij", "klmno-pqrst"}, (*resp.Payload)["recoveryCodes"])
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		mockTwoFactorService := new(MockTwoFactorService)
+		userHandler := handler.NewUserHandler(nil, nil, nil, nil, nil, mockTwoFactorService)
+
+		mockTwoFactorService.On("Disable", mock.Anything, testUserID, "abcde-fghij").Return(nil).Once()
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodDelete, "/user/2fa", bytes.NewBufferString(`{"code": "abcde-fghij"}`))
+		userHandler.DisableTwoFactor(rr, withUser(req))
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		mockTwoFactorService.AssertExpectations(t)
+	})
+
+	t.Run("disable with a wrong code", func(t *testing.T) {
+		mockTwoFactorService := new(MockTwoFactorService)
+		userHandler := handler.NewUserHandler(nil, nil, nil, nil, nil, mockTwoFactorService)
+
+		mockTwoFactorService.On("Disable", mock.Anything, testUserID, "000000").
+			Return(apperrors.NewValidationError(apperrors.INVALID_CODE, "invalid or already used authentication code")).Once()
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodDelete, "/user/2fa", bytes.NewBufferString(`{"code": "000000"}`))
+		userHandler.DisableTwoFactor(rr, withUser(req))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("unauthorized if no user in context", func(t *testing.T) {
+		mockTwoFactorService := new(MockTwoFactorService)
+		userHandler := handler.NewUserHandler(nil, nil, nil, nil, nil, mockTwoFactorService)
+
+		rr := httptest.NewRecorder()
+		userHandler.EnrollTwoFactor(rr, httptest.NewRequest(http.MethodPost, "/user/2fa/enroll", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		mockTwoFactorService.AssertNotCalled(t, "Enroll", mock.Anything, mock.Anything)
+	})
 }
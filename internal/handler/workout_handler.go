@@ -236,6 +236,130 @@ func (h *WorkoutHandler) UpdateExercisePlansInWorkoutPlan(w http.ResponseWriter,
 
 }
 
+// AddExercisePlan
+func (h *WorkoutHandler) AddExercisePlan(w http.ResponseWriter, r *http.Request) {
+	wpId, err := doubleAuth(w, r, h.WorkoutService)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	var req api.AddExercisePlanJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding add exercise plan request: %v", err)
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	ep := req.ExercisePlan
+	if ep.ExerciseId == nil || ep.Sets == nil || ep.Repetitions == nil || ep.Weights == nil || ep.WeightUnit == nil {
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "exercise plan is incomplete"))
+		return
+	}
+
+	position := 0
+	if req.Position != nil {
+		position = *req.Position
+	}
+
+	updatedWP, err := h.WorkoutService.AddExercisePlan(r.Context(), wpId, *toServiceCreateEP(&ep), position)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to add exercise plan: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.UPDATE,
+		Message: "successfully add exercise plan",
+		Payload: &map[string]interface{}{
+			"workoutPlan": toAPIWorkout(updatedWP),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// RemoveExercisePlan
+func (h *WorkoutHandler) RemoveExercisePlan(w http.ResponseWriter, r *http.Request) {
+	wpId, err := doubleAuth(w, r, h.WorkoutService)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	epId, err := pathID(w, r, "exercisePlanId")
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	updatedWP, err := h.WorkoutService.RemoveExercisePlan(r.Context(), wpId, epId)
+	if err != nil {
+		helper.SendErrorResponse(w, fmt.Errorf("failed to remove exercise plan: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.UPDATE,
+		Message: "successfully remove exercise plan",
+		Payload: &map[string]interface{}{
+			"workoutPlan": toAPIWorkout(updatedWP),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
+// MoveExercisePlan
+func (h *WorkoutHandler) MoveExercisePlan(w http.ResponseWriter, r *http.Request) {
+	wpId, err := doubleAuth(w, r, h.WorkoutService)
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	epId, err := pathID(w, r, "exercisePlanId")
+	if err != nil {
+		log.Print(err)
+		return
+	}
+
+	var req api.MoveExercisePlanJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("Error decoding move exercise plan request: %v", err)
+		helper.SendErrorResponse(w, apperrors.NewValidationError(apperrors.INVALID_INPUT, "invalid request body"))
+		return
+	}
+
+	updatedWP, err := h.WorkoutService.MoveExercisePlan(r.Context(), wpId, epId, req.Position)
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			helper.SendErrorResponse(w, err)
+			return
+		}
+
+		helper.SendErrorResponse(w, fmt.Errorf("failed to move exercise plan: %w", err))
+		return
+	}
+
+	response := api.Success{
+		Code:    api.UPDATE,
+		Message: "successfully move exercise plan",
+		Payload: &map[string]interface{}{
+			"workoutPlan": toAPIWorkout(updatedWP),
+		},
+	}
+
+	helper.SendSuccessResponse(w, http.StatusOK, &response)
+}
+
 // CompleteWorkoutPlanById
 func (h *WorkoutHandler) CompleteWorkoutPlanById(w http.ResponseWriter, r *http.Request) {
 	wpId, err := doubleAuth(w, r, h.WorkoutService)
@@ -361,6 +485,7 @@ func toAPIExercisePlan(exercisePlan *service.ExercisePlan) *api.ExercisePlan {
 	return &api.ExercisePlan{
 		ExerciseId:    util.IntTo64(exercisePlan.ExerciseId),
 		Id:            util.IntTo64(exercisePlan.Id),
+		Position:      &exercisePlan.Position,
 		Repetitions:   &exercisePlan.Repetitions,
 		Sets:          &exercisePlan.Sets,
 		WeightUnit:    (*api.WeightUnit)(&exercisePlan.WeightUnit),
@@ -69,6 +69,30 @@ func (m *MockWorkoutService) UpdateExercisePlans(ctx context.Context, workoutID
 	return args.Get(0).(*service.WorkoutPlan), args.Error(1)
 }
 
+func (m *MockWorkoutService) AddExercisePlan(ctx context.Context, workoutID int, data service.ExercisePlanCreate, position int) (*service.WorkoutPlan, error) {
+	args := m.Called(ctx, workoutID, data, position)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutPlan), args.Error(1)
+}
+
+func (m *MockWorkoutService) RemoveExercisePlan(ctx context.Context, workoutID int, exercisePlanId int) (*service.WorkoutPlan, error) {
+	args := m.Called(ctx, workoutID, exercisePlanId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutPlan), args.Error(1)
+}
+
+func (m *MockWorkoutService) MoveExercisePlan(ctx context.Context, workoutID int, exercisePlanId int, position int) (*service.WorkoutPlan, error) {
+	args := m.Called(ctx, workoutID, exercisePlanId, position)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutPlan), args.Error(1)
+}
+
 func (m *MockWorkoutService) DeleteWorkoutById(ctx context.Context, id int) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -234,7 +258,7 @@ func TestWorkoutHandler(t *testing.T) {
 		var mockExerciseId int64 = 1
 		mockRepetion := 2
 		mockSet := 3
-		mockWeightUnit := api.Kg
+		mockWeightUnit := api.WeightUnitKg
 		var mockWeight float32 = 60.2
 		mockCreateExercisePlans := []api.CreateExercisePlan{
 			{
@@ -354,6 +378,32 @@ func TestWorkoutHandler(t *testing.T) {
 			assert.Equal(t, string(apperrors.INTERNAL_ERROR), resp.Code)
 			mockWorkoutService.AssertExpectations(t)
 		})
+
+		t.Run("Unknown exercise returns foreign key violation", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			workoutHandler := handler.NewWorkoutHandler(mockWorkoutService)
+			reqBody := api.CreateWorkoutPlanJSONRequestBody{
+				ScheduledDate: &mockScheduledDate,
+				ExercisePlans: &mockCreateExercisePlans,
+			}
+			serviceErr := fmt.Errorf("failed to create exercise plan: %w", apperrors.ErrForeignKeyViolation)
+
+			mockWorkoutService.On("CreateWorkout", mock.Anything, mock.Anything).Return(nil, serviceErr).Once()
+
+			body, _ := json.Marshal(reqBody)
+			req := createRequestWithUser(http.MethodPost, "/workouts", body)
+			rr := httptest.NewRecorder()
+
+			workoutHandler.CreateWorkoutPlan(rr, req)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+			var resp api.Error
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, string(apperrors.FOREIGN_KEY_VIOLATION), resp.Code)
+			assert.Equal(t, "referenced resource does not exist", resp.Message)
+			mockWorkoutService.AssertExpectations(t)
+		})
 	})
 
 	t.Run("GetWorkoutPlanbyID", func(t *testing.T) {
@@ -725,7 +775,7 @@ func TestWorkoutHandler(t *testing.T) {
 		mockSet := 3
 		mockRepetition := 10
 		var mockWeights float32 = 50.2
-		mockWeightUnit := api.Kg
+		mockWeightUnit := api.WeightUnitKg
 		now := time.Now()
 
 		// Setup for successful doubleAuth check
@@ -881,4 +931,221 @@ func TestWorkoutHandler(t *testing.T) {
 			mockWorkoutService.AssertExpectations(t)
 		})
 	})
+
+	t.Run("AddExercisePlan", func(t *testing.T) {
+		workoutID := 123
+		var mockExerciseId int64 = 7
+		mockSet := 3
+		mockRepetition := 10
+		var mockWeights float32 = 40
+		mockWeightUnit := api.WeightUnitKg
+		position := 1
+		now := time.Now()
+
+		existingWorkout := &service.WorkoutPlan{
+			Id:            workoutID,
+			UserId:        testUserID,
+			Status:        service.PENDING,
+			ScheduledDate: now,
+		}
+		createEP := api.CreateExercisePlan{
+			ExerciseId:  &mockExerciseId,
+			Sets:        &mockSet,
+			Repetitions: &mockRepetition,
+			Weights:     &mockWeights,
+			WeightUnit:  &mockWeightUnit,
+		}
+		serviceCreateEP := service.ExercisePlanCreate{
+			ExerciseId:  int(mockExerciseId),
+			Sets:        mockSet,
+			Repetitions: mockRepetition,
+			Weights:     mockWeights,
+			WeightUnit:  service.WeightUnit(mockWeightUnit),
+		}
+		updatedWorkout := &service.WorkoutPlan{
+			Id:            workoutID,
+			UserId:        testUserID,
+			Status:        service.PENDING,
+			ScheduledDate: now,
+			ExercisePlans: []service.ExercisePlan{
+				{Id: 9, ExerciseId: int(mockExerciseId), WorkoutPlanId: workoutID, Position: 1},
+			},
+		}
+
+		t.Run("Successful add at position", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			workoutHandler := handler.NewWorkoutHandler(mockWorkoutService)
+
+			mockWorkoutService.On("GetWorkoutById", mock.Anything, workoutID).Return(existingWorkout, nil).Once()
+			mockWorkoutService.On("AddExercisePlan", mock.Anything, workoutID, serviceCreateEP, position).Return(updatedWorkout, nil).Once()
+
+			body, _ := json.Marshal(api.AddExercisePlanJSONRequestBody{ExercisePlan: createEP, Position: &position})
+			req := createRequestWithUserAndWorkoutID(http.MethodPost, fmt.Sprintf("/workouts/%d/exercise-plans", workoutID), workoutID, body)
+			rr := httptest.NewRecorder()
+
+			workoutHandler.AddExercisePlan(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			var resp api.Success
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, api.UPDATE, resp.Code)
+			assert.Equal(t, "successfully add exercise plan", resp.Message)
+			mockWorkoutService.AssertExpectations(t)
+		})
+
+		t.Run("Missing position appends", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			workoutHandler := handler.NewWorkoutHandler(mockWorkoutService)
+
+			mockWorkoutService.On("GetWorkoutById", mock.Anything, workoutID).Return(existingWorkout, nil).Once()
+			mockWorkoutService.On("AddExercisePlan", mock.Anything, workoutID, serviceCreateEP, 0).Return(updatedWorkout, nil).Once()
+
+			body, _ := json.Marshal(api.AddExercisePlanJSONRequestBody{ExercisePlan: createEP})
+			req := createRequestWithUserAndWorkoutID(http.MethodPost, fmt.Sprintf("/workouts/%d/exercise-plans", workoutID), workoutID, body)
+			rr := httptest.NewRecorder()
+
+			workoutHandler.AddExercisePlan(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			mockWorkoutService.AssertExpectations(t)
+		})
+
+		t.Run("Incomplete exercise plan", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			workoutHandler := handler.NewWorkoutHandler(mockWorkoutService)
+
+			mockWorkoutService.On("GetWorkoutById", mock.Anything, workoutID).Return(existingWorkout, nil).Once()
+
+			body, _ := json.Marshal(api.AddExercisePlanJSONRequestBody{ExercisePlan: api.CreateExercisePlan{ExerciseId: &mockExerciseId}})
+			req := createRequestWithUserAndWorkoutID(http.MethodPost, fmt.Sprintf("/workouts/%d/exercise-plans", workoutID), workoutID, body)
+			rr := httptest.NewRecorder()
+
+			workoutHandler.AddExercisePlan(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			mockWorkoutService.AssertNotCalled(t, "AddExercisePlan")
+		})
+
+		t.Run("Unknown exercise returns foreign key violation", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			workoutHandler := handler.NewWorkoutHandler(mockWorkoutService)
+
+			fkErr := fmt.Errorf("%w: insert failed", apperrors.ErrForeignKeyViolation)
+			mockWorkoutService.On("GetWorkoutById", mock.Anything, workoutID).Return(existingWorkout, nil).Once()
+			mockWorkoutService.On("AddExercisePlan", mock.Anything, workoutID, serviceCreateEP, 0).Return(nil, fkErr).Once()
+
+			body, _ := json.Marshal(api.AddExercisePlanJSONRequestBody{ExercisePlan: createEP})
+			req := createRequestWithUserAndWorkoutID(http.MethodPost, fmt.Sprintf("/workouts/%d/exercise-plans", workoutID), workoutID, body)
+			rr := httptest.NewRecorder()
+
+			workoutHandler.AddExercisePlan(rr, req)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+			mockWorkoutService.AssertExpectations(t)
+		})
+	})
+
+	t.Run("RemoveExercisePlan", func(t *testing.T) {
+		workoutID := 123
+		epID := 9
+
+		existingWorkout := &service.WorkoutPlan{
+			Id:            workoutID,
+			UserId:        testUserID,
+			Status:        service.PENDING,
+			ScheduledDate: time.Now(),
+		}
+
+		t.Run("Successful removal", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			workoutHandler := handler.NewWorkoutHandler(mockWorkoutService)
+
+			mockWorkoutService.On("GetWorkoutById", mock.Anything, workoutID).Return(existingWorkout, nil).Once()
+			mockWorkoutService.On("RemoveExercisePlan", mock.Anything, workoutID, epID).Return(existingWorkout, nil).Once()
+
+			req := createRequestWithUserAndWorkoutID(http.MethodDelete, fmt.Sprintf("/workouts/%d/exercise-plans/%d", workoutID, epID), workoutID, nil)
+			req.SetPathValue("exercisePlanId", strconv.Itoa(epID))
+			rr := httptest.NewRecorder()
+
+			workoutHandler.RemoveExercisePlan(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			var resp api.Success
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, "successfully remove exercise plan", resp.Message)
+			mockWorkoutService.AssertExpectations(t)
+		})
+
+		t.Run("Exercise plan not in workout", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			workoutHandler := handler.NewWorkoutHandler(mockWorkoutService)
+
+			mockWorkoutService.On("GetWorkoutById", mock.Anything, workoutID).Return(existingWorkout, nil).Once()
+			mockWorkoutService.On("RemoveExercisePlan", mock.Anything, workoutID, epID).Return(nil, apperrors.ErrNotFound).Once()
+
+			req := createRequestWithUserAndWorkoutID(http.MethodDelete, fmt.Sprintf("/workouts/%d/exercise-plans/%d", workoutID, epID), workoutID, nil)
+			req.SetPathValue("exercisePlanId", strconv.Itoa(epID))
+			rr := httptest.NewRecorder()
+
+			workoutHandler.RemoveExercisePlan(rr, req)
+
+			assert.Equal(t, http.StatusNotFound, rr.Code)
+			mockWorkoutService.AssertExpectations(t)
+		})
+	})
+
+	t.Run("MoveExercisePlan", func(t *testing.T) {
+		workoutID := 123
+		epID := 9
+
+		existingWorkout := &service.WorkoutPlan{
+			Id:            workoutID,
+			UserId:        testUserID,
+			Status:        service.PENDING,
+			ScheduledDate: time.Now(),
+		}
+
+		t.Run("Successful move", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			workoutHandler := handler.NewWorkoutHandler(mockWorkoutService)
+
+			mockWorkoutService.On("GetWorkoutById", mock.Anything, workoutID).Return(existingWorkout, nil).Once()
+			mockWorkoutService.On("MoveExercisePlan", mock.Anything, workoutID, epID, 2).Return(existingWorkout, nil).Once()
+
+			body, _ := json.Marshal(api.MoveExercisePlanJSONRequestBody{Position: 2})
+			req := createRequestWithUserAndWorkoutID(http.MethodPut, fmt.Sprintf("/workouts/%d/exercise-plans/%d/move", workoutID, epID), workoutID, body)
+			req.SetPathValue("exercisePlanId", strconv.Itoa(epID))
+			rr := httptest.NewRecorder()
+
+			workoutHandler.MoveExercisePlan(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			var resp api.Success
+			err := json.NewDecoder(rr.Body).Decode(&resp)
+			assert.NoError(t, err)
+			assert.Equal(t, api.UPDATE, resp.Code)
+			mockWorkoutService.AssertExpectations(t)
+		})
+
+		t.Run("Invalid position", func(t *testing.T) {
+			mockWorkoutService := new(MockWorkoutService)
+			workoutHandler := handler.NewWorkoutHandler(mockWorkoutService)
+
+			validationErr := apperrors.NewValidationError(apperrors.INVALID_SETTING, "position must be at least 1")
+			mockWorkoutService.On("GetWorkoutById", mock.Anything, workoutID).Return(existingWorkout, nil).Once()
+			mockWorkoutService.On("MoveExercisePlan", mock.Anything, workoutID, epID, 0).Return(nil, validationErr).Once()
+
+			body, _ := json.Marshal(api.MoveExercisePlanJSONRequestBody{Position: 0})
+			req := createRequestWithUserAndWorkoutID(http.MethodPut, fmt.Sprintf("/workouts/%d/exercise-plans/%d/move", workoutID, epID), workoutID, body)
+			req.SetPathValue("exercisePlanId", strconv.Itoa(epID))
+			rr := httptest.NewRecorder()
+
+			workoutHandler.MoveExercisePlan(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			mockWorkoutService.AssertExpectations(t)
+		})
+	})
 }
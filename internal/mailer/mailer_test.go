@@ -0,0 +1,64 @@
+package mailer_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"workout-tracker-api/internal/mailer"
+)
+
+func TestFileMailer_Send(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "mail.log")
+
+	m, err := mailer.New(mailer.Config{Driver: mailer.DriverFile, From: "no-reply@example.com", FilePath: path})
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when creating the file mailer", err)
+	}
+
+	t.Run("appends every message", func(t *testing.T) {
+		err := m.Send(ctx, mailer.Message{To: "john@example.com", Subject: "Reset your password", Body: "line one\nline two"})
+		assert.NoError(t, err)
+		err = m.Send(ctx, mailer.Message{To: "jane@example.com", Subject: "Second", Body: "hello"})
+		assert.NoError(t, err)
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when reading the mail file", err)
+		}
+		content := string(data)
+		assert.Contains(t, content, "From: no-reply@example.com\r\n")
+		assert.Contains(t, content, "To: john@example.com\r\n")
+		assert.Contains(t, content, "Subject: Reset your password\r\n")
+		assert.Contains(t, content, "\r\n\r\nline one\r\nline two\r\n")
+		assert.Equal(t, 2, strings.Count(content, "MIME-Version: 1.0"))
+	})
+
+	t.Run("rejects header injection", func(t *testing.T) {
+		err := m.Send(ctx, mailer.Message{To: "john@example.com\r\nBcc: eve@example.com", Subject: "x", Body: "y"})
+		assert.Error(t, err)
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Run("log is the default", func(t *testing.T) {
+		m, err := mailer.New(mailer.Config{From: "no-reply@example.com"})
+		assert.NoError(t, err)
+		assert.IsType(t, &mailer.LogMailer{}, m)
+	})
+
+	t.Run("smtp needs a host", func(t *testing.T) {
+		_, err := mailer.New(mailer.Config{Driver: mailer.DriverSMTP, From: "no-reply@example.com"})
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown driver", func(t *testing.T) {
+		_, err := mailer.New(mailer.Config{Driver: "pigeon"})
+		assert.Error(t, err)
+	})
+}
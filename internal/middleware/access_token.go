@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util/auth"
+	"workout-tracker-api/internal/util/helper"
+)
+
+// AccessTokenAuthMiddleware accepts personal access tokens next to the JWTs of login sessions.
+// Requests made with a personal access token only reach the routes wrapped by RequireScope
+// with one of its scopes.
+func AccessTokenAuthMiddleware(tokenService auth.TokenInterface, accessTokens service.AccessTokenServiceInterface) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		jwtAuth := JWTAuthMiddleware(tokenService)(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokenString, ok := bearerToken(r)
+			if !ok || !service.IsAccessToken(tokenString) {
+				jwtAuth.ServeHTTP(w, r)
+				return
+			}
+
+			owner, err := accessTokens.Authenticate(r.Context(), tokenString)
+			if err != nil {
+				if errors.Is(err, apperrors.ErrUnauthorized) {
+					log.Printf("Refused personal access token from %s", r.RemoteAddr)
+					helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+					return
+				}
+				helper.SendErrorResponse(w, fmt.Errorf("error checking personal access token: %w", err))
+				return
+			}
+
+			scopes := make([]string, 0, len(owner.Scopes))
+			for _, scope := range owner.Scopes {
+				scopes = append(scopes, string(scope))
+			}
+
+			ctx := helper.SetUserInfoToContext(r.Context(), &helper.UserInfo{
+				Id:            owner.User.Id,
+				Email:         owner.User.Email,
+				Name:          owner.User.Name,
+				EmailVerified: owner.User.EmailVerified,
+				Scopes:        scopes,
+			})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireScope lets personal access tokens through when they have the scope. Login sessions
+// are not limited by scopes and always pass.
+func RequireScope(scope service.Scope) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+			if !ok {
+				log.Printf("Failed to get user info from context")
+				helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+				return
+			}
+
+			if userInfo.Scopes != nil && !slices.Contains(userInfo.Scopes, string(scope)) {
+				helper.SendErrorResponse(w, fmt.Errorf("%w: token is missing the %s scope", apperrors.ErrForbidden, scope))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
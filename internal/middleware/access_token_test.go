@@ -0,0 +1,150 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/middleware"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util/helper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAccessTokenService is a mock implementation of service.AccessTokenServiceInterface
+type MockAccessTokenService struct {
+	mock.Mock
+}
+
+func (m *MockAccessTokenService) CreateToken(ctx context.Context, data service.AccessTokenCreate) (*service.CreatedAccessToken, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.CreatedAccessToken), args.Error(1)
+}
+
+func (m *MockAccessTokenService) ListTokens(ctx context.Context, userId int) ([]service.AccessToken, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.AccessToken), args.Error(1)
+}
+
+func (m *MockAccessTokenService) GetToken(ctx context.Context, userId int, id int) (*service.AccessToken, error) {
+	args := m.Called(ctx, userId, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.AccessToken), args.Error(1)
+}
+
+func (m *MockAccessTokenService) UpdateToken(ctx context.Context, data service.AccessTokenUpdate) (*service.AccessToken, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.AccessToken), args.Error(1)
+}
+
+func (m *MockAccessTokenService) DeleteToken(ctx context.Context, userId int, id int) error {
+	args := m.Called(ctx, userId, id)
+	return args.Error(0)
+}
+
+func (m *MockAccessTokenService) Authenticate(ctx context.Context, token string) (*service.AccessTokenOwner, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.AccessTokenOwner), args.Error(1)
+}
+
+func TestAccessTokenAuthMiddleware(t *testing.T) {
+	personalToken := service.AccessTokenPrefix + "secret"
+
+	t.Run("personal access token carries its scopes", func(t *testing.T) {
+		tokenService := new(MockTokenService)
+		accessTokens := new(MockAccessTokenService)
+		accessTokens.On("Authenticate", mock.Anything, personalToken).Return(&service.AccessTokenOwner{
+			User:   service.User{Id: 7, Email: "jane@example.com", EmailVerified: true},
+			Scopes: []service.Scope{service.ScopeWorkoutsRead},
+		}, nil).Once()
+
+		rr, next := serve(middleware.AccessTokenAuthMiddleware(tokenService, accessTokens), bearerRequest(personalToken))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		if assert.NotNil(t, next.userInfo) {
+			assert.Equal(t, 7, next.userInfo.Id)
+			assert.Equal(t, []string{"workouts:read"}, next.userInfo.Scopes)
+		}
+		tokenService.AssertNotCalled(t, "ParseToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown or revoked personal access token", func(t *testing.T) {
+		accessTokens := new(MockAccessTokenService)
+		accessTokens.On("Authenticate", mock.Anything, personalToken).Return(nil, apperrors.ErrUnauthorized).Once()
+
+		rr, next := serve(middleware.AccessTokenAuthMiddleware(new(MockTokenService), accessTokens), bearerRequest(personalToken))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.False(t, next.called)
+	})
+
+	t.Run("lookup of the personal access token fails", func(t *testing.T) {
+		accessTokens := new(MockAccessTokenService)
+		accessTokens.On("Authenticate", mock.Anything, personalToken).Return(nil, errors.New("db error")).Once()
+
+		rr, next := serve(middleware.AccessTokenAuthMiddleware(new(MockTokenService), accessTokens), bearerRequest(personalToken))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.False(t, next.called)
+	})
+
+	t.Run("JWT of a login session goes through the JWT checks", func(t *testing.T) {
+		tokenService := new(MockTokenService)
+		accessTokens := new(MockAccessTokenService)
+		tokenService.On("ParseToken", mock.Anything, "jwt").Return(sessionClaims(), nil).Once()
+		tokenService.On("CheckBlacklist", mock.Anything, "jti-1").Return(false, nil).Once()
+		tokenService.On("CheckSessionRevoked", mock.Anything, "session-1").Return(true, nil).Once()
+
+		rr, next := serve(middleware.AccessTokenAuthMiddleware(tokenService, accessTokens), bearerRequest("jwt"))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.False(t, next.called)
+		accessTokens.AssertNotCalled(t, "Authenticate", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRequireScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		userInfo *helper.UserInfo
+		code     int
+	}{
+		{"Token with the scope", &helper.UserInfo{Id: 7, Scopes: []string{"workouts:read", "reports:read"}}, http.StatusOK},
+		{"Token without the scope", &helper.UserInfo{Id: 7, Scopes: []string{"workouts:write"}}, http.StatusForbidden},
+		{"Token without scopes", &helper.UserInfo{Id: 7, Scopes: []string{}}, http.StatusForbidden},
+		{"Login session", &helper.UserInfo{Id: 7}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr, next := serve(middleware.RequireScope(service.ScopeWorkoutsRead), withUser(tt.userInfo))
+
+			assert.Equal(t, tt.code, rr.Code)
+			assert.Equal(t, tt.code == http.StatusOK, next.called)
+		})
+	}
+
+	t.Run("No user in context", func(t *testing.T) {
+		rr, next := serve(middleware.RequireScope(service.ScopeWorkoutsRead), bearerRequest("ignored"))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.False(t, next.called)
+	})
+}
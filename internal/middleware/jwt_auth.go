@@ -14,20 +14,12 @@ func JWTAuthMiddleware(tokenService auth.TokenInterface) func(next http.Handler)
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// extract Token from AUthorization header
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
+			tokenString, ok := bearerToken(r)
+			if !ok {
 				helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
 				return
 			}
 
-			tokenParts := strings.Split(authHeader, " ")
-
-			if len(tokenParts) != 2 || strings.ToLower(tokenParts[0]) != "bearer" {
-				helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
-				return
-			}
-
-			tokenString := tokenParts[1]
 			// parse and validate token
 			claims, err := tokenService.ParseToken(r.Context(), tokenString)
 
@@ -57,15 +49,38 @@ func JWTAuthMiddleware(tokenService auth.TokenInterface) func(next http.Handler)
 				helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
 				return
 			}
+
+			// sessions revoked from another device end their access tokens at once
+			if claims.SessionId == "" {
+				log.Printf("Warning: JWT claims missing session id. Claims: %+v", claims)
+				helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+				return
+			}
+
+			isRevoked, err := tokenService.CheckSessionRevoked(r.Context(), claims.SessionId)
+			if err != nil {
+				helper.SendErrorResponse(w, fmt.Errorf("error checking revoked session: %w", err))
+				return
+			}
+
+			if isRevoked {
+				log.Printf("Attempt to use token of revoked session: %s", claims.SessionId)
+				helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+				return
+			}
+
 			// set user and JTI into context
 			ctx := helper.SetUserInfoToContext(r.Context(), &helper.UserInfo{
-				Id:    *claims.Payload.Id,
-				Email: claims.Email,
-				Name:  claims.Payload.Name,
+				Id:            *claims.Payload.Id,
+				Email:         claims.Email,
+				Name:          claims.Payload.Name,
+				EmailVerified: claims.EmailVerified,
+				Role:          claims.Role,
 			})
 
 			ctx = helper.SetJTIToContext(ctx, &helper.JTIInfo{
 				Id:             claims.ID,
+				SessionId:      claims.SessionId,
 				ExpirationTime: claims.ExpiresAt.Time,
 			})
 			r = r.WithContext(ctx)
@@ -75,3 +90,18 @@ func JWTAuthMiddleware(tokenService auth.TokenInterface) func(next http.Handler)
 		})
 	}
 }
+
+// bearerToken reads the token of a "Bearer <token>" Authorization header
+func bearerToken(r *http.Request) (string, bool) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return "", false
+	}
+
+	tokenParts := strings.Split(authHeader, " ")
+	if len(tokenParts) != 2 || strings.ToLower(tokenParts[0]) != "bearer" {
+		return "", false
+	}
+
+	return tokenParts[1], true
+}
@@ -0,0 +1,199 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"workout-tracker-api/internal/middleware"
+	"workout-tracker-api/internal/util/auth"
+	"workout-tracker-api/internal/util/helper"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTokenService is a mock implementation of auth.TokenInterface
+type MockTokenService struct {
+	mock.Mock
+}
+
+func (m *MockTokenService) GenerateToken(claims auth.Claims) (string, error) {
+	args := m.Called(claims)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTokenService) ParseToken(ctx context.Context, tokenString string) (*auth.Claims, error) {
+	args := m.Called(ctx, tokenString)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.Claims), args.Error(1)
+}
+
+func (m *MockTokenService) BlacklistToken(ctx context.Context, jti string, expirationTime time.Time) error {
+	args := m.Called(ctx, jti, expirationTime)
+	return args.Error(0)
+}
+
+func (m *MockTokenService) CheckBlacklist(ctx context.Context, jti string) (bool, error) {
+	args := m.Called(ctx, jti)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTokenService) JWKS() auth.JWKSet {
+	args := m.Called()
+	return args.Get(0).(auth.JWKSet)
+}
+
+func (m *MockTokenService) RevokeSession(ctx context.Context, sessionId string) error {
+	args := m.Called(ctx, sessionId)
+	return args.Error(0)
+}
+
+func (m *MockTokenService) CheckSessionRevoked(ctx context.Context, sessionId string) (bool, error) {
+	args := m.Called(ctx, sessionId)
+	return args.Bool(0), args.Error(1)
+}
+
+// nextHandler answers 200 and keeps the user info the middleware put into the context
+type nextHandler struct {
+	called   bool
+	userInfo *helper.UserInfo
+}
+
+func (h *nextHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.called = true
+	h.userInfo, _ = helper.GetUserInfoFromContext(r.Context())
+	w.WriteHeader(http.StatusOK)
+}
+
+// serve runs the request through the middleware in front of next
+func serve(mw func(http.Handler) http.Handler, req *http.Request) (*httptest.ResponseRecorder, *nextHandler) {
+	next := &nextHandler{}
+	rr := httptest.NewRecorder()
+	mw(next).ServeHTTP(rr, req)
+	return rr, next
+}
+
+// withUser is a request whose context already holds the user info, as after authentication
+func withUser(userInfo *helper.UserInfo) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/workout-tracker/v1/workouts", nil)
+	return req.WithContext(helper.SetUserInfoToContext(req.Context(), userInfo))
+}
+
+func bearerRequest(token string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/workout-tracker/v1/user/status", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+func sessionClaims() *auth.Claims {
+	id := 7
+	return &auth.Claims{
+		Payload: auth.Payload{
+			Id:            &id,
+			Name:          "Jane",
+			Email:         "jane@example.com",
+			SessionId:     "session-1",
+			EmailVerified: true,
+			Role:          "user",
+		},
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti-1",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(15 * time.Minute)),
+		},
+	}
+}
+
+func TestJWTAuthMiddleware(t *testing.T) {
+	t.Run("valid token of a live session", func(t *testing.T) {
+		tokenService := new(MockTokenService)
+		tokenService.On("ParseToken", mock.Anything, "good").Return(sessionClaims(), nil).Once()
+		tokenService.On("CheckBlacklist", mock.Anything, "jti-1").Return(false, nil).Once()
+		tokenService.On("CheckSessionRevoked", mock.Anything, "session-1").Return(false, nil).Once()
+
+		rr, next := serve(middleware.JWTAuthMiddleware(tokenService), bearerRequest("good"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, next.called)
+		if assert.NotNil(t, next.userInfo) {
+			assert.Equal(t, 7, next.userInfo.Id)
+			assert.Equal(t, "user", next.userInfo.Role)
+			assert.Nil(t, next.userInfo.Scopes)
+		}
+		tokenService.AssertExpectations(t)
+	})
+
+	t.Run("token of a revoked session", func(t *testing.T) {
+		tokenService := new(MockTokenService)
+		tokenService.On("ParseToken", mock.Anything, "good").Return(sessionClaims(), nil).Once()
+		tokenService.On("CheckBlacklist", mock.Anything, "jti-1").Return(false, nil).Once()
+		tokenService.On("CheckSessionRevoked", mock.Anything, "session-1").Return(true, nil).Once()
+
+		rr, next := serve(middleware.JWTAuthMiddleware(tokenService), bearerRequest("good"))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.False(t, next.called)
+	})
+
+	t.Run("revoked session lookup fails", func(t *testing.T) {
+		tokenService := new(MockTokenService)
+		tokenService.On("ParseToken", mock.Anything, "good").Return(sessionClaims(), nil).Once()
+		tokenService.On("CheckBlacklist", mock.Anything, "jti-1").Return(false, nil).Once()
+		tokenService.On("CheckSessionRevoked", mock.Anything, "session-1").Return(false, errors.New("cache down")).Once()
+
+		rr, next := serve(middleware.JWTAuthMiddleware(tokenService), bearerRequest("good"))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.False(t, next.called)
+	})
+
+	t.Run("token without a session", func(t *testing.T) {
+		claims := sessionClaims()
+		claims.SessionId = ""
+		tokenService := new(MockTokenService)
+		tokenService.On("ParseToken", mock.Anything, "good").Return(claims, nil).Once()
+		tokenService.On("CheckBlacklist", mock.Anything, "jti-1").Return(false, nil).Once()
+
+		rr, next := serve(middleware.JWTAuthMiddleware(tokenService), bearerRequest("good"))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.False(t, next.called)
+		tokenService.AssertNotCalled(t, "CheckSessionRevoked", mock.Anything, mock.Anything)
+	})
+
+	t.Run("blacklisted token", func(t *testing.T) {
+		tokenService := new(MockTokenService)
+		tokenService.On("ParseToken", mock.Anything, "good").Return(sessionClaims(), nil).Once()
+		tokenService.On("CheckBlacklist", mock.Anything, "jti-1").Return(true, nil).Once()
+
+		rr, next := serve(middleware.JWTAuthMiddleware(tokenService), bearerRequest("good"))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.False(t, next.called)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		tokenService := new(MockTokenService)
+		tokenService.On("ParseToken", mock.Anything, "bad").Return(nil, errors.New("signature is invalid")).Once()
+
+		rr, next := serve(middleware.JWTAuthMiddleware(tokenService), bearerRequest("bad"))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.False(t, next.called)
+	})
+
+	t.Run("no Authorization header", func(t *testing.T) {
+		tokenService := new(MockTokenService)
+
+		rr, next := serve(middleware.JWTAuthMiddleware(tokenService), httptest.NewRequest(http.MethodGet, "/workout-tracker/v1/user/status", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.False(t, next.called)
+		tokenService.AssertNotCalled(t, "ParseToken", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/util/helper"
+)
+
+// RequireRole lets through users whose token carries one of the roles. It has to run after
+// JWTAuthMiddleware, which puts the role of the token into the context.
+func RequireRole(roles ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+			if !ok {
+				log.Printf("Failed to get user info from context")
+				helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+				return
+			}
+
+			if !slices.Contains(roles, userInfo.Role) {
+				log.Printf("User %d with role '%s' tried to reach %s", userInfo.Id, userInfo.Role, r.URL.Path)
+				helper.SendErrorResponse(w, fmt.Errorf("%w: missing required role", apperrors.ErrForbidden))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
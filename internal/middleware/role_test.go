@@ -0,0 +1,48 @@
+package middleware_test
+
+import (
+	"net/http"
+	"testing"
+	"workout-tracker-api/internal/middleware"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util/helper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireRole(t *testing.T) {
+	admin := string(service.RoleAdmin)
+
+	tests := []struct {
+		name     string
+		userInfo *helper.UserInfo
+		code     int
+	}{
+		{"Admin", &helper.UserInfo{Id: 1, Role: admin}, http.StatusOK},
+		{"User", &helper.UserInfo{Id: 2, Role: string(service.RoleUser)}, http.StatusForbidden},
+		{"Token without a role", &helper.UserInfo{Id: 3}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr, next := serve(middleware.RequireRole(admin), withUser(tt.userInfo))
+
+			assert.Equal(t, tt.code, rr.Code)
+			assert.Equal(t, tt.code == http.StatusOK, next.called)
+		})
+	}
+
+	t.Run("Any of several roles", func(t *testing.T) {
+		rr, next := serve(middleware.RequireRole(admin, string(service.RoleUser)), withUser(&helper.UserInfo{Id: 2, Role: string(service.RoleUser)}))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, next.called)
+	})
+
+	t.Run("No user in context", func(t *testing.T) {
+		rr, next := serve(middleware.RequireRole(admin), bearerRequest("ignored"))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.False(t, next.called)
+	})
+}
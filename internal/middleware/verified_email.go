@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/util/helper"
+)
+
+// RequireVerifiedEmail keeps users who have not confirmed their email out of the wrapped routes.
+// It reads the claim set at login, so a user who verifies has to refresh the access token first.
+func RequireVerifiedEmail() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userInfo, ok := helper.GetUserInfoFromContext(r.Context())
+			if !ok {
+				log.Printf("Failed to get user info from context")
+				helper.SendErrorResponse(w, apperrors.ErrUnauthorized)
+				return
+			}
+
+			if !userInfo.EmailVerified {
+				helper.SendErrorResponse(w, fmt.Errorf("%w: email is not verified", apperrors.ErrForbidden))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package middleware_test
+
+import (
+	"net/http"
+	"testing"
+	"workout-tracker-api/internal/middleware"
+	"workout-tracker-api/internal/util/helper"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireVerifiedEmail(t *testing.T) {
+	t.Run("Verified email", func(t *testing.T) {
+		rr, next := serve(middleware.RequireVerifiedEmail(), withUser(&helper.UserInfo{Id: 7, EmailVerified: true}))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, next.called)
+	})
+
+	t.Run("Unverified email", func(t *testing.T) {
+		rr, next := serve(middleware.RequireVerifiedEmail(), withUser(&helper.UserInfo{Id: 7}))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.False(t, next.called)
+	})
+
+	t.Run("No user in context", func(t *testing.T) {
+		rr, next := serve(middleware.RequireVerifiedEmail(), bearerRequest("ignored"))
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.False(t, next.called)
+	})
+}
@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+
+	"github.com/lib/pq"
+)
+
+// AccessToken is a stored personal access token. ExpiresAt is not set for tokens that do not
+// expire, LastUsedAt until the token is used for the first time.
+type AccessToken struct {
+	Id         int          `json:"id"`
+	UserId     int          `json:"userId"`
+	Name       string       `json:"name"`
+	TokenHash  string       `json:"tokenHash"`
+	Scopes     []string     `json:"scopes"`
+	ExpiresAt  sql.NullTime `json:"expiresAt"`
+	LastUsedAt sql.NullTime `json:"lastUsedAt"`
+	CreatedAt  time.Time    `json:"createdAt"`
+	UpdatedAt  time.Time    `json:"updatedAt"`
+}
+
+type CreateAccessToken struct {
+	UserId    int          `json:"userId"`
+	Name      string       `json:"name"`
+	TokenHash string       `json:"tokenHash"`
+	Scopes    []string     `json:"scopes"`
+	ExpiresAt sql.NullTime `json:"expiresAt"`
+}
+
+type UpdateAccessToken struct {
+	Id     int      `json:"id"`
+	UserId int      `json:"userId"`
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type AccessTokenRepository interface {
+	CreateAccessToken(ctx context.Context, data CreateAccessToken) (*AccessToken, error)
+	ListAccessTokens(ctx context.Context, userId int) ([]AccessToken, error)
+	GetAccessToken(ctx context.Context, userId int, id int) (*AccessToken, error)
+	GetAccessTokenByHash(ctx context.Context, tokenHash string) (*AccessToken, error)
+	UpdateAccessToken(ctx context.Context, data UpdateAccessToken) (*AccessToken, error)
+	DeleteAccessToken(ctx context.Context, userId int, id int) error
+	DeleteUserAccessTokens(ctx context.Context, userId int) (int64, error)
+	TouchAccessToken(ctx context.Context, id int) error
+}
+
+type postgresAccessTokenRepository struct {
+	db *sql.DB
+}
+
+func NewAccessTokenRepository(db *sql.DB) AccessTokenRepository {
+	return &postgresAccessTokenRepository{
+		db: db,
+	}
+}
+
+const accessTokenColumns = `id,
+	user_id,
+	name,
+	token_hash,
+	scopes,
+	expires_at,
+	last_used_at,
+	created_at,
+	updated_at`
+
+func scanAccessToken(row interface{ Scan(...any) error }, at *AccessToken) error {
+	return row.Scan(
+		&at.Id,
+		&at.UserId,
+		&at.Name,
+		&at.TokenHash,
+		pq.Array(&at.Scopes),
+		&at.ExpiresAt,
+		&at.LastUsedAt,
+		&at.CreatedAt,
+		&at.UpdatedAt,
+	)
+}
+
+func (r *postgresAccessTokenRepository) CreateAccessToken(ctx context.Context, data CreateAccessToken) (*AccessToken, error) {
+	query := `INSERT INTO personal_access_tokens (user_id, name, token_hash, scopes, expires_at)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING ` + accessTokenColumns
+
+	row, err := executeQueryRow(ctx, r.db, query, data.UserId, data.Name, data.TokenHash, pq.Array(data.Scopes), data.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert access token: %w", err)
+	}
+
+	var at AccessToken
+	if err := scanAccessToken(row, &at); err != nil {
+		return nil, fmt.Errorf("failed to scan created access token: %w", mapDBError(err))
+	}
+
+	return &at, nil
+}
+
+func (r *postgresAccessTokenRepository) ListAccessTokens(ctx context.Context, userId int) ([]AccessToken, error) {
+	query := `SELECT ` + accessTokenColumns + ` FROM personal_access_tokens
+	WHERE user_id = $1
+	ORDER BY created_at DESC, id DESC`
+
+	rows, err := executeQuery(ctx, r.db, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query access tokens for user id '%v': %w", userId, err)
+	}
+	defer rows.Close()
+
+	var tokens []AccessToken
+	for rows.Next() {
+		var at AccessToken
+		if err := scanAccessToken(rows, &at); err != nil {
+			return nil, fmt.Errorf("failed to scan access token row: %w", err)
+		}
+		tokens = append(tokens, at)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating access token rows: %w", err)
+	}
+
+	return tokens, nil
+}
+
+func (r *postgresAccessTokenRepository) GetAccessToken(ctx context.Context, userId int, id int) (*AccessToken, error) {
+	query := `SELECT ` + accessTokenColumns + ` FROM personal_access_tokens WHERE id = $1 AND user_id = $2`
+
+	row, err := executeQueryRow(ctx, r.db, query, id, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query for access token id '%v': %w", id, err)
+	}
+
+	var at AccessToken
+	if err := scanAccessToken(row, &at); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to scan access token: %w", err)
+	}
+
+	return &at, nil
+}
+
+func (r *postgresAccessTokenRepository) GetAccessTokenByHash(ctx context.Context, tokenHash string) (*AccessToken, error) {
+	query := `SELECT ` + accessTokenColumns + ` FROM personal_access_tokens WHERE token_hash = $1`
+
+	row, err := executeQueryRow(ctx, r.db, query, tokenHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query for access token: %w", err)
+	}
+
+	var at AccessToken
+	if err := scanAccessToken(row, &at); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to scan access token: %w", err)
+	}
+
+	return &at, nil
+}
+
+func (r *postgresAccessTokenRepository) UpdateAccessToken(ctx context.Context, data UpdateAccessToken) (*AccessToken, error) {
+	query := `UPDATE personal_access_tokens SET name = $3, scopes = $4, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND user_id = $2
+	RETURNING ` + accessTokenColumns
+
+	row, err := executeQueryRow(ctx, r.db, query, data.Id, data.UserId, data.Name, pq.Array(data.Scopes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update access token id '%v': %w", data.Id, err)
+	}
+
+	var at AccessToken
+	if err := scanAccessToken(row, &at); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to scan updated access token: %w", err)
+	}
+
+	return &at, nil
+}
+
+func (r *postgresAccessTokenRepository) DeleteAccessToken(ctx context.Context, userId int, id int) error {
+	query := `DELETE FROM personal_access_tokens WHERE id = $1 AND user_id = $2`
+
+	result, err := executeNonQuery(ctx, r.db, query, id, userId)
+	if err != nil {
+		return fmt.Errorf("failed to delete access token id '%v': %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteUserAccessTokens deletes every token of the user and returns how many there were
+func (r *postgresAccessTokenRepository) DeleteUserAccessTokens(ctx context.Context, userId int) (int64, error) {
+	query := `DELETE FROM personal_access_tokens WHERE user_id = $1`
+
+	result, err := executeNonQuery(ctx, r.db, query, userId)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete access tokens of user id '%v': %w", userId, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
+// TouchAccessToken records the use of a token. It writes at most once a minute per token, scripts
+// making many requests do not turn every read into a write.
+func (r *postgresAccessTokenRepository) TouchAccessToken(ctx context.Context, id int) error {
+	query := `UPDATE personal_access_tokens SET last_used_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < CURRENT_TIMESTAMP - INTERVAL '1 minute')`
+
+	if _, err := executeNonQuery(ctx, r.db, query, id); err != nil {
+		return fmt.Errorf("failed to touch access token id '%v': %w", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,265 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+)
+
+var accessTokenColumns = []string{"id", "user_id", "name", "token_hash", "scopes", "expires_at", "last_used_at", "created_at", "updated_at"}
+
+func TestCreateAccessToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	atRepo := repository.NewAccessTokenRepository(db)
+	ctx := context.Background()
+	expiresAt := sql.NullTime{Time: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	createdAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	query := `INSERT INTO personal_access_tokens (user_id, name, token_hash, scopes, expires_at)`
+	data := repository.CreateAccessToken{UserId: 5, Name: "sync script", TokenHash: "hash", Scopes: []string{"workouts:read", "workouts:write"}, ExpiresAt: expiresAt}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(5, "sync script", "hash", pq.Array(data.Scopes), expiresAt).
+			WillReturnRows(sqlmock.NewRows(accessTokenColumns).
+				AddRow(1, 5, "sync script", "hash", "{workouts:read,workouts:write}", expiresAt.Time, nil, createdAt, createdAt))
+
+		at, err := atRepo.CreateAccessToken(ctx, data)
+		assert.NoError(t, err)
+		assert.Equal(t, &repository.AccessToken{
+			Id:        1,
+			UserId:    5,
+			Name:      "sync script",
+			TokenHash: "hash",
+			Scopes:    []string{"workouts:read", "workouts:write"},
+			ExpiresAt: expiresAt,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}, at)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		dbError := errors.New("insert failed")
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(5, "sync script", "hash", pq.Array(data.Scopes), expiresAt).
+			WillReturnError(dbError)
+
+		at, err := atRepo.CreateAccessToken(ctx, data)
+		assert.ErrorIs(t, err, dbError)
+		assert.Nil(t, at)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestListAccessTokens(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	atRepo := repository.NewAccessTokenRepository(db)
+	ctx := context.Background()
+	createdAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	usedAt := time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC)
+	query := `FROM personal_access_tokens
+	WHERE user_id = $1
+	ORDER BY created_at DESC, id DESC`
+
+	mock.ExpectPrepare(regexp.QuoteMeta(query)).
+		ExpectQuery().
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows(accessTokenColumns).
+			AddRow(2, 5, "reports", "hash-2", "{reports:read}", nil, nil, createdAt, createdAt).
+			AddRow(1, 5, "sync script", "hash-1", "{workouts:read}", nil, usedAt, createdAt, createdAt))
+
+	tokens, err := atRepo.ListAccessTokens(ctx, 5)
+	assert.NoError(t, err)
+	if assert.Len(t, tokens, 2) {
+		assert.Equal(t, []string{"reports:read"}, tokens[0].Scopes)
+		assert.False(t, tokens[0].LastUsedAt.Valid)
+		assert.Equal(t, usedAt, tokens[1].LastUsedAt.Time)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAccessToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	atRepo := repository.NewAccessTokenRepository(db)
+	ctx := context.Background()
+	createdAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("by id of the owner", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(`FROM personal_access_tokens WHERE id = $1 AND user_id = $2`)).
+			ExpectQuery().
+			WithArgs(1, 5).
+			WillReturnRows(sqlmock.NewRows(accessTokenColumns).
+				AddRow(1, 5, "sync script", "hash", "{workouts:read}", nil, nil, createdAt, createdAt))
+
+		at, err := atRepo.GetAccessToken(ctx, 5, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "sync script", at.Name)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("by hash", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(`FROM personal_access_tokens WHERE token_hash = $1`)).
+			ExpectQuery().
+			WithArgs("hash").
+			WillReturnRows(sqlmock.NewRows(accessTokenColumns).
+				AddRow(1, 5, "sync script", "hash", "{workouts:read}", nil, nil, createdAt, createdAt))
+
+		at, err := atRepo.GetAccessTokenByHash(ctx, "hash")
+		assert.NoError(t, err)
+		assert.Equal(t, 5, at.UserId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(`FROM personal_access_tokens WHERE token_hash = $1`)).
+			ExpectQuery().
+			WithArgs("unknown").
+			WillReturnError(sql.ErrNoRows)
+
+		at, err := atRepo.GetAccessTokenByHash(ctx, "unknown")
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Nil(t, at)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateAccessToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	atRepo := repository.NewAccessTokenRepository(db)
+	ctx := context.Background()
+	createdAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	query := `UPDATE personal_access_tokens SET name = $3, scopes = $4, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND user_id = $2`
+	data := repository.UpdateAccessToken{Id: 1, UserId: 5, Name: "read only", Scopes: []string{"workouts:read"}}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(1, 5, "read only", pq.Array(data.Scopes)).
+			WillReturnRows(sqlmock.NewRows(accessTokenColumns).
+				AddRow(1, 5, "read only", "hash", "{workouts:read}", nil, nil, createdAt, createdAt))
+
+		at, err := atRepo.UpdateAccessToken(ctx, data)
+		assert.NoError(t, err)
+		assert.Equal(t, "read only", at.Name)
+		assert.Equal(t, []string{"workouts:read"}, at.Scopes)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("token of another user", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(1, 5, "read only", pq.Array(data.Scopes)).
+			WillReturnError(sql.ErrNoRows)
+
+		at, err := atRepo.UpdateAccessToken(ctx, data)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Nil(t, at)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteAccessToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	atRepo := repository.NewAccessTokenRepository(db)
+	ctx := context.Background()
+	query := `DELETE FROM personal_access_tokens WHERE id = $1 AND user_id = $2`
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(1, 5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, atRepo.DeleteAccessToken(ctx, 5, 1))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(9, 5).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.ErrorIs(t, atRepo.DeleteAccessToken(ctx, 5, 9), apperrors.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteUserAccessTokens(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	atRepo := repository.NewAccessTokenRepository(db)
+	ctx := context.Background()
+
+	mock.ExpectPrepare(regexp.QuoteMeta(`DELETE FROM personal_access_tokens WHERE user_id = $1`)).
+		ExpectExec().
+		WithArgs(5).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	deleted, err := atRepo.DeleteUserAccessTokens(ctx, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTouchAccessToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	atRepo := repository.NewAccessTokenRepository(db)
+
+	mock.ExpectPrepare(regexp.QuoteMeta(`UPDATE personal_access_tokens SET last_used_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < CURRENT_TIMESTAMP - INTERVAL '1 minute')`)).
+		ExpectExec().
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, atRepo.TouchAccessToken(context.Background(), 1))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// SystemStats are the counts shown on the admin overview
+type SystemStats struct {
+	Users             int `json:"users"`
+	Admins            int `json:"admins"`
+	DisabledUsers     int `json:"disabledUsers"`
+	VerifiedUsers     int `json:"verifiedUsers"`
+	ActiveSessions    int `json:"activeSessions"`
+	WorkoutPlans      int `json:"workoutPlans"`
+	CompletedWorkouts int `json:"completedWorkouts"`
+	PerformedSets     int `json:"performedSets"`
+	CatalogExercises  int `json:"catalogExercises"`
+	CustomExercises   int `json:"customExercises"`
+}
+
+type AdminRepository interface {
+	GetSystemStats(ctx context.Context) (*SystemStats, error)
+}
+
+type postgresAdminRepository struct {
+	db *sql.DB
+}
+
+func NewAdminRepository(db *sql.DB) AdminRepository {
+	return &postgresAdminRepository{
+		db: db,
+	}
+}
+
+func (r *postgresAdminRepository) GetSystemStats(ctx context.Context) (*SystemStats, error) {
+	query := `SELECT
+		(SELECT COUNT(*) FROM users),
+		(SELECT COUNT(*) FROM users WHERE role = 'admin'),
+		(SELECT COUNT(*) FROM users WHERE disabled_at IS NOT NULL),
+		(SELECT COUNT(*) FROM users WHERE email_verified_at IS NOT NULL),
+		(SELECT COUNT(*) FROM sessions WHERE revoked_at IS NULL),
+		(SELECT COUNT(*) FROM workout_plans),
+		(SELECT COUNT(*) FROM workout_plans WHERE status = 'completed'),
+		(SELECT COUNT(*) FROM performed_sets),
+		(SELECT COUNT(*) FROM exercises WHERE owner_id IS NULL AND archived_at IS NULL),
+		(SELECT COUNT(*) FROM exercises WHERE owner_id IS NOT NULL AND archived_at IS NULL)`
+
+	row, err := executeQueryRow(ctx, r.db, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query for system stats: %w", err)
+	}
+
+	var stats SystemStats
+	err = row.Scan(
+		&stats.Users,
+		&stats.Admins,
+		&stats.DisabledUsers,
+		&stats.VerifiedUsers,
+		&stats.ActiveSessions,
+		&stats.WorkoutPlans,
+		&stats.CompletedWorkouts,
+		&stats.PerformedSets,
+		&stats.CatalogExercises,
+		&stats.CustomExercises,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan system stats: %w", err)
+	}
+
+	return &stats, nil
+}
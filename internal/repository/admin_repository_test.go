@@ -0,0 +1,58 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"workout-tracker-api/internal/repository"
+)
+
+func TestGetSystemStats(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	adminRepo := repository.NewAdminRepository(db)
+	ctx := context.Background()
+	query := regexp.QuoteMeta(`(SELECT COUNT(*) FROM users WHERE role = 'admin')`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectQuery().
+			WillReturnRows(sqlmock.NewRows([]string{"users", "admins", "disabled", "verified", "sessions", "workouts", "completed", "sets", "catalog", "custom"}).
+				AddRow(120, 2, 3, 100, 80, 900, 640, 12000, 45, 30))
+
+		stats, err := adminRepo.GetSystemStats(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, &repository.SystemStats{
+			Users:             120,
+			Admins:            2,
+			DisabledUsers:     3,
+			VerifiedUsers:     100,
+			ActiveSessions:    80,
+			WorkoutPlans:      900,
+			CompletedWorkouts: 640,
+			PerformedSets:     12000,
+			CatalogExercises:  45,
+			CustomExercises:   30,
+		}, stats)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		dbError := errors.New("connection reset")
+		mock.ExpectPrepare(query).ExpectQuery().WillReturnError(dbError)
+
+		stats, err := adminRepo.GetSystemStats(ctx)
+		assert.ErrorIs(t, err, dbError)
+		assert.Nil(t, stats)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
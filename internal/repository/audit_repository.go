@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type CreateAuditEntry struct {
+	Event     string `json:"event"`
+	UserId    *int   `json:"userId,omitempty"`
+	ActorId   *int   `json:"actorId,omitempty"`
+	IPAddress string `json:"ipAddress"`
+	Detail    string `json:"detail"`
+}
+
+type AuditRepository interface {
+	CreateAuditEntry(ctx context.Context, data CreateAuditEntry) error
+}
+
+type postgresAuditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) AuditRepository {
+	return &postgresAuditRepository{
+		db: db,
+	}
+}
+
+func (r *postgresAuditRepository) CreateAuditEntry(ctx context.Context, data CreateAuditEntry) error {
+	query := `INSERT INTO audit_log (event, user_id, actor_id, ip_address, detail) VALUES ($1, $2, $3, $4, $5)`
+
+	if _, err := executeNonQuery(ctx, r.db, query, data.Event, data.UserId, data.ActorId, data.IPAddress, data.Detail); err != nil {
+		return fmt.Errorf("failed to insert audit entry '%s': %w", data.Event, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"workout-tracker-api/internal/repository"
+)
+
+func TestCreateAuditEntry(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	auditRepo := repository.NewAuditRepository(db)
+	ctx := context.Background()
+	query := regexp.QuoteMeta(`INSERT INTO audit_log (event, user_id, actor_id, ip_address, detail) VALUES ($1, $2, $3, $4, $5)`)
+
+	t.Run("success", func(t *testing.T) {
+		userId := 5
+		mock.ExpectPrepare(query).
+			ExpectExec().
+			WithArgs("login.locked", &userId, nil, "203.0.113.7", "5 failed attempts").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := auditRepo.CreateAuditEntry(ctx, repository.CreateAuditEntry{
+			Event:     "login.locked",
+			UserId:    &userId,
+			IPAddress: "203.0.113.7",
+			Detail:    "5 failed attempts",
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		dbError := errors.New("insert failed")
+
+		mock.ExpectPrepare(query).
+			ExpectExec().
+			WillReturnError(dbError)
+
+		err := auditRepo.CreateAuditEntry(ctx, repository.CreateAuditEntry{Event: "login.locked"})
+		assert.ErrorIs(t, err, dbError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
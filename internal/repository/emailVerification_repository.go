@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+)
+
+type CreateVerificationToken struct {
+	UserId    int       `json:"userId"`
+	TokenHash string    `json:"tokenHash"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type EmailVerificationRepository interface {
+	CreateVerificationToken(ctx context.Context, data CreateVerificationToken) error
+	ConsumeVerificationToken(ctx context.Context, tokenHash string) (int, error)
+	InvalidateUserVerificationTokens(ctx context.Context, userId int) error
+}
+
+type postgresEmailVerificationRepository struct {
+	db *sql.DB
+}
+
+func NewEmailVerificationRepository(db *sql.DB) EmailVerificationRepository {
+	return &postgresEmailVerificationRepository{
+		db: db,
+	}
+}
+
+func (r *postgresEmailVerificationRepository) CreateVerificationToken(ctx context.Context, data CreateVerificationToken) error {
+	query := `INSERT INTO email_verification_tokens (user_id, token_hash, expires_at) VALUES ($1, $2, $3)`
+
+	if _, err := executeNonQuery(ctx, r.db, query, data.UserId, data.TokenHash, data.ExpiresAt); err != nil {
+		return fmt.Errorf("failed to insert email verification token for user id '%v': %w", data.UserId, err)
+	}
+
+	return nil
+}
+
+// ConsumeVerificationToken uses up a valid token and returns the id of the user it was mailed to
+func (r *postgresEmailVerificationRepository) ConsumeVerificationToken(ctx context.Context, tokenHash string) (int, error) {
+	query := `UPDATE email_verification_tokens SET used_at = CURRENT_TIMESTAMP
+	WHERE token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+	RETURNING user_id`
+
+	row, err := executeQueryRow(ctx, r.db, query, tokenHash)
+	if err != nil {
+		return 0, fmt.Errorf("failed to consume email verification token: %w", err)
+	}
+
+	var userId int
+	if err := row.Scan(&userId); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, apperrors.ErrNotFound
+		}
+		return 0, fmt.Errorf("failed to scan user id of email verification token: %w", err)
+	}
+
+	return userId, nil
+}
+
+// InvalidateUserVerificationTokens retires the tokens still pending for the user, e.g. on a resend
+func (r *postgresEmailVerificationRepository) InvalidateUserVerificationTokens(ctx context.Context, userId int) error {
+	query := `UPDATE email_verification_tokens SET used_at = CURRENT_TIMESTAMP
+	WHERE user_id = $1 AND used_at IS NULL`
+
+	if _, err := executeNonQuery(ctx, r.db, query, userId); err != nil {
+		return fmt.Errorf("failed to invalidate email verification tokens of user id '%v': %w", userId, err)
+	}
+
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"fmt"
 	"workout-tracker-api/internal/apperrors"
+
+	"github.com/lib/pq"
 )
 
 type WeightUnit string
@@ -12,6 +14,7 @@ type ExercisePlan struct {
 	Id            int        `json:"id"`
 	ExerciseId    int        `json:"exerciseId"`
 	WorkoutPlanId int        `json:"workoutPlanId"`
+	Position      int        `json:"position"`
 	Sets          int        `json:"sets"`
 	Repetitions   int        `json:"repetitions"`
 	Weights       float32    `json:"weights"`
@@ -40,12 +43,36 @@ const (
 	OTHER WeightUnit = "other"
 )
 
+// ExercisePlanDetail is an exercise plan with the name and muscle group of its exercise
+type ExercisePlanDetail struct {
+	ExercisePlan
+	ExerciseName string
+	MuscleGroup  MuscleGroup
+}
+
 type ExercisePlanRepository interface {
 	CreateExercisePlan(ctx context.Context, data CreateEP, workoutPlanID int) (*ExercisePlan, error)
+	InsertExercisePlan(ctx context.Context, data CreateEP, workoutPlanID int, position int) (*ExercisePlan, error)
 	GetExercisePlanById(ctx context.Context, id int) (*ExercisePlan, error)
 	UpdateExercisePlan(ctx context.Context, data UpdateEP) (*ExercisePlan, error)
+	MoveExercisePlan(ctx context.Context, id int, position int) (*ExercisePlan, error)
 	DeleteExercisePlanByID(ctx context.Context, id int) error
 	ListExercisePlans(ctx context.Context, workoutID int) ([]ExercisePlan, error)
+	ListExercisePlanDetails(ctx context.Context, workoutIds []int) ([]ExercisePlanDetail, error)
+}
+
+const exercisePlanColumns = `id, exercise_id, workout_plan_id, position, sets, repetitions, weights, weight_unit`
+
+func scanExercisePlan(row interface{ Scan(...any) error }, ep *ExercisePlan, extra ...any) error {
+	return row.Scan(append([]any{
+		&ep.Id,
+		&ep.ExerciseId,
+		&ep.WorkoutPlanId,
+		&ep.Position,
+		&ep.Sets,
+		&ep.Repetitions,
+		&ep.Weights,
+		&ep.WeightUnit}, extra...)...)
 }
 
 type postgresEPRepository struct {
@@ -58,13 +85,20 @@ func NewEPRepository(db *sql.DB) ExercisePlanRepository {
 	}
 }
 
+// CreateExercisePlan appends the exercise plan after the last one of the workout plan
 func (r *postgresEPRepository) CreateExercisePlan(ctx context.Context, data CreateEP, workoutPlanId int) (*ExercisePlan, error) {
+	return r.InsertExercisePlan(ctx, data, workoutPlanId, 0)
+}
+
+// InsertExercisePlan puts the exercise plan at the given 1-based position and shifts the ones after it down.
+// A position of 0 or past the end appends it
+func (r *postgresEPRepository) InsertExercisePlan(ctx context.Context, data CreateEP, workoutPlanId int, position int) (*ExercisePlan, error) {
 	var newExercisePlan ExercisePlan
 
 	err := executeTransaction(ctx, r.db, func(txCtx context.Context, tx *sql.Tx) error {
-		// 1. Verify that the workout plan exists
+		// 1. Verify that the workout plan exists, locking it so concurrent inserts get consecutive positions
 		var currentWorkoutPlanID int
-		err := tx.QueryRowContext(txCtx, "SELECT id FROM workout_plans WHERE id = $1", workoutPlanId).Scan(&currentWorkoutPlanID)
+		err := tx.QueryRowContext(txCtx, "SELECT id FROM workout_plans WHERE id = $1 FOR UPDATE", workoutPlanId).Scan(&currentWorkoutPlanID)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				return apperrors.ErrForeignKeyViolation
@@ -72,41 +106,46 @@ func (r *postgresEPRepository) CreateExercisePlan(ctx context.Context, data Crea
 			return fmt.Errorf("failed to query workout plan id '%d' for creating exercise plan: %w", workoutPlanId, err)
 		}
 
-		// 2. Insert the new exercise plan
+		// 2. Make room at the position
+		var count int
+		err = tx.QueryRowContext(txCtx, "SELECT COUNT(*) FROM exercise_plans WHERE workout_plan_id = $1", workoutPlanId).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("failed to count exercise plans of workout plan id '%d': %w", workoutPlanId, err)
+		}
+
+		if position <= 0 || position > count {
+			position = count + 1
+		} else {
+			_, err = tx.ExecContext(txCtx,
+				"UPDATE exercise_plans SET position = position + 1 WHERE workout_plan_id = $1 AND position >= $2",
+				workoutPlanId, position)
+			if err != nil {
+				return fmt.Errorf("failed to shift exercise plans of workout plan id '%d': %w", workoutPlanId, err)
+			}
+		}
+
+		// 3. Insert the new exercise plan
 		insertQuery := `INSERT INTO exercise_plans (
 			exercise_id, 
 			workout_plan_id,
+			position,
 			sets,
 			repetitions,
 			weights,
 			weight_unit
-			) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, 
-			exercise_id, 
-			workout_plan_id,
-			sets,
-			repetitions,
-			weights,
-			weight_unit
-			`
+			) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING ` + exercisePlanColumns
 
 		// Use tx.QueryRow for INSERT ... RETURNING
-		err = tx.QueryRowContext(txCtx,
+		err = scanExercisePlan(tx.QueryRowContext(txCtx,
 			insertQuery,
 			data.ExerciseId,
 			workoutPlanId, // Use the validated workoutPlanID
+			position,
 			data.Sets,
 			data.Repetitions,
 			data.Weights,
 			data.WeightUnit,
-		).Scan(
-			&newExercisePlan.Id,
-			&newExercisePlan.ExerciseId,
-			&newExercisePlan.WorkoutPlanId,
-			&newExercisePlan.Sets,
-			&newExercisePlan.Repetitions,
-			&newExercisePlan.Weights,
-			&newExercisePlan.WeightUnit,
-		)
+		), &newExercisePlan)
 		if err != nil {
 			return fmt.Errorf("failed to insert and scan new exercise plan: %w", err)
 		}
@@ -124,28 +163,14 @@ func (r *postgresEPRepository) CreateExercisePlan(ctx context.Context, data Crea
 func (r *postgresEPRepository) GetExercisePlanById(ctx context.Context, id int) (*ExercisePlan, error) {
 	var exercisePlan ExercisePlan
 
-	query := `SELECT 
-        id, 
-		exercise_id, 
-		workout_plan_id,
-		sets,
-		repetitions,
-		weights,
-		weight_unit FROM exercise_plans WHERE id = $1`
+	query := `SELECT ` + exercisePlanColumns + ` FROM exercise_plans WHERE id = $1`
 
 	row, err := executeQueryRow(ctx, r.db, query, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query exercise plan by id '%v': %w", id, err)
 	}
 
-	err = row.Scan(
-		&exercisePlan.Id,
-		&exercisePlan.ExerciseId,
-		&exercisePlan.WorkoutPlanId,
-		&exercisePlan.Sets,
-		&exercisePlan.Repetitions,
-		&exercisePlan.Weights,
-		&exercisePlan.WeightUnit)
+	err = scanExercisePlan(row, &exercisePlan)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -179,22 +204,14 @@ func (r *postgresEPRepository) UpdateExercisePlan(ctx context.Context, data Upda
 						weights = COALESCE($3, weights),
 						weight_unit = COALESCE($4, weight_unit)
 						WHERE id = $5
-						RETURNING id, exercise_id, workout_plan_id, sets, repetitions, weights, weight_unit`
-		err = tx.QueryRowContext(txCtx,
+						RETURNING ` + exercisePlanColumns
+		err = scanExercisePlan(tx.QueryRowContext(txCtx,
 			query,
 			data.Sets,
 			data.Repetitions,
 			data.Weights,
 			data.WeightUnit,
-			data.Id).Scan(
-			&updatedEP.Id,
-			&updatedEP.ExerciseId,
-			&updatedEP.WorkoutPlanId,
-			&updatedEP.Sets,
-			&updatedEP.Repetitions,
-			&updatedEP.Weights,
-			&updatedEP.WeightUnit,
-		)
+			data.Id), &updatedEP)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				return apperrors.ErrNotFound
@@ -212,35 +229,88 @@ func (r *postgresEPRepository) UpdateExercisePlan(ctx context.Context, data Upda
 	return outsideUpdatedEP, nil
 }
 
-func (r *postgresEPRepository) DeleteExercisePlanByID(ctx context.Context, id int) error {
-	deleteQuery := `DELETE FROM exercise_plans WHERE id = $1`
+// MoveExercisePlan moves the exercise plan to the given 1-based position inside its workout plan,
+// shifting the plans in between by one. Positions past the end move it to the end
+func (r *postgresEPRepository) MoveExercisePlan(ctx context.Context, id int, position int) (*ExercisePlan, error) {
+	var movedEP ExercisePlan
+	err := executeTransaction(ctx, r.db, func(txCtx context.Context, tx *sql.Tx) error {
+		var workoutPlanId, current int
+		err := tx.QueryRowContext(txCtx, "SELECT workout_plan_id, position FROM exercise_plans WHERE id = $1 FOR UPDATE", id).Scan(&workoutPlanId, &current)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return apperrors.ErrNotFound
+			}
+			return fmt.Errorf("failed to query exercise plan by id '%v' for move: %w", id, err)
+		}
 
-	result, err := executeNonQuery(ctx, r.db, deleteQuery, id) // Use executeNonQuery
-	if err != nil {
-		return fmt.Errorf("failed to delete exercise plan with id '%v': %w", id, err)
-	}
+		var count int
+		err = tx.QueryRowContext(txCtx, "SELECT COUNT(*) FROM exercise_plans WHERE workout_plan_id = $1", workoutPlanId).Scan(&count)
+		if err != nil {
+			return fmt.Errorf("failed to count exercise plans of workout plan id '%d': %w", workoutPlanId, err)
+		}
+
+		if position < 1 {
+			position = 1
+		}
+		if position > count {
+			position = count
+		}
+
+		if position < current {
+			_, err = tx.ExecContext(txCtx,
+				"UPDATE exercise_plans SET position = position + 1 WHERE workout_plan_id = $1 AND position >= $2 AND position < $3",
+				workoutPlanId, position, current)
+		} else if position > current {
+			_, err = tx.ExecContext(txCtx,
+				"UPDATE exercise_plans SET position = position - 1 WHERE workout_plan_id = $1 AND position > $2 AND position <= $3",
+				workoutPlanId, current, position)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to shift exercise plans of workout plan id '%d': %w", workoutPlanId, err)
+		}
+
+		query := `UPDATE exercise_plans SET position = $1 WHERE id = $2 RETURNING ` + exercisePlanColumns
+		if err := scanExercisePlan(tx.QueryRowContext(txCtx, query, position, id), &movedEP); err != nil {
+			return fmt.Errorf("failed to move and scan exercise plan with id '%v': %w", id, err)
+		}
+		return nil
+	})
 
-	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected after deleting exercise plan with id '%v': %w", id, err)
+		return nil, err
 	}
 
-	if rowsAffected == 0 {
-		return apperrors.ErrNotFound
-	}
+	return &movedEP, nil
+}
+
+// DeleteExercisePlanByID removes the exercise plan and closes the gap it leaves in the ordering
+func (r *postgresEPRepository) DeleteExercisePlanByID(ctx context.Context, id int) error {
+	return executeTransaction(ctx, r.db, func(txCtx context.Context, tx *sql.Tx) error {
+		var workoutPlanId, position int
+		deleteQuery := `DELETE FROM exercise_plans WHERE id = $1 RETURNING workout_plan_id, position`
+
+		err := tx.QueryRowContext(txCtx, deleteQuery, id).Scan(&workoutPlanId, &position)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return apperrors.ErrNotFound
+			}
+			return fmt.Errorf("failed to delete exercise plan with id '%v': %w", id, err)
+		}
+
+		_, err = tx.ExecContext(txCtx,
+			"UPDATE exercise_plans SET position = position - 1 WHERE workout_plan_id = $1 AND position > $2",
+			workoutPlanId, position)
+		if err != nil {
+			return fmt.Errorf("failed to shift exercise plans of workout plan id '%d': %w", workoutPlanId, err)
+		}
 
-	return nil
+		return nil
+	})
 }
+
 func (r *postgresEPRepository) ListExercisePlans(ctx context.Context, workoutId int) ([]ExercisePlan, error) {
-	query := `SELECT 
-		id,
-		exercise_id, 
-		workout_plan_id,
-		sets,
-		repetitions,
-		weights,
-		weight_unit
-	FROM exercise_plans WHERE workout_plan_id = $1`
+	query := `SELECT ` + exercisePlanColumns + `
+	FROM exercise_plans WHERE workout_plan_id = $1 ORDER BY position ASC, id ASC`
 
 	rows, err := executeQuery(ctx, r.db, query, workoutId)
 
@@ -252,14 +322,7 @@ func (r *postgresEPRepository) ListExercisePlans(ctx context.Context, workoutId
 	var epsList []ExercisePlan
 	for rows.Next() {
 		var ep ExercisePlan
-		if err := rows.Scan(
-			&ep.Id,
-			&ep.ExerciseId,
-			&ep.WorkoutPlanId,
-			&ep.Sets,
-			&ep.Repetitions,
-			&ep.Weights,
-			&ep.WeightUnit); err != nil {
+		if err := scanExercisePlan(rows, &ep); err != nil {
 			return nil, fmt.Errorf("failed to scan exercise plan row: %w", err)
 		}
 		epsList = append(epsList, ep)
@@ -271,3 +334,35 @@ func (r *postgresEPRepository) ListExercisePlans(ctx context.Context, workoutId
 
 	return epsList, nil
 }
+
+// ListExercisePlanDetails returns the exercise plans of several workout plans at once, by workout
+// plan and then in their order
+func (r *postgresEPRepository) ListExercisePlanDetails(ctx context.Context, workoutIds []int) ([]ExercisePlanDetail, error) {
+	query := `SELECT ep.id, ep.exercise_id, ep.workout_plan_id, ep.position, ep.sets, ep.repetitions, ep.weights, ep.weight_unit,
+		e.name, e.muscle_group
+	FROM exercise_plans ep
+	JOIN exercises e ON e.id = ep.exercise_id
+	WHERE ep.workout_plan_id = ANY($1::int[])
+	ORDER BY ep.workout_plan_id ASC, ep.position ASC, ep.id ASC`
+
+	rows, err := executeQuery(ctx, r.db, query, pq.Array(workoutIds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query exercise plans of workout plans: %w", err)
+	}
+	defer rows.Close()
+
+	var details []ExercisePlanDetail
+	for rows.Next() {
+		var detail ExercisePlanDetail
+		if err := scanExercisePlan(rows, &detail.ExercisePlan, &detail.ExerciseName, &detail.MuscleGroup); err != nil {
+			return nil, fmt.Errorf("failed to scan exercise plan row: %w", err)
+		}
+		details = append(details, detail)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating exercise plan rows: %w", err)
+	}
+
+	return details, nil
+}
@@ -38,16 +38,20 @@ func TestCreateExercisePlan(t *testing.T) {
 
 		mock.ExpectBegin()
 		mock.ExpectQuery(regexp.QuoteMeta(
-			`SELECT id FROM workout_plans WHERE id = $1`)).
+			`SELECT id FROM workout_plans WHERE id = $1 FOR UPDATE`)).
 			WithArgs(workoutPlanID).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(workoutPlanID))
+		mock.ExpectQuery(regexp.QuoteMeta(
+			`SELECT COUNT(*) FROM exercise_plans WHERE workout_plan_id = $1`)).
+			WithArgs(workoutPlanID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 
 		mock.ExpectQuery(regexp.QuoteMeta(
-			`INSERT INTO exercise_plans ( exercise_id, workout_plan_id, sets, repetitions, weights, weight_unit ) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, exercise_id, workout_plan_id, sets, repetitions, weights, weight_unit`,
+			`INSERT INTO exercise_plans ( exercise_id, workout_plan_id, position, sets, repetitions, weights, weight_unit ) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, exercise_id, workout_plan_id, position, sets, repetitions, weights, weight_unit`,
 		)).
-			WithArgs(newEP.ExerciseId, workoutPlanID, newEP.Sets, newEP.Repetitions, newEP.Weights, newEP.WeightUnit).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "exercise_id", "workout_plan_id", "sets", "repetitions", "weights", "weight_unit"}).
-				AddRow(expectedID, newEP.ExerciseId, workoutPlanID, newEP.Sets, newEP.Repetitions, newEP.Weights, newEP.WeightUnit))
+			WithArgs(newEP.ExerciseId, workoutPlanID, 1, newEP.Sets, newEP.Repetitions, newEP.Weights, newEP.WeightUnit).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "exercise_id", "workout_plan_id", "position", "sets", "repetitions", "weights", "weight_unit"}).
+				AddRow(expectedID, newEP.ExerciseId, workoutPlanID, 1, newEP.Sets, newEP.Repetitions, newEP.Weights, newEP.WeightUnit))
 		mock.ExpectCommit()
 
 		exercisePlan, err := epRepo.CreateExercisePlan(ctx, newEP, workoutPlanID)
@@ -76,14 +80,18 @@ func TestCreateExercisePlan(t *testing.T) {
 
 		mock.ExpectBegin()
 		mock.ExpectQuery(regexp.QuoteMeta(
-			`SELECT id FROM workout_plans WHERE id = $1`)).
+			`SELECT id FROM workout_plans WHERE id = $1 FOR UPDATE`)).
 			WithArgs(workoutPlanID).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(workoutPlanID))
+		mock.ExpectQuery(regexp.QuoteMeta(
+			`SELECT COUNT(*) FROM exercise_plans WHERE workout_plan_id = $1`)).
+			WithArgs(workoutPlanID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 
 		mock.ExpectQuery(regexp.QuoteMeta(
-			`INSERT INTO exercise_plans ( exercise_id, workout_plan_id, sets, repetitions, weights, weight_unit ) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, exercise_id, workout_plan_id, sets, repetitions, weights, weight_unit`,
+			`INSERT INTO exercise_plans ( exercise_id, workout_plan_id, position, sets, repetitions, weights, weight_unit ) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, exercise_id, workout_plan_id, position, sets, repetitions, weights, weight_unit`,
 		)).
-			WithArgs(newEP.ExerciseId, workoutPlanID, newEP.Sets, newEP.Repetitions, newEP.Weights, newEP.WeightUnit).
+			WithArgs(newEP.ExerciseId, workoutPlanID, 1, newEP.Sets, newEP.Repetitions, newEP.Weights, newEP.WeightUnit).
 			WillReturnError(dbError)
 		mock.ExpectRollback()
 
@@ -131,16 +139,20 @@ func TestCreateExercisePlan(t *testing.T) {
 		mock.ExpectBegin()
 
 		mock.ExpectQuery(regexp.QuoteMeta(
-			`SELECT id FROM workout_plans WHERE id = $1`)).
+			`SELECT id FROM workout_plans WHERE id = $1 FOR UPDATE`)).
 			WithArgs(workoutPlanID).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(workoutPlanID))
+		mock.ExpectQuery(regexp.QuoteMeta(
+			`SELECT COUNT(*) FROM exercise_plans WHERE workout_plan_id = $1`)).
+			WithArgs(workoutPlanID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 
 		mock.ExpectQuery(regexp.QuoteMeta(
-			`INSERT INTO exercise_plans ( exercise_id, workout_plan_id, sets, repetitions, weights, weight_unit ) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, exercise_id, workout_plan_id, sets, repetitions, weights, weight_unit`,
+			`INSERT INTO exercise_plans ( exercise_id, workout_plan_id, position, sets, repetitions, weights, weight_unit ) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, exercise_id, workout_plan_id, position, sets, repetitions, weights, weight_unit`,
 		)).
-			WithArgs(newEP.ExerciseId, workoutPlanID, newEP.Sets, newEP.Repetitions, newEP.Weights, newEP.WeightUnit).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "exercise_id", "workout_plan_id", "sets", "repetitions", "weights", "weight_unit"}).
-				AddRow(expectedID, newEP.ExerciseId, workoutPlanID, newEP.Sets, newEP.Repetitions, newEP.Weights, newEP.WeightUnit))
+			WithArgs(newEP.ExerciseId, workoutPlanID, 1, newEP.Sets, newEP.Repetitions, newEP.Weights, newEP.WeightUnit).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "exercise_id", "workout_plan_id", "position", "sets", "repetitions", "weights", "weight_unit"}).
+				AddRow(expectedID, newEP.ExerciseId, workoutPlanID, 1, newEP.Sets, newEP.Repetitions, newEP.Weights, newEP.WeightUnit))
 		mock.ExpectCommit().WillReturnError(commitErr)
 
 		exercisePlan, err := epRepo.CreateExercisePlan(ctx, newEP, workoutPlanID)
@@ -169,6 +181,7 @@ func TestGetExercisePlanById(t *testing.T) {
 			Id:            epID,
 			ExerciseId:    101,
 			WorkoutPlanId: 201,
+			Position:      2,
 			Sets:          4,
 			Repetitions:   8,
 			Weights:       70.0,
@@ -176,12 +189,12 @@ func TestGetExercisePlanById(t *testing.T) {
 		}
 
 		mock.ExpectPrepare(regexp.QuoteMeta(
-			`SELECT id, exercise_id, workout_plan_id, sets, repetitions, weights, weight_unit FROM exercise_plans WHERE id = $1`,
+			`SELECT id, exercise_id, workout_plan_id, position, sets, repetitions, weights, weight_unit FROM exercise_plans WHERE id = $1`,
 		)).
 			ExpectQuery().
 			WithArgs(epID).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "exercise_id", "workout_plan_id", "sets", "repetitions", "weights", "weight_unit"}).
-				AddRow(expectedEP.Id, expectedEP.ExerciseId, expectedEP.WorkoutPlanId, expectedEP.Sets, expectedEP.Repetitions, expectedEP.Weights, expectedEP.WeightUnit))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "exercise_id", "workout_plan_id", "position", "sets", "repetitions", "weights", "weight_unit"}).
+				AddRow(expectedEP.Id, expectedEP.ExerciseId, expectedEP.WorkoutPlanId, expectedEP.Position, expectedEP.Sets, expectedEP.Repetitions, expectedEP.Weights, expectedEP.WeightUnit))
 
 		exercisePlan, err := epRepo.GetExercisePlanById(ctx, epID)
 		assert.NoError(t, err)
@@ -194,7 +207,7 @@ func TestGetExercisePlanById(t *testing.T) {
 	t.Run("not found", func(t *testing.T) {
 		epID := 99
 		mock.ExpectPrepare(regexp.QuoteMeta(
-			`SELECT id, exercise_id, workout_plan_id, sets, repetitions, weights, weight_unit FROM exercise_plans WHERE id = $1`,
+			`SELECT id, exercise_id, workout_plan_id, position, sets, repetitions, weights, weight_unit FROM exercise_plans WHERE id = $1`,
 		)).
 			ExpectQuery().
 			WithArgs(epID).
@@ -213,7 +226,7 @@ func TestGetExercisePlanById(t *testing.T) {
 		dbError := errors.New("database connection lost")
 
 		mock.ExpectPrepare(regexp.QuoteMeta(
-			`SELECT id, exercise_id, workout_plan_id, sets, repetitions, weights, weight_unit FROM exercise_plans WHERE id = $1`,
+			`SELECT id, exercise_id, workout_plan_id, position, sets, repetitions, weights, weight_unit FROM exercise_plans WHERE id = $1`,
 		)).
 			WillReturnError(dbError)
 
@@ -272,11 +285,11 @@ func TestUpdateExercisePlan(t *testing.T) {
 						weights = COALESCE($3, weights),
 						weight_unit = COALESCE($4, weight_unit)
 						WHERE id = $5
-						RETURNING id, exercise_id, workout_plan_id, sets, repetitions, weights, weight_unit`,
+						RETURNING id, exercise_id, workout_plan_id, position, sets, repetitions, weights, weight_unit`,
 		)).
 			WithArgs(*updateData.Sets, *updateData.Repetitions, *updateData.Weights, *updateData.WeightUnit, updateData.Id).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "exercise_id", "workout_plan_id", "sets", "repetitions", "weights", "weight_unit"}).
-				AddRow(expectedEP.Id, expectedEP.ExerciseId, expectedEP.WorkoutPlanId, expectedEP.Sets, expectedEP.Repetitions, expectedEP.Weights, expectedEP.WeightUnit))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "exercise_id", "workout_plan_id", "position", "sets", "repetitions", "weights", "weight_unit"}).
+				AddRow(expectedEP.Id, expectedEP.ExerciseId, expectedEP.WorkoutPlanId, expectedEP.Position, expectedEP.Sets, expectedEP.Repetitions, expectedEP.Weights, expectedEP.WeightUnit))
 		mock.ExpectCommit()
 
 		exercisePlan, err := epRepo.UpdateExercisePlan(ctx, updateData)
@@ -322,11 +335,11 @@ func TestUpdateExercisePlan(t *testing.T) {
 						weights = COALESCE($3, weights),
 						weight_unit = COALESCE($4, weight_unit)
 						WHERE id = $5
-						RETURNING id, exercise_id, workout_plan_id, sets, repetitions, weights, weight_unit`,
+						RETURNING id, exercise_id, workout_plan_id, position, sets, repetitions, weights, weight_unit`,
 		)).
 			WithArgs(*updateData.Sets, nil, nil, nil, updateData.Id).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "exercise_id", "workout_plan_id", "sets", "repetitions", "weights", "weight_unit"}).
-				AddRow(expectedEP.Id, expectedEP.ExerciseId, expectedEP.WorkoutPlanId, expectedEP.Sets, expectedEP.Repetitions, expectedEP.Weights, expectedEP.WeightUnit))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "exercise_id", "workout_plan_id", "position", "sets", "repetitions", "weights", "weight_unit"}).
+				AddRow(expectedEP.Id, expectedEP.ExerciseId, expectedEP.WorkoutPlanId, expectedEP.Position, expectedEP.Sets, expectedEP.Repetitions, expectedEP.Weights, expectedEP.WeightUnit))
 		mock.ExpectCommit()
 
 		exercisePlan, err := epRepo.UpdateExercisePlan(ctx, updateData)
@@ -411,7 +424,7 @@ func TestUpdateExercisePlan(t *testing.T) {
 						weights = COALESCE($3, weights),
 						weight_unit = COALESCE($4, weight_unit)
 						WHERE id = $5
-						RETURNING id, exercise_id, workout_plan_id, sets, repetitions, weights, weight_unit`,
+						RETURNING id, exercise_id, workout_plan_id, position, sets, repetitions, weights, weight_unit`,
 		)).WithArgs(*updateData.Sets, *updateData.Repetitions, *updateData.Weights, *updateData.WeightUnit, updateData.Id).
 			WillReturnError(dbError)
 		mock.ExpectRollback()
@@ -472,10 +485,10 @@ func TestUpdateExercisePlan(t *testing.T) {
 						weights = COALESCE($3, weights),
 						weight_unit = COALESCE($4, weight_unit)
 						WHERE id = $5
-						RETURNING id, exercise_id, workout_plan_id, sets, repetitions, weights, weight_unit`,
+						RETURNING id, exercise_id, workout_plan_id, position, sets, repetitions, weights, weight_unit`,
 		)).WithArgs(*updateData.Sets, *updateData.Repetitions, *updateData.Weights, *updateData.WeightUnit, updateData.Id).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "exercise_id", "workout_plan_id", "sets", "repetitions", "weights", "weight_unit"}).
-				AddRow(epID, 101, 201, 3, 10, 50.0, repository.KG))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "exercise_id", "workout_plan_id", "position", "sets", "repetitions", "weights", "weight_unit"}).
+				AddRow(epID, 101, 201, 1, 3, 10, 50.0, repository.KG))
 
 		mock.ExpectCommit().WillReturnError(commitErr)
 
@@ -498,15 +511,19 @@ func TestDeleteExercisePlanByID(t *testing.T) {
 
 	epRepo := repository.NewEPRepository(db)
 	ctx := context.Background()
+	deleteQuery := `DELETE FROM exercise_plans WHERE id = $1 RETURNING workout_plan_id, position`
+	shiftQuery := `UPDATE exercise_plans SET position = position - 1 WHERE workout_plan_id = $1 AND position > $2`
 
-	t.Run("success", func(t *testing.T) {
+	t.Run("success closes the gap", func(t *testing.T) {
 		epID := 1
-		mock.ExpectPrepare(regexp.QuoteMeta(
-			`DELETE FROM exercise_plans WHERE id = $1`,
-		)).
-			ExpectExec().
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(deleteQuery)).
 			WithArgs(epID).
-			WillReturnResult(sqlmock.NewResult(0, 1)) // 1 row affected
+			WillReturnRows(sqlmock.NewRows([]string{"workout_plan_id", "position"}).AddRow(10, 2))
+		mock.ExpectExec(regexp.QuoteMeta(shiftQuery)).
+			WithArgs(10, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
 
 		err := epRepo.DeleteExercisePlanByID(ctx, epID)
 		assert.NoError(t, err)
@@ -516,12 +533,11 @@ func TestDeleteExercisePlanByID(t *testing.T) {
 
 	t.Run("not found", func(t *testing.T) {
 		epID := 99
-		mock.ExpectPrepare(regexp.QuoteMeta(
-			`DELETE FROM exercise_plans WHERE id = $1`,
-		)).
-			ExpectExec().
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(deleteQuery)).
 			WithArgs(epID).
-			WillReturnResult(sqlmock.NewResult(0, 0)) // 0 rows affected
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
 
 		err := epRepo.DeleteExercisePlanByID(ctx, epID)
 		assert.Error(t, err)
@@ -533,12 +549,11 @@ func TestDeleteExercisePlanByID(t *testing.T) {
 	t.Run("db error", func(t *testing.T) {
 		epID := 1
 		dbError := errors.New("database down")
-		mock.ExpectPrepare(regexp.QuoteMeta(
-			`DELETE FROM exercise_plans WHERE id = $1`,
-		)).
-			ExpectExec().
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(deleteQuery)).
 			WithArgs(epID).
 			WillReturnError(dbError)
+		mock.ExpectRollback()
 
 		err := epRepo.DeleteExercisePlanByID(ctx, epID)
 		assert.Error(t, err)
@@ -548,21 +563,179 @@ func TestDeleteExercisePlanByID(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
-	t.Run("rows affected error", func(t *testing.T) {
+	t.Run("shift error rolls back", func(t *testing.T) {
 		epID := 1
-		rowsAffectedError := errors.New("cannot get rows affected")
+		shiftError := errors.New("cannot shift positions")
 
-		mock.ExpectPrepare(regexp.QuoteMeta(
-			`DELETE FROM exercise_plans WHERE id = $1`,
-		)).
-			ExpectExec().
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(deleteQuery)).
 			WithArgs(epID).
-			WillReturnResult(sqlmock.NewErrorResult(rowsAffectedError))
+			WillReturnRows(sqlmock.NewRows([]string{"workout_plan_id", "position"}).AddRow(10, 1))
+		mock.ExpectExec(regexp.QuoteMeta(shiftQuery)).
+			WithArgs(10, 1).
+			WillReturnError(shiftError)
+		mock.ExpectRollback()
 
 		err := epRepo.DeleteExercisePlanByID(ctx, epID)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to get rows affected after deleting exercise plan with id")
-		assert.Contains(t, err.Error(), rowsAffectedError.Error())
+		assert.Contains(t, err.Error(), "failed to shift exercise plans of workout plan id")
+		assert.Contains(t, err.Error(), shiftError.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestInsertExercisePlan(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	epRepo := repository.NewEPRepository(db)
+	ctx := context.Background()
+	workoutPlanID := 10
+	newEP := repository.CreateEP{ExerciseId: 5, Sets: 3, Repetitions: 8, Weights: 40, WeightUnit: repository.KG}
+
+	expectLockAndCount := func(count int) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM workout_plans WHERE id = $1 FOR UPDATE`)).
+			WithArgs(workoutPlanID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(workoutPlanID))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM exercise_plans WHERE workout_plan_id = $1`)).
+			WithArgs(workoutPlanID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+	}
+
+	t.Run("insert in the middle shifts the rest", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLockAndCount(3)
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE exercise_plans SET position = position + 1 WHERE workout_plan_id = $1 AND position >= $2`)).
+			WithArgs(workoutPlanID, 2).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO exercise_plans`)).
+			WithArgs(newEP.ExerciseId, workoutPlanID, 2, newEP.Sets, newEP.Repetitions, newEP.Weights, newEP.WeightUnit).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "exercise_id", "workout_plan_id", "position", "sets", "repetitions", "weights", "weight_unit"}).
+				AddRow(7, newEP.ExerciseId, workoutPlanID, 2, newEP.Sets, newEP.Repetitions, newEP.Weights, newEP.WeightUnit))
+		mock.ExpectCommit()
+
+		exercisePlan, err := epRepo.InsertExercisePlan(ctx, newEP, workoutPlanID, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, exercisePlan.Position)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("position past the end appends", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectLockAndCount(3)
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO exercise_plans`)).
+			WithArgs(newEP.ExerciseId, workoutPlanID, 4, newEP.Sets, newEP.Repetitions, newEP.Weights, newEP.WeightUnit).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "exercise_id", "workout_plan_id", "position", "sets", "repetitions", "weights", "weight_unit"}).
+				AddRow(8, newEP.ExerciseId, workoutPlanID, 4, newEP.Sets, newEP.Repetitions, newEP.Weights, newEP.WeightUnit))
+		mock.ExpectCommit()
+
+		exercisePlan, err := epRepo.InsertExercisePlan(ctx, newEP, workoutPlanID, 42)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, exercisePlan.Position)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("workout plan not found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM workout_plans WHERE id = $1 FOR UPDATE`)).
+			WithArgs(workoutPlanID).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		exercisePlan, err := epRepo.InsertExercisePlan(ctx, newEP, workoutPlanID, 1)
+		assert.True(t, errors.Is(err, apperrors.ErrForeignKeyViolation))
+		assert.Nil(t, exercisePlan)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMoveExercisePlan(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	epRepo := repository.NewEPRepository(db)
+	ctx := context.Background()
+	epColumns := []string{"id", "exercise_id", "workout_plan_id", "position", "sets", "repetitions", "weights", "weight_unit"}
+
+	expectCurrent := func(epID, workoutPlanID, current, count int) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT workout_plan_id, position FROM exercise_plans WHERE id = $1 FOR UPDATE`)).
+			WithArgs(epID).
+			WillReturnRows(sqlmock.NewRows([]string{"workout_plan_id", "position"}).AddRow(workoutPlanID, current))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM exercise_plans WHERE workout_plan_id = $1`)).
+			WithArgs(workoutPlanID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+	}
+
+	t.Run("move up shifts the plans in between down", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectCurrent(3, 10, 4, 4)
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE exercise_plans SET position = position + 1 WHERE workout_plan_id = $1 AND position >= $2 AND position < $3`)).
+			WithArgs(10, 1, 4).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE exercise_plans SET position = $1 WHERE id = $2 RETURNING`)).
+			WithArgs(1, 3).
+			WillReturnRows(sqlmock.NewRows(epColumns).AddRow(3, 101, 10, 1, 3, 10, 50.0, "kg"))
+		mock.ExpectCommit()
+
+		exercisePlan, err := epRepo.MoveExercisePlan(ctx, 3, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, exercisePlan.Position)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("move down past the end lands last", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectCurrent(3, 10, 1, 4)
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE exercise_plans SET position = position - 1 WHERE workout_plan_id = $1 AND position > $2 AND position <= $3`)).
+			WithArgs(10, 1, 4).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE exercise_plans SET position = $1 WHERE id = $2 RETURNING`)).
+			WithArgs(4, 3).
+			WillReturnRows(sqlmock.NewRows(epColumns).AddRow(3, 101, 10, 4, 3, 10, 50.0, "kg"))
+		mock.ExpectCommit()
+
+		exercisePlan, err := epRepo.MoveExercisePlan(ctx, 3, 9)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, exercisePlan.Position)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("same position only rewrites itself", func(t *testing.T) {
+		mock.ExpectBegin()
+		expectCurrent(3, 10, 2, 4)
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE exercise_plans SET position = $1 WHERE id = $2 RETURNING`)).
+			WithArgs(2, 3).
+			WillReturnRows(sqlmock.NewRows(epColumns).AddRow(3, 101, 10, 2, 3, 10, 50.0, "kg"))
+		mock.ExpectCommit()
+
+		_, err := epRepo.MoveExercisePlan(ctx, 3, 2)
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT workout_plan_id, position FROM exercise_plans WHERE id = $1 FOR UPDATE`)).
+			WithArgs(99).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		exercisePlan, err := epRepo.MoveExercisePlan(ctx, 99, 1)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+		assert.Nil(t, exercisePlan)
 
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
@@ -582,16 +755,16 @@ func TestListExercisePlans(t *testing.T) {
 
 	t.Run("success with multiple exercise plans", func(t *testing.T) {
 		expectedEPs := []repository.ExercisePlan{
-			{Id: 1, ExerciseId: 101, WorkoutPlanId: workoutID, Sets: 3, Repetitions: 10, Weights: 50.0, WeightUnit: repository.KG},
-			{Id: 2, ExerciseId: 102, WorkoutPlanId: workoutID, Sets: 4, Repetitions: 8, Weights: 70.0, WeightUnit: repository.LBS},
+			{Id: 1, ExerciseId: 101, WorkoutPlanId: workoutID, Position: 1, Sets: 3, Repetitions: 10, Weights: 50.0, WeightUnit: repository.KG},
+			{Id: 2, ExerciseId: 102, WorkoutPlanId: workoutID, Position: 2, Sets: 4, Repetitions: 8, Weights: 70.0, WeightUnit: repository.LBS},
 		}
 
-		rows := sqlmock.NewRows([]string{"id", "exercise_id", "workout_plan_id", "sets", "repetitions", "weights", "weight_unit"}).
-			AddRow(expectedEPs[0].Id, expectedEPs[0].ExerciseId, expectedEPs[0].WorkoutPlanId, expectedEPs[0].Sets, expectedEPs[0].Repetitions, expectedEPs[0].Weights, expectedEPs[0].WeightUnit).
-			AddRow(expectedEPs[1].Id, expectedEPs[1].ExerciseId, expectedEPs[1].WorkoutPlanId, expectedEPs[1].Sets, expectedEPs[1].Repetitions, expectedEPs[1].Weights, expectedEPs[1].WeightUnit)
+		rows := sqlmock.NewRows([]string{"id", "exercise_id", "workout_plan_id", "position", "sets", "repetitions", "weights", "weight_unit"}).
+			AddRow(expectedEPs[0].Id, expectedEPs[0].ExerciseId, expectedEPs[0].WorkoutPlanId, expectedEPs[0].Position, expectedEPs[0].Sets, expectedEPs[0].Repetitions, expectedEPs[0].Weights, expectedEPs[0].WeightUnit).
+			AddRow(expectedEPs[1].Id, expectedEPs[1].ExerciseId, expectedEPs[1].WorkoutPlanId, expectedEPs[1].Position, expectedEPs[1].Sets, expectedEPs[1].Repetitions, expectedEPs[1].Weights, expectedEPs[1].WeightUnit)
 
 		mock.ExpectPrepare(regexp.QuoteMeta(
-			`SELECT id, exercise_id, workout_plan_id, sets, repetitions, weights, weight_unit FROM exercise_plans WHERE workout_plan_id = $1`,
+			`SELECT id, exercise_id, workout_plan_id, position, sets, repetitions, weights, weight_unit FROM exercise_plans WHERE workout_plan_id = $1 ORDER BY position ASC, id ASC`,
 		)).
 			ExpectQuery().
 			WithArgs(workoutID).
@@ -608,11 +781,11 @@ func TestListExercisePlans(t *testing.T) {
 
 	t.Run("success with no exercise plans", func(t *testing.T) {
 		mock.ExpectPrepare(regexp.QuoteMeta(
-			`SELECT id, exercise_id, workout_plan_id, sets, repetitions, weights, weight_unit FROM exercise_plans WHERE workout_plan_id = $1`,
+			`SELECT id, exercise_id, workout_plan_id, position, sets, repetitions, weights, weight_unit FROM exercise_plans WHERE workout_plan_id = $1 ORDER BY position ASC, id ASC`,
 		)).
 			ExpectQuery().
 			WithArgs(workoutID).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "exercise_id", "workout_plan_id", "sets", "repetitions", "weights", "weight_unit"})) // No rows
+			WillReturnRows(sqlmock.NewRows([]string{"id", "exercise_id", "workout_plan_id", "position", "sets", "repetitions", "weights", "weight_unit"})) // No rows
 
 		exercisePlans, err := epRepo.ListExercisePlans(ctx, workoutID)
 		assert.NoError(t, err)
@@ -625,7 +798,7 @@ func TestListExercisePlans(t *testing.T) {
 		dbError := errors.New("network error")
 
 		mock.ExpectPrepare(regexp.QuoteMeta(
-			`SELECT id, exercise_id, workout_plan_id, sets, repetitions, weights, weight_unit FROM exercise_plans WHERE workout_plan_id = $1`,
+			`SELECT id, exercise_id, workout_plan_id, position, sets, repetitions, weights, weight_unit FROM exercise_plans WHERE workout_plan_id = $1 ORDER BY position ASC, id ASC`,
 		)).
 			WillReturnError(dbError)
 
@@ -639,3 +812,51 @@ func TestListExercisePlans(t *testing.T) {
 	})
 
 }
+
+func TestListExercisePlanDetails(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	epRepo := repository.NewEPRepository(db)
+	ctx := context.Background()
+	detailQuery := `FROM exercise_plans ep
+	JOIN exercises e ON e.id = ep.exercise_id
+	WHERE ep.workout_plan_id = ANY($1::int[])`
+
+	t.Run("success", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "exercise_id", "workout_plan_id", "position", "sets", "repetitions", "weights", "weight_unit", "name", "muscle_group"}).
+			AddRow(1, 101, 10, 1, 3, 10, 50.0, repository.KG, "Bench Press", repository.Chest).
+			AddRow(5, 102, 12, 1, 4, 8, 70.0, repository.LBS, "Squat", repository.Legs)
+
+		mock.ExpectPrepare(regexp.QuoteMeta(detailQuery)).
+			ExpectQuery().
+			WithArgs("{10,12}").
+			WillReturnRows(rows)
+
+		details, err := epRepo.ListExercisePlanDetails(ctx, []int{10, 12})
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.ExercisePlanDetail{
+			{ExercisePlan: repository.ExercisePlan{Id: 1, ExerciseId: 101, WorkoutPlanId: 10, Position: 1, Sets: 3, Repetitions: 10, Weights: 50, WeightUnit: repository.KG}, ExerciseName: "Bench Press", MuscleGroup: repository.Chest},
+			{ExercisePlan: repository.ExercisePlan{Id: 5, ExerciseId: 102, WorkoutPlanId: 12, Position: 1, Sets: 4, Repetitions: 8, Weights: 70, WeightUnit: repository.LBS}, ExerciseName: "Squat", MuscleGroup: repository.Legs},
+		}, details)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		dbError := errors.New("network error")
+
+		mock.ExpectPrepare(regexp.QuoteMeta(detailQuery)).
+			ExpectQuery().
+			WillReturnError(dbError)
+
+		details, err := epRepo.ListExercisePlanDetails(ctx, []int{10})
+		assert.ErrorIs(t, err, dbError)
+		assert.Nil(t, details)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
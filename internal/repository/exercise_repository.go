@@ -5,23 +5,61 @@ import (
 	"database/sql"
 	"fmt"
 	"workout-tracker-api/internal/apperrors"
+
+	"github.com/lib/pq"
 )
 
 type MuscleGroup string
 
+type Equipment string
+
 type Exercise struct {
-	Id          int         `json:"id"`
+	Id          int           `json:"id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	MuscleGroup MuscleGroup   `json:"muscleGroup"`
+	Equipment   Equipment     `json:"equipment"`
+	OwnerId     sql.NullInt64 `json:"ownerId"` // null for the seeded global catalog
+	ArchivedAt  sql.NullTime  `json:"archivedAt"`
+}
+
+type CreateExer struct {
 	Name        string      `json:"name"`
 	Description string      `json:"description"`
 	MuscleGroup MuscleGroup `json:"muscleGroup"`
+	Equipment   Equipment   `json:"equipment"` // other when not set
+	OwnerId     *int        `json:"ownerId,omitempty"`
 }
 
-type CreateExer struct {
+type UpdateExer struct {
+	Id          int         `json:"id"`
 	Name        string      `json:"name"`
 	Description string      `json:"description"`
 	MuscleGroup MuscleGroup `json:"muscleGroup"`
+	Equipment   Equipment   `json:"equipment"`
 }
 
+// ExerciseFilter narrows ListExercises, empty fields do not filter
+type ExerciseFilter struct {
+	UserId       int
+	Name         string
+	MuscleGroups []MuscleGroup
+	Equipment    []Equipment
+	After        *ExerciseCursor
+	Limit        int
+}
+
+// ExerciseCursor is the sort key of the last exercise on a page.
+// Rank orders name prefix matches before substring and fuzzy matches.
+type ExerciseCursor struct {
+	Rank int    `json:"r"`
+	Name string `json:"n"`
+	Id   int    `json:"i"`
+}
+
+// FuzzyThreshold is the minimum pg_trgm word similarity for a fuzzy name match
+const FuzzyThreshold = 0.3
+
 const (
 	Chest     MuscleGroup = "chest"
 	Legs      MuscleGroup = "legs"
@@ -32,11 +70,25 @@ const (
 	Glutes    MuscleGroup = "glutes"
 )
 
+const (
+	Barbell    Equipment = "barbell"
+	Dumbbell   Equipment = "dumbbell"
+	Machine    Equipment = "machine"
+	Cable      Equipment = "cable"
+	Bodyweight Equipment = "bodyweight"
+	Kettlebell Equipment = "kettlebell"
+	Band       Equipment = "band"
+	Other      Equipment = "other"
+)
+
 type ExerciseRepository interface {
 	CreateExercise(ctx context.Context, data CreateExer) (*Exercise, error)
+	UpdateExercise(ctx context.Context, data UpdateExer) (*Exercise, error)
 	DeleteExercise(ctx context.Context, id int) error
+	ArchiveExercise(ctx context.Context, id int) error
+	IsExerciseReferenced(ctx context.Context, id int) (bool, error)
 	GetExerciseById(ctx context.Context, id int) (*Exercise, error)
-	ListExercises(ctx context.Context) ([]Exercise, error)
+	ListExercises(ctx context.Context, filter ExerciseFilter) ([]Exercise, *ExerciseCursor, error)
 }
 
 type postgresExerRepository struct {
@@ -49,17 +101,30 @@ func NewExerRepository(db *sql.DB) ExerciseRepository {
 	}
 }
 
+const exerciseColumns = "id, name, description, muscle_group, equipment, owner_id, archived_at"
+
+func scanExercise(row interface{ Scan(...any) error }, exercise *Exercise, extra ...any) error {
+	return row.Scan(append([]any{
+		&exercise.Id,
+		&exercise.Name,
+		&exercise.Description,
+		&exercise.MuscleGroup,
+		&exercise.Equipment,
+		&exercise.OwnerId,
+		&exercise.ArchivedAt}, extra...)...)
+}
+
 func (r *postgresExerRepository) GetExerciseById(ctx context.Context, id int) (*Exercise, error) {
 	var exercise Exercise
 
-	query := `SELECT id, name, description, muscle_group FROM exercises WHERE id = $1`
+	query := `SELECT ` + exerciseColumns + ` FROM exercises WHERE id = $1`
 
 	row, err := executeQueryRow(ctx, r.db, query, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query exercise by id '%v': %w", id, err)
 	}
 
-	err = row.Scan(&exercise.Id, &exercise.Name, &exercise.Description, &exercise.MuscleGroup)
+	err = scanExercise(row, &exercise)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -73,52 +138,100 @@ func (r *postgresExerRepository) GetExerciseById(ctx context.Context, id int) (*
 
 }
 
-func (r *postgresExerRepository) ListExercises(ctx context.Context) ([]Exercise, error) {
-	query := `SELECT id, name, description, muscle_group FROM exercises`
+// listExercisesQuery ranks name prefix matches first, then substring and fuzzy matches,
+// and pages with a keyset on (rank, sort_name, id) so the order stays stable between pages
+const listExercisesQuery = `SELECT ` + exerciseColumns + `, rank, sort_name FROM (
+		SELECT ` + exerciseColumns + `, lower(name) AS sort_name,
+			CASE WHEN $2 = '' OR strpos(lower(name), lower($2)) = 1 THEN 0
+				WHEN strpos(lower(name), lower($2)) > 1 THEN 1
+				ELSE 2 END AS rank
+		FROM exercises
+		WHERE archived_at IS NULL AND (owner_id IS NULL OR owner_id = $1)
+			AND (cardinality($3::text[]) = 0 OR muscle_group = ANY($3::text[]))
+			AND (cardinality($4::text[]) = 0 OR equipment = ANY($4::text[]))
+			AND ($2 = '' OR strpos(lower(name), lower($2)) > 0 OR word_similarity(lower($2), lower(name)) >= $5)
+	) matches
+	WHERE $6::int IS NULL OR (rank, sort_name, id) > ($6::int, $7::text, $8::int)
+	ORDER BY rank ASC, sort_name ASC, id ASC
+	LIMIT $9`
+
+// ListExercises returns a page of the global catalog merged with the user's own exercises, archived ones are left out.
+// The cursor of the next page is nil on the last page.
+func (r *postgresExerRepository) ListExercises(ctx context.Context, filter ExerciseFilter) ([]Exercise, *ExerciseCursor, error) {
+	muscleGroups := make([]string, 0, len(filter.MuscleGroups))
+	for _, mg := range filter.MuscleGroups {
+		muscleGroups = append(muscleGroups, string(mg))
+	}
+	equipment := make([]string, 0, len(filter.Equipment))
+	for _, eq := range filter.Equipment {
+		equipment = append(equipment, string(eq))
+	}
 
-	rows, err := executeQuery(ctx, r.db, query)
+	var afterRank sql.NullInt64
+	var afterName string
+	var afterId int
+	if filter.After != nil {
+		afterRank = sql.NullInt64{Int64: int64(filter.After.Rank), Valid: true}
+		afterName = filter.After.Name
+		afterId = filter.After.Id
+	}
 
+	// one extra row tells whether another page follows
+	rows, err := executeQuery(ctx, r.db, listExercisesQuery,
+		filter.UserId,
+		filter.Name,
+		pq.Array(muscleGroups),
+		pq.Array(equipment),
+		FuzzyThreshold,
+		afterRank,
+		afterName,
+		afterId,
+		filter.Limit+1)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query all exercises: %w", err)
+		return nil, nil, fmt.Errorf("failed to query all exercises: %w", err)
 	}
 	defer rows.Close()
 
 	var esList []Exercise
+	var keys []ExerciseCursor
 	for rows.Next() {
 		var exercise Exercise
-		if err := rows.Scan(
-			&exercise.Id,
-			&exercise.Name,
-			&exercise.Description,
-			&exercise.MuscleGroup); err != nil {
-			return nil, fmt.Errorf("failed to scan exercise row: %w", err)
+		var key ExerciseCursor
+		if err := scanExercise(rows, &exercise, &key.Rank, &key.Name); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan exercise row: %w", err)
 		}
+		key.Id = exercise.Id
 		esList = append(esList, exercise)
+		keys = append(keys, key)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating exercise rows: %w", err)
+		return nil, nil, fmt.Errorf("error iterating exercise rows: %w", err)
+	}
+
+	if len(esList) <= filter.Limit {
+		return esList, nil, nil
 	}
 
-	return esList, nil
+	next := keys[filter.Limit-1]
+
+	return esList[:filter.Limit], &next, nil
 }
 
 func (r *postgresExerRepository) CreateExercise(ctx context.Context, data CreateExer) (*Exercise, error) {
 	var newExercise Exercise
 
 	err := executeTransaction(ctx, r.db, func(txCtx context.Context, tx *sql.Tx) error {
-		insertQuery := `INSERT INTO exercises (name, description, muscle_group) VALUES ($1, $2, $3) RETURNING id, name, description, muscle_group`
+		insertQuery := `INSERT INTO exercises (name, description, muscle_group, equipment, owner_id)
+			VALUES ($1, $2, $3, COALESCE(NULLIF($4, ''), 'other'), $5) RETURNING ` + exerciseColumns
 
-		err := tx.QueryRowContext(txCtx, insertQuery,
+		err := scanExercise(tx.QueryRowContext(txCtx, insertQuery,
 			data.Name,
 			data.Description,
 			data.MuscleGroup,
-		).Scan(
-			&newExercise.Id,
-			&newExercise.Name,
-			&newExercise.Description,
-			&newExercise.MuscleGroup,
-		)
+			data.Equipment,
+			data.OwnerId,
+		), &newExercise)
 		if err != nil {
 			return fmt.Errorf("failed to insert and scan new exercise : %w", err)
 		}
@@ -131,6 +244,66 @@ func (r *postgresExerRepository) CreateExercise(ctx context.Context, data Create
 	return &newExercise, nil
 }
 
+func (r *postgresExerRepository) UpdateExercise(ctx context.Context, data UpdateExer) (*Exercise, error) {
+	var updatedExercise Exercise
+
+	query := `UPDATE exercises SET name = $1, description = $2, muscle_group = $3, equipment = $4 WHERE id = $5 RETURNING ` + exerciseColumns
+
+	row, err := executeQueryRow(ctx, r.db, query, data.Name, data.Description, data.MuscleGroup, data.Equipment, data.Id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute update query for exercise id '%v': %w", data.Id, err)
+	}
+
+	if err := scanExercise(row, &updatedExercise); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to update and scan exercise with id '%v': %w", data.Id, err)
+	}
+
+	return &updatedExercise, nil
+}
+
+// IsExerciseReferenced reports whether any exercise plan or template still points at the exercise
+func (r *postgresExerRepository) IsExerciseReferenced(ctx context.Context, id int) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM exercise_plans WHERE exercise_id = $1)
+		OR EXISTS (SELECT 1 FROM template_exercises WHERE exercise_id = $1)`
+
+	row, err := executeQueryRow(ctx, r.db, query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to query references of exercise id '%v': %w", id, err)
+	}
+
+	var referenced bool
+	if err := row.Scan(&referenced); err != nil {
+		return false, fmt.Errorf("failed to scan references of exercise id '%v': %w", id, err)
+	}
+
+	return referenced, nil
+}
+
+// ArchiveExercise hides the exercise from listings while keeping it for the plans that reference it.
+// Archiving an archived exercise succeeds and keeps the time it was first archived.
+func (r *postgresExerRepository) ArchiveExercise(ctx context.Context, id int) error {
+	query := `UPDATE exercises SET archived_at = COALESCE(archived_at, CURRENT_TIMESTAMP) WHERE id = $1`
+
+	result, err := executeNonQuery(ctx, r.db, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to archive exercise with id '%v': %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after archiving exercise with id '%v': %w", id, err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
 func (r *postgresExerRepository) DeleteExercise(ctx context.Context, id int) error {
 	deleteQuery := `DELETE FROM exercises WHERE id = $1`
 
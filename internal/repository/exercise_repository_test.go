@@ -14,6 +14,8 @@ import (
 	"workout-tracker-api/internal/repository"
 )
 
+var exerciseRows = []string{"id", "name", "description", "muscle_group", "equipment", "owner_id", "archived_at"}
+
 func TestCreateExercise(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -34,11 +36,11 @@ func TestCreateExercise(t *testing.T) {
 
 		mock.ExpectBegin()
 		mock.ExpectQuery(regexp.QuoteMeta(
-			`INSERT INTO exercises (name, description, muscle_group) VALUES ($1, $2, $3) RETURNING id, name, description, muscle_group`,
+			`INSERT INTO exercises (name, description, muscle_group, equipment, owner_id) VALUES ($1, $2, $3, COALESCE(NULLIF($4, ''), 'other'), $5) RETURNING id, name, description, muscle_group, equipment, owner_id, archived_at`,
 		)).
-			WithArgs(newExer.Name, newExer.Description, newExer.MuscleGroup).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "muscle_group"}).
-				AddRow(expectedID, newExer.Name, newExer.Description, newExer.MuscleGroup))
+			WithArgs(newExer.Name, newExer.Description, newExer.MuscleGroup, newExer.Equipment, nil).
+			WillReturnRows(sqlmock.NewRows(exerciseRows).
+				AddRow(expectedID, newExer.Name, newExer.Description, newExer.MuscleGroup, repository.Other, nil, nil))
 		mock.ExpectCommit()
 
 		exercise, err := exerciseRepo.CreateExercise(ctx, newExer)
@@ -62,9 +64,9 @@ func TestCreateExercise(t *testing.T) {
 
 		mock.ExpectBegin()
 		mock.ExpectQuery(regexp.QuoteMeta(
-			`INSERT INTO exercises (name, description, muscle_group) VALUES ($1, $2, $3) RETURNING id, name, description, muscle_group`,
+			`INSERT INTO exercises (name, description, muscle_group, equipment, owner_id) VALUES ($1, $2, $3, COALESCE(NULLIF($4, ''), 'other'), $5) RETURNING id, name, description, muscle_group, equipment, owner_id, archived_at`,
 		)).
-			WithArgs(newExer.Name, newExer.Description, newExer.MuscleGroup).
+			WithArgs(newExer.Name, newExer.Description, newExer.MuscleGroup, newExer.Equipment, nil).
 			WillReturnError(dbError)
 		mock.ExpectRollback() // Expect rollback on query error
 
@@ -77,6 +79,33 @@ func TestCreateExercise(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
+	t.Run("custom exercise keeps its owner", func(t *testing.T) {
+		ownerID := 5
+		newExer := repository.CreateExer{
+			Name:        "Sled Push",
+			Description: "Custom exercise",
+			MuscleGroup: repository.Legs,
+			Equipment:   repository.Machine,
+			OwnerId:     &ownerID,
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(
+			`INSERT INTO exercises (name, description, muscle_group, equipment, owner_id)`,
+		)).
+			WithArgs(newExer.Name, newExer.Description, newExer.MuscleGroup, newExer.Equipment, ownerID).
+			WillReturnRows(sqlmock.NewRows(exerciseRows).
+				AddRow(2, newExer.Name, newExer.Description, newExer.MuscleGroup, newExer.Equipment, ownerID, nil))
+		mock.ExpectCommit()
+
+		exercise, err := exerciseRepo.CreateExercise(ctx, newExer)
+		assert.NoError(t, err)
+		assert.True(t, exercise.OwnerId.Valid)
+		assert.Equal(t, int64(ownerID), exercise.OwnerId.Int64)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("db error during begin transaction", func(t *testing.T) {
 		newExer := repository.CreateExer{
 			Name:        "Push-up",
@@ -107,11 +136,11 @@ func TestCreateExercise(t *testing.T) {
 
 		mock.ExpectBegin()
 		mock.ExpectQuery(regexp.QuoteMeta(
-			`INSERT INTO exercises (name, description, muscle_group) VALUES ($1, $2, $3) RETURNING id, name, description, muscle_group`,
+			`INSERT INTO exercises (name, description, muscle_group, equipment, owner_id) VALUES ($1, $2, $3, COALESCE(NULLIF($4, ''), 'other'), $5) RETURNING id, name, description, muscle_group, equipment, owner_id, archived_at`,
 		)).
-			WithArgs(newExer.Name, newExer.Description, newExer.MuscleGroup).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "muscle_group"}).
-				AddRow(expectedID, newExer.Name, newExer.Description, newExer.MuscleGroup))
+			WithArgs(newExer.Name, newExer.Description, newExer.MuscleGroup, newExer.Equipment, nil).
+			WillReturnRows(sqlmock.NewRows(exerciseRows).
+				AddRow(expectedID, newExer.Name, newExer.Description, newExer.MuscleGroup, repository.Other, nil, nil))
 		mock.ExpectCommit().WillReturnError(commitErr)
 
 		exercise, err := exerciseRepo.CreateExercise(ctx, newExer)
@@ -141,15 +170,16 @@ func TestGetExerciseById(t *testing.T) {
 			Name:        "Squat",
 			Description: "Lower body exercise",
 			MuscleGroup: repository.Legs,
+			Equipment:   repository.Barbell,
 		}
 
 		mock.ExpectPrepare(regexp.QuoteMeta(
-			`SELECT id, name, description, muscle_group FROM exercises WHERE id = $1`,
+			`SELECT id, name, description, muscle_group, equipment, owner_id, archived_at FROM exercises WHERE id = $1`,
 		)).
 			ExpectQuery().
 			WithArgs(exerciseID).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "muscle_group"}).
-				AddRow(expectedExercise.Id, expectedExercise.Name, expectedExercise.Description, expectedExercise.MuscleGroup))
+			WillReturnRows(sqlmock.NewRows(exerciseRows).
+				AddRow(expectedExercise.Id, expectedExercise.Name, expectedExercise.Description, expectedExercise.MuscleGroup, expectedExercise.Equipment, nil, nil))
 
 		exercise, err := exerciseRepo.GetExerciseById(ctx, exerciseID)
 		assert.NoError(t, err)
@@ -162,7 +192,7 @@ func TestGetExerciseById(t *testing.T) {
 	t.Run("not found", func(t *testing.T) {
 		exerciseID := 99
 		mock.ExpectPrepare(regexp.QuoteMeta(
-			`SELECT id, name, description, muscle_group FROM exercises WHERE id = $1`,
+			`SELECT id, name, description, muscle_group, equipment, owner_id, archived_at FROM exercises WHERE id = $1`,
 		)).
 			ExpectQuery().
 			WithArgs(exerciseID).
@@ -181,7 +211,7 @@ func TestGetExerciseById(t *testing.T) {
 		dbError := errors.New("database connection lost")
 
 		mock.ExpectPrepare(regexp.QuoteMeta(
-			`SELECT id, name, description, muscle_group FROM exercises WHERE id = $1`,
+			`SELECT id, name, description, muscle_group, equipment, owner_id, archived_at FROM exercises WHERE id = $1`,
 		)).
 			WillReturnError(dbError)
 
@@ -204,42 +234,87 @@ func TestListExercises(t *testing.T) {
 
 	exerciseRepo := repository.NewExerRepository(db)
 	ctx := context.Background()
+	userID := 5
+	listQuery := `FROM exercises
+			WHERE archived_at IS NULL AND (owner_id IS NULL OR owner_id = $1)`
+	listRows := append(append([]string{}, exerciseRows...), "rank", "sort_name")
 
 	t.Run("success with multiple exercises", func(t *testing.T) {
 		expectedExercises := []repository.Exercise{
-			{Id: 1, Name: "Squat", Description: "Lower body", MuscleGroup: repository.Legs},
-			{Id: 2, Name: "Bench Press", Description: "Upper body", MuscleGroup: repository.Chest},
+			{Id: 2, Name: "Bench Press", Description: "Upper body", MuscleGroup: repository.Chest, Equipment: repository.Barbell},
+			{Id: 3, Name: "Sled Push", Description: "Custom", MuscleGroup: repository.Legs, Equipment: repository.Machine, OwnerId: sql.NullInt64{Int64: int64(userID), Valid: true}},
+			{Id: 1, Name: "Squat", Description: "Lower body", MuscleGroup: repository.Legs, Equipment: repository.Barbell},
 		}
 
-		rows := sqlmock.NewRows([]string{"id", "name", "description", "muscle_group"}).
-			AddRow(expectedExercises[0].Id, expectedExercises[0].Name, expectedExercises[0].Description, expectedExercises[0].MuscleGroup).
-			AddRow(expectedExercises[1].Id, expectedExercises[1].Name, expectedExercises[1].Description, expectedExercises[1].MuscleGroup)
+		rows := sqlmock.NewRows(listRows).
+			AddRow(2, "Bench Press", "Upper body", repository.Chest, repository.Barbell, nil, nil, 0, "bench press").
+			AddRow(3, "Sled Push", "Custom", repository.Legs, repository.Machine, userID, nil, 0, "sled push").
+			AddRow(1, "Squat", "Lower body", repository.Legs, repository.Barbell, nil, nil, 0, "squat")
 
-		mock.ExpectPrepare(regexp.QuoteMeta(
-			`SELECT id, name, description, muscle_group FROM exercises`,
-		)).
+		mock.ExpectPrepare(regexp.QuoteMeta(listQuery)).
 			ExpectQuery().
+			WithArgs(userID, "", "{}", "{}", repository.FuzzyThreshold, nil, "", 0, 11).
 			WillReturnRows(rows)
 
-		exercises, err := exerciseRepo.ListExercises(ctx)
+		exercises, next, err := exerciseRepo.ListExercises(ctx, repository.ExerciseFilter{UserId: userID, Limit: 10})
 		assert.NoError(t, err)
-		assert.NotNil(t, exercises)
-		assert.Len(t, exercises, 2)
+		assert.Len(t, exercises, 3)
 		assert.Equal(t, expectedExercises, exercises)
+		assert.Nil(t, next)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("search and filters are passed on", func(t *testing.T) {
+		filter := repository.ExerciseFilter{
+			UserId:       userID,
+			Name:         "sq",
+			MuscleGroups: []repository.MuscleGroup{repository.Legs, repository.Glutes},
+			Equipment:    []repository.Equipment{repository.Barbell},
+			After:        &repository.ExerciseCursor{Rank: 0, Name: "bench press", Id: 2},
+			Limit:        10,
+		}
+
+		mock.ExpectPrepare(regexp.QuoteMeta(listQuery)).
+			ExpectQuery().
+			WithArgs(userID, "sq", "{\"legs\",\"glutes\"}", "{\"barbell\"}", repository.FuzzyThreshold, int64(0), "bench press", 2, 11).
+			WillReturnRows(sqlmock.NewRows(listRows).
+				AddRow(1, "Squat", "Lower body", repository.Legs, repository.Barbell, nil, nil, 0, "squat"))
+
+		exercises, next, err := exerciseRepo.ListExercises(ctx, filter)
+		assert.NoError(t, err)
+		assert.Len(t, exercises, 1)
+		assert.Nil(t, next)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("extra row returns the cursor of the last exercise", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(listQuery)).
+			ExpectQuery().
+			WithArgs(userID, "pr", "{}", "{}", repository.FuzzyThreshold, nil, "", 0, 3).
+			WillReturnRows(sqlmock.NewRows(listRows).
+				AddRow(4, "Press Around", "", repository.Chest, repository.Cable, nil, nil, 0, "press around").
+				AddRow(5, "Leg Press", "", repository.Legs, repository.Machine, nil, nil, 1, "leg press").
+				AddRow(6, "Bench Press", "", repository.Chest, repository.Barbell, nil, nil, 1, "bench press"))
+
+		exercises, next, err := exerciseRepo.ListExercises(ctx, repository.ExerciseFilter{UserId: userID, Name: "pr", Limit: 2})
+		assert.NoError(t, err)
+		assert.Len(t, exercises, 2)
+		assert.Equal(t, &repository.ExerciseCursor{Rank: 1, Name: "leg press", Id: 5}, next)
 
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
 	t.Run("success with no exercises", func(t *testing.T) {
-		mock.ExpectPrepare(regexp.QuoteMeta(
-			`SELECT id, name, description, muscle_group FROM exercises`,
-		)).
+		mock.ExpectPrepare(regexp.QuoteMeta(listQuery)).
 			ExpectQuery().
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "muscle_group"})) // No rows
+			WillReturnRows(sqlmock.NewRows(listRows)) // No rows
 
-		exercises, err := exerciseRepo.ListExercises(ctx)
+		exercises, next, err := exerciseRepo.ListExercises(ctx, repository.ExerciseFilter{UserId: userID, Limit: 10})
 		assert.NoError(t, err)
 		assert.Empty(t, exercises)
+		assert.Nil(t, next)
 
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
@@ -247,21 +322,19 @@ func TestListExercises(t *testing.T) {
 	t.Run("db error", func(t *testing.T) {
 		dbError := errors.New("network error")
 
-		mock.ExpectPrepare(regexp.QuoteMeta(
-			`SELECT id, name, description, muscle_group FROM exercises`,
-		)).
+		mock.ExpectPrepare(regexp.QuoteMeta(listQuery)).
 			ExpectQuery().
 			WillReturnError(dbError)
 
-		exercises, err := exerciseRepo.ListExercises(ctx)
+		exercises, next, err := exerciseRepo.ListExercises(ctx, repository.ExerciseFilter{UserId: userID, Limit: 10})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to query all exercises")
 		assert.Contains(t, err.Error(), dbError.Error())
 		assert.Nil(t, exercises)
+		assert.Nil(t, next)
 
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
-
 }
 
 func TestDeleteExercise(t *testing.T) {
@@ -342,3 +415,116 @@ func TestDeleteExercise(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestUpdateExercise(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	exerciseRepo := repository.NewExerRepository(db)
+	ctx := context.Background()
+	updateQuery := `UPDATE exercises SET name = $1, description = $2, muscle_group = $3, equipment = $4 WHERE id = $5 RETURNING id, name, description, muscle_group, equipment, owner_id, archived_at`
+	data := repository.UpdateExer{Id: 3, Name: "Sled Drag", Description: "Backwards", MuscleGroup: repository.Legs, Equipment: repository.Machine}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(updateQuery)).
+			ExpectQuery().
+			WithArgs(data.Name, data.Description, data.MuscleGroup, data.Equipment, data.Id).
+			WillReturnRows(sqlmock.NewRows(exerciseRows).
+				AddRow(data.Id, data.Name, data.Description, data.MuscleGroup, data.Equipment, 5, nil))
+
+		exercise, err := exerciseRepo.UpdateExercise(ctx, data)
+		assert.NoError(t, err)
+		assert.Equal(t, "Sled Drag", exercise.Name)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(updateQuery)).
+			ExpectQuery().
+			WithArgs(data.Name, data.Description, data.MuscleGroup, data.Equipment, data.Id).
+			WillReturnError(sql.ErrNoRows)
+
+		exercise, err := exerciseRepo.UpdateExercise(ctx, data)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+		assert.Nil(t, exercise)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestIsExerciseReferenced(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	exerciseRepo := repository.NewExerRepository(db)
+	ctx := context.Background()
+	query := `SELECT EXISTS (SELECT 1 FROM exercise_plans WHERE exercise_id = $1)
+		OR EXISTS (SELECT 1 FROM template_exercises WHERE exercise_id = $1)`
+
+	t.Run("referenced", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		referenced, err := exerciseRepo.IsExerciseReferenced(ctx, 3)
+		assert.NoError(t, err)
+		assert.True(t, referenced)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(3).
+			WillReturnError(errors.New("db down"))
+
+		referenced, err := exerciseRepo.IsExerciseReferenced(ctx, 3)
+		assert.Error(t, err)
+		assert.False(t, referenced)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestArchiveExercise(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	exerciseRepo := repository.NewExerRepository(db)
+	ctx := context.Background()
+	query := `UPDATE exercises SET archived_at = COALESCE(archived_at, CURRENT_TIMESTAMP) WHERE id = $1`
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, exerciseRepo.ArchiveExercise(ctx, 3))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// an archived exercise is still matched, so archiving it again succeeds
+	t.Run("missing", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(3).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := exerciseRepo.ArchiveExercise(ctx, 3)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
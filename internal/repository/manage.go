@@ -3,13 +3,69 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"workout-tracker-api/internal/apperrors"
+
+	"github.com/lib/pq"
 )
 
+type txContextKey struct{}
+
+// UnitOfWork lets services run several repository calls in one database transaction.
+// Repositories pick the transaction up from the context, so their signatures stay the same
+type UnitOfWork interface {
+	WithinTransaction(ctx context.Context, fn func(txCtx context.Context) error) error
+}
+
+type postgresUnitOfWork struct {
+	db *sql.DB
+}
+
+func NewUnitOfWork(db *sql.DB) UnitOfWork {
+	return &postgresUnitOfWork{
+		db: db,
+	}
+}
+
+func (u *postgresUnitOfWork) WithinTransaction(ctx context.Context, fn func(txCtx context.Context) error) error {
+	return executeTransaction(ctx, u.db, func(txCtx context.Context, tx *sql.Tx) error {
+		return fn(txCtx)
+	})
+}
+
+// preparer is the part of *sql.DB and *sql.Tx the helpers below need
+type preparer interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// conn returns the transaction in the context if there is one, otherwise the db
+func conn(ctx context.Context, db *sql.DB) preparer {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// mapDBError turns postgres constraint errors into application errors, keeping the original error in the chain
+func mapDBError(err error) error {
+	if err == nil || errors.Is(err, apperrors.ErrForeignKeyViolation) {
+		return err
+	}
+
+	var pqErr *pq.Error
+	// SQLSTATE 23503 is the code for foreign_key_violation
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+		return fmt.Errorf("%w: %w", apperrors.ErrForeignKeyViolation, err)
+	}
+
+	return err
+}
+
 func executeQueryRow(ctx context.Context, db *sql.DB, query string, args ...any) (*sql.Row, error) {
 
-	stmr, err := db.PrepareContext(ctx, query)
+	stmr, err := conn(ctx, db).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare query: %w", err)
 	}
@@ -20,7 +76,7 @@ func executeQueryRow(ctx context.Context, db *sql.DB, query string, args ...any)
 }
 
 func executeQuery(ctx context.Context, db *sql.DB, query string, args ...any) (*sql.Rows, error) {
-	stmr, err := db.PrepareContext(ctx, query)
+	stmr, err := conn(ctx, db).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare query: %w", err)
 	}
@@ -32,7 +88,7 @@ func executeQuery(ctx context.Context, db *sql.DB, query string, args ...any) (*
 
 // For `Exec` operations.
 func executeNonQuery(ctx context.Context, db *sql.DB, query string, args ...any) (sql.Result, error) {
-	stmr, err := db.PrepareContext(ctx, query)
+	stmr, err := conn(ctx, db).PrepareContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare query: %w", err)
 	}
@@ -41,14 +97,22 @@ func executeNonQuery(ctx context.Context, db *sql.DB, query string, args ...any)
 
 	result, err := stmr.ExecContext(ctx, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute query: %w", err)
+		return nil, fmt.Errorf("failed to execute query: %w", mapDBError(err))
 	}
 	return result, nil
 
 }
 
-// For handling transactions.
+// For handling transactions. When the context already carries a transaction from UnitOfWork,
+// txFunc joins it and the outer caller is responsible for commit or rollback.
 func executeTransaction(ctx context.Context, db *sql.DB, txFunc func(context.Context, *sql.Tx) error) error {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		if err := txFunc(ctx, tx); err != nil {
+			return fmt.Errorf("transaction function failed: %w", mapDBError(err))
+		}
+		return nil
+	}
+
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -64,9 +128,10 @@ func executeTransaction(ctx context.Context, db *sql.DB, txFunc func(context.Con
 			log.Printf("failed to rollback transaction: %v", err)
 		}
 	}()
-	err = txFunc(ctx, tx) // execute the function with the transaction
+	txCtx := context.WithValue(ctx, txContextKey{}, tx)
+	err = txFunc(txCtx, tx) // execute the function with the transaction
 	if err != nil {
-		return fmt.Errorf("transaction function failed: %w", err)
+		return fmt.Errorf("transaction function failed: %w", mapDBError(err))
 	}
 
 	if err = tx.Commit(); err != nil {
@@ -0,0 +1,103 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+)
+
+func TestUnitOfWork(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	uow := repository.NewUnitOfWork(db)
+	wpRepo := repository.NewWorkoutRepository(db)
+	epRepo := repository.NewEPRepository(db)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	wpColumns := []string{"id", "user_id", "status", "scheduled_date", "comment", "created_at", "updated_at"}
+	epColumns := []string{"id", "exercise_id", "workout_plan_id", "position", "sets", "repetitions", "weights", "weight_unit"}
+	data := repository.CreateEP{ExerciseId: 3, Sets: 3, Repetitions: 10, Weights: 50, WeightUnit: repository.KG}
+
+	t.Run("repositories share one transaction and commit once", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO workout_plans`)).
+			ExpectQuery().
+			WillReturnRows(sqlmock.NewRows(wpColumns).AddRow(1, 1, repository.PENDING, now, nil, now, now))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM workout_plans WHERE id = $1 FOR UPDATE`)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM exercise_plans WHERE workout_plan_id = $1`)).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO exercise_plans`)).
+			WithArgs(3, 1, 1, 3, 10, float32(50), repository.KG).
+			WillReturnRows(sqlmock.NewRows(epColumns).AddRow(10, 3, 1, 1, 3, 10, 50.0, "kg"))
+		mock.ExpectCommit()
+
+		err := uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+			wp, err := wpRepo.CreateWorkout(txCtx, repository.CreateWP{UserId: 1, ScheduledDate: now})
+			if err != nil {
+				return err
+			}
+			_, err = epRepo.CreateExercisePlan(txCtx, data, wp.Id)
+			return err
+		})
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("foreign key violation rolls back everything", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO workout_plans`)).
+			ExpectQuery().
+			WillReturnRows(sqlmock.NewRows(wpColumns).AddRow(2, 1, repository.PENDING, now, nil, now, now))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM workout_plans WHERE id = $1 FOR UPDATE`)).
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM exercise_plans WHERE workout_plan_id = $1`)).
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO exercise_plans`)).
+			WillReturnError(&pq.Error{Code: "23503", Message: "insert or update on table \"exercise_plans\" violates foreign key constraint"})
+		mock.ExpectRollback()
+
+		err := uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+			wp, err := wpRepo.CreateWorkout(txCtx, repository.CreateWP{UserId: 1, ScheduledDate: now})
+			if err != nil {
+				return err
+			}
+			_, err = epRepo.CreateExercisePlan(txCtx, data, wp.Id)
+			return err
+		})
+		assert.True(t, errors.Is(err, apperrors.ErrForeignKeyViolation))
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("other errors are not mapped", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		err := uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+			return &pq.Error{Code: "23505", Message: "duplicate key value violates unique constraint"}
+		})
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, apperrors.ErrForeignKeyViolation))
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+)
+
+type CreatePasswordResetToken struct {
+	UserId    int       `json:"userId"`
+	TokenHash string    `json:"tokenHash"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type PasswordResetRepository interface {
+	CreatePasswordResetToken(ctx context.Context, data CreatePasswordResetToken) error
+	ConsumePasswordResetToken(ctx context.Context, tokenHash string) (int, error)
+	InvalidateUserResetTokens(ctx context.Context, userId int) error
+}
+
+type postgresPasswordResetRepository struct {
+	db *sql.DB
+}
+
+func NewPasswordResetRepository(db *sql.DB) PasswordResetRepository {
+	return &postgresPasswordResetRepository{
+		db: db,
+	}
+}
+
+func (r *postgresPasswordResetRepository) CreatePasswordResetToken(ctx context.Context, data CreatePasswordResetToken) error {
+	query := `INSERT INTO password_reset_tokens (user_id, token_hash, expires_at) VALUES ($1, $2, $3)`
+
+	if _, err := executeNonQuery(ctx, r.db, query, data.UserId, data.TokenHash, data.ExpiresAt); err != nil {
+		return fmt.Errorf("failed to insert password reset token for user id '%v': %w", data.UserId, err)
+	}
+
+	return nil
+}
+
+// ConsumePasswordResetToken marks an unused, unexpired token as used and returns its user. It is a
+// single conditional update, so two requests with the same token cannot both succeed.
+func (r *postgresPasswordResetRepository) ConsumePasswordResetToken(ctx context.Context, tokenHash string) (int, error) {
+	query := `UPDATE password_reset_tokens SET used_at = CURRENT_TIMESTAMP
+	WHERE token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+	RETURNING user_id`
+
+	row, err := executeQueryRow(ctx, r.db, query, tokenHash)
+	if err != nil {
+		return 0, fmt.Errorf("failed to consume password reset token: %w", err)
+	}
+
+	var userId int
+	if err := row.Scan(&userId); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, apperrors.ErrNotFound
+		}
+		return 0, fmt.Errorf("failed to scan user id of password reset token: %w", err)
+	}
+
+	return userId, nil
+}
+
+// InvalidateUserResetTokens marks every outstanding token of the user as used
+func (r *postgresPasswordResetRepository) InvalidateUserResetTokens(ctx context.Context, userId int) error {
+	query := `UPDATE password_reset_tokens SET used_at = CURRENT_TIMESTAMP
+	WHERE user_id = $1 AND used_at IS NULL`
+
+	if _, err := executeNonQuery(ctx, r.db, query, userId); err != nil {
+		return fmt.Errorf("failed to invalidate password reset tokens of user id '%v': %w", userId, err)
+	}
+
+	return nil
+}
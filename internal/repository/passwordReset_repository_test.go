@@ -0,0 +1,111 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+)
+
+func TestCreatePasswordResetToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	resetRepo := repository.NewPasswordResetRepository(db)
+	ctx := context.Background()
+	expiresAt := time.Date(2025, 5, 2, 9, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(`INSERT INTO password_reset_tokens (user_id, token_hash, expires_at) VALUES ($1, $2, $3)`)
+	data := repository.CreatePasswordResetToken{UserId: 5, TokenHash: "hash", ExpiresAt: expiresAt}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectExec().
+			WithArgs(5, "hash", expiresAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := resetRepo.CreatePasswordResetToken(ctx, data)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		dbError := errors.New("insert failed")
+
+		mock.ExpectPrepare(query).
+			ExpectExec().
+			WithArgs(5, "hash", expiresAt).
+			WillReturnError(dbError)
+
+		err := resetRepo.CreatePasswordResetToken(ctx, data)
+		assert.ErrorIs(t, err, dbError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestConsumePasswordResetToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	resetRepo := repository.NewPasswordResetRepository(db)
+	ctx := context.Background()
+	query := regexp.QuoteMeta(`WHERE token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+	RETURNING user_id`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectQuery().
+			WithArgs("hash").
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(5))
+
+		userId, err := resetRepo.ConsumePasswordResetToken(ctx, "hash")
+		assert.NoError(t, err)
+		assert.Equal(t, 5, userId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("used, expired or unknown", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectQuery().
+			WithArgs("hash").
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+		userId, err := resetRepo.ConsumePasswordResetToken(ctx, "hash")
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Zero(t, userId)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestInvalidateUserResetTokens(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	resetRepo := repository.NewPasswordResetRepository(db)
+	ctx := context.Background()
+
+	mock.ExpectPrepare(regexp.QuoteMeta(`UPDATE password_reset_tokens SET used_at = CURRENT_TIMESTAMP
+	WHERE user_id = $1 AND used_at IS NULL`)).
+		ExpectExec().
+		WithArgs(5).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = resetRepo.InvalidateUserResetTokens(ctx, 5)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+)
+
+type PerformedSet struct {
+	Id             int             `json:"id"`
+	ExercisePlanId int             `json:"exercisePlanId"`
+	SetNumber      int             `json:"setNumber"`
+	Repetitions    int             `json:"repetitions"`
+	Weights        float32         `json:"weights"`
+	WeightUnit     WeightUnit      `json:"weightUnit"`
+	RPE            sql.NullFloat64 `json:"rpe"`
+	CompletedAt    time.Time       `json:"completedAt"`
+}
+
+type CreatePS struct {
+	SetNumber   *int       `json:"setNumber,omitempty"` // next set number when not set
+	Repetitions int        `json:"repetitions"`
+	Weights     float32    `json:"weights"`
+	WeightUnit  WeightUnit `json:"weightUnit"`
+	RPE         *float32   `json:"rpe,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"` // now when not set
+}
+
+type UpdatePS struct {
+	Id          int         `json:"id"`
+	Repetitions *int        `json:"repetitions,omitempty"`
+	Weights     *float32    `json:"weights,omitempty"`
+	WeightUnit  *WeightUnit `json:"weightUnit,omitempty"`
+	RPE         *float32    `json:"rpe,omitempty"` // replaced as is, nil clears it
+	CompletedAt *time.Time  `json:"completedAt,omitempty"`
+}
+
+type PerformedSetRepository interface {
+	CreatePerformedSet(ctx context.Context, data CreatePS, exercisePlanID int) (*PerformedSet, error)
+	GetPerformedSetById(ctx context.Context, id int) (*PerformedSet, error)
+	UpdatePerformedSet(ctx context.Context, data UpdatePS) (*PerformedSet, error)
+	DeletePerformedSetById(ctx context.Context, id int) error
+	ListPerformedSets(ctx context.Context, exercisePlanID int) ([]PerformedSet, error)
+}
+
+type postgresPSRepository struct {
+	db *sql.DB
+}
+
+func NewPSRepository(db *sql.DB) PerformedSetRepository {
+	return &postgresPSRepository{
+		db: db,
+	}
+}
+
+func (r *postgresPSRepository) CreatePerformedSet(ctx context.Context, data CreatePS, exercisePlanID int) (*PerformedSet, error) {
+	var newPerformedSet PerformedSet
+
+	err := executeTransaction(ctx, r.db, func(txCtx context.Context, tx *sql.Tx) error {
+		// 1. Verify that the exercise plan exists
+		var currentExercisePlanID int
+		err := tx.QueryRowContext(txCtx, "SELECT id FROM exercise_plans WHERE id = $1", exercisePlanID).Scan(&currentExercisePlanID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return apperrors.ErrForeignKeyViolation
+			}
+			return fmt.Errorf("failed to query exercise plan id '%d' for creating performed set: %w", exercisePlanID, err)
+		}
+
+		// 2. Insert the new performed set, numbering it after the last logged set when no number is given
+		insertQuery := `INSERT INTO performed_sets (
+			exercise_plan_id,
+			set_number,
+			repetitions,
+			weights,
+			weight_unit,
+			rpe,
+			completed_at
+			) VALUES (
+			$1,
+			COALESCE($2, (SELECT COALESCE(MAX(set_number), 0) + 1 FROM performed_sets WHERE exercise_plan_id = $1)),
+			$3, $4, $5, $6,
+			COALESCE($7, CURRENT_TIMESTAMP)
+			) RETURNING id,
+			exercise_plan_id,
+			set_number,
+			repetitions,
+			weights,
+			weight_unit,
+			rpe,
+			completed_at
+			`
+
+		err = tx.QueryRowContext(txCtx,
+			insertQuery,
+			exercisePlanID,
+			data.SetNumber,
+			data.Repetitions,
+			data.Weights,
+			data.WeightUnit,
+			data.RPE,
+			data.CompletedAt,
+		).Scan(
+			&newPerformedSet.Id,
+			&newPerformedSet.ExercisePlanId,
+			&newPerformedSet.SetNumber,
+			&newPerformedSet.Repetitions,
+			&newPerformedSet.Weights,
+			&newPerformedSet.WeightUnit,
+			&newPerformedSet.RPE,
+			&newPerformedSet.CompletedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert and scan new performed set: %w", err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &newPerformedSet, nil
+}
+
+func (r *postgresPSRepository) GetPerformedSetById(ctx context.Context, id int) (*PerformedSet, error) {
+	var performedSet PerformedSet
+
+	query := `SELECT
+		id,
+		exercise_plan_id,
+		set_number,
+		repetitions,
+		weights,
+		weight_unit,
+		rpe,
+		completed_at FROM performed_sets WHERE id = $1`
+
+	row, err := executeQueryRow(ctx, r.db, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query performed set by id '%v': %w", id, err)
+	}
+
+	err = row.Scan(
+		&performedSet.Id,
+		&performedSet.ExercisePlanId,
+		&performedSet.SetNumber,
+		&performedSet.Repetitions,
+		&performedSet.Weights,
+		&performedSet.WeightUnit,
+		&performedSet.RPE,
+		&performedSet.CompletedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("failed to scan returned performed set data by id '%v': %w", id, err)
+	}
+
+	return &performedSet, nil
+}
+
+func (r *postgresPSRepository) UpdatePerformedSet(ctx context.Context, data UpdatePS) (*PerformedSet, error) {
+	var updatedPS PerformedSet
+
+	query := `UPDATE performed_sets
+				SET repetitions = COALESCE($1, repetitions),
+					weights = COALESCE($2, weights),
+					weight_unit = COALESCE($3, weight_unit),
+					rpe = $4,
+					completed_at = COALESCE($5, completed_at)
+				WHERE id = $6
+				RETURNING id, exercise_plan_id, set_number, repetitions, weights, weight_unit, rpe, completed_at`
+
+	row, err := executeQueryRow(ctx, r.db,
+		query,
+		data.Repetitions,
+		data.Weights,
+		data.WeightUnit,
+		data.RPE,
+		data.CompletedAt,
+		data.Id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute update query for performed set id '%v': %w", data.Id, err)
+	}
+
+	err = row.Scan(
+		&updatedPS.Id,
+		&updatedPS.ExercisePlanId,
+		&updatedPS.SetNumber,
+		&updatedPS.Repetitions,
+		&updatedPS.Weights,
+		&updatedPS.WeightUnit,
+		&updatedPS.RPE,
+		&updatedPS.CompletedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to update and scan performed set with id '%v': %w", data.Id, err)
+	}
+
+	return &updatedPS, nil
+}
+
+func (r *postgresPSRepository) DeletePerformedSetById(ctx context.Context, id int) error {
+	deleteQuery := `DELETE FROM performed_sets WHERE id = $1`
+
+	result, err := executeNonQuery(ctx, r.db, deleteQuery, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete performed set with id '%v': %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after deleting performed set with id '%v': %w", id, err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *postgresPSRepository) ListPerformedSets(ctx context.Context, exercisePlanID int) ([]PerformedSet, error) {
+	query := `SELECT
+		id,
+		exercise_plan_id,
+		set_number,
+		repetitions,
+		weights,
+		weight_unit,
+		rpe,
+		completed_at
+	FROM performed_sets WHERE exercise_plan_id = $1 ORDER BY set_number ASC`
+
+	rows, err := executeQuery(ctx, r.db, query, exercisePlanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query performed sets for exercise plan id '%v': %w", exercisePlanID, err)
+	}
+	defer rows.Close()
+
+	var psList []PerformedSet
+	for rows.Next() {
+		var ps PerformedSet
+		if err := rows.Scan(
+			&ps.Id,
+			&ps.ExercisePlanId,
+			&ps.SetNumber,
+			&ps.Repetitions,
+			&ps.Weights,
+			&ps.WeightUnit,
+			&ps.RPE,
+			&ps.CompletedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan performed set row: %w", err)
+		}
+		psList = append(psList, ps)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating performed set rows: %w", err)
+	}
+
+	return psList, nil
+}
@@ -0,0 +1,316 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+)
+
+var performedSetColumns = []string{"id", "exercise_plan_id", "set_number", "repetitions", "weights", "weight_unit", "rpe", "completed_at"}
+
+func TestCreatePerformedSet(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	psRepo := repository.NewPSRepository(db)
+	ctx := context.Background()
+
+	exercisePlanID := 7
+	completedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		rpe := float32(8)
+		newPS := repository.CreatePS{
+			Repetitions: 10,
+			Weights:     60,
+			WeightUnit:  repository.KG,
+			RPE:         &rpe,
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(
+			`SELECT id FROM exercise_plans WHERE id = $1`)).
+			WithArgs(exercisePlanID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(exercisePlanID))
+
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO performed_sets (`)).
+			WithArgs(exercisePlanID, nil, newPS.Repetitions, newPS.Weights, newPS.WeightUnit, rpe, nil).
+			WillReturnRows(sqlmock.NewRows(performedSetColumns).
+				AddRow(1, exercisePlanID, 1, newPS.Repetitions, newPS.Weights, newPS.WeightUnit, 8.0, completedAt))
+		mock.ExpectCommit()
+
+		performedSet, err := psRepo.CreatePerformedSet(ctx, newPS, exercisePlanID)
+		assert.NoError(t, err)
+		assert.NotNil(t, performedSet)
+		assert.Equal(t, 1, performedSet.Id)
+		assert.Equal(t, exercisePlanID, performedSet.ExercisePlanId)
+		assert.Equal(t, 1, performedSet.SetNumber)
+		assert.Equal(t, newPS.Repetitions, performedSet.Repetitions)
+		assert.Equal(t, newPS.Weights, performedSet.Weights)
+		assert.Equal(t, sql.NullFloat64{Float64: 8, Valid: true}, performedSet.RPE)
+		assert.Equal(t, completedAt, performedSet.CompletedAt)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("exercise plan not found", func(t *testing.T) {
+		newPS := repository.CreatePS{
+			Repetitions: 10,
+			Weights:     60,
+			WeightUnit:  repository.KG,
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(
+			`SELECT id FROM exercise_plans WHERE id = $1`)).
+			WithArgs(exercisePlanID).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		performedSet, err := psRepo.CreatePerformedSet(ctx, newPS, exercisePlanID)
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, apperrors.ErrForeignKeyViolation))
+		assert.Nil(t, performedSet)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error during insert", func(t *testing.T) {
+		newPS := repository.CreatePS{
+			Repetitions: 10,
+			Weights:     60,
+			WeightUnit:  repository.KG,
+		}
+		dbError := errors.New("database connection lost")
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(
+			`SELECT id FROM exercise_plans WHERE id = $1`)).
+			WithArgs(exercisePlanID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(exercisePlanID))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO performed_sets (`)).
+			WillReturnError(dbError)
+		mock.ExpectRollback()
+
+		performedSet, err := psRepo.CreatePerformedSet(ctx, newPS, exercisePlanID)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to insert and scan new performed set")
+		assert.Contains(t, err.Error(), dbError.Error())
+		assert.Nil(t, performedSet)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetPerformedSetById(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	psRepo := repository.NewPSRepository(db)
+	ctx := context.Background()
+	query := `SELECT id, exercise_plan_id, set_number, repetitions, weights, weight_unit, rpe, completed_at FROM performed_sets WHERE id = $1`
+
+	t.Run("success", func(t *testing.T) {
+		completedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+		expectedPS := repository.PerformedSet{
+			Id:             3,
+			ExercisePlanId: 7,
+			SetNumber:      2,
+			Repetitions:    8,
+			Weights:        135,
+			WeightUnit:     repository.LBS,
+			RPE:            sql.NullFloat64{Valid: false},
+			CompletedAt:    completedAt,
+		}
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(expectedPS.Id).
+			WillReturnRows(sqlmock.NewRows(performedSetColumns).
+				AddRow(expectedPS.Id, expectedPS.ExercisePlanId, expectedPS.SetNumber, expectedPS.Repetitions, expectedPS.Weights, expectedPS.WeightUnit, nil, completedAt))
+
+		performedSet, err := psRepo.GetPerformedSetById(ctx, expectedPS.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedPS, *performedSet)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(99).
+			WillReturnError(sql.ErrNoRows)
+
+		performedSet, err := psRepo.GetPerformedSetById(ctx, 99)
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+		assert.Nil(t, performedSet)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdatePerformedSet(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	psRepo := repository.NewPSRepository(db)
+	ctx := context.Background()
+	query := `UPDATE performed_sets SET repetitions = COALESCE($1, repetitions), weights = COALESCE($2, weights), weight_unit = COALESCE($3, weight_unit), rpe = $4, completed_at = COALESCE($5, completed_at) WHERE id = $6 RETURNING id, exercise_plan_id, set_number, repetitions, weights, weight_unit, rpe, completed_at`
+
+	t.Run("success", func(t *testing.T) {
+		reps := 12
+		completedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+		data := repository.UpdatePS{Id: 3, Repetitions: &reps}
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(reps, nil, nil, nil, nil, data.Id).
+			WillReturnRows(sqlmock.NewRows(performedSetColumns).
+				AddRow(data.Id, 7, 1, reps, 60, repository.KG, nil, completedAt))
+
+		performedSet, err := psRepo.UpdatePerformedSet(ctx, data)
+		assert.NoError(t, err)
+		assert.Equal(t, reps, performedSet.Repetitions)
+		assert.Equal(t, data.Id, performedSet.Id)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("clears the rpe", func(t *testing.T) {
+		completedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+		data := repository.UpdatePS{Id: 3}
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(nil, nil, nil, nil, nil, data.Id).
+			WillReturnRows(sqlmock.NewRows(performedSetColumns).
+				AddRow(data.Id, 7, 1, 10, 60, repository.KG, nil, completedAt))
+
+		performedSet, err := psRepo.UpdatePerformedSet(ctx, data)
+		assert.NoError(t, err)
+		assert.False(t, performedSet.RPE.Valid)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		data := repository.UpdatePS{Id: 99}
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(nil, nil, nil, nil, nil, data.Id).
+			WillReturnError(sql.ErrNoRows)
+
+		performedSet, err := psRepo.UpdatePerformedSet(ctx, data)
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+		assert.Nil(t, performedSet)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeletePerformedSetById(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	psRepo := repository.NewPSRepository(db)
+	ctx := context.Background()
+	query := `DELETE FROM performed_sets WHERE id = $1`
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := psRepo.DeletePerformedSetById(ctx, 3)
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(99).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := psRepo.DeletePerformedSetById(ctx, 99)
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestListPerformedSets(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	psRepo := repository.NewPSRepository(db)
+	ctx := context.Background()
+	query := `SELECT id, exercise_plan_id, set_number, repetitions, weights, weight_unit, rpe, completed_at FROM performed_sets WHERE exercise_plan_id = $1 ORDER BY set_number ASC`
+
+	t.Run("success", func(t *testing.T) {
+		completedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows(performedSetColumns).
+				AddRow(1, 7, 1, 10, 60, repository.KG, 7.5, completedAt).
+				AddRow(2, 7, 2, 8, 60, repository.KG, nil, completedAt.Add(3*time.Minute)))
+
+		psList, err := psRepo.ListPerformedSets(ctx, 7)
+		assert.NoError(t, err)
+		assert.Len(t, psList, 2)
+		assert.Equal(t, 1, psList[0].SetNumber)
+		assert.Equal(t, sql.NullFloat64{Float64: 7.5, Valid: true}, psList[0].RPE)
+		assert.False(t, psList[1].RPE.Valid)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		dbError := errors.New("query failed")
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(7).
+			WillReturnError(dbError)
+
+		psList, err := psRepo.ListPerformedSets(ctx, 7)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), dbError.Error())
+		assert.Nil(t, psList)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type PRKind string
+
+const (
+	PR_WEIGHT        PRKind = "weight"
+	PR_REPS          PRKind = "reps"
+	PR_ESTIMATED_1RM PRKind = "estimated_1rm"
+	PR_VOLUME        PRKind = "volume"
+)
+
+// PersonalRecord is a record broken in a workout. Values are stored in kg, WeightKg is only
+// set for reps records, which are kept per weight.
+type PersonalRecord struct {
+	Id            int             `json:"id"`
+	UserId        int             `json:"userId"`
+	ExerciseId    int             `json:"exerciseId"`
+	WorkoutPlanId int             `json:"workoutPlanId"`
+	Kind          PRKind          `json:"kind"`
+	Value         float64         `json:"value"`
+	WeightKg      sql.NullFloat64 `json:"weightKg"`
+	PreviousValue sql.NullFloat64 `json:"previousValue"`
+	AchievedAt    time.Time       `json:"achievedAt"`
+}
+
+type CreatePR struct {
+	UserId        int      `json:"userId"`
+	ExerciseId    int      `json:"exerciseId"`
+	WorkoutPlanId int      `json:"workoutPlanId"`
+	Kind          PRKind   `json:"kind"`
+	Value         float64  `json:"value"`
+	WeightKg      *float64 `json:"weightKg,omitempty"`
+	PreviousValue *float64 `json:"previousValue,omitempty"`
+}
+
+type PersonalRecordRepository interface {
+	CreatePersonalRecords(ctx context.Context, data []CreatePR) ([]PersonalRecord, error)
+	ListPersonalRecords(ctx context.Context, userId int, exerciseId *int) ([]PersonalRecord, error)
+	ListBestRecords(ctx context.Context, userId int, exerciseIds []int) ([]PersonalRecord, error)
+}
+
+type postgresPRRepository struct {
+	db *sql.DB
+}
+
+func NewPRRepository(db *sql.DB) PersonalRecordRepository {
+	return &postgresPRRepository{
+		db: db,
+	}
+}
+
+const personalRecordColumns = `id,
+	user_id,
+	exercise_id,
+	workout_plan_id,
+	kind,
+	value,
+	weight_kg,
+	previous_value,
+	achieved_at`
+
+func scanPersonalRecord(row interface{ Scan(...any) error }, pr *PersonalRecord) error {
+	return row.Scan(
+		&pr.Id,
+		&pr.UserId,
+		&pr.ExerciseId,
+		&pr.WorkoutPlanId,
+		&pr.Kind,
+		&pr.Value,
+		&pr.WeightKg,
+		&pr.PreviousValue,
+		&pr.AchievedAt,
+	)
+}
+
+func (r *postgresPRRepository) CreatePersonalRecords(ctx context.Context, data []CreatePR) ([]PersonalRecord, error) {
+	var created []PersonalRecord
+
+	insertQuery := `INSERT INTO personal_records (
+		user_id,
+		exercise_id,
+		workout_plan_id,
+		kind,
+		value,
+		weight_kg,
+		previous_value
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + personalRecordColumns
+
+	err := executeTransaction(ctx, r.db, func(txCtx context.Context, tx *sql.Tx) error {
+		for _, pr := range data {
+			var record PersonalRecord
+			err := scanPersonalRecord(tx.QueryRowContext(txCtx,
+				insertQuery,
+				pr.UserId,
+				pr.ExerciseId,
+				pr.WorkoutPlanId,
+				pr.Kind,
+				pr.Value,
+				pr.WeightKg,
+				pr.PreviousValue,
+			), &record)
+			if err != nil {
+				return fmt.Errorf("failed to insert and scan personal record for exercise id '%v': %w", pr.ExerciseId, err)
+			}
+			created = append(created, record)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (r *postgresPRRepository) ListPersonalRecords(ctx context.Context, userId int, exerciseId *int) ([]PersonalRecord, error) {
+	query := `SELECT ` + personalRecordColumns + `
+	FROM personal_records
+	WHERE user_id = $1 AND ($2::int IS NULL OR exercise_id = $2)
+	ORDER BY achieved_at DESC, id DESC`
+
+	rows, err := executeQuery(ctx, r.db, query, userId, exerciseId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query personal records for user id '%v': %w", userId, err)
+	}
+	defer rows.Close()
+
+	return scanPersonalRecords(rows)
+}
+
+// ListBestRecords returns the current best of every record kind for the given exercises,
+// reps records once per weight.
+func (r *postgresPRRepository) ListBestRecords(ctx context.Context, userId int, exerciseIds []int) ([]PersonalRecord, error) {
+	query := `SELECT DISTINCT ON (exercise_id, kind, COALESCE(weight_kg, 0)) ` + personalRecordColumns + `
+	FROM personal_records
+	WHERE user_id = $1 AND exercise_id = ANY($2::int[])
+	ORDER BY exercise_id, kind, COALESCE(weight_kg, 0), value DESC`
+
+	rows, err := executeQuery(ctx, r.db, query, userId, pq.Array(exerciseIds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query best personal records for user id '%v': %w", userId, err)
+	}
+	defer rows.Close()
+
+	return scanPersonalRecords(rows)
+}
+
+func scanPersonalRecords(rows *sql.Rows) ([]PersonalRecord, error) {
+	var prList []PersonalRecord
+	for rows.Next() {
+		var pr PersonalRecord
+		if err := scanPersonalRecord(rows, &pr); err != nil {
+			return nil, fmt.Errorf("failed to scan personal record row: %w", err)
+		}
+		prList = append(prList, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating personal record rows: %w", err)
+	}
+
+	return prList, nil
+}
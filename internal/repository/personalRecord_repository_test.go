@@ -0,0 +1,153 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"workout-tracker-api/internal/repository"
+)
+
+var personalRecordColumns = []string{"id", "user_id", "exercise_id", "workout_plan_id", "kind", "value", "weight_kg", "previous_value", "achieved_at"}
+
+func TestCreatePersonalRecords(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	prRepo := repository.NewPRRepository(db)
+	ctx := context.Background()
+	achievedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	weightKg := 80.0
+	previous := 8.0
+
+	t.Run("success", func(t *testing.T) {
+		data := []repository.CreatePR{
+			{UserId: 5, ExerciseId: 2, WorkoutPlanId: 9, Kind: repository.PR_WEIGHT, Value: 100},
+			{UserId: 5, ExerciseId: 2, WorkoutPlanId: 9, Kind: repository.PR_REPS, Value: 10, WeightKg: &weightKg, PreviousValue: &previous},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO personal_records (`)).
+			WithArgs(5, 2, 9, repository.PR_WEIGHT, 100.0, nil, nil).
+			WillReturnRows(sqlmock.NewRows(personalRecordColumns).
+				AddRow(1, 5, 2, 9, repository.PR_WEIGHT, 100.0, nil, nil, achievedAt))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO personal_records (`)).
+			WithArgs(5, 2, 9, repository.PR_REPS, 10.0, weightKg, previous).
+			WillReturnRows(sqlmock.NewRows(personalRecordColumns).
+				AddRow(2, 5, 2, 9, repository.PR_REPS, 10.0, weightKg, previous, achievedAt))
+		mock.ExpectCommit()
+
+		records, err := prRepo.CreatePersonalRecords(ctx, data)
+		assert.NoError(t, err)
+		assert.Len(t, records, 2)
+		assert.Equal(t, repository.PR_WEIGHT, records[0].Kind)
+		assert.False(t, records[0].WeightKg.Valid)
+		assert.Equal(t, sql.NullFloat64{Float64: weightKg, Valid: true}, records[1].WeightKg)
+		assert.Equal(t, sql.NullFloat64{Float64: previous, Valid: true}, records[1].PreviousValue)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("insert error rolls back", func(t *testing.T) {
+		dbError := errors.New("insert failed")
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO personal_records (`)).
+			WithArgs(5, 2, 9, repository.PR_VOLUME, 2400.0, nil, nil).
+			WillReturnError(dbError)
+		mock.ExpectRollback()
+
+		records, err := prRepo.CreatePersonalRecords(ctx, []repository.CreatePR{
+			{UserId: 5, ExerciseId: 2, WorkoutPlanId: 9, Kind: repository.PR_VOLUME, Value: 2400},
+		})
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, dbError))
+		assert.Nil(t, records)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestListPersonalRecords(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	prRepo := repository.NewPRRepository(db)
+	ctx := context.Background()
+	query := `FROM personal_records WHERE user_id = $1 AND ($2::int IS NULL OR exercise_id = $2) ORDER BY achieved_at DESC, id DESC`
+	achievedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("success with exercise filter", func(t *testing.T) {
+		exerciseID := 2
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(5, exerciseID).
+			WillReturnRows(sqlmock.NewRows(personalRecordColumns).
+				AddRow(2, 5, 2, 9, repository.PR_ESTIMATED_1RM, 110.0, nil, 105.0, achievedAt).
+				AddRow(1, 5, 2, 8, repository.PR_ESTIMATED_1RM, 105.0, nil, nil, achievedAt.Add(-48*time.Hour)))
+
+		records, err := prRepo.ListPersonalRecords(ctx, 5, &exerciseID)
+		assert.NoError(t, err)
+		assert.Len(t, records, 2)
+		assert.Equal(t, 110.0, records[0].Value)
+		assert.Equal(t, sql.NullFloat64{Float64: 105, Valid: true}, records[0].PreviousValue)
+		assert.False(t, records[1].PreviousValue.Valid)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		dbError := errors.New("query failed")
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(5, nil).
+			WillReturnError(dbError)
+
+		records, err := prRepo.ListPersonalRecords(ctx, 5, nil)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), dbError.Error())
+		assert.Nil(t, records)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestListBestRecords(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	prRepo := repository.NewPRRepository(db)
+	ctx := context.Background()
+	achievedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectPrepare(regexp.QuoteMeta(`SELECT DISTINCT ON (exercise_id, kind, COALESCE(weight_kg, 0))`)).
+		ExpectQuery().
+		WithArgs(5, "{2,3}").
+		WillReturnRows(sqlmock.NewRows(personalRecordColumns).
+			AddRow(1, 5, 2, 8, repository.PR_WEIGHT, 100.0, nil, nil, achievedAt).
+			AddRow(3, 5, 3, 8, repository.PR_REPS, 12.0, 60.0, nil, achievedAt))
+
+	records, err := prRepo.ListBestRecords(ctx, 5, []int{2, 3})
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, sql.NullFloat64{Float64: 60, Valid: true}, records[1].WeightKg)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+)
+
+// RefreshToken is a stored refresh token. UsedAt is set once it has been exchanged for a new
+// token, RevokedAt when it was logged out or its family was revoked.
+type RefreshToken struct {
+	Id        int          `json:"id"`
+	UserId    int          `json:"userId"`
+	FamilyId  string       `json:"familyId"`
+	TokenHash string       `json:"tokenHash"`
+	ExpiresAt time.Time    `json:"expiresAt"`
+	UsedAt    sql.NullTime `json:"usedAt"`
+	RevokedAt sql.NullTime `json:"revokedAt"`
+	CreatedAt time.Time    `json:"createdAt"`
+}
+
+type CreateRefreshToken struct {
+	UserId    int       `json:"userId"`
+	FamilyId  string    `json:"familyId"`
+	TokenHash string    `json:"tokenHash"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type RefreshTokenRepository interface {
+	CreateRefreshToken(ctx context.Context, data CreateRefreshToken) (*RefreshToken, error)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	MarkRefreshTokenUsed(ctx context.Context, id int) (bool, error)
+	RevokeTokenFamily(ctx context.Context, familyId string) error
+	RevokeUserTokens(ctx context.Context, userId int) error
+}
+
+type postgresRefreshTokenRepository struct {
+	db *sql.DB
+}
+
+func NewRTRepository(db *sql.DB) RefreshTokenRepository {
+	return &postgresRefreshTokenRepository{
+		db: db,
+	}
+}
+
+const refreshTokenColumns = `id,
+	user_id,
+	family_id,
+	token_hash,
+	expires_at,
+	used_at,
+	revoked_at,
+	created_at`
+
+func scanRefreshToken(row interface{ Scan(...any) error }, rt *RefreshToken) error {
+	return row.Scan(
+		&rt.Id,
+		&rt.UserId,
+		&rt.FamilyId,
+		&rt.TokenHash,
+		&rt.ExpiresAt,
+		&rt.UsedAt,
+		&rt.RevokedAt,
+		&rt.CreatedAt,
+	)
+}
+
+func (r *postgresRefreshTokenRepository) CreateRefreshToken(ctx context.Context, data CreateRefreshToken) (*RefreshToken, error) {
+	query := `INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at)
+	VALUES ($1, $2, $3, $4)
+	RETURNING ` + refreshTokenColumns
+
+	row, err := executeQueryRow(ctx, r.db, query, data.UserId, data.FamilyId, data.TokenHash, data.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert refresh token: %w", err)
+	}
+
+	var rt RefreshToken
+	if err := scanRefreshToken(row, &rt); err != nil {
+		return nil, fmt.Errorf("failed to scan created refresh token: %w", mapDBError(err))
+	}
+
+	return &rt, nil
+}
+
+func (r *postgresRefreshTokenRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*RefreshToken, error) {
+	query := `SELECT ` + refreshTokenColumns + ` FROM refresh_tokens WHERE token_hash = $1`
+
+	row, err := executeQueryRow(ctx, r.db, query, tokenHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query for refresh token: %w", err)
+	}
+
+	var rt RefreshToken
+	if err := scanRefreshToken(row, &rt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to scan refresh token: %w", err)
+	}
+
+	return &rt, nil
+}
+
+// MarkRefreshTokenUsed sets used_at when the token is still unused and not revoked. It returns
+// false when another request got there first, which has to be treated as a reuse.
+func (r *postgresRefreshTokenRepository) MarkRefreshTokenUsed(ctx context.Context, id int) (bool, error) {
+	query := `UPDATE refresh_tokens SET used_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND used_at IS NULL AND revoked_at IS NULL`
+
+	result, err := executeNonQuery(ctx, r.db, query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark refresh token id '%v' as used: %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (r *postgresRefreshTokenRepository) RevokeTokenFamily(ctx context.Context, familyId string) error {
+	query := `UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP
+	WHERE family_id = $1 AND revoked_at IS NULL`
+
+	if _, err := executeNonQuery(ctx, r.db, query, familyId); err != nil {
+		return fmt.Errorf("failed to revoke refresh token family '%v': %w", familyId, err)
+	}
+
+	return nil
+}
+
+func (r *postgresRefreshTokenRepository) RevokeUserTokens(ctx context.Context, userId int) error {
+	query := `UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP
+	WHERE user_id = $1 AND revoked_at IS NULL`
+
+	if _, err := executeNonQuery(ctx, r.db, query, userId); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens of user id '%v': %w", userId, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+)
+
+var refreshTokenColumns = []string{"id", "user_id", "family_id", "token_hash", "expires_at", "used_at", "revoked_at", "created_at"}
+
+const familyId = "7d1c2f0e-5b8a-4e53-9d2f-3c6a1b0e9f42"
+
+func TestCreateRefreshToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rtRepo := repository.NewRTRepository(db)
+	ctx := context.Background()
+	expiresAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC)
+	query := `INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at)`
+	data := repository.CreateRefreshToken{UserId: 5, FamilyId: familyId, TokenHash: "hash", ExpiresAt: expiresAt}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(5, familyId, "hash", expiresAt).
+			WillReturnRows(sqlmock.NewRows(refreshTokenColumns).
+				AddRow(1, 5, familyId, "hash", expiresAt, nil, nil, createdAt))
+
+		rt, err := rtRepo.CreateRefreshToken(ctx, data)
+		assert.NoError(t, err)
+		assert.Equal(t, &repository.RefreshToken{
+			Id:        1,
+			UserId:    5,
+			FamilyId:  familyId,
+			TokenHash: "hash",
+			ExpiresAt: expiresAt,
+			CreatedAt: createdAt,
+		}, rt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		dbError := errors.New("insert failed")
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(5, familyId, "hash", expiresAt).
+			WillReturnError(dbError)
+
+		rt, err := rtRepo.CreateRefreshToken(ctx, data)
+		assert.ErrorIs(t, err, dbError)
+		assert.Nil(t, rt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetRefreshTokenByHash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rtRepo := repository.NewRTRepository(db)
+	ctx := context.Background()
+	query := `FROM refresh_tokens WHERE token_hash = $1`
+
+	t.Run("success", func(t *testing.T) {
+		expiresAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+		usedAt := time.Date(2025, 5, 3, 0, 0, 0, 0, time.UTC)
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs("hash").
+			WillReturnRows(sqlmock.NewRows(refreshTokenColumns).
+				AddRow(1, 5, familyId, "hash", expiresAt, usedAt, nil, usedAt))
+
+		rt, err := rtRepo.GetRefreshTokenByHash(ctx, "hash")
+		assert.NoError(t, err)
+		assert.Equal(t, sql.NullTime{Time: usedAt, Valid: true}, rt.UsedAt)
+		assert.False(t, rt.RevokedAt.Valid)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs("unknown").
+			WillReturnError(sql.ErrNoRows)
+
+		rt, err := rtRepo.GetRefreshTokenByHash(ctx, "unknown")
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Nil(t, rt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMarkRefreshTokenUsed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rtRepo := repository.NewRTRepository(db)
+	ctx := context.Background()
+	query := `WHERE id = $1 AND used_at IS NULL AND revoked_at IS NULL`
+
+	t.Run("marked", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		marked, err := rtRepo.MarkRefreshTokenUsed(ctx, 1)
+		assert.NoError(t, err)
+		assert.True(t, marked)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already used", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		marked, err := rtRepo.MarkRefreshTokenUsed(ctx, 1)
+		assert.NoError(t, err)
+		assert.False(t, marked)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRevokeTokenFamily(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rtRepo := repository.NewRTRepository(db)
+	ctx := context.Background()
+	query := `WHERE family_id = $1 AND revoked_at IS NULL`
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(familyId).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		err := rtRepo.RevokeTokenFamily(ctx, familyId)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		dbError := errors.New("update failed")
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(familyId).
+			WillReturnError(dbError)
+
+		err := rtRepo.RevokeTokenFamily(ctx, familyId)
+		assert.ErrorIs(t, err, dbError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRevokeUserTokens(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rtRepo := repository.NewRTRepository(db)
+	ctx := context.Background()
+
+	mock.ExpectPrepare(regexp.QuoteMeta(`WHERE user_id = $1 AND revoked_at IS NULL`)).
+		ExpectExec().
+		WithArgs(5).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	err = rtRepo.RevokeUserTokens(ctx, 5)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
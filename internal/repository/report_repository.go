@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type Bucket string
+
+const (
+	DAY   Bucket = "day"
+	WEEK  Bucket = "week"
+	MONTH Bucket = "month"
+)
+
+// VolumeFilter buckets start at midnight in TimeZone, an IANA name
+type VolumeFilter struct {
+	UserId   int
+	From     time.Time // inclusive
+	To       time.Time // exclusive
+	Bucket   Bucket
+	TimeZone string
+}
+
+// VolumeRow is one line of the volume aggregation. Rows come in three levels per period:
+// per exercise (ExerciseId set), per muscle group (only MuscleGroup set) and the period total.
+type VolumeRow struct {
+	Period       time.Time      `json:"period"`
+	ExerciseId   sql.NullInt64  `json:"exerciseId"`
+	ExerciseName sql.NullString `json:"exerciseName"`
+	MuscleGroup  sql.NullString `json:"muscleGroup"`
+	Sets         int            `json:"sets"`
+	Repetitions  int            `json:"repetitions"`
+	VolumeKg     float64        `json:"volumeKg"`
+}
+
+// RangeFilter selects the completed workouts of UserId scheduled in [From, To)
+type RangeFilter struct {
+	UserId int
+	From   time.Time // inclusive
+	To     time.Time // exclusive
+}
+
+// MuscleGroupRow is the training of one muscle group. Sets in the 'other' unit count for Sets and
+// Repetitions but not for VolumeKg.
+type MuscleGroupRow struct {
+	MuscleGroup string  `json:"muscleGroup"`
+	Sets        int     `json:"sets"`
+	Repetitions int     `json:"repetitions"`
+	VolumeKg    float64 `json:"volumeKg"`
+}
+
+// ConsistencyFilter selects the workout plans of UserId scheduled in [From, To) and groups them by
+// their calendar day in TimeZone
+type ConsistencyFilter struct {
+	UserId   int
+	From     time.Time // inclusive
+	To       time.Time // exclusive
+	TimeZone string
+}
+
+// WorkoutDayRow is how many workout plans of one status are scheduled on one day. Day is the
+// calendar day in the time zone of the filter, as midnight UTC.
+type WorkoutDayRow struct {
+	Day    time.Time `json:"day"`
+	Status WPStatus  `json:"status"`
+	Count  int       `json:"count"`
+}
+
+// StrengthFilter selects the sets of ExerciseId in the completed workouts of UserId scheduled in
+// [From, To)
+type StrengthFilter struct {
+	UserId     int
+	ExerciseId int
+	From       time.Time // inclusive
+	To         time.Time // exclusive
+}
+
+// StrengthSetRow is one set of the exercise in a completed workout. The logged performed sets are
+// used, an exercise plan without any gives one row with its planned repetitions and weight.
+type StrengthSetRow struct {
+	WorkoutPlanId int        `json:"workoutPlanId"`
+	ScheduledDate time.Time  `json:"scheduledDate"`
+	Repetitions   int        `json:"repetitions"`
+	Weights       float32    `json:"weights"`
+	WeightUnit    WeightUnit `json:"weightUnit"`
+}
+
+type ReportRepository interface {
+	VolumeByPeriod(ctx context.Context, filter VolumeFilter) ([]VolumeRow, error)
+	WorkoutStatusByDay(ctx context.Context, filter ConsistencyFilter) ([]WorkoutDayRow, error)
+	StrengthSets(ctx context.Context, filter StrengthFilter) ([]StrengthSetRow, error)
+	VolumeByMuscleGroup(ctx context.Context, filter RangeFilter) ([]MuscleGroupRow, error)
+}
+
+type postgresReportRepository struct {
+	db *sql.DB
+}
+
+func NewReportRepository(db *sql.DB) ReportRepository {
+	return &postgresReportRepository{
+		db: db,
+	}
+}
+
+// completedLiftsCTE lists the sets of the completed workouts of $1 scheduled in [$2, $3). Logged
+// performed sets are used, an exercise plan without any counts as done as planned. Weights are
+// converted to kg, weight_kg is null for sets in the 'other' unit.
+const completedLiftsCTE = `WITH completed_plans AS (
+		SELECT ep.id, ep.exercise_id, ep.sets, ep.repetitions, ep.weights, ep.weight_unit, wp.scheduled_date
+		FROM workout_plans wp
+		JOIN exercise_plans ep ON ep.workout_plan_id = wp.id
+		WHERE wp.user_id = $1 AND wp.status = 'completed' AND wp.scheduled_date >= $2 AND wp.scheduled_date < $3
+	),
+	lifts AS (
+		SELECT cp.exercise_id, cp.scheduled_date, 1 AS sets, ps.repetitions, ps.weights, ps.weight_unit
+		FROM completed_plans cp
+		JOIN performed_sets ps ON ps.exercise_plan_id = cp.id
+		UNION ALL
+		SELECT cp.exercise_id, cp.scheduled_date, cp.sets, cp.repetitions, cp.weights, cp.weight_unit
+		FROM completed_plans cp
+		WHERE NOT EXISTS (SELECT 1 FROM performed_sets ps WHERE ps.exercise_plan_id = cp.id)
+	),
+	lifts_kg AS (
+		SELECT exercise_id, scheduled_date, sets, repetitions,
+			CASE weight_unit WHEN 'lbs' THEN weights * 0.45359237 WHEN 'kg' THEN weights END AS weight_kg
+		FROM lifts
+	)`
+
+func (r *postgresReportRepository) VolumeByPeriod(ctx context.Context, filter VolumeFilter) ([]VolumeRow, error) {
+	// periods start in the time zone of the filter, sets in the 'other' unit are left out
+	query := completedLiftsCTE + `,
+	periods AS (
+		SELECT exercise_id, sets, repetitions, weight_kg,
+			date_trunc($4, scheduled_date AT TIME ZONE $5) AT TIME ZONE $5 AS period
+		FROM lifts_kg
+		WHERE weight_kg IS NOT NULL
+	)
+	SELECT l.period,
+		e.id,
+		e.name,
+		e.muscle_group,
+		SUM(l.sets) AS sets,
+		SUM(l.sets * l.repetitions) AS repetitions,
+		SUM(l.sets * l.repetitions * l.weight_kg) AS volume_kg
+	FROM periods l
+	JOIN exercises e ON e.id = l.exercise_id
+	GROUP BY GROUPING SETS ((l.period, e.id, e.name, e.muscle_group), (l.period, e.muscle_group), (l.period))
+	ORDER BY l.period, e.muscle_group NULLS FIRST, e.id NULLS FIRST`
+
+	rows, err := executeQuery(ctx, r.db, query, filter.UserId, filter.From, filter.To, filter.Bucket, filter.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query volume for user id '%v': %w", filter.UserId, err)
+	}
+	defer rows.Close()
+
+	var volumeRows []VolumeRow
+	for rows.Next() {
+		var row VolumeRow
+		if err := rows.Scan(
+			&row.Period,
+			&row.ExerciseId,
+			&row.ExerciseName,
+			&row.MuscleGroup,
+			&row.Sets,
+			&row.Repetitions,
+			&row.VolumeKg); err != nil {
+			return nil, fmt.Errorf("failed to scan volume row: %w", err)
+		}
+		volumeRows = append(volumeRows, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating volume rows: %w", err)
+	}
+
+	return volumeRows, nil
+}
+
+func (r *postgresReportRepository) WorkoutStatusByDay(ctx context.Context, filter ConsistencyFilter) ([]WorkoutDayRow, error) {
+	query := `SELECT (scheduled_date AT TIME ZONE $4)::date AS day, COALESCE(status, 'pending') AS status, COUNT(*)
+	FROM workout_plans
+	WHERE user_id = $1 AND scheduled_date >= $2 AND scheduled_date < $3
+	GROUP BY day, 2
+	ORDER BY day, 2`
+
+	rows, err := executeQuery(ctx, r.db, query, filter.UserId, filter.From, filter.To, filter.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workout status by day for user id '%v': %w", filter.UserId, err)
+	}
+	defer rows.Close()
+
+	var dayRows []WorkoutDayRow
+	for rows.Next() {
+		var row WorkoutDayRow
+		if err := rows.Scan(&row.Day, &row.Status, &row.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan workout day row: %w", err)
+		}
+		dayRows = append(dayRows, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating workout day rows: %w", err)
+	}
+
+	return dayRows, nil
+}
+
+func (r *postgresReportRepository) StrengthSets(ctx context.Context, filter StrengthFilter) ([]StrengthSetRow, error) {
+	query := `SELECT wp.id, wp.scheduled_date,
+		COALESCE(ps.repetitions, ep.repetitions),
+		COALESCE(ps.weights, ep.weights),
+		COALESCE(ps.weight_unit, ep.weight_unit)
+	FROM workout_plans wp
+	JOIN exercise_plans ep ON ep.workout_plan_id = wp.id
+	LEFT JOIN performed_sets ps ON ps.exercise_plan_id = ep.id
+	WHERE wp.user_id = $1 AND ep.exercise_id = $2 AND wp.status = 'completed'
+		AND wp.scheduled_date >= $3 AND wp.scheduled_date < $4
+	ORDER BY wp.scheduled_date, wp.id`
+
+	rows, err := executeQuery(ctx, r.db, query, filter.UserId, filter.ExerciseId, filter.From, filter.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sets of exercise id '%v' for user id '%v': %w", filter.ExerciseId, filter.UserId, err)
+	}
+	defer rows.Close()
+
+	var setRows []StrengthSetRow
+	for rows.Next() {
+		var row StrengthSetRow
+		if err := rows.Scan(
+			&row.WorkoutPlanId,
+			&row.ScheduledDate,
+			&row.Repetitions,
+			&row.Weights,
+			&row.WeightUnit); err != nil {
+			return nil, fmt.Errorf("failed to scan strength set row: %w", err)
+		}
+		setRows = append(setRows, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating strength set rows: %w", err)
+	}
+
+	return setRows, nil
+}
+
+func (r *postgresReportRepository) VolumeByMuscleGroup(ctx context.Context, filter RangeFilter) ([]MuscleGroupRow, error) {
+	query := completedLiftsCTE + `
+	SELECT e.muscle_group,
+		SUM(l.sets) AS sets,
+		SUM(l.sets * l.repetitions) AS repetitions,
+		COALESCE(SUM(l.sets * l.repetitions * l.weight_kg), 0) AS volume_kg
+	FROM lifts_kg l
+	JOIN exercises e ON e.id = l.exercise_id
+	GROUP BY e.muscle_group
+	ORDER BY e.muscle_group`
+
+	rows, err := executeQuery(ctx, r.db, query, filter.UserId, filter.From, filter.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query volume by muscle group for user id '%v': %w", filter.UserId, err)
+	}
+	defer rows.Close()
+
+	var groupRows []MuscleGroupRow
+	for rows.Next() {
+		var row MuscleGroupRow
+		if err := rows.Scan(&row.MuscleGroup, &row.Sets, &row.Repetitions, &row.VolumeKg); err != nil {
+			return nil, fmt.Errorf("failed to scan muscle group row: %w", err)
+		}
+		groupRows = append(groupRows, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating muscle group rows: %w", err)
+	}
+
+	return groupRows, nil
+}
@@ -0,0 +1,220 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"workout-tracker-api/internal/repository"
+)
+
+func TestVolumeByPeriod(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	reportRepo := repository.NewReportRepository(db)
+	ctx := context.Background()
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	filter := repository.VolumeFilter{UserId: 5, From: from, To: to, Bucket: repository.WEEK, TimeZone: "Europe/Berlin"}
+	query := `GROUP BY GROUPING SETS ((l.period, e.id, e.name, e.muscle_group), (l.period, e.muscle_group), (l.period))`
+	columns := []string{"period", "id", "name", "muscle_group", "sets", "repetitions", "volume_kg"}
+
+	t.Run("success", func(t *testing.T) {
+		week := time.Date(2025, 5, 5, 0, 0, 0, 0, time.UTC)
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(5, from, to, repository.WEEK, "Europe/Berlin").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(week, nil, nil, nil, 6, 40, 3200.0).
+				AddRow(week, nil, nil, "chest", 6, 40, 3200.0).
+				AddRow(week, 2, "Bench Press", "chest", 6, 40, 3200.0))
+
+		volumeRows, err := reportRepo.VolumeByPeriod(ctx, filter)
+		assert.NoError(t, err)
+		assert.Len(t, volumeRows, 3)
+		assert.False(t, volumeRows[0].MuscleGroup.Valid)
+		assert.False(t, volumeRows[1].ExerciseId.Valid)
+		assert.Equal(t, sql.NullString{String: "chest", Valid: true}, volumeRows[1].MuscleGroup)
+		assert.Equal(t, repository.VolumeRow{
+			Period:       week,
+			ExerciseId:   sql.NullInt64{Int64: 2, Valid: true},
+			ExerciseName: sql.NullString{String: "Bench Press", Valid: true},
+			MuscleGroup:  sql.NullString{String: "chest", Valid: true},
+			Sets:         6,
+			Repetitions:  40,
+			VolumeKg:     3200,
+		}, volumeRows[2])
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		dbError := errors.New("query failed")
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(5, from, to, repository.WEEK, "Europe/Berlin").
+			WillReturnError(dbError)
+
+		volumeRows, err := reportRepo.VolumeByPeriod(ctx, filter)
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, dbError))
+		assert.Nil(t, volumeRows)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestWorkoutStatusByDay(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	reportRepo := repository.NewReportRepository(db)
+	ctx := context.Background()
+	from := time.Date(2025, 5, 4, 16, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 5, 18, 16, 0, 0, 0, time.UTC)
+	filter := repository.ConsistencyFilter{UserId: 5, From: from, To: to, TimeZone: "Asia/Hong_Kong"}
+	query := `SELECT (scheduled_date AT TIME ZONE $4)::date AS day, COALESCE(status, 'pending') AS status, COUNT(*) FROM workout_plans`
+
+	t.Run("success", func(t *testing.T) {
+		day := time.Date(2025, 5, 6, 0, 0, 0, 0, time.UTC)
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(5, from, to, "Asia/Hong_Kong").
+			WillReturnRows(sqlmock.NewRows([]string{"day", "status", "count"}).
+				AddRow(day, "completed", 2).
+				AddRow(day, "missed", 1))
+
+		dayRows, err := reportRepo.WorkoutStatusByDay(ctx, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.WorkoutDayRow{
+			{Day: day, Status: repository.COMPLETED, Count: 2},
+			{Day: day, Status: repository.MISSED, Count: 1},
+		}, dayRows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		dbError := errors.New("query failed")
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(5, from, to, "Asia/Hong_Kong").
+			WillReturnError(dbError)
+
+		dayRows, err := reportRepo.WorkoutStatusByDay(ctx, filter)
+		assert.ErrorIs(t, err, dbError)
+		assert.Nil(t, dayRows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestStrengthSets(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	reportRepo := repository.NewReportRepository(db)
+	ctx := context.Background()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	filter := repository.StrengthFilter{UserId: 5, ExerciseId: 2, From: from, To: to}
+	query := `LEFT JOIN performed_sets ps ON ps.exercise_plan_id = ep.id`
+	columns := []string{"id", "scheduled_date", "repetitions", "weights", "weight_unit"}
+
+	t.Run("success", func(t *testing.T) {
+		day := time.Date(2025, 2, 3, 18, 0, 0, 0, time.UTC)
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(5, 2, from, to).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(10, day, 5, 100.0, "kg").
+				AddRow(10, day, 3, 225.0, "lbs"))
+
+		setRows, err := reportRepo.StrengthSets(ctx, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.StrengthSetRow{
+			{WorkoutPlanId: 10, ScheduledDate: day, Repetitions: 5, Weights: 100, WeightUnit: repository.KG},
+			{WorkoutPlanId: 10, ScheduledDate: day, Repetitions: 3, Weights: 225, WeightUnit: repository.LBS},
+		}, setRows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		dbError := errors.New("query failed")
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(5, 2, from, to).
+			WillReturnError(dbError)
+
+		setRows, err := reportRepo.StrengthSets(ctx, filter)
+		assert.ErrorIs(t, err, dbError)
+		assert.Nil(t, setRows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestVolumeByMuscleGroup(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	reportRepo := repository.NewReportRepository(db)
+	ctx := context.Background()
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	filter := repository.RangeFilter{UserId: 5, From: from, To: to}
+	query := `GROUP BY e.muscle_group ORDER BY e.muscle_group`
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(5, from, to).
+			WillReturnRows(sqlmock.NewRows([]string{"muscle_group", "sets", "repetitions", "volume_kg"}).
+				AddRow("back", 12, 120, 6000.0).
+				AddRow("core", 6, 90, 0.0))
+
+		groupRows, err := reportRepo.VolumeByMuscleGroup(ctx, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.MuscleGroupRow{
+			{MuscleGroup: "back", Sets: 12, Repetitions: 120, VolumeKg: 6000},
+			{MuscleGroup: "core", Sets: 6, Repetitions: 90},
+		}, groupRows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		dbError := errors.New("query failed")
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(5, from, to).
+			WillReturnError(dbError)
+
+		groupRows, err := reportRepo.VolumeByMuscleGroup(ctx, filter)
+		assert.ErrorIs(t, err, dbError)
+		assert.Nil(t, groupRows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,344 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+)
+
+type Frequency string
+
+const (
+	DAILY   Frequency = "daily"
+	WEEKLY  Frequency = "weekly"
+	MONTHLY Frequency = "monthly"
+)
+
+type WorkoutSchedule struct {
+	Id          int            `json:"id"`
+	UserId      int            `json:"userId"`
+	Frequency   Frequency      `json:"frequency"`
+	Interval    int            `json:"interval"`
+	Weekdays    sql.NullString `json:"weekdays"` // comma separated, e.g. "MO,WE,FR"
+	StartsAt    time.Time      `json:"startsAt"`
+	Until       sql.NullTime   `json:"until"`
+	Count       sql.NullInt64  `json:"count"`
+	CancelledAt sql.NullTime   `json:"cancelledAt"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+type CreateWS struct {
+	UserId    int        `json:"userId"`
+	Frequency Frequency  `json:"frequency"`
+	Interval  int        `json:"interval"`
+	Weekdays  *string    `json:"weekdays,omitempty"`
+	StartsAt  time.Time  `json:"startsAt"`
+	Until     *time.Time `json:"until,omitempty"`
+	Count     *int       `json:"count,omitempty"`
+}
+
+type UpdateWS struct {
+	Id       int        `json:"id"`
+	StartsAt *time.Time `json:"startsAt,omitempty"`
+	Until    *time.Time `json:"until,omitempty"`
+}
+
+type ScheduleRepository interface {
+	CreateSchedule(ctx context.Context, data CreateWS) (*WorkoutSchedule, error)
+	GetScheduleById(ctx context.Context, id int) (*WorkoutSchedule, error)
+	UpdateSchedule(ctx context.Context, data UpdateWS) (*WorkoutSchedule, error)
+	ListUserSchedules(ctx context.Context, userID int) ([]WorkoutSchedule, error)
+	AttachWorkout(ctx context.Context, scheduleID int, workoutID int) error
+	ListScheduleWorkouts(ctx context.Context, scheduleID int) ([]WorkoutPlan, error)
+	CancelSchedule(ctx context.Context, id int, from time.Time) error
+	SplitSchedule(ctx context.Context, id int, from time.Time, data CreateWS) (*WorkoutSchedule, error)
+}
+
+type postgresScheduleRepository struct {
+	db *sql.DB
+}
+
+func NewScheduleRepository(db *sql.DB) ScheduleRepository {
+	return &postgresScheduleRepository{
+		db: db,
+	}
+}
+
+const scheduleColumns = `id,
+	user_id,
+	frequency,
+	repeat_interval,
+	weekdays,
+	starts_at,
+	until_date,
+	occurrence_count,
+	cancelled_at,
+	created_at,
+	updated_at`
+
+func scanSchedule(row interface{ Scan(...any) error }, ws *WorkoutSchedule) error {
+	return row.Scan(
+		&ws.Id,
+		&ws.UserId,
+		&ws.Frequency,
+		&ws.Interval,
+		&ws.Weekdays,
+		&ws.StartsAt,
+		&ws.Until,
+		&ws.Count,
+		&ws.CancelledAt,
+		&ws.CreatedAt,
+		&ws.UpdatedAt)
+}
+
+const insertScheduleQuery = `INSERT INTO workout_schedules (
+	user_id,
+	frequency,
+	repeat_interval,
+	weekdays,
+	starts_at,
+	until_date,
+	occurrence_count) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING ` + scheduleColumns
+
+func (r *postgresScheduleRepository) CreateSchedule(ctx context.Context, data CreateWS) (*WorkoutSchedule, error) {
+	row, err := executeQueryRow(ctx, r.db,
+		insertScheduleQuery,
+		data.UserId,
+		data.Frequency,
+		data.Interval,
+		data.Weekdays,
+		data.StartsAt,
+		data.Until,
+		data.Count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute insert query for creating new workout schedule: %w", err)
+	}
+
+	var newWS WorkoutSchedule
+	if err := scanSchedule(row, &newWS); err != nil {
+		return nil, fmt.Errorf("failed to scan returned new workout schedule: %w", err)
+	}
+
+	return &newWS, nil
+}
+
+func (r *postgresScheduleRepository) GetScheduleById(ctx context.Context, id int) (*WorkoutSchedule, error) {
+	query := `SELECT ` + scheduleColumns + ` FROM workout_schedules WHERE id = $1`
+
+	row, err := executeQueryRow(ctx, r.db, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workout schedule by id '%v': %w", id, err)
+	}
+
+	var schedule WorkoutSchedule
+	if err := scanSchedule(row, &schedule); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("failed to scan returned workout schedule by id '%v': %w", id, err)
+	}
+
+	return &schedule, nil
+}
+
+func (r *postgresScheduleRepository) UpdateSchedule(ctx context.Context, data UpdateWS) (*WorkoutSchedule, error) {
+	query := `UPDATE workout_schedules
+			SET starts_at = COALESCE($1, starts_at),
+				until_date = COALESCE($2, until_date),
+				updated_at = CURRENT_TIMESTAMP
+			WHERE id = $3 RETURNING ` + scheduleColumns
+
+	row, err := executeQueryRow(ctx, r.db, query, data.StartsAt, data.Until, data.Id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute update query for workout schedule id '%v': %w", data.Id, err)
+	}
+
+	var updatedWS WorkoutSchedule
+	if err := scanSchedule(row, &updatedWS); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to update and scan workout schedule with id '%v': %w", data.Id, err)
+	}
+
+	return &updatedWS, nil
+}
+
+func (r *postgresScheduleRepository) ListUserSchedules(ctx context.Context, userID int) ([]WorkoutSchedule, error) {
+	query := `SELECT ` + scheduleColumns + ` FROM workout_schedules WHERE user_id = $1 ORDER BY starts_at ASC`
+
+	rows, err := executeQuery(ctx, r.db, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workout schedules for user id '%v': %w", userID, err)
+	}
+	defer rows.Close()
+
+	var wsList []WorkoutSchedule
+	for rows.Next() {
+		var ws WorkoutSchedule
+		if err := scanSchedule(rows, &ws); err != nil {
+			return nil, fmt.Errorf("failed to scan workout schedule row: %w", err)
+		}
+		wsList = append(wsList, ws)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating workout schedule rows: %w", err)
+	}
+
+	return wsList, nil
+}
+
+func (r *postgresScheduleRepository) AttachWorkout(ctx context.Context, scheduleID int, workoutID int) error {
+	query := `UPDATE workout_plans SET schedule_id = $1 WHERE id = $2`
+
+	result, err := executeNonQuery(ctx, r.db, query, scheduleID, workoutID)
+	if err != nil {
+		return fmt.Errorf("failed to attach workout plan id '%v' to schedule id '%v': %w", workoutID, scheduleID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after attaching workout plan id '%v': %w", workoutID, err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *postgresScheduleRepository) ListScheduleWorkouts(ctx context.Context, scheduleID int) ([]WorkoutPlan, error) {
+	query := `SELECT
+		id,
+		user_id,
+		status,
+		scheduled_date,
+		comment,
+		created_at,
+		updated_at
+	FROM workout_plans WHERE schedule_id = $1 ORDER BY scheduled_date ASC`
+
+	rows, err := executeQuery(ctx, r.db, query, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workout plans for schedule id '%v': %w", scheduleID, err)
+	}
+	defer rows.Close()
+
+	var wpList []WorkoutPlan
+	for rows.Next() {
+		var wp WorkoutPlan
+		if err := rows.Scan(
+			&wp.Id,
+			&wp.UserId,
+			&wp.Status,
+			&wp.ScheduledDate,
+			&wp.Comment,
+			&wp.CreatedAt,
+			&wp.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan workout plan row: %w", err)
+		}
+		wpList = append(wpList, wp)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating workout plan rows: %w", err)
+	}
+
+	return wpList, nil
+}
+
+// CancelSchedule removes the pending occurrences scheduled from the given time on and marks the
+// series as cancelled. Occurrences already completed or missed are kept as history.
+func (r *postgresScheduleRepository) CancelSchedule(ctx context.Context, id int, from time.Time) error {
+	return executeTransaction(ctx, r.db, func(txCtx context.Context, tx *sql.Tx) error {
+		result, err := tx.ExecContext(txCtx,
+			`UPDATE workout_schedules SET cancelled_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP WHERE id = $1`, id)
+		if err != nil {
+			return fmt.Errorf("failed to cancel workout schedule id '%v': %w", id, err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected after cancelling workout schedule id '%v': %w", id, err)
+		}
+
+		if rowsAffected == 0 {
+			return apperrors.ErrNotFound
+		}
+
+		pendingQuery := `SELECT id FROM workout_plans WHERE schedule_id = $1 AND status = $2 AND scheduled_date >= $3`
+
+		_, err = tx.ExecContext(txCtx,
+			`DELETE FROM exercise_plans WHERE workout_plan_id IN (`+pendingQuery+`)`, id, PENDING, from)
+		if err != nil {
+			return fmt.Errorf("failed to delete exercise plans of workout schedule id '%v': %w", id, err)
+		}
+
+		_, err = tx.ExecContext(txCtx,
+			`DELETE FROM workout_plans WHERE id IN (`+pendingQuery+`)`, id, PENDING, from)
+		if err != nil {
+			return fmt.Errorf("failed to delete pending workout plans of workout schedule id '%v': %w", id, err)
+		}
+
+		return nil
+	})
+}
+
+// SplitSchedule ends the series right before the given time and moves the occurrences scheduled
+// from then on to a new series, so they can be edited without touching the earlier ones.
+func (r *postgresScheduleRepository) SplitSchedule(ctx context.Context, id int, from time.Time, data CreateWS) (*WorkoutSchedule, error) {
+	var newWS WorkoutSchedule
+
+	err := executeTransaction(ctx, r.db, func(txCtx context.Context, tx *sql.Tx) error {
+		result, err := tx.ExecContext(txCtx,
+			`UPDATE workout_schedules
+			SET until_date = $1::timestamptz - INTERVAL '1 second',
+				occurrence_count = NULL,
+				updated_at = CURRENT_TIMESTAMP
+			WHERE id = $2`, from, id)
+		if err != nil {
+			return fmt.Errorf("failed to end workout schedule id '%v': %w", id, err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected after ending workout schedule id '%v': %w", id, err)
+		}
+
+		if rowsAffected == 0 {
+			return apperrors.ErrNotFound
+		}
+
+		err = scanSchedule(tx.QueryRowContext(txCtx,
+			insertScheduleQuery,
+			data.UserId,
+			data.Frequency,
+			data.Interval,
+			data.Weekdays,
+			data.StartsAt,
+			data.Until,
+			data.Count), &newWS)
+		if err != nil {
+			return fmt.Errorf("failed to insert and scan split workout schedule: %w", err)
+		}
+
+		_, err = tx.ExecContext(txCtx,
+			`UPDATE workout_plans SET schedule_id = $1 WHERE schedule_id = $2 AND scheduled_date >= $3`, newWS.Id, id, from)
+		if err != nil {
+			return fmt.Errorf("failed to move workout plans to split workout schedule: %w", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &newWS, nil
+}
@@ -0,0 +1,211 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+)
+
+var scheduleColumns = []string{"id", "user_id", "frequency", "repeat_interval", "weekdays", "starts_at", "until_date", "occurrence_count", "cancelled_at", "created_at", "updated_at"}
+
+func TestCreateSchedule(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	wsRepo := repository.NewScheduleRepository(db)
+	ctx := context.Background()
+
+	startsAt := time.Date(2025, 6, 2, 7, 0, 0, 0, time.UTC)
+	now := time.Now().Truncate(time.Second)
+	weekdays := "MO,WE,FR"
+	count := 24
+
+	t.Run("success", func(t *testing.T) {
+		data := repository.CreateWS{
+			UserId:    1,
+			Frequency: repository.WEEKLY,
+			Interval:  1,
+			Weekdays:  &weekdays,
+			StartsAt:  startsAt,
+			Count:     &count,
+		}
+
+		mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO workout_schedules ( user_id, frequency, repeat_interval, weekdays, starts_at, until_date, occurrence_count) VALUES ($1, $2, $3, $4, $5, $6, $7)`)).
+			ExpectQuery().
+			WithArgs(data.UserId, data.Frequency, data.Interval, weekdays, startsAt, nil, count).
+			WillReturnRows(sqlmock.NewRows(scheduleColumns).
+				AddRow(1, 1, repository.WEEKLY, 1, weekdays, startsAt, nil, count, nil, now, now))
+
+		schedule, err := wsRepo.CreateSchedule(ctx, data)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, schedule.Id)
+		assert.Equal(t, repository.WEEKLY, schedule.Frequency)
+		assert.Equal(t, sql.NullString{String: weekdays, Valid: true}, schedule.Weekdays)
+		assert.Equal(t, sql.NullInt64{Int64: int64(count), Valid: true}, schedule.Count)
+		assert.False(t, schedule.Until.Valid)
+		assert.False(t, schedule.CancelledAt.Valid)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetScheduleById(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	wsRepo := repository.NewScheduleRepository(db)
+	ctx := context.Background()
+	query := `SELECT id, user_id, frequency, repeat_interval, weekdays, starts_at, until_date, occurrence_count, cancelled_at, created_at, updated_at FROM workout_schedules WHERE id = $1`
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(99).
+			WillReturnError(sql.ErrNoRows)
+
+		schedule, err := wsRepo.GetScheduleById(ctx, 99)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+		assert.Nil(t, schedule)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAttachWorkout(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	wsRepo := repository.NewScheduleRepository(db)
+	ctx := context.Background()
+	query := `UPDATE workout_plans SET schedule_id = $1 WHERE id = $2`
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(1, 10).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := wsRepo.AttachWorkout(ctx, 1, 10)
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("workout plan not found", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(1, 99).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := wsRepo.AttachWorkout(ctx, 1, 99)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCancelSchedule(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	wsRepo := repository.NewScheduleRepository(db)
+	ctx := context.Background()
+	from := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE workout_schedules SET cancelled_at = CURRENT_TIMESTAMP`)).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM exercise_plans WHERE workout_plan_id IN (SELECT id FROM workout_plans WHERE schedule_id = $1 AND status = $2 AND scheduled_date >= $3)`)).
+			WithArgs(1, repository.PENDING, from).
+			WillReturnResult(sqlmock.NewResult(0, 6))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM workout_plans WHERE id IN (SELECT id FROM workout_plans WHERE schedule_id = $1 AND status = $2 AND scheduled_date >= $3)`)).
+			WithArgs(1, repository.PENDING, from).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		err := wsRepo.CancelSchedule(ctx, 1, from)
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("schedule not found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE workout_schedules SET cancelled_at = CURRENT_TIMESTAMP`)).
+			WithArgs(99).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := wsRepo.CancelSchedule(ctx, 99, from)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSplitSchedule(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	wsRepo := repository.NewScheduleRepository(db)
+	ctx := context.Background()
+	from := time.Date(2025, 7, 2, 7, 0, 0, 0, time.UTC)
+	now := time.Now().Truncate(time.Second)
+	count := 10
+
+	t.Run("success", func(t *testing.T) {
+		data := repository.CreateWS{
+			UserId:    1,
+			Frequency: repository.DAILY,
+			Interval:  2,
+			StartsAt:  from.Add(time.Hour),
+			Count:     &count,
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE workout_schedules SET until_date = $1::timestamptz - INTERVAL '1 second'`)).
+			WithArgs(from, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO workout_schedules`)).
+			WithArgs(data.UserId, data.Frequency, data.Interval, nil, data.StartsAt, nil, count).
+			WillReturnRows(sqlmock.NewRows(scheduleColumns).
+				AddRow(2, 1, repository.DAILY, 2, nil, data.StartsAt, nil, count, nil, now, now))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE workout_plans SET schedule_id = $1 WHERE schedule_id = $2 AND scheduled_date >= $3`)).
+			WithArgs(2, 1, from).
+			WillReturnResult(sqlmock.NewResult(0, 10))
+		mock.ExpectCommit()
+
+		schedule, err := wsRepo.SplitSchedule(ctx, 1, from, data)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, schedule.Id)
+		assert.Equal(t, data.StartsAt, schedule.StartsAt)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
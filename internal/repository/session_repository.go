@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+)
+
+// Session is one login of a user. Its id is also the family id of the refresh tokens issued for it.
+type Session struct {
+	Id         string         `json:"id"`
+	UserId     int            `json:"userId"`
+	UserAgent  string         `json:"userAgent"`
+	IPAddress  string         `json:"ipAddress"`
+	LastJTI    sql.NullString `json:"lastJti"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	LastSeenAt time.Time      `json:"lastSeenAt"`
+	RevokedAt  sql.NullTime   `json:"revokedAt"`
+}
+
+type CreateSession struct {
+	Id        string `json:"id"`
+	UserId    int    `json:"userId"`
+	UserAgent string `json:"userAgent"`
+	IPAddress string `json:"ipAddress"`
+	JTI       string `json:"jti"`
+}
+
+type SessionRepository interface {
+	CreateSession(ctx context.Context, data CreateSession) (*Session, error)
+	ListActiveSessions(ctx context.Context, userId int) ([]Session, error)
+	TouchSession(ctx context.Context, id string, jti string) error
+	RevokeSession(ctx context.Context, userId int, id string) error
+	RevokeUserSessions(ctx context.Context, userId int) ([]string, error)
+}
+
+type postgresSessionRepository struct {
+	db *sql.DB
+}
+
+func NewSessionRepository(db *sql.DB) SessionRepository {
+	return &postgresSessionRepository{
+		db: db,
+	}
+}
+
+const sessionColumns = `id,
+	user_id,
+	user_agent,
+	ip_address,
+	last_jti,
+	created_at,
+	last_seen_at,
+	revoked_at`
+
+func scanSession(row interface{ Scan(...any) error }, s *Session) error {
+	return row.Scan(
+		&s.Id,
+		&s.UserId,
+		&s.UserAgent,
+		&s.IPAddress,
+		&s.LastJTI,
+		&s.CreatedAt,
+		&s.LastSeenAt,
+		&s.RevokedAt,
+	)
+}
+
+func (r *postgresSessionRepository) CreateSession(ctx context.Context, data CreateSession) (*Session, error) {
+	query := `INSERT INTO sessions (id, user_id, user_agent, ip_address, last_jti)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING ` + sessionColumns
+
+	row, err := executeQueryRow(ctx, r.db, query, data.Id, data.UserId, data.UserAgent, data.IPAddress, data.JTI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert session: %w", err)
+	}
+
+	var session Session
+	if err := scanSession(row, &session); err != nil {
+		return nil, fmt.Errorf("failed to scan created session: %w", mapDBError(err))
+	}
+
+	return &session, nil
+}
+
+// ListActiveSessions returns the sessions that are not revoked and still hold a usable refresh
+// token, the most recently seen first
+func (r *postgresSessionRepository) ListActiveSessions(ctx context.Context, userId int) ([]Session, error) {
+	query := `SELECT ` + sessionColumns + `
+	FROM sessions s
+	WHERE s.user_id = $1 AND s.revoked_at IS NULL AND EXISTS (
+		SELECT 1 FROM refresh_tokens rt
+		WHERE rt.family_id = s.id AND rt.used_at IS NULL AND rt.revoked_at IS NULL AND rt.expires_at > CURRENT_TIMESTAMP
+	)
+	ORDER BY s.last_seen_at DESC`
+
+	rows, err := executeQuery(ctx, r.db, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sessions for user id '%v': %w", userId, err)
+	}
+	defer rows.Close()
+
+	var sessions []Session
+	for rows.Next() {
+		var session Session
+		if err := scanSession(rows, &session); err != nil {
+			return nil, fmt.Errorf("failed to scan session row: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating session rows: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// TouchSession records a new access token issued for the session
+func (r *postgresSessionRepository) TouchSession(ctx context.Context, id string, jti string) error {
+	query := `UPDATE sessions SET last_jti = $2, last_seen_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND revoked_at IS NULL`
+
+	result, err := executeNonQuery(ctx, r.db, query, id, jti)
+	if err != nil {
+		return fmt.Errorf("failed to touch session '%v': %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *postgresSessionRepository) RevokeSession(ctx context.Context, userId int, id string) error {
+	query := `UPDATE sessions SET revoked_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	result, err := executeNonQuery(ctx, r.db, query, id, userId)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session '%v': %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// RevokeUserSessions revokes every open session of the user and returns their ids
+func (r *postgresSessionRepository) RevokeUserSessions(ctx context.Context, userId int) ([]string, error) {
+	query := `UPDATE sessions SET revoked_at = CURRENT_TIMESTAMP
+	WHERE user_id = $1 AND revoked_at IS NULL
+	RETURNING id`
+
+	rows, err := executeQuery(ctx, r.db, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions of user id '%v': %w", userId, err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan revoked session id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating revoked session rows: %w", err)
+	}
+
+	return ids, nil
+}
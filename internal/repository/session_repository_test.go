@@ -0,0 +1,203 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+)
+
+var sessionColumns = []string{"id", "user_id", "user_agent", "ip_address", "last_jti", "created_at", "last_seen_at", "revoked_at"}
+
+const sessionId = "0b6c7a52-3f0e-4a7e-8f61-1d2c3b4a5e6f"
+
+func TestCreateSession(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sessionRepo := repository.NewSessionRepository(db)
+	ctx := context.Background()
+	now := time.Date(2025, 5, 2, 8, 0, 0, 0, time.UTC)
+	query := `INSERT INTO sessions (id, user_id, user_agent, ip_address, last_jti)`
+	data := repository.CreateSession{Id: sessionId, UserId: 5, UserAgent: "curl/8.0", IPAddress: "203.0.113.7", JTI: "jti-1"}
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(sessionId, 5, "curl/8.0", "203.0.113.7", "jti-1").
+			WillReturnRows(sqlmock.NewRows(sessionColumns).
+				AddRow(sessionId, 5, "curl/8.0", "203.0.113.7", "jti-1", now, now, nil))
+
+		session, err := sessionRepo.CreateSession(ctx, data)
+		assert.NoError(t, err)
+		assert.Equal(t, &repository.Session{
+			Id:         sessionId,
+			UserId:     5,
+			UserAgent:  "curl/8.0",
+			IPAddress:  "203.0.113.7",
+			LastJTI:    sql.NullString{String: "jti-1", Valid: true},
+			CreatedAt:  now,
+			LastSeenAt: now,
+		}, session)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		dbError := errors.New("insert failed")
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(sessionId, 5, "curl/8.0", "203.0.113.7", "jti-1").
+			WillReturnError(dbError)
+
+		session, err := sessionRepo.CreateSession(ctx, data)
+		assert.ErrorIs(t, err, dbError)
+		assert.Nil(t, session)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestListActiveSessions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sessionRepo := repository.NewSessionRepository(db)
+	ctx := context.Background()
+	query := `WHERE s.user_id = $1 AND s.revoked_at IS NULL AND EXISTS (`
+
+	t.Run("success", func(t *testing.T) {
+		now := time.Date(2025, 5, 2, 8, 0, 0, 0, time.UTC)
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(5).
+			WillReturnRows(sqlmock.NewRows(sessionColumns).
+				AddRow(sessionId, 5, "curl/8.0", "203.0.113.7", "jti-2", now, now.Add(time.Hour), nil).
+				AddRow("second", 5, "Safari", "198.51.100.2", nil, now, now, nil))
+
+		sessions, err := sessionRepo.ListActiveSessions(ctx, 5)
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 2)
+		assert.Equal(t, sessionId, sessions[0].Id)
+		assert.False(t, sessions[1].LastJTI.Valid)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		dbError := errors.New("query failed")
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(5).
+			WillReturnError(dbError)
+
+		sessions, err := sessionRepo.ListActiveSessions(ctx, 5)
+		assert.ErrorIs(t, err, dbError)
+		assert.Nil(t, sessions)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTouchSession(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sessionRepo := repository.NewSessionRepository(db)
+	ctx := context.Background()
+	query := `UPDATE sessions SET last_jti = $2, last_seen_at = CURRENT_TIMESTAMP`
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(sessionId, "jti-2").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := sessionRepo.TouchSession(ctx, sessionId, "jti-2")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("revoked session", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(sessionId, "jti-3").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := sessionRepo.TouchSession(ctx, sessionId, "jti-3")
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRevokeSession(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sessionRepo := repository.NewSessionRepository(db)
+	ctx := context.Background()
+	query := `WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(sessionId, 5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := sessionRepo.RevokeSession(ctx, 5, sessionId)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found or other user", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(sessionId, 6).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := sessionRepo.RevokeSession(ctx, 6, sessionId)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	sessionRepo := repository.NewSessionRepository(db)
+	ctx := context.Background()
+
+	mock.ExpectPrepare(regexp.QuoteMeta(`WHERE user_id = $1 AND revoked_at IS NULL
+	RETURNING id`)).
+		ExpectQuery().
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(sessionId).AddRow("second"))
+
+	ids, err := sessionRepo.RevokeUserSessions(ctx, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{sessionId, "second"}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+)
+
+type WorkoutTemplate struct {
+	Id          int            `json:"id"`
+	UserId      int            `json:"userId"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+type TemplateExercise struct {
+	Id          int        `json:"id"`
+	TemplateId  int        `json:"templateId"`
+	Position    int        `json:"position"`
+	ExerciseId  int        `json:"exerciseId"`
+	Sets        int        `json:"sets"`
+	Repetitions int        `json:"repetitions"`
+	Weights     float32    `json:"weights"`
+	WeightUnit  WeightUnit `json:"weightUnit"`
+}
+
+type CreateWT struct {
+	UserId      int        `json:"userId"`
+	Name        string     `json:"name"`
+	Description *string    `json:"description,omitempty"`
+	Exercises   []CreateEP `json:"exercises"`
+}
+
+// UpdateWT replaces the exercises of the template only when Exercises is not nil
+type UpdateWT struct {
+	Id          int        `json:"id"`
+	Name        *string    `json:"name,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	Exercises   []CreateEP `json:"exercises,omitempty"`
+}
+
+type TemplateRepository interface {
+	CreateTemplate(ctx context.Context, data CreateWT) (*WorkoutTemplate, error)
+	GetTemplateById(ctx context.Context, id int) (*WorkoutTemplate, error)
+	UpdateTemplate(ctx context.Context, data UpdateWT) (*WorkoutTemplate, error)
+	DeleteTemplateById(ctx context.Context, id int) error
+	ListUserTemplates(ctx context.Context, userID int) ([]WorkoutTemplate, error)
+	ListTemplateExercises(ctx context.Context, templateID int) ([]TemplateExercise, error)
+}
+
+type postgresTemplateRepository struct {
+	db *sql.DB
+}
+
+func NewTemplateRepository(db *sql.DB) TemplateRepository {
+	return &postgresTemplateRepository{
+		db: db,
+	}
+}
+
+const templateColumns = `id,
+	user_id,
+	name,
+	description,
+	created_at,
+	updated_at`
+
+func scanTemplate(row interface{ Scan(...any) error }, wt *WorkoutTemplate) error {
+	return row.Scan(
+		&wt.Id,
+		&wt.UserId,
+		&wt.Name,
+		&wt.Description,
+		&wt.CreatedAt,
+		&wt.UpdatedAt)
+}
+
+// insertTemplateExercises stores the exercises in the given order, starting at position 1
+func insertTemplateExercises(ctx context.Context, tx *sql.Tx, templateID int, exercises []CreateEP) error {
+	insertQuery := `INSERT INTO template_exercises (
+		template_id,
+		position,
+		exercise_id,
+		sets,
+		repetitions,
+		weights,
+		weight_unit) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	for i, ex := range exercises {
+		_, err := tx.ExecContext(ctx, insertQuery,
+			templateID,
+			i+1,
+			ex.ExerciseId,
+			ex.Sets,
+			ex.Repetitions,
+			ex.Weights,
+			ex.WeightUnit)
+		if err != nil {
+			return fmt.Errorf("failed to insert exercise at position %d of template id '%v': %w", i+1, templateID, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *postgresTemplateRepository) CreateTemplate(ctx context.Context, data CreateWT) (*WorkoutTemplate, error) {
+	var newWT WorkoutTemplate
+
+	err := executeTransaction(ctx, r.db, func(txCtx context.Context, tx *sql.Tx) error {
+		insertQuery := `INSERT INTO workout_templates (
+			user_id,
+			name,
+			description) VALUES ($1, $2, $3)
+			RETURNING ` + templateColumns
+
+		err := scanTemplate(tx.QueryRowContext(txCtx, insertQuery, data.UserId, data.Name, data.Description), &newWT)
+		if err != nil {
+			return fmt.Errorf("failed to insert and scan new workout template: %w", err)
+		}
+
+		return insertTemplateExercises(txCtx, tx, newWT.Id, data.Exercises)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &newWT, nil
+}
+
+func (r *postgresTemplateRepository) GetTemplateById(ctx context.Context, id int) (*WorkoutTemplate, error) {
+	query := `SELECT ` + templateColumns + ` FROM workout_templates WHERE id = $1`
+
+	row, err := executeQueryRow(ctx, r.db, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workout template by id '%v': %w", id, err)
+	}
+
+	var template WorkoutTemplate
+	if err := scanTemplate(row, &template); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("failed to scan returned workout template by id '%v': %w", id, err)
+	}
+
+	return &template, nil
+}
+
+func (r *postgresTemplateRepository) UpdateTemplate(ctx context.Context, data UpdateWT) (*WorkoutTemplate, error) {
+	var updatedWT WorkoutTemplate
+
+	err := executeTransaction(ctx, r.db, func(txCtx context.Context, tx *sql.Tx) error {
+		updateQuery := `UPDATE workout_templates
+				SET name = COALESCE($1, name),
+					description = COALESCE($2, description),
+					updated_at = CURRENT_TIMESTAMP
+				WHERE id = $3 RETURNING ` + templateColumns
+
+		err := scanTemplate(tx.QueryRowContext(txCtx, updateQuery, data.Name, data.Description, data.Id), &updatedWT)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return apperrors.ErrNotFound
+			}
+			return fmt.Errorf("failed to update and scan workout template with id '%v': %w", data.Id, err)
+		}
+
+		if data.Exercises == nil {
+			return nil
+		}
+
+		if _, err := tx.ExecContext(txCtx, `DELETE FROM template_exercises WHERE template_id = $1`, data.Id); err != nil {
+			return fmt.Errorf("failed to delete exercises of template id '%v': %w", data.Id, err)
+		}
+
+		return insertTemplateExercises(txCtx, tx, data.Id, data.Exercises)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedWT, nil
+}
+
+func (r *postgresTemplateRepository) DeleteTemplateById(ctx context.Context, id int) error {
+	query := `DELETE FROM workout_templates WHERE id = $1`
+
+	result, err := executeNonQuery(ctx, r.db, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to execute delete query for workout template id '%v': %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after deleting workout template id '%v': %w", id, err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *postgresTemplateRepository) ListUserTemplates(ctx context.Context, userID int) ([]WorkoutTemplate, error) {
+	query := `SELECT ` + templateColumns + ` FROM workout_templates WHERE user_id = $1 ORDER BY name ASC`
+
+	rows, err := executeQuery(ctx, r.db, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workout templates for user id '%v': %w", userID, err)
+	}
+	defer rows.Close()
+
+	var wtList []WorkoutTemplate
+	for rows.Next() {
+		var wt WorkoutTemplate
+		if err := scanTemplate(rows, &wt); err != nil {
+			return nil, fmt.Errorf("failed to scan workout template row: %w", err)
+		}
+		wtList = append(wtList, wt)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating workout template rows: %w", err)
+	}
+
+	return wtList, nil
+}
+
+func (r *postgresTemplateRepository) ListTemplateExercises(ctx context.Context, templateID int) ([]TemplateExercise, error) {
+	query := `SELECT
+		id,
+		template_id,
+		position,
+		exercise_id,
+		sets,
+		repetitions,
+		weights,
+		weight_unit
+	FROM template_exercises WHERE template_id = $1 ORDER BY position ASC`
+
+	rows, err := executeQuery(ctx, r.db, query, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query exercises for template id '%v': %w", templateID, err)
+	}
+	defer rows.Close()
+
+	var exList []TemplateExercise
+	for rows.Next() {
+		var ex TemplateExercise
+		if err := rows.Scan(
+			&ex.Id,
+			&ex.TemplateId,
+			&ex.Position,
+			&ex.ExerciseId,
+			&ex.Sets,
+			&ex.Repetitions,
+			&ex.Weights,
+			&ex.WeightUnit); err != nil {
+			return nil, fmt.Errorf("failed to scan template exercise row: %w", err)
+		}
+		exList = append(exList, ex)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating template exercise rows: %w", err)
+	}
+
+	return exList, nil
+}
@@ -0,0 +1,248 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+)
+
+var templateColumns = []string{"id", "user_id", "name", "description", "created_at", "updated_at"}
+
+func TestCreateTemplate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	wtRepo := repository.NewTemplateRepository(db)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	description := "heavy lower body day"
+
+	t.Run("success", func(t *testing.T) {
+		data := repository.CreateWT{
+			UserId:      1,
+			Name:        "Leg day",
+			Description: &description,
+			Exercises: []repository.CreateEP{
+				{ExerciseId: 3, Sets: 5, Repetitions: 5, Weights: 100, WeightUnit: repository.KG},
+				{ExerciseId: 7, Sets: 3, Repetitions: 12, Weights: 40, WeightUnit: repository.KG},
+			},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO workout_templates ( user_id, name, description) VALUES ($1, $2, $3)`)).
+			WithArgs(1, "Leg day", description).
+			WillReturnRows(sqlmock.NewRows(templateColumns).AddRow(1, 1, "Leg day", description, now, now))
+		for i, ex := range data.Exercises {
+			mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO template_exercises`)).
+				WithArgs(1, i+1, ex.ExerciseId, ex.Sets, ex.Repetitions, ex.Weights, ex.WeightUnit).
+				WillReturnResult(sqlmock.NewResult(int64(i+1), 1))
+		}
+		mock.ExpectCommit()
+
+		wt, err := wtRepo.CreateTemplate(ctx, data)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, wt.Id)
+		assert.Equal(t, "Leg day", wt.Name)
+		assert.Equal(t, sql.NullString{String: description, Valid: true}, wt.Description)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("exercise insert fails", func(t *testing.T) {
+		data := repository.CreateWT{
+			UserId:    1,
+			Name:      "Push",
+			Exercises: []repository.CreateEP{{ExerciseId: 999, Sets: 3, Repetitions: 8, Weights: 60, WeightUnit: repository.KG}},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO workout_templates`)).
+			WithArgs(1, "Push", nil).
+			WillReturnRows(sqlmock.NewRows(templateColumns).AddRow(2, 1, "Push", nil, now, now))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO template_exercises`)).
+			WillReturnError(errors.New("insert or update on table violates foreign key constraint"))
+		mock.ExpectRollback()
+
+		wt, err := wtRepo.CreateTemplate(ctx, data)
+		assert.Error(t, err)
+		assert.Nil(t, wt)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetTemplateById(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	wtRepo := repository.NewTemplateRepository(db)
+	ctx := context.Background()
+	query := `SELECT id, user_id, name, description, created_at, updated_at FROM workout_templates WHERE id = $1`
+
+	t.Run("success", func(t *testing.T) {
+		now := time.Now().Truncate(time.Second)
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(templateColumns).AddRow(1, 1, "Leg day", nil, now, now))
+
+		wt, err := wtRepo.GetTemplateById(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "Leg day", wt.Name)
+		assert.False(t, wt.Description.Valid)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectQuery().
+			WithArgs(99).
+			WillReturnError(sql.ErrNoRows)
+
+		wt, err := wtRepo.GetTemplateById(ctx, 99)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+		assert.Nil(t, wt)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateTemplate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	wtRepo := repository.NewTemplateRepository(db)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	name := "Leg day B"
+
+	t.Run("rename only keeps exercises", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE workout_templates`)).
+			WithArgs(name, nil, 1).
+			WillReturnRows(sqlmock.NewRows(templateColumns).AddRow(1, 1, name, nil, now, now))
+		mock.ExpectCommit()
+
+		wt, err := wtRepo.UpdateTemplate(ctx, repository.UpdateWT{Id: 1, Name: &name})
+		assert.NoError(t, err)
+		assert.Equal(t, name, wt.Name)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("replace exercises", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE workout_templates`)).
+			WithArgs(nil, nil, 1).
+			WillReturnRows(sqlmock.NewRows(templateColumns).AddRow(1, 1, name, nil, now, now))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM template_exercises WHERE template_id = $1`)).
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO template_exercises`)).
+			WithArgs(1, 1, 4, 4, 6, float32(80), repository.KG).
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectCommit()
+
+		_, err := wtRepo.UpdateTemplate(ctx, repository.UpdateWT{
+			Id:        1,
+			Exercises: []repository.CreateEP{{ExerciseId: 4, Sets: 4, Repetitions: 6, Weights: 80, WeightUnit: repository.KG}},
+		})
+		assert.NoError(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`UPDATE workout_templates`)).
+			WithArgs(name, nil, 99).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		wt, err := wtRepo.UpdateTemplate(ctx, repository.UpdateWT{Id: 99, Name: &name})
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+		assert.Nil(t, wt)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteTemplateById(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	wtRepo := repository.NewTemplateRepository(db)
+	ctx := context.Background()
+	query := `DELETE FROM workout_templates WHERE id = $1`
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, wtRepo.DeleteTemplateById(ctx, 1))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(99).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := wtRepo.DeleteTemplateById(ctx, 99)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestListTemplateExercises(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	wtRepo := repository.NewTemplateRepository(db)
+	ctx := context.Background()
+
+	t.Run("success in position order", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(`FROM template_exercises WHERE template_id = $1 ORDER BY position ASC`)).
+			ExpectQuery().
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "template_id", "position", "exercise_id", "sets", "repetitions", "weights", "weight_unit"}).
+				AddRow(1, 1, 1, 3, 5, 5, 100.0, "kg").
+				AddRow(2, 1, 2, 7, 3, 12, 40.0, "kg"))
+
+		exList, err := wtRepo.ListTemplateExercises(ctx, 1)
+		assert.NoError(t, err)
+		assert.Len(t, exList, 2)
+		assert.Equal(t, 1, exList[0].Position)
+		assert.Equal(t, 7, exList[1].ExerciseId)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+
+	"github.com/lib/pq"
+)
+
+type TOTP struct {
+	UserId       int           `json:"userId"`
+	Secret       string        `json:"secret"`
+	ConfirmedAt  sql.NullTime  `json:"confirmedAt"`
+	LastUsedStep sql.NullInt64 `json:"lastUsedStep"`
+	CreatedAt    time.Time     `json:"createdAt"`
+}
+
+type RecoveryCode struct {
+	Id       int    `json:"id"`
+	CodeHash string `json:"codeHash"`
+}
+
+type TwoFactorRepository interface {
+	SavePendingTOTP(ctx context.Context, userId int, secret string) error
+	GetTOTP(ctx context.Context, userId int) (*TOTP, error)
+	ConfirmTOTP(ctx context.Context, userId int) error
+	UseTOTPStep(ctx context.Context, userId int, step int64) (bool, error)
+	DeleteTOTP(ctx context.Context, userId int) error
+	CreateRecoveryCodes(ctx context.Context, userId int, codeHashes []string) error
+	ListUnusedRecoveryCodes(ctx context.Context, userId int) ([]RecoveryCode, error)
+	UseRecoveryCode(ctx context.Context, id int) (bool, error)
+	DeleteRecoveryCodes(ctx context.Context, userId int) error
+}
+
+type postgresTwoFactorRepository struct {
+	db *sql.DB
+}
+
+func NewTwoFactorRepository(db *sql.DB) TwoFactorRepository {
+	return &postgresTwoFactorRepository{
+		db: db,
+	}
+}
+
+// SavePendingTOTP stores a new secret waiting for confirmation, replacing an earlier pending one.
+// A confirmed secret is kept and ErrAlreadyExists returned.
+func (r *postgresTwoFactorRepository) SavePendingTOTP(ctx context.Context, userId int, secret string) error {
+	query := `INSERT INTO user_totp (user_id, secret) VALUES ($1, $2)
+	ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, last_used_step = NULL, created_at = CURRENT_TIMESTAMP
+	WHERE user_totp.confirmed_at IS NULL`
+
+	result, err := executeNonQuery(ctx, r.db, query, userId, secret)
+	if err != nil {
+		return fmt.Errorf("failed to save TOTP secret of user id '%v': %w", userId, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrAlreadyExists
+	}
+
+	return nil
+}
+
+func (r *postgresTwoFactorRepository) GetTOTP(ctx context.Context, userId int) (*TOTP, error) {
+	query := `SELECT user_id, secret, confirmed_at, last_used_step, created_at FROM user_totp WHERE user_id = $1`
+
+	row, err := executeQueryRow(ctx, r.db, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query TOTP of user id '%v': %w", userId, err)
+	}
+
+	var totp TOTP
+	if err := row.Scan(&totp.UserId, &totp.Secret, &totp.ConfirmedAt, &totp.LastUsedStep, &totp.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to scan TOTP row: %w", err)
+	}
+
+	return &totp, nil
+}
+
+func (r *postgresTwoFactorRepository) ConfirmTOTP(ctx context.Context, userId int) error {
+	query := `UPDATE user_totp SET confirmed_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND confirmed_at IS NULL`
+
+	result, err := executeNonQuery(ctx, r.db, query, userId)
+	if err != nil {
+		return fmt.Errorf("failed to confirm TOTP of user id '%v': %w", userId, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// UseTOTPStep records the time step of an accepted code. It returns false when that step or a
+// later one was used already, so a code cannot be replayed.
+func (r *postgresTwoFactorRepository) UseTOTPStep(ctx context.Context, userId int, step int64) (bool, error) {
+	query := `UPDATE user_totp SET last_used_step = $2
+	WHERE user_id = $1 AND (last_used_step IS NULL OR last_used_step < $2)`
+
+	result, err := executeNonQuery(ctx, r.db, query, userId, step)
+	if err != nil {
+		return false, fmt.Errorf("failed to use TOTP step of user id '%v': %w", userId, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *postgresTwoFactorRepository) DeleteTOTP(ctx context.Context, userId int) error {
+	query := `DELETE FROM user_totp WHERE user_id = $1`
+
+	result, err := executeNonQuery(ctx, r.db, query, userId)
+	if err != nil {
+		return fmt.Errorf("failed to delete TOTP of user id '%v': %w", userId, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *postgresTwoFactorRepository) CreateRecoveryCodes(ctx context.Context, userId int, codeHashes []string) error {
+	query := `INSERT INTO totp_recovery_codes (user_id, code_hash) SELECT $1, unnest($2::text[])`
+
+	if _, err := executeNonQuery(ctx, r.db, query, userId, pq.Array(codeHashes)); err != nil {
+		return fmt.Errorf("failed to insert recovery codes of user id '%v': %w", userId, err)
+	}
+
+	return nil
+}
+
+func (r *postgresTwoFactorRepository) ListUnusedRecoveryCodes(ctx context.Context, userId int) ([]RecoveryCode, error) {
+	query := `SELECT id, code_hash FROM totp_recovery_codes WHERE user_id = $1 AND used_at IS NULL ORDER BY id`
+
+	rows, err := executeQuery(ctx, r.db, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recovery codes of user id '%v': %w", userId, err)
+	}
+	defer rows.Close()
+
+	var codes []RecoveryCode
+	for rows.Next() {
+		var code RecoveryCode
+		if err := rows.Scan(&code.Id, &code.CodeHash); err != nil {
+			return nil, fmt.Errorf("failed to scan recovery code row: %w", err)
+		}
+		codes = append(codes, code)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recovery code rows: %w", err)
+	}
+
+	return codes, nil
+}
+
+// UseRecoveryCode marks a code used, false means another request used it first
+func (r *postgresTwoFactorRepository) UseRecoveryCode(ctx context.Context, id int) (bool, error) {
+	query := `UPDATE totp_recovery_codes SET used_at = CURRENT_TIMESTAMP WHERE id = $1 AND used_at IS NULL`
+
+	result, err := executeNonQuery(ctx, r.db, query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code id '%v': %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *postgresTwoFactorRepository) DeleteRecoveryCodes(ctx context.Context, userId int) error {
+	query := `DELETE FROM totp_recovery_codes WHERE user_id = $1`
+
+	if _, err := executeNonQuery(ctx, r.db, query, userId); err != nil {
+		return fmt.Errorf("failed to delete recovery codes of user id '%v': %w", userId, err)
+	}
+
+	return nil
+}
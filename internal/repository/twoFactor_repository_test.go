@@ -0,0 +1,225 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+)
+
+func TestSavePendingTOTP(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	twoFactorRepo := repository.NewTwoFactorRepository(db)
+	ctx := context.Background()
+	query := regexp.QuoteMeta(`ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, last_used_step = NULL, created_at = CURRENT_TIMESTAMP
+	WHERE user_totp.confirmed_at IS NULL`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectExec().
+			WithArgs(5, "SECRET").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := twoFactorRepo.SavePendingTOTP(ctx, 5, "SECRET")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already confirmed", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectExec().
+			WithArgs(5, "SECRET").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := twoFactorRepo.SavePendingTOTP(ctx, 5, "SECRET")
+		assert.ErrorIs(t, err, apperrors.ErrAlreadyExists)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetTOTP(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	twoFactorRepo := repository.NewTwoFactorRepository(db)
+	ctx := context.Background()
+	now := time.Date(2025, 5, 2, 9, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(`SELECT user_id, secret, confirmed_at, last_used_step, created_at FROM user_totp WHERE user_id = $1`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectQuery().
+			WithArgs(5).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "secret", "confirmed_at", "last_used_step", "created_at"}).
+				AddRow(5, "SECRET", now, int64(42), now))
+
+		totp, err := twoFactorRepo.GetTOTP(ctx, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, &repository.TOTP{
+			UserId:       5,
+			Secret:       "SECRET",
+			ConfirmedAt:  sql.NullTime{Time: now, Valid: true},
+			LastUsedStep: sql.NullInt64{Int64: 42, Valid: true},
+			CreatedAt:    now,
+		}, totp)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectQuery().
+			WithArgs(6).
+			WillReturnError(sql.ErrNoRows)
+
+		totp, err := twoFactorRepo.GetTOTP(ctx, 6)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Nil(t, totp)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestConfirmTOTP(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	twoFactorRepo := repository.NewTwoFactorRepository(db)
+	ctx := context.Background()
+	query := regexp.QuoteMeta(`UPDATE user_totp SET confirmed_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND confirmed_at IS NULL`)
+
+	mock.ExpectPrepare(query).ExpectExec().WithArgs(5).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, twoFactorRepo.ConfirmTOTP(ctx, 5))
+
+	mock.ExpectPrepare(query).ExpectExec().WithArgs(5).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, twoFactorRepo.ConfirmTOTP(ctx, 5), apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUseTOTPStep(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	twoFactorRepo := repository.NewTwoFactorRepository(db)
+	ctx := context.Background()
+	query := regexp.QuoteMeta(`WHERE user_id = $1 AND (last_used_step IS NULL OR last_used_step < $2)`)
+
+	t.Run("new step", func(t *testing.T) {
+		mock.ExpectPrepare(query).ExpectExec().WithArgs(5, int64(100)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		used, err := twoFactorRepo.UseTOTPStep(ctx, 5, 100)
+		assert.NoError(t, err)
+		assert.True(t, used)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("replayed step", func(t *testing.T) {
+		mock.ExpectPrepare(query).ExpectExec().WithArgs(5, int64(100)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		used, err := twoFactorRepo.UseTOTPStep(ctx, 5, 100)
+		assert.NoError(t, err)
+		assert.False(t, used)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteTOTP(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	twoFactorRepo := repository.NewTwoFactorRepository(db)
+	ctx := context.Background()
+	query := regexp.QuoteMeta(`DELETE FROM user_totp WHERE user_id = $1`)
+
+	mock.ExpectPrepare(query).ExpectExec().WithArgs(5).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, twoFactorRepo.DeleteTOTP(ctx, 5))
+
+	mock.ExpectPrepare(query).ExpectExec().WithArgs(6).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, twoFactorRepo.DeleteTOTP(ctx, 6), apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	twoFactorRepo := repository.NewTwoFactorRepository(db)
+	ctx := context.Background()
+
+	t.Run("create", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(`INSERT INTO totp_recovery_codes (user_id, code_hash) SELECT $1, unnest($2::text[])`)).
+			ExpectExec().
+			WithArgs(5, pq.Array([]string{"hash-1", "hash-2"})).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := twoFactorRepo.CreateRecoveryCodes(ctx, 5, []string{"hash-1", "hash-2"})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("list unused", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(`SELECT id, code_hash FROM totp_recovery_codes WHERE user_id = $1 AND used_at IS NULL ORDER BY id`)).
+			ExpectQuery().
+			WithArgs(5).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "code_hash"}).AddRow(1, "hash-1").AddRow(2, "hash-2"))
+
+		codes, err := twoFactorRepo.ListUnusedRecoveryCodes(ctx, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.RecoveryCode{{Id: 1, CodeHash: "hash-1"}, {Id: 2, CodeHash: "hash-2"}}, codes)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("use", func(t *testing.T) {
+		query := regexp.QuoteMeta(`UPDATE totp_recovery_codes SET used_at = CURRENT_TIMESTAMP WHERE id = $1 AND used_at IS NULL`)
+		mock.ExpectPrepare(query).ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectPrepare(query).ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		used, err := twoFactorRepo.UseRecoveryCode(ctx, 1)
+		assert.NoError(t, err)
+		assert.True(t, used)
+
+		used, err = twoFactorRepo.UseRecoveryCode(ctx, 1)
+		assert.NoError(t, err)
+		assert.False(t, used)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		dbError := errors.New("delete failed")
+		mock.ExpectPrepare(regexp.QuoteMeta(`DELETE FROM totp_recovery_codes WHERE user_id = $1`)).
+			ExpectExec().
+			WithArgs(5).
+			WillReturnError(dbError)
+
+		err := twoFactorRepo.DeleteRecoveryCodes(ctx, 5)
+		assert.ErrorIs(t, err, dbError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -18,6 +18,27 @@ type User struct {
 	PasswordHash string    `json:"password_hash"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+	// EmailVerifiedAt is set once the user opened the verification token sent at signup
+	EmailVerifiedAt sql.NullTime `json:"email_verified_at"`
+	Role            Role         `json:"role"`
+	// DisabledAt is set while an admin keeps the user from logging in
+	DisabledAt sql.NullTime `json:"disabled_at"`
+	// DeletionDueAt is set while the user waits for the account to be deleted
+	DeletionDueAt sql.NullTime `json:"deletion_due_at"`
+}
+
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
+// UserFilter pages through the users for the admin, Email is a case-insensitive substring
+type UserFilter struct {
+	Email  string
+	Limit  int
+	Offset int
 }
 
 type UserCreate struct {
@@ -29,8 +50,23 @@ type UserCreate struct {
 type UserRepository interface {
 	CreateUser(ctx context.Context, user UserCreate) (*User, error)
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
+	GetUserById(ctx context.Context, userId int) (*User, error)
 	DeleteUserByEmail(ctx context.Context, email string) error
 	ExistUser(ctx context.Context, email string) (bool, error)
+	GetPreferredUnit(ctx context.Context, userId int) (WeightUnit, error)
+	UpdatePreferredUnit(ctx context.Context, userId int, unit WeightUnit) error
+	GetTimeZone(ctx context.Context, userId int) (string, error)
+	UpdateTimeZone(ctx context.Context, userId int, timeZone string) error
+	UpdatePasswordHash(ctx context.Context, userId int, passwordHash string) error
+	MarkEmailVerified(ctx context.Context, userId int) error
+	ListUsers(ctx context.Context, filter UserFilter) ([]User, error)
+	SetUserDisabled(ctx context.Context, userId int, disabled bool) error
+	UpdateUserRole(ctx context.Context, userId int, role Role) error
+	GrantRoleByEmails(ctx context.Context, emails []string, role Role) (int64, error)
+	ScheduleUserDeletion(ctx context.Context, userId int, dueAt time.Time) error
+	CancelUserDeletion(ctx context.Context, userId int) error
+	ListUsersDueForDeletion(ctx context.Context, now time.Time, afterId int, limit int) ([]int, error)
+	DeleteDueUser(ctx context.Context, userId int, now time.Time) (bool, error)
 	// ... other user-related methods
 }
 
@@ -45,10 +81,16 @@ func NewUserRepository(db *sql.DB) UserRepository {
 	}
 }
 
+const userColumns = "id, name, email, password_hash, created_at, updated_at, email_verified_at, role, disabled_at, deletion_due_at"
+
+func scanUser(row interface{ Scan(...any) error }, user *User) error {
+	return row.Scan(&user.Id, &user.Name, &user.Email, &user.PasswordHash, &user.CreatedAt, &user.UpdatedAt, &user.EmailVerifiedAt, &user.Role, &user.DisabledAt, &user.DeletionDueAt)
+}
+
 func (r *postgresUserRepository) CreateUser(ctx context.Context, data UserCreate) (*User, error) {
 
 	// insert into users table, second
-	query := `INSERT INTO users (name, email, password_hash) VALUES ($1, $2, $3) RETURNING id, name, email, password_hash, created_at, updated_at`
+	query := `INSERT INTO users (name, email, password_hash) VALUES ($1, $2, $3) RETURNING ` + userColumns
 
 	row, err := executeQueryRow(ctx, r.db, query, data.Name, data.Email, data.PasswordHash)
 	if err != nil {
@@ -57,7 +99,7 @@ func (r *postgresUserRepository) CreateUser(ctx context.Context, data UserCreate
 
 	// Scan the result into a User struct, third
 	var newUser User
-	err = row.Scan(&newUser.Id, &newUser.Name, &newUser.Email, &newUser.PasswordHash, &newUser.CreatedAt, &newUser.UpdatedAt)
+	err = scanUser(row, &newUser)
 	if err != nil {
 		var pqErr *pq.Error
 		// Check if the error is a PostgreSQL error
@@ -76,14 +118,14 @@ func (r *postgresUserRepository) CreateUser(ctx context.Context, data UserCreate
 func (r *postgresUserRepository) GetUserByEmail(ctx context.Context, email string) (*User, error) {
 	var user User
 
-	query := `SELECT id, name, email, password_hash, created_at, updated_at FROM users WHERE email = $1`
+	query := `SELECT ` + userColumns + ` FROM users WHERE email = $1`
 
 	row, err := executeQueryRow(ctx, r.db, query, email)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute query for user: %w", err)
 	}
 
-	err = row.Scan(&user.Id, &user.Name, &user.Email, &user.PasswordHash, &user.CreatedAt, &user.UpdatedAt)
+	err = scanUser(row, &user)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -96,6 +138,27 @@ func (r *postgresUserRepository) GetUserByEmail(ctx context.Context, email strin
 
 }
 
+func (r *postgresUserRepository) GetUserById(ctx context.Context, userId int) (*User, error) {
+	var user User
+
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+
+	row, err := executeQueryRow(ctx, r.db, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query for user: %w", err)
+	}
+
+	err = scanUser(row, &user)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("failed to scan returned user data by id '%v': %w", userId, err)
+	}
+	return &user, nil
+}
+
 func (r *postgresUserRepository) DeleteUserByEmail(ctx context.Context, email string) error {
 	return executeTransaction(ctx, r.db, func(txCtx context.Context, tx *sql.Tx) error {
 		var userID int
@@ -109,54 +172,80 @@ func (r *postgresUserRepository) DeleteUserByEmail(ctx context.Context, email st
 			return fmt.Errorf("failed to scan user data by email '%v' for deletion: %w", email, err)
 		}
 
-		// delete all exercise plans first associated with the user's workout plans
-		deleteExercisePlansQuery := ` 
+		return deleteUserData(txCtx, tx, userID)
+	})
+
+}
+
+// deleteUserData deletes the user with everything the foreign keys do not cascade: the workout
+// plans with their exercise plans, the schedules, the templates and the custom exercises. The
+// sessions are deleted explicitly, the rest of the account goes with the user row.
+func deleteUserData(ctx context.Context, tx *sql.Tx, userID int) error {
+	// delete all exercise plans first associated with the user's workout plans
+	deleteExercisePlansQuery := ` 
 	DELETE FROM exercise_plans
 	WHERE workout_plan_id IN (SELECT id FROM workout_plans WHERE user_id = $1)
 	`
-		_, err = tx.ExecContext(txCtx, deleteExercisePlansQuery, userID)
-		if err != nil {
-			return fmt.Errorf("failed to delete exercise plans for user ID %d: %w", userID, err)
-		}
+	_, err := tx.ExecContext(ctx, deleteExercisePlansQuery, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete exercise plans for user ID %d: %w", userID, err)
+	}
 
-		// delete all workout plans second associated with the user
-		deleteWorkoutPlansQuery := `
+	// delete all workout plans second associated with the user
+	deleteWorkoutPlansQuery := `
 		DELETE FROM workout_plans WHERE user_id = $1
 	`
 
-		_, err = tx.ExecContext(txCtx, deleteWorkoutPlansQuery, userID)
+	_, err = tx.ExecContext(ctx, deleteWorkoutPlansQuery, userID)
 
-		if err != nil {
-			return fmt.Errorf("failed to delete workout plans for user ID %d: %w", userID, err)
+	if err != nil {
+		return fmt.Errorf("failed to delete workout plans for user ID %d: %w", userID, err)
+	}
+
+	// the custom exercises go last, the plans and templates above referenced them. Plans and templates
+	// of other users may still reference one, those are archived and detached from the account
+	// instead, deleting them would violate the references and the owner's cascade would try it.
+	customData := []struct{ action, query string }{
+		{"delete workout schedules", `DELETE FROM workout_schedules WHERE user_id = $1`},
+		{"delete workout templates", `DELETE FROM workout_templates WHERE user_id = $1`},
+		{"archive referenced custom exercises", `
+		UPDATE exercises SET owner_id = NULL, archived_at = COALESCE(archived_at, CURRENT_TIMESTAMP)
+		WHERE owner_id = $1
+			AND (EXISTS (SELECT 1 FROM exercise_plans WHERE exercise_id = exercises.id)
+				OR EXISTS (SELECT 1 FROM template_exercises WHERE exercise_id = exercises.id))`},
+		{"delete custom exercises", `DELETE FROM exercises WHERE owner_id = $1`},
+		{"delete sessions", `DELETE FROM sessions WHERE user_id = $1`},
+	}
+	for _, data := range customData {
+		if _, err := tx.ExecContext(ctx, data.query, userID); err != nil {
+			return fmt.Errorf("failed to %s for user ID %d: %w", data.action, userID, err)
 		}
+	}
 
-		// delete the user finally
+	// delete the user finally
 
-		deleteUserQuery := `
+	deleteUserQuery := `
 		DELETE FROM users WHERE id = $1
 	`
 
-		result, err := tx.ExecContext(txCtx, deleteUserQuery, userID)
+	result, err := tx.ExecContext(ctx, deleteUserQuery, userID)
 
-		if err != nil {
-			return fmt.Errorf("failed to delete user with ID %d: %w", userID, err)
-		}
-
-		// ... after tx.Exec(deleteUserQuery, userID)
-		rowsAffected, err := result.RowsAffected()
-		if err != nil {
-			return fmt.Errorf("failed to get rows affected after deleting user with ID %d: %w", userID, err)
-		}
-		if rowsAffected == 0 {
-			// This case should ideally not be reached if the initial SELECT found the user,
-			// but it's a good safeguard or indicates an unexpected state.
-			return fmt.Errorf("user with ID %d not found for final deletion step: %w", userID, sql.ErrNoRows)
-		}
-
-		return nil
+	if err != nil {
+		return fmt.Errorf("failed to delete user with ID %d: %w", userID, err)
+	}
 
-	})
+	// ... after tx.Exec(deleteUserQuery, userID)
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after deleting user with ID %d: %w", userID, err)
+	}
+	if rowsAffected == 0 {
+		// This case should ideally not be reached if the initial SELECT found the user,
+		// but it's a good safeguard or indicates an unexpected state.
+		return fmt.Errorf("user with ID %d not found for final deletion step: %w", userID, sql.ErrNoRows)
+	}
 
+	return nil
 }
 
 func (r *postgresUserRepository) ExistUser(ctx context.Context, email string) (bool, error) {
@@ -180,3 +269,306 @@ func (r *postgresUserRepository) ExistUser(ctx context.Context, email string) (b
 	return true, nil
 
 }
+
+func (r *postgresUserRepository) GetPreferredUnit(ctx context.Context, userId int) (WeightUnit, error) {
+	query := `SELECT preferred_unit FROM users WHERE id = $1`
+
+	row, err := executeQueryRow(ctx, r.db, query, userId)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute query for preferred unit: %w", err)
+	}
+
+	var unit WeightUnit
+	if err = row.Scan(&unit); err != nil {
+		if err == sql.ErrNoRows {
+			return "", apperrors.ErrNotFound
+		}
+
+		return "", fmt.Errorf("failed to scan preferred unit of user id '%v': %w", userId, err)
+	}
+	return unit, nil
+}
+
+func (r *postgresUserRepository) UpdatePreferredUnit(ctx context.Context, userId int, unit WeightUnit) error {
+	query := `UPDATE users SET preferred_unit = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+
+	result, err := executeNonQuery(ctx, r.db, query, unit, userId)
+	if err != nil {
+		return fmt.Errorf("failed to update preferred unit of user id '%v': %w", userId, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after updating preferred unit of user id '%v': %w", userId, err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *postgresUserRepository) GetTimeZone(ctx context.Context, userId int) (string, error) {
+	query := `SELECT time_zone FROM users WHERE id = $1`
+
+	row, err := executeQueryRow(ctx, r.db, query, userId)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute query for time zone: %w", err)
+	}
+
+	var timeZone string
+	if err = row.Scan(&timeZone); err != nil {
+		if err == sql.ErrNoRows {
+			return "", apperrors.ErrNotFound
+		}
+
+		return "", fmt.Errorf("failed to scan time zone of user id '%v': %w", userId, err)
+	}
+	return timeZone, nil
+}
+
+func (r *postgresUserRepository) UpdateTimeZone(ctx context.Context, userId int, timeZone string) error {
+	query := `UPDATE users SET time_zone = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+
+	result, err := executeNonQuery(ctx, r.db, query, timeZone, userId)
+	if err != nil {
+		return fmt.Errorf("failed to update time zone of user id '%v': %w", userId, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after updating time zone of user id '%v': %w", userId, err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *postgresUserRepository) UpdatePasswordHash(ctx context.Context, userId int, passwordHash string) error {
+	query := `UPDATE users SET password_hash = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+
+	result, err := executeNonQuery(ctx, r.db, query, passwordHash, userId)
+	if err != nil {
+		return fmt.Errorf("failed to update password of user id '%v': %w", userId, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after updating password of user id '%v': %w", userId, err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// MarkEmailVerified keeps the first verification time when the email was already verified
+func (r *postgresUserRepository) MarkEmailVerified(ctx context.Context, userId int) error {
+	query := `UPDATE users SET email_verified_at = COALESCE(email_verified_at, CURRENT_TIMESTAMP), updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	result, err := executeNonQuery(ctx, r.db, query, userId)
+	if err != nil {
+		return fmt.Errorf("failed to mark email of user id '%v' as verified: %w", userId, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after verifying email of user id '%v': %w", userId, err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListUsers returns a page of users in signup order
+func (r *postgresUserRepository) ListUsers(ctx context.Context, filter UserFilter) ([]User, error) {
+	query := `SELECT ` + userColumns + ` FROM users
+	WHERE ($1 = '' OR email ILIKE '%' || $1 || '%')
+	ORDER BY id LIMIT $2 OFFSET $3`
+
+	rows, err := executeQuery(ctx, r.db, query, filter.Email, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []User{}
+	for rows.Next() {
+		var user User
+		if err := scanUser(rows, &user); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate users: %w", err)
+	}
+
+	return users, nil
+}
+
+// SetUserDisabled keeps the first disable time when the user was already disabled
+func (r *postgresUserRepository) SetUserDisabled(ctx context.Context, userId int, disabled bool) error {
+	query := `UPDATE users SET disabled_at = CASE WHEN $1 THEN COALESCE(disabled_at, CURRENT_TIMESTAMP) END, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+
+	result, err := executeNonQuery(ctx, r.db, query, disabled, userId)
+	if err != nil {
+		return fmt.Errorf("failed to update disabled state of user id '%v': %w", userId, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after updating disabled state of user id '%v': %w", userId, err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *postgresUserRepository) UpdateUserRole(ctx context.Context, userId int, role Role) error {
+	query := `UPDATE users SET role = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+
+	result, err := executeNonQuery(ctx, r.db, query, role, userId)
+	if err != nil {
+		return fmt.Errorf("failed to update role of user id '%v': %w", userId, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after updating role of user id '%v': %w", userId, err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// GrantRoleByEmails gives the role to the registered users among the emails, matched
+// case-insensitively, and returns how many changed
+func (r *postgresUserRepository) GrantRoleByEmails(ctx context.Context, emails []string, role Role) (int64, error) {
+	query := `UPDATE users SET role = $1, updated_at = CURRENT_TIMESTAMP
+	WHERE lower(email) = ANY(SELECT lower(unnest($2::text[]))) AND role <> $1`
+
+	result, err := executeNonQuery(ctx, r.db, query, role, pq.Array(emails))
+	if err != nil {
+		return 0, fmt.Errorf("failed to grant role '%v': %w", role, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected after granting role '%v': %w", role, err)
+	}
+
+	return rowsAffected, nil
+}
+
+// ScheduleUserDeletion keeps the first due time when the deletion was already scheduled
+func (r *postgresUserRepository) ScheduleUserDeletion(ctx context.Context, userId int, dueAt time.Time) error {
+	query := `UPDATE users SET deletion_due_at = COALESCE(deletion_due_at, $1), updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+
+	result, err := executeNonQuery(ctx, r.db, query, dueAt, userId)
+	if err != nil {
+		return fmt.Errorf("failed to schedule deletion of user id '%v': %w", userId, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after scheduling deletion of user id '%v': %w", userId, err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// CancelUserDeletion only works before the due time, ErrNotFound means there was nothing left to cancel
+func (r *postgresUserRepository) CancelUserDeletion(ctx context.Context, userId int) error {
+	query := `UPDATE users SET deletion_due_at = NULL, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND deletion_due_at > CURRENT_TIMESTAMP`
+
+	result, err := executeNonQuery(ctx, r.db, query, userId)
+	if err != nil {
+		return fmt.Errorf("failed to cancel deletion of user id '%v': %w", userId, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected after cancelling deletion of user id '%v': %w", userId, err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
+
+// ListUsersDueForDeletion returns up to limit ids greater than afterId of users whose deletion is due
+// at now, in id order, so a purge can page past accounts it failed to delete
+func (r *postgresUserRepository) ListUsersDueForDeletion(ctx context.Context, now time.Time, afterId int, limit int) ([]int, error) {
+	query := `SELECT id FROM users WHERE deletion_due_at <= $1 AND id > $2 ORDER BY id LIMIT $3`
+
+	rows, err := executeQuery(ctx, r.db, query, now, afterId, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query users due for deletion: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan user id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate users due for deletion: %w", err)
+	}
+
+	return ids, nil
+}
+
+// DeleteDueUser deletes the user and its data in one transaction if the deletion is still due at
+// now. The row is locked first, so a cancellation either lands before and the user is kept, or
+// waits and finds nothing to cancel.
+func (r *postgresUserRepository) DeleteDueUser(ctx context.Context, userId int, now time.Time) (bool, error) {
+	deleted := false
+	err := executeTransaction(ctx, r.db, func(txCtx context.Context, tx *sql.Tx) error {
+		var userID int
+		err := tx.QueryRowContext(txCtx, "SELECT id FROM users WHERE id = $1 AND deletion_due_at <= $2 FOR UPDATE", userId, now).Scan(&userID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			return fmt.Errorf("failed to lock user id '%v' for deletion: %w", userId, err)
+		}
+
+		if err := deleteUserData(txCtx, tx, userID); err != nil {
+			return err
+		}
+		deleted = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return deleted, nil
+}
@@ -37,11 +37,11 @@ func TestCreateUser(t *testing.T) {
 		}
 
 		// Expect both Prepare and QueryRow calls
-		mock.ExpectPrepare(`INSERT INTO users \(name, email, password_hash\) VALUES \(\$1, \$2, \$3\) RETURNING id, name, email, password_hash, created_at, updated_at`).
+		mock.ExpectPrepare(`INSERT INTO users \(name, email, password_hash\) VALUES \(\$1, \$2, \$3\) RETURNING id, name, email, password_hash, created_at, updated_at, email_verified_at, role, disabled_at, deletion_due_at`).
 			ExpectQuery(). // This expects the QueryRowContext call after preparation
 			WithArgs(userToCreate.Name, userToCreate.Email, userToCreate.PasswordHash).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password_hash", "created_at", "updated_at"}).
-				AddRow(1, userToCreate.Name, userToCreate.Email, userToCreate.PasswordHash, time.Now(), time.Now()))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password_hash", "created_at", "updated_at", "email_verified_at", "role", "disabled_at", "deletion_due_at"}).
+				AddRow(1, userToCreate.Name, userToCreate.Email, userToCreate.PasswordHash, time.Now(), time.Now(), nil, "user", nil, nil))
 
 		createdUser, err := userRepo.CreateUser(ctx, userToCreate)
 
@@ -67,7 +67,7 @@ func TestCreateUser(t *testing.T) {
 			Detail:   "Key (email)=(duplicate@example.com) already exists.",
 			Where:    "SQL statement \"INSERT INTO users ...\"",
 		}
-		mock.ExpectPrepare(`INSERT INTO users \(name, email, password_hash\) VALUES \(\$1, \$2, \$3\) RETURNING id, name, email, password_hash, created_at, updated_at`).
+		mock.ExpectPrepare(`INSERT INTO users \(name, email, password_hash\) VALUES \(\$1, \$2, \$3\) RETURNING id, name, email, password_hash, created_at, updated_at, email_verified_at, role, disabled_at, deletion_due_at`).
 			ExpectQuery().
 			WithArgs(userToCreate.Name, userToCreate.Email, userToCreate.PasswordHash).
 			WillReturnError(mockedPQError)
@@ -131,11 +131,11 @@ func TestGetUserByEmail(t *testing.T) {
 
 		// This one might also need ExpectPrepare if executeQueryRow is used.
 		// Let's assume it does, as your other helpers Prepare.
-		mock.ExpectPrepare(`SELECT id, name, email, password_hash, created_at, updated_at FROM users WHERE email = \$1`).
+		mock.ExpectPrepare(`SELECT id, name, email, password_hash, created_at, updated_at, email_verified_at, role, disabled_at, deletion_due_at FROM users WHERE email = \$1`).
 			ExpectQuery(). // Add this
 			WithArgs(email).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password_hash", "created_at", "updated_at"}).
-				AddRow(expectedUser.Id, expectedUser.Name, expectedUser.Email, expectedUser.PasswordHash, expectedUser.CreatedAt, expectedUser.UpdatedAt))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password_hash", "created_at", "updated_at", "email_verified_at", "role", "disabled_at", "deletion_due_at"}).
+				AddRow(expectedUser.Id, expectedUser.Name, expectedUser.Email, expectedUser.PasswordHash, expectedUser.CreatedAt, expectedUser.UpdatedAt, nil, "user", nil, nil))
 
 		user, err := userRepo.GetUserByEmail(ctx, email)
 
@@ -149,7 +149,7 @@ func TestGetUserByEmail(t *testing.T) {
 	t.Run("not found", func(t *testing.T) {
 		email := "notfound@example.com"
 
-		mock.ExpectPrepare(`SELECT id, name, email, password_hash, created_at, updated_at FROM users WHERE email = \$1`).
+		mock.ExpectPrepare(`SELECT id, name, email, password_hash, created_at, updated_at, email_verified_at, role, disabled_at, deletion_due_at FROM users WHERE email = \$1`).
 			ExpectQuery(). // Add this
 			WithArgs(email).
 			WillReturnError(sql.ErrNoRows)
@@ -166,7 +166,7 @@ func TestGetUserByEmail(t *testing.T) {
 	t.Run("database error", func(t *testing.T) {
 		email := "dberror@example.com"
 
-		mock.ExpectPrepare(`SELECT id, name, email, password_hash, created_at, updated_at FROM users WHERE email = \$1`).
+		mock.ExpectPrepare(`SELECT id, name, email, password_hash, created_at, updated_at, email_verified_at, role, disabled_at, deletion_due_at FROM users WHERE email = \$1`).
 			ExpectQuery(). // Add this
 			WithArgs(email).
 			WillReturnError(errors.New("connection reset by peer"))
@@ -245,6 +245,20 @@ func TestExistUser(t *testing.T) {
 	})
 }
 
+// expectCustomDataDeleted expects the statements deleting the rest of the user's data after the
+// workout plans
+func expectCustomDataDeleted(mock sqlmock.Sqlmock, userID int) {
+	for _, query := range []string{
+		`DELETE FROM workout_schedules WHERE user_id = $1`,
+		`DELETE FROM workout_templates WHERE user_id = $1`,
+		`UPDATE exercises SET owner_id = NULL, archived_at = COALESCE(archived_at, CURRENT_TIMESTAMP) WHERE owner_id = $1`,
+		`DELETE FROM exercises WHERE owner_id = $1`,
+		`DELETE FROM sessions WHERE user_id = $1`,
+	} {
+		mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(userID).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+}
+
 func TestDeleteUserByEmail(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -276,6 +290,7 @@ func TestDeleteUserByEmail(t *testing.T) {
 		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM workout_plans WHERE user_id = $1`)).
 			WithArgs(userID).
 			WillReturnResult(sqlmock.NewResult(0, 2)) // 2 workout plans affected
+		expectCustomDataDeleted(mock, userID)
 
 		// 5. Expect DELETE user
 		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM users WHERE id = $1`)).
@@ -330,6 +345,7 @@ func TestDeleteUserByEmail(t *testing.T) {
 		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM workout_plans WHERE user_id = $1`)).
 			WithArgs(userID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectCustomDataDeleted(mock, userID)
 
 		// 5. Expect DELETE user, but 0 rows affected
 		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM users WHERE id = $1`)).
@@ -398,6 +414,7 @@ func TestDeleteUserByEmail(t *testing.T) {
 		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM workout_plans WHERE user_id = $1`)).
 			WithArgs(userID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectCustomDataDeleted(mock, userID)
 
 		// 4. Expect DELETE user to return an error
 		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM users WHERE id = $1`)).
@@ -449,6 +466,7 @@ func TestDeleteUserByEmail(t *testing.T) {
 		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM workout_plans WHERE user_id = $1`)).
 			WithArgs(userID).
 			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectCustomDataDeleted(mock, userID)
 
 		// 5. Expect DELETE user (succeeds)
 		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM users WHERE id = $1`)).
@@ -468,3 +486,481 @@ func TestDeleteUserByEmail(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestGetUserById(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	userRepo := repository.NewUserRepository(db)
+	ctx := context.Background()
+	query := regexp.QuoteMeta(`SELECT id, name, email, password_hash, created_at, updated_at, email_verified_at, role, disabled_at, deletion_due_at FROM users WHERE id = $1`)
+	columns := []string{"id", "name", "email", "password_hash", "created_at", "updated_at", "email_verified_at", "role", "disabled_at", "deletion_due_at"}
+
+	t.Run("success", func(t *testing.T) {
+		now := time.Now()
+		mock.ExpectPrepare(query).
+			ExpectQuery().
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "John Doe", "john@example.com", "hashed", now, now, now, "user", nil, nil))
+
+		user, err := userRepo.GetUserById(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, &repository.User{
+			Id:              1,
+			Name:            "John Doe",
+			Email:           "john@example.com",
+			PasswordHash:    "hashed",
+			CreatedAt:       now,
+			UpdatedAt:       now,
+			EmailVerifiedAt: sql.NullTime{Time: now, Valid: true},
+			Role:            repository.RoleUser,
+		}, user)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectQuery().
+			WithArgs(99).
+			WillReturnError(sql.ErrNoRows)
+
+		user, err := userRepo.GetUserById(ctx, 99)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Nil(t, user)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetPreferredUnit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	userRepo := repository.NewUserRepository(db)
+	ctx := context.Background()
+	query := regexp.QuoteMeta(`SELECT preferred_unit FROM users WHERE id = $1`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectQuery().
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"preferred_unit"}).AddRow("lbs"))
+
+		unit, err := userRepo.GetPreferredUnit(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, repository.LBS, unit)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectQuery().
+			WithArgs(99).
+			WillReturnError(sql.ErrNoRows)
+
+		unit, err := userRepo.GetPreferredUnit(ctx, 99)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Empty(t, unit)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdatePreferredUnit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	userRepo := repository.NewUserRepository(db)
+	ctx := context.Background()
+	query := regexp.QuoteMeta(`UPDATE users SET preferred_unit = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectExec().
+			WithArgs(repository.LBS, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := userRepo.UpdatePreferredUnit(ctx, 1, repository.LBS)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectExec().
+			WithArgs(repository.KG, 99).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := userRepo.UpdatePreferredUnit(ctx, 99, repository.KG)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetTimeZone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	userRepo := repository.NewUserRepository(db)
+	ctx := context.Background()
+	query := regexp.QuoteMeta(`SELECT time_zone FROM users WHERE id = $1`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectQuery().
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"time_zone"}).AddRow("Asia/Hong_Kong"))
+
+		timeZone, err := userRepo.GetTimeZone(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "Asia/Hong_Kong", timeZone)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectQuery().
+			WithArgs(99).
+			WillReturnError(sql.ErrNoRows)
+
+		timeZone, err := userRepo.GetTimeZone(ctx, 99)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Empty(t, timeZone)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateTimeZone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	userRepo := repository.NewUserRepository(db)
+	ctx := context.Background()
+	query := regexp.QuoteMeta(`UPDATE users SET time_zone = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectExec().
+			WithArgs("Europe/Berlin", 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := userRepo.UpdateTimeZone(ctx, 1, "Europe/Berlin")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectExec().
+			WithArgs("UTC", 99).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := userRepo.UpdateTimeZone(ctx, 99, "UTC")
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdatePasswordHash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	userRepo := repository.NewUserRepository(db)
+	ctx := context.Background()
+	query := regexp.QuoteMeta(`UPDATE users SET password_hash = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectExec().
+			WithArgs("new_hash", 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := userRepo.UpdatePasswordHash(ctx, 1, "new_hash")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectExec().
+			WithArgs("new_hash", 99).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := userRepo.UpdatePasswordHash(ctx, 99, "new_hash")
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMarkEmailVerified(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	userRepo := repository.NewUserRepository(db)
+	ctx := context.Background()
+	query := regexp.QuoteMeta(`UPDATE users SET email_verified_at = COALESCE(email_verified_at, CURRENT_TIMESTAMP), updated_at = CURRENT_TIMESTAMP WHERE id = $1`)
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectExec().
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := userRepo.MarkEmailVerified(ctx, 1)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mock.ExpectPrepare(query).
+			ExpectExec().
+			WithArgs(99).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := userRepo.MarkEmailVerified(ctx, 99)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestListUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	userRepo := repository.NewUserRepository(db)
+	ctx := context.Background()
+	now := time.Now()
+	query := regexp.QuoteMeta(`WHERE ($1 = '' OR email ILIKE '%' || $1 || '%')
+	ORDER BY id LIMIT $2 OFFSET $3`)
+	columns := []string{"id", "name", "email", "password_hash", "created_at", "updated_at", "email_verified_at", "role", "disabled_at", "deletion_due_at"}
+
+	mock.ExpectPrepare(query).
+		ExpectQuery().
+		WithArgs("example", 20, 40).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, "Admin", "admin@example.com", "hashed", now, now, now, "admin", nil, nil).
+			AddRow(2, "John Doe", "john@example.com", "hashed", now, now, nil, "user", now, now))
+
+	users, err := userRepo.ListUsers(ctx, repository.UserFilter{Email: "example", Limit: 20, Offset: 40})
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, repository.RoleAdmin, users[0].Role)
+	assert.False(t, users[0].DisabledAt.Valid)
+	assert.True(t, users[1].DisabledAt.Valid)
+	assert.True(t, users[1].DeletionDueAt.Valid)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetUserDisabled(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	userRepo := repository.NewUserRepository(db)
+	ctx := context.Background()
+	query := regexp.QuoteMeta(`UPDATE users SET disabled_at = CASE WHEN $1 THEN COALESCE(disabled_at, CURRENT_TIMESTAMP) END, updated_at = CURRENT_TIMESTAMP WHERE id = $2`)
+
+	mock.ExpectPrepare(query).ExpectExec().WithArgs(true, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, userRepo.SetUserDisabled(ctx, 2, true))
+
+	mock.ExpectPrepare(query).ExpectExec().WithArgs(false, 9).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, userRepo.SetUserDisabled(ctx, 9, false), apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateUserRole(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	userRepo := repository.NewUserRepository(db)
+	ctx := context.Background()
+	query := regexp.QuoteMeta(`UPDATE users SET role = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`)
+
+	mock.ExpectPrepare(query).ExpectExec().WithArgs(repository.RoleAdmin, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, userRepo.UpdateUserRole(ctx, 2, repository.RoleAdmin))
+
+	mock.ExpectPrepare(query).ExpectExec().WithArgs(repository.RoleUser, 9).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, userRepo.UpdateUserRole(ctx, 9, repository.RoleUser), apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGrantRoleByEmails(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	userRepo := repository.NewUserRepository(db)
+	ctx := context.Background()
+	emails := []string{"Admin@example.com", "ops@example.com"}
+
+	mock.ExpectPrepare(regexp.QuoteMeta(`WHERE lower(email) = ANY(SELECT lower(unnest($2::text[]))) AND role <> $1`)).
+		ExpectExec().
+		WithArgs(repository.RoleAdmin, pq.Array(emails)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	granted, err := userRepo.GrantRoleByEmails(ctx, emails, repository.RoleAdmin)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), granted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestScheduleUserDeletion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	userRepo := repository.NewUserRepository(db)
+	ctx := context.Background()
+	dueAt := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	query := regexp.QuoteMeta(`UPDATE users SET deletion_due_at = COALESCE(deletion_due_at, $1), updated_at = CURRENT_TIMESTAMP WHERE id = $2`)
+
+	mock.ExpectPrepare(query).ExpectExec().WithArgs(dueAt, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, userRepo.ScheduleUserDeletion(ctx, 2, dueAt))
+
+	mock.ExpectPrepare(query).ExpectExec().WithArgs(dueAt, 9).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, userRepo.ScheduleUserDeletion(ctx, 9, dueAt), apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCancelUserDeletion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	userRepo := repository.NewUserRepository(db)
+	ctx := context.Background()
+	query := regexp.QuoteMeta(`UPDATE users SET deletion_due_at = NULL, updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND deletion_due_at > CURRENT_TIMESTAMP`)
+
+	mock.ExpectPrepare(query).ExpectExec().WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, userRepo.CancelUserDeletion(ctx, 2))
+
+	// nothing scheduled, or the grace period is over
+	mock.ExpectPrepare(query).ExpectExec().WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, userRepo.CancelUserDeletion(ctx, 3), apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListUsersDueForDeletion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	userRepo := repository.NewUserRepository(db)
+	ctx := context.Background()
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectPrepare(regexp.QuoteMeta(`SELECT id FROM users WHERE deletion_due_at <= $1 AND id > $2 ORDER BY id LIMIT $3`)).
+		ExpectQuery().
+		WithArgs(now, 3, 50).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4).AddRow(7))
+
+	ids, err := userRepo.ListUsersDueForDeletion(ctx, now, 3, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 7}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteDueUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	userRepo := repository.NewUserRepository(db)
+	ctx := context.Background()
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	userID := 4
+	lockQuery := regexp.QuoteMeta(`SELECT id FROM users WHERE id = $1 AND deletion_due_at <= $2 FOR UPDATE`)
+
+	t.Run("deletes the user and its data", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(userID, now).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userID))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM exercise_plans WHERE workout_plan_id IN (SELECT id FROM workout_plans WHERE user_id = $1)`)).
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM workout_plans WHERE user_id = $1`)).
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectCustomDataDeleted(mock, userID)
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM users WHERE id = $1`)).
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		deleted, err := userRepo.DeleteDueUser(ctx, userID, now)
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("skips a cancelled deletion", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(userID, now).WillReturnError(sql.ErrNoRows)
+		mock.ExpectCommit()
+
+		deleted, err := userRepo.DeleteDueUser(ctx, userID, now)
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rolls back when a delete fails", func(t *testing.T) {
+		dbErr := errors.New("foreign key violation")
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(userID, now).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userID))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM exercise_plans WHERE workout_plan_id IN (SELECT id FROM workout_plans WHERE user_id = $1)`)).
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM workout_plans WHERE user_id = $1`)).
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM workout_schedules WHERE user_id = $1`)).
+			WithArgs(userID).
+			WillReturnError(dbErr)
+		mock.ExpectRollback()
+
+		deleted, err := userRepo.DeleteDueUser(ctx, userID, now)
+		assert.ErrorIs(t, err, dbErr)
+		assert.Contains(t, err.Error(), "failed to delete workout schedules")
+		assert.False(t, deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
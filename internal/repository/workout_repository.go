@@ -39,6 +39,21 @@ type UpdateWP struct {
 	Comment       *string    `json:"comment,omitempty"` // poniter for optional update
 }
 
+// WorkoutPageFilter narrows ListWorkoutPage, a zero From or To does not bound the range
+type WorkoutPageFilter struct {
+	UserId int
+	From   time.Time // inclusive
+	To     time.Time // exclusive
+	After  *WorkoutCursor
+	Limit  int
+}
+
+// WorkoutCursor is the sort key of the last workout plan on a page
+type WorkoutCursor struct {
+	ScheduledDate time.Time
+	Id            int
+}
+
 type WorkoutRepository interface {
 	CreateWorkout(ctx context.Context, data CreateWP) (*WorkoutPlan, error)
 	GetWorkoutById(ctx context.Context, id int) (*WorkoutPlan, error)
@@ -46,6 +61,8 @@ type WorkoutRepository interface {
 	DeleteWorkoutById(ctx context.Context, id int) error
 	ListWorkoutsByStatus(ctx context.Context, userId int, status WPStatus, asc bool) ([]WorkoutPlan, error)
 	ListUserWorkouts(ctx context.Context, userId int) ([]WorkoutPlan, error)
+	ListWorkoutPage(ctx context.Context, filter WorkoutPageFilter) ([]WorkoutPlan, *WorkoutCursor, error)
+	MarkOverdueAsMissed(ctx context.Context, before time.Time) (int64, error)
 }
 
 type postgresWorkoutRepository struct {
@@ -318,3 +335,98 @@ func (r *postgresWorkoutRepository) ListUserWorkouts(ctx context.Context, userID
 	return wpList, nil
 
 }
+
+// listWorkoutPageQuery pages with a keyset on (scheduled_date, id), so plans added or removed
+// while a page is read do not shift the next one
+const listWorkoutPageQuery = `SELECT
+		id,
+		user_id,
+		status,
+		scheduled_date,
+		comment,
+		created_at,
+		updated_at
+	FROM workout_plans
+	WHERE user_id = $1
+		AND ($2::timestamptz IS NULL OR scheduled_date >= $2)
+		AND ($3::timestamptz IS NULL OR scheduled_date < $3)
+		AND ($4::timestamptz IS NULL OR (scheduled_date, id) > ($4::timestamptz, $5::int))
+	ORDER BY scheduled_date ASC, id ASC
+	LIMIT $6`
+
+// ListWorkoutPage returns a page of the user's workout plans by scheduled date.
+// The cursor of the next page is nil on the last page.
+func (r *postgresWorkoutRepository) ListWorkoutPage(ctx context.Context, filter WorkoutPageFilter) ([]WorkoutPlan, *WorkoutCursor, error) {
+	var from, to, afterDate sql.NullTime
+	if !filter.From.IsZero() {
+		from = sql.NullTime{Time: filter.From, Valid: true}
+	}
+	if !filter.To.IsZero() {
+		to = sql.NullTime{Time: filter.To, Valid: true}
+	}
+	var afterId int
+	if filter.After != nil {
+		afterDate = sql.NullTime{Time: filter.After.ScheduledDate, Valid: true}
+		afterId = filter.After.Id
+	}
+
+	// one extra row tells whether another page follows
+	rows, err := executeQuery(ctx, r.db, listWorkoutPageQuery,
+		filter.UserId,
+		from,
+		to,
+		afterDate,
+		afterId,
+		filter.Limit+1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query workout plan page for user id '%v': %w", filter.UserId, err)
+	}
+	defer rows.Close()
+
+	var wpList []WorkoutPlan
+	for rows.Next() {
+		var wp WorkoutPlan
+		if err := rows.Scan(
+			&wp.Id,
+			&wp.UserId,
+			&wp.Status,
+			&wp.ScheduledDate,
+			&wp.Comment,
+			&wp.CreatedAt,
+			&wp.UpdatedAt); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan workout plan row: %w", err)
+		}
+		wpList = append(wpList, wp)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating workout plan rows: %w", err)
+	}
+
+	if len(wpList) <= filter.Limit {
+		return wpList, nil, nil
+	}
+
+	last := wpList[filter.Limit-1]
+	return wpList[:filter.Limit], &WorkoutCursor{ScheduledDate: last.ScheduledDate, Id: last.Id}, nil
+}
+
+// MarkOverdueAsMissed moves every pending workout plan scheduled before the given time to missed,
+// and returns how many were moved.
+func (r *postgresWorkoutRepository) MarkOverdueAsMissed(ctx context.Context, before time.Time) (int64, error) {
+	query := `UPDATE workout_plans
+			SET status = $1,
+				updated_at = CURRENT_TIMESTAMP
+			WHERE status = $2 AND scheduled_date < $3`
+
+	result, err := executeNonQuery(ctx, r.db, query, MISSED, PENDING, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark overdue workout plans as missed: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected after marking overdue workout plans: %w", err)
+	}
+
+	return rowsAffected, nil
+}
@@ -791,3 +791,136 @@ func TestListWorkoutsByStatus(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestMarkOverdueAsMissed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	wpRepo := repository.NewWorkoutRepository(db)
+	ctx := context.Background()
+	cutoff := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	query := `UPDATE workout_plans SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE status = $2 AND scheduled_date < $3`
+
+	t.Run("success", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(repository.MISSED, repository.PENDING, cutoff).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		marked, err := wpRepo.MarkOverdueAsMissed(ctx, cutoff)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), marked)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nothing overdue", func(t *testing.T) {
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(repository.MISSED, repository.PENDING, cutoff).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		marked, err := wpRepo.MarkOverdueAsMissed(ctx, cutoff)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), marked)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		dbError := errors.New("connection reset")
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query)).
+			ExpectExec().
+			WithArgs(repository.MISSED, repository.PENDING, cutoff).
+			WillReturnError(dbError)
+
+		marked, err := wpRepo.MarkOverdueAsMissed(ctx, cutoff)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to mark overdue workout plans as missed")
+		assert.Equal(t, int64(0), marked)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestListWorkoutPage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	wpRepo := repository.NewWorkoutRepository(db)
+	ctx := context.Background()
+
+	userID := 10
+	pageQuery := `FROM workout_plans WHERE user_id = $1`
+	columns := []string{"id", "user_id", "status", "scheduled_date", "comment", "created_at", "updated_at"}
+	day := time.Date(2025, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	t.Run("extra row gives the cursor of the next page", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow(1, userID, repository.COMPLETED, day, "Legs", day, day).
+			AddRow(4, userID, repository.PENDING, day, nil, day, day).
+			AddRow(2, userID, repository.PENDING, day.AddDate(0, 0, 1), nil, day, day)
+
+		mock.ExpectPrepare(regexp.QuoteMeta(pageQuery)).
+			ExpectQuery().
+			WithArgs(userID, nil, nil, nil, 0, 3).
+			WillReturnRows(rows)
+
+		workoutPlans, next, err := wpRepo.ListWorkoutPage(ctx, repository.WorkoutPageFilter{UserId: userID, Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, []repository.WorkoutPlan{
+			{Id: 1, UserId: userID, Status: repository.COMPLETED, ScheduledDate: day, Comment: sql.NullString{String: "Legs", Valid: true}, CreatedAt: day, UpdatedAt: day},
+			{Id: 4, UserId: userID, Status: repository.PENDING, ScheduledDate: day, CreatedAt: day, UpdatedAt: day},
+		}, workoutPlans)
+		assert.Equal(t, &repository.WorkoutCursor{ScheduledDate: day, Id: 4}, next)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("range and cursor are passed on", func(t *testing.T) {
+		from, to := day.AddDate(0, -1, 0), day.AddDate(0, 1, 0)
+		rows := sqlmock.NewRows(columns).
+			AddRow(2, userID, repository.PENDING, day.AddDate(0, 0, 1), nil, day, day)
+
+		mock.ExpectPrepare(regexp.QuoteMeta(pageQuery)).
+			ExpectQuery().
+			WithArgs(userID, from, to, day, 4, 3).
+			WillReturnRows(rows)
+
+		workoutPlans, next, err := wpRepo.ListWorkoutPage(ctx, repository.WorkoutPageFilter{
+			UserId: userID,
+			From:   from,
+			To:     to,
+			After:  &repository.WorkoutCursor{ScheduledDate: day, Id: 4},
+			Limit:  2,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, workoutPlans, 1)
+		assert.Nil(t, next)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		dbError := errors.New("network error")
+
+		mock.ExpectPrepare(regexp.QuoteMeta(pageQuery)).
+			ExpectQuery().
+			WillReturnError(dbError)
+
+		workoutPlans, next, err := wpRepo.ListWorkoutPage(ctx, repository.WorkoutPageFilter{UserId: userID, Limit: 2})
+		assert.ErrorIs(t, err, dbError)
+		assert.Contains(t, err.Error(), "failed to query workout plan page for user id")
+		assert.Nil(t, workoutPlans)
+		assert.Nil(t, next)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
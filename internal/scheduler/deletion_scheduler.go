@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+	"workout-tracker-api/internal/cache"
+	"workout-tracker-api/internal/service"
+)
+
+const deletionLockKey = "scheduler:account-deletion:lock"
+
+type DeletionConfig struct {
+	Interval time.Duration
+}
+
+type DeletionSchedulerInterface interface {
+	Start(ctx context.Context)
+	RunOnce(ctx context.Context) (int, bool, error)
+}
+
+// DeletionScheduler deletes the accounts whose grace period is over
+type DeletionScheduler struct {
+	Deletions service.AccountDeletionServiceInterface
+	Clock     Clock
+	Config    DeletionConfig
+	Leader    *LeaderJob
+}
+
+func NewDeletionScheduler(ds service.AccountDeletionServiceInterface, locker cache.CacheInterface, clock Clock, config DeletionConfig) DeletionSchedulerInterface {
+	return &DeletionScheduler{
+		Deletions: ds,
+		Clock:     clock,
+		Config:    config,
+		Leader:    NewLeaderJob(deletionLockKey, locker, config.Interval),
+	}
+}
+
+// Start runs the job right away and then on every interval until ctx is cancelled
+func (s *DeletionScheduler) Start(ctx context.Context) {
+	s.Leader.Start(ctx, func(ctx context.Context) {
+		if purged, ran, err := s.RunOnce(ctx); err != nil {
+			log.Printf("Account deletion job failed: %v", err)
+		} else if ran && purged > 0 {
+			log.Printf("Account deletion job deleted %d accounts", purged)
+		}
+	})
+}
+
+// RunOnce deletes the due accounts while holding the leader lock, it returns how many were deleted.
+// Replicas that do not get the lock report ran as false.
+func (s *DeletionScheduler) RunOnce(ctx context.Context) (int, bool, error) {
+	var purged int
+	ran, err := s.Leader.Run(ctx, func(ctx context.Context) error {
+		// the accounts deleted are reported along with the ones that failed
+		var err error
+		purged, err = s.Deletions.PurgeDueAccounts(ctx, s.Clock.Now())
+		return err
+	})
+	return purged, ran, err
+}
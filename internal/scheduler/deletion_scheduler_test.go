@@ -0,0 +1,87 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker-api/internal/scheduler"
+	"workout-tracker-api/internal/service"
+)
+
+// MockAccountDeletionService is a mock implementation of service.AccountDeletionServiceInterface
+type MockAccountDeletionService struct {
+	mock.Mock
+}
+
+func (m *MockAccountDeletionService) RequestDeletion(ctx context.Context, userId int, input service.AccountDeletionRequest) (*service.ScheduledDeletion, error) {
+	args := m.Called(ctx, userId, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ScheduledDeletion), args.Error(1)
+}
+
+func (m *MockAccountDeletionService) CancelDeletion(ctx context.Context, input service.UserLogin) error {
+	args := m.Called(ctx, input)
+	return args.Error(0)
+}
+
+func (m *MockAccountDeletionService) PurgeDueAccounts(ctx context.Context, now time.Time) (int, error) {
+	args := m.Called(ctx, now)
+	return args.Int(0), args.Error(1)
+}
+
+func TestDeletionScheduler_RunOnce(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	config := scheduler.DeletionConfig{Interval: time.Hour}
+
+	t.Run("leader purges the due accounts", func(t *testing.T) {
+		mockDeletions := new(MockAccountDeletionService)
+		mockCache := new(MockCache)
+		s := scheduler.NewDeletionScheduler(mockDeletions, mockCache, &fakeClock{now: now}, config)
+
+		mockCache.On("LockCache", ctx, mock.Anything, mock.Anything, 30*time.Minute).Return(true, nil).Once()
+		mockCache.On("UnlockCache", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		mockDeletions.On("PurgeDueAccounts", ctx, now).Return(2, nil).Once()
+
+		purged, ran, err := s.RunOnce(ctx)
+		assert.NoError(t, err)
+		assert.True(t, ran)
+		assert.Equal(t, 2, purged)
+		mockDeletions.AssertExpectations(t)
+	})
+
+	t.Run("another replica holds the lock", func(t *testing.T) {
+		mockDeletions := new(MockAccountDeletionService)
+		mockCache := new(MockCache)
+		s := scheduler.NewDeletionScheduler(mockDeletions, mockCache, &fakeClock{now: now}, config)
+
+		mockCache.On("LockCache", ctx, mock.Anything, mock.Anything, 30*time.Minute).Return(false, nil).Once()
+
+		_, ran, err := s.RunOnce(ctx)
+		assert.NoError(t, err)
+		assert.False(t, ran)
+		mockDeletions.AssertNotCalled(t, "PurgeDueAccounts", mock.Anything, mock.Anything)
+	})
+
+	t.Run("purge error", func(t *testing.T) {
+		mockDeletions := new(MockAccountDeletionService)
+		mockCache := new(MockCache)
+		s := scheduler.NewDeletionScheduler(mockDeletions, mockCache, &fakeClock{now: now}, config)
+
+		mockCache.On("LockCache", ctx, mock.Anything, mock.Anything, 30*time.Minute).Return(true, nil).Once()
+		mockCache.On("UnlockCache", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		mockDeletions.On("PurgeDueAccounts", ctx, now).Return(1, errors.New("db down")).Once()
+
+		purged, ran, err := s.RunOnce(ctx)
+		assert.Error(t, err)
+		assert.True(t, ran)
+		assert.Equal(t, 1, purged)
+	})
+}
@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+	"workout-tracker-api/internal/cache"
+)
+
+// minLockTTL keeps a short interval from letting the lock expire while a run is still going
+const minLockTTL = time.Minute
+
+// LeaderJob runs a background job on one replica at a time, the one that takes the lock
+type LeaderJob struct {
+	LockKey  string
+	Locker   cache.CacheInterface
+	Interval time.Duration
+	Instance string
+}
+
+func NewLeaderJob(lockKey string, locker cache.CacheInterface, interval time.Duration) *LeaderJob {
+	return &LeaderJob{
+		LockKey:  lockKey,
+		Locker:   locker,
+		Interval: interval,
+		Instance: instanceName(),
+	}
+}
+
+// instanceName identifies the replica holding a leader lock
+func instanceName() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+// lockTTL only matters when the leader dies mid run, a finished run releases the lock
+func (j *LeaderJob) lockTTL() time.Duration {
+	return max(j.Interval/2, minLockTTL)
+}
+
+// Start calls tick right away and then on every interval until ctx is cancelled
+func (j *LeaderJob) Start(ctx context.Context, tick func(ctx context.Context)) {
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+
+	for {
+		tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run takes the lock, runs the job and releases the lock again. Replicas that do not get the lock
+// skip the job and report ran as false.
+func (j *LeaderJob) Run(ctx context.Context, job func(ctx context.Context) error) (bool, error) {
+	locked, err := j.Locker.LockCache(ctx, j.LockKey, j.Instance, j.lockTTL())
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lock '%s': %w", j.LockKey, err)
+	}
+	if !locked {
+		return false, nil
+	}
+
+	defer func() {
+		// a cancelled run still releases the lock, when that fails it expires on its own
+		if err := j.Locker.UnlockCache(context.WithoutCancel(ctx), j.LockKey, j.Instance); err != nil {
+			log.Printf("Failed to release lock '%s': %v", j.LockKey, err)
+		}
+	}()
+
+	return true, job(ctx)
+}
@@ -0,0 +1,107 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker-api/internal/scheduler"
+)
+
+func TestLeaderJob_Run(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("releases the lock after the run", func(t *testing.T) {
+		mockCache := new(MockCache)
+		job := scheduler.NewLeaderJob("scheduler:test:lock", mockCache, time.Hour)
+
+		mockCache.On("LockCache", ctx, "scheduler:test:lock", job.Instance, 30*time.Minute).Return(true, nil).Once()
+		mockCache.On("UnlockCache", mock.Anything, "scheduler:test:lock", job.Instance).Return(nil).Once()
+
+		calls := 0
+		ran, err := job.Run(ctx, func(ctx context.Context) error {
+			calls++
+			mockCache.AssertNotCalled(t, "UnlockCache", mock.Anything, mock.Anything, mock.Anything)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.True(t, ran)
+		assert.Equal(t, 1, calls)
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("releases the lock when the job fails", func(t *testing.T) {
+		mockCache := new(MockCache)
+		job := scheduler.NewLeaderJob("scheduler:test:lock", mockCache, time.Hour)
+		jobErr := errors.New("db down")
+
+		mockCache.On("LockCache", ctx, mock.Anything, mock.Anything, mock.Anything).Return(true, nil).Once()
+		mockCache.On("UnlockCache", mock.Anything, "scheduler:test:lock", job.Instance).Return(nil).Once()
+
+		ran, err := job.Run(ctx, func(ctx context.Context) error { return jobErr })
+		assert.ErrorIs(t, err, jobErr)
+		assert.True(t, ran)
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("releases the lock of a cancelled run", func(t *testing.T) {
+		mockCache := new(MockCache)
+		job := scheduler.NewLeaderJob("scheduler:test:lock", mockCache, time.Hour)
+		runCtx, cancel := context.WithCancel(ctx)
+
+		mockCache.On("LockCache", runCtx, mock.Anything, mock.Anything, mock.Anything).Return(true, nil).Once()
+		mockCache.On("UnlockCache", mock.MatchedBy(func(ctx context.Context) bool { return ctx.Err() == nil }), "scheduler:test:lock", job.Instance).
+			Return(nil).Once()
+
+		_, err := job.Run(runCtx, func(ctx context.Context) error {
+			cancel()
+			return ctx.Err()
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("short interval keeps the minimum lock ttl", func(t *testing.T) {
+		mockCache := new(MockCache)
+		job := scheduler.NewLeaderJob("scheduler:test:lock", mockCache, 10*time.Second)
+
+		mockCache.On("LockCache", ctx, mock.Anything, mock.Anything, time.Minute).Return(true, nil).Once()
+		mockCache.On("UnlockCache", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+
+		ran, err := job.Run(ctx, func(ctx context.Context) error { return nil })
+		assert.NoError(t, err)
+		assert.True(t, ran)
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("unlock error does not fail the run", func(t *testing.T) {
+		mockCache := new(MockCache)
+		job := scheduler.NewLeaderJob("scheduler:test:lock", mockCache, time.Hour)
+
+		mockCache.On("LockCache", ctx, mock.Anything, mock.Anything, mock.Anything).Return(true, nil).Once()
+		mockCache.On("UnlockCache", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("redis unavailable")).Once()
+
+		ran, err := job.Run(ctx, func(ctx context.Context) error { return nil })
+		assert.NoError(t, err)
+		assert.True(t, ran)
+	})
+
+	t.Run("another replica holds the lock", func(t *testing.T) {
+		mockCache := new(MockCache)
+		job := scheduler.NewLeaderJob("scheduler:test:lock", mockCache, time.Hour)
+
+		mockCache.On("LockCache", ctx, mock.Anything, mock.Anything, mock.Anything).Return(false, nil).Once()
+
+		ran, err := job.Run(ctx, func(ctx context.Context) error {
+			t.Fatal("job ran without the lock")
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.False(t, ran)
+		mockCache.AssertNotCalled(t, "UnlockCache", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,101 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+	"workout-tracker-api/internal/cache"
+	"workout-tracker-api/internal/repository"
+)
+
+const missedLockKey = "scheduler:missed-workouts:lock"
+
+// Clock lets tests control what "now" is
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func NewSystemClock() Clock {
+	return systemClock{}
+}
+
+type MissedConfig struct {
+	GracePeriod time.Duration
+	Interval    time.Duration
+}
+
+type MissedResult struct {
+	Cutoff time.Time
+	Marked int64
+}
+
+type MissedSchedulerInterface interface {
+	Start(ctx context.Context)
+	RunOnce(ctx context.Context) (*MissedResult, bool, error)
+	MarkMissed(ctx context.Context) (*MissedResult, error)
+}
+
+type MissedScheduler struct {
+	WPRepo repository.WorkoutRepository
+	Clock  Clock
+	Config MissedConfig
+	Leader *LeaderJob
+}
+
+func NewMissedScheduler(wr repository.WorkoutRepository, locker cache.CacheInterface, clock Clock, config MissedConfig) MissedSchedulerInterface {
+	return &MissedScheduler{
+		WPRepo: wr,
+		Clock:  clock,
+		Config: config,
+		Leader: NewLeaderJob(missedLockKey, locker, config.Interval),
+	}
+}
+
+// Start runs the job right away and then on every interval until ctx is cancelled
+func (s *MissedScheduler) Start(ctx context.Context) {
+	s.Leader.Start(ctx, func(ctx context.Context) {
+		if result, ran, err := s.RunOnce(ctx); err != nil {
+			log.Printf("Missed workout job failed: %v", err)
+		} else if ran && result.Marked > 0 {
+			log.Printf("Missed workout job marked %d workout plans scheduled before %s", result.Marked, result.Cutoff.Format(time.RFC3339))
+		}
+	})
+}
+
+// RunOnce marks overdue workouts while holding the leader lock. Replicas that do not get the lock
+// report ran as false
+func (s *MissedScheduler) RunOnce(ctx context.Context) (*MissedResult, bool, error) {
+	var result *MissedResult
+	ran, err := s.Leader.Run(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.MarkMissed(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, ran, err
+	}
+
+	return result, ran, nil
+}
+
+// MarkMissed moves pending workouts older than the grace period to missed. Running it twice is harmless
+func (s *MissedScheduler) MarkMissed(ctx context.Context) (*MissedResult, error) {
+	cutoff := s.Clock.Now().Add(-s.Config.GracePeriod)
+
+	marked, err := s.WPRepo.MarkOverdueAsMissed(ctx, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark overdue workouts as missed: %w", err)
+	}
+
+	return &MissedResult{
+		Cutoff: cutoff,
+		Marked: marked,
+	}, nil
+}
@@ -0,0 +1,249 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker-api/internal/repository"
+	"workout-tracker-api/internal/scheduler"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+type MockWorkoutRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorkoutRepository) CreateWorkout(ctx context.Context, data repository.CreateWP) (*repository.WorkoutPlan, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.WorkoutPlan), args.Error(1)
+}
+
+func (m *MockWorkoutRepository) GetWorkoutById(ctx context.Context, id int) (*repository.WorkoutPlan, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.WorkoutPlan), args.Error(1)
+}
+
+func (m *MockWorkoutRepository) UpdateWorkout(ctx context.Context, data repository.UpdateWP) (*repository.WorkoutPlan, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.WorkoutPlan), args.Error(1)
+}
+
+func (m *MockWorkoutRepository) DeleteWorkoutById(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWorkoutRepository) ListWorkoutsByStatus(ctx context.Context, userId int, status repository.WPStatus, asc bool) ([]repository.WorkoutPlan, error) {
+	args := m.Called(ctx, userId, status, asc)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.WorkoutPlan), args.Error(1)
+}
+
+func (m *MockWorkoutRepository) ListUserWorkouts(ctx context.Context, userId int) ([]repository.WorkoutPlan, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.WorkoutPlan), args.Error(1)
+}
+
+func (m *MockWorkoutRepository) ListWorkoutPage(ctx context.Context, filter repository.WorkoutPageFilter) ([]repository.WorkoutPlan, *repository.WorkoutCursor, error) {
+	args := m.Called(ctx, filter)
+	var next *repository.WorkoutCursor
+	if args.Get(1) != nil {
+		next = args.Get(1).(*repository.WorkoutCursor)
+	}
+	if args.Get(0) == nil {
+		return nil, next, args.Error(2)
+	}
+	return args.Get(0).([]repository.WorkoutPlan), next, args.Error(2)
+}
+
+func (m *MockWorkoutRepository) MarkOverdueAsMissed(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockCache struct {
+	mock.Mock
+}
+
+func (m *MockCache) SaveCache(ctx context.Context, key string, value string, expiration *time.Duration) error {
+	args := m.Called(ctx, key, value, expiration)
+	return args.Error(0)
+}
+
+func (m *MockCache) GetCache(ctx context.Context, key string) (string, error) {
+	args := m.Called(ctx, key)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockCache) ExistCache(ctx context.Context, key string) (bool, error) {
+	args := m.Called(ctx, key)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCache) CleanCache(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockCache) LockCache(ctx context.Context, key string, value string, expiration time.Duration) (bool, error) {
+	args := m.Called(ctx, key, value, expiration)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCache) UnlockCache(ctx context.Context, key string, value string) error {
+	args := m.Called(ctx, key, value)
+	return args.Error(0)
+}
+
+func (m *MockCache) IncrCache(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	args := m.Called(ctx, key, expiration)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestMarkMissed(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	config := scheduler.MissedConfig{GracePeriod: 24 * time.Hour, Interval: 10 * time.Minute}
+
+	t.Run("cutoff follows the clock and grace period", func(t *testing.T) {
+		mockRepo := new(MockWorkoutRepository)
+		s := scheduler.NewMissedScheduler(mockRepo, new(MockCache), &fakeClock{now: now}, config)
+
+		expectedCutoff := time.Date(2025, 3, 9, 12, 0, 0, 0, time.UTC)
+		mockRepo.On("MarkOverdueAsMissed", ctx, expectedCutoff).Return(int64(2), nil).Once()
+
+		result, err := s.MarkMissed(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedCutoff, result.Cutoff)
+		assert.Equal(t, int64(2), result.Marked)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo := new(MockWorkoutRepository)
+		s := scheduler.NewMissedScheduler(mockRepo, new(MockCache), &fakeClock{now: now}, config)
+
+		mockRepo.On("MarkOverdueAsMissed", ctx, mock.Anything).Return(int64(0), errors.New("db down")).Once()
+
+		result, err := s.MarkMissed(ctx)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "db down")
+		assert.Nil(t, result)
+
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestRunOnce(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	config := scheduler.MissedConfig{GracePeriod: time.Hour, Interval: 10 * time.Minute}
+
+	t.Run("leader marks overdue workouts", func(t *testing.T) {
+		mockRepo := new(MockWorkoutRepository)
+		mockCache := new(MockCache)
+		s := scheduler.NewMissedScheduler(mockRepo, mockCache, &fakeClock{now: now}, config)
+
+		mockCache.On("LockCache", ctx, mock.Anything, mock.Anything, 5*time.Minute).Return(true, nil).Once()
+		mockCache.On("UnlockCache", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		mockRepo.On("MarkOverdueAsMissed", ctx, now.Add(-time.Hour)).Return(int64(1), nil).Once()
+
+		result, ran, err := s.RunOnce(ctx)
+		assert.NoError(t, err)
+		assert.True(t, ran)
+		assert.Equal(t, int64(1), result.Marked)
+
+		mockCache.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("another replica holds the lock", func(t *testing.T) {
+		mockRepo := new(MockWorkoutRepository)
+		mockCache := new(MockCache)
+		s := scheduler.NewMissedScheduler(mockRepo, mockCache, &fakeClock{now: now}, config)
+
+		mockCache.On("LockCache", ctx, mock.Anything, mock.Anything, 5*time.Minute).Return(false, nil).Once()
+
+		result, ran, err := s.RunOnce(ctx)
+		assert.NoError(t, err)
+		assert.False(t, ran)
+		assert.Nil(t, result)
+
+		mockCache.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "MarkOverdueAsMissed", mock.Anything, mock.Anything)
+	})
+
+	t.Run("lock error skips the run", func(t *testing.T) {
+		mockRepo := new(MockWorkoutRepository)
+		mockCache := new(MockCache)
+		s := scheduler.NewMissedScheduler(mockRepo, mockCache, &fakeClock{now: now}, config)
+
+		mockCache.On("LockCache", ctx, mock.Anything, mock.Anything, 5*time.Minute).Return(false, errors.New("redis unavailable")).Once()
+
+		result, ran, err := s.RunOnce(ctx)
+		assert.Error(t, err)
+		assert.False(t, ran)
+		assert.Nil(t, result)
+
+		mockRepo.AssertNotCalled(t, "MarkOverdueAsMissed", mock.Anything, mock.Anything)
+	})
+}
+
+func TestStart(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	config := scheduler.MissedConfig{GracePeriod: time.Hour, Interval: time.Hour}
+
+	t.Run("runs immediately and stops on cancel", func(t *testing.T) {
+		mockRepo := new(MockWorkoutRepository)
+		mockCache := new(MockCache)
+		s := scheduler.NewMissedScheduler(mockRepo, mockCache, &fakeClock{now: now}, config)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		mockCache.On("LockCache", ctx, mock.Anything, mock.Anything, 30*time.Minute).Return(true, nil).Once()
+		mockCache.On("UnlockCache", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		mockRepo.On("MarkOverdueAsMissed", ctx, now.Add(-time.Hour)).Return(int64(0), nil).Once().
+			Run(func(args mock.Arguments) { cancel() })
+
+		done := make(chan struct{})
+		go func() {
+			s.Start(ctx)
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("scheduler did not stop after the context was cancelled")
+		}
+
+		mockCache.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+}
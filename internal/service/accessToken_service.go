@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+)
+
+// AccessTokenPrefix starts every personal access token, it tells them apart from JWTs and makes
+// leaked tokens easy to search for
+const AccessTokenPrefix = "wtp_"
+
+// MaxAccessTokenNameLength matches the column of the token name
+const MaxAccessTokenNameLength = 100
+
+// Scope is a permission of a personal access token. Login sessions are not limited by scopes.
+type Scope string
+
+const (
+	ScopeWorkoutsRead   Scope = "workouts:read"
+	ScopeWorkoutsWrite  Scope = "workouts:write"
+	ScopeExercisesRead  Scope = "exercises:read"
+	ScopeExercisesWrite Scope = "exercises:write"
+	ScopeReportsRead    Scope = "reports:read"
+)
+
+func (s Scope) IsValid() bool {
+	switch s {
+	case ScopeWorkoutsRead, ScopeWorkoutsWrite, ScopeExercisesRead, ScopeExercisesWrite, ScopeReportsRead:
+		return true
+	}
+	return false
+}
+
+type AccessToken struct {
+	Id         int        `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []Scope    `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// CreatedAccessToken carries the token itself, it is only known when the token is created
+type CreatedAccessToken struct {
+	AccessToken
+	Token string `json:"token"`
+}
+
+type AccessTokenCreate struct {
+	UserId    int        `json:"userId"`
+	Name      string     `json:"name"`
+	Scopes    []Scope    `json:"scopes"`
+	ExpiresAt *time.Time `json:"expiresAt"` // never expires when not set
+}
+
+func (data *AccessTokenCreate) Validate() error {
+	if data.ExpiresAt != nil && !data.ExpiresAt.After(time.Now()) {
+		return apperrors.NewValidationError(apperrors.INVALID_DATE, "expiration has to be in the future")
+	}
+	return validateAccessToken(data.Name, data.Scopes)
+}
+
+type AccessTokenUpdate struct {
+	Id     int     `json:"id"`
+	UserId int     `json:"userId"`
+	Name   string  `json:"name"`
+	Scopes []Scope `json:"scopes"`
+}
+
+func (data *AccessTokenUpdate) Validate() error {
+	return validateAccessToken(data.Name, data.Scopes)
+}
+
+func validateAccessToken(name string, scopes []Scope) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return apperrors.NewValidationError(apperrors.INVALID_NAME, "token name can not be empty")
+	}
+	if len(name) > MaxAccessTokenNameLength {
+		return apperrors.NewValidationError(apperrors.INVALID_NAME, fmt.Sprintf("token name can not be longer than %d", MaxAccessTokenNameLength))
+	}
+
+	if len(scopes) == 0 {
+		return apperrors.NewValidationError(apperrors.INVALID_SCOPE, "token needs at least one scope")
+	}
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return apperrors.NewValidationError(apperrors.INVALID_SCOPE, fmt.Sprintf("unknown scope '%s'", scope))
+		}
+	}
+
+	return nil
+}
+
+// AccessTokenOwner is who a personal access token acts for, and what it may do
+type AccessTokenOwner struct {
+	User   User
+	Scopes []Scope
+}
+
+type AccessTokenServiceInterface interface {
+	CreateToken(ctx context.Context, data AccessTokenCreate) (*CreatedAccessToken, error)
+	ListTokens(ctx context.Context, userId int) ([]AccessToken, error)
+	GetToken(ctx context.Context, userId int, id int) (*AccessToken, error)
+	UpdateToken(ctx context.Context, data AccessTokenUpdate) (*AccessToken, error)
+	DeleteToken(ctx context.Context, userId int, id int) error
+	Authenticate(ctx context.Context, token string) (*AccessTokenOwner, error)
+}
+
+type AccessTokenService struct {
+	TokenRepo repository.AccessTokenRepository
+	UserRepo  repository.UserRepository
+}
+
+func NewAccessTokenService(tr repository.AccessTokenRepository, ur repository.UserRepository) AccessTokenServiceInterface {
+	return &AccessTokenService{
+		TokenRepo: tr,
+		UserRepo:  ur,
+	}
+}
+
+// IsAccessToken tells whether a bearer token is a personal access token rather than a JWT
+func IsAccessToken(token string) bool {
+	return strings.HasPrefix(token, AccessTokenPrefix)
+}
+
+// CreateToken returns the token only this once, afterwards just its hash is known
+func (s *AccessTokenService) CreateToken(ctx context.Context, data AccessTokenCreate) (*CreatedAccessToken, error) {
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+
+	secret, err := newOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+	token := AccessTokenPrefix + secret
+
+	var expiresAt sql.NullTime
+	if data.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: data.ExpiresAt.UTC(), Valid: true}
+	}
+
+	created, err := s.TokenRepo.CreateAccessToken(ctx, repository.CreateAccessToken{
+		UserId:    data.UserId,
+		Name:      strings.TrimSpace(data.Name),
+		TokenHash: hashToken(token),
+		Scopes:    scopeStrings(data.Scopes),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save access token: %w", err)
+	}
+
+	return &CreatedAccessToken{
+		AccessToken: *toServiceAccessToken(created),
+		Token:       token,
+	}, nil
+}
+
+func (s *AccessTokenService) ListTokens(ctx context.Context, userId int) ([]AccessToken, error) {
+	stored, err := s.TokenRepo.ListAccessTokens(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch access tokens: %w", err)
+	}
+
+	tokens := []AccessToken{}
+	for _, at := range stored {
+		tokens = append(tokens, *toServiceAccessToken(&at))
+	}
+
+	return tokens, nil
+}
+
+func (s *AccessTokenService) GetToken(ctx context.Context, userId int, id int) (*AccessToken, error) {
+	stored, err := s.TokenRepo.GetAccessToken(ctx, userId, id)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to fetch access token: %w", err)
+	}
+
+	return toServiceAccessToken(stored), nil
+}
+
+// UpdateToken renames a token or changes its scopes, the token itself stays the same
+func (s *AccessTokenService) UpdateToken(ctx context.Context, data AccessTokenUpdate) (*AccessToken, error) {
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.TokenRepo.UpdateAccessToken(ctx, repository.UpdateAccessToken{
+		Id:     data.Id,
+		UserId: data.UserId,
+		Name:   strings.TrimSpace(data.Name),
+		Scopes: scopeStrings(data.Scopes),
+	})
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update access token: %w", err)
+	}
+
+	return toServiceAccessToken(updated), nil
+}
+
+func (s *AccessTokenService) DeleteToken(ctx context.Context, userId int, id int) error {
+	if err := s.TokenRepo.DeleteAccessToken(ctx, userId, id); err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete access token: %w", err)
+	}
+	return nil
+}
+
+// Authenticate returns the owner of a valid token. Unknown and expired tokens and tokens of
+// disabled users all give ErrUnauthorized.
+func (s *AccessTokenService) Authenticate(ctx context.Context, token string) (*AccessTokenOwner, error) {
+	stored, err := s.TokenRepo.GetAccessTokenByHash(ctx, hashToken(token))
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, apperrors.ErrUnauthorized
+		}
+		return nil, fmt.Errorf("failed to fetch access token: %w", err)
+	}
+
+	if stored.ExpiresAt.Valid && !stored.ExpiresAt.Time.After(time.Now()) {
+		return nil, apperrors.ErrUnauthorized
+	}
+
+	fetchedUser, err := s.UserRepo.GetUserById(ctx, stored.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user of access token: %w", err)
+	}
+	if !canLogIn(fetchedUser) {
+		return nil, apperrors.ErrUnauthorized
+	}
+
+	// last used is informative, a failed write must not refuse the request
+	if err := s.TokenRepo.TouchAccessToken(ctx, stored.Id); err != nil {
+		log.Printf("Failed to record use of access token %d: %v", stored.Id, err)
+	}
+
+	return &AccessTokenOwner{
+		User:   *toServiceUser(fetchedUser),
+		Scopes: toScopes(stored.Scopes),
+	}, nil
+}
+
+// scopeStrings stores each scope once, in a stable order
+func scopeStrings(scopes []Scope) []string {
+	values := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		values = append(values, string(scope))
+	}
+	slices.Sort(values)
+	return slices.Compact(values)
+}
+
+func toScopes(values []string) []Scope {
+	scopes := make([]Scope, 0, len(values))
+	for _, value := range values {
+		scopes = append(scopes, Scope(value))
+	}
+	return scopes
+}
+
+func toServiceAccessToken(at *repository.AccessToken) *AccessToken {
+	if at == nil {
+		return nil
+	}
+
+	token := &AccessToken{
+		Id:        at.Id,
+		Name:      at.Name,
+		Scopes:    toScopes(at.Scopes),
+		CreatedAt: at.CreatedAt,
+	}
+	if at.ExpiresAt.Valid {
+		token.ExpiresAt = &at.ExpiresAt.Time
+	}
+	if at.LastUsedAt.Valid {
+		token.LastUsedAt = &at.LastUsedAt.Time
+	}
+
+	return token
+}
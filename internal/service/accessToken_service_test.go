@@ -0,0 +1,246 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+	"workout-tracker-api/internal/service"
+)
+
+// MockAccessTokenRepository is a mock implementation of repository.AccessTokenRepository
+type MockAccessTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockAccessTokenRepository) CreateAccessToken(ctx context.Context, data repository.CreateAccessToken) (*repository.AccessToken, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.AccessToken), args.Error(1)
+}
+
+func (m *MockAccessTokenRepository) ListAccessTokens(ctx context.Context, userId int) ([]repository.AccessToken, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.AccessToken), args.Error(1)
+}
+
+func (m *MockAccessTokenRepository) GetAccessToken(ctx context.Context, userId int, id int) (*repository.AccessToken, error) {
+	args := m.Called(ctx, userId, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.AccessToken), args.Error(1)
+}
+
+func (m *MockAccessTokenRepository) GetAccessTokenByHash(ctx context.Context, tokenHash string) (*repository.AccessToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.AccessToken), args.Error(1)
+}
+
+func (m *MockAccessTokenRepository) UpdateAccessToken(ctx context.Context, data repository.UpdateAccessToken) (*repository.AccessToken, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.AccessToken), args.Error(1)
+}
+
+func (m *MockAccessTokenRepository) DeleteAccessToken(ctx context.Context, userId int, id int) error {
+	args := m.Called(ctx, userId, id)
+	return args.Error(0)
+}
+
+func (m *MockAccessTokenRepository) DeleteUserAccessTokens(ctx context.Context, userId int) (int64, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAccessTokenRepository) TouchAccessToken(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestAccessTokenService_CreateToken(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Stores the hash and returns the token once", func(t *testing.T) {
+		mockTokenRepo := new(MockAccessTokenRepository)
+		tokenService := service.NewAccessTokenService(mockTokenRepo, nil)
+		expiresAt := time.Now().Add(30 * 24 * time.Hour)
+
+		var saved repository.CreateAccessToken
+		mockTokenRepo.On("CreateAccessToken", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(repository.CreateAccessToken) }).
+			Return(&repository.AccessToken{
+				Id:        1,
+				UserId:    5,
+				Name:      "sync script",
+				Scopes:    []string{"workouts:read", "workouts:write"},
+				ExpiresAt: sql.NullTime{Time: expiresAt, Valid: true},
+			}, nil).Once()
+
+		created, err := tokenService.CreateToken(ctx, service.AccessTokenCreate{
+			UserId:    5,
+			Name:      " sync script ",
+			Scopes:    []service.Scope{service.ScopeWorkoutsWrite, service.ScopeWorkoutsRead, service.ScopeWorkoutsWrite},
+			ExpiresAt: &expiresAt,
+		})
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(created.Token, service.AccessTokenPrefix))
+		assert.True(t, service.IsAccessToken(created.Token))
+		assert.Equal(t, 1, created.Id)
+		assert.Equal(t, []service.Scope{service.ScopeWorkoutsRead, service.ScopeWorkoutsWrite}, created.Scopes)
+
+		assert.Equal(t, "sync script", saved.Name)
+		assert.Equal(t, []string{"workouts:read", "workouts:write"}, saved.Scopes)
+		assert.Len(t, saved.TokenHash, 64)
+		assert.NotContains(t, saved.TokenHash, created.Token)
+		assert.True(t, saved.ExpiresAt.Valid)
+	})
+
+	t.Run("Unknown scope", func(t *testing.T) {
+		mockTokenRepo := new(MockAccessTokenRepository)
+		tokenService := service.NewAccessTokenService(mockTokenRepo, nil)
+
+		_, err := tokenService.CreateToken(ctx, service.AccessTokenCreate{UserId: 5, Name: "script", Scopes: []service.Scope{"admin:all"}})
+		var validationErr *apperrors.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, apperrors.INVALID_SCOPE, validationErr.Field)
+		mockTokenRepo.AssertNotCalled(t, "CreateAccessToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Expiration in the past", func(t *testing.T) {
+		tokenService := service.NewAccessTokenService(new(MockAccessTokenRepository), nil)
+		expiresAt := time.Now().Add(-time.Hour)
+
+		_, err := tokenService.CreateToken(ctx, service.AccessTokenCreate{UserId: 5, Name: "script", Scopes: []service.Scope{service.ScopeReportsRead}, ExpiresAt: &expiresAt})
+		var validationErr *apperrors.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, apperrors.INVALID_DATE, validationErr.Field)
+	})
+
+	t.Run("Missing name", func(t *testing.T) {
+		tokenService := service.NewAccessTokenService(new(MockAccessTokenRepository), nil)
+
+		_, err := tokenService.CreateToken(ctx, service.AccessTokenCreate{UserId: 5, Name: "  ", Scopes: []service.Scope{service.ScopeReportsRead}})
+		var validationErr *apperrors.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, apperrors.INVALID_NAME, validationErr.Field)
+	})
+}
+
+func TestAccessTokenService_UpdateToken(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Changes name and scopes", func(t *testing.T) {
+		mockTokenRepo := new(MockAccessTokenRepository)
+		tokenService := service.NewAccessTokenService(mockTokenRepo, nil)
+
+		mockTokenRepo.On("UpdateAccessToken", ctx, repository.UpdateAccessToken{Id: 1, UserId: 5, Name: "read only", Scopes: []string{"workouts:read"}}).
+			Return(&repository.AccessToken{Id: 1, UserId: 5, Name: "read only", Scopes: []string{"workouts:read"}}, nil).Once()
+
+		updated, err := tokenService.UpdateToken(ctx, service.AccessTokenUpdate{Id: 1, UserId: 5, Name: "read only", Scopes: []service.Scope{service.ScopeWorkoutsRead}})
+		assert.NoError(t, err)
+		assert.Equal(t, "read only", updated.Name)
+		assert.Nil(t, updated.ExpiresAt)
+	})
+
+	t.Run("Token of another user", func(t *testing.T) {
+		mockTokenRepo := new(MockAccessTokenRepository)
+		tokenService := service.NewAccessTokenService(mockTokenRepo, nil)
+
+		mockTokenRepo.On("UpdateAccessToken", ctx, mock.Anything).Return(nil, apperrors.ErrNotFound).Once()
+
+		_, err := tokenService.UpdateToken(ctx, service.AccessTokenUpdate{Id: 1, UserId: 6, Name: "mine", Scopes: []service.Scope{service.ScopeWorkoutsRead}})
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+}
+
+func TestAccessTokenService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	token := service.AccessTokenPrefix + "secret"
+
+	newService := func() (service.AccessTokenServiceInterface, *MockAccessTokenRepository, *MockUserRepository) {
+		mockTokenRepo := new(MockAccessTokenRepository)
+		mockUserRepo := new(MockUserRepository)
+		return service.NewAccessTokenService(mockTokenRepo, mockUserRepo), mockTokenRepo, mockUserRepo
+	}
+
+	t.Run("Valid token", func(t *testing.T) {
+		tokenService, mockTokenRepo, mockUserRepo := newService()
+
+		mockTokenRepo.On("GetAccessTokenByHash", ctx, sha256Hex(token)).
+			Return(&repository.AccessToken{Id: 1, UserId: 5, Scopes: []string{"reports:read"}}, nil).Once()
+		mockUserRepo.On("GetUserById", ctx, 5).Return(&repository.User{Id: 5, Email: "user@example.com", Role: repository.RoleUser}, nil).Once()
+		mockTokenRepo.On("TouchAccessToken", ctx, 1).Return(nil).Once()
+
+		owner, err := tokenService.Authenticate(ctx, token)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, owner.User.Id)
+		assert.Equal(t, []service.Scope{service.ScopeReportsRead}, owner.Scopes)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed touch does not refuse the token", func(t *testing.T) {
+		tokenService, mockTokenRepo, mockUserRepo := newService()
+
+		mockTokenRepo.On("GetAccessTokenByHash", ctx, sha256Hex(token)).
+			Return(&repository.AccessToken{Id: 1, UserId: 5, Scopes: []string{"reports:read"}}, nil).Once()
+		mockUserRepo.On("GetUserById", ctx, 5).Return(&repository.User{Id: 5}, nil).Once()
+		mockTokenRepo.On("TouchAccessToken", ctx, 1).Return(errors.New("update failed")).Once()
+
+		owner, err := tokenService.Authenticate(ctx, token)
+		assert.NoError(t, err)
+		assert.NotNil(t, owner)
+	})
+
+	t.Run("Unknown token", func(t *testing.T) {
+		tokenService, mockTokenRepo, _ := newService()
+
+		mockTokenRepo.On("GetAccessTokenByHash", ctx, sha256Hex(token)).Return(nil, apperrors.ErrNotFound).Once()
+
+		owner, err := tokenService.Authenticate(ctx, token)
+		assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+		assert.Nil(t, owner)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		tokenService, mockTokenRepo, mockUserRepo := newService()
+
+		mockTokenRepo.On("GetAccessTokenByHash", ctx, sha256Hex(token)).Return(&repository.AccessToken{
+			Id: 1, UserId: 5, Scopes: []string{"reports:read"}, ExpiresAt: sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true},
+		}, nil).Once()
+
+		_, err := tokenService.Authenticate(ctx, token)
+		assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+		mockUserRepo.AssertNotCalled(t, "GetUserById", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Disabled user", func(t *testing.T) {
+		tokenService, mockTokenRepo, mockUserRepo := newService()
+
+		mockTokenRepo.On("GetAccessTokenByHash", ctx, sha256Hex(token)).
+			Return(&repository.AccessToken{Id: 1, UserId: 5, Scopes: []string{"reports:read"}}, nil).Once()
+		mockUserRepo.On("GetUserById", ctx, 5).Return(&repository.User{Id: 5, DisabledAt: sql.NullTime{Time: time.Now(), Valid: true}}, nil).Once()
+
+		_, err := tokenService.Authenticate(ctx, token)
+		assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+		mockTokenRepo.AssertNotCalled(t, "TouchAccessToken", mock.Anything, mock.Anything)
+	})
+}
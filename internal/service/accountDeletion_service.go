@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+	"workout-tracker-api/internal/util/encrypt"
+)
+
+// audit events written around account deletion
+const (
+	AuditDeletionRequested = "account.deletion_requested"
+	AuditDeletionCancelled = "account.deletion_cancelled"
+	AuditAccountDeleted    = "account.deleted"
+)
+
+// purgeBatchSize is how many due accounts PurgeDueAccounts loads at a time
+const purgeBatchSize = 100
+
+// AccountDeletionConfig sets how long a deleted account can still be recovered
+type AccountDeletionConfig struct {
+	GracePeriod time.Duration
+}
+
+// AccountDeletionRequest re-authenticates the user, Code is needed when two-factor authentication
+// is enabled
+type AccountDeletionRequest struct {
+	Password  string `json:"password"`
+	Code      string `json:"code"`
+	IPAddress string `json:"-"`
+}
+
+// ScheduledDeletion is when the account gets deleted and the sessions that were revoked for it
+type ScheduledDeletion struct {
+	DeleteAt        time.Time
+	RevokedSessions []string
+}
+
+type AccountDeletionServiceInterface interface {
+	RequestDeletion(ctx context.Context, userId int, input AccountDeletionRequest) (*ScheduledDeletion, error)
+	CancelDeletion(ctx context.Context, input UserLogin) error
+	PurgeDueAccounts(ctx context.Context, now time.Time) (int, error)
+}
+
+type AccountDeletionService struct {
+	UserRepo         repository.UserRepository
+	TokenRepo        repository.AccessTokenRepository
+	AuditRepo        repository.AuditRepository
+	UoW              repository.UnitOfWork
+	SessionService   SessionServiceInterface
+	TwoFactorService TwoFactorServiceInterface
+	LoginGuard       LoginGuardInterface
+	Hash             encrypt.HashHelperInterface
+	Config           AccountDeletionConfig
+}
+
+func NewAccountDeletionService(ur repository.UserRepository, tr repository.AccessTokenRepository, aur repository.AuditRepository, uow repository.UnitOfWork, ss SessionServiceInterface, tfs TwoFactorServiceInterface, lg LoginGuardInterface, h encrypt.HashHelperInterface, cfg AccountDeletionConfig) AccountDeletionServiceInterface {
+	return &AccountDeletionService{
+		UserRepo:         ur,
+		TokenRepo:        tr,
+		AuditRepo:        aur,
+		UoW:              uow,
+		SessionService:   ss,
+		TwoFactorService: tfs,
+		LoginGuard:       lg,
+		Hash:             h,
+		Config:           cfg,
+	}
+}
+
+// RequestDeletion schedules the account for deletion after the grace period. The password, and
+// the second factor when it is on, are checked again so a stolen access token alone cannot do it.
+// The account stops working right away: every session is revoked and the personal access tokens
+// are deleted. The revoked session ids are returned so their access tokens can be revoked too.
+func (s *AccountDeletionService) RequestDeletion(ctx context.Context, userId int, input AccountDeletionRequest) (*ScheduledDeletion, error) {
+	fetchedUser, err := s.UserRepo.GetUserById(ctx, userId)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	attempt := LoginAttempt{Email: fetchedUser.Email, IPAddress: input.IPAddress}
+	authenticated, err := authenticate(ctx, s.LoginGuard, s.Hash, attempt, input.Password, func() (*repository.User, error) {
+		return fetchedUser, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if authenticated == nil {
+		return nil, apperrors.NewValidationError(apperrors.INVALID_PASSWORD, "password is incorrect")
+	}
+
+	enabled, err := s.TwoFactorService.IsEnabled(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check two-factor authentication: %w", err)
+	}
+	if enabled {
+		if input.Code == "" {
+			return nil, apperrors.NewValidationError(apperrors.INVALID_CODE, "two-factor code is required")
+		}
+		if err := s.TwoFactorService.VerifyCode(ctx, userId, input.Code); err != nil {
+			return nil, err
+		}
+	}
+
+	deleteAt := time.Now().UTC().Add(s.Config.GracePeriod)
+	var revoked []string
+	err = s.UoW.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.UserRepo.ScheduleUserDeletion(txCtx, userId, deleteAt); err != nil {
+			return err
+		}
+		if _, err := s.TokenRepo.DeleteUserAccessTokens(txCtx, userId); err != nil {
+			return err
+		}
+
+		var err error
+		revoked, err = s.SessionService.RevokeAllSessions(txCtx, userId)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to schedule account deletion: %w", err)
+	}
+
+	s.audit(ctx, repository.CreateAuditEntry{
+		Event:     AuditDeletionRequested,
+		UserId:    &userId,
+		ActorId:   &userId,
+		IPAddress: input.IPAddress,
+		Detail:    "deletion due at " + deleteAt.Format(time.RFC3339),
+	})
+
+	return &ScheduledDeletion{
+		DeleteAt:        deleteAt,
+		RevokedSessions: revoked,
+	}, nil
+}
+
+// CancelDeletion keeps the account during the grace period. The user can not log in while the
+// deletion is pending, so it takes the credentials instead of an access token and goes through the
+// login guard like a login. ErrNotFound means there is no deletion left to cancel.
+func (s *AccountDeletionService) CancelDeletion(ctx context.Context, input UserLogin) error {
+	attempt := LoginAttempt{Email: input.Email, IPAddress: input.IPAddress}
+	fetchedUser, err := authenticate(ctx, s.LoginGuard, s.Hash, attempt, input.Password, func() (*repository.User, error) {
+		return s.UserRepo.GetUserByEmail(ctx, input.Email)
+	})
+	if err != nil {
+		return err
+	}
+	if fetchedUser == nil {
+		return apperrors.NewValidationError(apperrors.INVALID_CREDENTIALS, "invalid email or password")
+	}
+
+	if !fetchedUser.DeletionDueAt.Valid {
+		return fmt.Errorf("%w: account is not scheduled for deletion", apperrors.ErrNotFound)
+	}
+
+	if err := s.UserRepo.CancelUserDeletion(ctx, fetchedUser.Id); err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return fmt.Errorf("%w: the grace period is over", apperrors.ErrNotFound)
+		}
+		return fmt.Errorf("failed to cancel account deletion: %w", err)
+	}
+
+	s.audit(ctx, repository.CreateAuditEntry{
+		Event:     AuditDeletionCancelled,
+		UserId:    &fetchedUser.Id,
+		ActorId:   &fetchedUser.Id,
+		IPAddress: input.IPAddress,
+	})
+
+	return nil
+}
+
+// PurgeDueAccounts deletes every account whose grace period is over at now, each with its data in
+// one transaction, and returns how many were deleted. An account that fails is logged and skipped,
+// so it can not hold up the others; it is retried by the next run.
+func (s *AccountDeletionService) PurgeDueAccounts(ctx context.Context, now time.Time) (int, error) {
+	purged, failed, afterId := 0, 0, 0
+	for {
+		ids, err := s.UserRepo.ListUsersDueForDeletion(ctx, now, afterId, purgeBatchSize)
+		if err != nil {
+			return purged, fmt.Errorf("failed to list accounts due for deletion: %w", err)
+		}
+
+		for _, id := range ids {
+			afterId = id
+			deleted, err := s.UserRepo.DeleteDueUser(ctx, id, now)
+			if err != nil {
+				failed++
+				log.Printf("Failed to delete account of user id '%v': %v", id, err)
+				continue
+			}
+			// cancelled since it was listed
+			if !deleted {
+				continue
+			}
+
+			purged++
+			// the user row is gone, the id only survives in the detail
+			s.audit(ctx, repository.CreateAuditEntry{
+				Event:  AuditAccountDeleted,
+				Detail: fmt.Sprintf("user id %d deleted", id),
+			})
+		}
+
+		if len(ids) < purgeBatchSize {
+			break
+		}
+	}
+
+	if failed > 0 {
+		return purged, fmt.Errorf("failed to delete %d of the accounts due for deletion", failed)
+	}
+	return purged, nil
+}
+
+// audit failures are logged, the change they describe is already committed
+func (s *AccountDeletionService) audit(ctx context.Context, entry repository.CreateAuditEntry) {
+	if err := s.AuditRepo.CreateAuditEntry(ctx, entry); err != nil {
+		log.Printf("Failed to write audit entry: %v", err)
+	}
+}
@@ -0,0 +1,250 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+	"workout-tracker-api/internal/service"
+)
+
+var deletionConfig = service.AccountDeletionConfig{GracePeriod: 30 * 24 * time.Hour}
+
+type deletionMocks struct {
+	userRepo      *MockUserRepository
+	tokenRepo     *MockAccessTokenRepository
+	auditRepo     *MockAuditRepository
+	uow           *MockUnitOfWork
+	sessions      *MockUserSessionService
+	twoFactorRepo *MockTwoFactorRepository
+	hash          *MockHashHelper
+}
+
+func newDeletionService(guard *MockLoginGuard) (service.AccountDeletionServiceInterface, *deletionMocks) {
+	m := &deletionMocks{
+		userRepo:      new(MockUserRepository),
+		tokenRepo:     new(MockAccessTokenRepository),
+		auditRepo:     new(MockAuditRepository),
+		uow:           new(MockUnitOfWork),
+		sessions:      new(MockUserSessionService),
+		twoFactorRepo: new(MockTwoFactorRepository),
+		hash:          new(MockHashHelper),
+	}
+	twoFactorService := service.NewTwoFactorService(m.twoFactorRepo, m.userRepo, m.uow, m.hash, nil, guard, twoFactorConfig)
+	deletionService := service.NewAccountDeletionService(m.userRepo, m.tokenRepo, m.auditRepo, m.uow, m.sessions, twoFactorService, guard, m.hash, deletionConfig)
+	return deletionService, m
+}
+
+func TestAccountDeletionService_RequestDeletion(t *testing.T) {
+	ctx := context.Background()
+	stored := &repository.User{Id: 3, Email: "test@example.com", PasswordHash: "hash"}
+	input := service.AccountDeletionRequest{Password: "password123", IPAddress: "203.0.113.7"}
+
+	t.Run("Schedules the deletion and ends every session and token", func(t *testing.T) {
+		deletionService, m := newDeletionService(openLoginGuard())
+
+		m.userRepo.On("GetUserById", ctx, 3).Return(stored, nil).Once()
+		m.hash.On("CheckPasswordHash", "hash", "password123").Return(true).Once()
+		m.twoFactorRepo.On("GetTOTP", ctx, 3).Return(nil, apperrors.ErrNotFound).Once()
+		m.userRepo.On("ScheduleUserDeletion", ctx, 3, mock.MatchedBy(func(at time.Time) bool {
+			return at.Sub(time.Now().Add(deletionConfig.GracePeriod)).Abs() < time.Minute
+		})).Return(nil).Once()
+		m.tokenRepo.On("DeleteUserAccessTokens", ctx, 3).Return(int64(2), nil).Once()
+		m.sessions.On("RevokeAllSessions", ctx, 3).Return([]string{"session-1", "session-2"}, nil).Once()
+		m.auditRepo.On("CreateAuditEntry", ctx, mock.MatchedBy(func(e repository.CreateAuditEntry) bool {
+			return e.Event == service.AuditDeletionRequested && *e.UserId == 3 && e.IPAddress == "203.0.113.7"
+		})).Return(nil).Once()
+
+		scheduled, err := deletionService.RequestDeletion(ctx, 3, input)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"session-1", "session-2"}, scheduled.RevokedSessions)
+		assert.WithinDuration(t, time.Now().Add(deletionConfig.GracePeriod), scheduled.DeleteAt, time.Minute)
+		assert.Equal(t, 1, m.uow.Calls)
+		m.userRepo.AssertExpectations(t)
+		m.tokenRepo.AssertExpectations(t)
+		m.auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("Wrong password counts as a failed login", func(t *testing.T) {
+		guard := new(MockLoginGuard)
+		deletionService, m := newDeletionService(guard)
+
+		m.userRepo.On("GetUserById", ctx, 3).Return(stored, nil).Once()
+		guard.On("CheckLogin", ctx, service.LoginAttempt{Email: "test@example.com", IPAddress: "203.0.113.7"}).Return(nil).Once()
+		m.hash.On("CheckPasswordHash", "hash", "password123").Return(false).Once()
+		guard.On("RecordFailure", ctx, service.LoginAttempt{Email: "test@example.com", IPAddress: "203.0.113.7", UserId: 3}).Return(nil).Once()
+
+		scheduled, err := deletionService.RequestDeletion(ctx, 3, input)
+		var validationErr *apperrors.ValidationError
+		if assert.ErrorAs(t, err, &validationErr) {
+			assert.Equal(t, apperrors.INVALID_PASSWORD, validationErr.Field)
+		}
+		assert.Nil(t, scheduled)
+		guard.AssertExpectations(t)
+		m.userRepo.AssertNotCalled(t, "ScheduleUserDeletion", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Two-factor code is required when enabled", func(t *testing.T) {
+		deletionService, m := newDeletionService(openLoginGuard())
+
+		m.userRepo.On("GetUserById", ctx, 3).Return(stored, nil).Once()
+		m.hash.On("CheckPasswordHash", "hash", "password123").Return(true).Once()
+		m.twoFactorRepo.On("GetTOTP", ctx, 3).Return(confirmedTOTP(), nil).Once()
+
+		_, err := deletionService.RequestDeletion(ctx, 3, input)
+		var validationErr *apperrors.ValidationError
+		if assert.ErrorAs(t, err, &validationErr) {
+			assert.Equal(t, apperrors.INVALID_CODE, validationErr.Field)
+		}
+		assert.Equal(t, 0, m.uow.Calls)
+	})
+
+	t.Run("Two-factor code is checked", func(t *testing.T) {
+		deletionService, m := newDeletionService(openLoginGuard())
+		code, step := currentTOTPCode(t)
+
+		m.userRepo.On("GetUserById", ctx, 3).Return(stored, nil).Once()
+		m.hash.On("CheckPasswordHash", "hash", "password123").Return(true).Once()
+		m.twoFactorRepo.On("GetTOTP", ctx, 3).Return(confirmedTOTP(), nil).Twice()
+		m.twoFactorRepo.On("UseTOTPStep", ctx, 3, step).Return(true, nil).Once()
+		m.userRepo.On("ScheduleUserDeletion", ctx, 3, mock.Anything).Return(nil).Once()
+		m.tokenRepo.On("DeleteUserAccessTokens", ctx, 3).Return(int64(0), nil).Once()
+		m.sessions.On("RevokeAllSessions", ctx, 3).Return([]string{}, nil).Once()
+		m.auditRepo.On("CreateAuditEntry", ctx, mock.Anything).Return(nil).Once()
+
+		withCode := input
+		withCode.Code = code
+		_, err := deletionService.RequestDeletion(ctx, 3, withCode)
+		assert.NoError(t, err)
+		m.twoFactorRepo.AssertExpectations(t)
+	})
+
+	t.Run("Nothing changes when the sessions cannot be revoked", func(t *testing.T) {
+		deletionService, m := newDeletionService(openLoginGuard())
+
+		m.userRepo.On("GetUserById", ctx, 3).Return(stored, nil).Once()
+		m.hash.On("CheckPasswordHash", "hash", "password123").Return(true).Once()
+		m.twoFactorRepo.On("GetTOTP", ctx, 3).Return(nil, apperrors.ErrNotFound).Once()
+		m.userRepo.On("ScheduleUserDeletion", ctx, 3, mock.Anything).Return(nil).Once()
+		m.tokenRepo.On("DeleteUserAccessTokens", ctx, 3).Return(int64(1), nil).Once()
+		m.sessions.On("RevokeAllSessions", ctx, 3).Return(nil, errors.New("update failed")).Once()
+
+		scheduled, err := deletionService.RequestDeletion(ctx, 3, input)
+		assert.Error(t, err)
+		assert.Nil(t, scheduled)
+		assert.True(t, m.uow.RolledBack)
+		m.auditRepo.AssertNotCalled(t, "CreateAuditEntry", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAccountDeletionService_CancelDeletion(t *testing.T) {
+	ctx := context.Background()
+	login := service.UserLogin{Email: "test@example.com", Password: "password123"}
+	pending := &repository.User{
+		Id: 3, Email: "test@example.com", PasswordHash: "hash",
+		DeletionDueAt: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		deletionService, m := newDeletionService(openLoginGuard())
+
+		m.userRepo.On("GetUserByEmail", ctx, "test@example.com").Return(pending, nil).Once()
+		m.hash.On("CheckPasswordHash", "hash", "password123").Return(true).Once()
+		m.userRepo.On("CancelUserDeletion", ctx, 3).Return(nil).Once()
+		m.auditRepo.On("CreateAuditEntry", ctx, mock.MatchedBy(func(e repository.CreateAuditEntry) bool {
+			return e.Event == service.AuditDeletionCancelled && *e.UserId == 3
+		})).Return(nil).Once()
+
+		assert.NoError(t, deletionService.CancelDeletion(ctx, login))
+		m.userRepo.AssertExpectations(t)
+		m.auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("Wrong password", func(t *testing.T) {
+		deletionService, m := newDeletionService(openLoginGuard())
+
+		m.userRepo.On("GetUserByEmail", ctx, "test@example.com").Return(pending, nil).Once()
+		m.hash.On("CheckPasswordHash", "hash", "password123").Return(false).Once()
+
+		err := deletionService.CancelDeletion(ctx, login)
+		var validationErr *apperrors.ValidationError
+		if assert.ErrorAs(t, err, &validationErr) {
+			assert.Equal(t, apperrors.INVALID_CREDENTIALS, validationErr.Field)
+		}
+		m.userRepo.AssertNotCalled(t, "CancelUserDeletion", mock.Anything, mock.Anything)
+	})
+
+	t.Run("No deletion scheduled", func(t *testing.T) {
+		deletionService, m := newDeletionService(openLoginGuard())
+
+		m.userRepo.On("GetUserByEmail", ctx, "test@example.com").Return(&repository.User{Id: 3, PasswordHash: "hash"}, nil).Once()
+		m.hash.On("CheckPasswordHash", "hash", "password123").Return(true).Once()
+
+		err := deletionService.CancelDeletion(ctx, login)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		m.userRepo.AssertNotCalled(t, "CancelUserDeletion", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Grace period is over", func(t *testing.T) {
+		deletionService, m := newDeletionService(openLoginGuard())
+
+		m.userRepo.On("GetUserByEmail", ctx, "test@example.com").Return(pending, nil).Once()
+		m.hash.On("CheckPasswordHash", "hash", "password123").Return(true).Once()
+		m.userRepo.On("CancelUserDeletion", ctx, 3).Return(apperrors.ErrNotFound).Once()
+
+		err := deletionService.CancelDeletion(ctx, login)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		m.auditRepo.AssertNotCalled(t, "CreateAuditEntry", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAccountDeletionService_PurgeDueAccounts(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Deletes the due accounts and skips cancelled ones", func(t *testing.T) {
+		deletionService, m := newDeletionService(nil)
+
+		m.userRepo.On("ListUsersDueForDeletion", ctx, now, 0, 100).Return([]int{4, 7}, nil).Once()
+		m.userRepo.On("DeleteDueUser", ctx, 4, now).Return(true, nil).Once()
+		m.userRepo.On("DeleteDueUser", ctx, 7, now).Return(false, nil).Once()
+		m.auditRepo.On("CreateAuditEntry", ctx, mock.MatchedBy(func(e repository.CreateAuditEntry) bool {
+			return e.Event == service.AuditAccountDeleted && e.UserId == nil && e.Detail == "user id 4 deleted"
+		})).Return(nil).Once()
+
+		purged, err := deletionService.PurgeDueAccounts(ctx, now)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, purged)
+		m.userRepo.AssertExpectations(t)
+		m.auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("Skips a failed account and carries on", func(t *testing.T) {
+		deletionService, m := newDeletionService(nil)
+
+		// a full batch makes the purge read on, past the ids it has already seen
+		batch := make([]int, 100)
+		for i := range batch {
+			batch[i] = i + 1
+		}
+		m.userRepo.On("ListUsersDueForDeletion", ctx, now, 0, 100).Return(batch, nil).Once()
+		m.userRepo.On("ListUsersDueForDeletion", ctx, now, 100, 100).Return([]int{101}, nil).Once()
+		// e.g. a custom exercise the delete could not get rid of
+		m.userRepo.On("DeleteDueUser", ctx, 1, now).Return(false, errors.New("foreign key violation")).Once()
+		m.userRepo.On("DeleteDueUser", ctx, mock.MatchedBy(func(id int) bool { return id != 1 }), now).Return(true, nil).Times(100)
+		m.auditRepo.On("CreateAuditEntry", ctx, mock.AnythingOfType("repository.CreateAuditEntry")).Return(nil).Times(100)
+
+		purged, err := deletionService.PurgeDueAccounts(ctx, now)
+		assert.EqualError(t, err, "failed to delete 1 of the accounts due for deletion")
+		assert.Equal(t, 100, purged)
+		m.userRepo.AssertExpectations(t)
+		m.auditRepo.AssertExpectations(t)
+	})
+}
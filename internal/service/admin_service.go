@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+)
+
+// audit events written by the admin routes
+const (
+	AuditUserDisabled    = "user.disabled"
+	AuditUserEnabled     = "user.enabled"
+	AuditUserRoleChanged = "user.role_changed"
+)
+
+const (
+	DefaultUserPageSize = 50
+	MaxUserPageSize     = 100
+)
+
+// UserListQuery pages through all users, Email is a case-insensitive substring
+type UserListQuery struct {
+	Email  string `json:"email"`
+	Limit  int    `json:"limit"` // DefaultUserPageSize when not set
+	Offset int    `json:"offset"`
+}
+
+func (query *UserListQuery) Validate() error {
+	if query.Limit == 0 {
+		query.Limit = DefaultUserPageSize
+	}
+	if query.Limit < 1 || query.Limit > MaxUserPageSize {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, fmt.Sprintf("limit must be between 1 and %d", MaxUserPageSize))
+	}
+	if query.Offset < 0 {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, "offset can not be negative")
+	}
+	if len(query.Email) > 255 {
+		return apperrors.NewValidationError(apperrors.INVALID_EMAIL, "search text can not be longer than 255")
+	}
+
+	return nil
+}
+
+type SystemStats struct {
+	Users             int `json:"users"`
+	Admins            int `json:"admins"`
+	DisabledUsers     int `json:"disabledUsers"`
+	VerifiedUsers     int `json:"verifiedUsers"`
+	ActiveSessions    int `json:"activeSessions"`
+	WorkoutPlans      int `json:"workoutPlans"`
+	CompletedWorkouts int `json:"completedWorkouts"`
+	PerformedSets     int `json:"performedSets"`
+	CatalogExercises  int `json:"catalogExercises"`
+	CustomExercises   int `json:"customExercises"`
+}
+
+type AdminServiceInterface interface {
+	ListUsers(ctx context.Context, query UserListQuery) ([]User, error)
+	DisableUser(ctx context.Context, actorId int, userId int) ([]string, error)
+	EnableUser(ctx context.Context, actorId int, userId int) error
+	SetUserRole(ctx context.Context, actorId int, userId int, role Role) error
+	GetSystemStats(ctx context.Context) (*SystemStats, error)
+	GrantAdmins(ctx context.Context, emails []string) error
+}
+
+type AdminService struct {
+	UserRepo       repository.UserRepository
+	AdminRepo      repository.AdminRepository
+	AuditRepo      repository.AuditRepository
+	UoW            repository.UnitOfWork
+	SessionService SessionServiceInterface
+}
+
+func NewAdminService(ur repository.UserRepository, ar repository.AdminRepository, aur repository.AuditRepository, uow repository.UnitOfWork, ss SessionServiceInterface) AdminServiceInterface {
+	return &AdminService{
+		UserRepo:       ur,
+		AdminRepo:      ar,
+		AuditRepo:      aur,
+		UoW:            uow,
+		SessionService: ss,
+	}
+}
+
+func (s *AdminService) ListUsers(ctx context.Context, query UserListQuery) ([]User, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	usersList, err := s.UserRepo.ListUsers(ctx, repository.UserFilter{
+		Email:  query.Email,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	result := []User{}
+	for _, u := range usersList {
+		result = append(result, *toServiceUser(&u))
+	}
+
+	return result, nil
+}
+
+// DisableUser keeps the user from logging in and ends every session, it returns the revoked
+// session ids so their access tokens can be revoked too
+func (s *AdminService) DisableUser(ctx context.Context, actorId int, userId int) ([]string, error) {
+	if actorId == userId {
+		return nil, apperrors.NewValidationError(apperrors.INVALID_ID, "admins can not disable their own account")
+	}
+
+	var revoked []string
+	err := s.UoW.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.UserRepo.SetUserDisabled(txCtx, userId, true); err != nil {
+			return err
+		}
+
+		var err error
+		revoked, err = s.SessionService.RevokeAllSessions(txCtx, userId)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to disable user: %w", err)
+	}
+
+	s.audit(ctx, repository.CreateAuditEntry{Event: AuditUserDisabled, UserId: &userId, ActorId: &actorId})
+
+	return revoked, nil
+}
+
+func (s *AdminService) EnableUser(ctx context.Context, actorId int, userId int) error {
+	if err := s.UserRepo.SetUserDisabled(ctx, userId, false); err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to enable user: %w", err)
+	}
+
+	s.audit(ctx, repository.CreateAuditEntry{Event: AuditUserEnabled, UserId: &userId, ActorId: &actorId})
+
+	return nil
+}
+
+// SetUserRole changes the role of another user. The new role reaches the access token of the user
+// on the next refresh or login.
+func (s *AdminService) SetUserRole(ctx context.Context, actorId int, userId int, role Role) error {
+	if !role.IsValid() {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, fmt.Sprintf("invalid role '%s'", role))
+	}
+	// keeps the last admin from locking everyone out of the admin routes
+	if actorId == userId {
+		return apperrors.NewValidationError(apperrors.INVALID_ID, "admins can not change their own role")
+	}
+
+	if err := s.UserRepo.UpdateUserRole(ctx, userId, repository.Role(role)); err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to update user role: %w", err)
+	}
+
+	s.audit(ctx, repository.CreateAuditEntry{
+		Event:   AuditUserRoleChanged,
+		UserId:  &userId,
+		ActorId: &actorId,
+		Detail:  "role set to " + string(role),
+	})
+
+	return nil
+}
+
+func (s *AdminService) GetSystemStats(ctx context.Context) (*SystemStats, error) {
+	stats, err := s.AdminRepo.GetSystemStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch system stats: %w", err)
+	}
+
+	return &SystemStats{
+		Users:             stats.Users,
+		Admins:            stats.Admins,
+		DisabledUsers:     stats.DisabledUsers,
+		VerifiedUsers:     stats.VerifiedUsers,
+		ActiveSessions:    stats.ActiveSessions,
+		WorkoutPlans:      stats.WorkoutPlans,
+		CompletedWorkouts: stats.CompletedWorkouts,
+		PerformedSets:     stats.PerformedSets,
+		CatalogExercises:  stats.CatalogExercises,
+		CustomExercises:   stats.CustomExercises,
+	}, nil
+}
+
+// GrantAdmins gives the admin role to the registered users among the emails. It runs at startup
+// so a fresh deployment has a way to its first admin.
+func (s *AdminService) GrantAdmins(ctx context.Context, emails []string) error {
+	if len(emails) == 0 {
+		return nil
+	}
+
+	granted, err := s.UserRepo.GrantRoleByEmails(ctx, emails, repository.RoleAdmin)
+	if err != nil {
+		return fmt.Errorf("failed to grant admin role: %w", err)
+	}
+	if granted > 0 {
+		log.Printf("Granted the admin role to %d users from the configured admin emails", granted)
+	}
+
+	return nil
+}
+
+// audit failures are logged, the change they describe is already committed
+func (s *AdminService) audit(ctx context.Context, entry repository.CreateAuditEntry) {
+	if err := s.AuditRepo.CreateAuditEntry(ctx, entry); err != nil {
+		log.Printf("Failed to write audit entry: %v", err)
+	}
+}
@@ -0,0 +1,194 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+	"workout-tracker-api/internal/service"
+)
+
+// MockAdminRepository is a mock implementation of repository.AdminRepository
+type MockAdminRepository struct {
+	mock.Mock
+}
+
+func (m *MockAdminRepository) GetSystemStats(ctx context.Context) (*repository.SystemStats, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.SystemStats), args.Error(1)
+}
+
+func TestAdminService_ListUsers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Defaults the page size", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		adminService := service.NewAdminService(mockUserRepo, nil, nil, new(MockUnitOfWork), nil)
+
+		now := time.Now()
+		mockUserRepo.On("ListUsers", ctx, repository.UserFilter{Email: "example", Limit: service.DefaultUserPageSize}).Return([]repository.User{
+			{Id: 1, Email: "admin@example.com", Role: repository.RoleAdmin},
+			{Id: 2, Email: "john@example.com", Role: repository.RoleUser, DisabledAt: sql.NullTime{Time: now, Valid: true}},
+		}, nil).Once()
+
+		users, err := adminService.ListUsers(ctx, service.UserListQuery{Email: "example"})
+		assert.NoError(t, err)
+		assert.Len(t, users, 2)
+		assert.Equal(t, service.RoleAdmin, users[0].Role)
+		assert.False(t, users[0].Disabled)
+		assert.True(t, users[1].Disabled)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Limit out of range", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		adminService := service.NewAdminService(mockUserRepo, nil, nil, new(MockUnitOfWork), nil)
+
+		_, err := adminService.ListUsers(ctx, service.UserListQuery{Limit: service.MaxUserPageSize + 1})
+		var validationErr *apperrors.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		mockUserRepo.AssertNotCalled(t, "ListUsers", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAdminService_DisableUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Disables the user and revokes the sessions", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockAuditRepo := new(MockAuditRepository)
+		mockSessions := new(MockUserSessionService)
+		uow := new(MockUnitOfWork)
+		adminService := service.NewAdminService(mockUserRepo, nil, mockAuditRepo, uow, mockSessions)
+
+		mockUserRepo.On("SetUserDisabled", ctx, 3, true).Return(nil).Once()
+		mockSessions.On("RevokeAllSessions", ctx, 3).Return([]string{"session-1"}, nil).Once()
+		mockAuditRepo.On("CreateAuditEntry", ctx, mock.MatchedBy(func(e repository.CreateAuditEntry) bool {
+			return e.Event == service.AuditUserDisabled && *e.UserId == 3 && *e.ActorId == 1
+		})).Return(nil).Once()
+
+		revoked, err := adminService.DisableUser(ctx, 1, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"session-1"}, revoked)
+		assert.Equal(t, 1, uow.Calls)
+		mockAuditRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockAuditRepo := new(MockAuditRepository)
+		uow := new(MockUnitOfWork)
+		adminService := service.NewAdminService(mockUserRepo, nil, mockAuditRepo, uow, new(MockUserSessionService))
+
+		mockUserRepo.On("SetUserDisabled", ctx, 9, true).Return(apperrors.ErrNotFound).Once()
+
+		_, err := adminService.DisableUser(ctx, 1, 9)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.True(t, uow.RolledBack)
+		mockAuditRepo.AssertNotCalled(t, "CreateAuditEntry", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Own account", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		adminService := service.NewAdminService(mockUserRepo, nil, nil, new(MockUnitOfWork), nil)
+
+		_, err := adminService.DisableUser(ctx, 1, 1)
+		var validationErr *apperrors.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		mockUserRepo.AssertNotCalled(t, "SetUserDisabled", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAdminService_EnableUser(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := new(MockUserRepository)
+	mockAuditRepo := new(MockAuditRepository)
+	adminService := service.NewAdminService(mockUserRepo, nil, mockAuditRepo, new(MockUnitOfWork), nil)
+
+	mockUserRepo.On("SetUserDisabled", ctx, 3, false).Return(nil).Once()
+	// the audit entry is best effort
+	mockAuditRepo.On("CreateAuditEntry", ctx, mock.Anything).Return(errors.New("insert failed")).Once()
+
+	assert.NoError(t, adminService.EnableUser(ctx, 1, 3))
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestAdminService_SetUserRole(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Promotes a user", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockAuditRepo := new(MockAuditRepository)
+		adminService := service.NewAdminService(mockUserRepo, nil, mockAuditRepo, new(MockUnitOfWork), nil)
+
+		mockUserRepo.On("UpdateUserRole", ctx, 3, repository.RoleAdmin).Return(nil).Once()
+		mockAuditRepo.On("CreateAuditEntry", ctx, mock.MatchedBy(func(e repository.CreateAuditEntry) bool {
+			return e.Event == service.AuditUserRoleChanged && e.Detail == "role set to admin"
+		})).Return(nil).Once()
+
+		assert.NoError(t, adminService.SetUserRole(ctx, 1, 3, service.RoleAdmin))
+		mockAuditRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown role", func(t *testing.T) {
+		adminService := service.NewAdminService(new(MockUserRepository), nil, nil, new(MockUnitOfWork), nil)
+
+		err := adminService.SetUserRole(ctx, 1, 3, "owner")
+		var validationErr *apperrors.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, apperrors.INVALID_SETTING, validationErr.Field)
+	})
+
+	t.Run("Own role", func(t *testing.T) {
+		adminService := service.NewAdminService(new(MockUserRepository), nil, nil, new(MockUnitOfWork), nil)
+
+		err := adminService.SetUserRole(ctx, 1, 1, service.RoleUser)
+		var validationErr *apperrors.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, apperrors.INVALID_ID, validationErr.Field)
+	})
+}
+
+func TestAdminService_GrantAdmins(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Grants the configured emails", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		adminService := service.NewAdminService(mockUserRepo, nil, nil, new(MockUnitOfWork), nil)
+
+		mockUserRepo.On("GrantRoleByEmails", ctx, []string{"admin@example.com"}, repository.RoleAdmin).Return(int64(1), nil).Once()
+
+		assert.NoError(t, adminService.GrantAdmins(ctx, []string{"admin@example.com"}))
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Nothing configured", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		adminService := service.NewAdminService(mockUserRepo, nil, nil, new(MockUnitOfWork), nil)
+
+		assert.NoError(t, adminService.GrantAdmins(ctx, nil))
+		mockUserRepo.AssertNotCalled(t, "GrantRoleByEmails", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAdminService_GetSystemStats(t *testing.T) {
+	ctx := context.Background()
+	mockAdminRepo := new(MockAdminRepository)
+	adminService := service.NewAdminService(nil, mockAdminRepo, nil, new(MockUnitOfWork), nil)
+
+	mockAdminRepo.On("GetSystemStats", ctx).Return(&repository.SystemStats{Users: 10, Admins: 1, CatalogExercises: 45}, nil).Once()
+
+	stats, err := adminService.GetSystemStats(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, &service.SystemStats{Users: 10, Admins: 1, CatalogExercises: 45}, stats)
+}
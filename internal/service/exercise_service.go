@@ -2,32 +2,184 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
+	"workout-tracker-api/internal/apperrors"
 	"workout-tracker-api/internal/repository"
 )
 
 type ExerciseServiceInterface interface {
+	CreateExercise(ctx context.Context, data ExerciseCreate) (*Exercise, error)
+	CreateCatalogExercise(ctx context.Context, data ExerciseCreate) (*Exercise, error)
 	GetExerciseById(ctx context.Context, id int) (*Exercise, error)
-	ListExercises(ctx context.Context) ([]Exercise, error)
+	ListExercises(ctx context.Context, query ExerciseQuery) (*ExercisePage, error)
+	UpdateExercise(ctx context.Context, data ExerciseUpdate) (*Exercise, error)
+	DeleteExercise(ctx context.Context, id int) (archived bool, err error)
 }
 
 type ExerciseService struct {
 	exerciseRepo repository.ExerciseRepository
+	UoW          repository.UnitOfWork
 }
 
-func NewExerciseService(r repository.ExerciseRepository) ExerciseServiceInterface {
+func NewExerciseService(r repository.ExerciseRepository, uow repository.UnitOfWork) ExerciseServiceInterface {
 	return &ExerciseService{
 		exerciseRepo: r,
+		UoW:          uow,
 	}
 }
 
 type MuscleGroup string
 
+type Equipment string
+
 type Exercise struct {
 	Id          int         `json:"id"`
 	Name        string      `json:"name"`
 	Description string      `json:"description"`
 	MuscleGroup MuscleGroup `json:"muscleGroup"`
+	Equipment   Equipment   `json:"equipment"`
+	OwnerId     *int        `json:"ownerId,omitempty"` // nil for the global catalog
+	ArchivedAt  *time.Time  `json:"archivedAt,omitempty"`
+}
+
+// IsCustom reports whether the exercise was created by a user instead of seeded
+func (e *Exercise) IsCustom() bool {
+	return e.OwnerId != nil
+}
+
+type ExerciseCreate struct {
+	OwnerId     int         `json:"ownerId"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	MuscleGroup MuscleGroup `json:"muscleGroup"`
+	Equipment   Equipment   `json:"equipment"` // other when not set
+}
+
+func (data *ExerciseCreate) Validate() error {
+	if data.OwnerId <= 0 {
+		return apperrors.NewValidationError(apperrors.INVALID_ID, "not a valid user id")
+	}
+
+	if data.Equipment == "" {
+		data.Equipment = Other
+	}
+
+	return validateExercise(data.Name, data.MuscleGroup, data.Equipment)
+}
+
+type ExerciseUpdate struct {
+	Id          int         `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	MuscleGroup MuscleGroup `json:"muscleGroup"`
+	Equipment   Equipment   `json:"equipment"`
+}
+
+func (data *ExerciseUpdate) Validate() error {
+	return validateExercise(data.Name, data.MuscleGroup, data.Equipment)
+}
+
+func validateExercise(name string, muscleGroup MuscleGroup, equipment Equipment) error {
+	if len(name) < 1 || len(name) > 255 {
+		return apperrors.NewValidationError(apperrors.INVALID_NAME, "Set the name length between 1 and 255")
+	}
+
+	if !muscleGroup.IsValid() {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, fmt.Sprintf("invalid muscle group '%s'", muscleGroup))
+	}
+
+	if !equipment.IsValid() {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, fmt.Sprintf("invalid equipment '%s'", equipment))
+	}
+
+	return nil
+}
+
+const (
+	DefaultExercisePageSize = 50
+	MaxExercisePageSize     = 100
+)
+
+// ExerciseQuery searches the exercises visible to the user, empty fields do not filter
+type ExerciseQuery struct {
+	UserId       int           `json:"userId"`
+	Name         string        `json:"name"` // case-insensitive prefix, substring or fuzzy match
+	MuscleGroups []MuscleGroup `json:"muscleGroups"`
+	Equipment    []Equipment   `json:"equipment"`
+	Cursor       string        `json:"cursor"` // nextCursor of the previous page
+	Limit        int           `json:"limit"`  // DefaultExercisePageSize when not set
+}
+
+func (query *ExerciseQuery) Validate() error {
+	query.Name = strings.TrimSpace(query.Name)
+	if len(query.Name) > 100 {
+		return apperrors.NewValidationError(apperrors.INVALID_NAME, "search text can not be longer than 100")
+	}
+
+	if query.Limit == 0 {
+		query.Limit = DefaultExercisePageSize
+	}
+	if query.Limit < 1 || query.Limit > MaxExercisePageSize {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, fmt.Sprintf("limit must be between 1 and %d", MaxExercisePageSize))
+	}
+
+	for _, mg := range query.MuscleGroups {
+		if !mg.IsValid() {
+			return apperrors.NewValidationError(apperrors.INVALID_SETTING, fmt.Sprintf("invalid muscle group '%s'", mg))
+		}
+	}
+
+	for _, eq := range query.Equipment {
+		if !eq.IsValid() {
+			return apperrors.NewValidationError(apperrors.INVALID_SETTING, fmt.Sprintf("invalid equipment '%s'", eq))
+		}
+	}
+
+	return nil
+}
+
+type ExercisePage struct {
+	Exercises  []Exercise `json:"exercises"`
+	NextCursor *string    `json:"nextCursor,omitempty"` // nil on the last page
+}
+
+// the cursor is opaque to clients, it only has to survive a round trip
+func encodeExerciseCursor(cursor *repository.ExerciseCursor) (*string, error) {
+	if cursor == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode exercise cursor: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(raw)
+	return &encoded, nil
+}
+
+func decodeExerciseCursor(encoded string) (*repository.ExerciseCursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+
+	invalid := apperrors.NewValidationError(apperrors.INVALID_INPUT, "cursor is not valid")
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, invalid
+	}
+
+	var cursor repository.ExerciseCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.Id <= 0 {
+		return nil, invalid
+	}
+
+	return &cursor, nil
 }
 
 const (
@@ -40,9 +192,58 @@ const (
 	Glutes    MuscleGroup = "glutes"
 )
 
-// list exercises
-func (s *ExerciseService) ListExercises(ctx context.Context) ([]Exercise, error) {
-	exercisesList, err := s.exerciseRepo.ListExercises(ctx)
+func (mg MuscleGroup) IsValid() bool {
+	switch mg {
+	case Chest, Legs, Back, Shoulders, Arms, Core, Glutes:
+		return true
+	}
+	return false
+}
+
+const (
+	Barbell    Equipment = "barbell"
+	Dumbbell   Equipment = "dumbbell"
+	Machine    Equipment = "machine"
+	Cable      Equipment = "cable"
+	Bodyweight Equipment = "bodyweight"
+	Kettlebell Equipment = "kettlebell"
+	Band       Equipment = "band"
+	Other      Equipment = "other"
+)
+
+func (eq Equipment) IsValid() bool {
+	switch eq {
+	case Barbell, Dumbbell, Machine, Cable, Bodyweight, Kettlebell, Band, Other:
+		return true
+	}
+	return false
+}
+
+// list a page of the global exercises together with the user's own ones
+func (s *ExerciseService) ListExercises(ctx context.Context, query ExerciseQuery) (*ExercisePage, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	after, err := decodeExerciseCursor(query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repository.ExerciseFilter{
+		UserId: query.UserId,
+		Name:   query.Name,
+		After:  after,
+		Limit:  query.Limit,
+	}
+	for _, mg := range query.MuscleGroups {
+		filter.MuscleGroups = append(filter.MuscleGroups, repository.MuscleGroup(mg))
+	}
+	for _, eq := range query.Equipment {
+		filter.Equipment = append(filter.Equipment, repository.Equipment(eq))
+	}
+
+	exercisesList, next, err := s.exerciseRepo.ListExercises(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list exercises: %w", err)
 	}
@@ -55,7 +256,12 @@ func (s *ExerciseService) ListExercises(ctx context.Context) ([]Exercise, error)
 		result = append(result, *serviceExercise)
 	}
 
-	return result, nil
+	nextCursor, err := encodeExerciseCursor(next)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExercisePage{Exercises: result, NextCursor: nextCursor}, nil
 }
 
 // get exercise
@@ -71,15 +277,141 @@ func (s *ExerciseService) GetExerciseById(ctx context.Context, id int) (*Exercis
 	return result, nil
 }
 
+// create a private exercise for the owner
+func (s *ExerciseService) CreateExercise(ctx context.Context, data ExerciseCreate) (*Exercise, error) {
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+
+	exercise, err := s.exerciseRepo.CreateExercise(ctx, repository.CreateExer{
+		Name:        data.Name,
+		Description: data.Description,
+		MuscleGroup: repository.MuscleGroup(data.MuscleGroup),
+		Equipment:   repository.Equipment(data.Equipment),
+		OwnerId:     &data.OwnerId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create exercise: %w", err)
+	}
+
+	return toServiceExercise(exercise), nil
+}
+
+// CreateCatalogExercise adds an exercise to the global catalog every user sees, OwnerId is ignored
+func (s *ExerciseService) CreateCatalogExercise(ctx context.Context, data ExerciseCreate) (*Exercise, error) {
+	if data.Equipment == "" {
+		data.Equipment = Other
+	}
+	if err := validateExercise(data.Name, data.MuscleGroup, data.Equipment); err != nil {
+		return nil, err
+	}
+
+	exercise, err := s.exerciseRepo.CreateExercise(ctx, repository.CreateExer{
+		Name:        data.Name,
+		Description: data.Description,
+		MuscleGroup: repository.MuscleGroup(data.MuscleGroup),
+		Equipment:   repository.Equipment(data.Equipment),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create catalog exercise: %w", err)
+	}
+
+	return toServiceExercise(exercise), nil
+}
+
+// update exercise
+func (s *ExerciseService) UpdateExercise(ctx context.Context, data ExerciseUpdate) (*Exercise, error) {
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+
+	exercise, err := s.exerciseRepo.UpdateExercise(ctx, repository.UpdateExer{
+		Id:          data.Id,
+		Name:        data.Name,
+		Description: data.Description,
+		MuscleGroup: repository.MuscleGroup(data.MuscleGroup),
+		Equipment:   repository.Equipment(data.Equipment),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update exercise '%v': %w", data.Id, err)
+	}
+
+	return toServiceExercise(exercise), nil
+}
+
+// DeleteExercise removes the exercise, or archives it when workout plans or templates still use it
+func (s *ExerciseService) DeleteExercise(ctx context.Context, id int) (bool, error) {
+	var archived bool
+
+	err := s.UoW.WithinTransaction(ctx, func(txCtx context.Context) error {
+		referenced, err := s.exerciseRepo.IsExerciseReferenced(txCtx, id)
+		if err != nil {
+			return err
+		}
+
+		if referenced {
+			archived = true
+			return s.exerciseRepo.ArchiveExercise(txCtx, id)
+		}
+
+		return s.exerciseRepo.DeleteExercise(txCtx, id)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete exercise '%v': %w", id, err)
+	}
+
+	return archived, nil
+}
+
+// checkExerciseAccess makes sure the user may plan every exercise of the exercise plans: it has to be
+// a global exercise or one of the user's own custom exercises, and it must not be archived
+func checkExerciseAccess(ctx context.Context, exerciseRepo repository.ExerciseRepository, userId int, epsCreate []ExercisePlanCreate) error {
+	checked := make(map[int]bool, len(epsCreate))
+	for _, ep := range epsCreate {
+		if checked[ep.ExerciseId] {
+			continue
+		}
+		checked[ep.ExerciseId] = true
+
+		exercise, err := exerciseRepo.GetExerciseById(ctx, ep.ExerciseId)
+		if err != nil {
+			return fmt.Errorf("failed to fetch exercise id '%v': %w", ep.ExerciseId, err)
+		}
+
+		// custom exercises are private to their owner
+		if exercise.OwnerId.Valid && int(exercise.OwnerId.Int64) != userId {
+			return fmt.Errorf("exercise id '%v' is not available to user id '%v': %w", ep.ExerciseId, userId, apperrors.ErrForbidden)
+		}
+
+		if exercise.ArchivedAt.Valid {
+			return apperrors.NewValidationError(apperrors.INVALID_ID, fmt.Sprintf("exercise id '%v' is archived", ep.ExerciseId))
+		}
+	}
+
+	return nil
+}
+
 func toServiceExercise(mu *repository.Exercise) *Exercise {
 	if mu == nil {
 		return nil
 	}
 
-	return &Exercise{
+	exercise := &Exercise{
 		Id:          mu.Id,
 		Name:        mu.Name,
 		Description: mu.Description,
 		MuscleGroup: MuscleGroup(mu.MuscleGroup),
+		Equipment:   Equipment(mu.Equipment),
+	}
+
+	if mu.OwnerId.Valid {
+		ownerId := int(mu.OwnerId.Int64)
+		exercise.OwnerId = &ownerId
+	}
+
+	if mu.ArchivedAt.Valid {
+		exercise.ArchivedAt = &mu.ArchivedAt.Time
 	}
+
+	return exercise
 }
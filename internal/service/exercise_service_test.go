@@ -2,6 +2,7 @@ package service_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 
@@ -39,18 +40,48 @@ func (m *MockExerciseRepository) GetExerciseById(ctx context.Context, id int) (*
 	return args.Get(0).(*repository.Exercise), args.Error(1)
 }
 
-func (m *MockExerciseRepository) ListExercises(ctx context.Context) ([]repository.Exercise, error) {
-	args := m.Called(ctx)
+func (m *MockExerciseRepository) UpdateExercise(ctx context.Context, data repository.UpdateExer) (*repository.Exercise, error) {
+	args := m.Called(ctx, data)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]repository.Exercise), args.Error(1)
+	return args.Get(0).(*repository.Exercise), args.Error(1)
+}
+
+func (m *MockExerciseRepository) ArchiveExercise(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockExerciseRepository) IsExerciseReferenced(ctx context.Context, id int) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockExerciseRepository) ListExercises(ctx context.Context, filter repository.ExerciseFilter) ([]repository.Exercise, *repository.ExerciseCursor, error) {
+	args := m.Called(ctx, filter)
+	var next *repository.ExerciseCursor
+	if args.Get(1) != nil {
+		next = args.Get(1).(*repository.ExerciseCursor)
+	}
+	if args.Get(0) == nil {
+		return nil, next, args.Error(2)
+	}
+	return args.Get(0).([]repository.Exercise), next, args.Error(2)
+}
+
+// globalExercises finds every exercise in the global catalog, for tests that plan exercises without caring which
+func globalExercises() *MockExerciseRepository {
+	exerciseRepo := new(MockExerciseRepository)
+	exerciseRepo.On("GetExerciseById", mock.Anything, mock.Anything).Return(&repository.Exercise{Id: 1, Name: "Squat", MuscleGroup: repository.Legs}, nil).Maybe()
+	return exerciseRepo
 }
 
 func TestListExercises(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(MockExerciseRepository)
-	exerciseService := service.NewExerciseService(mockRepo)
+	exerciseService := service.NewExerciseService(mockRepo, new(MockUnitOfWork))
+	defaultFilter := repository.ExerciseFilter{UserId: 5, Limit: service.DefaultExercisePageSize}
 
 	tests := []struct {
 		name              string
@@ -61,21 +92,21 @@ func TestListExercises(t *testing.T) {
 		{
 			name: "Successful list of exercises",
 			mockRepoSetup: func() {
-				mockRepo.On("ListExercises", ctx).Return([]repository.Exercise{
-					{Id: 1, Name: "Push-ups", Description: "Bodyweight exercise", MuscleGroup: "chest"},
-					{Id: 2, Name: "Squats", Description: "Lower body exercise", MuscleGroup: "legs"},
-				}, nil).Once()
+				mockRepo.On("ListExercises", ctx, defaultFilter).Return([]repository.Exercise{
+					{Id: 1, Name: "Push-ups", Description: "Bodyweight exercise", MuscleGroup: "chest", Equipment: "bodyweight"},
+					{Id: 2, Name: "Squats", Description: "Lower body exercise", MuscleGroup: "legs", Equipment: "barbell"},
+				}, nil, nil).Once()
 			},
 			expectedExercises: []service.Exercise{
-				{Id: 1, Name: "Push-ups", Description: "Bodyweight exercise", MuscleGroup: "chest"},
-				{Id: 2, Name: "Squats", Description: "Lower body exercise", MuscleGroup: "legs"},
+				{Id: 1, Name: "Push-ups", Description: "Bodyweight exercise", MuscleGroup: "chest", Equipment: "bodyweight"},
+				{Id: 2, Name: "Squats", Description: "Lower body exercise", MuscleGroup: "legs", Equipment: "barbell"},
 			},
 			expectedError: nil,
 		},
 		{
 			name: "No exercises found",
 			mockRepoSetup: func() {
-				mockRepo.On("ListExercises", ctx).Return([]repository.Exercise{}, nil).Once()
+				mockRepo.On("ListExercises", ctx, defaultFilter).Return([]repository.Exercise{}, nil, nil).Once()
 			},
 			expectedExercises: []service.Exercise(nil),
 			expectedError:     nil,
@@ -83,7 +114,7 @@ func TestListExercises(t *testing.T) {
 		{
 			name: "Repository error",
 			mockRepoSetup: func() {
-				mockRepo.On("ListExercises", ctx).Return(nil, errors.New("database error")).Once()
+				mockRepo.On("ListExercises", ctx, defaultFilter).Return(nil, nil, errors.New("database error")).Once()
 			},
 			expectedExercises: nil,
 			expectedError:     errors.New("failed to list exercises: database error"),
@@ -93,25 +124,101 @@ func TestListExercises(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockRepoSetup()
-			exercises, err := exerciseService.ListExercises(ctx)
+			page, err := exerciseService.ListExercises(ctx, service.ExerciseQuery{UserId: 5})
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
 				assert.EqualError(t, err, tt.expectedError.Error())
-				assert.Nil(t, exercises)
+				assert.Nil(t, page)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedExercises, exercises)
+				assert.Equal(t, tt.expectedExercises, page.Exercises)
+				assert.Nil(t, page.NextCursor)
 			}
 			mockRepo.AssertExpectations(t) // Verify that expectations were met
 		})
 	}
 }
 
+func TestListExercises_SearchAndPaging(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("filters are trimmed and passed to the repository", func(t *testing.T) {
+		mockRepo := new(MockExerciseRepository)
+		exerciseService := service.NewExerciseService(mockRepo, new(MockUnitOfWork))
+
+		mockRepo.On("ListExercises", ctx, repository.ExerciseFilter{
+			UserId:       5,
+			Name:         "squ",
+			MuscleGroups: []repository.MuscleGroup{repository.Legs, repository.Glutes},
+			Equipment:    []repository.Equipment{repository.Barbell},
+			Limit:        10,
+		}).Return([]repository.Exercise{{Id: 1, Name: "Squat"}}, nil, nil).Once()
+
+		page, err := exerciseService.ListExercises(ctx, service.ExerciseQuery{
+			UserId:       5,
+			Name:         "  squ ",
+			MuscleGroups: []service.MuscleGroup{service.Legs, service.Glutes},
+			Equipment:    []service.Equipment{service.Barbell},
+			Limit:        10,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, page.Exercises, 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("next cursor round trips", func(t *testing.T) {
+		mockRepo := new(MockExerciseRepository)
+		exerciseService := service.NewExerciseService(mockRepo, new(MockUnitOfWork))
+		cursor := &repository.ExerciseCursor{Rank: 1, Name: "leg press", Id: 5}
+
+		mockRepo.On("ListExercises", ctx, repository.ExerciseFilter{UserId: 5, Name: "pr", Limit: 2}).
+			Return([]repository.Exercise{{Id: 4}, {Id: 5}}, cursor, nil).Once()
+		mockRepo.On("ListExercises", ctx, repository.ExerciseFilter{UserId: 5, Name: "pr", Limit: 2, After: cursor}).
+			Return([]repository.Exercise{{Id: 6}}, nil, nil).Once()
+
+		first, err := exerciseService.ListExercises(ctx, service.ExerciseQuery{UserId: 5, Name: "pr", Limit: 2})
+		assert.NoError(t, err)
+		assert.NotNil(t, first.NextCursor)
+
+		second, err := exerciseService.ListExercises(ctx, service.ExerciseQuery{UserId: 5, Name: "pr", Limit: 2, Cursor: *first.NextCursor})
+		assert.NoError(t, err)
+		assert.Len(t, second.Exercises, 1)
+		assert.Nil(t, second.NextCursor)
+		mockRepo.AssertExpectations(t)
+	})
+
+	invalid := []struct {
+		name  string
+		query service.ExerciseQuery
+		field apperrors.ValidationField
+	}{
+		{"limit too large", service.ExerciseQuery{UserId: 5, Limit: service.MaxExercisePageSize + 1}, apperrors.INVALID_SETTING},
+		{"negative limit", service.ExerciseQuery{UserId: 5, Limit: -1}, apperrors.INVALID_SETTING},
+		{"unknown muscle group", service.ExerciseQuery{UserId: 5, MuscleGroups: []service.MuscleGroup{"neck"}}, apperrors.INVALID_SETTING},
+		{"unknown equipment", service.ExerciseQuery{UserId: 5, Equipment: []service.Equipment{"rope"}}, apperrors.INVALID_SETTING},
+		{"garbage cursor", service.ExerciseQuery{UserId: 5, Cursor: "not a cursor!"}, apperrors.INVALID_INPUT},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockExerciseRepository)
+			exerciseService := service.NewExerciseService(mockRepo, new(MockUnitOfWork))
+
+			page, err := exerciseService.ListExercises(ctx, tt.query)
+			var validationErr *apperrors.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+			assert.Nil(t, page)
+			mockRepo.AssertNotCalled(t, "ListExercises", mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestGetExerciseById(t *testing.T) {
 	ctx := context.Background()
+	ownerID := 5
 	mockRepo := new(MockExerciseRepository)
-	exerciseService := service.NewExerciseService(mockRepo)
+	exerciseService := service.NewExerciseService(mockRepo, new(MockUnitOfWork))
 
 	tests := []struct {
 		name             string
@@ -133,6 +240,19 @@ func TestGetExerciseById(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name:       "Custom exercise keeps owner",
+			exerciseID: 3,
+			mockRepoSetup: func(id int) {
+				mockRepo.On("GetExerciseById", ctx, id).Return(&repository.Exercise{
+					Id: 3, Name: "Sled Push", MuscleGroup: "legs", OwnerId: sql.NullInt64{Int64: 5, Valid: true},
+				}, nil).Once()
+			},
+			expectedExercise: &service.Exercise{
+				Id: 3, Name: "Sled Push", MuscleGroup: "legs", OwnerId: &ownerID,
+			},
+			expectedError: nil,
+		},
 		{
 			name:       "Exercise not found",
 			exerciseID: 99,
@@ -170,3 +290,148 @@ func TestGetExerciseById(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateExercise(t *testing.T) {
+	ctx := context.Background()
+	ownerID := 5
+
+	t.Run("creates a private exercise", func(t *testing.T) {
+		mockRepo := new(MockExerciseRepository)
+		exerciseService := service.NewExerciseService(mockRepo, new(MockUnitOfWork))
+
+		mockRepo.On("CreateExercise", ctx, repository.CreateExer{
+			Name: "Sled Push", Description: "heavy", MuscleGroup: repository.Legs, Equipment: repository.Other, OwnerId: &ownerID,
+		}).Return(&repository.Exercise{
+			Id: 3, Name: "Sled Push", Description: "heavy", MuscleGroup: repository.Legs, OwnerId: sql.NullInt64{Int64: 5, Valid: true},
+		}, nil).Once()
+
+		exercise, err := exerciseService.CreateExercise(ctx, service.ExerciseCreate{
+			OwnerId: ownerID, Name: "Sled Push", Description: "heavy", MuscleGroup: service.Legs,
+		})
+		assert.NoError(t, err)
+		assert.True(t, exercise.IsCustom())
+		assert.Equal(t, ownerID, *exercise.OwnerId)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid muscle group", func(t *testing.T) {
+		mockRepo := new(MockExerciseRepository)
+		exerciseService := service.NewExerciseService(mockRepo, new(MockUnitOfWork))
+
+		exercise, err := exerciseService.CreateExercise(ctx, service.ExerciseCreate{
+			OwnerId: ownerID, Name: "Sled Push", MuscleGroup: "neck",
+		})
+		var validationErr *apperrors.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, apperrors.INVALID_SETTING, validationErr.Field)
+		assert.Nil(t, exercise)
+		mockRepo.AssertNotCalled(t, "CreateExercise")
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		mockRepo := new(MockExerciseRepository)
+		exerciseService := service.NewExerciseService(mockRepo, new(MockUnitOfWork))
+
+		_, err := exerciseService.CreateExercise(ctx, service.ExerciseCreate{
+			OwnerId: ownerID, MuscleGroup: service.Legs,
+		})
+		var validationErr *apperrors.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, apperrors.INVALID_NAME, validationErr.Field)
+	})
+}
+
+func TestCreateCatalogExercise(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockExerciseRepository)
+	exerciseService := service.NewExerciseService(mockRepo, new(MockUnitOfWork))
+
+	mockRepo.On("CreateExercise", ctx, repository.CreateExer{
+		Name: "Hip Thrust", MuscleGroup: repository.Glutes, Equipment: repository.Barbell,
+	}).Return(&repository.Exercise{
+		Id: 9, Name: "Hip Thrust", MuscleGroup: repository.Glutes, Equipment: repository.Barbell,
+	}, nil).Once()
+
+	// the owner of the request does not make it a custom exercise
+	exercise, err := exerciseService.CreateCatalogExercise(ctx, service.ExerciseCreate{
+		OwnerId: 5, Name: "Hip Thrust", MuscleGroup: service.Glutes, Equipment: service.Barbell,
+	})
+	assert.NoError(t, err)
+	assert.False(t, exercise.IsCustom())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateExercise(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		mockRepo := new(MockExerciseRepository)
+		exerciseService := service.NewExerciseService(mockRepo, new(MockUnitOfWork))
+
+		mockRepo.On("UpdateExercise", ctx, repository.UpdateExer{
+			Id: 3, Name: "Sled Drag", MuscleGroup: repository.Legs, Equipment: repository.Machine,
+		}).Return(&repository.Exercise{Id: 3, Name: "Sled Drag", MuscleGroup: repository.Legs}, nil).Once()
+
+		exercise, err := exerciseService.UpdateExercise(ctx, service.ExerciseUpdate{Id: 3, Name: "Sled Drag", MuscleGroup: service.Legs, Equipment: service.Machine})
+		assert.NoError(t, err)
+		assert.Equal(t, "Sled Drag", exercise.Name)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid muscle group", func(t *testing.T) {
+		mockRepo := new(MockExerciseRepository)
+		exerciseService := service.NewExerciseService(mockRepo, new(MockUnitOfWork))
+
+		_, err := exerciseService.UpdateExercise(ctx, service.ExerciseUpdate{Id: 3, Name: "Sled Drag", MuscleGroup: "neck", Equipment: service.Machine})
+		var validationErr *apperrors.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		mockRepo.AssertNotCalled(t, "UpdateExercise")
+	})
+}
+
+func TestDeleteExercise(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unused exercise is deleted", func(t *testing.T) {
+		mockRepo := new(MockExerciseRepository)
+		uow := new(MockUnitOfWork)
+		exerciseService := service.NewExerciseService(mockRepo, uow)
+
+		mockRepo.On("IsExerciseReferenced", ctx, 3).Return(false, nil).Once()
+		mockRepo.On("DeleteExercise", ctx, 3).Return(nil).Once()
+
+		archived, err := exerciseService.DeleteExercise(ctx, 3)
+		assert.NoError(t, err)
+		assert.False(t, archived)
+		assert.Equal(t, 1, uow.Calls)
+		mockRepo.AssertNotCalled(t, "ArchiveExercise", mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("referenced exercise is archived", func(t *testing.T) {
+		mockRepo := new(MockExerciseRepository)
+		exerciseService := service.NewExerciseService(mockRepo, new(MockUnitOfWork))
+
+		mockRepo.On("IsExerciseReferenced", ctx, 3).Return(true, nil).Once()
+		mockRepo.On("ArchiveExercise", ctx, 3).Return(nil).Once()
+
+		archived, err := exerciseService.DeleteExercise(ctx, 3)
+		assert.NoError(t, err)
+		assert.True(t, archived)
+		mockRepo.AssertNotCalled(t, "DeleteExercise", mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("repository error rolls back", func(t *testing.T) {
+		mockRepo := new(MockExerciseRepository)
+		uow := new(MockUnitOfWork)
+		exerciseService := service.NewExerciseService(mockRepo, uow)
+
+		mockRepo.On("IsExerciseReferenced", ctx, 3).Return(false, errors.New("db down")).Once()
+
+		archived, err := exerciseService.DeleteExercise(ctx, 3)
+		assert.Error(t, err)
+		assert.False(t, archived)
+		assert.True(t, uow.RolledBack)
+	})
+}
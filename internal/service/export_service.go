@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+)
+
+// exportPageSize is how many workout plans an export reads from the database at a time
+const exportPageSize = 100
+
+// ExportQuery picks the workout plans to export by scheduled date. From and To are read like the
+// bounds of a report, a bound that is not set leaves that end of the range open.
+type ExportQuery struct {
+	UserId int
+	From   string // inclusive
+	To     string // exclusive
+}
+
+// ExportedExercisePlan is an exercise plan with the exercise it is for
+type ExportedExercisePlan struct {
+	Id           int         `json:"id"`
+	ExerciseId   int         `json:"exerciseId"`
+	ExerciseName string      `json:"exerciseName"`
+	MuscleGroup  MuscleGroup `json:"muscleGroup"`
+	Position     int         `json:"position"`
+	Sets         int         `json:"sets"`
+	Repetitions  int         `json:"repetitions"`
+	Weights      float32     `json:"weights"`
+	WeightUnit   WeightUnit  `json:"weightUnit"`
+}
+
+// ExportedWorkout is a workout plan of the export, ScheduledDate is in the user's time zone
+type ExportedWorkout struct {
+	Id            int                    `json:"id"`
+	Status        WPStatus               `json:"status"`
+	ScheduledDate time.Time              `json:"scheduledDate"`
+	Comment       *string                `json:"comment"`
+	CreatedAt     time.Time              `json:"createdAt"`
+	UpdatedAt     time.Time              `json:"updatedAt"`
+	ExercisePlans []ExportedExercisePlan `json:"exercisePlans"`
+}
+
+// WorkoutExport reads the exported workout plans a page at a time. Next returns an empty page once
+// every workout plan was read.
+type WorkoutExport interface {
+	Next(ctx context.Context) ([]ExportedWorkout, error)
+}
+
+type ExportServiceInterface interface {
+	ExportWorkouts(ctx context.Context, query ExportQuery) (WorkoutExport, error)
+}
+
+type ExportService struct {
+	WPRepo   repository.WorkoutRepository
+	EPRepo   repository.ExercisePlanRepository
+	UserRepo repository.UserRepository
+}
+
+func NewExportService(wr repository.WorkoutRepository, er repository.ExercisePlanRepository, ur repository.UserRepository) ExportServiceInterface {
+	return &ExportService{
+		WPRepo:   wr,
+		EPRepo:   er,
+		UserRepo: ur,
+	}
+}
+
+// ExportWorkouts checks the range and returns the cursor over the user's workout plans in it,
+// nothing is read before the first Next. A validation error is returned here, so the caller can
+// still answer with an error before it starts sending the file.
+func (s *ExportService) ExportWorkouts(ctx context.Context, query ExportQuery) (WorkoutExport, error) {
+	loc, err := userLocation(ctx, s.UserRepo, query.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := ReportRange{From: query.From, To: query.To}.parse(loc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate: %w", err)
+	}
+	if !from.IsZero() && !to.IsZero() && !to.After(from) {
+		return nil, apperrors.NewValidationError(apperrors.INVALID_DATE, "to must be after from")
+	}
+
+	return &workoutExport{
+		wpRepo: s.WPRepo,
+		epRepo: s.EPRepo,
+		loc:    loc,
+		filter: repository.WorkoutPageFilter{
+			UserId: query.UserId,
+			From:   from,
+			To:     to,
+			Limit:  exportPageSize,
+		},
+	}, nil
+}
+
+type workoutExport struct {
+	wpRepo repository.WorkoutRepository
+	epRepo repository.ExercisePlanRepository
+	loc    *time.Location
+	filter repository.WorkoutPageFilter
+	done   bool
+}
+
+// Next reads the next page of workout plans and the exercise plans of all of them in one query
+func (e *workoutExport) Next(ctx context.Context) ([]ExportedWorkout, error) {
+	if e.done {
+		return nil, nil
+	}
+
+	plans, next, err := e.wpRepo.ListWorkoutPage(ctx, e.filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workout plans: %w", err)
+	}
+	e.filter.After = next
+	e.done = next == nil
+	if len(plans) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int, 0, len(plans))
+	for _, wp := range plans {
+		ids = append(ids, wp.Id)
+	}
+	details, err := e.epRepo.ListExercisePlanDetails(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch exercise plans: %w", err)
+	}
+
+	exercisePlans := make(map[int][]ExportedExercisePlan, len(plans))
+	for _, detail := range details {
+		exercisePlans[detail.WorkoutPlanId] = append(exercisePlans[detail.WorkoutPlanId], ExportedExercisePlan{
+			Id:           detail.Id,
+			ExerciseId:   detail.ExerciseId,
+			ExerciseName: detail.ExerciseName,
+			MuscleGroup:  MuscleGroup(detail.MuscleGroup),
+			Position:     detail.Position,
+			Sets:         detail.Sets,
+			Repetitions:  detail.Repetitions,
+			Weights:      detail.Weights,
+			WeightUnit:   WeightUnit(detail.WeightUnit),
+		})
+	}
+
+	workouts := make([]ExportedWorkout, 0, len(plans))
+	for _, wp := range plans {
+		var comment *string
+		if wp.Comment.Valid {
+			comment = &wp.Comment.String
+		}
+		workouts = append(workouts, ExportedWorkout{
+			Id:            wp.Id,
+			Status:        WPStatus(wp.Status),
+			ScheduledDate: wp.ScheduledDate.In(e.loc),
+			Comment:       comment,
+			CreatedAt:     wp.CreatedAt,
+			UpdatedAt:     wp.UpdatedAt,
+			ExercisePlans: exercisePlans[wp.Id],
+		})
+	}
+
+	return workouts, nil
+}
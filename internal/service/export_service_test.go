@@ -0,0 +1,128 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+	"workout-tracker-api/internal/service"
+)
+
+func TestExportService_ExportWorkouts(t *testing.T) {
+	ctx := context.Background()
+	userID := 7
+	hongKong, _ := time.LoadLocation("Asia/Hong_Kong")
+	monday := time.Date(2025, 5, 5, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Reads page by page with the exercise plans of each page", func(t *testing.T) {
+		mockWPRepo := new(MockWorkoutRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockUserRepo.On("GetTimeZone", ctx, userID).Return("Asia/Hong_Kong", nil).Once()
+
+		from := time.Date(2025, 5, 1, 0, 0, 0, 0, hongKong)
+		filter := repository.WorkoutPageFilter{UserId: userID, From: from, Limit: 100}
+		cursor := &repository.WorkoutCursor{ScheduledDate: monday, Id: 2}
+		mockWPRepo.On("ListWorkoutPage", ctx, filter).Return([]repository.WorkoutPlan{
+			{Id: 1, UserId: userID, Status: repository.COMPLETED, ScheduledDate: monday, Comment: sql.NullString{String: "heavy", Valid: true}},
+			{Id: 2, UserId: userID, Status: repository.MISSED, ScheduledDate: monday},
+		}, cursor, nil).Once()
+		mockEPRepo.On("ListExercisePlanDetails", ctx, []int{1, 2}).Return([]repository.ExercisePlanDetail{
+			{ExercisePlan: repository.ExercisePlan{Id: 10, ExerciseId: 3, WorkoutPlanId: 1, Position: 1, Sets: 5, Repetitions: 5, Weights: 100, WeightUnit: repository.KG}, ExerciseName: "Squat", MuscleGroup: repository.Legs},
+			{ExercisePlan: repository.ExercisePlan{Id: 11, ExerciseId: 4, WorkoutPlanId: 1, Position: 2, Sets: 3, Repetitions: 8, Weights: 60, WeightUnit: repository.KG}, ExerciseName: "Bench Press", MuscleGroup: repository.Chest},
+		}, nil).Once()
+
+		filter.After = cursor
+		mockWPRepo.On("ListWorkoutPage", ctx, filter).Return([]repository.WorkoutPlan{
+			{Id: 5, UserId: userID, Status: repository.PENDING, ScheduledDate: monday.AddDate(0, 0, 7)},
+		}, nil, nil).Once()
+		mockEPRepo.On("ListExercisePlanDetails", ctx, []int{5}).Return(nil, nil).Once()
+
+		exportService := service.NewExportService(mockWPRepo, mockEPRepo, mockUserRepo)
+		export, err := exportService.ExportWorkouts(ctx, service.ExportQuery{UserId: userID, From: "2025-05-01"})
+		assert.NoError(t, err)
+
+		first, err := export.Next(ctx)
+		assert.NoError(t, err)
+		if assert.Len(t, first, 2) {
+			assert.Equal(t, "heavy", *first[0].Comment)
+			assert.Equal(t, monday.In(hongKong), first[0].ScheduledDate)
+			assert.Equal(t, "Asia/Hong_Kong", first[0].ScheduledDate.Location().String())
+			assert.Equal(t, []service.ExportedExercisePlan{
+				{Id: 10, ExerciseId: 3, ExerciseName: "Squat", MuscleGroup: service.Legs, Position: 1, Sets: 5, Repetitions: 5, Weights: 100, WeightUnit: service.KG},
+				{Id: 11, ExerciseId: 4, ExerciseName: "Bench Press", MuscleGroup: service.Chest, Position: 2, Sets: 3, Repetitions: 8, Weights: 60, WeightUnit: service.KG},
+			}, first[0].ExercisePlans)
+			assert.Nil(t, first[1].Comment)
+			assert.Empty(t, first[1].ExercisePlans)
+		}
+
+		second, err := export.Next(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, second, 1)
+
+		// the last page had no cursor, nothing more is read
+		end, err := export.Next(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, end)
+		mockWPRepo.AssertExpectations(t)
+		mockEPRepo.AssertExpectations(t)
+	})
+
+	t.Run("No workout plans", func(t *testing.T) {
+		mockWPRepo := new(MockWorkoutRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		mockWPRepo.On("ListWorkoutPage", ctx, repository.WorkoutPageFilter{UserId: userID, Limit: 100}).Return(nil, nil, nil).Once()
+
+		exportService := service.NewExportService(mockWPRepo, mockEPRepo, userInUTC(userID))
+		export, err := exportService.ExportWorkouts(ctx, service.ExportQuery{UserId: userID})
+		assert.NoError(t, err)
+
+		page, err := export.Next(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, page)
+		mockEPRepo.AssertNotCalled(t, "ListExercisePlanDetails", mock.Anything, mock.Anything)
+	})
+
+	validationTests := []struct {
+		name  string
+		query service.ExportQuery
+	}{
+		{name: "Unreadable date", query: service.ExportQuery{UserId: userID, From: "05/01/2025"}},
+		{name: "To before from", query: service.ExportQuery{UserId: userID, From: "2025-05-10", To: "2025-05-01"}},
+	}
+
+	for _, tt := range validationTests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWPRepo := new(MockWorkoutRepository)
+			exportService := service.NewExportService(mockWPRepo, new(MockExercisePlanRepository), userInUTC(userID))
+			export, err := exportService.ExportWorkouts(ctx, tt.query)
+
+			var validationErr *apperrors.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Nil(t, export)
+			mockWPRepo.AssertNotCalled(t, "ListWorkoutPage", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("Error from ListExercisePlanDetails", func(t *testing.T) {
+		mockWPRepo := new(MockWorkoutRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		mockWPRepo.On("ListWorkoutPage", ctx, mock.AnythingOfType("repository.WorkoutPageFilter")).Return([]repository.WorkoutPlan{{Id: 1}}, nil, nil).Once()
+		mockEPRepo.On("ListExercisePlanDetails", ctx, []int{1}).Return(nil, errors.New("db error")).Once()
+
+		exportService := service.NewExportService(mockWPRepo, mockEPRepo, userInUTC(userID))
+		export, err := exportService.ExportWorkouts(ctx, service.ExportQuery{UserId: userID})
+		assert.NoError(t, err)
+
+		page, err := export.Next(ctx)
+		assert.EqualError(t, err, "failed to fetch exercise plans: db error")
+		assert.Nil(t, page)
+	})
+}
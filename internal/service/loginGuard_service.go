@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/cache"
+	"workout-tracker-api/internal/repository"
+)
+
+const (
+	loginFailPrefix = "loginfail:"
+	loginLockPrefix = "loginlock:"
+)
+
+// audit events written by the login guard
+const (
+	AuditLoginLocked     = "login.locked"
+	AuditAccountUnlocked = "account.unlocked"
+)
+
+// LoginGuardConfig sets when failed logins lock an account or an IP address. The first lock lasts
+// BaseLockout and every failure after it doubles the next one, up to MaxLockout. Failures are
+// counted for FailureWindow from the first one, so it should be longer than MaxLockout.
+type LoginGuardConfig struct {
+	AccountThreshold int
+	IPThreshold      int
+	BaseLockout      time.Duration
+	MaxLockout       time.Duration
+	FailureWindow    time.Duration
+}
+
+// LoginAttempt identifies a login, UserId is 0 while the email is not matched to an account
+type LoginAttempt struct {
+	Email     string
+	IPAddress string
+	UserId    int
+}
+
+type LoginGuardInterface interface {
+	CheckLogin(ctx context.Context, attempt LoginAttempt) error
+	RecordFailure(ctx context.Context, attempt LoginAttempt) error
+	RecordSuccess(ctx context.Context, attempt LoginAttempt) error
+	UnlockAccount(ctx context.Context, actorId int, userId int) error
+}
+
+type LoginGuard struct {
+	Cache     cache.CacheInterface
+	AuditRepo repository.AuditRepository
+	UserRepo  repository.UserRepository
+	Config    LoginGuardConfig
+}
+
+func NewLoginGuard(c cache.CacheInterface, ar repository.AuditRepository, ur repository.UserRepository, cfg LoginGuardConfig) LoginGuardInterface {
+	return &LoginGuard{
+		Cache:     c,
+		AuditRepo: ar,
+		UserRepo:  ur,
+		Config:    cfg,
+	}
+}
+
+// CheckLogin refuses the attempt with a LockoutError while its account or IP address is locked
+func (g *LoginGuard) CheckLogin(ctx context.Context, attempt LoginAttempt) error {
+	for _, key := range g.lockKeys(attempt) {
+		value, err := g.Cache.GetCache(ctx, loginLockPrefix+key)
+		if err != nil {
+			return fmt.Errorf("failed to check login lock: %w", err)
+		}
+		if value == "" {
+			continue
+		}
+
+		until, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			log.Printf("Ignoring malformed login lock '%s': %v", key, err)
+			continue
+		}
+		if retryAfter := time.Until(time.Unix(until, 0)); retryAfter > 0 {
+			return &apperrors.LockoutError{RetryAfter: retryAfter}
+		}
+	}
+
+	return nil
+}
+
+// RecordFailure counts a failed attempt for the account and the IP address and locks them once
+// they reach their threshold
+func (g *LoginGuard) RecordFailure(ctx context.Context, attempt LoginAttempt) error {
+	thresholds := map[string]int{
+		accountKey(attempt.Email): g.Config.AccountThreshold,
+		ipKey(attempt.IPAddress):  g.Config.IPThreshold,
+	}
+
+	for _, key := range g.lockKeys(attempt) {
+		failures, err := g.Cache.IncrCache(ctx, loginFailPrefix+key, g.Config.FailureWindow)
+		if err != nil {
+			return fmt.Errorf("failed to count failed login: %w", err)
+		}
+
+		threshold := thresholds[key]
+		if threshold <= 0 || failures < int64(threshold) {
+			continue
+		}
+
+		lockout := g.lockoutFor(failures - int64(threshold))
+		until := strconv.FormatInt(time.Now().Add(lockout).Unix(), 10)
+		if err := g.Cache.SaveCache(ctx, loginLockPrefix+key, until, &lockout); err != nil {
+			return fmt.Errorf("failed to lock login: %w", err)
+		}
+
+		log.Printf("Locked login '%s' for %s after %d failed attempts", key, lockout, failures)
+		g.audit(ctx, repository.CreateAuditEntry{
+			Event:     AuditLoginLocked,
+			UserId:    optionalId(attempt.UserId),
+			IPAddress: attempt.IPAddress,
+			Detail:    fmt.Sprintf("%s locked for %s after %d failed attempts", key, lockout, failures),
+		})
+	}
+
+	return nil
+}
+
+// RecordSuccess resets the failures of the account. The IP address keeps its count, one valid
+// login should not clear the attempts made on other accounts.
+func (g *LoginGuard) RecordSuccess(ctx context.Context, attempt LoginAttempt) error {
+	if err := g.Cache.CleanCache(ctx, loginFailPrefix+accountKey(attempt.Email)); err != nil {
+		return fmt.Errorf("failed to reset failed logins: %w", err)
+	}
+	return nil
+}
+
+// UnlockAccount lifts the lock of an account and resets its failures
+func (g *LoginGuard) UnlockAccount(ctx context.Context, actorId int, userId int) error {
+	fetchedUser, err := g.UserRepo.GetUserById(ctx, userId)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	key := accountKey(fetchedUser.Email)
+	for _, prefix := range []string{loginLockPrefix, loginFailPrefix} {
+		if err := g.Cache.CleanCache(ctx, prefix+key); err != nil {
+			return fmt.Errorf("failed to unlock account: %w", err)
+		}
+	}
+
+	g.audit(ctx, repository.CreateAuditEntry{
+		Event:   AuditAccountUnlocked,
+		UserId:  &userId,
+		ActorId: &actorId,
+	})
+
+	return nil
+}
+
+func (g *LoginGuard) lockKeys(attempt LoginAttempt) []string {
+	keys := []string{accountKey(attempt.Email)}
+	if attempt.IPAddress != "" {
+		keys = append(keys, ipKey(attempt.IPAddress))
+	}
+	return keys
+}
+
+// lockoutFor doubles BaseLockout for every failure past the threshold, capped at MaxLockout
+func (g *LoginGuard) lockoutFor(extraFailures int64) time.Duration {
+	lockout := g.Config.BaseLockout
+	for i := int64(0); i < extraFailures && lockout < g.Config.MaxLockout; i++ {
+		lockout *= 2
+	}
+	return min(lockout, g.Config.MaxLockout)
+}
+
+// audit failures are logged, they must not undo the lock or unlock they describe
+func (g *LoginGuard) audit(ctx context.Context, entry repository.CreateAuditEntry) {
+	if err := g.AuditRepo.CreateAuditEntry(ctx, entry); err != nil {
+		log.Printf("Failed to write audit entry: %v", err)
+	}
+}
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+func optionalId(id int) *int {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
@@ -0,0 +1,259 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+	"workout-tracker-api/internal/service"
+)
+
+// MockLoginGuard is a mock implementation of service.LoginGuardInterface
+type MockLoginGuard struct {
+	mock.Mock
+}
+
+func (m *MockLoginGuard) CheckLogin(ctx context.Context, attempt service.LoginAttempt) error {
+	args := m.Called(ctx, attempt)
+	return args.Error(0)
+}
+
+func (m *MockLoginGuard) RecordFailure(ctx context.Context, attempt service.LoginAttempt) error {
+	args := m.Called(ctx, attempt)
+	return args.Error(0)
+}
+
+func (m *MockLoginGuard) RecordSuccess(ctx context.Context, attempt service.LoginAttempt) error {
+	args := m.Called(ctx, attempt)
+	return args.Error(0)
+}
+
+func (m *MockLoginGuard) UnlockAccount(ctx context.Context, actorId int, userId int) error {
+	args := m.Called(ctx, actorId, userId)
+	return args.Error(0)
+}
+
+// openLoginGuard never locks, for tests that are not about lockouts
+func openLoginGuard() *MockLoginGuard {
+	guard := new(MockLoginGuard)
+	guard.On("CheckLogin", mock.Anything, mock.Anything).Return(nil).Maybe()
+	guard.On("RecordFailure", mock.Anything, mock.Anything).Return(nil).Maybe()
+	guard.On("RecordSuccess", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return guard
+}
+
+// MockAuditRepository is a mock implementation of repository.AuditRepository
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) CreateAuditEntry(ctx context.Context, data repository.CreateAuditEntry) error {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+// memoryCache keeps values in a map and only remembers the expiration they were saved with
+type memoryCache struct {
+	values      map[string]string
+	expirations map[string]time.Duration
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{values: map[string]string{}, expirations: map[string]time.Duration{}}
+}
+
+func (c *memoryCache) SaveCache(ctx context.Context, key string, value string, expiration *time.Duration) error {
+	c.values[key] = value
+	if expiration != nil {
+		c.expirations[key] = *expiration
+	}
+	return nil
+}
+
+func (c *memoryCache) GetCache(ctx context.Context, key string) (string, error) {
+	return c.values[key], nil
+}
+
+func (c *memoryCache) ExistCache(ctx context.Context, key string) (bool, error) {
+	_, ok := c.values[key]
+	return ok, nil
+}
+
+func (c *memoryCache) CleanCache(ctx context.Context, key string) error {
+	delete(c.values, key)
+	delete(c.expirations, key)
+	return nil
+}
+
+func (c *memoryCache) LockCache(ctx context.Context, key string, value string, expiration time.Duration) (bool, error) {
+	if _, ok := c.values[key]; ok {
+		return false, nil
+	}
+	c.values[key] = value
+	c.expirations[key] = expiration
+	return true, nil
+}
+
+func (c *memoryCache) UnlockCache(ctx context.Context, key string, value string) error {
+	if c.values[key] == value {
+		delete(c.values, key)
+		delete(c.expirations, key)
+	}
+	return nil
+}
+
+func (c *memoryCache) IncrCache(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	count, _ := strconv.ParseInt(c.values[key], 10, 64)
+	count++
+	c.values[key] = strconv.FormatInt(count, 10)
+	if _, ok := c.expirations[key]; !ok {
+		c.expirations[key] = expiration
+	}
+	return count, nil
+}
+
+var guardConfig = service.LoginGuardConfig{
+	AccountThreshold: 3,
+	IPThreshold:      10,
+	BaseLockout:      time.Minute,
+	MaxLockout:       5 * time.Minute,
+	FailureWindow:    24 * time.Hour,
+}
+
+func TestLoginGuard_RecordFailure(t *testing.T) {
+	ctx := context.Background()
+	attempt := service.LoginAttempt{Email: "Test@Example.com", IPAddress: "203.0.113.7", UserId: 3}
+
+	t.Run("Locks the account at the threshold with exponential backoff", func(t *testing.T) {
+		cache := newMemoryCache()
+		mockAuditRepo := new(MockAuditRepository)
+		guard := service.NewLoginGuard(cache, mockAuditRepo, nil, guardConfig)
+
+		var entries []repository.CreateAuditEntry
+		mockAuditRepo.On("CreateAuditEntry", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { entries = append(entries, args.Get(1).(repository.CreateAuditEntry)) }).
+			Return(nil)
+
+		for range 2 {
+			assert.NoError(t, guard.RecordFailure(ctx, attempt))
+		}
+		assert.NoError(t, guard.CheckLogin(ctx, attempt))
+		assert.Empty(t, entries)
+
+		assert.NoError(t, guard.RecordFailure(ctx, attempt))
+		err := guard.CheckLogin(ctx, attempt)
+		var lockErr *apperrors.LockoutError
+		assert.ErrorAs(t, err, &lockErr)
+		assert.InDelta(t, time.Minute.Seconds(), lockErr.RetryAfter.Seconds(), 2)
+		assert.Equal(t, time.Minute, cache.expirations["loginlock:account:test@example.com"])
+		assert.Equal(t, 24*time.Hour, cache.expirations["loginfail:account:test@example.com"])
+
+		if assert.Len(t, entries, 1) {
+			assert.Equal(t, service.AuditLoginLocked, entries[0].Event)
+			assert.Equal(t, 3, *entries[0].UserId)
+			assert.Equal(t, "203.0.113.7", entries[0].IPAddress)
+		}
+
+		// every further failure doubles the lock until the cap
+		assert.NoError(t, guard.RecordFailure(ctx, attempt))
+		assert.Equal(t, 2*time.Minute, cache.expirations["loginlock:account:test@example.com"])
+		assert.NoError(t, guard.RecordFailure(ctx, attempt))
+		assert.Equal(t, 4*time.Minute, cache.expirations["loginlock:account:test@example.com"])
+		assert.NoError(t, guard.RecordFailure(ctx, attempt))
+		assert.Equal(t, 5*time.Minute, cache.expirations["loginlock:account:test@example.com"])
+	})
+
+	t.Run("Locks the IP address across accounts", func(t *testing.T) {
+		cache := newMemoryCache()
+		mockAuditRepo := new(MockAuditRepository)
+		guard := service.NewLoginGuard(cache, mockAuditRepo, nil, guardConfig)
+		mockAuditRepo.On("CreateAuditEntry", ctx, mock.Anything).Return(nil)
+
+		for i := range guardConfig.IPThreshold {
+			other := service.LoginAttempt{Email: "user" + strconv.Itoa(i) + "@example.com", IPAddress: "198.51.100.2"}
+			assert.NoError(t, guard.RecordFailure(ctx, other))
+		}
+
+		err := guard.CheckLogin(ctx, service.LoginAttempt{Email: "fresh@example.com", IPAddress: "198.51.100.2"})
+		assert.ErrorIs(t, err, apperrors.ErrTooManyRequests)
+		assert.NoError(t, guard.CheckLogin(ctx, service.LoginAttempt{Email: "fresh@example.com", IPAddress: "203.0.113.7"}))
+	})
+
+	t.Run("Audit error does not undo the lock", func(t *testing.T) {
+		cache := newMemoryCache()
+		mockAuditRepo := new(MockAuditRepository)
+		guard := service.NewLoginGuard(cache, mockAuditRepo, nil, guardConfig)
+		mockAuditRepo.On("CreateAuditEntry", ctx, mock.Anything).Return(errors.New("insert failed"))
+
+		for range guardConfig.AccountThreshold {
+			assert.NoError(t, guard.RecordFailure(ctx, attempt))
+		}
+		assert.ErrorIs(t, guard.CheckLogin(ctx, attempt), apperrors.ErrTooManyRequests)
+	})
+}
+
+func TestLoginGuard_RecordSuccess(t *testing.T) {
+	ctx := context.Background()
+	cache := newMemoryCache()
+	guard := service.NewLoginGuard(cache, new(MockAuditRepository), nil, guardConfig)
+	attempt := service.LoginAttempt{Email: "test@example.com", IPAddress: "203.0.113.7"}
+
+	for range guardConfig.AccountThreshold - 1 {
+		assert.NoError(t, guard.RecordFailure(ctx, attempt))
+	}
+	assert.NoError(t, guard.RecordSuccess(ctx, attempt))
+
+	// the account starts over, the address keeps its count
+	assert.Empty(t, cache.values["loginfail:account:test@example.com"])
+	assert.Equal(t, strconv.Itoa(guardConfig.AccountThreshold-1), cache.values["loginfail:ip:203.0.113.7"])
+}
+
+func TestLoginGuard_UnlockAccount(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		cache := newMemoryCache()
+		mockAuditRepo := new(MockAuditRepository)
+		mockUserRepo := new(MockUserRepository)
+		guard := service.NewLoginGuard(cache, mockAuditRepo, mockUserRepo, guardConfig)
+		attempt := service.LoginAttempt{Email: "test@example.com"}
+
+		mockAuditRepo.On("CreateAuditEntry", ctx, mock.MatchedBy(func(e repository.CreateAuditEntry) bool {
+			return e.Event == service.AuditLoginLocked
+		})).Return(nil).Once()
+		for range guardConfig.AccountThreshold {
+			assert.NoError(t, guard.RecordFailure(ctx, attempt))
+		}
+		assert.Error(t, guard.CheckLogin(ctx, attempt))
+
+		mockUserRepo.On("GetUserById", ctx, 3).Return(&repository.User{Id: 3, Email: "Test@example.com"}, nil).Once()
+		mockAuditRepo.On("CreateAuditEntry", ctx, mock.MatchedBy(func(e repository.CreateAuditEntry) bool {
+			return e.Event == service.AuditAccountUnlocked && *e.UserId == 3 && *e.ActorId == 1
+		})).Return(nil).Once()
+
+		err := guard.UnlockAccount(ctx, 1, 3)
+		assert.NoError(t, err)
+		assert.NoError(t, guard.CheckLogin(ctx, attempt))
+		assert.Empty(t, cache.values["loginfail:account:test@example.com"])
+		mockAuditRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockAuditRepo := new(MockAuditRepository)
+		guard := service.NewLoginGuard(newMemoryCache(), mockAuditRepo, mockUserRepo, guardConfig)
+
+		mockUserRepo.On("GetUserById", ctx, 9).Return(nil, apperrors.ErrNotFound).Once()
+
+		err := guard.UnlockAccount(ctx, 1, 9)
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		mockAuditRepo.AssertNotCalled(t, "CreateAuditEntry", mock.Anything, mock.Anything)
+	})
+}
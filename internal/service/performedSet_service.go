@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+)
+
+type PerformedSet struct {
+	Id             int        `json:"id"`
+	ExercisePlanId int        `json:"exercisePlanId"`
+	SetNumber      int        `json:"setNumber"`
+	Repetitions    int        `json:"repetitions"`
+	Weights        float32    `json:"weights"`
+	WeightUnit     WeightUnit `json:"weightUnit"`
+	RPE            *float32   `json:"rpe,omitempty"`
+	CompletedAt    time.Time  `json:"completedAt"`
+}
+
+// ExercisePlanLog pairs what was planned with the sets that were actually performed.
+type ExercisePlanLog struct {
+	ExercisePlan  ExercisePlan   `json:"exercisePlan"`
+	PerformedSets []PerformedSet `json:"performedSets"`
+}
+
+type PerformedSetCreate struct {
+	SetNumber   *int       `json:"setNumber,omitempty"`
+	Repetitions int        `json:"repetitions"`
+	Weights     float32    `json:"weights"`
+	WeightUnit  WeightUnit `json:"weightUnit"`
+	RPE         *float32   `json:"rpe,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+func (data *PerformedSetCreate) Validate() error {
+	if data.SetNumber != nil && (*data.SetNumber <= 0 || *data.SetNumber > 999) {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, "set number can not be zero or too large")
+	}
+
+	return validatePerformance(data.Repetitions, data.Weights, data.WeightUnit, data.RPE)
+}
+
+type PerformedSetUpdate struct {
+	Id          int        `json:"id"`
+	Repetitions int        `json:"repetitions"`
+	Weights     float32    `json:"weights"`
+	WeightUnit  WeightUnit `json:"weightUnit"`
+	RPE         *float32   `json:"rpe,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+func (data *PerformedSetUpdate) Validate() error {
+	return validatePerformance(data.Repetitions, data.Weights, data.WeightUnit, data.RPE)
+}
+
+func validatePerformance(repetitions int, weights float32, unit WeightUnit, rpe *float32) error {
+	if repetitions < 0 {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, "repetitions can not be negative")
+	}
+
+	if weights < 0 {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, "weights can not be negative")
+	}
+
+	switch unit {
+	case KG, LBS, OTHER:
+	default:
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, "invalide weight unit")
+	}
+
+	// RPE follows the usual 1 to 10 scale
+	if rpe != nil && (*rpe < 1 || *rpe > 10) {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, "rpe must be between 1 and 10")
+	}
+
+	return nil
+}
+
+type PerformedSetServiceInterface interface {
+	LogPerformedSet(ctx context.Context, workoutId int, exercisePlanId int, data PerformedSetCreate) (*PerformedSet, error)
+	ListPerformedSets(ctx context.Context, workoutId int, exercisePlanId int) (*ExercisePlanLog, error)
+	UpdatePerformedSet(ctx context.Context, workoutId int, exercisePlanId int, data PerformedSetUpdate) (*PerformedSet, error)
+	DeletePerformedSet(ctx context.Context, workoutId int, exercisePlanId int, setId int) error
+}
+
+type PerformedSetService struct {
+	PSRepo repository.PerformedSetRepository
+	EPRepo repository.ExercisePlanRepository
+}
+
+func NewPSService(pr repository.PerformedSetRepository, er repository.ExercisePlanRepository) PerformedSetServiceInterface {
+	return &PerformedSetService{
+		PSRepo: pr,
+		EPRepo: er,
+	}
+}
+
+func (ps *PerformedSetService) LogPerformedSet(ctx context.Context, workoutId int, exercisePlanId int, data PerformedSetCreate) (*PerformedSet, error) {
+	if err := data.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate: %w", err)
+	}
+
+	if _, err := ps.getExercisePlanInWorkout(ctx, workoutId, exercisePlanId); err != nil {
+		return nil, err
+	}
+
+	performedSet, err := ps.PSRepo.CreatePerformedSet(ctx, repository.CreatePS{
+		SetNumber:   data.SetNumber,
+		Repetitions: data.Repetitions,
+		Weights:     data.Weights,
+		WeightUnit:  repository.WeightUnit(data.WeightUnit),
+		RPE:         data.RPE,
+		CompletedAt: data.CompletedAt,
+	}, exercisePlanId)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to log performed set: %w", err)
+	}
+
+	return toServicePS(performedSet), nil
+}
+
+func (ps *PerformedSetService) ListPerformedSets(ctx context.Context, workoutId int, exercisePlanId int) (*ExercisePlanLog, error) {
+	exercisePlan, err := ps.getExercisePlanInWorkout(ctx, workoutId, exercisePlanId)
+	if err != nil {
+		return nil, err
+	}
+
+	psList, err := ps.PSRepo.ListPerformedSets(ctx, exercisePlanId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch performed sets: %w", err)
+	}
+
+	var performedSets []PerformedSet
+	for _, set := range psList {
+		performedSets = append(performedSets, *toServicePS(&set))
+	}
+
+	return &ExercisePlanLog{
+		ExercisePlan:  *toServiceEP(exercisePlan),
+		PerformedSets: performedSets,
+	}, nil
+}
+
+func (ps *PerformedSetService) UpdatePerformedSet(ctx context.Context, workoutId int, exercisePlanId int, data PerformedSetUpdate) (*PerformedSet, error) {
+	if err := data.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate performed set id '%v': %w", data.Id, err)
+	}
+
+	if err := ps.checkSetInExercisePlan(ctx, workoutId, exercisePlanId, data.Id); err != nil {
+		return nil, err
+	}
+
+	performedSet, err := ps.PSRepo.UpdatePerformedSet(ctx, repository.UpdatePS{
+		Id:          data.Id,
+		Repetitions: &data.Repetitions,
+		Weights:     &data.Weights,
+		WeightUnit:  (*repository.WeightUnit)(&data.WeightUnit),
+		RPE:         data.RPE,
+		CompletedAt: data.CompletedAt,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to update performed set id '%v': %w", data.Id, err)
+	}
+
+	return toServicePS(performedSet), nil
+}
+
+func (ps *PerformedSetService) DeletePerformedSet(ctx context.Context, workoutId int, exercisePlanId int, setId int) error {
+	if err := ps.checkSetInExercisePlan(ctx, workoutId, exercisePlanId, setId); err != nil {
+		return err
+	}
+
+	if err := ps.PSRepo.DeletePerformedSetById(ctx, setId); err != nil {
+		return fmt.Errorf("failed to delete performed set id '%v': %w", setId, err)
+	}
+
+	return nil
+}
+
+// getExercisePlanInWorkout makes sure the exercise plan is part of the workout plan, so that
+// the ownership checked on the workout plan also covers the exercise plan.
+func (ps *PerformedSetService) getExercisePlanInWorkout(ctx context.Context, workoutId int, exercisePlanId int) (*repository.ExercisePlan, error) {
+	exercisePlan, err := ps.EPRepo.GetExercisePlanById(ctx, exercisePlanId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch exercise plan id '%v': %w", exercisePlanId, err)
+	}
+
+	if exercisePlan.WorkoutPlanId != workoutId {
+		return nil, fmt.Errorf("exercise plan id '%v' is not in workout plan id '%v': %w", exercisePlanId, workoutId, apperrors.ErrNotFound)
+	}
+
+	return exercisePlan, nil
+}
+
+func (ps *PerformedSetService) checkSetInExercisePlan(ctx context.Context, workoutId int, exercisePlanId int, setId int) error {
+	if _, err := ps.getExercisePlanInWorkout(ctx, workoutId, exercisePlanId); err != nil {
+		return err
+	}
+
+	performedSet, err := ps.PSRepo.GetPerformedSetById(ctx, setId)
+	if err != nil {
+		return fmt.Errorf("failed to fetch performed set id '%v': %w", setId, err)
+	}
+
+	if performedSet.ExercisePlanId != exercisePlanId {
+		return fmt.Errorf("performed set id '%v' is not in exercise plan id '%v': %w", setId, exercisePlanId, apperrors.ErrNotFound)
+	}
+
+	return nil
+}
+
+func toServicePS(ps *repository.PerformedSet) *PerformedSet {
+	if ps == nil {
+		return nil
+	}
+
+	var rpe *float32
+	if ps.RPE.Valid {
+		value := float32(ps.RPE.Float64)
+		rpe = &value
+	}
+
+	return &PerformedSet{
+		Id:             ps.Id,
+		ExercisePlanId: ps.ExercisePlanId,
+		SetNumber:      ps.SetNumber,
+		Repetitions:    ps.Repetitions,
+		Weights:        ps.Weights,
+		WeightUnit:     WeightUnit(ps.WeightUnit),
+		RPE:            rpe,
+		CompletedAt:    ps.CompletedAt,
+	}
+}
@@ -0,0 +1,240 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+	"workout-tracker-api/internal/service"
+)
+
+// MockPerformedSetRepository is a mock implementation of repository.PerformedSetRepository
+type MockPerformedSetRepository struct {
+	mock.Mock
+}
+
+func (m *MockPerformedSetRepository) CreatePerformedSet(ctx context.Context, data repository.CreatePS, exercisePlanID int) (*repository.PerformedSet, error) {
+	args := m.Called(ctx, data, exercisePlanID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.PerformedSet), args.Error(1)
+}
+func (m *MockPerformedSetRepository) GetPerformedSetById(ctx context.Context, id int) (*repository.PerformedSet, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.PerformedSet), args.Error(1)
+}
+func (m *MockPerformedSetRepository) UpdatePerformedSet(ctx context.Context, data repository.UpdatePS) (*repository.PerformedSet, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.PerformedSet), args.Error(1)
+}
+func (m *MockPerformedSetRepository) DeletePerformedSetById(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+func (m *MockPerformedSetRepository) ListPerformedSets(ctx context.Context, exercisePlanID int) ([]repository.PerformedSet, error) {
+	args := m.Called(ctx, exercisePlanID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.PerformedSet), args.Error(1)
+}
+
+func TestPerformedSetService_LogPerformedSet(t *testing.T) {
+	ctx := context.Background()
+	completedAt := time.Now().Truncate(time.Second)
+	exercisePlan := &repository.ExercisePlan{Id: 5, ExerciseId: 10, WorkoutPlanId: 1, Sets: 3, Repetitions: 10, Weights: 50, WeightUnit: repository.KG}
+	rpe := float32(8.5)
+
+	t.Run("Successful log", func(t *testing.T) {
+		mockPSRepo := new(MockPerformedSetRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		psService := service.NewPSService(mockPSRepo, mockEPRepo)
+
+		input := service.PerformedSetCreate{Repetitions: 10, Weights: 52.5, WeightUnit: service.KG, RPE: &rpe}
+
+		mockEPRepo.On("GetExercisePlanById", ctx, 5).Return(exercisePlan, nil).Once()
+		mockPSRepo.On("CreatePerformedSet", ctx, repository.CreatePS{
+			Repetitions: 10, Weights: 52.5, WeightUnit: repository.KG, RPE: &rpe,
+		}, 5).Return(&repository.PerformedSet{
+			Id: 1, ExercisePlanId: 5, SetNumber: 1, Repetitions: 10, Weights: 52.5, WeightUnit: repository.KG,
+			RPE: sql.NullFloat64{Float64: 8.5, Valid: true}, CompletedAt: completedAt,
+		}, nil).Once()
+
+		ps, err := psService.LogPerformedSet(ctx, 1, 5, input)
+		assert.NoError(t, err)
+		assert.Equal(t, &service.PerformedSet{
+			Id: 1, ExercisePlanId: 5, SetNumber: 1, Repetitions: 10, Weights: 52.5, WeightUnit: service.KG,
+			RPE: &rpe, CompletedAt: completedAt,
+		}, ps)
+		mockEPRepo.AssertExpectations(t)
+		mockPSRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid rpe", func(t *testing.T) {
+		mockPSRepo := new(MockPerformedSetRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		psService := service.NewPSService(mockPSRepo, mockEPRepo)
+
+		tooHard := float32(11)
+		input := service.PerformedSetCreate{Repetitions: 10, Weights: 50, WeightUnit: service.KG, RPE: &tooHard}
+
+		ps, err := psService.LogPerformedSet(ctx, 1, 5, input)
+		assert.Nil(t, ps)
+		var validationErr *apperrors.ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, apperrors.INVALID_SETTING, validationErr.Field)
+		mockEPRepo.AssertNotCalled(t, "GetExercisePlanById")
+		mockPSRepo.AssertNotCalled(t, "CreatePerformedSet")
+	})
+
+	t.Run("Exercise plan belongs to another workout", func(t *testing.T) {
+		mockPSRepo := new(MockPerformedSetRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		psService := service.NewPSService(mockPSRepo, mockEPRepo)
+
+		input := service.PerformedSetCreate{Repetitions: 10, Weights: 50, WeightUnit: service.KG}
+
+		mockEPRepo.On("GetExercisePlanById", ctx, 5).Return(exercisePlan, nil).Once()
+
+		ps, err := psService.LogPerformedSet(ctx, 2, 5, input)
+		assert.Nil(t, ps)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+		mockPSRepo.AssertNotCalled(t, "CreatePerformedSet")
+	})
+}
+
+func TestPerformedSetService_ListPerformedSets(t *testing.T) {
+	ctx := context.Background()
+	completedAt := time.Now().Truncate(time.Second)
+	exercisePlan := &repository.ExercisePlan{Id: 5, ExerciseId: 10, WorkoutPlanId: 1, Sets: 3, Repetitions: 10, Weights: 50, WeightUnit: repository.KG}
+
+	t.Run("Successful listing", func(t *testing.T) {
+		mockPSRepo := new(MockPerformedSetRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		psService := service.NewPSService(mockPSRepo, mockEPRepo)
+
+		mockEPRepo.On("GetExercisePlanById", ctx, 5).Return(exercisePlan, nil).Once()
+		mockPSRepo.On("ListPerformedSets", ctx, 5).Return([]repository.PerformedSet{
+			{Id: 1, ExercisePlanId: 5, SetNumber: 1, Repetitions: 10, Weights: 50, WeightUnit: repository.KG, CompletedAt: completedAt},
+			{Id: 2, ExercisePlanId: 5, SetNumber: 2, Repetitions: 9, Weights: 50, WeightUnit: repository.KG, CompletedAt: completedAt},
+		}, nil).Once()
+
+		epLog, err := psService.ListPerformedSets(ctx, 1, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, epLog.ExercisePlan.Id)
+		assert.Equal(t, 3, epLog.ExercisePlan.Sets)
+		assert.Len(t, epLog.PerformedSets, 2)
+		assert.Equal(t, 9, epLog.PerformedSets[1].Repetitions)
+		assert.Nil(t, epLog.PerformedSets[0].RPE)
+		mockEPRepo.AssertExpectations(t)
+		mockPSRepo.AssertExpectations(t)
+	})
+
+	t.Run("Exercise plan not found", func(t *testing.T) {
+		mockPSRepo := new(MockPerformedSetRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		psService := service.NewPSService(mockPSRepo, mockEPRepo)
+
+		mockEPRepo.On("GetExercisePlanById", ctx, 5).Return(nil, apperrors.ErrNotFound).Once()
+
+		epLog, err := psService.ListPerformedSets(ctx, 1, 5)
+		assert.Nil(t, epLog)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+		mockPSRepo.AssertNotCalled(t, "ListPerformedSets")
+	})
+}
+
+func TestPerformedSetService_UpdatePerformedSet(t *testing.T) {
+	ctx := context.Background()
+	completedAt := time.Now().Truncate(time.Second)
+	exercisePlan := &repository.ExercisePlan{Id: 5, ExerciseId: 10, WorkoutPlanId: 1, Sets: 3, Repetitions: 10, Weights: 50, WeightUnit: repository.KG}
+
+	t.Run("Successful update clears an omitted RPE", func(t *testing.T) {
+		mockPSRepo := new(MockPerformedSetRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		psService := service.NewPSService(mockPSRepo, mockEPRepo)
+
+		input := service.PerformedSetUpdate{Id: 2, Repetitions: 8, Weights: 55, WeightUnit: service.KG}
+		unit := repository.KG
+
+		mockEPRepo.On("GetExercisePlanById", ctx, 5).Return(exercisePlan, nil).Once()
+		mockPSRepo.On("GetPerformedSetById", ctx, 2).Return(&repository.PerformedSet{Id: 2, ExercisePlanId: 5}, nil).Once()
+		mockPSRepo.On("UpdatePerformedSet", ctx, repository.UpdatePS{
+			Id: 2, Repetitions: &input.Repetitions, Weights: &input.Weights, WeightUnit: &unit,
+		}).Return(&repository.PerformedSet{
+			Id: 2, ExercisePlanId: 5, SetNumber: 2, Repetitions: 8, Weights: 55, WeightUnit: repository.KG, CompletedAt: completedAt,
+		}, nil).Once()
+
+		ps, err := psService.UpdatePerformedSet(ctx, 1, 5, input)
+		assert.NoError(t, err)
+		assert.Equal(t, 8, ps.Repetitions)
+		assert.Equal(t, float32(55), ps.Weights)
+		assert.Nil(t, ps.RPE)
+		mockEPRepo.AssertExpectations(t)
+		mockPSRepo.AssertExpectations(t)
+	})
+
+	t.Run("Set belongs to another exercise plan", func(t *testing.T) {
+		mockPSRepo := new(MockPerformedSetRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		psService := service.NewPSService(mockPSRepo, mockEPRepo)
+
+		input := service.PerformedSetUpdate{Id: 2, Repetitions: 8, Weights: 55, WeightUnit: service.KG}
+
+		mockEPRepo.On("GetExercisePlanById", ctx, 5).Return(exercisePlan, nil).Once()
+		mockPSRepo.On("GetPerformedSetById", ctx, 2).Return(&repository.PerformedSet{Id: 2, ExercisePlanId: 6}, nil).Once()
+
+		ps, err := psService.UpdatePerformedSet(ctx, 1, 5, input)
+		assert.Nil(t, ps)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+		mockPSRepo.AssertNotCalled(t, "UpdatePerformedSet")
+	})
+}
+
+func TestPerformedSetService_DeletePerformedSet(t *testing.T) {
+	ctx := context.Background()
+	exercisePlan := &repository.ExercisePlan{Id: 5, ExerciseId: 10, WorkoutPlanId: 1, Sets: 3, Repetitions: 10, Weights: 50, WeightUnit: repository.KG}
+
+	t.Run("Successful deletion", func(t *testing.T) {
+		mockPSRepo := new(MockPerformedSetRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		psService := service.NewPSService(mockPSRepo, mockEPRepo)
+
+		mockEPRepo.On("GetExercisePlanById", ctx, 5).Return(exercisePlan, nil).Once()
+		mockPSRepo.On("GetPerformedSetById", ctx, 2).Return(&repository.PerformedSet{Id: 2, ExercisePlanId: 5}, nil).Once()
+		mockPSRepo.On("DeletePerformedSetById", ctx, 2).Return(nil).Once()
+
+		err := psService.DeletePerformedSet(ctx, 1, 5, 2)
+		assert.NoError(t, err)
+		mockPSRepo.AssertExpectations(t)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		mockPSRepo := new(MockPerformedSetRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		psService := service.NewPSService(mockPSRepo, mockEPRepo)
+
+		dbErr := errors.New("db error")
+		mockEPRepo.On("GetExercisePlanById", ctx, 5).Return(exercisePlan, nil).Once()
+		mockPSRepo.On("GetPerformedSetById", ctx, 2).Return(&repository.PerformedSet{Id: 2, ExercisePlanId: 5}, nil).Once()
+		mockPSRepo.On("DeletePerformedSetById", ctx, 2).Return(dbErr).Once()
+
+		err := psService.DeletePerformedSet(ctx, 1, 5, 2)
+		assert.ErrorIs(t, err, dbErr)
+		mockPSRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+	"workout-tracker-api/internal/repository"
+)
+
+type PRKind string
+
+const (
+	PR_WEIGHT        PRKind = "weight"
+	PR_REPS          PRKind = "reps"
+	PR_ESTIMATED_1RM PRKind = "estimated_1rm"
+	PR_VOLUME        PRKind = "volume"
+)
+
+// LbsToKg converts pounds to kilograms, records are always compared and stored in kg
+const LbsToKg = 0.45359237
+
+// MaxRepsFor1RM is the highest rep count the Epley formula is trusted for
+const MaxRepsFor1RM = 12
+
+type PersonalRecord struct {
+	Id            int       `json:"id"`
+	ExerciseId    int       `json:"exerciseId"`
+	WorkoutPlanId int       `json:"workoutPlanId"`
+	Kind          PRKind    `json:"kind"`
+	Value         float64   `json:"value"`
+	WeightKg      *float64  `json:"weightKg,omitempty"`
+	PreviousValue *float64  `json:"previousValue,omitempty"`
+	AchievedAt    time.Time `json:"achievedAt"`
+}
+
+type PersonalRecordServiceInterface interface {
+	DetectPersonalRecords(ctx context.Context, workoutId int) ([]PersonalRecord, error)
+	ListPersonalRecords(ctx context.Context, userId int, exerciseId *int) ([]PersonalRecord, error)
+}
+
+type PersonalRecordService struct {
+	WPRepo repository.WorkoutRepository
+	EPRepo repository.ExercisePlanRepository
+	PSRepo repository.PerformedSetRepository
+	PRRepo repository.PersonalRecordRepository
+}
+
+func NewPRService(wr repository.WorkoutRepository, er repository.ExercisePlanRepository, pr repository.PerformedSetRepository, rr repository.PersonalRecordRepository) PersonalRecordServiceInterface {
+	return &PersonalRecordService{
+		WPRepo: wr,
+		EPRepo: er,
+		PSRepo: pr,
+		PRRepo: rr,
+	}
+}
+
+// lift is one set of an exercise with the weight already converted to kg
+type lift struct {
+	reps     int
+	weightKg float64
+}
+
+// recordKey identifies a record line: reps records are kept per weight, the others per exercise
+type recordKey struct {
+	exerciseId int
+	kind       PRKind
+	weightKg   float64
+}
+
+// DetectPersonalRecords compares the lifts of a workout against the best records of the user
+// and stores every record that was beaten. The performed sets are used when they were logged,
+// otherwise the planned sets count as done.
+func (ps *PersonalRecordService) DetectPersonalRecords(ctx context.Context, workoutId int) ([]PersonalRecord, error) {
+	workout, err := ps.WPRepo.GetWorkoutById(ctx, workoutId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workout plan id '%v': %w", workoutId, err)
+	}
+
+	epList, err := ps.EPRepo.ListExercisePlans(ctx, workoutId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch exercise plans: %w", err)
+	}
+
+	var exerciseIds []int
+	lifts := make(map[int][]lift)
+	for _, ep := range epList {
+		psList, err := ps.PSRepo.ListPerformedSets(ctx, ep.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch performed sets of exercise plan id '%v': %w", ep.Id, err)
+		}
+
+		var epLifts []lift
+		if len(psList) > 0 {
+			for _, set := range psList {
+				if l, ok := toLift(set.Repetitions, set.Weights, WeightUnit(set.WeightUnit)); ok {
+					epLifts = append(epLifts, l)
+				}
+			}
+		} else if l, ok := toLift(ep.Repetitions, ep.Weights, WeightUnit(ep.WeightUnit)); ok {
+			for i := 0; i < ep.Sets; i++ {
+				epLifts = append(epLifts, l)
+			}
+		}
+
+		if len(epLifts) == 0 {
+			continue
+		}
+		if _, seen := lifts[ep.ExerciseId]; !seen {
+			exerciseIds = append(exerciseIds, ep.ExerciseId)
+		}
+		lifts[ep.ExerciseId] = append(lifts[ep.ExerciseId], epLifts...)
+	}
+
+	if len(exerciseIds) == 0 {
+		return nil, nil
+	}
+
+	bestList, err := ps.PRRepo.ListBestRecords(ctx, workout.UserId, exerciseIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch best personal records: %w", err)
+	}
+
+	best := make(map[recordKey]float64)
+	for _, pr := range bestList {
+		best[recordKey{exerciseId: pr.ExerciseId, kind: PRKind(pr.Kind), weightKg: pr.WeightKg.Float64}] = pr.Value
+	}
+
+	var prsCreate []repository.CreatePR
+	for _, exerciseId := range exerciseIds {
+		for _, candidate := range recordCandidates(exerciseId, lifts[exerciseId]) {
+			prCreate := repository.CreatePR{
+				UserId:        workout.UserId,
+				ExerciseId:    exerciseId,
+				WorkoutPlanId: workoutId,
+				Kind:          repository.PRKind(candidate.key.kind),
+				Value:         candidate.value,
+			}
+
+			if previous, ok := best[candidate.key]; ok {
+				if candidate.value <= previous {
+					continue
+				}
+				prCreate.PreviousValue = &previous
+			}
+
+			if candidate.key.kind == PR_REPS {
+				weightKg := candidate.key.weightKg
+				prCreate.WeightKg = &weightKg
+			}
+
+			prsCreate = append(prsCreate, prCreate)
+		}
+	}
+
+	if len(prsCreate) == 0 {
+		return nil, nil
+	}
+
+	created, err := ps.PRRepo.CreatePersonalRecords(ctx, prsCreate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save personal records: %w", err)
+	}
+
+	return toServicePRs(created), nil
+}
+
+func (ps *PersonalRecordService) ListPersonalRecords(ctx context.Context, userId int, exerciseId *int) ([]PersonalRecord, error) {
+	prList, err := ps.PRRepo.ListPersonalRecords(ctx, userId, exerciseId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch personal records: %w", err)
+	}
+
+	return toServicePRs(prList), nil
+}
+
+type recordCandidate struct {
+	key   recordKey
+	value float64
+}
+
+// recordCandidates works out the best weight, the best reps per weight, the best estimated 1RM
+// and the total volume of an exercise in one workout
+func recordCandidates(exerciseId int, lifts []lift) []recordCandidate {
+	var bestWeight, best1RM, volume float64
+	repsAtWeight := make(map[float64]int)
+
+	for _, l := range lifts {
+		bestWeight = math.Max(bestWeight, l.weightKg)
+		if l.reps > repsAtWeight[l.weightKg] {
+			repsAtWeight[l.weightKg] = l.reps
+		}
+		if l.reps <= MaxRepsFor1RM {
+			best1RM = math.Max(best1RM, EstimateOneRepMax(l.weightKg, l.reps))
+		}
+		volume += l.weightKg * float64(l.reps)
+	}
+
+	candidates := []recordCandidate{
+		{key: recordKey{exerciseId: exerciseId, kind: PR_WEIGHT}, value: bestWeight},
+	}
+
+	weights := make([]float64, 0, len(repsAtWeight))
+	for weight := range repsAtWeight {
+		weights = append(weights, weight)
+	}
+	sort.Float64s(weights)
+	for _, weight := range weights {
+		candidates = append(candidates, recordCandidate{
+			key:   recordKey{exerciseId: exerciseId, kind: PR_REPS, weightKg: weight},
+			value: float64(repsAtWeight[weight]),
+		})
+	}
+
+	if best1RM > 0 {
+		candidates = append(candidates, recordCandidate{
+			key:   recordKey{exerciseId: exerciseId, kind: PR_ESTIMATED_1RM},
+			value: roundKg(best1RM),
+		})
+	}
+
+	return append(candidates, recordCandidate{
+		key:   recordKey{exerciseId: exerciseId, kind: PR_VOLUME},
+		value: roundKg(volume),
+	})
+}
+
+// EstimateOneRepMax uses the Epley formula, a single rep is taken as it is
+func EstimateOneRepMax(weightKg float64, reps int) float64 {
+	return EPLEY.Estimate(weightKg, reps)
+}
+
+// toLift skips sets that can not make a record: no reps, no load or a unit that can not be converted
+func toLift(reps int, weights float32, unit WeightUnit) (lift, bool) {
+	weightKg, ok := ToKg(weights, unit)
+	if !ok || reps <= 0 || weightKg <= 0 {
+		return lift{}, false
+	}
+	return lift{reps: reps, weightKg: weightKg}, true
+}
+
+// ToKg converts a weight to kg, rounded to 10 grams so equal weights logged in lbs compare equal
+func ToKg(weights float32, unit WeightUnit) (float64, bool) {
+	switch unit {
+	case KG:
+		return roundKg(float64(weights)), true
+	case LBS:
+		return roundKg(float64(weights) * LbsToKg), true
+	default:
+		return 0, false
+	}
+}
+
+// FromKg converts a weight in kg to the given unit, rounded to two decimals
+func FromKg(weightKg float64, unit WeightUnit) float64 {
+	if unit == LBS {
+		return roundKg(weightKg / LbsToKg)
+	}
+	return roundKg(weightKg)
+}
+
+func roundKg(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func toServicePRs(prList []repository.PersonalRecord) []PersonalRecord {
+	var result []PersonalRecord
+	for _, pr := range prList {
+		result = append(result, PersonalRecord{
+			Id:            pr.Id,
+			ExerciseId:    pr.ExerciseId,
+			WorkoutPlanId: pr.WorkoutPlanId,
+			Kind:          PRKind(pr.Kind),
+			Value:         pr.Value,
+			WeightKg:      nullFloatToPtr(pr.WeightKg),
+			PreviousValue: nullFloatToPtr(pr.PreviousValue),
+			AchievedAt:    pr.AchievedAt,
+		})
+	}
+	return result
+}
+
+func nullFloatToPtr(value sql.NullFloat64) *float64 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Float64
+}
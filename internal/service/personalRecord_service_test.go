@@ -0,0 +1,215 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+	"workout-tracker-api/internal/service"
+)
+
+// MockPersonalRecordRepository is a mock implementation of repository.PersonalRecordRepository
+type MockPersonalRecordRepository struct {
+	mock.Mock
+}
+
+func (m *MockPersonalRecordRepository) CreatePersonalRecords(ctx context.Context, data []repository.CreatePR) ([]repository.PersonalRecord, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.PersonalRecord), args.Error(1)
+}
+func (m *MockPersonalRecordRepository) ListPersonalRecords(ctx context.Context, userId int, exerciseId *int) ([]repository.PersonalRecord, error) {
+	args := m.Called(ctx, userId, exerciseId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.PersonalRecord), args.Error(1)
+}
+func (m *MockPersonalRecordRepository) ListBestRecords(ctx context.Context, userId int, exerciseIds []int) ([]repository.PersonalRecord, error) {
+	args := m.Called(ctx, userId, exerciseIds)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.PersonalRecord), args.Error(1)
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
+
+func TestPersonalRecordService_DetectPersonalRecords(t *testing.T) {
+	ctx := context.Background()
+	workoutID := 1
+	userID := 5
+	workout := &repository.WorkoutPlan{Id: workoutID, UserId: userID, Status: repository.COMPLETED}
+	epList := []repository.ExercisePlan{
+		{Id: 5, ExerciseId: 10, WorkoutPlanId: workoutID, Sets: 3, Repetitions: 5, Weights: 100, WeightUnit: repository.KG},
+		{Id: 6, ExerciseId: 11, WorkoutPlanId: workoutID, Sets: 3, Repetitions: 10, Weights: 100, WeightUnit: repository.LBS},
+		{Id: 7, ExerciseId: 12, WorkoutPlanId: workoutID, Sets: 3, Repetitions: 10, Weights: 5, WeightUnit: repository.OTHER},
+	}
+	performedSets := []repository.PerformedSet{
+		{Id: 1, ExercisePlanId: 5, SetNumber: 1, Repetitions: 5, Weights: 100, WeightUnit: repository.KG},
+		{Id: 2, ExercisePlanId: 5, SetNumber: 2, Repetitions: 8, Weights: 80, WeightUnit: repository.KG},
+		{Id: 3, ExercisePlanId: 5, SetNumber: 3, Repetitions: 0, Weights: 120, WeightUnit: repository.KG},
+	}
+
+	tests := []struct {
+		name          string
+		mockSetup     func(*MockWorkoutRepository, *MockExercisePlanRepository, *MockPerformedSetRepository, *MockPersonalRecordRepository)
+		expectedCount int
+		expectedError string
+	}{
+		{
+			name: "Beaten and first records are saved in kg",
+			mockSetup: func(mwr *MockWorkoutRepository, mer *MockExercisePlanRepository, mpr *MockPerformedSetRepository, mrr *MockPersonalRecordRepository) {
+				mwr.On("GetWorkoutById", ctx, workoutID).Return(workout, nil).Once()
+				mer.On("ListExercisePlans", ctx, workoutID).Return(epList, nil).Once()
+				mpr.On("ListPerformedSets", ctx, 5).Return(performedSets, nil).Once()
+				mpr.On("ListPerformedSets", ctx, 6).Return([]repository.PerformedSet{}, nil).Once()
+				mpr.On("ListPerformedSets", ctx, 7).Return([]repository.PerformedSet{}, nil).Once()
+				mrr.On("ListBestRecords", ctx, userID, []int{10, 11}).Return([]repository.PersonalRecord{
+					{ExerciseId: 10, Kind: repository.PR_WEIGHT, Value: 100},
+					{ExerciseId: 10, Kind: repository.PR_REPS, Value: 4, WeightKg: sql.NullFloat64{Float64: 100, Valid: true}},
+					{ExerciseId: 10, Kind: repository.PR_ESTIMATED_1RM, Value: 120},
+					{ExerciseId: 10, Kind: repository.PR_VOLUME, Value: 1000},
+				}, nil).Once()
+
+				expected := []repository.CreatePR{
+					// weight and estimated 1RM of exercise 10 are not beaten, the set without reps is skipped
+					{UserId: userID, ExerciseId: 10, WorkoutPlanId: workoutID, Kind: repository.PR_REPS, Value: 8, WeightKg: floatPtr(80)},
+					{UserId: userID, ExerciseId: 10, WorkoutPlanId: workoutID, Kind: repository.PR_REPS, Value: 5, WeightKg: floatPtr(100), PreviousValue: floatPtr(4)},
+					{UserId: userID, ExerciseId: 10, WorkoutPlanId: workoutID, Kind: repository.PR_VOLUME, Value: 1140, PreviousValue: floatPtr(1000)},
+					// exercise 11 has no logged sets, so the planned 3x10 at 100 lbs counts
+					{UserId: userID, ExerciseId: 11, WorkoutPlanId: workoutID, Kind: repository.PR_WEIGHT, Value: 45.36},
+					{UserId: userID, ExerciseId: 11, WorkoutPlanId: workoutID, Kind: repository.PR_REPS, Value: 10, WeightKg: floatPtr(45.36)},
+					{UserId: userID, ExerciseId: 11, WorkoutPlanId: workoutID, Kind: repository.PR_ESTIMATED_1RM, Value: 60.48},
+					{UserId: userID, ExerciseId: 11, WorkoutPlanId: workoutID, Kind: repository.PR_VOLUME, Value: 1360.8},
+				}
+				created := make([]repository.PersonalRecord, len(expected))
+				for i, pr := range expected {
+					created[i] = repository.PersonalRecord{Id: i + 1, UserId: pr.UserId, ExerciseId: pr.ExerciseId, WorkoutPlanId: pr.WorkoutPlanId, Kind: pr.Kind, Value: pr.Value}
+				}
+				mrr.On("CreatePersonalRecords", ctx, expected).Return(created, nil).Once()
+			},
+			expectedCount: 7,
+		},
+		{
+			name: "Nothing is saved when no record is beaten",
+			mockSetup: func(mwr *MockWorkoutRepository, mer *MockExercisePlanRepository, mpr *MockPerformedSetRepository, mrr *MockPersonalRecordRepository) {
+				mwr.On("GetWorkoutById", ctx, workoutID).Return(workout, nil).Once()
+				mer.On("ListExercisePlans", ctx, workoutID).Return(epList[:1], nil).Once()
+				mpr.On("ListPerformedSets", ctx, 5).Return(performedSets[:1], nil).Once()
+				mrr.On("ListBestRecords", ctx, userID, []int{10}).Return([]repository.PersonalRecord{
+					{ExerciseId: 10, Kind: repository.PR_WEIGHT, Value: 100},
+					{ExerciseId: 10, Kind: repository.PR_REPS, Value: 5, WeightKg: sql.NullFloat64{Float64: 100, Valid: true}},
+					{ExerciseId: 10, Kind: repository.PR_ESTIMATED_1RM, Value: 116.67},
+					{ExerciseId: 10, Kind: repository.PR_VOLUME, Value: 500},
+				}, nil).Once()
+			},
+			expectedCount: 0,
+		},
+		{
+			name: "Workouts without measurable lifts skip the record lookup",
+			mockSetup: func(mwr *MockWorkoutRepository, mer *MockExercisePlanRepository, mpr *MockPerformedSetRepository, mrr *MockPersonalRecordRepository) {
+				mwr.On("GetWorkoutById", ctx, workoutID).Return(workout, nil).Once()
+				mer.On("ListExercisePlans", ctx, workoutID).Return(epList[2:], nil).Once()
+				mpr.On("ListPerformedSets", ctx, 7).Return([]repository.PerformedSet{}, nil).Once()
+			},
+			expectedCount: 0,
+		},
+		{
+			name: "Workout not found",
+			mockSetup: func(mwr *MockWorkoutRepository, mer *MockExercisePlanRepository, mpr *MockPerformedSetRepository, mrr *MockPersonalRecordRepository) {
+				mwr.On("GetWorkoutById", ctx, workoutID).Return(nil, apperrors.ErrNotFound).Once()
+			},
+			expectedError: "failed to fetch workout plan id '1': resource not found",
+		},
+		{
+			name: "DB error saving records",
+			mockSetup: func(mwr *MockWorkoutRepository, mer *MockExercisePlanRepository, mpr *MockPerformedSetRepository, mrr *MockPersonalRecordRepository) {
+				mwr.On("GetWorkoutById", ctx, workoutID).Return(workout, nil).Once()
+				mer.On("ListExercisePlans", ctx, workoutID).Return(epList[:1], nil).Once()
+				mpr.On("ListPerformedSets", ctx, 5).Return(performedSets[:1], nil).Once()
+				mrr.On("ListBestRecords", ctx, userID, []int{10}).Return([]repository.PersonalRecord{}, nil).Once()
+				mrr.On("CreatePersonalRecords", ctx, mock.AnythingOfType("[]repository.CreatePR")).Return(nil, errors.New("db insert error")).Once()
+			},
+			expectedError: "failed to save personal records: db insert error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWPRepo := new(MockWorkoutRepository)
+			mockEPRepo := new(MockExercisePlanRepository)
+			mockPSRepo := new(MockPerformedSetRepository)
+			mockPRRepo := new(MockPersonalRecordRepository)
+			tt.mockSetup(mockWPRepo, mockEPRepo, mockPSRepo, mockPRRepo)
+
+			prService := service.NewPRService(mockWPRepo, mockEPRepo, mockPSRepo, mockPRRepo)
+			records, err := prService.DetectPersonalRecords(ctx, workoutID)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, records)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, records, tt.expectedCount)
+			}
+
+			mockWPRepo.AssertExpectations(t)
+			mockEPRepo.AssertExpectations(t)
+			mockPSRepo.AssertExpectations(t)
+			mockPRRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPersonalRecordService_ListPersonalRecords(t *testing.T) {
+	ctx := context.Background()
+	exerciseID := 10
+	achievedAt := time.Now().Truncate(time.Second)
+
+	t.Run("Success", func(t *testing.T) {
+		mockPRRepo := new(MockPersonalRecordRepository)
+		mockPRRepo.On("ListPersonalRecords", ctx, 5, &exerciseID).Return([]repository.PersonalRecord{
+			{Id: 2, UserId: 5, ExerciseId: 10, WorkoutPlanId: 3, Kind: repository.PR_REPS, Value: 8, WeightKg: sql.NullFloat64{Float64: 80, Valid: true}, PreviousValue: sql.NullFloat64{Float64: 6, Valid: true}, AchievedAt: achievedAt},
+			{Id: 1, UserId: 5, ExerciseId: 10, WorkoutPlanId: 2, Kind: repository.PR_WEIGHT, Value: 100, AchievedAt: achievedAt},
+		}, nil).Once()
+
+		prService := service.NewPRService(nil, nil, nil, mockPRRepo)
+		records, err := prService.ListPersonalRecords(ctx, 5, &exerciseID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []service.PersonalRecord{
+			{Id: 2, ExerciseId: 10, WorkoutPlanId: 3, Kind: service.PR_REPS, Value: 8, WeightKg: floatPtr(80), PreviousValue: floatPtr(6), AchievedAt: achievedAt},
+			{Id: 1, ExerciseId: 10, WorkoutPlanId: 2, Kind: service.PR_WEIGHT, Value: 100, AchievedAt: achievedAt},
+		}, records)
+		mockPRRepo.AssertExpectations(t)
+	})
+
+	t.Run("DB error", func(t *testing.T) {
+		mockPRRepo := new(MockPersonalRecordRepository)
+		mockPRRepo.On("ListPersonalRecords", ctx, 5, (*int)(nil)).Return(nil, errors.New("db error")).Once()
+
+		prService := service.NewPRService(nil, nil, nil, mockPRRepo)
+		records, err := prService.ListPersonalRecords(ctx, 5, nil)
+
+		assert.EqualError(t, err, "failed to fetch personal records: db error")
+		assert.Nil(t, records)
+		mockPRRepo.AssertExpectations(t)
+	})
+}
+
+func TestEstimateOneRepMax(t *testing.T) {
+	assert.Equal(t, 100.0, service.EstimateOneRepMax(100, 1))
+	assert.InDelta(t, 116.67, service.EstimateOneRepMax(100, 5), 0.01)
+}
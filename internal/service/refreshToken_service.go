@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+)
+
+// opaqueTokenBytes is the entropy of refresh and password reset tokens before encoding
+const opaqueTokenBytes = 32
+
+// RotatedRefreshToken is the result of a refresh: the owner and session of the old token and
+// the token that replaces it
+type RotatedRefreshToken struct {
+	User         User
+	SessionId    string
+	RefreshToken string
+}
+
+type RefreshTokenServiceInterface interface {
+	IssueRefreshToken(ctx context.Context, userId int, sessionId string) (string, error)
+	RotateRefreshToken(ctx context.Context, token string) (*RotatedRefreshToken, error)
+}
+
+type RefreshTokenService struct {
+	RTRepo      repository.RefreshTokenRepository
+	UserRepo    repository.UserRepository
+	SessionRepo repository.SessionRepository
+	UoW         repository.UnitOfWork
+	TTL         time.Duration
+}
+
+func NewRTService(rr repository.RefreshTokenRepository, ur repository.UserRepository, sr repository.SessionRepository, uow repository.UnitOfWork, ttl time.Duration) RefreshTokenServiceInterface {
+	return &RefreshTokenService{
+		RTRepo:      rr,
+		UserRepo:    ur,
+		SessionRepo: sr,
+		UoW:         uow,
+		TTL:         ttl,
+	}
+}
+
+// IssueRefreshToken starts the token family of a new session
+func (s *RefreshTokenService) IssueRefreshToken(ctx context.Context, userId int, sessionId string) (string, error) {
+	return s.createRefreshToken(ctx, userId, sessionId)
+}
+
+// RotateRefreshToken exchanges a refresh token for a new one of the same family. A token that
+// was already exchanged is a sign it was stolen, so the whole family and its session are revoked
+// and both the thief and the owner have to log in again. That case returns a
+// *apperrors.TokenReuseError, the caller still has to end the access tokens of the session.
+func (s *RefreshTokenService) RotateRefreshToken(ctx context.Context, token string) (*RotatedRefreshToken, error) {
+	var rotated RotatedRefreshToken
+	// reusedSession is the session revoked because its refresh token was used twice
+	var reusedSession string
+
+	err := s.UoW.WithinTransaction(ctx, func(txCtx context.Context) error {
+		stored, err := s.RTRepo.GetRefreshTokenByHash(txCtx, hashToken(token))
+		if err != nil {
+			if errors.Is(err, apperrors.ErrNotFound) {
+				return apperrors.ErrUnauthorized
+			}
+			return fmt.Errorf("failed to fetch refresh token: %w", err)
+		}
+
+		if stored.RevokedAt.Valid || !stored.ExpiresAt.After(time.Now()) {
+			return apperrors.ErrUnauthorized
+		}
+
+		marked, err := s.RTRepo.MarkRefreshTokenUsed(txCtx, stored.Id)
+		if err != nil {
+			return fmt.Errorf("failed to mark refresh token as used: %w", err)
+		}
+		if !marked {
+			// the revocation has to be committed, so the transaction must not fail here
+			reusedSession = stored.FamilyId
+			log.Printf("Refresh token reuse detected, revoking token family '%s' of user id '%v'", stored.FamilyId, stored.UserId)
+			if err := s.RTRepo.RevokeTokenFamily(txCtx, stored.FamilyId); err != nil {
+				return fmt.Errorf("failed to revoke token family: %w", err)
+			}
+			// the family id is the id of the session, which may be revoked already
+			err := s.SessionRepo.RevokeSession(txCtx, stored.UserId, stored.FamilyId)
+			if err != nil && !errors.Is(err, apperrors.ErrNotFound) {
+				return fmt.Errorf("failed to revoke session: %w", err)
+			}
+			return nil
+		}
+
+		fetchedUser, err := s.UserRepo.GetUserById(txCtx, stored.UserId)
+		if err != nil {
+			return fmt.Errorf("failed to fetch user of refresh token: %w", err)
+		}
+		if !canLogIn(fetchedUser) {
+			return apperrors.ErrUnauthorized
+		}
+		rotated.User = *toServiceUser(fetchedUser)
+		rotated.SessionId = stored.FamilyId
+
+		rotated.RefreshToken, err = s.createRefreshToken(txCtx, stored.UserId, stored.FamilyId)
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUnauthorized) {
+			return nil, apperrors.ErrUnauthorized
+		}
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	if reusedSession != "" {
+		return nil, &apperrors.TokenReuseError{SessionId: reusedSession}
+	}
+
+	return &rotated, nil
+}
+
+func (s *RefreshTokenService) createRefreshToken(ctx context.Context, userId int, familyId string) (string, error) {
+	token, err := newOpaqueToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	_, err = s.RTRepo.CreateRefreshToken(ctx, repository.CreateRefreshToken{
+		UserId:    userId,
+		FamilyId:  familyId,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().UTC().Add(s.TTL),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to save refresh token: %w", err)
+	}
+
+	return token, nil
+}
+
+// newOpaqueToken returns a random url safe token, the caller only stores its hashToken
+func newOpaqueToken() (string, error) {
+	raw := make([]byte, opaqueTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// hashToken is what gets stored, a leaked table does not give out usable tokens
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,261 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+	"workout-tracker-api/internal/service"
+)
+
+// MockRefreshTokenRepository is a mock implementation of repository.RefreshTokenRepository
+type MockRefreshTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockRefreshTokenRepository) CreateRefreshToken(ctx context.Context, data repository.CreateRefreshToken) (*repository.RefreshToken, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*repository.RefreshToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.RefreshToken), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) MarkRefreshTokenUsed(ctx context.Context, id int) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRefreshTokenRepository) RevokeTokenFamily(ctx context.Context, familyId string) error {
+	args := m.Called(ctx, familyId)
+	return args.Error(0)
+}
+
+func (m *MockRefreshTokenRepository) RevokeUserTokens(ctx context.Context, userId int) error {
+	args := m.Called(ctx, userId)
+	return args.Error(0)
+}
+
+func sha256Hex(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+const refreshTTL = 30 * 24 * time.Hour
+
+func TestRefreshTokenService_IssueRefreshToken(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		mockRTRepo := new(MockRefreshTokenRepository)
+		rtService := service.NewRTService(mockRTRepo, nil, nil, new(MockUnitOfWork), refreshTTL)
+
+		var saved repository.CreateRefreshToken
+		mockRTRepo.On("CreateRefreshToken", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(repository.CreateRefreshToken) }).
+			Return(&repository.RefreshToken{Id: 1}, nil).Once()
+
+		token, err := rtService.IssueRefreshToken(ctx, 5, "session-1")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, token)
+		assert.Equal(t, 5, saved.UserId)
+		assert.Equal(t, "session-1", saved.FamilyId)
+		assert.Equal(t, sha256Hex(token), saved.TokenHash)
+		assert.WithinDuration(t, time.Now().Add(refreshTTL), saved.ExpiresAt, time.Minute)
+		mockRTRepo.AssertExpectations(t)
+	})
+
+	t.Run("DB error", func(t *testing.T) {
+		mockRTRepo := new(MockRefreshTokenRepository)
+		rtService := service.NewRTService(mockRTRepo, nil, nil, new(MockUnitOfWork), refreshTTL)
+		dbError := errors.New("insert failed")
+
+		mockRTRepo.On("CreateRefreshToken", ctx, mock.Anything).Return(nil, dbError).Once()
+
+		token, err := rtService.IssueRefreshToken(ctx, 5, "session-1")
+		assert.ErrorIs(t, err, dbError)
+		assert.Empty(t, token)
+	})
+}
+
+func TestRefreshTokenService_RotateRefreshToken(t *testing.T) {
+	ctx := context.Background()
+	family := "family-1"
+	active := &repository.RefreshToken{
+		Id:        1,
+		UserId:    5,
+		FamilyId:  family,
+		TokenHash: sha256Hex("old-token"),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	t.Run("Success rotates within the family", func(t *testing.T) {
+		mockRTRepo := new(MockRefreshTokenRepository)
+		mockUserRepo := new(MockUserRepository)
+		uow := new(MockUnitOfWork)
+		rtService := service.NewRTService(mockRTRepo, mockUserRepo, nil, uow, refreshTTL)
+
+		var saved repository.CreateRefreshToken
+		mockRTRepo.On("GetRefreshTokenByHash", ctx, sha256Hex("old-token")).Return(active, nil).Once()
+		mockRTRepo.On("MarkRefreshTokenUsed", ctx, 1).Return(true, nil).Once()
+		mockUserRepo.On("GetUserById", ctx, 5).Return(&repository.User{Id: 5, Name: "John", Email: "john@example.com"}, nil).Once()
+		mockRTRepo.On("CreateRefreshToken", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(repository.CreateRefreshToken) }).
+			Return(&repository.RefreshToken{Id: 2}, nil).Once()
+
+		rotated, err := rtService.RotateRefreshToken(ctx, "old-token")
+		assert.NoError(t, err)
+		assert.Equal(t, "john@example.com", rotated.User.Email)
+		assert.Equal(t, family, rotated.SessionId)
+		assert.NotEqual(t, "old-token", rotated.RefreshToken)
+		assert.Equal(t, family, saved.FamilyId)
+		assert.Equal(t, sha256Hex(rotated.RefreshToken), saved.TokenHash)
+		assert.Equal(t, 1, uow.Calls)
+		mockRTRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Reused token revokes the family and its session", func(t *testing.T) {
+		mockRTRepo := new(MockRefreshTokenRepository)
+		mockSessionRepo := new(MockSessionRepository)
+		uow := new(MockUnitOfWork)
+		rtService := service.NewRTService(mockRTRepo, nil, mockSessionRepo, uow, refreshTTL)
+
+		used := *active
+		used.UsedAt = sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}
+		mockRTRepo.On("GetRefreshTokenByHash", ctx, sha256Hex("old-token")).Return(&used, nil).Once()
+		mockRTRepo.On("MarkRefreshTokenUsed", ctx, 1).Return(false, nil).Once()
+		mockRTRepo.On("RevokeTokenFamily", ctx, family).Return(nil).Once()
+		mockSessionRepo.On("RevokeSession", ctx, used.UserId, family).Return(nil).Once()
+
+		rotated, err := rtService.RotateRefreshToken(ctx, "old-token")
+		assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+		var reuseErr *apperrors.TokenReuseError
+		if assert.ErrorAs(t, err, &reuseErr) {
+			assert.Equal(t, family, reuseErr.SessionId)
+		}
+		assert.Nil(t, rotated)
+		assert.False(t, uow.RolledBack)
+		mockRTRepo.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+		mockRTRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Reused token of a session revoked already", func(t *testing.T) {
+		mockRTRepo := new(MockRefreshTokenRepository)
+		mockSessionRepo := new(MockSessionRepository)
+		rtService := service.NewRTService(mockRTRepo, nil, mockSessionRepo, new(MockUnitOfWork), refreshTTL)
+
+		used := *active
+		used.UsedAt = sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}
+		mockRTRepo.On("GetRefreshTokenByHash", ctx, sha256Hex("old-token")).Return(&used, nil).Once()
+		mockRTRepo.On("MarkRefreshTokenUsed", ctx, 1).Return(false, nil).Once()
+		mockRTRepo.On("RevokeTokenFamily", ctx, family).Return(nil).Once()
+		mockSessionRepo.On("RevokeSession", ctx, used.UserId, family).Return(apperrors.ErrNotFound).Once()
+
+		_, err := rtService.RotateRefreshToken(ctx, "old-token")
+		var reuseErr *apperrors.TokenReuseError
+		assert.ErrorAs(t, err, &reuseErr)
+	})
+
+	t.Run("Revoked token", func(t *testing.T) {
+		mockRTRepo := new(MockRefreshTokenRepository)
+		rtService := service.NewRTService(mockRTRepo, nil, nil, new(MockUnitOfWork), refreshTTL)
+
+		revoked := *active
+		revoked.RevokedAt = sql.NullTime{Time: time.Now(), Valid: true}
+		mockRTRepo.On("GetRefreshTokenByHash", ctx, sha256Hex("old-token")).Return(&revoked, nil).Once()
+
+		_, err := rtService.RotateRefreshToken(ctx, "old-token")
+		assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+		mockRTRepo.AssertNotCalled(t, "MarkRefreshTokenUsed", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		mockRTRepo := new(MockRefreshTokenRepository)
+		rtService := service.NewRTService(mockRTRepo, nil, nil, new(MockUnitOfWork), refreshTTL)
+
+		expired := *active
+		expired.ExpiresAt = time.Now().Add(-time.Second)
+		mockRTRepo.On("GetRefreshTokenByHash", ctx, sha256Hex("old-token")).Return(&expired, nil).Once()
+
+		_, err := rtService.RotateRefreshToken(ctx, "old-token")
+		assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+	})
+
+	t.Run("Disabled user", func(t *testing.T) {
+		mockRTRepo := new(MockRefreshTokenRepository)
+		mockUserRepo := new(MockUserRepository)
+		uow := new(MockUnitOfWork)
+		rtService := service.NewRTService(mockRTRepo, mockUserRepo, nil, uow, refreshTTL)
+
+		mockRTRepo.On("GetRefreshTokenByHash", ctx, sha256Hex("old-token")).Return(active, nil).Once()
+		mockRTRepo.On("MarkRefreshTokenUsed", ctx, 1).Return(true, nil).Once()
+		mockUserRepo.On("GetUserById", ctx, 5).Return(&repository.User{Id: 5, DisabledAt: sql.NullTime{Time: time.Now(), Valid: true}}, nil).Once()
+
+		rotated, err := rtService.RotateRefreshToken(ctx, "old-token")
+		assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+		assert.Nil(t, rotated)
+		assert.True(t, uow.RolledBack)
+		mockRTRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("User waiting for deletion", func(t *testing.T) {
+		mockRTRepo := new(MockRefreshTokenRepository)
+		mockUserRepo := new(MockUserRepository)
+		rtService := service.NewRTService(mockRTRepo, mockUserRepo, nil, new(MockUnitOfWork), refreshTTL)
+
+		mockRTRepo.On("GetRefreshTokenByHash", ctx, sha256Hex("old-token")).Return(active, nil).Once()
+		mockRTRepo.On("MarkRefreshTokenUsed", ctx, 1).Return(true, nil).Once()
+		mockUserRepo.On("GetUserById", ctx, 5).Return(&repository.User{Id: 5, DeletionDueAt: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true}}, nil).Once()
+
+		rotated, err := rtService.RotateRefreshToken(ctx, "old-token")
+		assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+		assert.Nil(t, rotated)
+		mockRTRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown token", func(t *testing.T) {
+		mockRTRepo := new(MockRefreshTokenRepository)
+		rtService := service.NewRTService(mockRTRepo, nil, nil, new(MockUnitOfWork), refreshTTL)
+
+		mockRTRepo.On("GetRefreshTokenByHash", ctx, sha256Hex("unknown")).Return(nil, apperrors.ErrNotFound).Once()
+
+		_, err := rtService.RotateRefreshToken(ctx, "unknown")
+		assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+	})
+
+	t.Run("Save error rolls back", func(t *testing.T) {
+		mockRTRepo := new(MockRefreshTokenRepository)
+		mockUserRepo := new(MockUserRepository)
+		uow := new(MockUnitOfWork)
+		rtService := service.NewRTService(mockRTRepo, mockUserRepo, nil, uow, refreshTTL)
+		dbError := errors.New("insert failed")
+
+		mockRTRepo.On("GetRefreshTokenByHash", ctx, sha256Hex("old-token")).Return(active, nil).Once()
+		mockRTRepo.On("MarkRefreshTokenUsed", ctx, 1).Return(true, nil).Once()
+		mockUserRepo.On("GetUserById", ctx, 5).Return(&repository.User{Id: 5}, nil).Once()
+		mockRTRepo.On("CreateRefreshToken", ctx, mock.Anything).Return(nil, dbError).Once()
+
+		_, err := rtService.RotateRefreshToken(ctx, "old-token")
+		assert.ErrorIs(t, err, dbError)
+		assert.True(t, uow.RolledBack)
+	})
+}
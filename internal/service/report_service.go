@@ -2,43 +2,1250 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"math"
+	"time"
+	"workout-tracker-api/internal/apperrors"
 	"workout-tracker-api/internal/repository"
+	customtime "workout-tracker-api/internal/util/custom_time"
 )
 
 type ReportServiceInterface interface {
-	Progress(ctx context.Context, userID int) (*ProgressStatus, error)
+	Progress(ctx context.Context, query ProgressQuery) (*ProgressStatus, error)
+	Volume(ctx context.Context, query VolumeQuery) (*VolumeReport, error)
+	Consistency(ctx context.Context, query ConsistencyQuery) (*ConsistencyReport, error)
+	Strength(ctx context.Context, query StrengthQuery) (*StrengthReport, error)
+	MuscleBalance(ctx context.Context, query MuscleBalanceQuery) (*MuscleBalanceReport, error)
 }
 
 type ReportService struct {
-	workoutRepo repository.WorkoutRepository
+	workoutRepo  repository.WorkoutRepository
+	reportRepo   repository.ReportRepository
+	userRepo     repository.UserRepository
+	exerciseRepo repository.ExerciseRepository
+	balance      MuscleBalanceConfig
 }
 
-func NewReportService(wr repository.WorkoutRepository) ReportServiceInterface {
+func NewReportService(wr repository.WorkoutRepository, rr repository.ReportRepository, ur repository.UserRepository, er repository.ExerciseRepository, bc MuscleBalanceConfig) ReportServiceInterface {
 	return &ReportService{
-		workoutRepo: wr,
+		workoutRepo:  wr,
+		reportRepo:   rr,
+		userRepo:     ur,
+		exerciseRepo: er,
+		balance:      bc,
 	}
 }
 
+// CompareTo picks the period a report is compared with: the period of the same length right
+// before it, or the same dates a year earlier
+type CompareTo string
+
+const (
+	PREVIOUS      CompareTo = "previous"
+	PREVIOUS_YEAR CompareTo = "previous_year"
+)
+
+// ReportRange is the from, to and compareTo of a report as they were sent. A date-time with an
+// offset is taken as it is, a date or a date-time without one is in the user's time zone. Bounds
+// that are not set get the defaults of the report, no comparison is made without CompareTo.
+type ReportRange struct {
+	From      string // inclusive
+	To        string // exclusive
+	CompareTo CompareTo
+}
+
+// parse reads the bounds in loc, a bound that is not set stays zero
+func (r ReportRange) parse(loc *time.Location) (from time.Time, to time.Time, err error) {
+	switch r.CompareTo {
+	case "", PREVIOUS, PREVIOUS_YEAR:
+	default:
+		return from, to, apperrors.NewValidationError(apperrors.INVALID_SETTING, "compareTo must be previous or previous_year")
+	}
+
+	if r.From != "" {
+		parsed, err := customtime.ParseTime(r.From, loc)
+		if err != nil {
+			return from, to, apperrors.NewValidationError(apperrors.INVALID_DATE, "from must be an RFC 3339 date-time or a date")
+		}
+		from = *parsed
+	}
+
+	if r.To != "" {
+		parsed, err := customtime.ParseTime(r.To, loc)
+		if err != nil {
+			return from, to, apperrors.NewValidationError(apperrors.INVALID_DATE, "to must be an RFC 3339 date-time or a date")
+		}
+		to = *parsed
+	}
+
+	return from, to, nil
+}
+
+// ComparedPeriod is the period a report was compared with
+type ComparedPeriod struct {
+	CompareTo CompareTo `json:"compareTo"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+}
+
+// comparedPeriod is the period from-to is compared with, nil when compareTo is not set
+func comparedPeriod(compareTo CompareTo, from, to time.Time) *ComparedPeriod {
+	switch compareTo {
+	case PREVIOUS:
+		return &ComparedPeriod{CompareTo: compareTo, From: from.Add(-to.Sub(from)), To: from}
+	case PREVIOUS_YEAR:
+		return &ComparedPeriod{CompareTo: compareTo, From: from.AddDate(-1, 0, 0), To: to.AddDate(-1, 0, 0)}
+	}
+	return nil
+}
+
+// Delta is a value of the compared period and how much the report differs from it. Percent is
+// nil when the compared value is 0.
+type Delta struct {
+	Previous float64  `json:"previous"`
+	Change   float64  `json:"change"`
+	Percent  *float64 `json:"percent"`
+}
+
+func newDelta(previous, current float64) Delta {
+	return Delta{
+		Previous: previous,
+		Change:   math.Round((current-previous)*10000) / 10000,
+		Percent:  percentChange(previous, current),
+	}
+}
+
+type ProgressQuery struct {
+	UserId int
+	// every workout counts when nothing is set, otherwise from is required and to is now when not set
+	ReportRange
+}
+
+type ProgressComparison struct {
+	ComparedPeriod
+	CompletedWorkouts Delta `json:"completedWorkouts"`
+	TotalWorkouts     Delta `json:"totalWorkouts"`
+}
+
+// ProgressStatus counts the workouts scheduled in the range, From and To are nil when every
+// workout counts
 type ProgressStatus struct {
-	CompleteWorkouts int `json:"completedWorkouts"`
-	TotalWorkouts    int `json:"totalWorkouts"`
+	From             *time.Time          `json:"from"`
+	To               *time.Time          `json:"to"`
+	CompleteWorkouts int                 `json:"completedWorkouts"`
+	TotalWorkouts    int                 `json:"totalWorkouts"`
+	Comparison       *ProgressComparison `json:"comparison"`
+}
+
+func (s *ReportService) Progress(ctx context.Context, query ProgressQuery) (*ProgressStatus, error) {
+	if query.ReportRange == (ReportRange{}) {
+		completed, err := s.workoutRepo.ListWorkoutsByStatus(ctx, query.UserId, repository.COMPLETED, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch workout plans by filter: %w", err)
+		}
+
+		all, err := s.workoutRepo.ListUserWorkouts(ctx, query.UserId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch all workout plans: %w", err)
+		}
+
+		return &ProgressStatus{
+			CompleteWorkouts: len(completed),
+			TotalWorkouts:    len(all),
+		}, nil
+	}
+
+	loc, err := userLocation(ctx, s.userRepo, query.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := query.parse(loc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate: %w", err)
+	}
+	if to.IsZero() {
+		to = time.Now().In(loc)
+	}
+	if from.IsZero() {
+		return nil, apperrors.NewValidationError(apperrors.INVALID_DATE, "from must be set with to or compareTo")
+	}
+	if !to.After(from) {
+		return nil, apperrors.NewValidationError(apperrors.INVALID_DATE, "to must be after from")
+	}
+
+	adherence, err := s.adherence(ctx, query.UserId, from, to, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &ProgressStatus{
+		From:             &from,
+		To:               &to,
+		CompleteWorkouts: adherence.Completed,
+		TotalWorkouts:    adherence.Scheduled,
+	}
+
+	if compared := comparedPeriod(query.CompareTo, from, to); compared != nil {
+		previous, err := s.adherence(ctx, query.UserId, compared.From, compared.To, loc)
+		if err != nil {
+			return nil, err
+		}
+		status.Comparison = &ProgressComparison{
+			ComparedPeriod:    *compared,
+			CompletedWorkouts: newDelta(float64(previous.Completed), float64(adherence.Completed)),
+			TotalWorkouts:     newDelta(float64(previous.Scheduled), float64(adherence.Scheduled)),
+		}
+	}
+
+	return status, nil
+}
+
+type Bucket string
+
+const (
+	DAY   Bucket = "day"
+	WEEK  Bucket = "week"
+	MONTH Bucket = "month"
+)
+
+// MaxReportRange keeps a report from scanning years of workouts at once
+const MaxReportRange = 2 * 366 * 24 * time.Hour
+
+type VolumeQuery struct {
+	UserId int
+	ReportRange
+	Bucket Bucket      // week when empty
+	Unit   *WeightUnit // the user's preferred unit when not set
 }
 
-func (s *ReportService) Progress(ctx context.Context, userID int) (*ProgressStatus, error) {
-	completed, err := s.workoutRepo.ListWorkoutsByStatus(ctx, userID, repository.COMPLETED, true)
+func (q *VolumeQuery) Validate(from, to time.Time) error {
+	if from.IsZero() || to.IsZero() {
+		return apperrors.NewValidationError(apperrors.INVALID_DATE, "from and to must both be set")
+	}
+
+	if !to.After(from) {
+		return apperrors.NewValidationError(apperrors.INVALID_DATE, "to must be after from")
+	}
+
+	if to.Sub(from) > MaxReportRange {
+		return apperrors.NewValidationError(apperrors.INVALID_DATE, "date range can not be longer than two years")
+	}
+
+	if q.Bucket == "" {
+		q.Bucket = WEEK
+	}
+
+	switch q.Bucket {
+	case DAY, WEEK, MONTH:
+	default:
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, "bucket must be day, week or month")
+	}
+
+	if q.Unit != nil && *q.Unit != KG && *q.Unit != LBS {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, "unit must be kg or lbs")
+	}
+
+	return nil
+}
+
+// VolumeTotals is sets, reps and volume (sets x reps x weight) in the unit of the report
+type VolumeTotals struct {
+	Sets        int     `json:"sets"`
+	Repetitions int     `json:"repetitions"`
+	Volume      float64 `json:"volume"`
+}
+
+type ExerciseVolume struct {
+	ExerciseId  int         `json:"exerciseId"`
+	Name        string      `json:"name"`
+	MuscleGroup MuscleGroup `json:"muscleGroup"`
+	VolumeTotals
+}
+
+type MuscleGroupVolume struct {
+	MuscleGroup MuscleGroup `json:"muscleGroup"`
+	VolumeTotals
+}
+
+// VolumePeriod is one bucket of the report, periods without completed workouts are left out. Start
+// is its first midnight in the user's time zone.
+type VolumePeriod struct {
+	Start        time.Time           `json:"start"`
+	Exercises    []ExerciseVolume    `json:"exercises"`
+	MuscleGroups []MuscleGroupVolume `json:"muscleGroups"`
+	VolumeTotals
+}
+
+type VolumeComparison struct {
+	ComparedPeriod
+	Sets        Delta `json:"sets"`
+	Repetitions Delta `json:"repetitions"`
+	Volume      Delta `json:"volume"`
+}
+
+type VolumeReport struct {
+	From       time.Time         `json:"from"`
+	To         time.Time         `json:"to"`
+	TimeZone   string            `json:"timeZone"`
+	Bucket     Bucket            `json:"bucket"`
+	Unit       WeightUnit        `json:"unit"`
+	Totals     VolumeTotals      `json:"totals"`
+	Periods    []VolumePeriod    `json:"periods"`
+	Comparison *VolumeComparison `json:"comparison"`
+}
+
+// Volume reports the training volume of completed workouts per period. The aggregation runs in the
+// database in kg, only the conversion to the requested unit happens here.
+func (s *ReportService) Volume(ctx context.Context, query VolumeQuery) (*VolumeReport, error) {
+	loc, err := userLocation(ctx, s.userRepo, query.UserId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch workout plans by filter: %w", err)
+		return nil, err
 	}
 
-	all, err := s.workoutRepo.ListUserWorkouts(ctx, userID)
+	from, to, err := query.parse(loc)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch all workout plans: %w", err)
+		return nil, fmt.Errorf("failed to validate: %w", err)
 	}
 
-	return &ProgressStatus{
-		CompleteWorkouts: len(completed),
-		TotalWorkouts:    len(all),
-	}, nil
+	if err := query.Validate(from, to); err != nil {
+		return nil, fmt.Errorf("failed to validate: %w", err)
+	}
+
+	var unit WeightUnit
+	if query.Unit != nil {
+		unit = *query.Unit
+	} else {
+		preferred, err := s.userRepo.GetPreferredUnit(ctx, query.UserId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch preferred unit: %w", err)
+		}
+		unit = WeightUnit(preferred)
+	}
+
+	filter := repository.VolumeFilter{
+		UserId:   query.UserId,
+		From:     from,
+		To:       to,
+		Bucket:   repository.Bucket(query.Bucket),
+		TimeZone: loc.String(),
+	}
+	rows, err := s.reportRepo.VolumeByPeriod(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate volume: %w", err)
+	}
+
+	report := &VolumeReport{
+		From:     from,
+		To:       to,
+		TimeZone: loc.String(),
+		Bucket:   query.Bucket,
+		Unit:     unit,
+		Totals:   volumeTotals(rows, unit),
+		Periods:  []VolumePeriod{},
+	}
+
+	for _, row := range rows {
+		// rows are ordered by period, so a new period always starts at the end of the list
+		if len(report.Periods) == 0 || !report.Periods[len(report.Periods)-1].Start.Equal(row.Period) {
+			report.Periods = append(report.Periods, VolumePeriod{
+				Start:        row.Period,
+				Exercises:    []ExerciseVolume{},
+				MuscleGroups: []MuscleGroupVolume{},
+			})
+		}
+		period := &report.Periods[len(report.Periods)-1]
+
+		totals := VolumeTotals{
+			Sets:        row.Sets,
+			Repetitions: row.Repetitions,
+			Volume:      FromKg(row.VolumeKg, unit),
+		}
+
+		switch {
+		case !row.MuscleGroup.Valid:
+			period.VolumeTotals = totals
+		case !row.ExerciseId.Valid:
+			period.MuscleGroups = append(period.MuscleGroups, MuscleGroupVolume{
+				MuscleGroup:  MuscleGroup(row.MuscleGroup.String),
+				VolumeTotals: totals,
+			})
+		default:
+			period.Exercises = append(period.Exercises, ExerciseVolume{
+				ExerciseId:   int(row.ExerciseId.Int64),
+				Name:         row.ExerciseName.String,
+				MuscleGroup:  MuscleGroup(row.MuscleGroup.String),
+				VolumeTotals: totals,
+			})
+		}
+	}
+
+	if compared := comparedPeriod(query.CompareTo, from, to); compared != nil {
+		filter.From, filter.To = compared.From, compared.To
+		previousRows, err := s.reportRepo.VolumeByPeriod(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to aggregate compared volume: %w", err)
+		}
+
+		previous := volumeTotals(previousRows, unit)
+		report.Comparison = &VolumeComparison{
+			ComparedPeriod: *compared,
+			Sets:           newDelta(float64(previous.Sets), float64(report.Totals.Sets)),
+			Repetitions:    newDelta(float64(previous.Repetitions), float64(report.Totals.Repetitions)),
+			Volume:         newDelta(previous.Volume, report.Totals.Volume),
+		}
+	}
+
+	return report, nil
+}
+
+// volumeTotals adds up the period totals of rows, the volume is converted once at the end
+func volumeTotals(rows []repository.VolumeRow, unit WeightUnit) VolumeTotals {
+	var totals VolumeTotals
+	var volumeKg float64
+	for _, row := range rows {
+		if row.MuscleGroup.Valid {
+			continue
+		}
+		totals.Sets += row.Sets
+		totals.Repetitions += row.Repetitions
+		volumeKg += row.VolumeKg
+	}
+	totals.Volume = FromKg(volumeKg, unit)
+	return totals
+}
+
+// DefaultWeeklyTarget is how many completed workouts a week needs to count for a streak when the
+// query does not set it
+const DefaultWeeklyTarget = 3
+
+// defaultConsistencyWeeks is how far back the consistency report looks when from is not set
+const defaultConsistencyWeeks = 52
+
+type ConsistencyQuery struct {
+	UserId int
+	// from is defaultConsistencyWeeks before to when not set, to the end of the current week
+	ReportRange
+	WeeklyTarget int // DefaultWeeklyTarget when not set
+}
+
+func (q *ConsistencyQuery) Validate(from, to time.Time) error {
+	if q.WeeklyTarget == 0 {
+		q.WeeklyTarget = DefaultWeeklyTarget
+	}
+
+	if q.WeeklyTarget < 1 || q.WeeklyTarget > 21 {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, "weekly target must be between 1 and 21")
+	}
+
+	if !to.After(from) {
+		return apperrors.NewValidationError(apperrors.INVALID_DATE, "to must be after from")
+	}
+
+	if to.Sub(from) > MaxReportRange {
+		return apperrors.NewValidationError(apperrors.INVALID_DATE, "date range can not be longer than two years")
+	}
+
+	return nil
+}
+
+// AdherenceTotals counts the workout plans scheduled in a period by status. Rate is completed ÷
+// scheduled, nil when nothing was scheduled.
+type AdherenceTotals struct {
+	Scheduled int      `json:"scheduled"`
+	Completed int      `json:"completed"`
+	Missed    int      `json:"missed"`
+	Pending   int      `json:"pending"`
+	Rate      *float64 `json:"rate"`
+}
+
+func (t *AdherenceTotals) add(status repository.WPStatus, count int) {
+	t.Scheduled += count
+	switch status {
+	case repository.COMPLETED:
+		t.Completed += count
+	case repository.MISSED:
+		t.Missed += count
+	default:
+		t.Pending += count
+	}
+}
+
+func (t *AdherenceTotals) finish() {
+	if t.Scheduled == 0 {
+		return
+	}
+	rate := math.Round(float64(t.Completed)/float64(t.Scheduled)*10000) / 10000
+	t.Rate = &rate
+}
+
+// AdherencePeriod is one week or month, Start is its first midnight in the user's time zone
+type AdherencePeriod struct {
+	Start time.Time `json:"start"`
+	AdherenceTotals
+}
+
+// ConsistencyWeek is a week starting on monday, TargetMet when it has enough completed workouts
+type ConsistencyWeek struct {
+	AdherencePeriod
+	TargetMet bool `json:"targetMet"`
+}
+
+// ConsistencyDay is one day of the heatmap
+type ConsistencyDay struct {
+	Date      time.Time `json:"date"`
+	Completed int       `json:"completed"`
+}
+
+// ConsistencyComparison compares the adherence, Rate is nil unless both periods had workouts
+// scheduled
+type ConsistencyComparison struct {
+	ComparedPeriod
+	Scheduled Delta  `json:"scheduled"`
+	Completed Delta  `json:"completed"`
+	Missed    Delta  `json:"missed"`
+	Rate      *Delta `json:"rate"`
+}
+
+// ConsistencyReport covers whole weeks in the user's time zone. The first and last month can be
+// cut by the range. Days and weeks are listed even when nothing was scheduled.
+type ConsistencyReport struct {
+	From          time.Time              `json:"from"`
+	To            time.Time              `json:"to"`
+	TimeZone      string                 `json:"timeZone"`
+	WeeklyTarget  int                    `json:"weeklyTarget"`
+	CurrentStreak int                    `json:"currentStreak"`
+	LongestStreak int                    `json:"longestStreak"`
+	Adherence     AdherenceTotals        `json:"adherence"`
+	Weeks         []ConsistencyWeek      `json:"weeks"`
+	Months        []AdherencePeriod      `json:"months"`
+	Days          []ConsistencyDay       `json:"days"`
+	Comparison    *ConsistencyComparison `json:"comparison"`
+}
+
+// Consistency reports the weekly streaks, the adherence and the completed workouts per day. Days,
+// weeks and months follow the user's time zone, the range is widened to whole weeks. Streaks count
+// the weeks in the range that reach the weekly target, the week that is still going does not break
+// the current streak.
+func (s *ReportService) Consistency(ctx context.Context, query ConsistencyQuery) (*ConsistencyReport, error) {
+	loc, err := userLocation(ctx, s.userRepo, query.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := query.parse(loc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate: %w", err)
+	}
+
+	now := time.Now().In(loc)
+	if to.IsZero() {
+		to = startOfWeek(now).AddDate(0, 0, 7)
+	}
+	if from.IsZero() {
+		from = startOfWeek(to.In(loc)).AddDate(0, 0, -7*defaultConsistencyWeeks)
+	}
+
+	if err := query.Validate(from, to); err != nil {
+		return nil, fmt.Errorf("failed to validate: %w", err)
+	}
+
+	from = startOfWeek(from.In(loc))
+	to = startOfWeek(to.Add(-time.Nanosecond).In(loc)).AddDate(0, 0, 7)
+
+	rows, err := s.reportRepo.WorkoutStatusByDay(ctx, repository.ConsistencyFilter{
+		UserId:   query.UserId,
+		From:     from,
+		To:       to,
+		TimeZone: loc.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate workouts by day: %w", err)
+	}
+
+	byDay := map[string]*AdherenceTotals{}
+	for _, row := range rows {
+		key := row.Day.Format(time.DateOnly)
+		if byDay[key] == nil {
+			byDay[key] = &AdherenceTotals{}
+		}
+		byDay[key].add(row.Status, row.Count)
+	}
+
+	report := &ConsistencyReport{
+		From:         from,
+		To:           to,
+		TimeZone:     loc.String(),
+		WeeklyTarget: query.WeeklyTarget,
+		Weeks:        []ConsistencyWeek{},
+		Months:       []AdherencePeriod{},
+		Days:         []ConsistencyDay{},
+	}
+
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Monday {
+			report.Weeks = append(report.Weeks, ConsistencyWeek{AdherencePeriod: AdherencePeriod{Start: day}})
+		}
+		if len(report.Months) == 0 || day.Day() == 1 {
+			report.Months = append(report.Months, AdherencePeriod{Start: day})
+		}
+
+		totals := AdherenceTotals{}
+		if counted := byDay[day.Format(time.DateOnly)]; counted != nil {
+			totals = *counted
+		}
+		report.Days = append(report.Days, ConsistencyDay{Date: day, Completed: totals.Completed})
+
+		for _, period := range []*AdherenceTotals{
+			&report.Weeks[len(report.Weeks)-1].AdherenceTotals,
+			&report.Months[len(report.Months)-1].AdherenceTotals,
+			&report.Adherence,
+		} {
+			period.Scheduled += totals.Scheduled
+			period.Completed += totals.Completed
+			period.Missed += totals.Missed
+			period.Pending += totals.Pending
+		}
+	}
+
+	run := 0
+	for i := range report.Weeks {
+		week := &report.Weeks[i]
+		week.finish()
+		week.TargetMet = week.Completed >= query.WeeklyTarget
+
+		if week.TargetMet {
+			run++
+			report.LongestStreak = max(report.LongestStreak, run)
+		} else {
+			run = 0
+		}
+	}
+	for i := range report.Months {
+		report.Months[i].finish()
+	}
+	report.Adherence.finish()
+
+	for i := len(report.Weeks) - 1; i >= 0; i-- {
+		week := report.Weeks[i]
+		if week.TargetMet {
+			report.CurrentStreak++
+			continue
+		}
+		if i == len(report.Weeks)-1 && now.Before(week.Start.AddDate(0, 0, 7)) {
+			continue
+		}
+		break
+	}
+
+	if compared := comparedPeriod(query.CompareTo, from, to); compared != nil {
+		previous, err := s.adherence(ctx, query.UserId, compared.From, compared.To, loc)
+		if err != nil {
+			return nil, err
+		}
+
+		current := report.Adherence
+		report.Comparison = &ConsistencyComparison{
+			ComparedPeriod: *compared,
+			Scheduled:      newDelta(float64(previous.Scheduled), float64(current.Scheduled)),
+			Completed:      newDelta(float64(previous.Completed), float64(current.Completed)),
+			Missed:         newDelta(float64(previous.Missed), float64(current.Missed)),
+		}
+		if previous.Rate != nil && current.Rate != nil {
+			rate := newDelta(*previous.Rate, *current.Rate)
+			report.Comparison.Rate = &rate
+		}
+	}
+
+	return report, nil
+}
+
+// adherence counts the workouts of the user scheduled in [from, to) by status
+func (s *ReportService) adherence(ctx context.Context, userId int, from, to time.Time, loc *time.Location) (AdherenceTotals, error) {
+	var totals AdherenceTotals
+	rows, err := s.reportRepo.WorkoutStatusByDay(ctx, repository.ConsistencyFilter{
+		UserId:   userId,
+		From:     from,
+		To:       to,
+		TimeZone: loc.String(),
+	})
+	if err != nil {
+		return totals, fmt.Errorf("failed to aggregate workouts by day: %w", err)
+	}
 
+	for _, row := range rows {
+		totals.add(row.Status, row.Count)
+	}
+	totals.finish()
+	return totals, nil
+}
+
+// userLocation is the time zone the days of the user's reports and exports follow. A stored zone
+// this build does not know falls back to UTC rather than failing every report.
+func userLocation(ctx context.Context, userRepo repository.UserRepository, userId int) (*time.Location, error) {
+	timeZone, err := userRepo.GetTimeZone(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch time zone: %w", err)
+	}
+
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		log.Printf("Unknown time zone '%s' of user id '%v', using UTC: %v", timeZone, userId, err)
+		return time.UTC, nil
+	}
+	return loc, nil
+}
+
+// startOfWeek is midnight of the monday of t's week in t's location
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+}
+
+// OneRepMaxFormula estimates the heaviest single rep from a set of several reps
+type OneRepMaxFormula string
+
+const (
+	EPLEY    OneRepMaxFormula = "epley"
+	BRZYCKI  OneRepMaxFormula = "brzycki"
+	LOMBARDI OneRepMaxFormula = "lombardi"
+)
+
+// Estimate gives the one rep max of weight lifted reps times, a single rep is taken as it is
+func (f OneRepMaxFormula) Estimate(weight float64, reps int) float64 {
+	if reps == 1 {
+		return weight
+	}
+
+	switch f {
+	case BRZYCKI:
+		return weight * 36 / (37 - float64(reps))
+	case LOMBARDI:
+		return weight * math.Pow(float64(reps), 0.1)
+	default:
+		return weight * (1 + float64(reps)/30)
+	}
+}
+
+// DefaultTrendWeeks is how many weeks before the end of the range the strength trend covers
+const DefaultTrendWeeks = 8
+
+// RollingBestWindow is how far back the rolling best of a strength point looks
+const RollingBestWindow = 28 * 24 * time.Hour
+
+// defaultStrengthRange is how far back the strength report looks when from is not set
+const defaultStrengthRange = 52 * 7 * 24 * time.Hour
+
+type StrengthQuery struct {
+	UserId     int
+	ExerciseId int
+	// from is defaultStrengthRange before to when not set, to is now
+	ReportRange
+	Formula    OneRepMaxFormula // epley when empty
+	Unit       *WeightUnit      // the user's preferred unit when not set
+	TrendWeeks int              // DefaultTrendWeeks when not set
+}
+
+func (q *StrengthQuery) Validate(from, to time.Time) error {
+	if q.ExerciseId <= 0 {
+		return apperrors.NewValidationError(apperrors.INVALID_ID, "exercise id not valid")
+	}
+
+	if !to.After(from) {
+		return apperrors.NewValidationError(apperrors.INVALID_DATE, "to must be after from")
+	}
+
+	if to.Sub(from) > MaxReportRange {
+		return apperrors.NewValidationError(apperrors.INVALID_DATE, "date range can not be longer than two years")
+	}
+
+	if q.Formula == "" {
+		q.Formula = EPLEY
+	}
+
+	switch q.Formula {
+	case EPLEY, BRZYCKI, LOMBARDI:
+	default:
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, "formula must be epley, brzycki or lombardi")
+	}
+
+	if q.Unit != nil && *q.Unit != KG && *q.Unit != LBS {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, "unit must be kg or lbs")
+	}
+
+	if q.TrendWeeks == 0 {
+		q.TrendWeeks = DefaultTrendWeeks
+	}
+
+	if q.TrendWeeks < 1 || q.TrendWeeks > 104 {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, "trend weeks must be between 1 and 104")
+	}
+
+	return nil
+}
+
+// StrengthPoint is the best set of the exercise in one completed workout. RollingBest is the
+// highest estimate of the RollingBestWindow up to and including this workout.
+type StrengthPoint struct {
+	Date               time.Time `json:"date"`
+	WorkoutPlanId      int       `json:"workoutPlanId"`
+	Weight             float64   `json:"weight"`
+	Repetitions        int       `json:"repetitions"`
+	EstimatedOneRepMax float64   `json:"estimatedOneRepMax"`
+	RollingBest        float64   `json:"rollingBest"`
+}
+
+// StrengthTrend is a least squares line through the points of the last Weeks of the range.
+// ChangePercent compares the line at the first and the last of those points.
+type StrengthTrend struct {
+	Weeks         int     `json:"weeks"`
+	Points        int     `json:"points"`
+	SlopePerWeek  float64 `json:"slopePerWeek"`
+	ChangePercent float64 `json:"changePercent"`
+}
+
+// StrengthComparison compares the number of workouts with the exercise and the best estimate,
+// BestEstimatedOneRepMax is nil unless both periods have one
+type StrengthComparison struct {
+	ComparedPeriod
+	Workouts               Delta  `json:"workouts"`
+	BestEstimatedOneRepMax *Delta `json:"bestEstimatedOneRepMax"`
+}
+
+// StrengthReport has no Trend when the trend weeks hold fewer than two workouts on different days
+type StrengthReport struct {
+	ExerciseId int                 `json:"exerciseId"`
+	Name       string              `json:"name"`
+	From       time.Time           `json:"from"`
+	To         time.Time           `json:"to"`
+	Formula    OneRepMaxFormula    `json:"formula"`
+	Unit       WeightUnit          `json:"unit"`
+	Best       *StrengthPoint      `json:"best"`
+	Points     []StrengthPoint     `json:"points"`
+	Trend      *StrengthTrend      `json:"trend"`
+	Comparison *StrengthComparison `json:"comparison"`
+}
+
+// Strength reports the estimated one rep max of an exercise per completed workout. Sets are
+// compared in kg whatever unit they were logged in, sets in the other unit and sets of more than
+// MaxRepsFor1RM reps are left out.
+func (s *ReportService) Strength(ctx context.Context, query StrengthQuery) (*StrengthReport, error) {
+	loc, err := userLocation(ctx, s.userRepo, query.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := query.parse(loc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate: %w", err)
+	}
+	if to.IsZero() {
+		to = time.Now().In(loc)
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultStrengthRange)
+	}
+
+	if err := query.Validate(from, to); err != nil {
+		return nil, fmt.Errorf("failed to validate: %w", err)
+	}
+
+	exercise, err := s.exerciseRepo.GetExerciseById(ctx, query.ExerciseId)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to fetch exercise: %w", err)
+	}
+	// custom exercises are private to their owner
+	if exercise.OwnerId.Valid && int(exercise.OwnerId.Int64) != query.UserId {
+		return nil, apperrors.ErrForbidden
+	}
+
+	var unit WeightUnit
+	if query.Unit != nil {
+		unit = *query.Unit
+	} else {
+		preferred, err := s.userRepo.GetPreferredUnit(ctx, query.UserId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch preferred unit: %w", err)
+		}
+		unit = WeightUnit(preferred)
+	}
+
+	filter := repository.StrengthFilter{
+		UserId:     query.UserId,
+		ExerciseId: query.ExerciseId,
+		From:       from,
+		To:         to,
+	}
+	points, err := s.strengthPoints(ctx, filter, query.Formula)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &StrengthReport{
+		ExerciseId: exercise.Id,
+		Name:       exercise.Name,
+		From:       from,
+		To:         to,
+		Formula:    query.Formula,
+		Unit:       unit,
+		Points:     make([]StrengthPoint, 0, len(points)),
+		Trend:      strengthTrend(points, to, query.TrendWeeks),
+	}
+	if report.Trend != nil {
+		report.Trend.SlopePerWeek = FromKg(report.Trend.SlopePerWeek, unit)
+	}
+
+	windowStart := 0
+	for i := range points {
+		for points[windowStart].Date.Before(points[i].Date.Add(-RollingBestWindow)) {
+			windowStart++
+		}
+		for _, earlier := range points[windowStart : i+1] {
+			points[i].RollingBest = math.Max(points[i].RollingBest, earlier.EstimatedOneRepMax)
+		}
+
+		point := points[i]
+		point.Weight = FromKg(point.Weight, unit)
+		point.EstimatedOneRepMax = FromKg(point.EstimatedOneRepMax, unit)
+		point.RollingBest = FromKg(point.RollingBest, unit)
+		report.Points = append(report.Points, point)
+
+		// Points never grows past its capacity, so the pointer stays valid
+		if report.Best == nil || point.EstimatedOneRepMax > report.Best.EstimatedOneRepMax {
+			report.Best = &report.Points[len(report.Points)-1]
+		}
+	}
+
+	if compared := comparedPeriod(query.CompareTo, from, to); compared != nil {
+		filter.From, filter.To = compared.From, compared.To
+		previousPoints, err := s.strengthPoints(ctx, filter, query.Formula)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Comparison = &StrengthComparison{
+			ComparedPeriod: *compared,
+			Workouts:       newDelta(float64(len(previousPoints)), float64(len(points))),
+		}
+		if len(previousPoints) > 0 && report.Best != nil {
+			previousBest := 0.0
+			for _, point := range previousPoints {
+				previousBest = math.Max(previousBest, point.EstimatedOneRepMax)
+			}
+			best := newDelta(FromKg(previousBest, unit), report.Best.EstimatedOneRepMax)
+			report.Comparison.BestEstimatedOneRepMax = &best
+		}
+	}
+
+	return report, nil
+}
+
+// strengthPoints is the best set of every completed workout in kg, in the order of the workouts
+func (s *ReportService) strengthPoints(ctx context.Context, filter repository.StrengthFilter, formula OneRepMaxFormula) ([]StrengthPoint, error) {
+	rows, err := s.reportRepo.StrengthSets(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sets: %w", err)
+	}
+
+	var points []StrengthPoint
+	for _, row := range rows {
+		l, ok := toLift(row.Repetitions, row.Weights, WeightUnit(row.WeightUnit))
+		if !ok || l.reps > MaxRepsFor1RM {
+			continue
+		}
+
+		estimate := formula.Estimate(l.weightKg, l.reps)
+		if len(points) == 0 || points[len(points)-1].WorkoutPlanId != row.WorkoutPlanId {
+			points = append(points, StrengthPoint{Date: row.ScheduledDate, WorkoutPlanId: row.WorkoutPlanId})
+		}
+		point := &points[len(points)-1]
+		if estimate > point.EstimatedOneRepMax {
+			point.Weight = l.weightKg
+			point.Repetitions = l.reps
+			point.EstimatedOneRepMax = estimate
+		}
+	}
+	return points, nil
+}
+
+// strengthTrend fits a line through the estimates of the last weeks before to, in kg per week
+func strengthTrend(points []StrengthPoint, to time.Time, weeks int) *StrengthTrend {
+	since := to.AddDate(0, 0, -7*weeks)
+	var recent []StrengthPoint
+	for _, point := range points {
+		if !point.Date.Before(since) {
+			recent = append(recent, point)
+		}
+	}
+	if len(recent) < 2 {
+		return nil
+	}
+
+	first := recent[0].Date
+	var meanX, meanY float64
+	xs := make([]float64, len(recent))
+	for i, point := range recent {
+		xs[i] = point.Date.Sub(first).Hours() / 24
+		meanX += xs[i]
+		meanY += point.EstimatedOneRepMax
+	}
+	meanX /= float64(len(recent))
+	meanY /= float64(len(recent))
+
+	var covariance, variance float64
+	for i, point := range recent {
+		covariance += (xs[i] - meanX) * (point.EstimatedOneRepMax - meanY)
+		variance += (xs[i] - meanX) * (xs[i] - meanX)
+	}
+	if variance == 0 {
+		return nil
+	}
+
+	slopePerDay := covariance / variance
+	start := meanY + slopePerDay*(xs[0]-meanX)
+	end := meanY + slopePerDay*(xs[len(xs)-1]-meanX)
+
+	trend := &StrengthTrend{
+		Weeks:        weeks,
+		Points:       len(recent),
+		SlopePerWeek: slopePerDay * 7,
+	}
+	if start > 0 {
+		trend.ChangePercent = math.Round((end-start)/start*10000) / 100
+	}
+	return trend
+}
+
+// MuscleBalanceConfig sets how lopsided each pair of the muscle balance report may get before it
+// is flagged, as the ratio of the larger side to the smaller one. A ratio of 1.5 flags push:pull
+// above 1.5 as well as below 1/1.5.
+type MuscleBalanceConfig struct {
+	PushPullRatio   float64
+	ChestBackRatio  float64
+	UpperLowerRatio float64
+}
+
+// balance pairs of the muscle balance report
+const (
+	PushPull   = "push_pull"
+	ChestBack  = "chest_back"
+	UpperLower = "upper_lower"
+)
+
+// balancePair compares the sets of two sides. Arms train both pushing and pulling and core is
+// neither, so they are only part of upper vs. lower.
+type balancePair struct {
+	name        string
+	left, right []MuscleGroup
+	maxRatio    func(MuscleBalanceConfig) float64
+}
+
+var balancePairs = []balancePair{
+	{PushPull, []MuscleGroup{Chest, Shoulders}, []MuscleGroup{Back}, func(c MuscleBalanceConfig) float64 { return c.PushPullRatio }},
+	{ChestBack, []MuscleGroup{Chest}, []MuscleGroup{Back}, func(c MuscleBalanceConfig) float64 { return c.ChestBackRatio }},
+	{UpperLower, []MuscleGroup{Chest, Back, Shoulders, Arms}, []MuscleGroup{Legs, Glutes}, func(c MuscleBalanceConfig) float64 { return c.UpperLowerRatio }},
+}
+
+// muscleGroups is the order of the groups in the muscle balance report
+var muscleGroups = []MuscleGroup{Chest, Back, Shoulders, Arms, Legs, Glutes, Core}
+
+// defaultBalanceRange is the length of the period when the query does not set from
+const defaultBalanceRange = 4 * 7 * 24 * time.Hour
+
+type MuscleBalanceQuery struct {
+	UserId int
+	// from is defaultBalanceRange before to when not set, to is now. The report is compared with
+	// the previous period when compareTo is not set.
+	ReportRange
+	Unit *WeightUnit // the user's preferred unit when not set
+}
+
+func (q *MuscleBalanceQuery) Validate(from, to time.Time) error {
+	if !to.After(from) {
+		return apperrors.NewValidationError(apperrors.INVALID_DATE, "to must be after from")
+	}
+
+	// the compared period is scanned too
+	if to.Sub(from) > MaxReportRange/2 {
+		return apperrors.NewValidationError(apperrors.INVALID_DATE, "date range can not be longer than one year")
+	}
+
+	if q.Unit != nil && *q.Unit != KG && *q.Unit != LBS {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, "unit must be kg or lbs")
+	}
+
+	return nil
+}
+
+// MuscleGroupBalance is the training of a muscle group in the period and in the compared one
+type MuscleGroupBalance struct {
+	MuscleGroup  MuscleGroup  `json:"muscleGroup"`
+	Current      VolumeTotals `json:"current"`
+	Previous     VolumeTotals `json:"previous"`
+	SetsChange   Delta        `json:"setsChange"`
+	VolumeChange Delta        `json:"volumeChange"`
+}
+
+// BalanceRatio is the sets of the left side of a pair divided by the right side. Ratio is nil when
+// the right side has no sets, Imbalanced is still set when only one side was trained.
+type BalanceRatio struct {
+	Name          string   `json:"name"`
+	LeftSets      int      `json:"leftSets"`
+	RightSets     int      `json:"rightSets"`
+	Ratio         *float64 `json:"ratio"`
+	PreviousRatio *float64 `json:"previousRatio"`
+	MaxRatio      float64  `json:"maxRatio"`
+	Imbalanced    bool     `json:"imbalanced"`
+}
+
+type MuscleBalanceReport struct {
+	From         time.Time            `json:"from"`
+	To           time.Time            `json:"to"`
+	Comparison   ComparedPeriod       `json:"comparison"`
+	Unit         WeightUnit           `json:"unit"`
+	MuscleGroups []MuscleGroupBalance `json:"muscleGroups"`
+	Ratios       []BalanceRatio       `json:"ratios"`
+}
+
+// MuscleBalance reports sets and volume per muscle group of the completed workouts in the period
+// and in the compared period, and flags the pairs whose ratio of sets is past the configured
+// limit. Sets in the other unit count as sets but add no volume.
+func (s *ReportService) MuscleBalance(ctx context.Context, query MuscleBalanceQuery) (*MuscleBalanceReport, error) {
+	loc, err := userLocation(ctx, s.userRepo, query.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := query.parse(loc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate: %w", err)
+	}
+	if to.IsZero() {
+		to = time.Now().In(loc)
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultBalanceRange)
+	}
+	if query.CompareTo == "" {
+		query.CompareTo = PREVIOUS
+	}
+
+	if err := query.Validate(from, to); err != nil {
+		return nil, fmt.Errorf("failed to validate: %w", err)
+	}
+
+	var unit WeightUnit
+	if query.Unit != nil {
+		unit = *query.Unit
+	} else {
+		preferred, err := s.userRepo.GetPreferredUnit(ctx, query.UserId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch preferred unit: %w", err)
+		}
+		unit = WeightUnit(preferred)
+	}
+
+	compared := comparedPeriod(query.CompareTo, from, to)
+	current, err := s.muscleGroupTotals(ctx, query.UserId, from, to, unit)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := s.muscleGroupTotals(ctx, query.UserId, compared.From, compared.To, unit)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &MuscleBalanceReport{
+		From:         from,
+		To:           to,
+		Comparison:   *compared,
+		Unit:         unit,
+		MuscleGroups: make([]MuscleGroupBalance, 0, len(muscleGroups)),
+		Ratios:       make([]BalanceRatio, 0, len(balancePairs)),
+	}
+
+	for _, group := range muscleGroups {
+		report.MuscleGroups = append(report.MuscleGroups, MuscleGroupBalance{
+			MuscleGroup:  group,
+			Current:      current[group],
+			Previous:     previous[group],
+			SetsChange:   newDelta(float64(previous[group].Sets), float64(current[group].Sets)),
+			VolumeChange: newDelta(previous[group].Volume, current[group].Volume),
+		})
+	}
+
+	for _, pair := range balancePairs {
+		left, right := sumSets(current, pair.left), sumSets(current, pair.right)
+		ratio := BalanceRatio{
+			Name:          pair.name,
+			LeftSets:      left,
+			RightSets:     right,
+			Ratio:         setRatio(left, right),
+			PreviousRatio: setRatio(sumSets(previous, pair.left), sumSets(previous, pair.right)),
+			MaxRatio:      pair.maxRatio(s.balance),
+		}
+
+		switch {
+		case ratio.MaxRatio <= 0 || left+right == 0:
+		case ratio.Ratio == nil:
+			ratio.Imbalanced = true
+		default:
+			ratio.Imbalanced = *ratio.Ratio > ratio.MaxRatio || *ratio.Ratio < 1/ratio.MaxRatio
+		}
+
+		report.Ratios = append(report.Ratios, ratio)
+	}
+
+	return report, nil
+}
+
+func (s *ReportService) muscleGroupTotals(ctx context.Context, userId int, from, to time.Time, unit WeightUnit) (map[MuscleGroup]VolumeTotals, error) {
+	rows, err := s.reportRepo.VolumeByMuscleGroup(ctx, repository.RangeFilter{
+		UserId: userId,
+		From:   from,
+		To:     to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate muscle groups: %w", err)
+	}
+
+	totals := make(map[MuscleGroup]VolumeTotals, len(rows))
+	for _, row := range rows {
+		totals[MuscleGroup(row.MuscleGroup)] = VolumeTotals{
+			Sets:        row.Sets,
+			Repetitions: row.Repetitions,
+			Volume:      FromKg(row.VolumeKg, unit),
+		}
+	}
+	return totals, nil
+}
+
+func sumSets(totals map[MuscleGroup]VolumeTotals, groups []MuscleGroup) int {
+	sets := 0
+	for _, group := range groups {
+		sets += totals[group].Sets
+	}
+	return sets
+}
+
+func setRatio(left, right int) *float64 {
+	if right == 0 {
+		return nil
+	}
+	ratio := math.Round(float64(left)/float64(right)*100) / 100
+	return &ratio
+}
+
+// percentChange is the change from previous to current in percent, nil when previous is 0
+func percentChange(previous, current float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	change := math.Round((current-previous)/previous*10000) / 100
+	return &change
 }
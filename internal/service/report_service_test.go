@@ -2,12 +2,15 @@ package service_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"workout-tracker-api/internal/apperrors"
 	"workout-tracker-api/internal/repository"
 	"workout-tracker-api/internal/service"
 )
@@ -61,6 +64,78 @@ func (m *MockWorkoutForReportRepository) ListUserWorkouts(ctx context.Context, u
 	return args.Get(0).([]repository.WorkoutPlan), args.Error(1)
 }
 
+func (m *MockWorkoutForReportRepository) ListWorkoutPage(ctx context.Context, filter repository.WorkoutPageFilter) ([]repository.WorkoutPlan, *repository.WorkoutCursor, error) {
+	args := m.Called(ctx, filter)
+	var next *repository.WorkoutCursor
+	if args.Get(1) != nil {
+		next = args.Get(1).(*repository.WorkoutCursor)
+	}
+	if args.Get(0) == nil {
+		return nil, next, args.Error(2)
+	}
+	return args.Get(0).([]repository.WorkoutPlan), next, args.Error(2)
+}
+
+func (m *MockWorkoutForReportRepository) MarkOverdueAsMissed(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockReportRepository is a mock implementation of repository.ReportRepository
+type MockReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportRepository) VolumeByPeriod(ctx context.Context, filter repository.VolumeFilter) ([]repository.VolumeRow, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.VolumeRow), args.Error(1)
+}
+
+func (m *MockReportRepository) WorkoutStatusByDay(ctx context.Context, filter repository.ConsistencyFilter) ([]repository.WorkoutDayRow, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.WorkoutDayRow), args.Error(1)
+}
+
+func (m *MockReportRepository) StrengthSets(ctx context.Context, filter repository.StrengthFilter) ([]repository.StrengthSetRow, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.StrengthSetRow), args.Error(1)
+}
+
+func (m *MockReportRepository) VolumeByMuscleGroup(ctx context.Context, filter repository.RangeFilter) ([]repository.MuscleGroupRow, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.MuscleGroupRow), args.Error(1)
+}
+
+// userInUTC is a MockUserRepository whose user keeps the default time zone
+func userInUTC(userID int) *MockUserRepository {
+	userRepo := new(MockUserRepository)
+	userRepo.On("GetTimeZone", mock.Anything, userID).Return("UTC", nil).Maybe()
+	return userRepo
+}
+
+// rangeOf is the range the handler passes on for from and to
+func rangeOf(from, to time.Time) service.ReportRange {
+	return service.ReportRange{From: from.Format(time.RFC3339), To: to.Format(time.RFC3339)}
+}
+
+var balanceConfig = service.MuscleBalanceConfig{
+	PushPullRatio:   1.5,
+	ChestBackRatio:  1.5,
+	UpperLowerRatio: 2,
+}
+
 // --- Tests ---
 
 func TestReportService_Progress(t *testing.T) {
@@ -137,8 +212,8 @@ func TestReportService_Progress(t *testing.T) {
 			mockWorkoutRepo := new(MockWorkoutForReportRepository)
 			tt.mockRepoSetup(mockWorkoutRepo)
 
-			reportService := service.NewReportService(mockWorkoutRepo)
-			progress, err := reportService.Progress(ctx, tt.userID)
+			reportService := service.NewReportService(mockWorkoutRepo, nil, nil, nil, balanceConfig)
+			progress, err := reportService.Progress(ctx, service.ProgressQuery{UserId: tt.userID})
 
 			if tt.expectedErrorType != nil {
 				assert.Error(t, err)
@@ -155,3 +230,752 @@ func TestReportService_Progress(t *testing.T) {
 		})
 	}
 }
+
+func TestReportService_ProgressInRange(t *testing.T) {
+	ctx := context.Background()
+	userID := 123
+	hongKong, _ := time.LoadLocation("Asia/Hong_Kong")
+	percent := func(v float64) *float64 { return &v }
+
+	t.Run("Dates are read in the user's time zone and compared with the previous period", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockUserRepo.On("GetTimeZone", ctx, userID).Return("Asia/Hong_Kong", nil).Once()
+
+		from := time.Date(2025, 5, 1, 0, 0, 0, 0, hongKong)
+		to := time.Date(2025, 5, 15, 0, 0, 0, 0, hongKong)
+		mockReportRepo.On("WorkoutStatusByDay", ctx, repository.ConsistencyFilter{UserId: userID, From: from, To: to, TimeZone: "Asia/Hong_Kong"}).Return([]repository.WorkoutDayRow{
+			{Day: time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC), Status: repository.COMPLETED, Count: 3},
+			{Day: time.Date(2025, 5, 9, 0, 0, 0, 0, time.UTC), Status: repository.MISSED, Count: 1},
+		}, nil).Once()
+		mockReportRepo.On("WorkoutStatusByDay", ctx, repository.ConsistencyFilter{UserId: userID, From: from.AddDate(0, 0, -14), To: from, TimeZone: "Asia/Hong_Kong"}).Return([]repository.WorkoutDayRow{
+			{Day: time.Date(2025, 4, 20, 0, 0, 0, 0, time.UTC), Status: repository.COMPLETED, Count: 2},
+		}, nil).Once()
+
+		reportService := service.NewReportService(nil, mockReportRepo, mockUserRepo, nil, balanceConfig)
+		progress, err := reportService.Progress(ctx, service.ProgressQuery{
+			UserId:      userID,
+			ReportRange: service.ReportRange{From: "2025-05-01", To: "2025-05-15", CompareTo: service.PREVIOUS},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, from, *progress.From)
+		assert.Equal(t, to, *progress.To)
+		assert.Equal(t, 3, progress.CompleteWorkouts)
+		assert.Equal(t, 4, progress.TotalWorkouts)
+		assert.Equal(t, &service.ProgressComparison{
+			ComparedPeriod:    service.ComparedPeriod{CompareTo: service.PREVIOUS, From: from.AddDate(0, 0, -14), To: from},
+			CompletedWorkouts: service.Delta{Previous: 2, Change: 1, Percent: percent(50)},
+			TotalWorkouts:     service.Delta{Previous: 2, Change: 2, Percent: percent(100)},
+		}, progress.Comparison)
+		mockReportRepo.AssertExpectations(t)
+	})
+
+	t.Run("To defaults to now without a comparison", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockReportRepo.On("WorkoutStatusByDay", ctx, mock.AnythingOfType("repository.ConsistencyFilter")).Return(nil, nil).Once()
+
+		reportService := service.NewReportService(nil, mockReportRepo, userInUTC(userID), nil, balanceConfig)
+		progress, err := reportService.Progress(ctx, service.ProgressQuery{UserId: userID, ReportRange: service.ReportRange{From: "2025-01-01T00:00:00Z"}})
+
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), *progress.To, time.Minute)
+		assert.Nil(t, progress.Comparison)
+		mockReportRepo.AssertExpectations(t)
+	})
+
+	validationTests := []struct {
+		name        string
+		reportRange service.ReportRange
+	}{
+		{name: "Compare without from", reportRange: service.ReportRange{CompareTo: service.PREVIOUS}},
+		{name: "To without from", reportRange: service.ReportRange{To: "2025-05-01"}},
+		{name: "Unreadable date", reportRange: service.ReportRange{From: "01/05/2025"}},
+		{name: "Unknown comparison", reportRange: service.ReportRange{From: "2025-05-01", CompareTo: "last_month"}},
+		{name: "To before from", reportRange: service.ReportRange{From: "2025-05-15", To: "2025-05-01"}},
+	}
+
+	for _, tt := range validationTests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockReportRepo := new(MockReportRepository)
+			reportService := service.NewReportService(nil, mockReportRepo, userInUTC(userID), nil, balanceConfig)
+			progress, err := reportService.Progress(ctx, service.ProgressQuery{UserId: userID, ReportRange: tt.reportRange})
+
+			var validationErr *apperrors.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Nil(t, progress)
+			mockReportRepo.AssertNotCalled(t, "WorkoutStatusByDay", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestReportService_Volume(t *testing.T) {
+	ctx := context.Background()
+	userID := 123
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC)
+	week1 := time.Date(2025, 4, 28, 0, 0, 0, 0, time.UTC)
+	week2 := time.Date(2025, 5, 5, 0, 0, 0, 0, time.UTC)
+	lbs := service.LBS
+	other := service.OTHER
+
+	volumeRows := []repository.VolumeRow{
+		{Period: week1, Sets: 3, Repetitions: 30, VolumeKg: 1500},
+		{Period: week1, MuscleGroup: sql.NullString{String: "chest", Valid: true}, Sets: 3, Repetitions: 30, VolumeKg: 1500},
+		{Period: week1, ExerciseId: sql.NullInt64{Int64: 2, Valid: true}, ExerciseName: sql.NullString{String: "Bench Press", Valid: true}, MuscleGroup: sql.NullString{String: "chest", Valid: true}, Sets: 3, Repetitions: 30, VolumeKg: 1500},
+		{Period: week2, Sets: 5, Repetitions: 25, VolumeKg: 2500},
+		{Period: week2, MuscleGroup: sql.NullString{String: "legs", Valid: true}, Sets: 5, Repetitions: 25, VolumeKg: 2500},
+		{Period: week2, ExerciseId: sql.NullInt64{Int64: 1, Valid: true}, ExerciseName: sql.NullString{String: "Squat", Valid: true}, MuscleGroup: sql.NullString{String: "legs", Valid: true}, Sets: 2, Repetitions: 10, VolumeKg: 1000},
+		{Period: week2, ExerciseId: sql.NullInt64{Int64: 4, Valid: true}, ExerciseName: sql.NullString{String: "Lunge", Valid: true}, MuscleGroup: sql.NullString{String: "legs", Valid: true}, Sets: 3, Repetitions: 15, VolumeKg: 1500},
+	}
+
+	t.Run("Periods are built from the aggregated rows in the preferred unit", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockUserRepo := userInUTC(userID)
+		mockUserRepo.On("GetPreferredUnit", ctx, userID).Return(repository.KG, nil).Once()
+		mockReportRepo.On("VolumeByPeriod", ctx, repository.VolumeFilter{UserId: userID, From: from, To: to, Bucket: repository.WEEK, TimeZone: "UTC"}).Return(volumeRows, nil).Once()
+
+		reportService := service.NewReportService(nil, mockReportRepo, mockUserRepo, nil, balanceConfig)
+		report, err := reportService.Volume(ctx, service.VolumeQuery{UserId: userID, ReportRange: rangeOf(from, to)})
+
+		assert.NoError(t, err)
+		assert.Equal(t, service.WEEK, report.Bucket)
+		assert.Equal(t, service.KG, report.Unit)
+		assert.Len(t, report.Periods, 2)
+		assert.Equal(t, week1, report.Periods[0].Start)
+		assert.Equal(t, service.VolumeTotals{Sets: 3, Repetitions: 30, Volume: 1500}, report.Periods[0].VolumeTotals)
+		assert.Equal(t, []service.MuscleGroupVolume{
+			{MuscleGroup: service.Legs, VolumeTotals: service.VolumeTotals{Sets: 5, Repetitions: 25, Volume: 2500}},
+		}, report.Periods[1].MuscleGroups)
+		assert.Equal(t, []service.ExerciseVolume{
+			{ExerciseId: 1, Name: "Squat", MuscleGroup: service.Legs, VolumeTotals: service.VolumeTotals{Sets: 2, Repetitions: 10, Volume: 1000}},
+			{ExerciseId: 4, Name: "Lunge", MuscleGroup: service.Legs, VolumeTotals: service.VolumeTotals{Sets: 3, Repetitions: 15, Volume: 1500}},
+		}, report.Periods[1].Exercises)
+
+		mockReportRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Totals are compared with the same dates a year earlier", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		filter := repository.VolumeFilter{UserId: userID, From: from, To: to, Bucket: repository.WEEK, TimeZone: "UTC"}
+		mockReportRepo.On("VolumeByPeriod", ctx, filter).Return(volumeRows, nil).Once()
+		filter.From, filter.To = from.AddDate(-1, 0, 0), to.AddDate(-1, 0, 0)
+		mockReportRepo.On("VolumeByPeriod", ctx, filter).Return(volumeRows[:3], nil).Once()
+
+		kg := service.KG
+		query := service.VolumeQuery{UserId: userID, ReportRange: rangeOf(from, to), Unit: &kg}
+		query.CompareTo = service.PREVIOUS_YEAR
+		reportService := service.NewReportService(nil, mockReportRepo, userInUTC(userID), nil, balanceConfig)
+		report, err := reportService.Volume(ctx, query)
+
+		assert.NoError(t, err)
+		assert.Equal(t, service.VolumeTotals{Sets: 8, Repetitions: 55, Volume: 4000}, report.Totals)
+		percent := func(v float64) *float64 { return &v }
+		assert.Equal(t, &service.VolumeComparison{
+			ComparedPeriod: service.ComparedPeriod{CompareTo: service.PREVIOUS_YEAR, From: filter.From, To: filter.To},
+			Sets:           service.Delta{Previous: 3, Change: 5, Percent: percent(166.67)},
+			Repetitions:    service.Delta{Previous: 30, Change: 25, Percent: percent(83.33)},
+			Volume:         service.Delta{Previous: 1500, Change: 2500, Percent: percent(166.67)},
+		}, report.Comparison)
+		mockReportRepo.AssertExpectations(t)
+	})
+
+	t.Run("Requested unit overrides the preferred unit", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockUserRepo := userInUTC(userID)
+		mockReportRepo.On("VolumeByPeriod", ctx, repository.VolumeFilter{UserId: userID, From: from, To: to, Bucket: repository.DAY, TimeZone: "UTC"}).Return(volumeRows[:3], nil).Once()
+
+		reportService := service.NewReportService(nil, mockReportRepo, mockUserRepo, nil, balanceConfig)
+		report, err := reportService.Volume(ctx, service.VolumeQuery{UserId: userID, ReportRange: rangeOf(from, to), Bucket: service.DAY, Unit: &lbs})
+
+		assert.NoError(t, err)
+		assert.Equal(t, service.LBS, report.Unit)
+		assert.Equal(t, 3306.93, report.Periods[0].Volume)
+		mockUserRepo.AssertNotCalled(t, "GetPreferredUnit")
+		mockReportRepo.AssertExpectations(t)
+	})
+
+	t.Run("No completed workouts gives no periods", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockUserRepo := userInUTC(userID)
+		mockUserRepo.On("GetPreferredUnit", ctx, userID).Return(repository.KG, nil).Once()
+		mockReportRepo.On("VolumeByPeriod", ctx, mock.AnythingOfType("repository.VolumeFilter")).Return(nil, nil).Once()
+
+		reportService := service.NewReportService(nil, mockReportRepo, mockUserRepo, nil, balanceConfig)
+		report, err := reportService.Volume(ctx, service.VolumeQuery{UserId: userID, ReportRange: rangeOf(from, to)})
+
+		assert.NoError(t, err)
+		assert.Empty(t, report.Periods)
+		assert.NotNil(t, report.Periods)
+	})
+
+	t.Run("Error fetching the preferred unit", func(t *testing.T) {
+		mockUserRepo := userInUTC(userID)
+		mockUserRepo.On("GetPreferredUnit", ctx, userID).Return(repository.WeightUnit(""), apperrors.ErrNotFound).Once()
+
+		reportService := service.NewReportService(nil, new(MockReportRepository), mockUserRepo, nil, balanceConfig)
+		report, err := reportService.Volume(ctx, service.VolumeQuery{UserId: userID, ReportRange: rangeOf(from, to)})
+
+		assert.EqualError(t, err, "failed to fetch preferred unit: resource not found")
+		assert.Nil(t, report)
+	})
+
+	t.Run("Error aggregating volume", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockReportRepo.On("VolumeByPeriod", ctx, mock.AnythingOfType("repository.VolumeFilter")).Return(nil, errors.New("db error")).Once()
+
+		reportService := service.NewReportService(nil, mockReportRepo, userInUTC(userID), nil, balanceConfig)
+		report, err := reportService.Volume(ctx, service.VolumeQuery{UserId: userID, ReportRange: rangeOf(from, to), Unit: &lbs})
+
+		assert.EqualError(t, err, "failed to aggregate volume: db error")
+		assert.Nil(t, report)
+	})
+
+	validationTests := []struct {
+		name  string
+		query service.VolumeQuery
+	}{
+		{name: "Missing range", query: service.VolumeQuery{UserId: userID}},
+		{name: "To before from", query: service.VolumeQuery{UserId: userID, ReportRange: rangeOf(to, from)}},
+		{name: "Range too long", query: service.VolumeQuery{UserId: userID, ReportRange: rangeOf(from, from.AddDate(3, 0, 0))}},
+		{name: "Unknown bucket", query: service.VolumeQuery{UserId: userID, ReportRange: rangeOf(from, to), Bucket: "year"}},
+		{name: "Unit other", query: service.VolumeQuery{UserId: userID, ReportRange: rangeOf(from, to), Unit: &other}},
+	}
+
+	for _, tt := range validationTests {
+		t.Run(tt.name, func(t *testing.T) {
+			reportService := service.NewReportService(nil, new(MockReportRepository), userInUTC(userID), nil, balanceConfig)
+			report, err := reportService.Volume(ctx, tt.query)
+
+			var validationErr *apperrors.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Nil(t, report)
+		})
+	}
+}
+
+func TestReportService_Consistency(t *testing.T) {
+	ctx := context.Background()
+	userID := 123
+	hongKong, _ := time.LoadLocation("Asia/Hong_Kong")
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("Weeks, months and days follow the user's time zone", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockUserRepo.On("GetTimeZone", ctx, userID).Return("Asia/Hong_Kong", nil).Once()
+
+		// wednesday 7 may to wednesday 28 may in Hong Kong, widened to the mondays around it
+		from := time.Date(2025, 5, 7, 1, 0, 0, 0, hongKong)
+		to := time.Date(2025, 5, 28, 1, 0, 0, 0, hongKong)
+		mockReportRepo.On("WorkoutStatusByDay", ctx, mock.MatchedBy(func(f repository.ConsistencyFilter) bool {
+			return f.UserId == userID && f.TimeZone == "Asia/Hong_Kong" &&
+				f.From.Equal(time.Date(2025, 5, 4, 16, 0, 0, 0, time.UTC)) &&
+				f.To.Equal(time.Date(2025, 6, 1, 16, 0, 0, 0, time.UTC))
+		})).Return([]repository.WorkoutDayRow{
+			{Day: date(2025, 5, 5), Status: repository.COMPLETED, Count: 2},
+			{Day: date(2025, 5, 8), Status: repository.COMPLETED, Count: 1},
+			{Day: date(2025, 5, 13), Status: repository.COMPLETED, Count: 3},
+			{Day: date(2025, 5, 13), Status: repository.MISSED, Count: 1},
+			{Day: date(2025, 5, 20), Status: repository.COMPLETED, Count: 1},
+			{Day: date(2025, 5, 21), Status: repository.MISSED, Count: 2},
+			{Day: date(2025, 5, 27), Status: repository.COMPLETED, Count: 2},
+			{Day: date(2025, 6, 1), Status: repository.COMPLETED, Count: 1},
+		}, nil).Once()
+
+		reportService := service.NewReportService(nil, mockReportRepo, mockUserRepo, nil, balanceConfig)
+		report, err := reportService.Consistency(ctx, service.ConsistencyQuery{UserId: userID, ReportRange: rangeOf(from, to)})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Asia/Hong_Kong", report.TimeZone)
+		assert.Equal(t, service.DefaultWeeklyTarget, report.WeeklyTarget)
+		assert.Equal(t, time.Date(2025, 5, 5, 0, 0, 0, 0, hongKong), report.From)
+		assert.Equal(t, time.Date(2025, 6, 2, 0, 0, 0, 0, hongKong), report.To)
+
+		if assert.Len(t, report.Weeks, 4) {
+			met := []bool{}
+			for _, week := range report.Weeks {
+				met = append(met, week.TargetMet)
+			}
+			assert.Equal(t, []bool{true, true, false, true}, met)
+			assert.Equal(t, 4, report.Weeks[1].Scheduled)
+			assert.Equal(t, 0.75, *report.Weeks[1].Rate)
+			assert.Equal(t, 2, report.Weeks[2].Missed)
+			assert.Equal(t, 0.3333, *report.Weeks[2].Rate)
+		}
+		assert.Equal(t, 1, report.CurrentStreak)
+		assert.Equal(t, 2, report.LongestStreak)
+
+		if assert.Len(t, report.Months, 2) {
+			assert.Equal(t, time.Date(2025, 5, 5, 0, 0, 0, 0, hongKong), report.Months[0].Start)
+			assert.Equal(t, 9, report.Months[0].Completed)
+			assert.Equal(t, time.Date(2025, 6, 1, 0, 0, 0, 0, hongKong), report.Months[1].Start)
+			assert.Equal(t, 1, report.Months[1].Completed)
+		}
+		assert.Equal(t, service.AdherenceTotals{Scheduled: 13, Completed: 10, Missed: 3, Rate: report.Adherence.Rate}, report.Adherence)
+		assert.Equal(t, 0.7692, *report.Adherence.Rate)
+
+		assert.Len(t, report.Days, 28)
+		assert.Equal(t, service.ConsistencyDay{Date: time.Date(2025, 5, 5, 0, 0, 0, 0, hongKong), Completed: 2}, report.Days[0])
+		assert.Equal(t, 0, report.Days[1].Completed)
+		mockReportRepo.AssertExpectations(t)
+	})
+
+	t.Run("The running week does not break the current streak", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockUserRepo.On("GetTimeZone", ctx, userID).Return("UTC", nil).Once()
+
+		now := time.Now().UTC()
+		monday := time.Date(now.Year(), now.Month(), now.Day()-(int(now.Weekday())+6)%7, 0, 0, 0, 0, time.UTC)
+		mockReportRepo.On("WorkoutStatusByDay", ctx, mock.MatchedBy(func(f repository.ConsistencyFilter) bool {
+			return f.From.Equal(monday.AddDate(0, 0, -7*52+7)) && f.To.Equal(monday.AddDate(0, 0, 7))
+		})).Return([]repository.WorkoutDayRow{
+			{Day: monday.AddDate(0, 0, -14), Status: repository.COMPLETED, Count: 3},
+			{Day: monday.AddDate(0, 0, -7), Status: repository.COMPLETED, Count: 3},
+			{Day: monday, Status: repository.PENDING, Count: 2},
+		}, nil).Once()
+
+		reportService := service.NewReportService(nil, mockReportRepo, mockUserRepo, nil, balanceConfig)
+		report, err := reportService.Consistency(ctx, service.ConsistencyQuery{UserId: userID})
+
+		assert.NoError(t, err)
+		assert.Len(t, report.Weeks, 52)
+		assert.Equal(t, 2, report.CurrentStreak)
+		assert.Equal(t, 2, report.LongestStreak)
+		assert.Equal(t, 2, report.Weeks[51].Pending)
+		assert.Equal(t, 0.0, *report.Weeks[51].Rate)
+		assert.Nil(t, report.Weeks[0].Rate)
+		mockReportRepo.AssertExpectations(t)
+	})
+
+	t.Run("Adherence is compared with the previous period", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		monday := date(2025, 5, 5)
+		mockReportRepo.On("WorkoutStatusByDay", ctx, repository.ConsistencyFilter{UserId: userID, From: monday, To: monday.AddDate(0, 0, 7), TimeZone: "UTC"}).Return([]repository.WorkoutDayRow{
+			{Day: monday, Status: repository.COMPLETED, Count: 3},
+			{Day: monday, Status: repository.MISSED, Count: 1},
+		}, nil).Once()
+		mockReportRepo.On("WorkoutStatusByDay", ctx, repository.ConsistencyFilter{UserId: userID, From: monday.AddDate(0, 0, -7), To: monday, TimeZone: "UTC"}).Return([]repository.WorkoutDayRow{
+			{Day: monday.AddDate(0, 0, -7), Status: repository.COMPLETED, Count: 1},
+			{Day: monday.AddDate(0, 0, -6), Status: repository.MISSED, Count: 1},
+		}, nil).Once()
+
+		query := service.ConsistencyQuery{UserId: userID, ReportRange: rangeOf(monday, monday.AddDate(0, 0, 7))}
+		query.CompareTo = service.PREVIOUS
+		reportService := service.NewReportService(nil, mockReportRepo, userInUTC(userID), nil, balanceConfig)
+		report, err := reportService.Consistency(ctx, query)
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, report.Comparison) {
+			percent := func(v float64) *float64 { return &v }
+			assert.Equal(t, service.Delta{Previous: 2, Change: 2, Percent: percent(100)}, report.Comparison.Scheduled)
+			assert.Equal(t, service.Delta{Previous: 1, Change: 2, Percent: percent(200)}, report.Comparison.Completed)
+			assert.Equal(t, service.Delta{Previous: 1, Change: 0, Percent: percent(0)}, report.Comparison.Missed)
+			assert.Equal(t, &service.Delta{Previous: 0.5, Change: 0.25, Percent: percent(50)}, report.Comparison.Rate)
+		}
+		mockReportRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown stored time zone falls back to UTC", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockUserRepo.On("GetTimeZone", ctx, userID).Return("Mars/Olympus", nil).Once()
+		mockReportRepo.On("WorkoutStatusByDay", ctx, mock.MatchedBy(func(f repository.ConsistencyFilter) bool {
+			return f.TimeZone == "UTC"
+		})).Return(nil, nil).Once()
+
+		reportService := service.NewReportService(nil, mockReportRepo, mockUserRepo, nil, balanceConfig)
+		report, err := reportService.Consistency(ctx, service.ConsistencyQuery{UserId: userID, ReportRange: rangeOf(date(2025, 5, 5), date(2025, 5, 12))})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "UTC", report.TimeZone)
+		assert.Len(t, report.Weeks, 1)
+		assert.Equal(t, 0, report.CurrentStreak)
+	})
+
+	t.Run("Error fetching the time zone", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockUserRepo.On("GetTimeZone", ctx, userID).Return("", apperrors.ErrNotFound).Once()
+
+		reportService := service.NewReportService(nil, new(MockReportRepository), mockUserRepo, nil, balanceConfig)
+		report, err := reportService.Consistency(ctx, service.ConsistencyQuery{UserId: userID})
+
+		assert.EqualError(t, err, "failed to fetch time zone: resource not found")
+		assert.Nil(t, report)
+	})
+
+	t.Run("Error aggregating workouts", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockUserRepo.On("GetTimeZone", ctx, userID).Return("UTC", nil).Once()
+		mockReportRepo.On("WorkoutStatusByDay", ctx, mock.Anything).Return(nil, errors.New("db error")).Once()
+
+		reportService := service.NewReportService(nil, mockReportRepo, mockUserRepo, nil, balanceConfig)
+		report, err := reportService.Consistency(ctx, service.ConsistencyQuery{UserId: userID})
+
+		assert.EqualError(t, err, "failed to aggregate workouts by day: db error")
+		assert.Nil(t, report)
+	})
+
+	validationTests := []struct {
+		name  string
+		query service.ConsistencyQuery
+	}{
+		{name: "To before from", query: service.ConsistencyQuery{UserId: userID, ReportRange: rangeOf(date(2025, 5, 12), date(2025, 5, 5))}},
+		{name: "Range too long", query: service.ConsistencyQuery{UserId: userID, ReportRange: rangeOf(date(2020, 1, 1), date(2025, 1, 1))}},
+		{name: "Weekly target too high", query: service.ConsistencyQuery{UserId: userID, WeeklyTarget: 30}},
+		{name: "Negative weekly target", query: service.ConsistencyQuery{UserId: userID, WeeklyTarget: -1}},
+	}
+
+	for _, tt := range validationTests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockReportRepo := new(MockReportRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockUserRepo.On("GetTimeZone", ctx, userID).Return("UTC", nil).Once()
+
+			reportService := service.NewReportService(nil, mockReportRepo, mockUserRepo, nil, balanceConfig)
+			report, err := reportService.Consistency(ctx, tt.query)
+
+			var validationErr *apperrors.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Nil(t, report)
+			mockReportRepo.AssertNotCalled(t, "WorkoutStatusByDay", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOneRepMaxFormula_Estimate(t *testing.T) {
+	assert.Equal(t, 116.67, service.FromKg(service.EPLEY.Estimate(100, 5), service.KG))
+	assert.Equal(t, 112.5, service.FromKg(service.BRZYCKI.Estimate(100, 5), service.KG))
+	assert.Equal(t, 117.46, service.FromKg(service.LOMBARDI.Estimate(100, 5), service.KG))
+	for _, formula := range []service.OneRepMaxFormula{service.EPLEY, service.BRZYCKI, service.LOMBARDI} {
+		assert.Equal(t, 100.0, formula.Estimate(100, 1))
+	}
+}
+
+func TestReportService_Strength(t *testing.T) {
+	ctx := context.Background()
+	userID := 123
+	exerciseID := 2
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	bench := &repository.Exercise{Id: exerciseID, Name: "Bench Press", MuscleGroup: repository.Chest}
+	filter := repository.StrengthFilter{UserId: userID, ExerciseId: exerciseID, From: from, To: to}
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2025, month, d, 18, 0, 0, 0, time.UTC)
+	}
+
+	setRows := []repository.StrengthSetRow{
+		{WorkoutPlanId: 1, ScheduledDate: day(1, 1), Repetitions: 5, Weights: 100, WeightUnit: repository.KG},
+		{WorkoutPlanId: 1, ScheduledDate: day(1, 1), Repetitions: 1, Weights: 110, WeightUnit: repository.KG},
+		{WorkoutPlanId: 2, ScheduledDate: day(1, 15), Repetitions: 3, Weights: 225, WeightUnit: repository.LBS},
+		{WorkoutPlanId: 2, ScheduledDate: day(1, 15), Repetitions: 1, Weights: 200, WeightUnit: repository.OTHER},
+		{WorkoutPlanId: 2, ScheduledDate: day(1, 15), Repetitions: 20, Weights: 100, WeightUnit: repository.KG},
+		{WorkoutPlanId: 3, ScheduledDate: day(2, 12), Repetitions: 5, Weights: 105, WeightUnit: repository.KG},
+		{WorkoutPlanId: 4, ScheduledDate: day(2, 26), Repetitions: 5, Weights: 110, WeightUnit: repository.KG},
+	}
+
+	t.Run("Best set per workout with rolling best and trend", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockUserRepo := userInUTC(userID)
+		mockExerciseRepo := new(MockExerciseRepository)
+		mockExerciseRepo.On("GetExerciseById", ctx, exerciseID).Return(bench, nil).Once()
+		mockUserRepo.On("GetPreferredUnit", ctx, userID).Return(repository.KG, nil).Once()
+		mockReportRepo.On("StrengthSets", ctx, filter).Return(setRows, nil).Once()
+
+		reportService := service.NewReportService(nil, mockReportRepo, mockUserRepo, mockExerciseRepo, balanceConfig)
+		report, err := reportService.Strength(ctx, service.StrengthQuery{UserId: userID, ExerciseId: exerciseID, ReportRange: rangeOf(from, to)})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Bench Press", report.Name)
+		assert.Equal(t, service.EPLEY, report.Formula)
+		assert.Equal(t, service.KG, report.Unit)
+		assert.Equal(t, []service.StrengthPoint{
+			{Date: day(1, 1), WorkoutPlanId: 1, Weight: 100, Repetitions: 5, EstimatedOneRepMax: 116.67, RollingBest: 116.67},
+			{Date: day(1, 15), WorkoutPlanId: 2, Weight: 102.06, Repetitions: 3, EstimatedOneRepMax: 112.27, RollingBest: 116.67},
+			{Date: day(2, 12), WorkoutPlanId: 3, Weight: 105, Repetitions: 5, EstimatedOneRepMax: 122.5, RollingBest: 122.5},
+			{Date: day(2, 26), WorkoutPlanId: 4, Weight: 110, Repetitions: 5, EstimatedOneRepMax: 128.33, RollingBest: 128.33},
+		}, report.Points)
+		assert.Equal(t, 4, report.Best.WorkoutPlanId)
+		// the first workout is older than the eight trend weeks
+		assert.Equal(t, &service.StrengthTrend{Weeks: 8, Points: 3, SlopePerWeek: 2.66, ChangePercent: 14.23}, report.Trend)
+
+		mockReportRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Formula and unit can be chosen", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockUserRepo := userInUTC(userID)
+		mockExerciseRepo := new(MockExerciseRepository)
+		mockExerciseRepo.On("GetExerciseById", ctx, exerciseID).Return(bench, nil).Once()
+		mockReportRepo.On("StrengthSets", ctx, filter).Return(setRows[:1], nil).Once()
+
+		lbs := service.LBS
+		reportService := service.NewReportService(nil, mockReportRepo, mockUserRepo, mockExerciseRepo, balanceConfig)
+		report, err := reportService.Strength(ctx, service.StrengthQuery{UserId: userID, ExerciseId: exerciseID, ReportRange: rangeOf(from, to), Formula: service.BRZYCKI, Unit: &lbs})
+
+		assert.NoError(t, err)
+		assert.Equal(t, service.LBS, report.Unit)
+		assert.Equal(t, 248.02, report.Points[0].EstimatedOneRepMax)
+		assert.Equal(t, 220.46, report.Points[0].Weight)
+		assert.Nil(t, report.Trend)
+		mockUserRepo.AssertNotCalled(t, "GetPreferredUnit")
+	})
+
+	t.Run("Best estimate is compared with the previous period", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockExerciseRepo := new(MockExerciseRepository)
+		mockExerciseRepo.On("GetExerciseById", ctx, exerciseID).Return(bench, nil).Once()
+		mockReportRepo.On("StrengthSets", ctx, filter).Return(setRows, nil).Once()
+		previousFilter := filter
+		previousFilter.From, previousFilter.To = from.Add(-to.Sub(from)), from
+		mockReportRepo.On("StrengthSets", ctx, previousFilter).Return(setRows[:1], nil).Once()
+
+		kg := service.KG
+		query := service.StrengthQuery{UserId: userID, ExerciseId: exerciseID, ReportRange: rangeOf(from, to), Unit: &kg}
+		query.CompareTo = service.PREVIOUS
+		reportService := service.NewReportService(nil, mockReportRepo, userInUTC(userID), mockExerciseRepo, balanceConfig)
+		report, err := reportService.Strength(ctx, query)
+
+		assert.NoError(t, err)
+		percent := func(v float64) *float64 { return &v }
+		assert.Equal(t, &service.StrengthComparison{
+			ComparedPeriod:         service.ComparedPeriod{CompareTo: service.PREVIOUS, From: previousFilter.From, To: from},
+			Workouts:               service.Delta{Previous: 1, Change: 3, Percent: percent(300)},
+			BestEstimatedOneRepMax: &service.Delta{Previous: 116.67, Change: 11.66, Percent: percent(9.99)},
+		}, report.Comparison)
+		mockReportRepo.AssertExpectations(t)
+	})
+
+	t.Run("No completed workouts", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockUserRepo := userInUTC(userID)
+		mockExerciseRepo := new(MockExerciseRepository)
+		mockExerciseRepo.On("GetExerciseById", ctx, exerciseID).Return(bench, nil).Once()
+		mockUserRepo.On("GetPreferredUnit", ctx, userID).Return(repository.KG, nil).Once()
+		mockReportRepo.On("StrengthSets", ctx, mock.AnythingOfType("repository.StrengthFilter")).Return(nil, nil).Once()
+
+		reportService := service.NewReportService(nil, mockReportRepo, mockUserRepo, mockExerciseRepo, balanceConfig)
+		report, err := reportService.Strength(ctx, service.StrengthQuery{UserId: userID, ExerciseId: exerciseID})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, report.Points)
+		assert.Empty(t, report.Points)
+		assert.Nil(t, report.Best)
+		assert.Nil(t, report.Trend)
+		assert.WithinDuration(t, time.Now(), report.To, time.Minute)
+		assert.Equal(t, report.To.AddDate(0, 0, -364), report.From)
+	})
+
+	t.Run("Custom exercise of another user", func(t *testing.T) {
+		mockExerciseRepo := new(MockExerciseRepository)
+		mockExerciseRepo.On("GetExerciseById", ctx, exerciseID).Return(&repository.Exercise{
+			Id:      exerciseID,
+			OwnerId: sql.NullInt64{Int64: 7, Valid: true},
+		}, nil).Once()
+
+		reportService := service.NewReportService(nil, new(MockReportRepository), userInUTC(userID), mockExerciseRepo, balanceConfig)
+		report, err := reportService.Strength(ctx, service.StrengthQuery{UserId: userID, ExerciseId: exerciseID, ReportRange: rangeOf(from, to)})
+
+		assert.ErrorIs(t, err, apperrors.ErrForbidden)
+		assert.Nil(t, report)
+	})
+
+	t.Run("Unknown exercise", func(t *testing.T) {
+		mockExerciseRepo := new(MockExerciseRepository)
+		mockExerciseRepo.On("GetExerciseById", ctx, exerciseID).Return(nil, apperrors.ErrNotFound).Once()
+
+		reportService := service.NewReportService(nil, new(MockReportRepository), userInUTC(userID), mockExerciseRepo, balanceConfig)
+		report, err := reportService.Strength(ctx, service.StrengthQuery{UserId: userID, ExerciseId: exerciseID, ReportRange: rangeOf(from, to)})
+
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Nil(t, report)
+	})
+
+	t.Run("Error fetching sets", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockExerciseRepo := new(MockExerciseRepository)
+		mockExerciseRepo.On("GetExerciseById", ctx, exerciseID).Return(bench, nil).Once()
+		mockReportRepo.On("StrengthSets", ctx, filter).Return(nil, errors.New("db error")).Once()
+
+		kg := service.KG
+		reportService := service.NewReportService(nil, mockReportRepo, userInUTC(userID), mockExerciseRepo, balanceConfig)
+		report, err := reportService.Strength(ctx, service.StrengthQuery{UserId: userID, ExerciseId: exerciseID, ReportRange: rangeOf(from, to), Unit: &kg})
+
+		assert.EqualError(t, err, "failed to fetch sets: db error")
+		assert.Nil(t, report)
+	})
+
+	other := service.OTHER
+	validationTests := []struct {
+		name  string
+		query service.StrengthQuery
+	}{
+		{name: "Missing exercise", query: service.StrengthQuery{UserId: userID, ReportRange: rangeOf(from, to)}},
+		{name: "To before from", query: service.StrengthQuery{UserId: userID, ExerciseId: exerciseID, ReportRange: rangeOf(to, from)}},
+		{name: "Unknown formula", query: service.StrengthQuery{UserId: userID, ExerciseId: exerciseID, ReportRange: rangeOf(from, to), Formula: "wathan"}},
+		{name: "Unit other", query: service.StrengthQuery{UserId: userID, ExerciseId: exerciseID, ReportRange: rangeOf(from, to), Unit: &other}},
+		{name: "Trend weeks too long", query: service.StrengthQuery{UserId: userID, ExerciseId: exerciseID, ReportRange: rangeOf(from, to), TrendWeeks: 200}},
+	}
+
+	for _, tt := range validationTests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExerciseRepo := new(MockExerciseRepository)
+			reportService := service.NewReportService(nil, new(MockReportRepository), userInUTC(userID), mockExerciseRepo, balanceConfig)
+			report, err := reportService.Strength(ctx, tt.query)
+
+			var validationErr *apperrors.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Nil(t, report)
+			mockExerciseRepo.AssertNotCalled(t, "GetExerciseById", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestReportService_MuscleBalance(t *testing.T) {
+	ctx := context.Background()
+	userID := 123
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 5, 29, 0, 0, 0, 0, time.UTC)
+	previousFrom := time.Date(2025, 4, 3, 0, 0, 0, 0, time.UTC)
+	currentFilter := repository.RangeFilter{UserId: userID, From: from, To: to}
+	previousFilter := repository.RangeFilter{UserId: userID, From: previousFrom, To: from}
+	ratio := func(v float64) *float64 { return &v }
+
+	t.Run("Compares with the previous period and flags imbalances", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockUserRepo := userInUTC(userID)
+		mockUserRepo.On("GetPreferredUnit", ctx, userID).Return(repository.KG, nil).Once()
+		mockReportRepo.On("VolumeByMuscleGroup", ctx, currentFilter).Return([]repository.MuscleGroupRow{
+			{MuscleGroup: "back", Sets: 9, Repetitions: 90, VolumeKg: 5400},
+			{MuscleGroup: "chest", Sets: 12, Repetitions: 96, VolumeKg: 6000},
+			{MuscleGroup: "legs", Sets: 10, Repetitions: 80, VolumeKg: 8000},
+			{MuscleGroup: "shoulders", Sets: 6, Repetitions: 60, VolumeKg: 1800},
+		}, nil).Once()
+		mockReportRepo.On("VolumeByMuscleGroup", ctx, previousFilter).Return([]repository.MuscleGroupRow{
+			{MuscleGroup: "back", Sets: 10, Repetitions: 100, VolumeKg: 6000},
+			{MuscleGroup: "chest", Sets: 10, Repetitions: 80, VolumeKg: 4000},
+		}, nil).Once()
+
+		reportService := service.NewReportService(nil, mockReportRepo, mockUserRepo, nil, balanceConfig)
+		report, err := reportService.MuscleBalance(ctx, service.MuscleBalanceQuery{UserId: userID, ReportRange: rangeOf(from, to)})
+
+		assert.NoError(t, err)
+		assert.Equal(t, service.ComparedPeriod{CompareTo: service.PREVIOUS, From: previousFrom, To: from}, report.Comparison)
+		assert.Equal(t, service.KG, report.Unit)
+		assert.Len(t, report.MuscleGroups, 7)
+		assert.Equal(t, service.MuscleGroupBalance{
+			MuscleGroup:  service.Chest,
+			Current:      service.VolumeTotals{Sets: 12, Repetitions: 96, Volume: 6000},
+			Previous:     service.VolumeTotals{Sets: 10, Repetitions: 80, Volume: 4000},
+			SetsChange:   service.Delta{Previous: 10, Change: 2, Percent: ratio(20)},
+			VolumeChange: service.Delta{Previous: 4000, Change: 2000, Percent: ratio(50)},
+		}, report.MuscleGroups[0])
+		assert.Equal(t, service.Back, report.MuscleGroups[1].MuscleGroup)
+		assert.Equal(t, service.Delta{Previous: 10, Change: -1, Percent: ratio(-10)}, report.MuscleGroups[1].SetsChange)
+		// nothing the period before to compare with
+		assert.Equal(t, service.Legs, report.MuscleGroups[4].MuscleGroup)
+		assert.Equal(t, service.Delta{Change: 10}, report.MuscleGroups[4].SetsChange)
+
+		assert.Equal(t, []service.BalanceRatio{
+			{Name: service.PushPull, LeftSets: 18, RightSets: 9, Ratio: ratio(2), PreviousRatio: ratio(1), MaxRatio: 1.5, Imbalanced: true},
+			{Name: service.ChestBack, LeftSets: 12, RightSets: 9, Ratio: ratio(1.33), PreviousRatio: ratio(1), MaxRatio: 1.5},
+			{Name: service.UpperLower, LeftSets: 27, RightSets: 10, Ratio: ratio(2.7), MaxRatio: 2, Imbalanced: true},
+		}, report.Ratios)
+
+		mockReportRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Compared with the same dates a year earlier", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockReportRepo.On("VolumeByMuscleGroup", ctx, currentFilter).Return(nil, nil).Once()
+		mockReportRepo.On("VolumeByMuscleGroup", ctx, repository.RangeFilter{UserId: userID, From: from.AddDate(-1, 0, 0), To: to.AddDate(-1, 0, 0)}).Return(nil, nil).Once()
+
+		kg := service.KG
+		query := service.MuscleBalanceQuery{UserId: userID, ReportRange: rangeOf(from, to), Unit: &kg}
+		query.CompareTo = service.PREVIOUS_YEAR
+		reportService := service.NewReportService(nil, mockReportRepo, userInUTC(userID), nil, balanceConfig)
+		report, err := reportService.MuscleBalance(ctx, query)
+
+		assert.NoError(t, err)
+		assert.Equal(t, service.PREVIOUS_YEAR, report.Comparison.CompareTo)
+		assert.Equal(t, to.AddDate(-1, 0, 0), report.Comparison.To)
+		mockReportRepo.AssertExpectations(t)
+	})
+
+	t.Run("One side without sets", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockReportRepo.On("VolumeByMuscleGroup", ctx, currentFilter).Return([]repository.MuscleGroupRow{
+			{MuscleGroup: "chest", Sets: 8, Repetitions: 64, VolumeKg: 3000},
+		}, nil).Once()
+		mockReportRepo.On("VolumeByMuscleGroup", ctx, previousFilter).Return(nil, nil).Once()
+
+		lbs := service.LBS
+		reportService := service.NewReportService(nil, mockReportRepo, userInUTC(userID), nil, balanceConfig)
+		report, err := reportService.MuscleBalance(ctx, service.MuscleBalanceQuery{UserId: userID, ReportRange: rangeOf(from, to), Unit: &lbs})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 6613.87, report.MuscleGroups[0].Current.Volume)
+		assert.Nil(t, report.Ratios[0].Ratio)
+		assert.True(t, report.Ratios[0].Imbalanced)
+		assert.Nil(t, report.Ratios[0].PreviousRatio)
+	})
+
+	t.Run("No workouts", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockUserRepo := userInUTC(userID)
+		mockUserRepo.On("GetPreferredUnit", ctx, userID).Return(repository.LBS, nil).Once()
+		mockReportRepo.On("VolumeByMuscleGroup", ctx, mock.AnythingOfType("repository.RangeFilter")).Return(nil, nil).Twice()
+
+		reportService := service.NewReportService(nil, mockReportRepo, mockUserRepo, nil, balanceConfig)
+		report, err := reportService.MuscleBalance(ctx, service.MuscleBalanceQuery{UserId: userID})
+
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), report.To, time.Minute)
+		assert.Equal(t, report.To.AddDate(0, 0, -28), report.From)
+		assert.Equal(t, report.To.AddDate(0, 0, -56), report.Comparison.From)
+		for _, r := range report.Ratios {
+			assert.False(t, r.Imbalanced)
+		}
+		mockReportRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error aggregating", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockReportRepo.On("VolumeByMuscleGroup", ctx, currentFilter).Return(nil, errors.New("db error")).Once()
+
+		kg := service.KG
+		reportService := service.NewReportService(nil, mockReportRepo, userInUTC(userID), nil, balanceConfig)
+		report, err := reportService.MuscleBalance(ctx, service.MuscleBalanceQuery{UserId: userID, ReportRange: rangeOf(from, to), Unit: &kg})
+
+		assert.EqualError(t, err, "failed to aggregate muscle groups: db error")
+		assert.Nil(t, report)
+	})
+
+	other := service.OTHER
+	validationTests := []struct {
+		name  string
+		query service.MuscleBalanceQuery
+	}{
+		{name: "To before from", query: service.MuscleBalanceQuery{UserId: userID, ReportRange: rangeOf(to, from)}},
+		{name: "Range too long", query: service.MuscleBalanceQuery{UserId: userID, ReportRange: rangeOf(from.AddDate(-2, 0, 0), to)}},
+		{name: "Unit other", query: service.MuscleBalanceQuery{UserId: userID, ReportRange: rangeOf(from, to), Unit: &other}},
+	}
+
+	for _, tt := range validationTests {
+		t.Run(tt.name, func(t *testing.T) {
+			reportService := service.NewReportService(nil, new(MockReportRepository), userInUTC(userID), nil, balanceConfig)
+			report, err := reportService.MuscleBalance(ctx, tt.query)
+
+			var validationErr *apperrors.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Nil(t, report)
+		})
+	}
+}
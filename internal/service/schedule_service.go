@@ -0,0 +1,646 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+)
+
+// a series is expanded into concrete workout plans up front, so it has to stay bounded
+const MaxOccurrences = 366
+
+type Frequency string
+
+const (
+	DAILY   Frequency = "daily"
+	WEEKLY  Frequency = "weekly"
+	MONTHLY Frequency = "monthly"
+)
+
+// Weekday uses the RRULE BYDAY notation
+type Weekday string
+
+const (
+	MO Weekday = "MO"
+	TU Weekday = "TU"
+	WE Weekday = "WE"
+	TH Weekday = "TH"
+	FR Weekday = "FR"
+	SA Weekday = "SA"
+	SU Weekday = "SU"
+)
+
+// offset of the weekday from Monday
+var weekdayOffsets = map[Weekday]int{MO: 0, TU: 1, WE: 2, TH: 3, FR: 4, SA: 5, SU: 6}
+
+type RecurrenceRule struct {
+	Frequency Frequency  `json:"frequency"`
+	Interval  int        `json:"interval"`
+	Weekdays  []Weekday  `json:"weekdays,omitempty"`
+	StartDate time.Time  `json:"startDate"`
+	Until     *time.Time `json:"until,omitempty"`
+	Count     *int       `json:"count,omitempty"`
+}
+
+func (rule *RecurrenceRule) Validate() error {
+	switch rule.Frequency {
+	case DAILY, WEEKLY, MONTHLY:
+	default:
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, "invalid frequency")
+	}
+
+	if rule.Interval <= 0 || rule.Interval > 99 {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, "interval must be between 1 and 99")
+	}
+
+	if len(rule.Weekdays) > 0 && rule.Frequency != WEEKLY {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, "weekdays can only be set on a weekly frequency")
+	}
+
+	for _, day := range rule.Weekdays {
+		if _, ok := weekdayOffsets[day]; !ok {
+			return apperrors.NewValidationError(apperrors.INVALID_SETTING, fmt.Sprintf("invalid weekday '%s'", day))
+		}
+	}
+
+	if rule.StartDate.IsZero() {
+		return apperrors.NewValidationError(apperrors.INVALID_DATE, "not valid start date, date is not set")
+	}
+
+	if rule.Until == nil && rule.Count == nil {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, "either until or count must be set")
+	}
+
+	if rule.Until != nil && rule.Until.Before(rule.StartDate) {
+		return apperrors.NewValidationError(apperrors.INVALID_DATE, "until can not be before start date")
+	}
+
+	if rule.Count != nil && (*rule.Count <= 0 || *rule.Count > MaxOccurrences) {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, fmt.Sprintf("count must be between 1 and %d", MaxOccurrences))
+	}
+
+	return nil
+}
+
+// String renders the rule in RFC 5545 RRULE notation.
+func (rule RecurrenceRule) String() string {
+	parts := []string{
+		"FREQ=" + strings.ToUpper(string(rule.Frequency)),
+		fmt.Sprintf("INTERVAL=%d", rule.Interval),
+	}
+
+	if len(rule.Weekdays) > 0 {
+		days := make([]string, len(rule.Weekdays))
+		for i, day := range rule.Weekdays {
+			days[i] = string(day)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if rule.Until != nil {
+		parts = append(parts, "UNTIL="+rule.Until.UTC().Format("20060102T150405Z"))
+	}
+
+	if rule.Count != nil {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", *rule.Count))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Occurrences expands the rule into the dates of the series, in order. The time of day and the
+// location of the start date are kept for every occurrence.
+func (rule RecurrenceRule) Occurrences() ([]time.Time, error) {
+	limit := MaxOccurrences
+	if rule.Count != nil {
+		limit = *rule.Count
+	}
+
+	var occurrences []time.Time
+
+	// add reports whether the expansion should go on
+	add := func(date time.Time) bool {
+		if rule.Until != nil && date.After(*rule.Until) {
+			return false
+		}
+		occurrences = append(occurrences, date)
+		return len(occurrences) < limit
+	}
+
+	start := rule.StartDate
+
+	switch rule.Frequency {
+	case DAILY:
+		for i := 0; add(start.AddDate(0, 0, i*rule.Interval)); i++ {
+		}
+
+	case WEEKLY:
+		days := rule.Weekdays
+		if len(days) == 0 {
+			days = []Weekday{weekdayOf(start)}
+		}
+		offsets := make([]int, 0, len(days))
+		for _, day := range days {
+			offsets = append(offsets, weekdayOffsets[day])
+		}
+		slices.Sort(offsets)
+		offsets = slices.Compact(offsets)
+
+		weekStart := start.AddDate(0, 0, -weekdayOffsets[weekdayOf(start)])
+
+	weeks:
+		for week := 0; week <= MaxOccurrences; week += rule.Interval {
+			for _, offset := range offsets {
+				date := weekStart.AddDate(0, 0, week*7+offset)
+				if date.Before(start) {
+					continue
+				}
+				if !add(date) {
+					break weeks
+				}
+			}
+		}
+
+	case MONTHLY:
+		for i := 0; i <= MaxOccurrences; i++ {
+			date := start.AddDate(0, i*rule.Interval, 0)
+			// months without that day are skipped, as RRULE does
+			if date.Day() != start.Day() {
+				continue
+			}
+			if !add(date) {
+				break
+			}
+		}
+	}
+
+	if rule.Count == nil && rule.Until != nil && len(occurrences) == MaxOccurrences {
+		last := occurrences[len(occurrences)-1]
+		if last.Before(*rule.Until) {
+			return nil, apperrors.NewValidationError(apperrors.INVALID_SETTING, fmt.Sprintf("series can not have more than %d occurrences", MaxOccurrences))
+		}
+	}
+
+	return occurrences, nil
+}
+
+func weekdayOf(date time.Time) Weekday {
+	return [...]Weekday{SU, MO, TU, WE, TH, FR, SA}[date.Weekday()]
+}
+
+type WorkoutSchedule struct {
+	Id          int            `json:"id"`
+	UserId      int            `json:"userId"`
+	Recurrence  RecurrenceRule `json:"recurrence"`
+	CancelledAt *time.Time     `json:"cancelledAt,omitempty"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+	Workouts    []WorkoutPlan  `json:"workoutPlans,omitempty"`
+}
+
+type WorkoutScheduleCreate struct {
+	UserId        int                  `json:"userId"`
+	Recurrence    RecurrenceRule       `json:"recurrence"`
+	ExercisePlans []ExercisePlanCreate `json:"exercisePlans"`
+}
+
+func (data *WorkoutScheduleCreate) Validate() error {
+	if data.UserId <= 0 {
+		return apperrors.NewValidationError(apperrors.INVALID_ID, "not a valid user id")
+	}
+
+	if err := data.Recurrence.Validate(); err != nil {
+		return err
+	}
+
+	for _, ep := range data.ExercisePlans {
+		if err := ep.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type OccurrenceScope string
+
+const (
+	THIS_OCCURRENCE       OccurrenceScope = "this"
+	FOLLOWING_OCCURRENCES OccurrenceScope = "following"
+)
+
+// OccurrenceUpdate edits one occurrence of a series, or that occurrence and all following ones.
+// A nil ExercisePlans keeps the exercise plans as they are.
+type OccurrenceUpdate struct {
+	Scope         OccurrenceScope      `json:"scope"`
+	ScheduledDate *time.Time           `json:"scheduledDate,omitempty"`
+	ExercisePlans []ExercisePlanCreate `json:"exercisePlans,omitempty"`
+}
+
+func (data *OccurrenceUpdate) Validate() error {
+	switch data.Scope {
+	case THIS_OCCURRENCE, FOLLOWING_OCCURRENCES:
+	default:
+		return apperrors.NewValidationError(apperrors.INVALID_INPUT, "scope must be 'this' or 'following'")
+	}
+
+	for _, ep := range data.ExercisePlans {
+		if err := ep.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type ScheduleServiceInterface interface {
+	CreateSchedule(ctx context.Context, data WorkoutScheduleCreate) (*WorkoutSchedule, error)
+	PreviewSchedule(ctx context.Context, rule RecurrenceRule) ([]time.Time, error)
+	GetScheduleById(ctx context.Context, id int) (*WorkoutSchedule, error)
+	ListSchedules(ctx context.Context, userId int) ([]WorkoutSchedule, error)
+	CancelSchedule(ctx context.Context, id int, from *time.Time) (*WorkoutSchedule, error)
+	UpdateOccurrence(ctx context.Context, scheduleId int, workoutId int, data OccurrenceUpdate) (*WorkoutSchedule, error)
+}
+
+type ScheduleService struct {
+	WSRepo       repository.ScheduleRepository
+	WPRepo       repository.WorkoutRepository
+	EPRepo       repository.ExercisePlanRepository
+	ExerciseRepo repository.ExerciseRepository
+	UoW          repository.UnitOfWork
+}
+
+func NewScheduleService(sr repository.ScheduleRepository, wr repository.WorkoutRepository, er repository.ExercisePlanRepository, exr repository.ExerciseRepository, uow repository.UnitOfWork) ScheduleServiceInterface {
+	return &ScheduleService{
+		WSRepo:       sr,
+		WPRepo:       wr,
+		EPRepo:       er,
+		ExerciseRepo: exr,
+		UoW:          uow,
+	}
+}
+
+func (ss *ScheduleService) PreviewSchedule(ctx context.Context, rule RecurrenceRule) ([]time.Time, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate: %w", err)
+	}
+
+	return rule.Occurrences()
+}
+
+func (ss *ScheduleService) CreateSchedule(ctx context.Context, data WorkoutScheduleCreate) (*WorkoutSchedule, error) {
+	if err := data.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate: %w", err)
+	}
+
+	occurrences, err := data.Recurrence.Occurrences()
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand recurrence: %w", err)
+	}
+
+	if err := checkExerciseAccess(ctx, ss.ExerciseRepo, data.UserId, data.ExercisePlans); err != nil {
+		return nil, err
+	}
+
+	// the schedule and all of its workout plans are created together, a failure leaves nothing behind
+	var result *WorkoutSchedule
+	err = ss.UoW.WithinTransaction(ctx, func(txCtx context.Context) error {
+		schedule, err := ss.WSRepo.CreateSchedule(txCtx, toRepoCreateWS(data.UserId, data.Recurrence))
+		if err != nil {
+			return fmt.Errorf("failed to create workout schedule: %w", err)
+		}
+
+		var workouts []WorkoutPlan
+		for _, date := range occurrences {
+			workout, err := ss.WPRepo.CreateWorkout(txCtx, repository.CreateWP{
+				UserId:        data.UserId,
+				ScheduledDate: date,
+				Comment:       nil,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create workout plan of schedule id '%v': %w", schedule.Id, err)
+			}
+
+			if err := ss.WSRepo.AttachWorkout(txCtx, schedule.Id, workout.Id); err != nil {
+				return fmt.Errorf("failed to attach workout plan to schedule id '%v': %w", schedule.Id, err)
+			}
+
+			exercisePlans, err := ss.createExercisePlans(txCtx, workout.Id, data.ExercisePlans)
+			if err != nil {
+				return err
+			}
+
+			workouts = append(workouts, *toServiceWP(workout, exercisePlans))
+		}
+
+		result = toServiceWS(schedule)
+		result.Workouts = workouts
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (ss *ScheduleService) GetScheduleById(ctx context.Context, id int) (*WorkoutSchedule, error) {
+	schedule, err := ss.WSRepo.GetScheduleById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workout schedule: %w", err)
+	}
+
+	wpList, err := ss.WSRepo.ListScheduleWorkouts(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workout plans of schedule: %w", err)
+	}
+
+	result := toServiceWS(schedule)
+	for _, wp := range wpList {
+		epList, err := ss.EPRepo.ListExercisePlans(ctx, wp.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get exercise plans: %w", err)
+		}
+
+		result.Workouts = append(result.Workouts, *toServiceWP(&wp, epList))
+	}
+
+	return result, nil
+}
+
+func (ss *ScheduleService) ListSchedules(ctx context.Context, userId int) ([]WorkoutSchedule, error) {
+	wsList, err := ss.WSRepo.ListUserSchedules(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workout schedules: %w", err)
+	}
+
+	var result []WorkoutSchedule
+	for _, ws := range wsList {
+		result = append(result, *toServiceWS(&ws))
+	}
+
+	return result, nil
+}
+
+func (ss *ScheduleService) CancelSchedule(ctx context.Context, id int, from *time.Time) (*WorkoutSchedule, error) {
+	cancelFrom := time.Now()
+	if from != nil {
+		cancelFrom = *from
+	}
+
+	if err := ss.WSRepo.CancelSchedule(ctx, id, cancelFrom); err != nil {
+		return nil, fmt.Errorf("failed to cancel workout schedule id '%v': %w", id, err)
+	}
+
+	return ss.GetScheduleById(ctx, id)
+}
+
+func (ss *ScheduleService) UpdateOccurrence(ctx context.Context, scheduleId int, workoutId int, data OccurrenceUpdate) (*WorkoutSchedule, error) {
+	if err := data.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate: %w", err)
+	}
+
+	schedule, err := ss.WSRepo.GetScheduleById(ctx, scheduleId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workout schedule id '%v': %w", scheduleId, err)
+	}
+
+	if schedule.CancelledAt.Valid {
+		return nil, apperrors.NewValidationError(apperrors.INVALID_INPUT, "workout schedule is cancelled")
+	}
+
+	wpList, err := ss.WSRepo.ListScheduleWorkouts(ctx, scheduleId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workout plans of schedule id '%v': %w", scheduleId, err)
+	}
+
+	index := slices.IndexFunc(wpList, func(wp repository.WorkoutPlan) bool { return wp.Id == workoutId })
+	if index < 0 {
+		return nil, fmt.Errorf("workout plan id '%v' is not in schedule id '%v': %w", workoutId, scheduleId, apperrors.ErrNotFound)
+	}
+
+	target := wpList[index]
+	if target.Status != repository.PENDING {
+		return nil, apperrors.NewValidationError(apperrors.INVALID_INPUT, "only pending occurrences can be edited")
+	}
+
+	if err := checkExerciseAccess(ctx, ss.ExerciseRepo, schedule.UserId, data.ExercisePlans); err != nil {
+		return nil, err
+	}
+
+	var shift *occurrenceShift
+	if data.ScheduledDate != nil && !data.ScheduledDate.Equal(target.ScheduledDate) {
+		shift = newOccurrenceShift(target.ScheduledDate, *data.ScheduledDate, schedule.StartsAt.Location())
+	}
+
+	// the edited occurrences change together, a failure leaves the series as it was
+	seriesId := scheduleId
+	err = ss.UoW.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if data.Scope == THIS_OCCURRENCE {
+			return ss.updateWorkout(txCtx, target, shift, data.ExercisePlans)
+		}
+
+		// "this and following": the edited occurrences become a series of their own, starting at the
+		// edited occurrence, unless the edit starts from the very first occurrence
+		rule := toServiceRule(schedule)
+		rule.StartDate = shift.apply(target.ScheduledDate)
+		if rule.Until != nil {
+			until := shift.apply(*rule.Until)
+			rule.Until = &until
+		}
+
+		if index > 0 {
+			if rule.Count != nil {
+				remaining := len(wpList) - index
+				rule.Count = &remaining
+			}
+
+			newSchedule, err := ss.WSRepo.SplitSchedule(txCtx, scheduleId, target.ScheduledDate, toRepoCreateWS(schedule.UserId, rule))
+			if err != nil {
+				return fmt.Errorf("failed to split workout schedule id '%v': %w", scheduleId, err)
+			}
+			seriesId = newSchedule.Id
+		} else if shift != nil {
+			_, err := ss.WSRepo.UpdateSchedule(txCtx, repository.UpdateWS{
+				Id:       scheduleId,
+				StartsAt: &rule.StartDate,
+				Until:    rule.Until,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update workout schedule id '%v': %w", scheduleId, err)
+			}
+		}
+
+		for _, wp := range wpList[index:] {
+			if wp.Status != repository.PENDING {
+				continue
+			}
+
+			if err := ss.updateWorkout(txCtx, wp, shift, data.ExercisePlans); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ss.GetScheduleById(ctx, seriesId)
+}
+
+// occurrenceShift moves occurrences by whole days and onto the time of day of the edited occurrence,
+// on the wall clock of the schedule's location. Adding the plain duration between the old and the
+// new date would move the following occurrences an hour off across a DST change.
+type occurrenceShift struct {
+	days int
+	to   time.Time
+}
+
+func newOccurrenceShift(from time.Time, to time.Time, loc *time.Location) *occurrenceShift {
+	from, to = from.In(loc), to.In(loc)
+	fromDay := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	return &occurrenceShift{
+		days: int(toDay.Sub(fromDay) / (24 * time.Hour)),
+		to:   to,
+	}
+}
+
+// apply returns the date unchanged on a nil shift
+func (shift *occurrenceShift) apply(date time.Time) time.Time {
+	if shift == nil {
+		return date
+	}
+
+	day := date.In(shift.to.Location()).AddDate(0, 0, shift.days)
+	hour, minute, second := shift.to.Clock()
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, shift.to.Nanosecond(), shift.to.Location())
+}
+
+// updateWorkout moves the workout plan by the given shift and replaces its exercise plans when new ones are given
+func (ss *ScheduleService) updateWorkout(ctx context.Context, wp repository.WorkoutPlan, shift *occurrenceShift, epsCreate []ExercisePlanCreate) error {
+	if shift != nil {
+		scheduledDate := shift.apply(wp.ScheduledDate)
+		_, err := ss.WPRepo.UpdateWorkout(ctx, repository.UpdateWP{
+			Id:            wp.Id,
+			Status:        repository.PENDING,
+			ScheduledDate: &scheduledDate,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to reschedule workout plan id '%v': %w", wp.Id, err)
+		}
+	}
+
+	if epsCreate == nil {
+		return nil
+	}
+
+	epList, err := ss.EPRepo.ListExercisePlans(ctx, wp.Id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch exercise plans of workout plan id '%v': %w", wp.Id, err)
+	}
+
+	for _, ep := range epList {
+		if err := ss.EPRepo.DeleteExercisePlanByID(ctx, ep.Id); err != nil {
+			return fmt.Errorf("failed to delete exercise plan id '%v': %w", ep.Id, err)
+		}
+	}
+
+	_, err = ss.createExercisePlans(ctx, wp.Id, epsCreate)
+	return err
+}
+
+func (ss *ScheduleService) createExercisePlans(ctx context.Context, workoutId int, epsCreate []ExercisePlanCreate) ([]repository.ExercisePlan, error) {
+	var exercisePlans []repository.ExercisePlan
+	for _, ep := range epsCreate {
+		exercisePlan, err := ss.EPRepo.CreateExercisePlan(ctx, repository.CreateEP{
+			ExerciseId:  ep.ExerciseId,
+			Sets:        ep.Sets,
+			Repetitions: ep.Repetitions,
+			Weights:     ep.Weights,
+			WeightUnit:  repository.WeightUnit(ep.WeightUnit),
+		}, workoutId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create exercise plan: %w", err)
+		}
+
+		exercisePlans = append(exercisePlans, *exercisePlan)
+	}
+
+	return exercisePlans, nil
+}
+
+func toRepoCreateWS(userId int, rule RecurrenceRule) repository.CreateWS {
+	var weekdays *string
+	if len(rule.Weekdays) > 0 {
+		days := make([]string, len(rule.Weekdays))
+		for i, day := range rule.Weekdays {
+			days[i] = string(day)
+		}
+		joined := strings.Join(days, ",")
+		weekdays = &joined
+	}
+
+	return repository.CreateWS{
+		UserId:    userId,
+		Frequency: repository.Frequency(rule.Frequency),
+		Interval:  rule.Interval,
+		Weekdays:  weekdays,
+		StartsAt:  rule.StartDate,
+		Until:     rule.Until,
+		Count:     rule.Count,
+	}
+}
+
+func toServiceRule(ws *repository.WorkoutSchedule) RecurrenceRule {
+	rule := RecurrenceRule{
+		Frequency: Frequency(ws.Frequency),
+		Interval:  ws.Interval,
+		StartDate: ws.StartsAt,
+	}
+
+	if ws.Weekdays.Valid && ws.Weekdays.String != "" {
+		for _, day := range strings.Split(ws.Weekdays.String, ",") {
+			rule.Weekdays = append(rule.Weekdays, Weekday(day))
+		}
+	}
+
+	if ws.Until.Valid {
+		until := ws.Until.Time
+		rule.Until = &until
+	}
+
+	if ws.Count.Valid {
+		count := int(ws.Count.Int64)
+		rule.Count = &count
+	}
+
+	return rule
+}
+
+func toServiceWS(ws *repository.WorkoutSchedule) *WorkoutSchedule {
+	if ws == nil {
+		return nil
+	}
+
+	var cancelledAt *time.Time
+	if ws.CancelledAt.Valid {
+		cancelledAt = &ws.CancelledAt.Time
+	}
+
+	return &WorkoutSchedule{
+		Id:          ws.Id,
+		UserId:      ws.UserId,
+		Recurrence:  toServiceRule(ws),
+		CancelledAt: cancelledAt,
+		CreatedAt:   ws.CreatedAt,
+		UpdatedAt:   ws.UpdatedAt,
+	}
+}
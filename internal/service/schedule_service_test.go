@@ -0,0 +1,501 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+	"workout-tracker-api/internal/service"
+)
+
+// MockScheduleRepository is a mock implementation of repository.ScheduleRepository
+type MockScheduleRepository struct {
+	mock.Mock
+}
+
+func (m *MockScheduleRepository) CreateSchedule(ctx context.Context, data repository.CreateWS) (*repository.WorkoutSchedule, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.WorkoutSchedule), args.Error(1)
+}
+func (m *MockScheduleRepository) GetScheduleById(ctx context.Context, id int) (*repository.WorkoutSchedule, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.WorkoutSchedule), args.Error(1)
+}
+func (m *MockScheduleRepository) UpdateSchedule(ctx context.Context, data repository.UpdateWS) (*repository.WorkoutSchedule, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.WorkoutSchedule), args.Error(1)
+}
+func (m *MockScheduleRepository) ListUserSchedules(ctx context.Context, userID int) ([]repository.WorkoutSchedule, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.WorkoutSchedule), args.Error(1)
+}
+func (m *MockScheduleRepository) AttachWorkout(ctx context.Context, scheduleID int, workoutID int) error {
+	args := m.Called(ctx, scheduleID, workoutID)
+	return args.Error(0)
+}
+func (m *MockScheduleRepository) ListScheduleWorkouts(ctx context.Context, scheduleID int) ([]repository.WorkoutPlan, error) {
+	args := m.Called(ctx, scheduleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.WorkoutPlan), args.Error(1)
+}
+func (m *MockScheduleRepository) CancelSchedule(ctx context.Context, id int, from time.Time) error {
+	args := m.Called(ctx, id, from)
+	return args.Error(0)
+}
+func (m *MockScheduleRepository) SplitSchedule(ctx context.Context, id int, from time.Time, data repository.CreateWS) (*repository.WorkoutSchedule, error) {
+	args := m.Called(ctx, id, from, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.WorkoutSchedule), args.Error(1)
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 7, 0, 0, 0, time.UTC)
+}
+
+func TestRecurrenceRule_Occurrences(t *testing.T) {
+	count := func(n int) *int { return &n }
+
+	t.Run("Weekly on given weekdays", func(t *testing.T) {
+		// 2025-06-04 is a Wednesday
+		rule := service.RecurrenceRule{
+			Frequency: service.WEEKLY,
+			Interval:  1,
+			Weekdays:  []service.Weekday{service.FR, service.MO, service.WE},
+			StartDate: date(2025, 6, 4),
+			Count:     count(5),
+		}
+
+		occurrences, err := rule.Occurrences()
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			date(2025, 6, 4), date(2025, 6, 6), date(2025, 6, 9), date(2025, 6, 11), date(2025, 6, 13),
+		}, occurrences)
+		assert.Equal(t, "FREQ=WEEKLY;INTERVAL=1;BYDAY=FR,MO,WE;COUNT=5", rule.String())
+	})
+
+	t.Run("Every other week on the start weekday", func(t *testing.T) {
+		rule := service.RecurrenceRule{
+			Frequency: service.WEEKLY,
+			Interval:  2,
+			StartDate: date(2025, 6, 4),
+			Count:     count(3),
+		}
+
+		occurrences, err := rule.Occurrences()
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{date(2025, 6, 4), date(2025, 6, 18), date(2025, 7, 2)}, occurrences)
+	})
+
+	t.Run("Monthly skips months without the start day", func(t *testing.T) {
+		rule := service.RecurrenceRule{
+			Frequency: service.MONTHLY,
+			Interval:  1,
+			StartDate: date(2025, 1, 31),
+			Count:     count(3),
+		}
+
+		occurrences, err := rule.Occurrences()
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{date(2025, 1, 31), date(2025, 3, 31), date(2025, 5, 31)}, occurrences)
+	})
+
+	t.Run("Daily until a date", func(t *testing.T) {
+		until := date(2025, 6, 10)
+		rule := service.RecurrenceRule{
+			Frequency: service.DAILY,
+			Interval:  2,
+			StartDate: date(2025, 6, 1),
+			Until:     &until,
+		}
+
+		occurrences, err := rule.Occurrences()
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			date(2025, 6, 1), date(2025, 6, 3), date(2025, 6, 5), date(2025, 6, 7), date(2025, 6, 9),
+		}, occurrences)
+	})
+
+	t.Run("Too many occurrences", func(t *testing.T) {
+		until := date(2027, 1, 1)
+		rule := service.RecurrenceRule{
+			Frequency: service.DAILY,
+			Interval:  1,
+			StartDate: date(2025, 1, 1),
+			Until:     &until,
+		}
+
+		occurrences, err := rule.Occurrences()
+		assert.Nil(t, occurrences)
+		var validationErr *apperrors.ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+	})
+}
+
+func TestValidate_RecurrenceRule(t *testing.T) {
+	count := 3
+	before := date(2025, 5, 1)
+
+	tests := []struct {
+		name  string
+		rule  service.RecurrenceRule
+		field apperrors.ValidationField
+	}{
+		{"Invalid frequency", service.RecurrenceRule{Frequency: "yearly", Interval: 1, StartDate: date(2025, 6, 1), Count: &count}, apperrors.INVALID_SETTING},
+		{"Invalid interval", service.RecurrenceRule{Frequency: service.DAILY, Interval: 0, StartDate: date(2025, 6, 1), Count: &count}, apperrors.INVALID_SETTING},
+		{"Weekdays on daily", service.RecurrenceRule{Frequency: service.DAILY, Interval: 1, Weekdays: []service.Weekday{service.MO}, StartDate: date(2025, 6, 1), Count: &count}, apperrors.INVALID_SETTING},
+		{"Invalid weekday", service.RecurrenceRule{Frequency: service.WEEKLY, Interval: 1, Weekdays: []service.Weekday{"XX"}, StartDate: date(2025, 6, 1), Count: &count}, apperrors.INVALID_SETTING},
+		{"Missing start date", service.RecurrenceRule{Frequency: service.DAILY, Interval: 1, Count: &count}, apperrors.INVALID_DATE},
+		{"Unbounded", service.RecurrenceRule{Frequency: service.DAILY, Interval: 1, StartDate: date(2025, 6, 1)}, apperrors.INVALID_SETTING},
+		{"Until before start", service.RecurrenceRule{Frequency: service.DAILY, Interval: 1, StartDate: date(2025, 6, 1), Until: &before}, apperrors.INVALID_DATE},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			var validationErr *apperrors.ValidationError
+			assert.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
+
+func TestScheduleService_CreateSchedule(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	count := 2
+
+	t.Run("Successful creation", func(t *testing.T) {
+		mockWSRepo := new(MockScheduleRepository)
+		mockWPRepo := new(MockWorkoutRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		wsService := service.NewScheduleService(mockWSRepo, mockWPRepo, mockEPRepo, globalExercises(), new(MockUnitOfWork))
+
+		input := service.WorkoutScheduleCreate{
+			UserId: 1,
+			Recurrence: service.RecurrenceRule{
+				Frequency: service.DAILY,
+				Interval:  1,
+				StartDate: date(2025, 6, 1),
+				Count:     &count,
+			},
+			ExercisePlans: []service.ExercisePlanCreate{
+				{ExerciseId: 10, Sets: 3, Repetitions: 10, Weights: 50, WeightUnit: service.KG},
+			},
+		}
+
+		mockWSRepo.On("CreateSchedule", ctx, repository.CreateWS{
+			UserId: 1, Frequency: repository.DAILY, Interval: 1, StartsAt: date(2025, 6, 1), Count: &count,
+		}).Return(&repository.WorkoutSchedule{
+			Id: 1, UserId: 1, Frequency: repository.DAILY, Interval: 1, StartsAt: date(2025, 6, 1),
+			Count: sql.NullInt64{Int64: 2, Valid: true}, CreatedAt: now, UpdatedAt: now,
+		}, nil).Once()
+
+		for i, day := range []int{1, 2} {
+			wpId := 10 + i
+			mockWPRepo.On("CreateWorkout", ctx, repository.CreateWP{UserId: 1, ScheduledDate: date(2025, 6, day)}).
+				Return(&repository.WorkoutPlan{Id: wpId, UserId: 1, Status: repository.PENDING, ScheduledDate: date(2025, 6, day), CreatedAt: now, UpdatedAt: now}, nil).Once()
+			mockWSRepo.On("AttachWorkout", ctx, 1, wpId).Return(nil).Once()
+			mockEPRepo.On("CreateExercisePlan", ctx, repository.CreateEP{
+				ExerciseId: 10, Sets: 3, Repetitions: 10, Weights: 50, WeightUnit: repository.KG,
+			}, wpId).Return(&repository.ExercisePlan{
+				Id: 100 + i, ExerciseId: 10, WorkoutPlanId: wpId, Sets: 3, Repetitions: 10, Weights: 50, WeightUnit: repository.KG,
+			}, nil).Once()
+		}
+
+		ws, err := wsService.CreateSchedule(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, ws.Id)
+		assert.Equal(t, "FREQ=DAILY;INTERVAL=1;COUNT=2", ws.Recurrence.String())
+		assert.Len(t, ws.Workouts, 2)
+		assert.Equal(t, date(2025, 6, 2), ws.Workouts[1].ScheduledDate)
+		assert.Len(t, ws.Workouts[1].ExercisePlans, 1)
+		mockWSRepo.AssertExpectations(t)
+		mockWPRepo.AssertExpectations(t)
+		mockEPRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unbounded series", func(t *testing.T) {
+		mockWSRepo := new(MockScheduleRepository)
+		mockWPRepo := new(MockWorkoutRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		wsService := service.NewScheduleService(mockWSRepo, mockWPRepo, mockEPRepo, globalExercises(), new(MockUnitOfWork))
+
+		input := service.WorkoutScheduleCreate{
+			UserId: 1,
+			Recurrence: service.RecurrenceRule{
+				Frequency: service.DAILY,
+				Interval:  1,
+				StartDate: date(2025, 6, 1),
+			},
+		}
+
+		ws, err := wsService.CreateSchedule(ctx, input)
+		assert.Nil(t, ws)
+		var validationErr *apperrors.ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		mockWSRepo.AssertNotCalled(t, "CreateSchedule")
+		mockWPRepo.AssertNotCalled(t, "CreateWorkout")
+	})
+
+	t.Run("Custom exercise of another user", func(t *testing.T) {
+		mockWSRepo := new(MockScheduleRepository)
+		mockWPRepo := new(MockWorkoutRepository)
+		mockExerRepo := new(MockExerciseRepository)
+		wsService := service.NewScheduleService(mockWSRepo, mockWPRepo, new(MockExercisePlanRepository), mockExerRepo, new(MockUnitOfWork))
+
+		mockExerRepo.On("GetExerciseById", ctx, 10).Return(&repository.Exercise{Id: 10, OwnerId: sql.NullInt64{Int64: 2, Valid: true}}, nil).Once()
+
+		ws, err := wsService.CreateSchedule(ctx, service.WorkoutScheduleCreate{
+			UserId:     1,
+			Recurrence: service.RecurrenceRule{Frequency: service.DAILY, Interval: 1, StartDate: date(2025, 6, 1), Count: &count},
+			ExercisePlans: []service.ExercisePlanCreate{
+				{ExerciseId: 10, Sets: 3, Repetitions: 10, Weights: 50, WeightUnit: service.KG},
+			},
+		})
+		assert.Nil(t, ws)
+		assert.True(t, errors.Is(err, apperrors.ErrForbidden))
+		mockWSRepo.AssertNotCalled(t, "CreateSchedule", mock.Anything, mock.Anything)
+		mockWPRepo.AssertNotCalled(t, "CreateWorkout", mock.Anything, mock.Anything)
+	})
+	t.Run("Rolls back when a workout plan fails", func(t *testing.T) {
+		mockWSRepo := new(MockScheduleRepository)
+		mockWPRepo := new(MockWorkoutRepository)
+		uow := new(MockUnitOfWork)
+		wsService := service.NewScheduleService(mockWSRepo, mockWPRepo, new(MockExercisePlanRepository), globalExercises(), uow)
+
+		mockWSRepo.On("CreateSchedule", ctx, mock.AnythingOfType("repository.CreateWS")).
+			Return(&repository.WorkoutSchedule{Id: 1, UserId: 1, Frequency: repository.DAILY, Interval: 1, StartsAt: date(2025, 6, 1)}, nil).Once()
+		mockWPRepo.On("CreateWorkout", ctx, repository.CreateWP{UserId: 1, ScheduledDate: date(2025, 6, 1)}).
+			Return(&repository.WorkoutPlan{Id: 10, UserId: 1, ScheduledDate: date(2025, 6, 1)}, nil).Once()
+		mockWSRepo.On("AttachWorkout", ctx, 1, 10).Return(nil).Once()
+		mockWPRepo.On("CreateWorkout", ctx, repository.CreateWP{UserId: 1, ScheduledDate: date(2025, 6, 2)}).
+			Return(nil, errors.New("db error")).Once()
+
+		ws, err := wsService.CreateSchedule(ctx, service.WorkoutScheduleCreate{
+			UserId:     1,
+			Recurrence: service.RecurrenceRule{Frequency: service.DAILY, Interval: 1, StartDate: date(2025, 6, 1), Count: &count},
+		})
+		assert.Nil(t, ws)
+		assert.Error(t, err)
+		assert.Equal(t, 1, uow.Calls)
+		assert.True(t, uow.RolledBack)
+		mockWSRepo.AssertExpectations(t)
+		mockWPRepo.AssertExpectations(t)
+	})
+}
+
+func TestScheduleService_UpdateOccurrence(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	schedule := &repository.WorkoutSchedule{
+		Id: 1, UserId: 1, Frequency: repository.DAILY, Interval: 1, StartsAt: date(2025, 6, 1),
+		Count: sql.NullInt64{Int64: 3, Valid: true}, CreatedAt: now, UpdatedAt: now,
+	}
+	workouts := []repository.WorkoutPlan{
+		{Id: 10, UserId: 1, Status: repository.COMPLETED, ScheduledDate: date(2025, 6, 1)},
+		{Id: 11, UserId: 1, Status: repository.PENDING, ScheduledDate: date(2025, 6, 2)},
+		{Id: 12, UserId: 1, Status: repository.PENDING, ScheduledDate: date(2025, 6, 3)},
+	}
+
+	t.Run("This occurrence with new exercise plans", func(t *testing.T) {
+		mockWSRepo := new(MockScheduleRepository)
+		mockWPRepo := new(MockWorkoutRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		wsService := service.NewScheduleService(mockWSRepo, mockWPRepo, mockEPRepo, globalExercises(), new(MockUnitOfWork))
+
+		input := service.OccurrenceUpdate{
+			Scope: service.THIS_OCCURRENCE,
+			ExercisePlans: []service.ExercisePlanCreate{
+				{ExerciseId: 20, Sets: 5, Repetitions: 5, Weights: 80, WeightUnit: service.KG},
+			},
+		}
+
+		mockWSRepo.On("GetScheduleById", ctx, 1).Return(schedule, nil)
+		mockWSRepo.On("ListScheduleWorkouts", ctx, 1).Return(workouts, nil)
+		mockEPRepo.On("ListExercisePlans", ctx, 11).Return([]repository.ExercisePlan{{Id: 100, WorkoutPlanId: 11}}, nil).Once()
+		mockEPRepo.On("DeleteExercisePlanByID", ctx, 100).Return(nil).Once()
+		mockEPRepo.On("CreateExercisePlan", ctx, repository.CreateEP{
+			ExerciseId: 20, Sets: 5, Repetitions: 5, Weights: 80, WeightUnit: repository.KG,
+		}, 11).Return(&repository.ExercisePlan{Id: 101, WorkoutPlanId: 11}, nil).Once()
+		mockEPRepo.On("ListExercisePlans", ctx, mock.AnythingOfType("int")).Return([]repository.ExercisePlan{}, nil)
+
+		ws, err := wsService.UpdateOccurrence(ctx, 1, 11, input)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, ws.Id)
+		mockWPRepo.AssertNotCalled(t, "UpdateWorkout")
+		mockWSRepo.AssertNotCalled(t, "SplitSchedule")
+		mockEPRepo.AssertExpectations(t)
+	})
+
+	t.Run("Following occurrences split the series", func(t *testing.T) {
+		mockWSRepo := new(MockScheduleRepository)
+		mockWPRepo := new(MockWorkoutRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		wsService := service.NewScheduleService(mockWSRepo, mockWPRepo, mockEPRepo, globalExercises(), new(MockUnitOfWork))
+
+		newDate := date(2025, 6, 2).Add(time.Hour)
+		input := service.OccurrenceUpdate{Scope: service.FOLLOWING_OCCURRENCES, ScheduledDate: &newDate}
+		remaining := 2
+
+		mockWSRepo.On("GetScheduleById", ctx, 1).Return(schedule, nil).Once()
+		mockWSRepo.On("ListScheduleWorkouts", ctx, 1).Return(workouts, nil).Once()
+		mockWSRepo.On("SplitSchedule", ctx, 1, date(2025, 6, 2), repository.CreateWS{
+			UserId: 1, Frequency: repository.DAILY, Interval: 1, StartsAt: newDate, Count: &remaining,
+		}).Return(&repository.WorkoutSchedule{
+			Id: 2, UserId: 1, Frequency: repository.DAILY, Interval: 1, StartsAt: newDate,
+			Count: sql.NullInt64{Int64: 2, Valid: true}, CreatedAt: now, UpdatedAt: now,
+		}, nil).Once()
+
+		for _, wp := range workouts[1:] {
+			shifted := wp.ScheduledDate.Add(time.Hour)
+			mockWPRepo.On("UpdateWorkout", ctx, repository.UpdateWP{Id: wp.Id, Status: repository.PENDING, ScheduledDate: &shifted}).
+				Return(&repository.WorkoutPlan{Id: wp.Id, UserId: 1, Status: repository.PENDING, ScheduledDate: shifted}, nil).Once()
+		}
+
+		mockWSRepo.On("GetScheduleById", ctx, 2).Return(&repository.WorkoutSchedule{
+			Id: 2, UserId: 1, Frequency: repository.DAILY, Interval: 1, StartsAt: newDate,
+			Count: sql.NullInt64{Int64: 2, Valid: true}, CreatedAt: now, UpdatedAt: now,
+		}, nil).Once()
+		mockWSRepo.On("ListScheduleWorkouts", ctx, 2).Return([]repository.WorkoutPlan{}, nil).Once()
+
+		ws, err := wsService.UpdateOccurrence(ctx, 1, 11, input)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, ws.Id)
+		assert.Equal(t, newDate, ws.Recurrence.StartDate)
+		mockWSRepo.AssertExpectations(t)
+		mockWPRepo.AssertExpectations(t)
+		mockEPRepo.AssertNotCalled(t, "DeleteExercisePlanByID")
+	})
+
+	t.Run("Following occurrences roll back together", func(t *testing.T) {
+		mockWSRepo := new(MockScheduleRepository)
+		mockWPRepo := new(MockWorkoutRepository)
+		uow := new(MockUnitOfWork)
+		wsService := service.NewScheduleService(mockWSRepo, mockWPRepo, new(MockExercisePlanRepository), globalExercises(), uow)
+
+		newDate := date(2025, 6, 2).Add(time.Hour)
+		mockWSRepo.On("GetScheduleById", ctx, 1).Return(schedule, nil).Once()
+		mockWSRepo.On("ListScheduleWorkouts", ctx, 1).Return(workouts, nil).Once()
+		mockWSRepo.On("SplitSchedule", ctx, 1, date(2025, 6, 2), mock.AnythingOfType("repository.CreateWS")).
+			Return(&repository.WorkoutSchedule{Id: 2, UserId: 1, Frequency: repository.DAILY, Interval: 1, StartsAt: newDate}, nil).Once()
+		mockWPRepo.On("UpdateWorkout", ctx, mock.MatchedBy(func(wp repository.UpdateWP) bool { return wp.Id == 11 })).
+			Return(&repository.WorkoutPlan{Id: 11}, nil).Once()
+		mockWPRepo.On("UpdateWorkout", ctx, mock.MatchedBy(func(wp repository.UpdateWP) bool { return wp.Id == 12 })).
+			Return(nil, errors.New("db error")).Once()
+
+		ws, err := wsService.UpdateOccurrence(ctx, 1, 11, service.OccurrenceUpdate{Scope: service.FOLLOWING_OCCURRENCES, ScheduledDate: &newDate})
+		assert.Nil(t, ws)
+		assert.Error(t, err)
+		assert.Equal(t, 1, uow.Calls)
+		assert.True(t, uow.RolledBack)
+		mockWPRepo.AssertExpectations(t)
+		// the new series is never read back
+		mockWSRepo.AssertNotCalled(t, "GetScheduleById", ctx, 2)
+	})
+
+	t.Run("Following occurrences keep their wall clock time across DST", func(t *testing.T) {
+		mockWSRepo := new(MockScheduleRepository)
+		mockWPRepo := new(MockWorkoutRepository)
+		wsService := service.NewScheduleService(mockWSRepo, mockWPRepo, new(MockExercisePlanRepository), globalExercises(), new(MockUnitOfWork))
+
+		// New York moves to daylight saving time on 2025-03-09, moving 03-08 07:00 to 03-09 07:00 is 23 hours
+		newYork, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			t.Fatalf("failed to load location: %v", err)
+		}
+		at := func(day int, hour int) time.Time { return time.Date(2025, 3, day, hour, 0, 0, 0, newYork) }
+		dstSchedule := &repository.WorkoutSchedule{
+			Id: 1, UserId: 1, Frequency: repository.DAILY, Interval: 1, StartsAt: at(7, 7),
+			Until: sql.NullTime{Time: at(9, 7), Valid: true}, CreatedAt: now, UpdatedAt: now,
+		}
+		dstWorkouts := []repository.WorkoutPlan{
+			{Id: 10, UserId: 1, Status: repository.COMPLETED, ScheduledDate: at(7, 7)},
+			{Id: 11, UserId: 1, Status: repository.PENDING, ScheduledDate: at(8, 7)},
+			{Id: 12, UserId: 1, Status: repository.PENDING, ScheduledDate: at(9, 7)},
+		}
+		newDate := at(9, 7).UTC()
+
+		mockWSRepo.On("GetScheduleById", ctx, 1).Return(dstSchedule, nil).Once()
+		mockWSRepo.On("ListScheduleWorkouts", ctx, 1).Return(dstWorkouts, nil).Once()
+		mockWSRepo.On("SplitSchedule", ctx, 1, at(8, 7), mock.MatchedBy(func(ws repository.CreateWS) bool {
+			return ws.StartsAt.Equal(at(9, 7)) && ws.Until != nil && ws.Until.Equal(at(10, 7))
+		})).Return(&repository.WorkoutSchedule{Id: 2, UserId: 1, Frequency: repository.DAILY, Interval: 1, StartsAt: at(9, 7)}, nil).Once()
+		for id, expected := range map[int]time.Time{11: at(9, 7), 12: at(10, 7)} {
+			mockWPRepo.On("UpdateWorkout", ctx, mock.MatchedBy(func(wp repository.UpdateWP) bool {
+				return wp.Id == id && wp.ScheduledDate.Equal(expected)
+			})).Return(&repository.WorkoutPlan{Id: id}, nil).Once()
+		}
+		mockWSRepo.On("GetScheduleById", ctx, 2).Return(&repository.WorkoutSchedule{Id: 2, UserId: 1, StartsAt: at(9, 7)}, nil).Once()
+		mockWSRepo.On("ListScheduleWorkouts", ctx, 2).Return([]repository.WorkoutPlan{}, nil).Once()
+
+		_, err = wsService.UpdateOccurrence(ctx, 1, 11, service.OccurrenceUpdate{Scope: service.FOLLOWING_OCCURRENCES, ScheduledDate: &newDate})
+		assert.NoError(t, err)
+		mockWSRepo.AssertExpectations(t)
+		mockWPRepo.AssertExpectations(t)
+	})
+
+	t.Run("Completed occurrence", func(t *testing.T) {
+		mockWSRepo := new(MockScheduleRepository)
+		mockWPRepo := new(MockWorkoutRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		wsService := service.NewScheduleService(mockWSRepo, mockWPRepo, mockEPRepo, globalExercises(), new(MockUnitOfWork))
+
+		mockWSRepo.On("GetScheduleById", ctx, 1).Return(schedule, nil).Once()
+		mockWSRepo.On("ListScheduleWorkouts", ctx, 1).Return(workouts, nil).Once()
+
+		ws, err := wsService.UpdateOccurrence(ctx, 1, 10, service.OccurrenceUpdate{Scope: service.THIS_OCCURRENCE})
+		assert.Nil(t, ws)
+		var validationErr *apperrors.ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		mockWPRepo.AssertNotCalled(t, "UpdateWorkout")
+	})
+
+	t.Run("Occurrence not in schedule", func(t *testing.T) {
+		mockWSRepo := new(MockScheduleRepository)
+		mockWPRepo := new(MockWorkoutRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		wsService := service.NewScheduleService(mockWSRepo, mockWPRepo, mockEPRepo, globalExercises(), new(MockUnitOfWork))
+
+		mockWSRepo.On("GetScheduleById", ctx, 1).Return(schedule, nil).Once()
+		mockWSRepo.On("ListScheduleWorkouts", ctx, 1).Return(workouts, nil).Once()
+
+		ws, err := wsService.UpdateOccurrence(ctx, 1, 99, service.OccurrenceUpdate{Scope: service.THIS_OCCURRENCE})
+		assert.Nil(t, ws)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+	})
+
+	t.Run("Invalid scope", func(t *testing.T) {
+		mockWSRepo := new(MockScheduleRepository)
+		wsService := service.NewScheduleService(mockWSRepo, new(MockWorkoutRepository), new(MockExercisePlanRepository), globalExercises(), new(MockUnitOfWork))
+
+		ws, err := wsService.UpdateOccurrence(ctx, 1, 11, service.OccurrenceUpdate{Scope: "all"})
+		assert.Nil(t, ws)
+		var validationErr *apperrors.ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		mockWSRepo.AssertNotCalled(t, "GetScheduleById")
+	})
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// MaxUserAgentLength caps the user agent stored with a session
+const MaxUserAgentLength = 512
+
+type Session struct {
+	Id         string    `json:"id"`
+	UserAgent  string    `json:"userAgent"`
+	IPAddress  string    `json:"ipAddress"`
+	LastJTI    string    `json:"lastJti"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+}
+
+// SessionStart describes a login, JTI is the id of the first access token of the session
+type SessionStart struct {
+	UserId    int    `json:"userId"`
+	UserAgent string `json:"userAgent"`
+	IPAddress string `json:"ipAddress"`
+	JTI       string `json:"jti"`
+}
+
+type SessionServiceInterface interface {
+	StartSession(ctx context.Context, input SessionStart) (*Session, error)
+	TouchSession(ctx context.Context, sessionId string, jti string) error
+	ListSessions(ctx context.Context, userId int) ([]Session, error)
+	RevokeSession(ctx context.Context, userId int, sessionId string) error
+	RevokeAllSessions(ctx context.Context, userId int) ([]string, error)
+}
+
+type SessionService struct {
+	SessionRepo repository.SessionRepository
+	RTRepo      repository.RefreshTokenRepository
+	UoW         repository.UnitOfWork
+}
+
+func NewSessionService(sr repository.SessionRepository, rr repository.RefreshTokenRepository, uow repository.UnitOfWork) SessionServiceInterface {
+	return &SessionService{
+		SessionRepo: sr,
+		RTRepo:      rr,
+		UoW:         uow,
+	}
+}
+
+func (s *SessionService) StartSession(ctx context.Context, input SessionStart) (*Session, error) {
+	created, err := s.SessionRepo.CreateSession(ctx, repository.CreateSession{
+		Id:        uuid.New().String(),
+		UserId:    input.UserId,
+		UserAgent: truncateUserAgent(input.UserAgent),
+		IPAddress: input.IPAddress,
+		JTI:       input.JTI,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return toServiceSession(created), nil
+}
+
+// truncateUserAgent cuts the user agent to MaxUserAgentLength bytes, backing off to the start of
+// the rune that would be split so the stored text stays valid UTF-8
+func truncateUserAgent(userAgent string) string {
+	if len(userAgent) <= MaxUserAgentLength {
+		return userAgent
+	}
+
+	cut := MaxUserAgentLength
+	for cut > 0 && !utf8.RuneStart(userAgent[cut]) {
+		cut--
+	}
+	return userAgent[:cut]
+}
+
+// TouchSession records the access token issued on a refresh, which also moves last seen
+func (s *SessionService) TouchSession(ctx context.Context, sessionId string, jti string) error {
+	if err := s.SessionRepo.TouchSession(ctx, sessionId, jti); err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SessionService) ListSessions(ctx context.Context, userId int) ([]Session, error) {
+	sessions, err := s.SessionRepo.ListActiveSessions(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sessions: %w", err)
+	}
+
+	var result []Session
+	for _, session := range sessions {
+		result = append(result, *toServiceSession(&session))
+	}
+
+	return result, nil
+}
+
+// RevokeSession ends one session of the user together with its refresh tokens
+func (s *SessionService) RevokeSession(ctx context.Context, userId int, sessionId string) error {
+	err := s.UoW.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.SessionRepo.RevokeSession(txCtx, userId, sessionId); err != nil {
+			return err
+		}
+		return s.RTRepo.RevokeTokenFamily(txCtx, sessionId)
+	})
+
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return apperrors.ErrNotFound
+		}
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAllSessions logs the user out everywhere and returns the ids of the sessions it ended
+func (s *SessionService) RevokeAllSessions(ctx context.Context, userId int) ([]string, error) {
+	var revoked []string
+
+	err := s.UoW.WithinTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		revoked, err = s.SessionRepo.RevokeUserSessions(txCtx, userId)
+		if err != nil {
+			return err
+		}
+		return s.RTRepo.RevokeUserTokens(txCtx, userId)
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return revoked, nil
+}
+
+func toServiceSession(rs *repository.Session) *Session {
+	if rs == nil {
+		return nil
+	}
+
+	return &Session{
+		Id:         rs.Id,
+		UserAgent:  rs.UserAgent,
+		IPAddress:  rs.IPAddress,
+		LastJTI:    rs.LastJTI.String,
+		CreatedAt:  rs.CreatedAt,
+		LastSeenAt: rs.LastSeenAt,
+	}
+}
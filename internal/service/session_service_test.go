@@ -0,0 +1,226 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+	"workout-tracker-api/internal/service"
+)
+
+// MockSessionRepository is a mock implementation of repository.SessionRepository
+type MockSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSessionRepository) CreateSession(ctx context.Context, data repository.CreateSession) (*repository.Session, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) ListActiveSessions(ctx context.Context, userId int) ([]repository.Session, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) TouchSession(ctx context.Context, id string, jti string) error {
+	args := m.Called(ctx, id, jti)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) RevokeSession(ctx context.Context, userId int, id string) error {
+	args := m.Called(ctx, userId, id)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) RevokeUserSessions(ctx context.Context, userId int) ([]string, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func TestSessionService_StartSession(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success truncates the user agent", func(t *testing.T) {
+		mockSessionRepo := new(MockSessionRepository)
+		sessionService := service.NewSessionService(mockSessionRepo, nil, new(MockUnitOfWork))
+		longAgent := strings.Repeat("a", service.MaxUserAgentLength+10)
+		now := time.Now()
+
+		var saved repository.CreateSession
+		mockSessionRepo.On("CreateSession", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(repository.CreateSession) }).
+			Return(&repository.Session{
+				Id:         "session-1",
+				UserId:     5,
+				IPAddress:  "203.0.113.7",
+				LastJTI:    sql.NullString{String: "jti-1", Valid: true},
+				CreatedAt:  now,
+				LastSeenAt: now,
+			}, nil).Once()
+
+		session, err := sessionService.StartSession(ctx, service.SessionStart{
+			UserId:    5,
+			UserAgent: longAgent,
+			IPAddress: "203.0.113.7",
+			JTI:       "jti-1",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "session-1", session.Id)
+		assert.Equal(t, "jti-1", session.LastJTI)
+		assert.NotEmpty(t, saved.Id)
+		assert.Len(t, saved.UserAgent, service.MaxUserAgentLength)
+		assert.Equal(t, "jti-1", saved.JTI)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Non-ASCII user agent is cut on a rune boundary", func(t *testing.T) {
+		mockSessionRepo := new(MockSessionRepository)
+		sessionService := service.NewSessionService(mockSessionRepo, nil, new(MockUnitOfWork))
+		// "é" takes two bytes, the byte limit falls in the middle of the last one that fits
+		longAgent := "a" + strings.Repeat("é", service.MaxUserAgentLength)
+
+		var saved repository.CreateSession
+		mockSessionRepo.On("CreateSession", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(repository.CreateSession) }).
+			Return(&repository.Session{Id: "session-1", UserId: 5}, nil).Once()
+
+		_, err := sessionService.StartSession(ctx, service.SessionStart{UserId: 5, UserAgent: longAgent})
+		assert.NoError(t, err)
+		assert.True(t, utf8.ValidString(saved.UserAgent))
+		assert.Len(t, saved.UserAgent, service.MaxUserAgentLength-1)
+		assert.Equal(t, "a"+strings.Repeat("é", (service.MaxUserAgentLength-2)/2), saved.UserAgent)
+	})
+
+	t.Run("DB error", func(t *testing.T) {
+		mockSessionRepo := new(MockSessionRepository)
+		sessionService := service.NewSessionService(mockSessionRepo, nil, new(MockUnitOfWork))
+		dbError := errors.New("insert failed")
+
+		mockSessionRepo.On("CreateSession", ctx, mock.Anything).Return(nil, dbError).Once()
+
+		session, err := sessionService.StartSession(ctx, service.SessionStart{UserId: 5})
+		assert.ErrorIs(t, err, dbError)
+		assert.Nil(t, session)
+	})
+}
+
+func TestSessionService_ListSessions(t *testing.T) {
+	ctx := context.Background()
+	mockSessionRepo := new(MockSessionRepository)
+	sessionService := service.NewSessionService(mockSessionRepo, nil, new(MockUnitOfWork))
+
+	mockSessionRepo.On("ListActiveSessions", ctx, 5).Return([]repository.Session{
+		{Id: "session-1", UserId: 5, UserAgent: "curl/8.0", LastJTI: sql.NullString{String: "jti-2", Valid: true}},
+		{Id: "session-2", UserId: 5, UserAgent: "Safari"},
+	}, nil).Once()
+
+	sessions, err := sessionService.ListSessions(ctx, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []service.Session{
+		{Id: "session-1", UserAgent: "curl/8.0", LastJTI: "jti-2"},
+		{Id: "session-2", UserAgent: "Safari"},
+	}, sessions)
+	mockSessionRepo.AssertExpectations(t)
+}
+
+func TestSessionService_RevokeSession(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success revokes the refresh tokens too", func(t *testing.T) {
+		mockSessionRepo := new(MockSessionRepository)
+		mockRTRepo := new(MockRefreshTokenRepository)
+		uow := new(MockUnitOfWork)
+		sessionService := service.NewSessionService(mockSessionRepo, mockRTRepo, uow)
+
+		mockSessionRepo.On("RevokeSession", ctx, 5, "session-1").Return(nil).Once()
+		mockRTRepo.On("RevokeTokenFamily", ctx, "session-1").Return(nil).Once()
+
+		err := sessionService.RevokeSession(ctx, 5, "session-1")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, uow.Calls)
+		mockSessionRepo.AssertExpectations(t)
+		mockRTRepo.AssertExpectations(t)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		mockSessionRepo := new(MockSessionRepository)
+		mockRTRepo := new(MockRefreshTokenRepository)
+		uow := new(MockUnitOfWork)
+		sessionService := service.NewSessionService(mockSessionRepo, mockRTRepo, uow)
+
+		mockSessionRepo.On("RevokeSession", ctx, 6, "session-1").Return(apperrors.ErrNotFound).Once()
+
+		err := sessionService.RevokeSession(ctx, 6, "session-1")
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.True(t, uow.RolledBack)
+		mockRTRepo.AssertNotCalled(t, "RevokeTokenFamily", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Refresh token error rolls back", func(t *testing.T) {
+		mockSessionRepo := new(MockSessionRepository)
+		mockRTRepo := new(MockRefreshTokenRepository)
+		uow := new(MockUnitOfWork)
+		sessionService := service.NewSessionService(mockSessionRepo, mockRTRepo, uow)
+		dbError := errors.New("update failed")
+
+		mockSessionRepo.On("RevokeSession", ctx, 5, "session-1").Return(nil).Once()
+		mockRTRepo.On("RevokeTokenFamily", ctx, "session-1").Return(dbError).Once()
+
+		err := sessionService.RevokeSession(ctx, 5, "session-1")
+		assert.ErrorIs(t, err, dbError)
+		assert.True(t, uow.RolledBack)
+	})
+}
+
+func TestSessionService_RevokeAllSessions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		mockSessionRepo := new(MockSessionRepository)
+		mockRTRepo := new(MockRefreshTokenRepository)
+		sessionService := service.NewSessionService(mockSessionRepo, mockRTRepo, new(MockUnitOfWork))
+
+		mockSessionRepo.On("RevokeUserSessions", ctx, 5).Return([]string{"session-1", "session-2"}, nil).Once()
+		mockRTRepo.On("RevokeUserTokens", ctx, 5).Return(nil).Once()
+
+		revoked, err := sessionService.RevokeAllSessions(ctx, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"session-1", "session-2"}, revoked)
+		mockSessionRepo.AssertExpectations(t)
+		mockRTRepo.AssertExpectations(t)
+	})
+
+	t.Run("DB error", func(t *testing.T) {
+		mockSessionRepo := new(MockSessionRepository)
+		mockRTRepo := new(MockRefreshTokenRepository)
+		uow := new(MockUnitOfWork)
+		sessionService := service.NewSessionService(mockSessionRepo, mockRTRepo, uow)
+		dbError := errors.New("update failed")
+
+		mockSessionRepo.On("RevokeUserSessions", ctx, 5).Return(nil, dbError).Once()
+
+		revoked, err := sessionService.RevokeAllSessions(ctx, 5)
+		assert.ErrorIs(t, err, dbError)
+		assert.Nil(t, revoked)
+		assert.True(t, uow.RolledBack)
+	})
+}
@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+)
+
+// WorkoutTemplate is a named list of exercise plans, kept in order, that workout plans can be created from
+type WorkoutTemplate struct {
+	Id            int                  `json:"id"`
+	UserId        int                  `json:"userId"`
+	Name          string               `json:"name"`
+	Description   *string              `json:"description,omitempty"`
+	CreatedAt     time.Time            `json:"createdAt"`
+	UpdatedAt     time.Time            `json:"updatedAt"`
+	ExercisePlans []ExercisePlanCreate `json:"exercisePlans"`
+}
+
+type WorkoutTemplateCreate struct {
+	UserId        int                  `json:"userId"`
+	Name          string               `json:"name"`
+	Description   *string              `json:"description,omitempty"`
+	ExercisePlans []ExercisePlanCreate `json:"exercisePlans"`
+}
+
+func (data *WorkoutTemplateCreate) Validate() error {
+	if data.UserId <= 0 {
+		return apperrors.NewValidationError(apperrors.INVALID_ID, "not a valid user id")
+	}
+
+	if len(data.Name) < 1 || len(data.Name) > 100 {
+		return apperrors.NewValidationError(apperrors.INVALID_NAME, "Set the name length between 1 and 100")
+	}
+
+	return validateTemplateExercises(data.ExercisePlans)
+}
+
+// WorkoutTemplateUpdate replaces the exercise plans of the template only when ExercisePlans is not nil
+type WorkoutTemplateUpdate struct {
+	Id            int                  `json:"id"`
+	Name          *string              `json:"name,omitempty"`
+	Description   *string              `json:"description,omitempty"`
+	ExercisePlans []ExercisePlanCreate `json:"exercisePlans,omitempty"`
+}
+
+func (data *WorkoutTemplateUpdate) Validate() error {
+	if data.Name != nil && (len(*data.Name) < 1 || len(*data.Name) > 100) {
+		return apperrors.NewValidationError(apperrors.INVALID_NAME, "Set the name length between 1 and 100")
+	}
+
+	if data.ExercisePlans == nil {
+		return nil
+	}
+
+	return validateTemplateExercises(data.ExercisePlans)
+}
+
+func validateTemplateExercises(epsCreate []ExercisePlanCreate) error {
+	if len(epsCreate) == 0 {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, "a template needs at least one exercise plan")
+	}
+
+	for _, ep := range epsCreate {
+		if err := ep.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type TemplateServiceInterface interface {
+	CreateTemplate(ctx context.Context, data WorkoutTemplateCreate) (*WorkoutTemplate, error)
+	GetTemplateById(ctx context.Context, id int) (*WorkoutTemplate, error)
+	ListTemplates(ctx context.Context, userId int) ([]WorkoutTemplate, error)
+	UpdateTemplate(ctx context.Context, data WorkoutTemplateUpdate) (*WorkoutTemplate, error)
+	DeleteTemplateById(ctx context.Context, id int) error
+	InstantiateTemplate(ctx context.Context, id int, scheduledDate *time.Time) (*WorkoutPlan, error)
+	SaveWorkoutAsTemplate(ctx context.Context, workoutId int, name string, description *string) (*WorkoutTemplate, error)
+}
+
+type TemplateService struct {
+	WTRepo         repository.TemplateRepository
+	ExerciseRepo   repository.ExerciseRepository
+	WorkoutService WorkoutServiceInterface
+}
+
+func NewTemplateService(tr repository.TemplateRepository, exr repository.ExerciseRepository, ws WorkoutServiceInterface) TemplateServiceInterface {
+	return &TemplateService{
+		WTRepo:         tr,
+		ExerciseRepo:   exr,
+		WorkoutService: ws,
+	}
+}
+
+func (ts *TemplateService) CreateTemplate(ctx context.Context, data WorkoutTemplateCreate) (*WorkoutTemplate, error) {
+	if err := data.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate: %w", err)
+	}
+
+	if err := checkExerciseAccess(ctx, ts.ExerciseRepo, data.UserId, data.ExercisePlans); err != nil {
+		return nil, err
+	}
+
+	template, err := ts.WTRepo.CreateTemplate(ctx, repository.CreateWT{
+		UserId:      data.UserId,
+		Name:        data.Name,
+		Description: data.Description,
+		Exercises:   toRepoTemplateExercises(data.ExercisePlans),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workout template: %w", err)
+	}
+
+	return toServiceWT(template, data.ExercisePlans), nil
+}
+
+func (ts *TemplateService) GetTemplateById(ctx context.Context, id int) (*WorkoutTemplate, error) {
+	template, err := ts.WTRepo.GetTemplateById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workout template: %w", err)
+	}
+
+	exList, err := ts.WTRepo.ListTemplateExercises(ctx, template.Id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exercises of workout template: %w", err)
+	}
+
+	return toServiceWT(template, toServiceTemplateExercises(exList)), nil
+}
+
+func (ts *TemplateService) ListTemplates(ctx context.Context, userId int) ([]WorkoutTemplate, error) {
+	wtList, err := ts.WTRepo.ListUserTemplates(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workout templates: %w", err)
+	}
+
+	var result []WorkoutTemplate
+	for _, wt := range wtList {
+		exList, err := ts.WTRepo.ListTemplateExercises(ctx, wt.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch exercises of workout template: %w", err)
+		}
+
+		result = append(result, *toServiceWT(&wt, toServiceTemplateExercises(exList)))
+	}
+
+	return result, nil
+}
+
+func (ts *TemplateService) UpdateTemplate(ctx context.Context, data WorkoutTemplateUpdate) (*WorkoutTemplate, error) {
+	if err := data.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate: %w", err)
+	}
+
+	update := repository.UpdateWT{
+		Id:          data.Id,
+		Name:        data.Name,
+		Description: data.Description,
+	}
+	if data.ExercisePlans != nil {
+		template, err := ts.WTRepo.GetTemplateById(ctx, data.Id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch workout template id '%v': %w", data.Id, err)
+		}
+
+		if err := checkExerciseAccess(ctx, ts.ExerciseRepo, template.UserId, data.ExercisePlans); err != nil {
+			return nil, err
+		}
+
+		update.Exercises = toRepoTemplateExercises(data.ExercisePlans)
+	}
+
+	if _, err := ts.WTRepo.UpdateTemplate(ctx, update); err != nil {
+		return nil, fmt.Errorf("failed to update workout template id '%v': %w", data.Id, err)
+	}
+
+	return ts.GetTemplateById(ctx, data.Id)
+}
+
+func (ts *TemplateService) DeleteTemplateById(ctx context.Context, id int) error {
+	if err := ts.WTRepo.DeleteTemplateById(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete workout template: %w", err)
+	}
+
+	return nil
+}
+
+// InstantiateTemplate creates a workout plan of the template's owner holding the template's exercise plans
+func (ts *TemplateService) InstantiateTemplate(ctx context.Context, id int, scheduledDate *time.Time) (*WorkoutPlan, error) {
+	template, err := ts.GetTemplateById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ts.WorkoutService.CreateWorkout(ctx, WorkoutPlanCreate{
+		UserId:        template.UserId,
+		ScheduledDate: scheduledDate,
+		ExercisePlans: template.ExercisePlans,
+	})
+}
+
+// SaveWorkoutAsTemplate snapshots the exercise plans of an existing workout plan into a new template
+func (ts *TemplateService) SaveWorkoutAsTemplate(ctx context.Context, workoutId int, name string, description *string) (*WorkoutTemplate, error) {
+	workout, err := ts.WorkoutService.GetWorkoutById(ctx, workoutId)
+	if err != nil {
+		return nil, err
+	}
+
+	var epsCreate []ExercisePlanCreate
+	for _, ep := range workout.ExercisePlans {
+		epsCreate = append(epsCreate, ExercisePlanCreate{
+			ExerciseId:  ep.ExerciseId,
+			Sets:        ep.Sets,
+			Repetitions: ep.Repetitions,
+			Weights:     ep.Weights,
+			WeightUnit:  ep.WeightUnit,
+		})
+	}
+
+	return ts.CreateTemplate(ctx, WorkoutTemplateCreate{
+		UserId:        workout.UserId,
+		Name:          name,
+		Description:   description,
+		ExercisePlans: epsCreate,
+	})
+}
+
+func toRepoTemplateExercises(epsCreate []ExercisePlanCreate) []repository.CreateEP {
+	exercises := make([]repository.CreateEP, 0, len(epsCreate))
+	for _, ep := range epsCreate {
+		exercises = append(exercises, repository.CreateEP{
+			ExerciseId:  ep.ExerciseId,
+			Sets:        ep.Sets,
+			Repetitions: ep.Repetitions,
+			Weights:     ep.Weights,
+			WeightUnit:  repository.WeightUnit(ep.WeightUnit),
+		})
+	}
+
+	return exercises
+}
+
+func toServiceTemplateExercises(exList []repository.TemplateExercise) []ExercisePlanCreate {
+	var epsCreate []ExercisePlanCreate
+	for _, ex := range exList {
+		epsCreate = append(epsCreate, ExercisePlanCreate{
+			ExerciseId:  ex.ExerciseId,
+			Sets:        ex.Sets,
+			Repetitions: ex.Repetitions,
+			Weights:     ex.Weights,
+			WeightUnit:  WeightUnit(ex.WeightUnit),
+		})
+	}
+
+	return epsCreate
+}
+
+func toServiceWT(wt *repository.WorkoutTemplate, epsCreate []ExercisePlanCreate) *WorkoutTemplate {
+	if wt == nil {
+		return nil
+	}
+
+	var description *string
+	if wt.Description.Valid {
+		description = &wt.Description.String
+	}
+
+	return &WorkoutTemplate{
+		Id:            wt.Id,
+		UserId:        wt.UserId,
+		Name:          wt.Name,
+		Description:   description,
+		CreatedAt:     wt.CreatedAt,
+		UpdatedAt:     wt.UpdatedAt,
+		ExercisePlans: epsCreate,
+	}
+}
@@ -0,0 +1,365 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+	"workout-tracker-api/internal/service"
+)
+
+// MockTemplateRepository is a mock implementation of repository.TemplateRepository
+type MockTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *MockTemplateRepository) CreateTemplate(ctx context.Context, data repository.CreateWT) (*repository.WorkoutTemplate, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.WorkoutTemplate), args.Error(1)
+}
+func (m *MockTemplateRepository) GetTemplateById(ctx context.Context, id int) (*repository.WorkoutTemplate, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.WorkoutTemplate), args.Error(1)
+}
+func (m *MockTemplateRepository) UpdateTemplate(ctx context.Context, data repository.UpdateWT) (*repository.WorkoutTemplate, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.WorkoutTemplate), args.Error(1)
+}
+func (m *MockTemplateRepository) DeleteTemplateById(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+func (m *MockTemplateRepository) ListUserTemplates(ctx context.Context, userID int) ([]repository.WorkoutTemplate, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.WorkoutTemplate), args.Error(1)
+}
+func (m *MockTemplateRepository) ListTemplateExercises(ctx context.Context, templateID int) ([]repository.TemplateExercise, error) {
+	args := m.Called(ctx, templateID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.TemplateExercise), args.Error(1)
+}
+
+// MockWorkoutService is a mock implementation of service.WorkoutServiceInterface
+type MockWorkoutService struct {
+	mock.Mock
+}
+
+func (m *MockWorkoutService) CreateWorkout(ctx context.Context, data service.WorkoutPlanCreate) (*service.WorkoutPlan, error) {
+	args := m.Called(ctx, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutPlan), args.Error(1)
+}
+func (m *MockWorkoutService) DeleteWorkoutById(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+func (m *MockWorkoutService) GetWorkoutById(ctx context.Context, id int) (*service.WorkoutPlan, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutPlan), args.Error(1)
+}
+func (m *MockWorkoutService) ListWorkouts(ctx context.Context, userId int) ([]service.WorkoutPlan, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.WorkoutPlan), args.Error(1)
+}
+func (m *MockWorkoutService) ListWorkoutsByStatus(ctx context.Context, userId int, status service.WPStatus, asc bool) ([]service.WorkoutPlan, error) {
+	args := m.Called(ctx, userId, status, asc)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.WorkoutPlan), args.Error(1)
+}
+func (m *MockWorkoutService) CompleteWorkout(ctx context.Context, id int, comment *string) error {
+	args := m.Called(ctx, id, comment)
+	return args.Error(0)
+}
+func (m *MockWorkoutService) ScheduleWorkout(ctx context.Context, id int, scheduledDate *time.Time) (*service.WorkoutPlan, error) {
+	args := m.Called(ctx, id, scheduledDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutPlan), args.Error(1)
+}
+func (m *MockWorkoutService) UpdateExercisePlans(ctx context.Context, workoutId int, epsUpdate []service.ExercisePlanUpdate) (*service.WorkoutPlan, error) {
+	args := m.Called(ctx, workoutId, epsUpdate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutPlan), args.Error(1)
+}
+
+func (m *MockWorkoutService) AddExercisePlan(ctx context.Context, workoutId int, data service.ExercisePlanCreate, position int) (*service.WorkoutPlan, error) {
+	args := m.Called(ctx, workoutId, data, position)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutPlan), args.Error(1)
+}
+
+func (m *MockWorkoutService) RemoveExercisePlan(ctx context.Context, workoutId int, exercisePlanId int) (*service.WorkoutPlan, error) {
+	args := m.Called(ctx, workoutId, exercisePlanId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutPlan), args.Error(1)
+}
+
+func (m *MockWorkoutService) MoveExercisePlan(ctx context.Context, workoutId int, exercisePlanId int, position int) (*service.WorkoutPlan, error) {
+	args := m.Called(ctx, workoutId, exercisePlanId, position)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.WorkoutPlan), args.Error(1)
+}
+
+func TestTemplateService_CreateTemplate(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	epsCreate := []service.ExercisePlanCreate{
+		{ExerciseId: 3, Sets: 5, Repetitions: 5, Weights: 100, WeightUnit: service.KG},
+		{ExerciseId: 7, Sets: 3, Repetitions: 12, Weights: 40, WeightUnit: service.KG},
+	}
+
+	t.Run("Successful creation", func(t *testing.T) {
+		mockWTRepo := new(MockTemplateRepository)
+		mockWorkoutService := new(MockWorkoutService)
+		wtService := service.NewTemplateService(mockWTRepo, globalExercises(), mockWorkoutService)
+
+		mockWTRepo.On("CreateTemplate", ctx, repository.CreateWT{
+			UserId: 1,
+			Name:   "Leg day",
+			Exercises: []repository.CreateEP{
+				{ExerciseId: 3, Sets: 5, Repetitions: 5, Weights: 100, WeightUnit: repository.KG},
+				{ExerciseId: 7, Sets: 3, Repetitions: 12, Weights: 40, WeightUnit: repository.KG},
+			},
+		}).Return(&repository.WorkoutTemplate{Id: 1, UserId: 1, Name: "Leg day", CreatedAt: now, UpdatedAt: now}, nil).Once()
+
+		wt, err := wtService.CreateTemplate(ctx, service.WorkoutTemplateCreate{UserId: 1, Name: "Leg day", ExercisePlans: epsCreate})
+		assert.NoError(t, err)
+		assert.Equal(t, &service.WorkoutTemplate{
+			Id: 1, UserId: 1, Name: "Leg day", CreatedAt: now, UpdatedAt: now, ExercisePlans: epsCreate,
+		}, wt)
+		mockWTRepo.AssertExpectations(t)
+	})
+
+	t.Run("Validation errors", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			input service.WorkoutTemplateCreate
+			field apperrors.ValidationField
+		}{
+			{"Empty name", service.WorkoutTemplateCreate{UserId: 1, Name: "", ExercisePlans: epsCreate}, apperrors.INVALID_NAME},
+			{"No exercise plans", service.WorkoutTemplateCreate{UserId: 1, Name: "Leg day"}, apperrors.INVALID_SETTING},
+			{"Invalid exercise plan", service.WorkoutTemplateCreate{UserId: 1, Name: "Leg day", ExercisePlans: []service.ExercisePlanCreate{
+				{ExerciseId: 3, Sets: 0, Repetitions: 5, Weights: 100, WeightUnit: service.KG},
+			}}, apperrors.INVALID_SETTING},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockWTRepo := new(MockTemplateRepository)
+				wtService := service.NewTemplateService(mockWTRepo, globalExercises(), new(MockWorkoutService))
+
+				wt, err := wtService.CreateTemplate(ctx, tt.input)
+				assert.Nil(t, wt)
+				var validationErr *apperrors.ValidationError
+				assert.True(t, errors.As(err, &validationErr))
+				assert.Equal(t, tt.field, validationErr.Field)
+				mockWTRepo.AssertNotCalled(t, "CreateTemplate")
+			})
+		}
+	})
+
+	t.Run("Custom exercise of another user", func(t *testing.T) {
+		mockWTRepo := new(MockTemplateRepository)
+		mockExerRepo := new(MockExerciseRepository)
+		wtService := service.NewTemplateService(mockWTRepo, mockExerRepo, new(MockWorkoutService))
+
+		mockExerRepo.On("GetExerciseById", ctx, 3).Return(&repository.Exercise{Id: 3}, nil).Once()
+		mockExerRepo.On("GetExerciseById", ctx, 7).Return(&repository.Exercise{Id: 7, OwnerId: sql.NullInt64{Int64: 2, Valid: true}}, nil).Once()
+
+		wt, err := wtService.CreateTemplate(ctx, service.WorkoutTemplateCreate{UserId: 1, Name: "Leg day", ExercisePlans: epsCreate})
+		assert.Nil(t, wt)
+		assert.True(t, errors.Is(err, apperrors.ErrForbidden))
+		mockWTRepo.AssertNotCalled(t, "CreateTemplate", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTemplateService_UpdateTemplate(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	name := "Leg day B"
+
+	t.Run("Rename keeps exercise plans", func(t *testing.T) {
+		mockWTRepo := new(MockTemplateRepository)
+		wtService := service.NewTemplateService(mockWTRepo, globalExercises(), new(MockWorkoutService))
+
+		mockWTRepo.On("UpdateTemplate", ctx, repository.UpdateWT{Id: 1, Name: &name}).
+			Return(&repository.WorkoutTemplate{Id: 1, UserId: 1, Name: name, CreatedAt: now, UpdatedAt: now}, nil).Once()
+		mockWTRepo.On("GetTemplateById", ctx, 1).
+			Return(&repository.WorkoutTemplate{Id: 1, UserId: 1, Name: name, CreatedAt: now, UpdatedAt: now}, nil).Once()
+		mockWTRepo.On("ListTemplateExercises", ctx, 1).Return([]repository.TemplateExercise{
+			{Id: 1, TemplateId: 1, Position: 1, ExerciseId: 3, Sets: 5, Repetitions: 5, Weights: 100, WeightUnit: repository.KG},
+		}, nil).Once()
+
+		wt, err := wtService.UpdateTemplate(ctx, service.WorkoutTemplateUpdate{Id: 1, Name: &name})
+		assert.NoError(t, err)
+		assert.Equal(t, name, wt.Name)
+		assert.Len(t, wt.ExercisePlans, 1)
+		mockWTRepo.AssertExpectations(t)
+	})
+
+	t.Run("Empty exercise plans", func(t *testing.T) {
+		mockWTRepo := new(MockTemplateRepository)
+		wtService := service.NewTemplateService(mockWTRepo, globalExercises(), new(MockWorkoutService))
+
+		wt, err := wtService.UpdateTemplate(ctx, service.WorkoutTemplateUpdate{Id: 1, ExercisePlans: []service.ExercisePlanCreate{}})
+		assert.Nil(t, wt)
+		var validationErr *apperrors.ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		mockWTRepo.AssertNotCalled(t, "UpdateTemplate")
+	})
+
+	t.Run("Archived exercise", func(t *testing.T) {
+		mockWTRepo := new(MockTemplateRepository)
+		mockExerRepo := new(MockExerciseRepository)
+		wtService := service.NewTemplateService(mockWTRepo, mockExerRepo, new(MockWorkoutService))
+
+		mockWTRepo.On("GetTemplateById", ctx, 1).Return(&repository.WorkoutTemplate{Id: 1, UserId: 1, Name: name}, nil).Once()
+		mockExerRepo.On("GetExerciseById", ctx, 3).Return(&repository.Exercise{Id: 3, OwnerId: sql.NullInt64{Int64: 1, Valid: true}, ArchivedAt: sql.NullTime{Time: now, Valid: true}}, nil).Once()
+
+		wt, err := wtService.UpdateTemplate(ctx, service.WorkoutTemplateUpdate{Id: 1, ExercisePlans: []service.ExercisePlanCreate{
+			{ExerciseId: 3, Sets: 5, Repetitions: 5, Weights: 100, WeightUnit: service.KG},
+		}})
+		assert.Nil(t, wt)
+		var validationErr *apperrors.ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		mockWTRepo.AssertNotCalled(t, "UpdateTemplate", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTemplateService_InstantiateTemplate(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	scheduledDate := time.Date(2025, 6, 2, 7, 0, 0, 0, time.UTC)
+
+	t.Run("Successful instantiate", func(t *testing.T) {
+		mockWTRepo := new(MockTemplateRepository)
+		mockWorkoutService := new(MockWorkoutService)
+		wtService := service.NewTemplateService(mockWTRepo, globalExercises(), mockWorkoutService)
+
+		mockWTRepo.On("GetTemplateById", ctx, 1).
+			Return(&repository.WorkoutTemplate{Id: 1, UserId: 1, Name: "Leg day", CreatedAt: now, UpdatedAt: now}, nil).Once()
+		mockWTRepo.On("ListTemplateExercises", ctx, 1).Return([]repository.TemplateExercise{
+			{Id: 1, TemplateId: 1, Position: 1, ExerciseId: 3, Sets: 5, Repetitions: 5, Weights: 100, WeightUnit: repository.KG},
+			{Id: 2, TemplateId: 1, Position: 2, ExerciseId: 7, Sets: 3, Repetitions: 12, Weights: 40, WeightUnit: repository.KG},
+		}, nil).Once()
+
+		expectedWP := &service.WorkoutPlan{Id: 10, UserId: 1, Status: service.PENDING, ScheduledDate: scheduledDate}
+		mockWorkoutService.On("CreateWorkout", ctx, service.WorkoutPlanCreate{
+			UserId:        1,
+			ScheduledDate: &scheduledDate,
+			ExercisePlans: []service.ExercisePlanCreate{
+				{ExerciseId: 3, Sets: 5, Repetitions: 5, Weights: 100, WeightUnit: service.KG},
+				{ExerciseId: 7, Sets: 3, Repetitions: 12, Weights: 40, WeightUnit: service.KG},
+			},
+		}).Return(expectedWP, nil).Once()
+
+		wp, err := wtService.InstantiateTemplate(ctx, 1, &scheduledDate)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedWP, wp)
+		mockWTRepo.AssertExpectations(t)
+		mockWorkoutService.AssertExpectations(t)
+	})
+
+	t.Run("Template not found", func(t *testing.T) {
+		mockWTRepo := new(MockTemplateRepository)
+		mockWorkoutService := new(MockWorkoutService)
+		wtService := service.NewTemplateService(mockWTRepo, globalExercises(), mockWorkoutService)
+
+		mockWTRepo.On("GetTemplateById", ctx, 99).Return(nil, apperrors.ErrNotFound).Once()
+
+		wp, err := wtService.InstantiateTemplate(ctx, 99, &scheduledDate)
+		assert.Nil(t, wp)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+		mockWorkoutService.AssertNotCalled(t, "CreateWorkout")
+	})
+}
+
+func TestTemplateService_SaveWorkoutAsTemplate(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	description := "copied from last monday"
+
+	t.Run("Successful save", func(t *testing.T) {
+		mockWTRepo := new(MockTemplateRepository)
+		mockWorkoutService := new(MockWorkoutService)
+		wtService := service.NewTemplateService(mockWTRepo, globalExercises(), mockWorkoutService)
+
+		mockWorkoutService.On("GetWorkoutById", ctx, 10).Return(&service.WorkoutPlan{
+			Id: 10, UserId: 1, Status: service.COMPLETED,
+			ExercisePlans: []service.ExercisePlan{
+				{Id: 100, ExerciseId: 3, WorkoutPlanId: 10, Sets: 5, Repetitions: 5, Weights: 100, WeightUnit: service.KG},
+			},
+		}, nil).Once()
+		mockWTRepo.On("CreateTemplate", ctx, repository.CreateWT{
+			UserId:      1,
+			Name:        "Monday",
+			Description: &description,
+			Exercises:   []repository.CreateEP{{ExerciseId: 3, Sets: 5, Repetitions: 5, Weights: 100, WeightUnit: repository.KG}},
+		}).Return(&repository.WorkoutTemplate{
+			Id: 2, UserId: 1, Name: "Monday", Description: sql.NullString{String: description, Valid: true}, CreatedAt: now, UpdatedAt: now,
+		}, nil).Once()
+
+		wt, err := wtService.SaveWorkoutAsTemplate(ctx, 10, "Monday", &description)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, wt.Id)
+		assert.Equal(t, &description, wt.Description)
+		assert.Equal(t, []service.ExercisePlanCreate{{ExerciseId: 3, Sets: 5, Repetitions: 5, Weights: 100, WeightUnit: service.KG}}, wt.ExercisePlans)
+		mockWorkoutService.AssertExpectations(t)
+		mockWTRepo.AssertExpectations(t)
+	})
+
+	t.Run("Workout without exercise plans", func(t *testing.T) {
+		mockWTRepo := new(MockTemplateRepository)
+		mockWorkoutService := new(MockWorkoutService)
+		wtService := service.NewTemplateService(mockWTRepo, globalExercises(), mockWorkoutService)
+
+		mockWorkoutService.On("GetWorkoutById", ctx, 11).Return(&service.WorkoutPlan{Id: 11, UserId: 1}, nil).Once()
+
+		wt, err := wtService.SaveWorkoutAsTemplate(ctx, 11, "Empty", nil)
+		assert.Nil(t, wt)
+		var validationErr *apperrors.ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		mockWTRepo.AssertNotCalled(t, "CreateTemplate")
+	})
+}
@@ -0,0 +1,390 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/cache"
+	"workout-tracker-api/internal/repository"
+	"workout-tracker-api/internal/util/auth"
+	"workout-tracker-api/internal/util/encrypt"
+)
+
+const (
+	twoFactorChallengePrefix = "2fachallenge:"
+	twoFactorAttemptPrefix   = "2faattempts:"
+	// totpSkew accepts the codes of one step before and after the current one for clock drift
+	totpSkew           = 1
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TwoFactorConfig sets the issuer shown in authenticator apps and how long a login challenge
+// lasts. A challenge is dropped after MaxAttempts wrong codes and the login starts over, the wrong
+// codes also count towards the lockout of the login guard.
+type TwoFactorConfig struct {
+	Issuer       string
+	ChallengeTTL time.Duration
+	MaxAttempts  int
+}
+
+// TOTPEnrollment is a secret waiting for confirmation, URI is the otpauth form for QR codes
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+type TwoFactorServiceInterface interface {
+	Enroll(ctx context.Context, userId int) (*TOTPEnrollment, error)
+	Confirm(ctx context.Context, userId int, code string) ([]string, error)
+	Disable(ctx context.Context, userId int, code string) error
+	VerifyCode(ctx context.Context, userId int, code string) error
+	IsEnabled(ctx context.Context, userId int) (bool, error)
+	CreateChallenge(ctx context.Context, userId int) (string, error)
+	CompleteChallenge(ctx context.Context, token string, code string, ipAddress string) (*User, error)
+}
+
+type TwoFactorService struct {
+	TwoFactorRepo repository.TwoFactorRepository
+	UserRepo      repository.UserRepository
+	UoW           repository.UnitOfWork
+	Hash          encrypt.HashHelperInterface
+	Cache         cache.CacheInterface
+	LoginGuard    LoginGuardInterface
+	Config        TwoFactorConfig
+}
+
+func NewTwoFactorService(tr repository.TwoFactorRepository, ur repository.UserRepository, uow repository.UnitOfWork, h encrypt.HashHelperInterface, c cache.CacheInterface, lg LoginGuardInterface, cfg TwoFactorConfig) TwoFactorServiceInterface {
+	return &TwoFactorService{
+		TwoFactorRepo: tr,
+		UserRepo:      ur,
+		UoW:           uow,
+		Hash:          h,
+		Cache:         c,
+		LoginGuard:    lg,
+		Config:        cfg,
+	}
+}
+
+// Enroll generates a new secret for the user. It is not used for logins until Confirm proves the
+// user's app produces the same codes, enrolling again before that replaces it.
+func (s *TwoFactorService) Enroll(ctx context.Context, userId int) (*TOTPEnrollment, error) {
+	fetchedUser, err := s.UserRepo.GetUserById(ctx, userId)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.TwoFactorRepo.SavePendingTOTP(ctx, userId, secret); err != nil {
+		if errors.Is(err, apperrors.ErrAlreadyExists) {
+			return nil, fmt.Errorf("%w: two-factor authentication is already enabled", apperrors.ErrAlreadyExists)
+		}
+		return nil, fmt.Errorf("failed to save TOTP secret: %w", err)
+	}
+
+	return &TOTPEnrollment{
+		Secret: secret,
+		URI:    auth.TOTPURI(s.Config.Issuer, fetchedUser.Email, secret),
+	}, nil
+}
+
+// Confirm turns 2FA on with the first code of the pending secret. The recovery codes it returns
+// are only stored hashed, this is the one time the user gets to see them.
+func (s *TwoFactorService) Confirm(ctx context.Context, userId int, code string) ([]string, error) {
+	totp, err := s.TwoFactorRepo.GetTOTP(ctx, userId)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, fmt.Errorf("%w: no two-factor enrollment to confirm", apperrors.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to fetch TOTP secret: %w", err)
+	}
+	if totp.ConfirmedAt.Valid {
+		return nil, fmt.Errorf("%w: two-factor authentication is already enabled", apperrors.ErrAlreadyExists)
+	}
+
+	step, ok := auth.ValidateTOTP(totp.Secret, normalizeCode(code), time.Now(), totpSkew)
+	if !ok {
+		return nil, invalidCodeError()
+	}
+
+	codes, hashes, err := s.newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.UoW.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.TwoFactorRepo.ConfirmTOTP(txCtx, userId); err != nil {
+			return err
+		}
+		if _, err := s.TwoFactorRepo.UseTOTPStep(txCtx, userId, step); err != nil {
+			return err
+		}
+		if err := s.TwoFactorRepo.DeleteRecoveryCodes(txCtx, userId); err != nil {
+			return err
+		}
+		return s.TwoFactorRepo.CreateRecoveryCodes(txCtx, userId, hashes)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+
+	return codes, nil
+}
+
+// Disable turns 2FA off, it takes a current code or a recovery code so a stolen access token
+// alone cannot do it
+func (s *TwoFactorService) Disable(ctx context.Context, userId int, code string) error {
+	if err := s.VerifyCode(ctx, userId, code); err != nil {
+		return err
+	}
+
+	err := s.UoW.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.TwoFactorRepo.DeleteRecoveryCodes(txCtx, userId); err != nil {
+			return err
+		}
+		return s.TwoFactorRepo.DeleteTOTP(txCtx, userId)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyCode checks a TOTP or recovery code of the user outside a login, for actions that ask for
+// the second factor again. ErrNotFound means 2FA is not enabled.
+func (s *TwoFactorService) VerifyCode(ctx context.Context, userId int, code string) error {
+	totp, err := s.enabledTOTP(ctx, userId)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return fmt.Errorf("%w: two-factor authentication is not enabled", apperrors.ErrNotFound)
+		}
+		return err
+	}
+
+	ok, err := s.verifyCode(ctx, totp, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return invalidCodeError()
+	}
+
+	return nil
+}
+
+// IsEnabled reports whether logins of the user need a second factor
+func (s *TwoFactorService) IsEnabled(ctx context.Context, userId int) (bool, error) {
+	if _, err := s.enabledTOTP(ctx, userId); err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateChallenge is issued after the password was checked, the login finishes when the token
+// comes back with a code before ChallengeTTL is over
+func (s *TwoFactorService) CreateChallenge(ctx context.Context, userId int) (string, error) {
+	token, err := newOpaqueToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate challenge token: %w", err)
+	}
+
+	ttl := s.Config.ChallengeTTL
+	if err := s.Cache.SaveCache(ctx, twoFactorChallengePrefix+hashToken(token), strconv.Itoa(userId), &ttl); err != nil {
+		return "", fmt.Errorf("failed to save login challenge: %w", err)
+	}
+
+	return token, nil
+}
+
+// CompleteChallenge exchanges a challenge token and a TOTP or recovery code for the user. An
+// unknown or expired token is ErrUnauthorized. A wrong code counts against MaxAttempts and is
+// recorded by the login guard like a wrong password, the failures of the account are only reset
+// once the code was right.
+func (s *TwoFactorService) CompleteChallenge(ctx context.Context, token string, code string, ipAddress string) (*User, error) {
+	key := hashToken(token)
+	value, err := s.Cache.GetCache(ctx, twoFactorChallengePrefix+key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch login challenge: %w", err)
+	}
+	if value == "" {
+		return nil, apperrors.ErrUnauthorized
+	}
+
+	userId, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("malformed login challenge: %w", err)
+	}
+
+	fetchedUser, err := s.UserRepo.GetUserById(ctx, userId)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, apperrors.ErrUnauthorized
+		}
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	attempt := LoginAttempt{Email: fetchedUser.Email, IPAddress: ipAddress, UserId: userId}
+	if err := s.LoginGuard.CheckLogin(ctx, attempt); err != nil {
+		var lockErr *apperrors.LockoutError
+		if errors.As(err, &lockErr) {
+			return nil, lockErr
+		}
+		return nil, fmt.Errorf("failed to check login lock: %w", err)
+	}
+
+	totp, err := s.enabledTOTP(ctx, userId)
+	if err != nil {
+		// 2FA was turned off since the password was checked, the login has to start over
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, apperrors.ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	ok, err := s.verifyCode(ctx, totp, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err := s.LoginGuard.RecordFailure(ctx, attempt); err != nil {
+			log.Printf("Failed to record failed two-factor login: %v", err)
+		}
+
+		attempts, err := s.Cache.IncrCache(ctx, twoFactorAttemptPrefix+key, s.Config.ChallengeTTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count two-factor attempt: %w", err)
+		}
+		if s.Config.MaxAttempts > 0 && attempts >= int64(s.Config.MaxAttempts) {
+			log.Printf("Dropping login challenge of user id '%v' after %d wrong codes", userId, attempts)
+			s.clearChallenge(ctx, key)
+		}
+		return nil, invalidCodeError()
+	}
+
+	s.clearChallenge(ctx, key)
+	recordLoginSuccess(ctx, s.LoginGuard, attempt)
+
+	// the account was disabled or scheduled for deletion since the password was checked
+	if !canLogIn(fetchedUser) {
+		return nil, apperrors.ErrUnauthorized
+	}
+
+	return toServiceUser(fetchedUser), nil
+}
+
+func (s *TwoFactorService) enabledTOTP(ctx context.Context, userId int) (*repository.TOTP, error) {
+	totp, err := s.TwoFactorRepo.GetTOTP(ctx, userId)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to fetch TOTP secret: %w", err)
+	}
+	if !totp.ConfirmedAt.Valid {
+		return nil, apperrors.ErrNotFound
+	}
+	return totp, nil
+}
+
+// verifyCode takes a TOTP code or a recovery code. Both work only once: a TOTP step is refused
+// after it was used and a recovery code is marked used.
+func (s *TwoFactorService) verifyCode(ctx context.Context, totp *repository.TOTP, code string) (bool, error) {
+	code = normalizeCode(code)
+
+	if len(code) == auth.TOTPDigits {
+		step, ok := auth.ValidateTOTP(totp.Secret, code, time.Now(), totpSkew)
+		if !ok {
+			return false, nil
+		}
+		used, err := s.TwoFactorRepo.UseTOTPStep(ctx, totp.UserId, step)
+		if err != nil {
+			return false, fmt.Errorf("failed to record TOTP step: %w", err)
+		}
+		return used, nil
+	}
+
+	if len(code) != recoveryCodeLength {
+		return false, nil
+	}
+
+	codes, err := s.TwoFactorRepo.ListUnusedRecoveryCodes(ctx, totp.UserId)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch recovery codes: %w", err)
+	}
+	for _, stored := range codes {
+		if !s.Hash.CheckPasswordHash(stored.CodeHash, code) {
+			continue
+		}
+		used, err := s.TwoFactorRepo.UseRecoveryCode(ctx, stored.Id)
+		if err != nil {
+			return false, fmt.Errorf("failed to use recovery code: %w", err)
+		}
+		return used, nil
+	}
+
+	return false, nil
+}
+
+// newRecoveryCodes returns the codes for the user, grouped in two halves to be easier to copy, and
+// the hashes of their normalized form to store
+func (s *TwoFactorService) newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for range recoveryCodeCount {
+		raw := make([]byte, recoveryCodeLength*5/8)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(raw))
+
+		hash, err := s.Hash.HashPassword(code)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to hash recovery code: %w", err)
+		}
+
+		half := recoveryCodeLength / 2
+		codes = append(codes, code[:half]+"-"+code[half:])
+		hashes = append(hashes, hash)
+	}
+
+	return codes, hashes, nil
+}
+
+func (s *TwoFactorService) clearChallenge(ctx context.Context, key string) {
+	for _, prefix := range []string{twoFactorChallengePrefix, twoFactorAttemptPrefix} {
+		if err := s.Cache.CleanCache(ctx, prefix+key); err != nil {
+			log.Printf("Failed to clear login challenge: %v", err)
+		}
+	}
+}
+
+// normalizeCode drops the separators users copy along with the code
+func normalizeCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+func invalidCodeError() error {
+	return apperrors.NewValidationError(apperrors.INVALID_CODE, "invalid or already used authentication code")
+}
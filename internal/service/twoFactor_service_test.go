@@ -0,0 +1,347 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/repository"
+	"workout-tracker-api/internal/service"
+	"workout-tracker-api/internal/util/auth"
+)
+
+// MockTwoFactorRepository is a mock implementation of repository.TwoFactorRepository
+type MockTwoFactorRepository struct {
+	mock.Mock
+}
+
+func (m *MockTwoFactorRepository) SavePendingTOTP(ctx context.Context, userId int, secret string) error {
+	args := m.Called(ctx, userId, secret)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) GetTOTP(ctx context.Context, userId int) (*repository.TOTP, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.TOTP), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) ConfirmTOTP(ctx context.Context, userId int) error {
+	args := m.Called(ctx, userId)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) UseTOTPStep(ctx context.Context, userId int, step int64) (bool, error) {
+	args := m.Called(ctx, userId, step)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) DeleteTOTP(ctx context.Context, userId int) error {
+	args := m.Called(ctx, userId)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) CreateRecoveryCodes(ctx context.Context, userId int, codeHashes []string) error {
+	args := m.Called(ctx, userId, codeHashes)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) ListUnusedRecoveryCodes(ctx context.Context, userId int) ([]repository.RecoveryCode, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.RecoveryCode), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) UseRecoveryCode(ctx context.Context, id int) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) DeleteRecoveryCodes(ctx context.Context, userId int) error {
+	args := m.Called(ctx, userId)
+	return args.Error(0)
+}
+
+// RFC 6238 test secret, "12345678901234567890" in base32
+const testTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+var twoFactorConfig = service.TwoFactorConfig{
+	Issuer:       "Workout Tracker",
+	ChallengeTTL: 5 * time.Minute,
+	MaxAttempts:  3,
+}
+
+func currentTOTPCode(t *testing.T) (string, int64) {
+	t.Helper()
+	step := auth.TOTPStep(time.Now())
+	code, err := auth.TOTPCode(testTOTPSecret, step)
+	if err != nil {
+		t.Fatalf("failed to generate TOTP code: %v", err)
+	}
+	return code, step
+}
+
+func confirmedTOTP() *repository.TOTP {
+	return &repository.TOTP{
+		UserId:      3,
+		Secret:      testTOTPSecret,
+		ConfirmedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	}
+}
+
+// noTwoFactor is the two-factor service of users who did not turn 2FA on
+func noTwoFactor() service.TwoFactorServiceInterface {
+	mockTwoFactorRepo := new(MockTwoFactorRepository)
+	mockTwoFactorRepo.On("GetTOTP", mock.Anything, mock.Anything).Return(nil, apperrors.ErrNotFound).Maybe()
+	return service.NewTwoFactorService(mockTwoFactorRepo, nil, &MockUnitOfWork{}, nil, nil, nil, twoFactorConfig)
+}
+
+func TestTwoFactorService_Enroll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Returns a secret and its otpauth URI", func(t *testing.T) {
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+		mockUserRepo := new(MockUserRepository)
+		twoFactorService := service.NewTwoFactorService(mockTwoFactorRepo, mockUserRepo, &MockUnitOfWork{}, nil, nil, nil, twoFactorConfig)
+
+		mockUserRepo.On("GetUserById", ctx, 3).Return(&repository.User{Id: 3, Email: "test@example.com"}, nil).Once()
+		mockTwoFactorRepo.On("SavePendingTOTP", ctx, 3, mock.AnythingOfType("string")).Return(nil).Once()
+
+		enrollment, err := twoFactorService.Enroll(ctx, 3)
+		assert.NoError(t, err)
+		assert.Len(t, enrollment.Secret, 32)
+		assert.True(t, strings.HasPrefix(enrollment.URI, "otpauth://totp/Workout%20Tracker:test@example.com?"))
+		assert.Contains(t, enrollment.URI, "secret="+enrollment.Secret)
+		mockTwoFactorRepo.AssertExpectations(t)
+	})
+
+	t.Run("Already enabled", func(t *testing.T) {
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+		mockUserRepo := new(MockUserRepository)
+		twoFactorService := service.NewTwoFactorService(mockTwoFactorRepo, mockUserRepo, &MockUnitOfWork{}, nil, nil, nil, twoFactorConfig)
+
+		mockUserRepo.On("GetUserById", ctx, 3).Return(&repository.User{Id: 3, Email: "test@example.com"}, nil).Once()
+		mockTwoFactorRepo.On("SavePendingTOTP", ctx, 3, mock.Anything).Return(apperrors.ErrAlreadyExists).Once()
+
+		enrollment, err := twoFactorService.Enroll(ctx, 3)
+		assert.ErrorIs(t, err, apperrors.ErrAlreadyExists)
+		assert.Nil(t, enrollment)
+	})
+}
+
+func TestTwoFactorService_Confirm(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Enables 2FA and returns recovery codes once", func(t *testing.T) {
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+		mockHash := new(MockHashHelper)
+		uow := &MockUnitOfWork{}
+		twoFactorService := service.NewTwoFactorService(mockTwoFactorRepo, nil, uow, mockHash, nil, nil, twoFactorConfig)
+		code, step := currentTOTPCode(t)
+
+		mockTwoFactorRepo.On("GetTOTP", ctx, 3).Return(&repository.TOTP{UserId: 3, Secret: testTOTPSecret}, nil).Once()
+		mockHash.On("HashPassword", mock.AnythingOfType("string")).Return("hashed", nil).Times(10)
+		mockTwoFactorRepo.On("ConfirmTOTP", ctx, 3).Return(nil).Once()
+		mockTwoFactorRepo.On("UseTOTPStep", ctx, 3, step).Return(true, nil).Once()
+		mockTwoFactorRepo.On("DeleteRecoveryCodes", ctx, 3).Return(nil).Once()
+		mockTwoFactorRepo.On("CreateRecoveryCodes", ctx, 3, mock.MatchedBy(func(hashes []string) bool {
+			return len(hashes) == 10 && hashes[0] == "hashed"
+		})).Return(nil).Once()
+
+		codes, err := twoFactorService.Confirm(ctx, 3, code)
+		assert.NoError(t, err)
+		assert.Len(t, codes, 10)
+		assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, codes[0])
+		assert.NotEqual(t, codes[0], codes[1])
+		assert.Equal(t, 1, uow.Calls)
+		mockTwoFactorRepo.AssertExpectations(t)
+	})
+
+	t.Run("Wrong code", func(t *testing.T) {
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+		twoFactorService := service.NewTwoFactorService(mockTwoFactorRepo, nil, &MockUnitOfWork{}, nil, nil, nil, twoFactorConfig)
+
+		mockTwoFactorRepo.On("GetTOTP", ctx, 3).Return(&repository.TOTP{UserId: 3, Secret: testTOTPSecret}, nil).Once()
+
+		codes, err := twoFactorService.Confirm(ctx, 3, "000000x")
+		assertInvalidCode(t, err)
+		assert.Nil(t, codes)
+		mockTwoFactorRepo.AssertNotCalled(t, "ConfirmTOTP", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Nothing to confirm", func(t *testing.T) {
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+		twoFactorService := service.NewTwoFactorService(mockTwoFactorRepo, nil, &MockUnitOfWork{}, nil, nil, nil, twoFactorConfig)
+
+		mockTwoFactorRepo.On("GetTOTP", ctx, 3).Return(nil, apperrors.ErrNotFound).Once()
+
+		_, err := twoFactorService.Confirm(ctx, 3, "123456")
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+
+	t.Run("Already confirmed", func(t *testing.T) {
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+		twoFactorService := service.NewTwoFactorService(mockTwoFactorRepo, nil, &MockUnitOfWork{}, nil, nil, nil, twoFactorConfig)
+
+		mockTwoFactorRepo.On("GetTOTP", ctx, 3).Return(confirmedTOTP(), nil).Once()
+
+		_, err := twoFactorService.Confirm(ctx, 3, "123456")
+		assert.ErrorIs(t, err, apperrors.ErrAlreadyExists)
+	})
+}
+
+func TestTwoFactorService_Disable(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("With a recovery code", func(t *testing.T) {
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+		mockHash := new(MockHashHelper)
+		uow := &MockUnitOfWork{}
+		twoFactorService := service.NewTwoFactorService(mockTwoFactorRepo, nil, uow, mockHash, nil, nil, twoFactorConfig)
+
+		mockTwoFactorRepo.On("GetTOTP", ctx, 3).Return(confirmedTOTP(), nil).Once()
+		mockTwoFactorRepo.On("ListUnusedRecoveryCodes", ctx, 3).Return([]repository.RecoveryCode{
+			{Id: 1, CodeHash: "hash-1"},
+			{Id: 2, CodeHash: "hash-2"},
+		}, nil).Once()
+		mockHash.On("CheckPasswordHash", "hash-1", "abcdefghij").Return(false).Once()
+		mockHash.On("CheckPasswordHash", "hash-2", "abcdefghij").Return(true).Once()
+		mockTwoFactorRepo.On("UseRecoveryCode", ctx, 2).Return(true, nil).Once()
+		mockTwoFactorRepo.On("DeleteRecoveryCodes", ctx, 3).Return(nil).Once()
+		mockTwoFactorRepo.On("DeleteTOTP", ctx, 3).Return(nil).Once()
+
+		err := twoFactorService.Disable(ctx, 3, "ABCDE-FGHIJ")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, uow.Calls)
+		mockTwoFactorRepo.AssertExpectations(t)
+	})
+
+	t.Run("Replayed TOTP code", func(t *testing.T) {
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+		twoFactorService := service.NewTwoFactorService(mockTwoFactorRepo, nil, &MockUnitOfWork{}, nil, nil, nil, twoFactorConfig)
+		code, step := currentTOTPCode(t)
+
+		mockTwoFactorRepo.On("GetTOTP", ctx, 3).Return(confirmedTOTP(), nil).Once()
+		mockTwoFactorRepo.On("UseTOTPStep", ctx, 3, step).Return(false, nil).Once()
+
+		err := twoFactorService.Disable(ctx, 3, code)
+		assertInvalidCode(t, err)
+		mockTwoFactorRepo.AssertNotCalled(t, "DeleteTOTP", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Not enabled", func(t *testing.T) {
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+		twoFactorService := service.NewTwoFactorService(mockTwoFactorRepo, nil, &MockUnitOfWork{}, nil, nil, nil, twoFactorConfig)
+
+		mockTwoFactorRepo.On("GetTOTP", ctx, 3).Return(&repository.TOTP{UserId: 3, Secret: testTOTPSecret}, nil).Once()
+
+		err := twoFactorService.Disable(ctx, 3, "123456")
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	})
+}
+
+func TestTwoFactorService_Challenge(t *testing.T) {
+	ctx := context.Background()
+
+	ipAddress := "203.0.113.7"
+	attempt := service.LoginAttempt{Email: "test@example.com", IPAddress: ipAddress, UserId: 3}
+
+	t.Run("A valid code completes the challenge once", func(t *testing.T) {
+		cache := newMemoryCache()
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockGuard := new(MockLoginGuard)
+		twoFactorService := service.NewTwoFactorService(mockTwoFactorRepo, mockUserRepo, &MockUnitOfWork{}, nil, cache, mockGuard, twoFactorConfig)
+		code, step := currentTOTPCode(t)
+
+		token, err := twoFactorService.CreateChallenge(ctx, 3)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, token)
+		for key, expiration := range cache.expirations {
+			assert.True(t, strings.HasPrefix(key, "2fachallenge:"))
+			assert.NotContains(t, key, token)
+			assert.Equal(t, 5*time.Minute, expiration)
+		}
+
+		mockUserRepo.On("GetUserById", ctx, 3).Return(&repository.User{Id: 3, Email: "test@example.com"}, nil).Once()
+		mockGuard.On("CheckLogin", ctx, attempt).Return(nil).Once()
+		mockTwoFactorRepo.On("GetTOTP", ctx, 3).Return(confirmedTOTP(), nil).Once()
+		mockTwoFactorRepo.On("UseTOTPStep", ctx, 3, step).Return(true, nil).Once()
+		mockGuard.On("RecordSuccess", ctx, attempt).Return(nil).Once()
+
+		user, err := twoFactorService.CompleteChallenge(ctx, token, code, ipAddress)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, user.Id)
+		mockGuard.AssertExpectations(t)
+
+		_, err = twoFactorService.CompleteChallenge(ctx, token, code, ipAddress)
+		assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+	})
+
+	t.Run("Unknown token", func(t *testing.T) {
+		twoFactorService := service.NewTwoFactorService(nil, nil, &MockUnitOfWork{}, nil, newMemoryCache(), nil, twoFactorConfig)
+
+		_, err := twoFactorService.CompleteChallenge(ctx, "unknown", "123456", ipAddress)
+		assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+	})
+
+	t.Run("Too many wrong codes drop the challenge", func(t *testing.T) {
+		cache := newMemoryCache()
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockGuard := new(MockLoginGuard)
+		twoFactorService := service.NewTwoFactorService(mockTwoFactorRepo, mockUserRepo, &MockUnitOfWork{}, nil, cache, mockGuard, twoFactorConfig)
+		mockUserRepo.On("GetUserById", ctx, 3).Return(&repository.User{Id: 3, Email: "test@example.com"}, nil)
+		mockGuard.On("CheckLogin", ctx, attempt).Return(nil)
+		mockGuard.On("RecordFailure", ctx, attempt).Return(nil).Times(twoFactorConfig.MaxAttempts)
+		mockTwoFactorRepo.On("GetTOTP", ctx, 3).Return(confirmedTOTP(), nil)
+
+		token, err := twoFactorService.CreateChallenge(ctx, 3)
+		assert.NoError(t, err)
+
+		for range twoFactorConfig.MaxAttempts {
+			_, err := twoFactorService.CompleteChallenge(ctx, token, "12345", ipAddress)
+			assertInvalidCode(t, err)
+		}
+
+		_, err = twoFactorService.CompleteChallenge(ctx, token, "12345", ipAddress)
+		assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+		assert.Empty(t, cache.values)
+		mockGuard.AssertExpectations(t)
+		mockGuard.AssertNotCalled(t, "RecordSuccess", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Locked account is refused before the code is checked", func(t *testing.T) {
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockGuard := new(MockLoginGuard)
+		twoFactorService := service.NewTwoFactorService(mockTwoFactorRepo, mockUserRepo, &MockUnitOfWork{}, nil, newMemoryCache(), mockGuard, twoFactorConfig)
+		mockUserRepo.On("GetUserById", ctx, 3).Return(&repository.User{Id: 3, Email: "test@example.com"}, nil).Once()
+		mockGuard.On("CheckLogin", ctx, attempt).Return(&apperrors.LockoutError{RetryAfter: time.Minute}).Once()
+
+		token, err := twoFactorService.CreateChallenge(ctx, 3)
+		assert.NoError(t, err)
+
+		_, err = twoFactorService.CompleteChallenge(ctx, token, "123456", ipAddress)
+		assert.ErrorIs(t, err, apperrors.ErrTooManyRequests)
+		mockTwoFactorRepo.AssertNotCalled(t, "GetTOTP", mock.Anything, mock.Anything)
+	})
+}
+
+func assertInvalidCode(t *testing.T, err error) {
+	t.Helper()
+	var validationErr *apperrors.ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Equal(t, apperrors.INVALID_CODE, validationErr.Field)
+	}
+}
@@ -4,19 +4,38 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"regexp"
 	"time"
 	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/mailer"
 	"workout-tracker-api/internal/repository"
 	"workout-tracker-api/internal/util/encrypt"
 )
 
 type User struct {
-	Id        int       `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Id            int       `json:"id"`
+	Name          string    `json:"name"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	Role          Role      `json:"role"`
+	Disabled      bool      `json:"disabled"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	// DeletionDueAt is when the account gets deleted, nil unless the user asked for it
+	DeletionDueAt *time.Time `json:"deletion_due_at,omitempty"`
+}
+
+// Role decides which routes a user reaches, it is carried in the access token
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
+func (role Role) IsValid() bool {
+	return role == RoleUser || role == RoleAdmin
 }
 
 type UserSignup struct {
@@ -35,33 +54,117 @@ func (data *UserSignup) Validate() error {
 		return apperrors.NewValidationError(apperrors.INVALID_EMAIL, "The email cannot match the format")
 	}
 
-	if len(data.Password) < 8 {
+	return validatePassword(data.Password)
+}
+
+func validatePassword(password string) error {
+	if len(password) < 8 {
 		return apperrors.NewValidationError(apperrors.INVALID_PASSWORD, "The password must be at least 8 characters long")
 	}
 
 	return nil
-
 }
 
+// UserLogin is a login attempt, IPAddress is the client it came from
 type UserLogin struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email     string `json:"email"`
+	Password  string `json:"password"`
+	IPAddress string `json:"-"`
+}
+
+// timingHash is compared against when the email is unknown, so the response takes as long as a
+// wrong password and does not tell which emails are registered
+const timingHash = "$2a$10$VXTiCyGUxX4zmFupd5lTJeloZTNENyzcmFu.L3QfuRFBV31aNC1Hm"
+
+// UserPreferences are per user settings, PreferredUnit is the unit reports are converted to and
+// TimeZone the IANA zone their days follow. An empty TimeZone keeps the stored one.
+type UserPreferences struct {
+	PreferredUnit WeightUnit `json:"preferredUnit"`
+	TimeZone      string     `json:"timeZone"`
+}
+
+func (data *UserPreferences) Validate() error {
+	if data.PreferredUnit != KG && data.PreferredUnit != LBS {
+		return apperrors.NewValidationError(apperrors.INVALID_SETTING, "preferred unit must be kg or lbs")
+	}
+
+	// Local is the zone of the server, not one a user can mean
+	if data.TimeZone != "" {
+		if _, err := time.LoadLocation(data.TimeZone); err != nil || data.TimeZone == "Local" {
+			return apperrors.NewValidationError(apperrors.INVALID_SETTING, "time zone must be an IANA time zone such as Europe/Berlin")
+		}
+	}
+
+	return nil
 }
+
+type UserPasswordChange struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+	IPAddress       string `json:"-"`
+}
+
+type UserPasswordReset struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"newPassword"`
+}
+
+// VerificationPolicy decides what a user whose email is not verified yet may do
+type VerificationPolicy string
+
+const (
+	// VerificationOptional treats unverified users like verified ones
+	VerificationOptional VerificationPolicy = "optional"
+	// VerificationLimited lets unverified users log in, the router keeps them to the account routes
+	VerificationLimited VerificationPolicy = "limited"
+	// VerificationRequired refuses the login until the email is verified
+	VerificationRequired VerificationPolicy = "required"
+)
+
+// UserConfig holds the account settings of UserService
+type UserConfig struct {
+	PasswordResetTTL   time.Duration
+	VerificationTTL    time.Duration
+	VerificationPolicy VerificationPolicy
+}
+
 type UserServiceInterface interface {
 	SignupUser(ctx context.Context, input UserSignup) (*User, error)
 	LoginUser(ctx context.Context, input UserLogin) (*User, error)
 	GetUser(ctx context.Context, userEmail string) (*User, error)
+	UpdatePreferences(ctx context.Context, userId int, input UserPreferences) (*UserPreferences, error)
+	ChangePassword(ctx context.Context, userId int, input UserPasswordChange) ([]string, error)
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, input UserPasswordReset) ([]string, error)
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, email string) error
 }
 
 type UserService struct {
-	userRepo repository.UserRepository
-	hash     encrypt.HashHelperInterface
+	userRepo   repository.UserRepository
+	resetRepo  repository.PasswordResetRepository
+	verifyRepo repository.EmailVerificationRepository
+	uow        repository.UnitOfWork
+	sessions   SessionServiceInterface
+	twoFactor  TwoFactorServiceInterface
+	guard      LoginGuardInterface
+	hash       encrypt.HashHelperInterface
+	mail       mailer.Mailer
+	cfg        UserConfig
 }
 
-func NewUserService(ur repository.UserRepository, h encrypt.HashHelperInterface) UserServiceInterface {
+func NewUserService(ur repository.UserRepository, rr repository.PasswordResetRepository, vr repository.EmailVerificationRepository, uow repository.UnitOfWork, ss SessionServiceInterface, tfs TwoFactorServiceInterface, lg LoginGuardInterface, h encrypt.HashHelperInterface, m mailer.Mailer, cfg UserConfig) UserServiceInterface {
 	return &UserService{
-		userRepo: ur,
-		hash:     h,
+		userRepo:   ur,
+		resetRepo:  rr,
+		verifyRepo: vr,
+		uow:        uow,
+		sessions:   ss,
+		twoFactor:  tfs,
+		guard:      lg,
+		hash:       h,
+		mail:       m,
+		cfg:        cfg,
 	}
 }
 
@@ -97,31 +200,117 @@ func (s *UserService) SignupUser(ctx context.Context, input UserSignup) (*User,
 		return nil, fmt.Errorf("failed to create user for sign up: %w", err)
 	}
 
+	// the account exists at this point, a mail that did not go out can be resent
+	if err := s.sendVerification(ctx, createdUser); err != nil {
+		log.Printf("Failed to send verification email to user id '%v': %v", createdUser.Id, err)
+	}
+
 	result := toServiceUser(createdUser)
 
 	return result, nil
 }
 
+// LoginUser checks the credentials. An unknown email and a wrong password get the same error, and
+// repeated failures lock the account or the client out for a while. With 2FA on the failures are
+// kept until the second factor passed as well.
 func (s *UserService) LoginUser(ctx context.Context, input UserLogin) (*User, error) {
+	attempt := LoginAttempt{Email: input.Email, IPAddress: input.IPAddress}
+	fetchedUser, err := checkPassword(ctx, s.guard, s.hash, attempt, input.Password, func() (*repository.User, error) {
+		return s.userRepo.GetUserByEmail(ctx, input.Email)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fetchedUser == nil {
+		return nil, apperrors.NewValidationError(apperrors.INVALID_CREDENTIALS, "invalid email or password")
+	}
 
-	fetchedUser, err := s.userRepo.GetUserByEmail(ctx, input.Email)
+	if fetchedUser.DisabledAt.Valid {
+		return nil, fmt.Errorf("%w: account is disabled", apperrors.ErrForbidden)
+	}
+
+	if fetchedUser.DeletionDueAt.Valid {
+		return nil, fmt.Errorf("%w: account is scheduled for deletion, cancel the deletion to log in again", apperrors.ErrForbidden)
+	}
 
+	if s.cfg.VerificationPolicy == VerificationRequired && !fetchedUser.EmailVerifiedAt.Valid {
+		return nil, fmt.Errorf("%w: email is not verified", apperrors.ErrForbidden)
+	}
+
+	enabled, err := s.twoFactor.IsEnabled(ctx, fetchedUser.Id)
 	if err != nil {
-		if errors.Is(err, apperrors.ErrNotFound) {
-			return nil, err
+		return nil, fmt.Errorf("failed to check two-factor authentication: %w", err)
+	}
+	if !enabled {
+		attempt.UserId = fetchedUser.Id
+		recordLoginSuccess(ctx, s.guard, attempt)
+	}
+
+	result := toServiceUser(fetchedUser)
+
+	return result, nil
+}
+
+// authenticate checks a password through the login guard like checkPassword, a right password
+// resets the failures of the account
+func authenticate(ctx context.Context, guard LoginGuardInterface, hash encrypt.HashHelperInterface, attempt LoginAttempt, password string, fetch func() (*repository.User, error)) (*repository.User, error) {
+	fetchedUser, err := checkPassword(ctx, guard, hash, attempt, password, fetch)
+	if err != nil || fetchedUser == nil {
+		return nil, err
+	}
+
+	attempt.UserId = fetchedUser.Id
+	recordLoginSuccess(ctx, guard, attempt)
+
+	return fetchedUser, nil
+}
+
+// checkPassword checks a password through the login guard: the attempt is refused while it is
+// locked out and a wrong password counts towards the lock. fetch only runs once the attempt is
+// allowed. An unknown user takes as long to refuse as a wrong password, both return a nil user.
+func checkPassword(ctx context.Context, guard LoginGuardInterface, hash encrypt.HashHelperInterface, attempt LoginAttempt, password string, fetch func() (*repository.User, error)) (*repository.User, error) {
+	if err := guard.CheckLogin(ctx, attempt); err != nil {
+		var lockErr *apperrors.LockoutError
+		if errors.As(err, &lockErr) {
+			return nil, lockErr
 		}
+		return nil, fmt.Errorf("failed to check login lock: %w", err)
+	}
+
+	fetchedUser, err := fetch()
+	if err != nil && !errors.Is(err, apperrors.ErrNotFound) {
 		return nil, fmt.Errorf("failed to fetch user: %w", err)
 	}
 
-	correct := s.hash.CheckPasswordHash(fetchedUser.PasswordHash, input.Password)
-	if !correct {
-		return nil, apperrors.NewValidationError(apperrors.INVALID_PASSWORD, "invalid password")
+	passwordHash := timingHash
+	if fetchedUser != nil {
+		passwordHash = fetchedUser.PasswordHash
+		attempt.UserId = fetchedUser.Id
 	}
 
-	result := toServiceUser(fetchedUser)
+	if !hash.CheckPasswordHash(passwordHash, password) || fetchedUser == nil {
+		if err := guard.RecordFailure(ctx, attempt); err != nil {
+			log.Printf("Failed to record failed login: %v", err)
+		}
+		return nil, nil
+	}
 
-	return result, nil
+	return fetchedUser, nil
+}
+
+// recordLoginSuccess resets the failures of the account, when that fails they run out on their own
+func recordLoginSuccess(ctx context.Context, guard LoginGuardInterface, attempt LoginAttempt) {
+	if err := guard.RecordSuccess(ctx, attempt); err != nil {
+		log.Printf("Failed to reset failed logins of user id '%v': %v", attempt.UserId, err)
+	}
 }
+
+// canLogIn is false while the account is disabled or waiting to be deleted, tokens issued before
+// stop working too
+func canLogIn(user *repository.User) bool {
+	return !user.DisabledAt.Valid && !user.DeletionDueAt.Valid
+}
+
 func (s *UserService) GetUser(ctx context.Context, userEmail string) (*User, error) {
 	userInfo, err := s.userRepo.GetUserByEmail(ctx, userEmail)
 	if err != nil {
@@ -135,16 +324,290 @@ func (s *UserService) GetUser(ctx context.Context, userEmail string) (*User, err
 
 }
 
+func (s *UserService) UpdatePreferences(ctx context.Context, userId int, input UserPreferences) (*UserPreferences, error) {
+	if err := input.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate: %w", err)
+	}
+
+	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.userRepo.UpdatePreferredUnit(txCtx, userId, repository.WeightUnit(input.PreferredUnit)); err != nil {
+			return err
+		}
+
+		if input.TimeZone == "" {
+			var err error
+			input.TimeZone, err = s.userRepo.GetTimeZone(txCtx, userId)
+			return err
+		}
+		return s.userRepo.UpdateTimeZone(txCtx, userId, input.TimeZone)
+	})
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update preferences: %w", err)
+	}
+
+	return &input, nil
+}
+
+// ChangePassword replaces the password after checking the current one. Every session of the user
+// is revoked with it, their ids are returned so the caller can end their access tokens too.
+func (s *UserService) ChangePassword(ctx context.Context, userId int, input UserPasswordChange) ([]string, error) {
+	fetchedUser, err := s.userRepo.GetUserById(ctx, userId)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	attempt := LoginAttempt{Email: fetchedUser.Email, IPAddress: input.IPAddress}
+	authenticated, err := authenticate(ctx, s.guard, s.hash, attempt, input.CurrentPassword, func() (*repository.User, error) {
+		return fetchedUser, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if authenticated == nil {
+		return nil, apperrors.NewValidationError(apperrors.INVALID_PASSWORD, "current password is incorrect")
+	}
+
+	if err := validatePassword(input.NewPassword); err != nil {
+		return nil, fmt.Errorf("failed to validate: %w", err)
+	}
+
+	hashPS, err := s.hash.HashPassword(input.NewPassword)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	revoked, err := s.replacePassword(ctx, userId, hashPS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to change password: %w", err)
+	}
+
+	return revoked, nil
+}
+
+// RequestPasswordReset mails a single use reset token. An unknown email is not an error, the
+// response must not tell which emails are registered.
+func (s *UserService) RequestPasswordReset(ctx context.Context, email string) error {
+	fetchedUser, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			log.Printf("Password reset requested for unregistered email")
+			return nil
+		}
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	token, err := newOpaqueToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate password reset token: %w", err)
+	}
+
+	// only the latest token works
+	expiresAt := time.Now().UTC().Add(s.cfg.PasswordResetTTL)
+	err = s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.resetRepo.InvalidateUserResetTokens(txCtx, fetchedUser.Id); err != nil {
+			return err
+		}
+		return s.resetRepo.CreatePasswordResetToken(txCtx, repository.CreatePasswordResetToken{
+			UserId:    fetchedUser.Id,
+			TokenHash: hashToken(token),
+			ExpiresAt: expiresAt,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save password reset token: %w", err)
+	}
+
+	err = s.mail.Send(ctx, mailer.Message{
+		To:      fetchedUser.Email,
+		Subject: "Reset your Workout Tracker password",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Use this token to reset your password. It works once and expires at %s:\n\n%s\n\n"+
+			"If you did not ask for a reset, ignore this email, your password has not changed.\n",
+			fetchedUser.Name, expiresAt.Format("2006-01-02 15:04 MST"), token),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password with a reset token. Like a password change it revokes every
+// session of the user and returns their ids.
+func (s *UserService) ResetPassword(ctx context.Context, input UserPasswordReset) ([]string, error) {
+	if input.Token == "" {
+		return nil, apperrors.NewValidationError(apperrors.INVALID_TOKEN, "reset token is required")
+	}
+
+	if err := validatePassword(input.NewPassword); err != nil {
+		return nil, fmt.Errorf("failed to validate: %w", err)
+	}
+
+	hashPS, err := s.hash.HashPassword(input.NewPassword)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	var revoked []string
+	err = s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		userId, err := s.resetRepo.ConsumePasswordResetToken(txCtx, hashToken(input.Token))
+		if err != nil {
+			if errors.Is(err, apperrors.ErrNotFound) {
+				return apperrors.NewValidationError(apperrors.INVALID_TOKEN, "reset token is invalid or expired")
+			}
+			return err
+		}
+
+		revoked, err = s.replacePassword(txCtx, userId, hashPS)
+		return err
+	})
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			return nil, validationErr
+		}
+		return nil, fmt.Errorf("failed to reset password: %w", err)
+	}
+
+	return revoked, nil
+}
+
+// VerifyEmail marks the email of the token's user as verified
+func (s *UserService) VerifyEmail(ctx context.Context, token string) error {
+	if token == "" {
+		return apperrors.NewValidationError(apperrors.INVALID_TOKEN, "verification token is required")
+	}
+
+	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		userId, err := s.verifyRepo.ConsumeVerificationToken(txCtx, hashToken(token))
+		if err != nil {
+			if errors.Is(err, apperrors.ErrNotFound) {
+				return apperrors.NewValidationError(apperrors.INVALID_TOKEN, "verification token is invalid or expired")
+			}
+			return err
+		}
+
+		if err := s.userRepo.MarkEmailVerified(txCtx, userId); err != nil {
+			return err
+		}
+		return s.verifyRepo.InvalidateUserVerificationTokens(txCtx, userId)
+	})
+	if err != nil {
+		var validationErr *apperrors.ValidationError
+		if errors.As(err, &validationErr) {
+			return validationErr
+		}
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	return nil
+}
+
+// ResendVerification mails a new verification token. Unknown and already verified emails are
+// skipped silently, like for password resets.
+func (s *UserService) ResendVerification(ctx context.Context, email string) error {
+	fetchedUser, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			log.Printf("Verification resend requested for unregistered email")
+			return nil
+		}
+		return fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	if fetchedUser.EmailVerifiedAt.Valid {
+		return nil
+	}
+
+	if err := s.sendVerification(ctx, fetchedUser); err != nil {
+		return fmt.Errorf("failed to resend verification email: %w", err)
+	}
+
+	return nil
+}
+
+// sendVerification replaces the pending verification tokens of the user with a new one and mails it
+func (s *UserService) sendVerification(ctx context.Context, user *repository.User) error {
+	token, err := newOpaqueToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate verification token: %w", err)
+	}
+
+	expiresAt := time.Now().UTC().Add(s.cfg.VerificationTTL)
+	err = s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.verifyRepo.InvalidateUserVerificationTokens(txCtx, user.Id); err != nil {
+			return err
+		}
+		return s.verifyRepo.CreateVerificationToken(txCtx, repository.CreateVerificationToken{
+			UserId:    user.Id,
+			TokenHash: hashToken(token),
+			ExpiresAt: expiresAt,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save verification token: %w", err)
+	}
+
+	err = s.mail.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Confirm your Workout Tracker email",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Welcome to Workout Tracker. Confirm your email with this token before %s:\n\n%s\n\n"+
+			"If you did not sign up, ignore this email.\n",
+			user.Name, expiresAt.Format("2006-01-02 15:04 MST"), token),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	return nil
+}
+
+// replacePassword stores the new hash, drops outstanding reset tokens and revokes every session
+// in one transaction
+func (s *UserService) replacePassword(ctx context.Context, userId int, passwordHash string) ([]string, error) {
+	var revoked []string
+	err := s.uow.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.userRepo.UpdatePasswordHash(txCtx, userId, passwordHash); err != nil {
+			return err
+		}
+		if err := s.resetRepo.InvalidateUserResetTokens(txCtx, userId); err != nil {
+			return err
+		}
+
+		var err error
+		revoked, err = s.sessions.RevokeAllSessions(txCtx, userId)
+		return err
+	})
+
+	return revoked, err
+}
+
 func toServiceUser(ru *repository.User) *User {
 	if ru == nil {
 		return nil
 	}
 
+	var deletionDueAt *time.Time
+	if ru.DeletionDueAt.Valid {
+		deletionDueAt = &ru.DeletionDueAt.Time
+	}
+
 	return &User{
-		Id:        ru.Id,
-		Name:      ru.Name,
-		Email:     ru.Email,
-		CreatedAt: ru.CreatedAt,
-		UpdatedAt: ru.UpdatedAt,
+		Id:            ru.Id,
+		Name:          ru.Name,
+		Email:         ru.Email,
+		EmailVerified: ru.EmailVerifiedAt.Valid,
+		Role:          Role(ru.Role),
+		Disabled:      ru.DisabledAt.Valid,
+		CreatedAt:     ru.CreatedAt,
+		UpdatedAt:     ru.UpdatedAt,
+		DeletionDueAt: deletionDueAt,
 	}
 }
@@ -2,10 +2,13 @@ package service_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 	"workout-tracker-api/internal/apperrors"
+	"workout-tracker-api/internal/mailer"
 	"workout-tracker-api/internal/repository"
 	"workout-tracker-api/internal/service"
 
@@ -34,6 +37,14 @@ func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (
 	return args.Get(0).(*repository.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetUserById(ctx context.Context, userId int) (*repository.User, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.User), args.Error(1)
+}
+
 func (m *MockUserRepository) ExistUser(ctx context.Context, email string) (bool, error) {
 	args := m.Called(ctx, email)
 	return args.Bool(0), args.Error(1)
@@ -45,6 +56,179 @@ func (m *MockUserRepository) DeleteUserByEmail(ctx context.Context, email string
 }
 
 // MockHashHelper is a mock implementation of encrypt.HashHelperInterface
+func (m *MockUserRepository) GetPreferredUnit(ctx context.Context, userId int) (repository.WeightUnit, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).(repository.WeightUnit), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdatePreferredUnit(ctx context.Context, userId int, unit repository.WeightUnit) error {
+	args := m.Called(ctx, userId, unit)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetTimeZone(ctx context.Context, userId int) (string, error) {
+	args := m.Called(ctx, userId)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateTimeZone(ctx context.Context, userId int, timeZone string) error {
+	args := m.Called(ctx, userId, timeZone)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) MarkEmailVerified(ctx context.Context, userId int) error {
+	args := m.Called(ctx, userId)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ListUsers(ctx context.Context, filter repository.UserFilter) ([]repository.User, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.User), args.Error(1)
+}
+
+func (m *MockUserRepository) SetUserDisabled(ctx context.Context, userId int, disabled bool) error {
+	args := m.Called(ctx, userId, disabled)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateUserRole(ctx context.Context, userId int, role repository.Role) error {
+	args := m.Called(ctx, userId, role)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GrantRoleByEmails(ctx context.Context, emails []string, role repository.Role) (int64, error) {
+	args := m.Called(ctx, emails, role)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) ScheduleUserDeletion(ctx context.Context, userId int, dueAt time.Time) error {
+	args := m.Called(ctx, userId, dueAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) CancelUserDeletion(ctx context.Context, userId int) error {
+	args := m.Called(ctx, userId)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ListUsersDueForDeletion(ctx context.Context, now time.Time, afterId int, limit int) ([]int, error) {
+	args := m.Called(ctx, now, afterId, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int), args.Error(1)
+}
+
+func (m *MockUserRepository) DeleteDueUser(ctx context.Context, userId int, now time.Time) (bool, error) {
+	args := m.Called(ctx, userId, now)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdatePasswordHash(ctx context.Context, userId int, passwordHash string) error {
+	args := m.Called(ctx, userId, passwordHash)
+	return args.Error(0)
+}
+
+// MockPasswordResetRepository is a mock implementation of repository.PasswordResetRepository
+type MockPasswordResetRepository struct {
+	mock.Mock
+}
+
+func (m *MockPasswordResetRepository) CreatePasswordResetToken(ctx context.Context, data repository.CreatePasswordResetToken) error {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockPasswordResetRepository) ConsumePasswordResetToken(ctx context.Context, tokenHash string) (int, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPasswordResetRepository) InvalidateUserResetTokens(ctx context.Context, userId int) error {
+	args := m.Called(ctx, userId)
+	return args.Error(0)
+}
+
+// MockUserSessionService is a mock implementation of service.SessionServiceInterface
+type MockUserSessionService struct {
+	mock.Mock
+}
+
+func (m *MockUserSessionService) StartSession(ctx context.Context, input service.SessionStart) (*service.Session, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.Session), args.Error(1)
+}
+
+func (m *MockUserSessionService) TouchSession(ctx context.Context, sessionId string, jti string) error {
+	args := m.Called(ctx, sessionId, jti)
+	return args.Error(0)
+}
+
+func (m *MockUserSessionService) ListSessions(ctx context.Context, userId int) ([]service.Session, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.Session), args.Error(1)
+}
+
+func (m *MockUserSessionService) RevokeSession(ctx context.Context, userId int, sessionId string) error {
+	args := m.Called(ctx, userId, sessionId)
+	return args.Error(0)
+}
+
+func (m *MockUserSessionService) RevokeAllSessions(ctx context.Context, userId int) ([]string, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// MockMailer is a mock implementation of mailer.Mailer
+type MockMailer struct {
+	mock.Mock
+}
+
+func (m *MockMailer) Send(ctx context.Context, msg mailer.Message) error {
+	args := m.Called(ctx, msg)
+	return args.Error(0)
+}
+
+// MockEmailVerificationRepository is a mock implementation of repository.EmailVerificationRepository
+type MockEmailVerificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockEmailVerificationRepository) CreateVerificationToken(ctx context.Context, data repository.CreateVerificationToken) error {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockEmailVerificationRepository) ConsumeVerificationToken(ctx context.Context, tokenHash string) (int, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockEmailVerificationRepository) InvalidateUserVerificationTokens(ctx context.Context, userId int) error {
+	args := m.Called(ctx, userId)
+	return args.Error(0)
+}
+
+const resetTTL = time.Hour
+
+var userConfig = service.UserConfig{
+	PasswordResetTTL:   resetTTL,
+	VerificationTTL:    48 * time.Hour,
+	VerificationPolicy: service.VerificationOptional,
+}
+
 type MockHashHelper struct {
 	mock.Mock
 }
@@ -205,7 +389,14 @@ func TestUserService_SignupUser(t *testing.T) {
 			tt.mockRepoSetup(mockRepo)
 			tt.mockHashSetup(mockHash)
 
-			userService := service.NewUserService(mockRepo, mockHash)
+			// a created user is sent a verification email
+			mockVerifyRepo := new(MockEmailVerificationRepository)
+			mockVerifyRepo.On("InvalidateUserVerificationTokens", ctx, mock.Anything).Return(nil).Maybe()
+			mockVerifyRepo.On("CreateVerificationToken", ctx, mock.Anything).Return(nil).Maybe()
+			mockMailer := new(MockMailer)
+			mockMailer.On("Send", ctx, mock.Anything).Return(nil).Maybe()
+
+			userService := service.NewUserService(mockRepo, nil, mockVerifyRepo, new(MockUnitOfWork), nil, nil, nil, mockHash, mockMailer, userConfig)
 			user, err := userService.SignupUser(ctx, tt.input)
 
 			if tt.expectedErrorType != nil {
@@ -274,9 +465,12 @@ func TestUserService_LoginUser(t *testing.T) {
 			mockRepoSetup: func(mur *MockUserRepository) {
 				mur.On("GetUserByEmail", ctx, "nonexistent@example.com").Return(nil, apperrors.ErrNotFound).Once()
 			},
-			mockHashSetup:     func(mhh *MockHashHelper) {},
+			mockHashSetup: func(mhh *MockHashHelper) {
+				// still compared, so it takes as long as a wrong password
+				mhh.On("CheckPasswordHash", mock.Anything, "password123").Return(false).Once()
+			},
 			expectedUser:      nil,
-			expectedErrorType: apperrors.ErrNotFound,
+			expectedErrorType: &apperrors.ValidationError{},
 		},
 		{
 			name: "Login with incorrect password",
@@ -323,16 +517,15 @@ func TestUserService_LoginUser(t *testing.T) {
 			tt.mockRepoSetup(mockRepo)
 			tt.mockHashSetup(mockHash)
 
-			userService := service.NewUserService(mockRepo, mockHash)
+			userService := service.NewUserService(mockRepo, nil, nil, new(MockUnitOfWork), nil, noTwoFactor(), openLoginGuard(), mockHash, nil, userConfig)
 			user, err := userService.LoginUser(ctx, tt.input)
 
 			if tt.expectedErrorType != nil {
 				assert.Error(t, err)
 				var validationErr *apperrors.ValidationError
-				if errors.As(err, &validationErr) {
+				if errors.As(tt.expectedErrorType, &validationErr) {
 					assert.ErrorAs(t, err, &validationErr)
-				} else if errors.Is(tt.expectedErrorType, apperrors.ErrNotFound) {
-					assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+					assert.Equal(t, apperrors.INVALID_CREDENTIALS, validationErr.Field)
 				}
 			} else {
 				assert.NoError(t, err)
@@ -420,7 +613,7 @@ func TestUserService_GetUser(t *testing.T) {
 			tt.mockRepoSetup(mockRepo)
 			tt.mockHashSetup(mockHash)
 
-			userService := service.NewUserService(mockRepo, mockHash)
+			userService := service.NewUserService(mockRepo, nil, nil, new(MockUnitOfWork), nil, nil, nil, mockHash, nil, userConfig)
 			user, err := userService.GetUser(ctx, tt.input)
 
 			if tt.expectedErrorType != nil {
@@ -515,3 +708,585 @@ func TestValidate_UserSignup(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_UpdatePreferences(t *testing.T) {
+	ctx := context.Background()
+	userID := 1
+
+	t.Run("Update preferred unit successfully", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockRepo.On("UpdatePreferredUnit", ctx, userID, repository.LBS).Return(nil).Once()
+		mockRepo.On("GetTimeZone", ctx, userID).Return("UTC", nil).Once()
+
+		userService := service.NewUserService(mockRepo, nil, nil, new(MockUnitOfWork), nil, nil, nil, new(MockHashHelper), nil, userConfig)
+		preferences, err := userService.UpdatePreferences(ctx, userID, service.UserPreferences{PreferredUnit: service.LBS})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &service.UserPreferences{PreferredUnit: service.LBS, TimeZone: "UTC"}, preferences)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Update time zone successfully", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		uow := new(MockUnitOfWork)
+		mockRepo.On("UpdatePreferredUnit", ctx, userID, repository.KG).Return(nil).Once()
+		mockRepo.On("UpdateTimeZone", ctx, userID, "Asia/Hong_Kong").Return(nil).Once()
+
+		userService := service.NewUserService(mockRepo, nil, nil, uow, nil, nil, nil, new(MockHashHelper), nil, userConfig)
+		preferences, err := userService.UpdatePreferences(ctx, userID, service.UserPreferences{PreferredUnit: service.KG, TimeZone: "Asia/Hong_Kong"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &service.UserPreferences{PreferredUnit: service.KG, TimeZone: "Asia/Hong_Kong"}, preferences)
+		assert.Equal(t, 1, uow.Calls)
+		mockRepo.AssertNotCalled(t, "GetTimeZone", mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
+	for _, timeZone := range []string{"Mars/Olympus", "Local"} {
+		t.Run("Unknown time zone "+timeZone, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+
+			userService := service.NewUserService(mockRepo, nil, nil, new(MockUnitOfWork), nil, nil, nil, new(MockHashHelper), nil, userConfig)
+			preferences, err := userService.UpdatePreferences(ctx, userID, service.UserPreferences{PreferredUnit: service.KG, TimeZone: timeZone})
+
+			var validationErr *apperrors.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Nil(t, preferences)
+			mockRepo.AssertNotCalled(t, "UpdatePreferredUnit")
+		})
+	}
+
+	t.Run("Unit other is not a valid preference", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+
+		userService := service.NewUserService(mockRepo, nil, nil, new(MockUnitOfWork), nil, nil, nil, new(MockHashHelper), nil, userConfig)
+		preferences, err := userService.UpdatePreferences(ctx, userID, service.UserPreferences{PreferredUnit: service.OTHER})
+
+		var validationErr *apperrors.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Nil(t, preferences)
+		mockRepo.AssertNotCalled(t, "UpdatePreferredUnit")
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockRepo.On("UpdatePreferredUnit", ctx, userID, repository.KG).Return(apperrors.ErrNotFound).Once()
+
+		userService := service.NewUserService(mockRepo, nil, nil, new(MockUnitOfWork), nil, nil, nil, new(MockHashHelper), nil, userConfig)
+		preferences, err := userService.UpdatePreferences(ctx, userID, service.UserPreferences{PreferredUnit: service.KG})
+
+		assert.ErrorIs(t, err, apperrors.ErrNotFound)
+		assert.Nil(t, preferences)
+	})
+}
+
+func TestUserService_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+	stored := &repository.User{Id: 5, Email: "john@example.com", PasswordHash: "old_hash"}
+
+	t.Run("Success revokes every session", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockResetRepo := new(MockPasswordResetRepository)
+		mockSessions := new(MockUserSessionService)
+		mockHash := new(MockHashHelper)
+		uow := new(MockUnitOfWork)
+		userService := service.NewUserService(mockRepo, mockResetRepo, nil, uow, mockSessions, nil, openLoginGuard(), mockHash, nil, userConfig)
+
+		mockRepo.On("GetUserById", ctx, 5).Return(stored, nil).Once()
+		mockHash.On("CheckPasswordHash", "old_hash", "oldpassword").Return(true).Once()
+		mockHash.On("HashPassword", "newpassword").Return("new_hash", nil).Once()
+		mockRepo.On("UpdatePasswordHash", ctx, 5, "new_hash").Return(nil).Once()
+		mockResetRepo.On("InvalidateUserResetTokens", ctx, 5).Return(nil).Once()
+		mockSessions.On("RevokeAllSessions", ctx, 5).Return([]string{"session-1", "session-2"}, nil).Once()
+
+		revoked, err := userService.ChangePassword(ctx, 5, service.UserPasswordChange{CurrentPassword: "oldpassword", NewPassword: "newpassword"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"session-1", "session-2"}, revoked)
+		assert.Equal(t, 1, uow.Calls)
+		mockRepo.AssertExpectations(t)
+		mockResetRepo.AssertExpectations(t)
+		mockSessions.AssertExpectations(t)
+		mockHash.AssertExpectations(t)
+	})
+
+	t.Run("Wrong current password counts as a failed login", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockHash := new(MockHashHelper)
+		guard := new(MockLoginGuard)
+		userService := service.NewUserService(mockRepo, nil, nil, new(MockUnitOfWork), nil, nil, guard, mockHash, nil, userConfig)
+
+		mockRepo.On("GetUserById", ctx, 5).Return(stored, nil).Once()
+		guard.On("CheckLogin", ctx, service.LoginAttempt{Email: "john@example.com", IPAddress: "203.0.113.7"}).Return(nil).Once()
+		mockHash.On("CheckPasswordHash", "old_hash", "guess").Return(false).Once()
+		guard.On("RecordFailure", ctx, service.LoginAttempt{Email: "john@example.com", IPAddress: "203.0.113.7", UserId: 5}).Return(nil).Once()
+
+		revoked, err := userService.ChangePassword(ctx, 5, service.UserPasswordChange{CurrentPassword: "guess", NewPassword: "newpassword", IPAddress: "203.0.113.7"})
+		var validationErr *apperrors.ValidationError
+		if assert.ErrorAs(t, err, &validationErr) {
+			assert.Equal(t, apperrors.INVALID_PASSWORD, validationErr.Field)
+		}
+		assert.Nil(t, revoked)
+		guard.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "UpdatePasswordHash", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Locked out attempt is refused before the password is checked", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockHash := new(MockHashHelper)
+		guard := new(MockLoginGuard)
+		userService := service.NewUserService(mockRepo, nil, nil, new(MockUnitOfWork), nil, nil, guard, mockHash, nil, userConfig)
+
+		mockRepo.On("GetUserById", ctx, 5).Return(stored, nil).Once()
+		guard.On("CheckLogin", ctx, mock.Anything).Return(&apperrors.LockoutError{RetryAfter: time.Minute}).Once()
+
+		_, err := userService.ChangePassword(ctx, 5, service.UserPasswordChange{CurrentPassword: "oldpassword", NewPassword: "newpassword"})
+		assert.ErrorIs(t, err, apperrors.ErrTooManyRequests)
+		mockHash.AssertNotCalled(t, "CheckPasswordHash", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "UpdatePasswordHash", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("New password too short", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockHash := new(MockHashHelper)
+		userService := service.NewUserService(mockRepo, nil, nil, new(MockUnitOfWork), nil, nil, openLoginGuard(), mockHash, nil, userConfig)
+
+		mockRepo.On("GetUserById", ctx, 5).Return(stored, nil).Once()
+		mockHash.On("CheckPasswordHash", "old_hash", "oldpassword").Return(true).Once()
+
+		_, err := userService.ChangePassword(ctx, 5, service.UserPasswordChange{CurrentPassword: "oldpassword", NewPassword: "short"})
+		var validationErr *apperrors.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		mockHash.AssertNotCalled(t, "HashPassword", mock.Anything)
+	})
+
+	t.Run("Session revoke error rolls back", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockResetRepo := new(MockPasswordResetRepository)
+		mockSessions := new(MockUserSessionService)
+		mockHash := new(MockHashHelper)
+		uow := new(MockUnitOfWork)
+		userService := service.NewUserService(mockRepo, mockResetRepo, nil, uow, mockSessions, nil, openLoginGuard(), mockHash, nil, userConfig)
+		dbError := errors.New("update failed")
+
+		mockRepo.On("GetUserById", ctx, 5).Return(stored, nil).Once()
+		mockHash.On("CheckPasswordHash", "old_hash", "oldpassword").Return(true).Once()
+		mockHash.On("HashPassword", "newpassword").Return("new_hash", nil).Once()
+		mockRepo.On("UpdatePasswordHash", ctx, 5, "new_hash").Return(nil).Once()
+		mockResetRepo.On("InvalidateUserResetTokens", ctx, 5).Return(nil).Once()
+		mockSessions.On("RevokeAllSessions", ctx, 5).Return(nil, dbError).Once()
+
+		_, err := userService.ChangePassword(ctx, 5, service.UserPasswordChange{CurrentPassword: "oldpassword", NewPassword: "newpassword"})
+		assert.ErrorIs(t, err, dbError)
+		assert.True(t, uow.RolledBack)
+	})
+}
+
+func TestUserService_RequestPasswordReset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success mails a token and stores its hash", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockResetRepo := new(MockPasswordResetRepository)
+		mockMailer := new(MockMailer)
+		userService := service.NewUserService(mockRepo, mockResetRepo, nil, new(MockUnitOfWork), nil, nil, nil, new(MockHashHelper), mockMailer, userConfig)
+
+		var saved repository.CreatePasswordResetToken
+		var sent mailer.Message
+		mockRepo.On("GetUserByEmail", ctx, "john@example.com").Return(&repository.User{Id: 5, Name: "John", Email: "john@example.com"}, nil).Once()
+		mockResetRepo.On("InvalidateUserResetTokens", ctx, 5).Return(nil).Once()
+		mockResetRepo.On("CreatePasswordResetToken", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(repository.CreatePasswordResetToken) }).
+			Return(nil).Once()
+		mockMailer.On("Send", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { sent = args.Get(1).(mailer.Message) }).
+			Return(nil).Once()
+
+		err := userService.RequestPasswordReset(ctx, "john@example.com")
+		assert.NoError(t, err)
+		assert.Equal(t, 5, saved.UserId)
+		assert.WithinDuration(t, time.Now().Add(resetTTL), saved.ExpiresAt, time.Minute)
+		assert.Equal(t, "john@example.com", sent.To)
+
+		// the mail carries the token, the table only its hash
+		var token string
+		for _, line := range strings.Split(sent.Body, "\n") {
+			if line != "" && sha256Hex(line) == saved.TokenHash {
+				token = line
+			}
+		}
+		assert.NotEmpty(t, token)
+		mockResetRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("Unknown email is not reported", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockMailer := new(MockMailer)
+		userService := service.NewUserService(mockRepo, nil, nil, new(MockUnitOfWork), nil, nil, nil, new(MockHashHelper), mockMailer, userConfig)
+
+		mockRepo.On("GetUserByEmail", ctx, "nobody@example.com").Return(nil, apperrors.ErrNotFound).Once()
+
+		err := userService.RequestPasswordReset(ctx, "nobody@example.com")
+		assert.NoError(t, err)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Mailer error", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockResetRepo := new(MockPasswordResetRepository)
+		mockMailer := new(MockMailer)
+		userService := service.NewUserService(mockRepo, mockResetRepo, nil, new(MockUnitOfWork), nil, nil, nil, new(MockHashHelper), mockMailer, userConfig)
+		mailError := errors.New("connection refused")
+
+		mockRepo.On("GetUserByEmail", ctx, "john@example.com").Return(&repository.User{Id: 5, Email: "john@example.com"}, nil).Once()
+		mockResetRepo.On("InvalidateUserResetTokens", ctx, 5).Return(nil).Once()
+		mockResetRepo.On("CreatePasswordResetToken", ctx, mock.Anything).Return(nil).Once()
+		mockMailer.On("Send", ctx, mock.Anything).Return(mailError).Once()
+
+		err := userService.RequestPasswordReset(ctx, "john@example.com")
+		assert.ErrorIs(t, err, mailError)
+	})
+}
+
+func TestUserService_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockResetRepo := new(MockPasswordResetRepository)
+		mockSessions := new(MockUserSessionService)
+		mockHash := new(MockHashHelper)
+		userService := service.NewUserService(mockRepo, mockResetRepo, nil, new(MockUnitOfWork), mockSessions, nil, nil, mockHash, nil, userConfig)
+
+		mockHash.On("HashPassword", "newpassword").Return("new_hash", nil).Once()
+		mockResetRepo.On("ConsumePasswordResetToken", ctx, sha256Hex("reset-token")).Return(5, nil).Once()
+		mockRepo.On("UpdatePasswordHash", ctx, 5, "new_hash").Return(nil).Once()
+		mockResetRepo.On("InvalidateUserResetTokens", ctx, 5).Return(nil).Once()
+		mockSessions.On("RevokeAllSessions", ctx, 5).Return([]string{"session-1"}, nil).Once()
+
+		revoked, err := userService.ResetPassword(ctx, service.UserPasswordReset{Token: "reset-token", NewPassword: "newpassword"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"session-1"}, revoked)
+		mockRepo.AssertExpectations(t)
+		mockResetRepo.AssertExpectations(t)
+		mockSessions.AssertExpectations(t)
+	})
+
+	t.Run("Used or expired token", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockResetRepo := new(MockPasswordResetRepository)
+		mockHash := new(MockHashHelper)
+		uow := new(MockUnitOfWork)
+		userService := service.NewUserService(mockRepo, mockResetRepo, nil, uow, nil, nil, nil, mockHash, nil, userConfig)
+
+		mockHash.On("HashPassword", "newpassword").Return("new_hash", nil).Once()
+		mockResetRepo.On("ConsumePasswordResetToken", ctx, sha256Hex("stale")).Return(0, apperrors.ErrNotFound).Once()
+
+		revoked, err := userService.ResetPassword(ctx, service.UserPasswordReset{Token: "stale", NewPassword: "newpassword"})
+		var validationErr *apperrors.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, apperrors.INVALID_TOKEN, validationErr.Field)
+		assert.Nil(t, revoked)
+		assert.True(t, uow.RolledBack)
+		mockRepo.AssertNotCalled(t, "UpdatePasswordHash", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Missing token", func(t *testing.T) {
+		userService := service.NewUserService(new(MockUserRepository), nil, nil, new(MockUnitOfWork), nil, nil, nil, new(MockHashHelper), nil, userConfig)
+
+		_, err := userService.ResetPassword(ctx, service.UserPasswordReset{NewPassword: "newpassword"})
+		var validationErr *apperrors.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, apperrors.INVALID_TOKEN, validationErr.Field)
+	})
+}
+
+func TestUserService_SignupUser_SendsVerification(t *testing.T) {
+	ctx := context.Background()
+	input := service.UserSignup{Name: "Test User", Email: "test@example.com", Password: "password123"}
+
+	setup := func() (*MockUserRepository, *MockHashHelper) {
+		mockRepo := new(MockUserRepository)
+		mockHash := new(MockHashHelper)
+		mockRepo.On("ExistUser", ctx, "test@example.com").Return(false, nil).Once()
+		mockHash.On("HashPassword", "password123").Return("hashedpassword", nil).Once()
+		mockRepo.On("CreateUser", ctx, mock.Anything).Return(&repository.User{Id: 3, Name: "Test User", Email: "test@example.com"}, nil).Once()
+		return mockRepo, mockHash
+	}
+
+	t.Run("Token stored and mailed", func(t *testing.T) {
+		mockRepo, mockHash := setup()
+		mockVerifyRepo := new(MockEmailVerificationRepository)
+		mockMailer := new(MockMailer)
+		userService := service.NewUserService(mockRepo, nil, mockVerifyRepo, new(MockUnitOfWork), nil, nil, nil, mockHash, mockMailer, userConfig)
+
+		var saved repository.CreateVerificationToken
+		var sent mailer.Message
+		mockVerifyRepo.On("InvalidateUserVerificationTokens", ctx, 3).Return(nil).Once()
+		mockVerifyRepo.On("CreateVerificationToken", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(repository.CreateVerificationToken) }).
+			Return(nil).Once()
+		mockMailer.On("Send", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { sent = args.Get(1).(mailer.Message) }).
+			Return(nil).Once()
+
+		user, err := userService.SignupUser(ctx, input)
+		assert.NoError(t, err)
+		assert.False(t, user.EmailVerified)
+		assert.Equal(t, 3, saved.UserId)
+		assert.WithinDuration(t, time.Now().Add(userConfig.VerificationTTL), saved.ExpiresAt, time.Minute)
+		assert.Equal(t, "test@example.com", sent.To)
+		mockVerifyRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("Mailer error does not fail the signup", func(t *testing.T) {
+		mockRepo, mockHash := setup()
+		mockVerifyRepo := new(MockEmailVerificationRepository)
+		mockMailer := new(MockMailer)
+		userService := service.NewUserService(mockRepo, nil, mockVerifyRepo, new(MockUnitOfWork), nil, nil, nil, mockHash, mockMailer, userConfig)
+
+		mockVerifyRepo.On("InvalidateUserVerificationTokens", ctx, 3).Return(nil).Once()
+		mockVerifyRepo.On("CreateVerificationToken", ctx, mock.Anything).Return(nil).Once()
+		mockMailer.On("Send", ctx, mock.Anything).Return(errors.New("connection refused")).Once()
+
+		user, err := userService.SignupUser(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, user.Id)
+	})
+}
+
+func TestUserService_LoginUser_VerificationPolicy(t *testing.T) {
+	ctx := context.Background()
+	unverified := &repository.User{Id: 3, Email: "test@example.com", PasswordHash: "hash"}
+	verified := &repository.User{Id: 4, Email: "done@example.com", PasswordHash: "hash", EmailVerifiedAt: sql.NullTime{Time: time.Now(), Valid: true}}
+
+	tests := []struct {
+		name      string
+		policy    service.VerificationPolicy
+		user      *repository.User
+		forbidden bool
+	}{
+		{name: "Optional lets unverified users in", policy: service.VerificationOptional, user: unverified},
+		{name: "Limited lets unverified users in", policy: service.VerificationLimited, user: unverified},
+		{name: "Required refuses unverified users", policy: service.VerificationRequired, user: unverified, forbidden: true},
+		{name: "Required lets verified users in", policy: service.VerificationRequired, user: verified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			mockHash := new(MockHashHelper)
+			cfg := userConfig
+			cfg.VerificationPolicy = tt.policy
+			userService := service.NewUserService(mockRepo, nil, nil, new(MockUnitOfWork), nil, noTwoFactor(), openLoginGuard(), mockHash, nil, cfg)
+
+			mockRepo.On("GetUserByEmail", ctx, tt.user.Email).Return(tt.user, nil).Once()
+			mockHash.On("CheckPasswordHash", "hash", "password123").Return(true).Once()
+
+			user, err := userService.LoginUser(ctx, service.UserLogin{Email: tt.user.Email, Password: "password123"})
+			if tt.forbidden {
+				assert.ErrorIs(t, err, apperrors.ErrForbidden)
+				assert.Nil(t, user)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.user.EmailVerifiedAt.Valid, user.EmailVerified)
+		})
+	}
+}
+
+func TestUserService_LoginUser_Disabled(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockUserRepository)
+	mockHash := new(MockHashHelper)
+	userService := service.NewUserService(mockRepo, nil, nil, new(MockUnitOfWork), nil, noTwoFactor(), openLoginGuard(), mockHash, nil, userConfig)
+
+	mockRepo.On("GetUserByEmail", ctx, "test@example.com").Return(&repository.User{
+		Id: 3, Email: "test@example.com", PasswordHash: "hash", DisabledAt: sql.NullTime{Time: time.Now(), Valid: true},
+	}, nil).Once()
+	mockHash.On("CheckPasswordHash", "hash", "password123").Return(true).Once()
+
+	user, err := userService.LoginUser(ctx, service.UserLogin{Email: "test@example.com", Password: "password123"})
+	assert.ErrorIs(t, err, apperrors.ErrForbidden)
+	assert.Contains(t, err.Error(), "account is disabled")
+	assert.Nil(t, user)
+}
+
+func TestUserService_LoginUser_DeletionScheduled(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockUserRepository)
+	mockHash := new(MockHashHelper)
+	userService := service.NewUserService(mockRepo, nil, nil, new(MockUnitOfWork), nil, noTwoFactor(), openLoginGuard(), mockHash, nil, userConfig)
+
+	mockRepo.On("GetUserByEmail", ctx, "test@example.com").Return(&repository.User{
+		Id: 3, Email: "test@example.com", PasswordHash: "hash", DeletionDueAt: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+	}, nil).Once()
+	mockHash.On("CheckPasswordHash", "hash", "password123").Return(true).Once()
+
+	user, err := userService.LoginUser(ctx, service.UserLogin{Email: "test@example.com", Password: "password123"})
+	assert.ErrorIs(t, err, apperrors.ErrForbidden)
+	assert.Contains(t, err.Error(), "scheduled for deletion")
+	assert.Nil(t, user)
+}
+
+func TestUserService_VerifyEmail(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockVerifyRepo := new(MockEmailVerificationRepository)
+		userService := service.NewUserService(mockRepo, nil, mockVerifyRepo, new(MockUnitOfWork), nil, nil, nil, new(MockHashHelper), nil, userConfig)
+
+		mockVerifyRepo.On("ConsumeVerificationToken", ctx, sha256Hex("verify-token")).Return(3, nil).Once()
+		mockRepo.On("MarkEmailVerified", ctx, 3).Return(nil).Once()
+		mockVerifyRepo.On("InvalidateUserVerificationTokens", ctx, 3).Return(nil).Once()
+
+		err := userService.VerifyEmail(ctx, "verify-token")
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockVerifyRepo.AssertExpectations(t)
+	})
+
+	t.Run("Used or expired token", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockVerifyRepo := new(MockEmailVerificationRepository)
+		uow := new(MockUnitOfWork)
+		userService := service.NewUserService(mockRepo, nil, mockVerifyRepo, uow, nil, nil, nil, new(MockHashHelper), nil, userConfig)
+
+		mockVerifyRepo.On("ConsumeVerificationToken", ctx, sha256Hex("stale")).Return(0, apperrors.ErrNotFound).Once()
+
+		err := userService.VerifyEmail(ctx, "stale")
+		var validationErr *apperrors.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, apperrors.INVALID_TOKEN, validationErr.Field)
+		assert.True(t, uow.RolledBack)
+		mockRepo.AssertNotCalled(t, "MarkEmailVerified", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserService_ResendVerification(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Unverified user gets a new token", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockVerifyRepo := new(MockEmailVerificationRepository)
+		mockMailer := new(MockMailer)
+		userService := service.NewUserService(mockRepo, nil, mockVerifyRepo, new(MockUnitOfWork), nil, nil, nil, new(MockHashHelper), mockMailer, userConfig)
+
+		mockRepo.On("GetUserByEmail", ctx, "test@example.com").Return(&repository.User{Id: 3, Email: "test@example.com"}, nil).Once()
+		mockVerifyRepo.On("InvalidateUserVerificationTokens", ctx, 3).Return(nil).Once()
+		mockVerifyRepo.On("CreateVerificationToken", ctx, mock.Anything).Return(nil).Once()
+		mockMailer.On("Send", ctx, mock.Anything).Return(nil).Once()
+
+		err := userService.ResendVerification(ctx, "test@example.com")
+		assert.NoError(t, err)
+		mockVerifyRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("Verified or unknown email is skipped", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockMailer := new(MockMailer)
+		userService := service.NewUserService(mockRepo, nil, nil, new(MockUnitOfWork), nil, nil, nil, new(MockHashHelper), mockMailer, userConfig)
+
+		mockRepo.On("GetUserByEmail", ctx, "done@example.com").
+			Return(&repository.User{Id: 4, EmailVerifiedAt: sql.NullTime{Time: time.Now(), Valid: true}}, nil).Once()
+		mockRepo.On("GetUserByEmail", ctx, "nobody@example.com").Return(nil, apperrors.ErrNotFound).Once()
+
+		assert.NoError(t, userService.ResendVerification(ctx, "done@example.com"))
+		assert.NoError(t, userService.ResendVerification(ctx, "nobody@example.com"))
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserService_LoginUser_Lockout(t *testing.T) {
+	ctx := context.Background()
+	stored := &repository.User{Id: 3, Email: "test@example.com", PasswordHash: "hash"}
+
+	t.Run("Locked login is refused before the password is checked", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockGuard := new(MockLoginGuard)
+		mockHash := new(MockHashHelper)
+		userService := service.NewUserService(mockRepo, nil, nil, new(MockUnitOfWork), nil, noTwoFactor(), mockGuard, mockHash, nil, userConfig)
+
+		attempt := service.LoginAttempt{Email: "test@example.com", IPAddress: "203.0.113.7"}
+		mockGuard.On("CheckLogin", ctx, attempt).Return(&apperrors.LockoutError{RetryAfter: time.Minute}).Once()
+
+		user, err := userService.LoginUser(ctx, service.UserLogin{Email: "test@example.com", Password: "password123", IPAddress: "203.0.113.7"})
+		var lockErr *apperrors.LockoutError
+		assert.ErrorAs(t, err, &lockErr)
+		assert.ErrorIs(t, err, apperrors.ErrTooManyRequests)
+		assert.Equal(t, time.Minute, lockErr.RetryAfter)
+		assert.Nil(t, user)
+		mockRepo.AssertNotCalled(t, "GetUserByEmail", mock.Anything, mock.Anything)
+		mockHash.AssertNotCalled(t, "CheckPasswordHash", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Wrong password is recorded against the account", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockGuard := new(MockLoginGuard)
+		mockHash := new(MockHashHelper)
+		userService := service.NewUserService(mockRepo, nil, nil, new(MockUnitOfWork), nil, noTwoFactor(), mockGuard, mockHash, nil, userConfig)
+
+		mockGuard.On("CheckLogin", ctx, mock.Anything).Return(nil).Once()
+		mockRepo.On("GetUserByEmail", ctx, "test@example.com").Return(stored, nil).Once()
+		mockHash.On("CheckPasswordHash", "hash", "wrongpassword").Return(false).Once()
+		mockGuard.On("RecordFailure", ctx, service.LoginAttempt{Email: "test@example.com", IPAddress: "203.0.113.7", UserId: 3}).Return(nil).Once()
+
+		_, err := userService.LoginUser(ctx, service.UserLogin{Email: "test@example.com", Password: "wrongpassword", IPAddress: "203.0.113.7"})
+		var validationErr *apperrors.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, apperrors.INVALID_CREDENTIALS, validationErr.Field)
+		mockGuard.AssertExpectations(t)
+		mockGuard.AssertNotCalled(t, "RecordSuccess", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success resets the failures", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockGuard := new(MockLoginGuard)
+		mockHash := new(MockHashHelper)
+		userService := service.NewUserService(mockRepo, nil, nil, new(MockUnitOfWork), nil, noTwoFactor(), mockGuard, mockHash, nil, userConfig)
+
+		mockGuard.On("CheckLogin", ctx, mock.Anything).Return(nil).Once()
+		mockRepo.On("GetUserByEmail", ctx, "test@example.com").Return(stored, nil).Once()
+		mockHash.On("CheckPasswordHash", "hash", "password123").Return(true).Once()
+		mockGuard.On("RecordSuccess", ctx, service.LoginAttempt{Email: "test@example.com", UserId: 3}).Return(nil).Once()
+
+		user, err := userService.LoginUser(ctx, service.UserLogin{Email: "test@example.com", Password: "password123"})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, user.Id)
+		mockGuard.AssertExpectations(t)
+		mockGuard.AssertNotCalled(t, "RecordFailure", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success with 2FA on keeps the failures until the code passed", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockGuard := new(MockLoginGuard)
+		mockHash := new(MockHashHelper)
+		mockTwoFactorRepo := new(MockTwoFactorRepository)
+		twoFactorService := service.NewTwoFactorService(mockTwoFactorRepo, nil, &MockUnitOfWork{}, nil, nil, mockGuard, twoFactorConfig)
+		userService := service.NewUserService(mockRepo, nil, nil, new(MockUnitOfWork), nil, twoFactorService, mockGuard, mockHash, nil, userConfig)
+
+		mockGuard.On("CheckLogin", ctx, mock.Anything).Return(nil).Once()
+		mockRepo.On("GetUserByEmail", ctx, "test@example.com").Return(stored, nil).Once()
+		mockHash.On("CheckPasswordHash", "hash", "password123").Return(true).Once()
+		mockTwoFactorRepo.On("GetTOTP", ctx, 3).Return(confirmedTOTP(), nil).Once()
+
+		user, err := userService.LoginUser(ctx, service.UserLogin{Email: "test@example.com", Password: "password123"})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, user.Id)
+		mockGuard.AssertNotCalled(t, "RecordSuccess", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Cache error", func(t *testing.T) {
+		mockGuard := new(MockLoginGuard)
+		userService := service.NewUserService(new(MockUserRepository), nil, nil, new(MockUnitOfWork), nil, noTwoFactor(), mockGuard, new(MockHashHelper), nil, userConfig)
+
+		mockGuard.On("CheckLogin", ctx, mock.Anything).Return(errors.New("redis down")).Once()
+
+		_, err := userService.LoginUser(ctx, service.UserLogin{Email: "test@example.com", Password: "password123"})
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, apperrors.ErrTooManyRequests)
+	})
+}
@@ -20,6 +20,7 @@ type ExercisePlan struct {
 	Id            int        `json:"id"`
 	ExerciseId    int        `json:"exerciseId"`
 	WorkoutPlanId int        `json:"workoutPlanId"`
+	Position      int        `json:"position"`
 	Sets          int        `json:"sets"`
 	Repetitions   int        `json:"repetitions"`
 	Weights       float32    `json:"weights"`
@@ -143,17 +144,26 @@ type WorkoutServiceInterface interface {
 	CompleteWorkout(ctx context.Context, id int, comment *string) error
 	ScheduleWorkout(ctx context.Context, id int, scheduledDate *time.Time) (*WorkoutPlan, error)
 	UpdateExercisePlans(ctx context.Context, workoutId int, epsUpdate []ExercisePlanUpdate) (*WorkoutPlan, error)
+	AddExercisePlan(ctx context.Context, workoutId int, data ExercisePlanCreate, position int) (*WorkoutPlan, error)
+	RemoveExercisePlan(ctx context.Context, workoutId int, exercisePlanId int) (*WorkoutPlan, error)
+	MoveExercisePlan(ctx context.Context, workoutId int, exercisePlanId int, position int) (*WorkoutPlan, error)
 }
 
 type WorkoutService struct {
-	WPRepo repository.WorkoutRepository
-	EPRepo repository.ExercisePlanRepository
+	WPRepo       repository.WorkoutRepository
+	EPRepo       repository.ExercisePlanRepository
+	ExerciseRepo repository.ExerciseRepository
+	UoW          repository.UnitOfWork
+	PRs          PersonalRecordServiceInterface
 }
 
-func NewWPService(wr repository.WorkoutRepository, er repository.ExercisePlanRepository) WorkoutServiceInterface {
+func NewWPService(wr repository.WorkoutRepository, er repository.ExercisePlanRepository, exr repository.ExerciseRepository, uow repository.UnitOfWork, prs PersonalRecordServiceInterface) WorkoutServiceInterface {
 	return &WorkoutService{
-		WPRepo: wr,
-		EPRepo: er,
+		WPRepo:       wr,
+		EPRepo:       er,
+		ExerciseRepo: exr,
+		UoW:          uow,
+		PRs:          prs,
 	}
 }
 
@@ -210,31 +220,46 @@ func (ws *WorkoutService) CreateWorkout(ctx context.Context, data WorkoutPlanCre
 		}
 	}
 
-	// alrealy validate
-	workout, err := ws.WPRepo.CreateWorkout(ctx, repository.CreateWP{
-		UserId:        data.UserId,
-		ScheduledDate: *data.ScheduledDate,
-		Comment:       nil,
-	})
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to create workout plan: %w", err)
+	if err := checkExerciseAccess(ctx, ws.ExerciseRepo, data.UserId, data.ExercisePlans); err != nil {
+		return nil, err
 	}
 
+	// alrealy validate
+	// workout plan and exercise plans are created together, a bad exercise plan leaves nothing behind
+	var workout *repository.WorkoutPlan
 	var exercisePlans []repository.ExercisePlan
-	for _, ep := range data.ExercisePlans {
-		exercisePlan, err := ws.EPRepo.CreateExercisePlan(ctx, repository.CreateEP{
-			ExerciseId:  ep.ExerciseId,
-			Sets:        ep.Sets,
-			Repetitions: ep.Repetitions,
-			Weights:     ep.Weights,
-			WeightUnit:  repository.WeightUnit(ep.WeightUnit),
-		}, workout.Id)
+	err := ws.UoW.WithinTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		workout, err = ws.WPRepo.CreateWorkout(txCtx, repository.CreateWP{
+			UserId:        data.UserId,
+			ScheduledDate: *data.ScheduledDate,
+			Comment:       nil,
+		})
+
 		if err != nil {
-			return nil, fmt.Errorf("failed to create exercise plan: %w", err)
+			return fmt.Errorf("failed to create workout plan: %w", err)
+		}
+
+		for _, ep := range data.ExercisePlans {
+			exercisePlan, err := ws.EPRepo.CreateExercisePlan(txCtx, repository.CreateEP{
+				ExerciseId:  ep.ExerciseId,
+				Sets:        ep.Sets,
+				Repetitions: ep.Repetitions,
+				Weights:     ep.Weights,
+				WeightUnit:  repository.WeightUnit(ep.WeightUnit),
+			}, workout.Id)
+			if err != nil {
+				return fmt.Errorf("failed to create exercise plan: %w", err)
+			}
+
+			exercisePlans = append(exercisePlans, *exercisePlan)
 		}
 
-		exercisePlans = append(exercisePlans, *exercisePlan)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
 	result := toServiceWP(workout, exercisePlans)
@@ -295,18 +320,27 @@ func (ws *WorkoutService) GetWorkoutById(ctx context.Context, id int) (*WorkoutP
 	return toServiceWP(workoutPlan, exercisePlans), nil
 
 }
+
+// CompleteWorkout marks the workout plan as completed and records the personal records set in it,
+// both in one transaction so a workout is never completed without its records.
 func (ws *WorkoutService) CompleteWorkout(ctx context.Context, id int, comment *string) error {
-	_, err := ws.WPRepo.UpdateWorkout(ctx, repository.UpdateWP{
-		Id:      id,
-		Status:  repository.COMPLETED,
-		Comment: comment,
-	})
+	return ws.UoW.WithinTransaction(ctx, func(txCtx context.Context) error {
+		_, err := ws.WPRepo.UpdateWorkout(txCtx, repository.UpdateWP{
+			Id:      id,
+			Status:  repository.COMPLETED,
+			Comment: comment,
+		})
 
-	if err != nil {
-		return fmt.Errorf("failed to set complete status to workout plan: %w", err)
-	}
+		if err != nil {
+			return fmt.Errorf("failed to set complete status to workout plan: %w", err)
+		}
 
-	return nil
+		if _, err := ws.PRs.DetectPersonalRecords(txCtx, id); err != nil {
+			return fmt.Errorf("failed to detect personal records: %w", err)
+		}
+
+		return nil
+	})
 }
 
 func (ws *WorkoutService) ScheduleWorkout(ctx context.Context, id int, scheduledDate *time.Time) (*WorkoutPlan, error) {
@@ -335,34 +369,126 @@ func (ws *WorkoutService) UpdateExercisePlans(ctx context.Context, workoutId int
 		return nil, fmt.Errorf("failed to fetch workout plan id '%v': %w", workoutId, err)
 	}
 
-	var exercisePlans []repository.ExercisePlan
 	for _, ep := range epsUpdate {
 		if err := ep.Validate(); err != nil {
 			return nil, fmt.Errorf("failed to validate exercise plan id '%v': %w", ep.Id, err)
 		}
+	}
 
-		exercisePlan, err := ws.EPRepo.UpdateExercisePlan(ctx, repository.UpdateEP{
-			Id:          ep.Id,
-			Sets:        &ep.Sets,
-			Repetitions: &ep.Repetitions,
-			Weights:     &ep.Weights,
-			WeightUnit:  (*repository.WeightUnit)(&ep.WeightUnit),
-		})
-
-		if err != nil {
-			return nil, fmt.Errorf("failed to update exercise id '%v': %w", ep.Id, err)
+	// either every exercise plan is updated or none
+	var exercisePlans []repository.ExercisePlan
+	err = ws.UoW.WithinTransaction(ctx, func(txCtx context.Context) error {
+		for _, ep := range epsUpdate {
+			if err := ws.checkExercisePlanOwner(txCtx, workoutId, ep.Id); err != nil {
+				return err
+			}
+
+			exercisePlan, err := ws.EPRepo.UpdateExercisePlan(txCtx, repository.UpdateEP{
+				Id:          ep.Id,
+				Sets:        &ep.Sets,
+				Repetitions: &ep.Repetitions,
+				Weights:     &ep.Weights,
+				WeightUnit:  (*repository.WeightUnit)(&ep.WeightUnit),
+			})
+
+			if err != nil {
+				return fmt.Errorf("failed to update exercise id '%v': %w", ep.Id, err)
+			}
+
+			exercisePlans = append(exercisePlans, *exercisePlan)
 		}
 
-		exercisePlans = append(exercisePlans, *exercisePlan)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
 	return toServiceWP(workoutPlan, exercisePlans), nil
 
 }
 
+// AddExercisePlan inserts a new exercise plan at the 1-based position, 0 appends it at the end
+func (ws *WorkoutService) AddExercisePlan(ctx context.Context, workoutId int, data ExercisePlanCreate, position int) (*WorkoutPlan, error) {
+	if err := data.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate: %w", err)
+	}
+
+	if position < 0 {
+		return nil, apperrors.NewValidationError(apperrors.INVALID_SETTING, "position can not be negative")
+	}
+
+	workout, err := ws.WPRepo.GetWorkoutById(ctx, workoutId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workout plan id '%v': %w", workoutId, err)
+	}
+
+	if err := checkExerciseAccess(ctx, ws.ExerciseRepo, workout.UserId, []ExercisePlanCreate{data}); err != nil {
+		return nil, err
+	}
+
+	_, err = ws.EPRepo.InsertExercisePlan(ctx, repository.CreateEP{
+		ExerciseId:  data.ExerciseId,
+		Sets:        data.Sets,
+		Repetitions: data.Repetitions,
+		Weights:     data.Weights,
+		WeightUnit:  repository.WeightUnit(data.WeightUnit),
+	}, workoutId, position)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add exercise plan to workout plan id '%v': %w", workoutId, err)
+	}
+
+	return ws.GetWorkoutById(ctx, workoutId)
+}
+
+func (ws *WorkoutService) RemoveExercisePlan(ctx context.Context, workoutId int, exercisePlanId int) (*WorkoutPlan, error) {
+	if err := ws.checkExercisePlanOwner(ctx, workoutId, exercisePlanId); err != nil {
+		return nil, err
+	}
+
+	if err := ws.EPRepo.DeleteExercisePlanByID(ctx, exercisePlanId); err != nil {
+		return nil, fmt.Errorf("failed to remove exercise plan id '%v': %w", exercisePlanId, err)
+	}
+
+	return ws.GetWorkoutById(ctx, workoutId)
+}
+
+func (ws *WorkoutService) MoveExercisePlan(ctx context.Context, workoutId int, exercisePlanId int, position int) (*WorkoutPlan, error) {
+	if position < 1 {
+		return nil, apperrors.NewValidationError(apperrors.INVALID_SETTING, "position starts from 1")
+	}
+
+	if err := ws.checkExercisePlanOwner(ctx, workoutId, exercisePlanId); err != nil {
+		return nil, err
+	}
+
+	if _, err := ws.EPRepo.MoveExercisePlan(ctx, exercisePlanId, position); err != nil {
+		return nil, fmt.Errorf("failed to move exercise plan id '%v': %w", exercisePlanId, err)
+	}
+
+	return ws.GetWorkoutById(ctx, workoutId)
+}
+
+// checkExercisePlanOwner makes sure the exercise plan is part of the workout plan, otherwise it is treated as not found
+func (ws *WorkoutService) checkExercisePlanOwner(ctx context.Context, workoutId int, exercisePlanId int) error {
+	exercisePlan, err := ws.EPRepo.GetExercisePlanById(ctx, exercisePlanId)
+	if err != nil {
+		return fmt.Errorf("failed to fetch exercise plan id '%v': %w", exercisePlanId, err)
+	}
+
+	if exercisePlan.WorkoutPlanId != workoutId {
+		return fmt.Errorf("exercise plan id '%v' is not in workout plan id '%v': %w", exercisePlanId, workoutId, apperrors.ErrNotFound)
+	}
+
+	return nil
+}
+
 func (ws *WorkoutService) DeleteWorkoutById(ctx context.Context, id int) error {
-	// already including delete exercise plans
-	err := ws.WPRepo.DeleteWorkoutById(ctx, id)
+	// already including delete exercise plans, the unit of work keeps it in one transaction with anything added here later
+	err := ws.UoW.WithinTransaction(ctx, func(txCtx context.Context) error {
+		return ws.WPRepo.DeleteWorkoutById(txCtx, id)
+	})
 
 	if err != nil {
 		return fmt.Errorf("failed to delete workout plan id '%v': %w", id, err)
@@ -381,6 +507,7 @@ func toServiceEP(ep *repository.ExercisePlan) *ExercisePlan {
 		Id:            ep.Id,
 		ExerciseId:    ep.ExerciseId,
 		WorkoutPlanId: ep.WorkoutPlanId,
+		Position:      ep.Position,
 		Sets:          ep.Sets,
 		Repetitions:   ep.Repetitions,
 		Weights:       ep.Weights,
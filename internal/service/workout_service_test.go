@@ -63,6 +63,23 @@ func (m *MockWorkoutRepository) ListUserWorkouts(ctx context.Context, userID int
 	return args.Get(0).([]repository.WorkoutPlan), args.Error(1)
 }
 
+func (m *MockWorkoutRepository) ListWorkoutPage(ctx context.Context, filter repository.WorkoutPageFilter) ([]repository.WorkoutPlan, *repository.WorkoutCursor, error) {
+	args := m.Called(ctx, filter)
+	var next *repository.WorkoutCursor
+	if args.Get(1) != nil {
+		next = args.Get(1).(*repository.WorkoutCursor)
+	}
+	if args.Get(0) == nil {
+		return nil, next, args.Error(2)
+	}
+	return args.Get(0).([]repository.WorkoutPlan), next, args.Error(2)
+}
+
+func (m *MockWorkoutRepository) MarkOverdueAsMissed(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // MockExercisePlanRepository is a mock implementation of repository.ExercisePlanRepository
 type MockExercisePlanRepository struct {
 	mock.Mock
@@ -89,6 +106,20 @@ func (m *MockExercisePlanRepository) UpdateExercisePlan(ctx context.Context, dat
 	}
 	return args.Get(0).(*repository.ExercisePlan), args.Error(1)
 }
+func (m *MockExercisePlanRepository) InsertExercisePlan(ctx context.Context, data repository.CreateEP, workoutPlanID int, position int) (*repository.ExercisePlan, error) {
+	args := m.Called(ctx, data, workoutPlanID, position)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.ExercisePlan), args.Error(1)
+}
+func (m *MockExercisePlanRepository) MoveExercisePlan(ctx context.Context, id int, position int) (*repository.ExercisePlan, error) {
+	args := m.Called(ctx, id, position)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.ExercisePlan), args.Error(1)
+}
 func (m *MockExercisePlanRepository) DeleteExercisePlanByID(ctx context.Context, id int) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -101,6 +132,50 @@ func (m *MockExercisePlanRepository) ListExercisePlans(ctx context.Context, work
 	return args.Get(0).([]repository.ExercisePlan), args.Error(1)
 }
 
+func (m *MockExercisePlanRepository) ListExercisePlanDetails(ctx context.Context, workoutIds []int) ([]repository.ExercisePlanDetail, error) {
+	args := m.Called(ctx, workoutIds)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.ExercisePlanDetail), args.Error(1)
+}
+
+// MockUnitOfWork runs the function with the caller's context and records a rollback when it fails
+type MockUnitOfWork struct {
+	Calls      int
+	RolledBack bool
+}
+
+func (m *MockUnitOfWork) WithinTransaction(ctx context.Context, fn func(txCtx context.Context) error) error {
+	m.Calls++
+	if err := fn(ctx); err != nil {
+		m.RolledBack = true
+		return err
+	}
+	return nil
+}
+
+// MockPersonalRecordService is a mock implementation of service.PersonalRecordServiceInterface
+type MockPersonalRecordService struct {
+	mock.Mock
+}
+
+func (m *MockPersonalRecordService) DetectPersonalRecords(ctx context.Context, workoutId int) ([]service.PersonalRecord, error) {
+	args := m.Called(ctx, workoutId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.PersonalRecord), args.Error(1)
+}
+
+func (m *MockPersonalRecordService) ListPersonalRecords(ctx context.Context, userId int, exerciseId *int) ([]service.PersonalRecord, error) {
+	args := m.Called(ctx, userId, exerciseId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.PersonalRecord), args.Error(1)
+}
+
 // --- Tests ---
 
 func TestWorkoutService_CreateWorkout(t *testing.T) {
@@ -256,7 +331,7 @@ func TestWorkoutService_CreateWorkout(t *testing.T) {
 			tt.mockWPRepoSetup(mockWPRepo)
 			tt.mockEPRepoSetup(mockEPRepo)
 
-			workoutService := service.NewWPService(mockWPRepo, mockEPRepo)
+			workoutService := service.NewWPService(mockWPRepo, mockEPRepo, globalExercises(), new(MockUnitOfWork), nil)
 			workout, err := workoutService.CreateWorkout(ctx, tt.input)
 
 			if tt.expectedErrorType != nil {
@@ -387,7 +462,7 @@ func TestWorkoutService_GetWorkoutById(t *testing.T) {
 			tt.mockWPRepoSetup(mockWPRepo)
 			tt.mockEPRepoSetup(mockEPRepo)
 
-			workoutService := service.NewWPService(mockWPRepo, mockEPRepo)
+			workoutService := service.NewWPService(mockWPRepo, mockEPRepo, globalExercises(), new(MockUnitOfWork), nil)
 			workout, err := workoutService.GetWorkoutById(ctx, tt.workoutID)
 
 			if tt.expectedErrorType != nil {
@@ -502,7 +577,7 @@ func TestWorkoutService_ListWorkouts(t *testing.T) {
 			tt.mockWPRepoSetup(mockWPRepo)
 			tt.mockEPRepoSetup(mockEPRepo)
 
-			workoutService := service.NewWPService(mockWPRepo, mockEPRepo)
+			workoutService := service.NewWPService(mockWPRepo, mockEPRepo, globalExercises(), new(MockUnitOfWork), nil)
 			workouts, err := workoutService.ListWorkouts(ctx, tt.userID)
 
 			if tt.expectedErrorType != nil {
@@ -595,7 +670,7 @@ func TestWorkoutService_ListWorkoutsByStatus(t *testing.T) {
 			tt.mockWPRepoSetup(mockWPRepo)
 			tt.mockEPRepoSetup(mockEPRepo)
 
-			workoutService := service.NewWPService(mockWPRepo, mockEPRepo)
+			workoutService := service.NewWPService(mockWPRepo, mockEPRepo, globalExercises(), new(MockUnitOfWork), nil)
 			workouts, err := workoutService.ListWorkoutsByStatus(ctx, tt.userID, tt.status, tt.asc)
 
 			if tt.expectedErrorType != nil {
@@ -624,7 +699,9 @@ func TestWorkoutService_CompleteWorkout(t *testing.T) {
 		workoutID         int
 		comment           *string
 		mockWPRepoSetup   func(*MockWorkoutRepository)
+		mockPRSetup       func(*MockPersonalRecordService)
 		expectedErrorType error
+		expectRollback    bool
 	}{
 		{
 			name:      "Successful completion with comment",
@@ -637,6 +714,9 @@ func TestWorkoutService_CompleteWorkout(t *testing.T) {
 					Comment: &comment,
 				}).Return(&repository.WorkoutPlan{}, nil).Once()
 			},
+			mockPRSetup: func(mps *MockPersonalRecordService) {
+				mps.On("DetectPersonalRecords", ctx, workoutID).Return([]service.PersonalRecord{{Id: 1, Kind: service.PR_WEIGHT, Value: 100}}, nil).Once()
+			},
 			expectedErrorType: nil,
 		},
 		{
@@ -650,6 +730,9 @@ func TestWorkoutService_CompleteWorkout(t *testing.T) {
 					Comment: nil,
 				}).Return(&repository.WorkoutPlan{}, nil).Once()
 			},
+			mockPRSetup: func(mps *MockPersonalRecordService) {
+				mps.On("DetectPersonalRecords", ctx, workoutID).Return(nil, nil).Once()
+			},
 			expectedErrorType: nil,
 		},
 		{
@@ -659,7 +742,9 @@ func TestWorkoutService_CompleteWorkout(t *testing.T) {
 			mockWPRepoSetup: func(mwr *MockWorkoutRepository) {
 				mwr.On("UpdateWorkout", ctx, mock.AnythingOfType("repository.UpdateWP")).Return(nil, apperrors.ErrNotFound).Once()
 			},
+			mockPRSetup:       func(mps *MockPersonalRecordService) {},
 			expectedErrorType: errors.New("failed to set complete status to workout plan: resource not found"),
+			expectRollback:    true,
 		},
 		{
 			name:      "DB error updating workout",
@@ -668,7 +753,22 @@ func TestWorkoutService_CompleteWorkout(t *testing.T) {
 			mockWPRepoSetup: func(mwr *MockWorkoutRepository) {
 				mwr.On("UpdateWorkout", ctx, mock.AnythingOfType("repository.UpdateWP")).Return(nil, errors.New("db update error")).Once()
 			},
+			mockPRSetup:       func(mps *MockPersonalRecordService) {},
 			expectedErrorType: errors.New("failed to set complete status to workout plan: db update error"),
+			expectRollback:    true,
+		},
+		{
+			name:      "Error detecting personal records rolls back completion",
+			workoutID: workoutID,
+			comment:   nil,
+			mockWPRepoSetup: func(mwr *MockWorkoutRepository) {
+				mwr.On("UpdateWorkout", ctx, mock.AnythingOfType("repository.UpdateWP")).Return(&repository.WorkoutPlan{}, nil).Once()
+			},
+			mockPRSetup: func(mps *MockPersonalRecordService) {
+				mps.On("DetectPersonalRecords", ctx, workoutID).Return(nil, errors.New("db insert error")).Once()
+			},
+			expectedErrorType: errors.New("failed to detect personal records: db insert error"),
+			expectRollback:    true,
 		},
 	}
 
@@ -676,10 +776,13 @@ func TestWorkoutService_CompleteWorkout(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockWPRepo := new(MockWorkoutRepository)
 			mockEPRepo := new(MockExercisePlanRepository) // Still need to pass it, even if not used
+			mockPRService := new(MockPersonalRecordService)
+			mockUoW := new(MockUnitOfWork)
 
 			tt.mockWPRepoSetup(mockWPRepo)
+			tt.mockPRSetup(mockPRService)
 
-			workoutService := service.NewWPService(mockWPRepo, mockEPRepo)
+			workoutService := service.NewWPService(mockWPRepo, mockEPRepo, globalExercises(), mockUoW, mockPRService)
 			err := workoutService.CompleteWorkout(ctx, tt.workoutID, tt.comment)
 
 			if tt.expectedErrorType != nil {
@@ -688,9 +791,12 @@ func TestWorkoutService_CompleteWorkout(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 			}
+			assert.Equal(t, 1, mockUoW.Calls)
+			assert.Equal(t, tt.expectRollback, mockUoW.RolledBack)
 
 			mockWPRepo.AssertExpectations(t)
 			mockEPRepo.AssertExpectations(t)
+			mockPRService.AssertExpectations(t)
 		})
 	}
 }
@@ -827,7 +933,7 @@ func TestWorkoutService_ScheduleWorkout(t *testing.T) {
 			tt.mockWPRepoSetup(mockWPRepo)
 			tt.mockEPRepoSetup(mockEPRepo)
 
-			workoutService := service.NewWPService(mockWPRepo, mockEPRepo)
+			workoutService := service.NewWPService(mockWPRepo, mockEPRepo, globalExercises(), new(MockUnitOfWork), nil)
 			workout, err := workoutService.ScheduleWorkout(ctx, tt.workoutID, tt.scheduledDate)
 
 			if tt.expectedErrorType != nil {
@@ -889,6 +995,8 @@ func TestWorkoutService_UpdateExercisePlans(t *testing.T) {
 				sets1, reps1, weights1, unit1 := 5, 15, float32(60), repository.KG
 				sets2, reps2, weights2, unit2 := 6, 10, float32(80), repository.LBS
 
+				mer.On("GetExercisePlanById", ctx, 10).Return(&repository.ExercisePlan{Id: 10, WorkoutPlanId: workoutID}, nil).Once()
+				mer.On("GetExercisePlanById", ctx, 20).Return(&repository.ExercisePlan{Id: 20, WorkoutPlanId: workoutID}, nil).Once()
 				mer.On("UpdateExercisePlan", ctx, repository.UpdateEP{
 					Id: 10, Sets: &sets1, Repetitions: &reps1, Weights: &weights1, WeightUnit: &unit1,
 				}).Return(&repository.ExercisePlan{
@@ -964,6 +1072,7 @@ func TestWorkoutService_UpdateExercisePlans(t *testing.T) {
 			},
 			mockEPRepoSetup: func(mer *MockExercisePlanRepository) {
 				sets, reps, weights, unit := 5, 15, float32(60), repository.KG
+				mer.On("GetExercisePlanById", ctx, 10).Return(&repository.ExercisePlan{Id: 10, WorkoutPlanId: workoutID}, nil).Once()
 				mer.On("UpdateExercisePlan", ctx, repository.UpdateEP{
 					Id: 10, Sets: &sets, Repetitions: &reps, Weights: &weights, WeightUnit: &unit,
 				}).Return(nil, errors.New("db error updating exercise plan")).Once()
@@ -971,6 +1080,22 @@ func TestWorkoutService_UpdateExercisePlans(t *testing.T) {
 			expectedWorkout:   nil,
 			expectedErrorType: errors.New("failed to update exercise id '10': db error updating exercise plan"),
 		},
+		{
+			name:      "Exercise plan of another workout plan",
+			workoutID: workoutID,
+			epsUpdate: []service.ExercisePlanUpdate{
+				{Id: 30, Sets: 5, Repetitions: 15, Weights: 60, WeightUnit: service.KG},
+			},
+			mockWPRepoSetup: func(mwr *MockWorkoutRepository) {
+				mwr.On("GetWorkoutById", ctx, workoutID).Return(&repository.WorkoutPlan{Id: workoutID, UserId: 100}, nil).Once()
+			},
+			mockEPRepoSetup: func(mer *MockExercisePlanRepository) {
+				// the exercise plan belongs to a workout plan of someone else, it must not be updated
+				mer.On("GetExercisePlanById", ctx, 30).Return(&repository.ExercisePlan{Id: 30, WorkoutPlanId: 2}, nil).Once()
+			},
+			expectedWorkout:   nil,
+			expectedErrorType: errors.New("exercise plan id '30' is not in workout plan id '1': resource not found"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -981,7 +1106,7 @@ func TestWorkoutService_UpdateExercisePlans(t *testing.T) {
 			tt.mockWPRepoSetup(mockWPRepo)
 			tt.mockEPRepoSetup(mockEPRepo)
 
-			workoutService := service.NewWPService(mockWPRepo, mockEPRepo)
+			workoutService := service.NewWPService(mockWPRepo, mockEPRepo, globalExercises(), new(MockUnitOfWork), nil)
 			workout, err := workoutService.UpdateExercisePlans(ctx, tt.workoutID, tt.epsUpdate)
 
 			if tt.expectedErrorType != nil {
@@ -1062,7 +1187,7 @@ func TestWorkoutService_DeleteWorkoutById(t *testing.T) {
 
 			tt.mockWPRepoSetup(mockWPRepo)
 
-			workoutService := service.NewWPService(mockWPRepo, mockEPRepo)
+			workoutService := service.NewWPService(mockWPRepo, mockEPRepo, globalExercises(), new(MockUnitOfWork), nil)
 			err := workoutService.DeleteWorkoutById(ctx, tt.workoutID)
 
 			if tt.expectedErrorType != nil {
@@ -1077,3 +1202,313 @@ func TestWorkoutService_DeleteWorkoutById(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkoutService_TransactionRollback(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	scheduledDate := now.Add(24 * time.Hour)
+
+	t.Run("create rolls back when an exercise does not exist", func(t *testing.T) {
+		mockWPRepo := new(MockWorkoutRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		uow := new(MockUnitOfWork)
+
+		mockWPRepo.On("CreateWorkout", ctx, mock.AnythingOfType("repository.CreateWP")).Return(&repository.WorkoutPlan{
+			Id: 1, UserId: 100, Status: repository.PENDING, ScheduledDate: scheduledDate, CreatedAt: now, UpdatedAt: now,
+		}, nil).Once()
+		mockEPRepo.On("CreateExercisePlan", ctx, repository.CreateEP{ExerciseId: 1, Sets: 3, Repetitions: 10, Weights: 50, WeightUnit: repository.KG}, 1).
+			Return(&repository.ExercisePlan{Id: 10, ExerciseId: 1, WorkoutPlanId: 1, Sets: 3, Repetitions: 10, Weights: 50, WeightUnit: repository.KG}, nil).Once()
+		mockEPRepo.On("CreateExercisePlan", ctx, repository.CreateEP{ExerciseId: 9999, Sets: 3, Repetitions: 10, Weights: 50, WeightUnit: repository.KG}, 1).
+			Return(nil, apperrors.ErrForeignKeyViolation).Once()
+
+		workoutService := service.NewWPService(mockWPRepo, mockEPRepo, globalExercises(), uow, nil)
+		workout, err := workoutService.CreateWorkout(ctx, service.WorkoutPlanCreate{
+			UserId:        100,
+			ScheduledDate: &scheduledDate,
+			ExercisePlans: []service.ExercisePlanCreate{
+				{ExerciseId: 1, Sets: 3, Repetitions: 10, Weights: 50, WeightUnit: service.KG},
+				{ExerciseId: 9999, Sets: 3, Repetitions: 10, Weights: 50, WeightUnit: service.KG},
+			},
+		})
+
+		assert.Nil(t, workout)
+		assert.True(t, errors.Is(err, apperrors.ErrForeignKeyViolation))
+		assert.Equal(t, 1, uow.Calls)
+		assert.True(t, uow.RolledBack)
+
+		mockWPRepo.AssertExpectations(t)
+		mockEPRepo.AssertExpectations(t)
+	})
+
+	t.Run("update exercise plans rolls back on partial failure", func(t *testing.T) {
+		mockWPRepo := new(MockWorkoutRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		uow := new(MockUnitOfWork)
+
+		mockWPRepo.On("GetWorkoutById", ctx, 1).Return(&repository.WorkoutPlan{Id: 1, UserId: 100}, nil).Once()
+		mockEPRepo.On("GetExercisePlanById", ctx, 10).Return(&repository.ExercisePlan{Id: 10, WorkoutPlanId: 1}, nil).Once()
+		mockEPRepo.On("GetExercisePlanById", ctx, 20).Return(&repository.ExercisePlan{Id: 20, WorkoutPlanId: 1}, nil).Once()
+		mockEPRepo.On("UpdateExercisePlan", ctx, mock.MatchedBy(func(ep repository.UpdateEP) bool { return ep.Id == 10 })).
+			Return(&repository.ExercisePlan{Id: 10, WorkoutPlanId: 1}, nil).Once()
+		mockEPRepo.On("UpdateExercisePlan", ctx, mock.MatchedBy(func(ep repository.UpdateEP) bool { return ep.Id == 20 })).
+			Return(nil, apperrors.ErrNotFound).Once()
+
+		workoutService := service.NewWPService(mockWPRepo, mockEPRepo, globalExercises(), uow, nil)
+		workout, err := workoutService.UpdateExercisePlans(ctx, 1, []service.ExercisePlanUpdate{
+			{Id: 10, Sets: 5, Repetitions: 5, Weights: 100, WeightUnit: service.KG},
+			{Id: 20, Sets: 5, Repetitions: 5, Weights: 100, WeightUnit: service.KG},
+		})
+
+		assert.Nil(t, workout)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+		assert.True(t, uow.RolledBack)
+
+		mockWPRepo.AssertExpectations(t)
+		mockEPRepo.AssertExpectations(t)
+	})
+
+	t.Run("validation error never opens a transaction", func(t *testing.T) {
+		mockWPRepo := new(MockWorkoutRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		uow := new(MockUnitOfWork)
+
+		workoutService := service.NewWPService(mockWPRepo, mockEPRepo, globalExercises(), uow, nil)
+		_, err := workoutService.CreateWorkout(ctx, service.WorkoutPlanCreate{UserId: 100})
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, uow.Calls)
+	})
+}
+
+func TestWorkoutService_CreateWorkout_ExerciseAccess(t *testing.T) {
+	ctx := context.Background()
+	scheduledDate := time.Now().UTC().Add(24 * time.Hour)
+	userID := 100
+
+	tests := []struct {
+		name     string
+		exercise *repository.Exercise
+		check    func(t *testing.T, err error)
+	}{
+		{
+			name:     "Custom exercise of another user",
+			exercise: &repository.Exercise{Id: 7, Name: "Secret Lift", OwnerId: sql.NullInt64{Int64: 200, Valid: true}},
+			check: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, apperrors.ErrForbidden))
+			},
+		},
+		{
+			name:     "Archived exercise",
+			exercise: &repository.Exercise{Id: 7, Name: "Old Lift", OwnerId: sql.NullInt64{Int64: int64(userID), Valid: true}, ArchivedAt: sql.NullTime{Time: time.Now(), Valid: true}},
+			check: func(t *testing.T, err error) {
+				var validationErr *apperrors.ValidationError
+				assert.True(t, errors.As(err, &validationErr))
+				assert.Equal(t, apperrors.INVALID_ID, validationErr.Field)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockWPRepo := new(MockWorkoutRepository)
+			mockExerRepo := new(MockExerciseRepository)
+			uow := new(MockUnitOfWork)
+			mockExerRepo.On("GetExerciseById", ctx, 7).Return(tt.exercise, nil).Once()
+
+			workoutService := service.NewWPService(mockWPRepo, new(MockExercisePlanRepository), mockExerRepo, uow, nil)
+			workout, err := workoutService.CreateWorkout(ctx, service.WorkoutPlanCreate{
+				UserId:        userID,
+				ScheduledDate: &scheduledDate,
+				ExercisePlans: []service.ExercisePlanCreate{
+					{ExerciseId: 7, Sets: 3, Repetitions: 10, Weights: 50, WeightUnit: service.KG},
+				},
+			})
+
+			assert.Nil(t, workout)
+			tt.check(t, err)
+			assert.Equal(t, 0, uow.Calls)
+			mockWPRepo.AssertNotCalled(t, "CreateWorkout", mock.Anything, mock.Anything)
+			mockExerRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Own custom exercise", func(t *testing.T) {
+		mockWPRepo := new(MockWorkoutRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		mockExerRepo := new(MockExerciseRepository)
+		mockExerRepo.On("GetExerciseById", ctx, 7).Return(&repository.Exercise{Id: 7, OwnerId: sql.NullInt64{Int64: int64(userID), Valid: true}}, nil).Once()
+		mockWPRepo.On("CreateWorkout", ctx, mock.AnythingOfType("repository.CreateWP")).Return(&repository.WorkoutPlan{Id: 1, UserId: userID, ScheduledDate: scheduledDate}, nil).Once()
+		mockEPRepo.On("CreateExercisePlan", ctx, mock.AnythingOfType("repository.CreateEP"), 1).Return(&repository.ExercisePlan{Id: 10, ExerciseId: 7, WorkoutPlanId: 1}, nil).Twice()
+
+		workoutService := service.NewWPService(mockWPRepo, mockEPRepo, mockExerRepo, new(MockUnitOfWork), nil)
+		workout, err := workoutService.CreateWorkout(ctx, service.WorkoutPlanCreate{
+			UserId:        userID,
+			ScheduledDate: &scheduledDate,
+			ExercisePlans: []service.ExercisePlanCreate{
+				{ExerciseId: 7, Sets: 3, Repetitions: 10, Weights: 50, WeightUnit: service.KG},
+				{ExerciseId: 7, Sets: 1, Repetitions: 5, Weights: 60, WeightUnit: service.KG},
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, workout.ExercisePlans, 2)
+		// the same exercise is only looked up once
+		mockExerRepo.AssertExpectations(t)
+	})
+}
+
+func TestWorkoutService_AddExercisePlan(t *testing.T) {
+	ctx := context.Background()
+	workoutID := 1
+	data := service.ExercisePlanCreate{ExerciseId: 5, Sets: 3, Repetitions: 8, Weights: 40, WeightUnit: service.KG}
+	repoData := repository.CreateEP{ExerciseId: 5, Sets: 3, Repetitions: 8, Weights: 40, WeightUnit: repository.KG}
+
+	t.Run("inserts at position and returns the reordered workout", func(t *testing.T) {
+		mockWPRepo := new(MockWorkoutRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+
+		mockEPRepo.On("InsertExercisePlan", ctx, repoData, workoutID, 1).
+			Return(&repository.ExercisePlan{Id: 30, ExerciseId: 5, WorkoutPlanId: workoutID, Position: 1}, nil).Once()
+		mockWPRepo.On("GetWorkoutById", ctx, workoutID).Return(&repository.WorkoutPlan{Id: workoutID, UserId: 100}, nil).Twice()
+		mockEPRepo.On("ListExercisePlans", ctx, workoutID).Return([]repository.ExercisePlan{
+			{Id: 30, ExerciseId: 5, WorkoutPlanId: workoutID, Position: 1},
+			{Id: 10, ExerciseId: 2, WorkoutPlanId: workoutID, Position: 2},
+		}, nil).Once()
+
+		workoutService := service.NewWPService(mockWPRepo, mockEPRepo, globalExercises(), new(MockUnitOfWork), nil)
+		workout, err := workoutService.AddExercisePlan(ctx, workoutID, data, 1)
+		assert.NoError(t, err)
+		assert.Len(t, workout.ExercisePlans, 2)
+		assert.Equal(t, 30, workout.ExercisePlans[0].Id)
+		assert.Equal(t, 1, workout.ExercisePlans[0].Position)
+
+		mockWPRepo.AssertExpectations(t)
+		mockEPRepo.AssertExpectations(t)
+	})
+
+	t.Run("negative position", func(t *testing.T) {
+		mockEPRepo := new(MockExercisePlanRepository)
+
+		workoutService := service.NewWPService(new(MockWorkoutRepository), mockEPRepo, globalExercises(), new(MockUnitOfWork), nil)
+		_, err := workoutService.AddExercisePlan(ctx, workoutID, data, -1)
+
+		var validationErr *apperrors.ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, apperrors.INVALID_SETTING, validationErr.Field)
+		mockEPRepo.AssertNotCalled(t, "InsertExercisePlan", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown exercise", func(t *testing.T) {
+		mockWPRepo := new(MockWorkoutRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		mockExerRepo := new(MockExerciseRepository)
+		mockWPRepo.On("GetWorkoutById", ctx, workoutID).Return(&repository.WorkoutPlan{Id: workoutID, UserId: 100}, nil).Once()
+		mockExerRepo.On("GetExerciseById", ctx, 5).Return(nil, apperrors.ErrNotFound).Once()
+
+		workoutService := service.NewWPService(mockWPRepo, mockEPRepo, mockExerRepo, new(MockUnitOfWork), nil)
+		workout, err := workoutService.AddExercisePlan(ctx, workoutID, data, 0)
+		assert.Nil(t, workout)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+
+		mockEPRepo.AssertNotCalled(t, "InsertExercisePlan", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("custom exercise of another user", func(t *testing.T) {
+		mockWPRepo := new(MockWorkoutRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+		mockExerRepo := new(MockExerciseRepository)
+		mockWPRepo.On("GetWorkoutById", ctx, workoutID).Return(&repository.WorkoutPlan{Id: workoutID, UserId: 100}, nil).Once()
+		mockExerRepo.On("GetExerciseById", ctx, 5).Return(&repository.Exercise{Id: 5, OwnerId: sql.NullInt64{Int64: 200, Valid: true}}, nil).Once()
+
+		workoutService := service.NewWPService(mockWPRepo, mockEPRepo, mockExerRepo, new(MockUnitOfWork), nil)
+		workout, err := workoutService.AddExercisePlan(ctx, workoutID, data, 0)
+		assert.Nil(t, workout)
+		assert.True(t, errors.Is(err, apperrors.ErrForbidden))
+
+		mockEPRepo.AssertNotCalled(t, "InsertExercisePlan", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestWorkoutService_RemoveExercisePlan(t *testing.T) {
+	ctx := context.Background()
+	workoutID := 1
+
+	t.Run("success", func(t *testing.T) {
+		mockWPRepo := new(MockWorkoutRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+
+		mockEPRepo.On("GetExercisePlanById", ctx, 10).Return(&repository.ExercisePlan{Id: 10, WorkoutPlanId: workoutID}, nil).Once()
+		mockEPRepo.On("DeleteExercisePlanByID", ctx, 10).Return(nil).Once()
+		mockWPRepo.On("GetWorkoutById", ctx, workoutID).Return(&repository.WorkoutPlan{Id: workoutID}, nil).Once()
+		mockEPRepo.On("ListExercisePlans", ctx, workoutID).Return([]repository.ExercisePlan{}, nil).Once()
+
+		workoutService := service.NewWPService(mockWPRepo, mockEPRepo, globalExercises(), new(MockUnitOfWork), nil)
+		workout, err := workoutService.RemoveExercisePlan(ctx, workoutID, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, workout.ExercisePlans)
+
+		mockWPRepo.AssertExpectations(t)
+		mockEPRepo.AssertExpectations(t)
+	})
+
+	t.Run("exercise plan of another workout", func(t *testing.T) {
+		mockEPRepo := new(MockExercisePlanRepository)
+		mockEPRepo.On("GetExercisePlanById", ctx, 10).Return(&repository.ExercisePlan{Id: 10, WorkoutPlanId: 2}, nil).Once()
+
+		workoutService := service.NewWPService(new(MockWorkoutRepository), mockEPRepo, globalExercises(), new(MockUnitOfWork), nil)
+		workout, err := workoutService.RemoveExercisePlan(ctx, workoutID, 10)
+		assert.Nil(t, workout)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+
+		mockEPRepo.AssertExpectations(t)
+		mockEPRepo.AssertNotCalled(t, "DeleteExercisePlanByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWorkoutService_MoveExercisePlan(t *testing.T) {
+	ctx := context.Background()
+	workoutID := 1
+
+	t.Run("success", func(t *testing.T) {
+		mockWPRepo := new(MockWorkoutRepository)
+		mockEPRepo := new(MockExercisePlanRepository)
+
+		mockEPRepo.On("GetExercisePlanById", ctx, 20).Return(&repository.ExercisePlan{Id: 20, WorkoutPlanId: workoutID, Position: 2}, nil).Once()
+		mockEPRepo.On("MoveExercisePlan", ctx, 20, 1).Return(&repository.ExercisePlan{Id: 20, WorkoutPlanId: workoutID, Position: 1}, nil).Once()
+		mockWPRepo.On("GetWorkoutById", ctx, workoutID).Return(&repository.WorkoutPlan{Id: workoutID}, nil).Once()
+		mockEPRepo.On("ListExercisePlans", ctx, workoutID).Return([]repository.ExercisePlan{
+			{Id: 20, WorkoutPlanId: workoutID, Position: 1},
+			{Id: 10, WorkoutPlanId: workoutID, Position: 2},
+		}, nil).Once()
+
+		workoutService := service.NewWPService(mockWPRepo, mockEPRepo, globalExercises(), new(MockUnitOfWork), nil)
+		workout, err := workoutService.MoveExercisePlan(ctx, workoutID, 20, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 20, workout.ExercisePlans[0].Id)
+
+		mockWPRepo.AssertExpectations(t)
+		mockEPRepo.AssertExpectations(t)
+	})
+
+	t.Run("position must start from 1", func(t *testing.T) {
+		mockEPRepo := new(MockExercisePlanRepository)
+
+		workoutService := service.NewWPService(new(MockWorkoutRepository), mockEPRepo, globalExercises(), new(MockUnitOfWork), nil)
+		_, err := workoutService.MoveExercisePlan(ctx, workoutID, 20, 0)
+
+		var validationErr *apperrors.ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		mockEPRepo.AssertNotCalled(t, "GetExercisePlanById", mock.Anything, mock.Anything)
+	})
+
+	t.Run("exercise plan not found", func(t *testing.T) {
+		mockEPRepo := new(MockExercisePlanRepository)
+		mockEPRepo.On("GetExercisePlanById", ctx, 99).Return(nil, apperrors.ErrNotFound).Once()
+
+		workoutService := service.NewWPService(new(MockWorkoutRepository), mockEPRepo, globalExercises(), new(MockUnitOfWork), nil)
+		_, err := workoutService.MoveExercisePlan(ctx, workoutID, 99, 1)
+		assert.True(t, errors.Is(err, apperrors.ErrNotFound))
+
+		mockEPRepo.AssertExpectations(t)
+	})
+}
@@ -13,10 +13,17 @@ import (
 
 const cachePrefix = "jwtblacklist:"
 
+const sessionCachePrefix = "jwtsessionrevoked:"
+
 type Payload struct {
-	Id    *int   `json:"id,omitempty"`
-	Name  string `json:"name,omitempty"`
-	Email string `json:"email,omitempty"`
+	Id        *int   `json:"id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Email     string `json:"email,omitempty"`
+	SessionId string `json:"sid,omitempty"`
+	// EmailVerified is how the route policy for unverified users is enforced without a lookup
+	EmailVerified bool `json:"email_verified"`
+	// Role is checked by the role middleware, a changed role applies from the next refresh
+	Role string `json:"role,omitempty"`
 }
 
 type Claims struct {
@@ -29,35 +36,43 @@ type TokenInterface interface {
 	ParseToken(ctx context.Context, tokenString string) (*Claims, error)
 	BlacklistToken(ctx context.Context, jti string, expirationTIme time.Time) error
 	CheckBlacklist(ctx context.Context, jti string) (bool, error)
+	RevokeSession(ctx context.Context, sessionId string) error
+	CheckSessionRevoked(ctx context.Context, sessionId string) (bool, error)
+	JWKS() JWKSet
 }
 
 type JWTService struct {
-	signingMethod jwt.SigningMethod
-	cache         cache.CacheInterface
-	secretKey     string
+	keys  *KeySet
+	cache cache.CacheInterface
+	ttl   time.Duration
 }
 
-func NewJWTService(sm jwt.SigningMethod, cache cache.CacheInterface, secretKey string) TokenInterface {
+// NewJWTService signs access tokens valid for ttl, sessions are kept alive with refresh tokens
+func NewJWTService(keys *KeySet, cache cache.CacheInterface, ttl time.Duration) TokenInterface {
 	return &JWTService{
-		signingMethod: sm,
-		cache:         cache,
-		secretKey:     secretKey,
+		keys:  keys,
+		cache: cache,
+		ttl:   ttl,
 	}
 }
 
 func (js *JWTService) GenerateToken(claims Claims) (string, error) {
 
-	expirationTime := time.Now().UTC().Add(24 * time.Hour)
+	expirationTime := time.Now().UTC().Add(js.ttl)
 	issuedAtTIme := time.Now().UTC()
-	tokenID := uuid.New().String()
 
 	claims.ExpiresAt = jwt.NewNumericDate(expirationTime)
 	claims.IssuedAt = jwt.NewNumericDate(issuedAtTIme)
-	claims.ID = tokenID
+	// the caller may pick the jti to record it with the session
+	if claims.ID == "" {
+		claims.ID = uuid.New().String()
+	}
 
-	token := jwt.NewWithClaims(js.signingMethod, claims)
+	key := js.keys.Signing()
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.Kid
 
-	signedToken, err := token.SignedString([]byte(js.secretKey))
+	signedToken, err := token.SignedString(key.signingKey())
 
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
@@ -67,15 +82,22 @@ func (js *JWTService) GenerateToken(claims Claims) (string, error) {
 
 }
 
-// ParseToken
+// ParseToken verifies the token with the key named by its kid header, the algorithm has to be
+// the one of that key so a public key can never be used as an HMAC secret
 func (js *JWTService) ParseToken(ctx context.Context, tokenString string) (*Claims, error) {
 
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(t *jwt.Token) (any, error) {
-		if t.Method != js.signingMethod {
+		kid, _ := t.Header["kid"].(string)
+		key, ok := js.keys.Lookup(kid)
+		if !ok {
+			return nil, fmt.Errorf("unknown key id: %v", t.Header["kid"])
+		}
+
+		if t.Method.Alg() != key.Method.Alg() {
 			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
 		}
 
-		return []byte(js.secretKey), nil
+		return key.verifyingKey(), nil
 	})
 
 	if err != nil {
@@ -117,3 +139,30 @@ func (js *JWTService) CheckBlacklist(ctx context.Context, jti string) (bool, err
 
 	return true, nil
 }
+
+// RevokeSession makes the access tokens of a session invalid. Tokens of a session can not outlive
+// the access token lifetime, so the mark only has to be kept that long.
+func (js *JWTService) RevokeSession(ctx context.Context, sessionId string) error {
+	key := sessionCachePrefix + sessionId
+	duration := js.ttl
+	if err := js.cache.SaveCache(ctx, key, sessionId, &duration); err != nil {
+		return fmt.Errorf("error saving cache: %w", err)
+	}
+
+	log.Printf("Session '%s' revoked\n", sessionId)
+	return nil
+}
+
+func (js *JWTService) CheckSessionRevoked(ctx context.Context, sessionId string) (bool, error) {
+	found, err := js.cache.ExistCache(ctx, sessionCachePrefix+sessionId)
+	if err != nil {
+		return false, fmt.Errorf("error checking cache: %w", err)
+	}
+
+	return found, nil
+}
+
+// JWKS returns the public keys tokens can be verified with, for /.well-known/jwks.json
+func (js *JWTService) JWKS() JWKSet {
+	return js.keys.JWKS()
+}
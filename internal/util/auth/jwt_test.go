@@ -0,0 +1,172 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/elliptic"
+	"testing"
+	"time"
+	"workout-tracker-api/internal/util/auth"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func newJWTService(t *testing.T, signing *auth.Key, retired ...*auth.Key) auth.TokenInterface {
+	t.Helper()
+	ks, err := auth.NewKeySet(signing, retired...)
+	noError(t, err)
+	return auth.NewJWTService(ks, nil, 15*time.Minute)
+}
+
+func testClaims() auth.Claims {
+	id := 7
+	return auth.Claims{
+		Payload: auth.Payload{Id: &id, Email: "jane@example.com", SessionId: "session-1", Role: "user"},
+	}
+}
+
+// headerOf reads the header of a token without verifying it
+func headerOf(t *testing.T, token string) map[string]any {
+	t.Helper()
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &auth.Claims{})
+	noError(t, err)
+	return parsed.Header
+}
+
+func TestJWTService_RoundTrip(t *testing.T) {
+	hmacKey, err := auth.NewHMACKey("", "secret")
+	noError(t, err)
+
+	tests := []struct {
+		name string
+		key  *auth.Key
+	}{
+		{"RSA", parseKey(t, "rsa", pkcs8PEM(t, newRSAKey(t)))},
+		{"EC", parseKey(t, "ec", pkcs8PEM(t, newECKey(t, elliptic.P256())))},
+		{"Ed25519", parseKey(t, "ed", pkcs8PEM(t, newEd25519Key(t)))},
+		{"HMAC", hmacKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			js := newJWTService(t, tt.key)
+
+			token, err := js.GenerateToken(testClaims())
+			noError(t, err)
+
+			header := headerOf(t, token)
+			assert.Equal(t, tt.key.Kid, header["kid"])
+			assert.Equal(t, tt.key.Method.Alg(), header["alg"])
+
+			claims, err := js.ParseToken(context.Background(), token)
+			noError(t, err)
+			assert.Equal(t, 7, *claims.Id)
+			assert.Equal(t, "session-1", claims.SessionId)
+			assert.NotEmpty(t, claims.ID)
+			assert.WithinDuration(t, time.Now().Add(15*time.Minute), claims.ExpiresAt.Time, time.Minute)
+		})
+	}
+}
+
+func TestJWTService_ParseToken(t *testing.T) {
+	ctx := context.Background()
+	rsaKey := newRSAKey(t)
+	current := parseKey(t, "current", pkcs8PEM(t, newECKey(t, elliptic.P256())))
+	old := parseKey(t, "old", pkcs8PEM(t, rsaKey))
+
+	t.Run("token signed by a retired key", func(t *testing.T) {
+		token, err := newJWTService(t, old).GenerateToken(testClaims())
+		noError(t, err)
+
+		// after the rotation only the public part of the old key is configured
+		retired := parseKey(t, "old", publicPEM(t, &rsaKey.PublicKey))
+		claims, err := newJWTService(t, current, retired).ParseToken(ctx, token)
+		noError(t, err)
+		assert.Equal(t, 7, *claims.Id)
+	})
+
+	t.Run("token of a key that was dropped", func(t *testing.T) {
+		token, err := newJWTService(t, old).GenerateToken(testClaims())
+		noError(t, err)
+
+		_, err = newJWTService(t, current).ParseToken(ctx, token)
+		assert.ErrorContains(t, err, "unknown key id")
+	})
+
+	t.Run("unknown kid", func(t *testing.T) {
+		stranger := parseKey(t, "stranger", pkcs8PEM(t, newECKey(t, elliptic.P256())))
+		token, err := newJWTService(t, stranger).GenerateToken(testClaims())
+		noError(t, err)
+
+		_, err = newJWTService(t, current).ParseToken(ctx, token)
+		assert.ErrorContains(t, err, "unknown key id")
+	})
+
+	t.Run("token without a kid", func(t *testing.T) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodES256, testClaims()).SignedString(current.Private)
+		noError(t, err)
+
+		_, err = newJWTService(t, current).ParseToken(ctx, token)
+		assert.ErrorContains(t, err, "unknown key id")
+	})
+
+	t.Run("alg does not match the key", func(t *testing.T) {
+		// the public key is published, it must not be accepted as an HMAC secret
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+		token.Header["kid"] = "old"
+		signed, err := token.SignedString(publicPEM(t, &rsaKey.PublicKey))
+		noError(t, err)
+
+		_, err = newJWTService(t, current, old).ParseToken(ctx, signed)
+		assert.ErrorContains(t, err, "unexpected signing method")
+	})
+
+	t.Run("alg none", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodNone, testClaims())
+		token.Header["kid"] = "current"
+		signed, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+		noError(t, err)
+
+		_, err = newJWTService(t, current).ParseToken(ctx, signed)
+		assert.Error(t, err)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		js := newJWTService(t, current)
+		token, err := js.GenerateToken(testClaims())
+		noError(t, err)
+
+		claims := testClaims()
+		claims.Role = "admin"
+		forged, err := newJWTService(t, parseKey(t, "current", pkcs8PEM(t, newECKey(t, elliptic.P256())))).GenerateToken(claims)
+		noError(t, err)
+
+		_, err = js.ParseToken(ctx, forged)
+		assert.Error(t, err)
+		_, err = js.ParseToken(ctx, token)
+		assert.NoError(t, err)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		claims := testClaims()
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+		token := jwt.NewWithClaims(current.Method, claims)
+		token.Header["kid"] = "current"
+		signed, err := token.SignedString(current.Private)
+		noError(t, err)
+
+		_, err = newJWTService(t, current).ParseToken(ctx, signed)
+		assert.ErrorIs(t, err, jwt.ErrTokenExpired)
+	})
+}
+
+func TestJWTService_JWKS(t *testing.T) {
+	current := parseKey(t, "current", pkcs8PEM(t, newECKey(t, elliptic.P256())))
+	old := parseKey(t, "old", pkcs8PEM(t, newRSAKey(t)))
+
+	set := newJWTService(t, current, old).JWKS()
+	if assert.Len(t, set.Keys, 2) {
+		assert.Equal(t, "current", set.Keys[0].Kid)
+		assert.Equal(t, "old", set.Keys[1].Kid)
+	}
+}
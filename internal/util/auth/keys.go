@@ -0,0 +1,273 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Key is one JWT key, identified in the token header by Kid. Private is nil for keys that are
+// only kept to verify tokens signed before a rotation, Public is nil for HMAC secrets.
+type Key struct {
+	Kid     string
+	Method  jwt.SigningMethod
+	Private crypto.PrivateKey
+	Public  crypto.PublicKey
+	secret  []byte
+}
+
+// LoadKeyFile reads a PEM encoded key (PKCS#8, SEC 1 or PKCS#1 private key, or a PKIX public key)
+// and picks ES256/ES384/ES512, RS256 or EdDSA from the key type. An empty kid is derived from
+// the public key so the same file always gets the same kid.
+func LoadKeyFile(kid string, path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file '%s': %w", path, err)
+	}
+
+	key, err := ParseKeyPEM(kid, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key file '%s': %w", path, err)
+	}
+
+	return key, nil
+}
+
+func ParseKeyPEM(kid string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	key := &Key{Kid: kid}
+	if private, err := parsePrivateKey(block.Bytes); err == nil {
+		signer, ok := private.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", private)
+		}
+		key.Private = private
+		key.Public = signer.Public()
+	} else if public, err := parsePublicKey(block.Bytes); err == nil {
+		key.Public = public
+	} else {
+		return nil, fmt.Errorf("unsupported PEM block '%s'", block.Type)
+	}
+
+	method, err := methodFor(key.Public)
+	if err != nil {
+		return nil, err
+	}
+	key.Method = method
+
+	if key.Kid == "" {
+		der, err := x509.MarshalPKIXPublicKey(key.Public)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive kid: %w", err)
+		}
+		sum := sha256.Sum256(der)
+		key.Kid = base64.RawURLEncoding.EncodeToString(sum[:12])
+	}
+
+	return key, nil
+}
+
+// NewHMACKey is the HS256 fallback used when no key pair is configured. The secret is never
+// published, so tokens signed with it can only be verified by this service.
+func NewHMACKey(kid string, secret string) (*Key, error) {
+	if secret == "" {
+		return nil, errors.New("HMAC secret is empty")
+	}
+	if kid == "" {
+		kid = "hs256"
+	}
+
+	return &Key{
+		Kid:    kid,
+		Method: jwt.SigningMethodHS256,
+		secret: []byte(secret),
+	}, nil
+}
+
+func (k *Key) signingKey() any {
+	if k.secret != nil {
+		return k.secret
+	}
+	return k.Private
+}
+
+func (k *Key) verifyingKey() any {
+	if k.secret != nil {
+		return k.secret
+	}
+	return k.Public
+}
+
+// KeySet holds the key new tokens are signed with and every key tokens are still accepted from.
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+	order   []string
+}
+
+// NewKeySet takes the current signing key and the retired keys that stay valid for verification.
+// A retired key should be kept until the last token it signed has expired, that is the access
+// token lifetime after it stopped being the signing key.
+func NewKeySet(signing *Key, retired ...*Key) (*KeySet, error) {
+	if signing == nil || signing.signingKey() == nil {
+		return nil, errors.New("signing key must include a private key or secret")
+	}
+
+	ks := &KeySet{
+		signing: signing,
+		keys:    make(map[string]*Key),
+	}
+	for _, key := range append([]*Key{signing}, retired...) {
+		if _, exist := ks.keys[key.Kid]; exist {
+			return nil, fmt.Errorf("duplicate key id '%s'", key.Kid)
+		}
+		ks.keys[key.Kid] = key
+		ks.order = append(ks.order, key.Kid)
+	}
+
+	return ks, nil
+}
+
+func (ks *KeySet) Signing() *Key {
+	return ks.signing
+}
+
+func (ks *KeySet) Lookup(kid string) (*Key, bool) {
+	key, ok := ks.keys[kid]
+	return key, ok
+}
+
+// JWK is the public part of a key as described in RFC 7517
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS lists the public keys of the set, the signing key first. HMAC keys are left out.
+func (ks *KeySet) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	for _, kid := range ks.order {
+		key := ks.keys[kid]
+		jwk := JWK{Kid: key.Kid, Use: "sig", Alg: key.Method.Alg()}
+
+		switch public := key.Public.(type) {
+		case *ecdsa.PublicKey:
+			size := (public.Curve.Params().BitSize + 7) / 8
+			jwk.Kty = "EC"
+			jwk.Crv = public.Curve.Params().Name
+			jwk.X = base64.RawURLEncoding.EncodeToString(public.X.FillBytes(make([]byte, size)))
+			jwk.Y = base64.RawURLEncoding.EncodeToString(public.Y.FillBytes(make([]byte, size)))
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		default:
+			continue
+		}
+
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set
+}
+
+func parsePrivateKey(der []byte) (crypto.PrivateKey, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+	return x509.ParsePKCS1PrivateKey(der)
+}
+
+func parsePublicKey(der []byte) (crypto.PublicKey, error) {
+	if key, err := x509.ParsePKIXPublicKey(der); err == nil {
+		return key, nil
+	}
+	return x509.ParsePKCS1PublicKey(der)
+}
+
+func methodFor(public crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch public := public.(type) {
+	case *ecdsa.PublicKey:
+		switch public.Curve {
+		case elliptic.P256():
+			return jwt.SigningMethodES256, nil
+		case elliptic.P384():
+			return jwt.SigningMethodES384, nil
+		case elliptic.P521():
+			return jwt.SigningMethodES512, nil
+		}
+		return nil, fmt.Errorf("unsupported elliptic curve '%s'", public.Curve.Params().Name)
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", public)
+	}
+}
+
+// LoadKeySet builds the key set from the configuration: the PEM key pair in signingKeyFile when
+// set, the HS256 secretKey otherwise. verifyKeyFiles are the retired keys as "path" or "kid=path".
+func LoadKeySet(signingKeyFile string, signingKeyId string, secretKey string, verifyKeyFiles []string) (*KeySet, error) {
+	var signing *Key
+	var err error
+	if signingKeyFile != "" {
+		signing, err = LoadKeyFile(signingKeyId, signingKeyFile)
+	} else {
+		signing, err = NewHMACKey(signingKeyId, secretKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load signing key: %w", err)
+	}
+
+	var retired []*Key
+	for _, entry := range verifyKeyFiles {
+		kid, path, found := strings.Cut(entry, "=")
+		if !found {
+			kid, path = "", entry
+		}
+
+		key, err := LoadKeyFile(kid, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load verification key: %w", err)
+		}
+		retired = append(retired, key)
+	}
+
+	return NewKeySet(signing, retired...)
+}
@@ -0,0 +1,274 @@
+package auth_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"workout-tracker-api/internal/util/auth"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// noError stops the test, the key material the rest of it needs is missing
+func noError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func newECKey(t *testing.T, curve elliptic.Curve) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	noError(t, err)
+	return key
+}
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	noError(t, err)
+	return key
+}
+
+func newEd25519Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	noError(t, err)
+	return key
+}
+
+// pkcs8PEM encodes the private key the way openssl genpkey writes it
+func pkcs8PEM(t *testing.T, key crypto.PrivateKey) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	noError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func publicPEM(t *testing.T, key crypto.PublicKey) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	noError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func parseKey(t *testing.T, kid string, data []byte) *auth.Key {
+	t.Helper()
+	key, err := auth.ParseKeyPEM(kid, data)
+	noError(t, err)
+	return key
+}
+
+func TestParseKeyPEM(t *testing.T) {
+	ecKey := newECKey(t, elliptic.P256())
+	sec1, err := x509.MarshalECPrivateKey(newECKey(t, elliptic.P384()))
+	noError(t, err)
+	rsaKey := newRSAKey(t)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		method  jwt.SigningMethod
+		private bool
+	}{
+		{"EC P-256 PKCS#8", pkcs8PEM(t, ecKey), jwt.SigningMethodES256, true},
+		{"EC P-384 SEC 1", pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1}), jwt.SigningMethodES384, true},
+		{"EC P-521 PKCS#8", pkcs8PEM(t, newECKey(t, elliptic.P521())), jwt.SigningMethodES512, true},
+		{"RSA PKCS#1", pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}), jwt.SigningMethodRS256, true},
+		{"RSA PKCS#8", pkcs8PEM(t, rsaKey), jwt.SigningMethodRS256, true},
+		{"Ed25519 PKCS#8", pkcs8PEM(t, newEd25519Key(t)), jwt.SigningMethodEdDSA, true},
+		{"EC public key", publicPEM(t, &ecKey.PublicKey), jwt.SigningMethodES256, false},
+		{"RSA public key", publicPEM(t, &rsaKey.PublicKey), jwt.SigningMethodRS256, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := parseKey(t, "", tt.data)
+
+			assert.Equal(t, tt.method, key.Method)
+			assert.NotNil(t, key.Public)
+			assert.Equal(t, tt.private, key.Private != nil)
+			assert.NotEmpty(t, key.Kid)
+		})
+	}
+
+	t.Run("kid is derived from the public key", func(t *testing.T) {
+		private := parseKey(t, "", pkcs8PEM(t, ecKey))
+		public := parseKey(t, "", publicPEM(t, &ecKey.PublicKey))
+		other := parseKey(t, "", pkcs8PEM(t, newECKey(t, elliptic.P256())))
+
+		assert.Equal(t, private.Kid, public.Kid)
+		assert.NotEqual(t, private.Kid, other.Kid)
+	})
+
+	t.Run("given kid is kept", func(t *testing.T) {
+		key := parseKey(t, "2025-01", pkcs8PEM(t, ecKey))
+		assert.Equal(t, "2025-01", key.Kid)
+	})
+
+	t.Run("no PEM block", func(t *testing.T) {
+		_, err := auth.ParseKeyPEM("", []byte("not a key"))
+		assert.Error(t, err)
+	})
+
+	t.Run("PEM block that is not a key", func(t *testing.T) {
+		_, err := auth.ParseKeyPEM("", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("garbage")}))
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported curve", func(t *testing.T) {
+		der, err := x509.MarshalECPrivateKey(newECKey(t, elliptic.P224()))
+		noError(t, err)
+
+		_, err = auth.ParseKeyPEM("", pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+		assert.ErrorContains(t, err, "unsupported elliptic curve")
+	})
+}
+
+func TestLoadKeyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "signing.pem")
+	noError(t, os.WriteFile(path, pkcs8PEM(t, newECKey(t, elliptic.P256())), 0o600))
+
+	key, err := auth.LoadKeyFile("current", path)
+	assert.NoError(t, err)
+	assert.Equal(t, "current", key.Kid)
+	assert.Equal(t, jwt.SigningMethodES256, key.Method)
+
+	_, err = auth.LoadKeyFile("", filepath.Join(t.TempDir(), "missing.pem"))
+	assert.ErrorContains(t, err, "failed to read key file")
+}
+
+func TestNewHMACKey(t *testing.T) {
+	key, err := auth.NewHMACKey("", "secret")
+	assert.NoError(t, err)
+	assert.Equal(t, "hs256", key.Kid)
+	assert.Equal(t, jwt.SigningMethodHS256, key.Method)
+
+	_, err = auth.NewHMACKey("", "")
+	assert.Error(t, err)
+}
+
+func TestNewKeySet(t *testing.T) {
+	signing := parseKey(t, "current", pkcs8PEM(t, newECKey(t, elliptic.P256())))
+
+	t.Run("signing key without a private key", func(t *testing.T) {
+		ecKey := newECKey(t, elliptic.P256())
+		_, err := auth.NewKeySet(parseKey(t, "", publicPEM(t, &ecKey.PublicKey)))
+		assert.Error(t, err)
+	})
+
+	t.Run("duplicate kid", func(t *testing.T) {
+		retired := parseKey(t, "current", pkcs8PEM(t, newECKey(t, elliptic.P256())))
+		_, err := auth.NewKeySet(signing, retired)
+		assert.ErrorContains(t, err, "duplicate key id 'current'")
+	})
+
+	t.Run("lookup by kid", func(t *testing.T) {
+		retired := parseKey(t, "old", pkcs8PEM(t, newRSAKey(t)))
+		ks, err := auth.NewKeySet(signing, retired)
+		noError(t, err)
+
+		assert.Same(t, signing, ks.Signing())
+		found, ok := ks.Lookup("old")
+		assert.True(t, ok)
+		assert.Same(t, retired, found)
+		_, ok = ks.Lookup("unknown")
+		assert.False(t, ok)
+	})
+}
+
+func TestLoadKeySet(t *testing.T) {
+	dir := t.TempDir()
+	signingPath := filepath.Join(dir, "signing.pem")
+	retiredPath := filepath.Join(dir, "retired.pem")
+	noError(t, os.WriteFile(signingPath, pkcs8PEM(t, newECKey(t, elliptic.P256())), 0o600))
+	retiredKey := newRSAKey(t)
+	noError(t, os.WriteFile(retiredPath, publicPEM(t, &retiredKey.PublicKey), 0o600))
+
+	t.Run("key pair with a retired key", func(t *testing.T) {
+		ks, err := auth.LoadKeySet(signingPath, "current", "", []string{"old=" + retiredPath})
+		noError(t, err)
+
+		assert.Equal(t, "current", ks.Signing().Kid)
+		retired, ok := ks.Lookup("old")
+		assert.True(t, ok)
+		assert.Nil(t, retired.Private)
+	})
+
+	t.Run("HMAC fallback", func(t *testing.T) {
+		ks, err := auth.LoadKeySet("", "", "secret", nil)
+		noError(t, err)
+		assert.Equal(t, jwt.SigningMethodHS256, ks.Signing().Method)
+	})
+
+	t.Run("missing retired key", func(t *testing.T) {
+		_, err := auth.LoadKeySet(signingPath, "", "", []string{filepath.Join(dir, "missing.pem")})
+		assert.ErrorContains(t, err, "failed to load verification key")
+	})
+}
+
+func decodeBase64URL(t *testing.T, value string) []byte {
+	t.Helper()
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	noError(t, err)
+	return decoded
+}
+
+func TestKeySetJWKS(t *testing.T) {
+	ecKey := newECKey(t, elliptic.P256())
+	rsaKey := newRSAKey(t)
+	edKey := newEd25519Key(t)
+	hmacKey, err := auth.NewHMACKey("hs", "secret")
+	noError(t, err)
+
+	ks, err := auth.NewKeySet(
+		parseKey(t, "ec", pkcs8PEM(t, ecKey)),
+		parseKey(t, "rsa", publicPEM(t, &rsaKey.PublicKey)),
+		parseKey(t, "ed", pkcs8PEM(t, edKey)),
+		hmacKey,
+	)
+	noError(t, err)
+
+	set := ks.JWKS()
+	// the HMAC secret is never published
+	if !assert.Len(t, set.Keys, 3) {
+		t.FailNow()
+	}
+
+	ec := set.Keys[0]
+	assert.Equal(t, auth.JWK{Kty: "EC", Kid: "ec", Use: "sig", Alg: "ES256", Crv: "P-256", X: ec.X, Y: ec.Y}, ec)
+	assert.Len(t, decodeBase64URL(t, ec.X), 32)
+	assert.Equal(t, 0, new(big.Int).SetBytes(decodeBase64URL(t, ec.X)).Cmp(ecKey.X))
+	assert.Equal(t, 0, new(big.Int).SetBytes(decodeBase64URL(t, ec.Y)).Cmp(ecKey.Y))
+
+	rsaJWK := set.Keys[1]
+	assert.Equal(t, "RSA", rsaJWK.Kty)
+	assert.Equal(t, "RS256", rsaJWK.Alg)
+	assert.Equal(t, "AQAB", rsaJWK.E)
+	assert.Equal(t, 0, new(big.Int).SetBytes(decodeBase64URL(t, rsaJWK.N)).Cmp(rsaKey.N))
+
+	ed := set.Keys[2]
+	assert.Equal(t, "OKP", ed.Kty)
+	assert.Equal(t, "Ed25519", ed.Crv)
+	assert.Equal(t, "EdDSA", ed.Alg)
+	assert.Equal(t, []byte(edKey.Public().(ed25519.PublicKey)), decodeBase64URL(t, ed.X))
+
+	t.Run("HMAC only", func(t *testing.T) {
+		ks, err := auth.NewKeySet(hmacKey)
+		noError(t, err)
+		assert.Empty(t, ks.JWKS().Keys)
+		assert.NotNil(t, ks.JWKS().Keys)
+	})
+}
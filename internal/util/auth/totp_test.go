@@ -0,0 +1,75 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"workout-tracker-api/internal/util/auth"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// base32 of the ASCII secret "12345678901234567890" used by the RFC 6238 test vectors
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	// RFC 6238 appendix B, SHA1, cut to 6 digits
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, expected := range vectors {
+		code, err := auth.TOTPCode(rfcSecret, auth.TOTPStep(time.Unix(unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, code, "time %d", unix)
+	}
+
+	_, err := auth.TOTPCode("not base32!", 1)
+	assert.Error(t, err)
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := auth.TOTPStep(now)
+	previous, _ := auth.TOTPCode(rfcSecret, step-1)
+	tooOld, _ := auth.TOTPCode(rfcSecret, step-2)
+
+	matched, ok := auth.ValidateTOTP(rfcSecret, "050471", now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, step, matched)
+
+	matched, ok = auth.ValidateTOTP(rfcSecret, previous, now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, step-1, matched)
+
+	_, ok = auth.ValidateTOTP(rfcSecret, tooOld, now, 1)
+	assert.False(t, ok)
+	_, ok = auth.ValidateTOTP(rfcSecret, "50471", now, 1)
+	assert.False(t, ok)
+}
+
+func TestNewTOTPSecret(t *testing.T) {
+	secret, err := auth.NewTOTPSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	other, _ := auth.NewTOTPSecret()
+	assert.NotEqual(t, secret, other)
+
+	_, err = auth.TOTPCode(secret, 1)
+	assert.NoError(t, err)
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := auth.TOTPURI("Workout Tracker", "john@example.com", rfcSecret)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Workout%20Tracker:john@example.com?"))
+	assert.Contains(t, uri, "secret="+rfcSecret)
+	assert.Contains(t, uri, "issuer=Workout+Tracker")
+	assert.Contains(t, uri, "digits=6")
+	assert.Contains(t, uri, "period=30")
+}
@@ -1,17 +1,34 @@
 package customtime
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
+
+// localLayouts are the layouts without an offset ParseTime accepts, they are read in its location
+var localLayouts = []string{"2006-01-02T15:04:05", time.DateOnly}
 
 func TimeToString(date time.Time) string {
 	return date.UTC().Format(time.RFC3339)
 }
 
-func ParseTime(timeStr string) (*time.Time, error) {
-	parsedTime, err := time.Parse(time.RFC3339, timeStr)
+// ParseTime reads an RFC 3339 date-time, or a date-time without offset or a date in loc. The
+// result is in loc either way, UTC when loc is nil.
+func ParseTime(timeStr string, loc *time.Location) (*time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	if parsedTime, err := time.Parse(time.RFC3339, timeStr); err == nil {
+		parsedTime = parsedTime.In(loc)
+		return &parsedTime, nil
+	}
 
-	if err != nil {
-		return nil, err
+	for _, layout := range localLayouts {
+		if parsedTime, err := time.ParseInLocation(layout, timeStr, loc); err == nil {
+			return &parsedTime, nil
+		}
 	}
 
-	return &parsedTime, nil
+	return nil, fmt.Errorf("'%s' is not an RFC 3339 date-time or a date", timeStr)
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -20,8 +22,58 @@ type DBVariables struct {
 	Hostname string
 }
 
+// JWTVariables configures the token keys. SigningKeyFile is a PEM private key (ES256, RS256 or
+// EdDSA), SecretKey is only used for HS256 when no key file is set. VerifyKeyFiles are the keys
+// retired by a rotation, kept until the tokens they signed have expired.
 type JWTVariables struct {
-	SecretKey string
+	SecretKey       string
+	SigningKeyFile  string
+	SigningKeyId    string
+	VerifyKeyFiles  []string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+// MailVariables configures outgoing email. Driver is smtp, file or log, the file and log drivers
+// keep the mails on the machine for development.
+type MailVariables struct {
+	Driver       string
+	From         string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	FilePath     string
+}
+
+// LoginVariables configures the lockout after failed logins, see service.LoginGuardConfig
+type LoginVariables struct {
+	AccountThreshold int
+	IPThreshold      int
+	BaseLockout      time.Duration
+	MaxLockout       time.Duration
+	FailureWindow    time.Duration
+}
+
+// TwoFactorVariables configures TOTP logins, see service.TwoFactorConfig
+type TwoFactorVariables struct {
+	Issuer       string
+	ChallengeTTL time.Duration
+	MaxAttempts  int
+}
+
+// MuscleBalanceVariables are the ratios past which the muscle balance report flags a pair, see
+// service.MuscleBalanceConfig
+type MuscleBalanceVariables struct {
+	PushPullRatio   float64
+	ChestBackRatio  float64
+	UpperLowerRatio float64
+}
+
+type SchedulerVariables struct {
+	MissedGracePeriod     time.Duration
+	MissedCheckInterval   time.Duration
+	DeletionCheckInterval time.Duration
 }
 
 type EnvVariables struct {
@@ -29,6 +81,23 @@ type EnvVariables struct {
 	ServerPort int
 	JWT        JWTVariables
 	Redis      RedisVariables
+	Scheduler  SchedulerVariables
+	Mail       MailVariables
+	Login      LoginVariables
+	TwoFactor  TwoFactorVariables
+	Balance    MuscleBalanceVariables
+	// AdminEmails are given the admin role at startup
+	AdminEmails []string
+	// PasswordResetTTL is how long a password reset token works
+	PasswordResetTTL time.Duration
+	// EmailVerificationPolicy is optional, limited or required: whether unverified users can use
+	// everything, only their account routes, or cannot log in at all
+	EmailVerificationPolicy string
+	EmailVerificationTTL    time.Duration
+	// AccountDeletionGracePeriod is how long a deleted account can be recovered before its data is gone
+	AccountDeletionGracePeriod time.Duration
+	// ExportTimeout is the deadline of a workout export, the other routes time out after a minute
+	ExportTimeout time.Duration
 }
 
 func LoadEnv() (*EnvVariables, error) {
@@ -91,11 +160,27 @@ func LoadEnv() (*EnvVariables, error) {
 	}
 	envVars.DB.Hostname = dbHostname
 
-	secretKey, err = variableValidater("SECRET_KEY")
+	// a PEM key pair is preferred, SECRET_KEY is only required for the HS256 fallback
+	envVars.JWT.SigningKeyFile = os.Getenv("JWT_SIGNING_KEY_FILE")
+	envVars.JWT.SigningKeyId = os.Getenv("JWT_SIGNING_KEY_ID")
+	envVars.JWT.VerifyKeyFiles = listValidater("JWT_VERIFY_KEY_FILES")
+	if envVars.JWT.SigningKeyFile == "" {
+		secretKey, err = variableValidater("SECRET_KEY")
+		if err != nil {
+			return nil, err
+		}
+		envVars.JWT.SecretKey = secretKey
+	}
+
+	envVars.JWT.AccessTokenTTL, err = durationValidater("ACCESS_TOKEN_TTL", 15*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars.JWT.RefreshTokenTTL, err = durationValidater("REFRESH_TOKEN_TTL", 30*24*time.Hour)
 	if err != nil {
 		return nil, err
 	}
-	envVars.JWT.SecretKey = secretKey
 
 	redisURL, err = variableValidater("REDIS_URL")
 
@@ -105,9 +190,127 @@ func LoadEnv() (*EnvVariables, error) {
 
 	envVars.Redis.URL = redisURL
 
+	// scheduler settings are optional, fall back to the defaults when unset
+	envVars.Scheduler.MissedGracePeriod, err = durationValidater("MISSED_GRACE_PERIOD", 24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars.Scheduler.MissedCheckInterval, err = durationValidater("MISSED_CHECK_INTERVAL", 15*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars.Scheduler.DeletionCheckInterval, err = durationValidater("ACCOUNT_DELETION_CHECK_INTERVAL", time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	// mail settings are optional, without them mails are only written to the log
+	envVars.Mail.Driver = defaultValue(os.Getenv("MAIL_DRIVER"), "log")
+	envVars.Mail.From = defaultValue(os.Getenv("MAIL_FROM"), "no-reply@workout-tracker.local")
+	envVars.Mail.FilePath = defaultValue(os.Getenv("MAIL_FILE_PATH"), "mail.log")
+	if envVars.Mail.Driver == "smtp" {
+		envVars.Mail.SMTPHost, err = variableValidater("SMTP_HOST")
+		if err != nil {
+			return nil, err
+		}
+		envVars.Mail.SMTPPort, err = portValidater(defaultValue(os.Getenv("SMTP_PORT"), "587"))
+		if err != nil {
+			return nil, err
+		}
+		envVars.Mail.SMTPUsername = os.Getenv("SMTP_USERNAME")
+		envVars.Mail.SMTPPassword = os.Getenv("SMTP_PASSWORD")
+	}
+
+	envVars.PasswordResetTTL, err = durationValidater("PASSWORD_RESET_TTL", time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars.Login.AccountThreshold, err = intValidater("LOGIN_ACCOUNT_THRESHOLD", 5)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars.Login.IPThreshold, err = intValidater("LOGIN_IP_THRESHOLD", 50)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars.Login.BaseLockout, err = durationValidater("LOGIN_BASE_LOCKOUT", time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars.Login.MaxLockout, err = durationValidater("LOGIN_MAX_LOCKOUT", time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars.Login.FailureWindow, err = durationValidater("LOGIN_FAILURE_WINDOW", 24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars.TwoFactor.Issuer = defaultValue(os.Getenv("TOTP_ISSUER"), "Workout Tracker")
+	envVars.TwoFactor.ChallengeTTL, err = durationValidater("TWO_FACTOR_CHALLENGE_TTL", 5*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars.TwoFactor.MaxAttempts, err = intValidater("TWO_FACTOR_MAX_ATTEMPTS", 5)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars.AdminEmails = listValidater("ADMIN_EMAILS")
+
+	envVars.EmailVerificationPolicy, err = oneOfValidater("EMAIL_VERIFICATION_POLICY", "optional", "optional", "limited", "required")
+	if err != nil {
+		return nil, err
+	}
+
+	envVars.EmailVerificationTTL, err = durationValidater("EMAIL_VERIFICATION_TTL", 48*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars.AccountDeletionGracePeriod, err = durationValidater("ACCOUNT_DELETION_GRACE_PERIOD", 30*24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars.ExportTimeout, err = durationValidater("EXPORT_TIMEOUT", 10*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars.Balance.PushPullRatio, err = ratioValidater("MUSCLE_BALANCE_PUSH_PULL_RATIO", 1.5)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars.Balance.ChestBackRatio, err = ratioValidater("MUSCLE_BALANCE_CHEST_BACK_RATIO", 1.5)
+	if err != nil {
+		return nil, err
+	}
+
+	envVars.Balance.UpperLowerRatio, err = ratioValidater("MUSCLE_BALANCE_UPPER_LOWER_RATIO", 2)
+	if err != nil {
+		return nil, err
+	}
+
 	return &envVars, nil
 }
 
+func defaultValue(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
 func portValidater(portStr string) (int, error) {
 	port, err := strconv.Atoi(portStr)
 	if err != nil {
@@ -126,3 +329,74 @@ func variableValidater(varStr string) (string, error) {
 	}
 	return variable, nil
 }
+
+// listValidater splits a comma separated variable, empty entries are dropped
+func listValidater(varStr string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(varStr), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func intValidater(varStr string, defaultValue int) (int, error) {
+	variable := os.Getenv(varStr)
+	if variable == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.Atoi(variable)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a valid integer: %w", varStr, err)
+	}
+	if value <= 0 {
+		return 0, fmt.Errorf("%s must be a positive integer", varStr)
+	}
+	return value, nil
+}
+
+// ratioValidater reads a ratio of at least 1, or the default when unset
+func ratioValidater(varStr string, defaultValue float64) (float64, error) {
+	variable := os.Getenv(varStr)
+	if variable == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.ParseFloat(variable, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a valid number: %w", varStr, err)
+	}
+	if value < 1 {
+		return 0, fmt.Errorf("%s must be at least 1", varStr)
+	}
+	return value, nil
+}
+
+// oneOfValidater returns the variable if it is one of the allowed values, or the default when unset
+func oneOfValidater(varStr string, defaultValue string, allowed ...string) (string, error) {
+	variable := os.Getenv(varStr)
+	if variable == "" {
+		return defaultValue, nil
+	}
+	for _, value := range allowed {
+		if variable == value {
+			return variable, nil
+		}
+	}
+	return "", fmt.Errorf("%s must be one of %s", varStr, strings.Join(allowed, ", "))
+}
+
+func durationValidater(varStr string, defaultValue time.Duration) (time.Duration, error) {
+	variable := os.Getenv(varStr)
+	if variable == "" {
+		return defaultValue, nil
+	}
+	duration, err := time.ParseDuration(variable)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a valid duration: %w", varStr, err)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("%s must be a positive duration", varStr)
+	}
+	return duration, nil
+}
@@ -8,13 +8,18 @@ import (
 type ContextKey string
 
 type UserInfo struct {
-	Id    int
-	Email string
-	Name  string
+	Id            int
+	Email         string
+	Name          string
+	EmailVerified bool
+	Role          string
+	// Scopes limits a request made with a personal access token, it is nil for login sessions
+	Scopes []string
 }
 
 type JTIInfo struct {
 	Id             string
+	SessionId      string
 	ExpirationTime time.Time
 }
 
@@ -29,7 +34,7 @@ func GetUserInfoFromContext(ctx context.Context) (*UserInfo, bool) {
 	return GetFromContext[*UserInfo](ctx, UserContextKey)
 }
 func GetJTIFromContext(ctx context.Context) (*JTIInfo, bool) {
-	return GetFromContext[*JTIInfo](ctx, UserContextKey)
+	return GetFromContext[*JTIInfo](ctx, JTIContextKey)
 }
 
 func SetUserInfoToContext(ctx context.Context, user *UserInfo) context.Context {
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"errors"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 
 	"workout-tracker-api/internal/apperrors"
 	"workout-tracker-api/pkg/api"
@@ -45,6 +47,20 @@ func SendErrorResponse(w http.ResponseWriter, err error) {
 		statusCode = http.StatusForbidden
 		message = err.Error()
 		errorCode = apperrors.FORBIDDEN
+	} else if errors.Is(err, apperrors.ErrTooManyRequests) {
+		statusCode = http.StatusTooManyRequests
+		message = err.Error()
+		errorCode = apperrors.TOO_MANY_REQUESTS
+		var lockErr *apperrors.LockoutError
+		if errors.As(err, &lockErr) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(lockErr.RetryAfter.Seconds()))))
+		}
+	} else if errors.Is(err, apperrors.ErrForeignKeyViolation) {
+		// only expose the sentinel message, the wrapped database error is logged instead
+		log.Printf("Foreign key violation: %v", err)
+		statusCode = http.StatusUnprocessableEntity
+		message = "referenced resource does not exist"
+		errorCode = apperrors.FOREIGN_KEY_VIOLATION
 	} else if errors.Is(err, apperrors.ErrInvalidInput) {
 		statusCode = http.StatusBadRequest
 		message = err.Error()
@@ -0,0 +1,265 @@
+// Package xlsx writes Office Open XML workbooks with a single sheet. Rows are streamed into the
+// zip archive as they are written, so a large sheet is never held in memory.
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cell styles of styles.xml, by index of cellXfs
+const (
+	styleDefault = 0
+	styleDate    = 1
+	styleHeader  = 2
+)
+
+// maxSheetName is the longest sheet name spreadsheet applications accept
+const maxSheetName = 31
+
+// excelEpoch is day 0 of the 1900 date system, past the leap day 1900 did not have
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+var ErrClosed = errors.New("xlsx: writer is closed")
+
+const contentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+	`</Types>`
+
+const rootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const workbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+// workbook takes the escaped sheet name
+const workbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>` +
+	`</workbook>`
+
+const styles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
+
+const sheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const sheetEnd = `</sheetData></worksheet>`
+
+// Writer writes the rows of the sheet in order. Close has to be called to finish the file.
+type Writer struct {
+	zip    *zip.Writer
+	sheet  *bufio.Writer
+	row    int
+	closed bool
+}
+
+// NewWriter writes the parts of the workbook around the sheet to w and opens the sheet, the name
+// must be a valid sheet name
+func NewWriter(w io.Writer, sheetName string) (*Writer, error) {
+	if err := validateSheetName(sheetName); err != nil {
+		return nil, err
+	}
+
+	var name strings.Builder
+	if err := xml.EscapeText(&name, []byte(sheetName)); err != nil {
+		return nil, err
+	}
+
+	zw := zip.NewWriter(w)
+	parts := []struct {
+		path    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", rootRels},
+		{"xl/workbook.xml", fmt.Sprintf(workbook, name.String())},
+		{"xl/_rels/workbook.xml.rels", workbookRels},
+		{"xl/styles.xml", styles},
+	}
+	for _, part := range parts {
+		f, err := zw.Create(part.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s: %w", part.path, err)
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", part.path, err)
+		}
+	}
+
+	// the sheet has to be the last entry, the zip format only allows one open entry at a time
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to add sheet: %w", err)
+	}
+	sheet := bufio.NewWriter(f)
+	if _, err := sheet.WriteString(sheetStart); err != nil {
+		return nil, fmt.Errorf("failed to write sheet: %w", err)
+	}
+
+	return &Writer{zip: zw, sheet: sheet}, nil
+}
+
+// WriteHeader writes a row of bold column names
+func (x *Writer) WriteHeader(names ...string) error {
+	cells := make([]any, 0, len(names))
+	for _, name := range names {
+		cells = append(cells, name)
+	}
+	return x.writeRow(styleHeader, cells)
+}
+
+// WriteRow writes the next row. A cell is a string, an integer, a float, a bool, a time.Time or nil
+// for an empty cell; times are written as the wall clock of their location, since cells have no
+// time zone.
+func (x *Writer) WriteRow(cells ...any) error {
+	return x.writeRow(styleDefault, cells)
+}
+
+func (x *Writer) writeRow(style int, cells []any) error {
+	if x.closed {
+		return ErrClosed
+	}
+
+	x.row++
+	row := strconv.Itoa(x.row)
+	x.sheet.WriteString(`<row r="` + row + `">`)
+	for i, cell := range cells {
+		if cell == nil {
+			continue
+		}
+		if err := x.writeCell(columnName(i)+row, style, cell); err != nil {
+			return err
+		}
+	}
+	_, err := x.sheet.WriteString(`</row>`)
+	return err
+}
+
+func (x *Writer) writeCell(ref string, style int, cell any) error {
+	var kind, value string
+	switch v := cell.(type) {
+	case string:
+		return x.writeText(ref, style, v)
+	case int:
+		value = strconv.Itoa(v)
+	case int64:
+		value = strconv.FormatInt(v, 10)
+	case float32:
+		value = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		value = strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		kind = ` t="b"`
+		value = "0"
+		if v {
+			value = "1"
+		}
+	case time.Time:
+		if style == styleDefault {
+			style = styleDate
+		}
+		value = strconv.FormatFloat(serialDate(v), 'f', -1, 64)
+	default:
+		return fmt.Errorf("xlsx: unsupported cell type %T", cell)
+	}
+
+	_, err := fmt.Fprintf(x.sheet, `<c r="%s"%s%s><v>%s</v></c>`, ref, styleAttr(style), kind, value)
+	return err
+}
+
+// writeText writes an inline string, so there is no shared string table to hold until the end
+func (x *Writer) writeText(ref string, style int, text string) error {
+	fmt.Fprintf(x.sheet, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">`, ref, styleAttr(style))
+	if err := xml.EscapeText(x.sheet, []byte(text)); err != nil {
+		return err
+	}
+	_, err := x.sheet.WriteString(`</t></is></c>`)
+	return err
+}
+
+// Close ends the sheet and the zip archive, it does not close the underlying writer
+func (x *Writer) Close() error {
+	if x.closed {
+		return ErrClosed
+	}
+	x.closed = true
+
+	if _, err := x.sheet.WriteString(sheetEnd); err != nil {
+		return fmt.Errorf("failed to write sheet: %w", err)
+	}
+	if err := x.sheet.Flush(); err != nil {
+		return fmt.Errorf("failed to write sheet: %w", err)
+	}
+	return x.zip.Close()
+}
+
+// Flush sends what was written so far to the underlying writer
+func (x *Writer) Flush() error {
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+	return x.zip.Flush()
+}
+
+func styleAttr(style int) string {
+	if style == styleDefault {
+		return ""
+	}
+	return ` s="` + strconv.Itoa(style) + `"`
+}
+
+// columnName is the letters of the 0-based column index: A to Z, then AA, AB and so on
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// serialDate is t as days since the epoch of the 1900 date system, the fraction being the time of day
+func serialDate(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	return wall.Sub(excelEpoch).Hours() / 24
+}
+
+func validateSheetName(name string) error {
+	if name == "" || len([]rune(name)) > maxSheetName {
+		return fmt.Errorf("xlsx: sheet name must be 1 to %d characters", maxSheetName)
+	}
+	if strings.ContainsAny(name, `[]:*?/\`) {
+		return fmt.Errorf("xlsx: sheet name '%s' contains one of []:*?/\\", name)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package xlsx_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+	"workout-tracker-api/internal/util/xlsx"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sheet is the part of a worksheet the tests look at
+type sheet struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R      string `xml:"r,attr"`
+			T      string `xml:"t,attr"`
+			S      string `xml:"s,attr"`
+			V      string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func readParts(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("not a zip archive: %v", err)
+	}
+
+	parts := map[string][]byte{}
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", f.Name, err)
+		}
+		parts[f.Name] = content
+	}
+	return parts
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := xlsx.NewWriter(&buf, "Workouts & sets")
+	assert.NoError(t, err)
+
+	hongKong, _ := time.LoadLocation("Asia/Hong_Kong")
+	assert.NoError(t, w.WriteHeader("name", "sets", "weights", "date", "done"))
+	assert.NoError(t, w.WriteRow("Bench <Press> & \"dips\"", 3, float32(62.5), time.Date(2025, 5, 1, 18, 0, 0, 0, hongKong), true))
+	assert.NoError(t, w.WriteRow(" padded ", nil, 1.25))
+	assert.NoError(t, w.Close())
+
+	parts := readParts(t, buf.Bytes())
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		assert.Contains(t, parts, name)
+	}
+	assert.Contains(t, string(parts["xl/workbook.xml"]), `name="Workouts &amp; sets"`)
+
+	var got sheet
+	assert.NoError(t, xml.Unmarshal(parts["xl/worksheets/sheet1.xml"], &got))
+	if !assert.Len(t, got.Rows, 3) {
+		return
+	}
+
+	header := got.Rows[0]
+	assert.Equal(t, 1, header.R)
+	assert.Equal(t, "name", header.Cells[0].Inline)
+	assert.Equal(t, "2", header.Cells[0].S)
+
+	row := got.Rows[1].Cells
+	assert.Equal(t, "A2", row[0].R)
+	assert.Equal(t, "inlineStr", row[0].T)
+	assert.Equal(t, "Bench <Press> & \"dips\"", row[0].Inline)
+	assert.Equal(t, "3", row[1].V)
+	assert.Equal(t, "62.5", row[2].V)
+	// 2025-05-01 18:00 on the wall clock of Hong Kong
+	assert.Equal(t, "D2", row[3].R)
+	assert.Equal(t, "1", row[3].S)
+	assert.Equal(t, "45778.75", row[3].V)
+	assert.Equal(t, "b", row[4].T)
+	assert.Equal(t, "1", row[4].V)
+
+	// nil leaves the cell out, the next keeps its column
+	padded := got.Rows[2].Cells
+	assert.Len(t, padded, 2)
+	assert.Equal(t, " padded ", padded[0].Inline)
+	assert.Equal(t, "C3", padded[1].R)
+}
+
+func TestWriter_ColumnsPastZ(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := xlsx.NewWriter(&buf, "Sheet1")
+	assert.NoError(t, err)
+
+	cells := make([]any, 28)
+	for i := range cells {
+		cells[i] = i
+	}
+	assert.NoError(t, w.WriteRow(cells...))
+	assert.NoError(t, w.Close())
+
+	var got sheet
+	assert.NoError(t, xml.Unmarshal(readParts(t, buf.Bytes())["xl/worksheets/sheet1.xml"], &got))
+	refs := got.Rows[0].Cells
+	assert.Equal(t, "Z1", refs[25].R)
+	assert.Equal(t, "AA1", refs[26].R)
+	assert.Equal(t, "AB1", refs[27].R)
+}
+
+func TestWriter_Errors(t *testing.T) {
+	_, err := xlsx.NewWriter(io.Discard, "")
+	assert.Error(t, err)
+	_, err = xlsx.NewWriter(io.Discard, "a/b")
+	assert.Error(t, err)
+	_, err = xlsx.NewWriter(io.Discard, "a sheet name longer than the limit")
+	assert.Error(t, err)
+
+	w, err := xlsx.NewWriter(io.Discard, "Sheet1")
+	assert.NoError(t, err)
+	assert.Error(t, w.WriteRow(struct{}{}))
+	assert.NoError(t, w.Close())
+	assert.ErrorIs(t, w.WriteRow("late"), xlsx.ErrClosed)
+}
@@ -19,6 +19,48 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for AccessTokenScope.
+const (
+	ExercisesRead  AccessTokenScope = "exercises:read"
+	ExercisesWrite AccessTokenScope = "exercises:write"
+	ReportsRead    AccessTokenScope = "reports:read"
+	WorkoutsRead   AccessTokenScope = "workouts:read"
+	WorkoutsWrite  AccessTokenScope = "workouts:write"
+)
+
+// Defines values for BalancePair.
+const (
+	ChestBack  BalancePair = "chest_back"
+	PushPull   BalancePair = "push_pull"
+	UpperLower BalancePair = "upper_lower"
+)
+
+// Defines values for Equipment.
+const (
+	EquipmentBand       Equipment = "band"
+	EquipmentBarbell    Equipment = "barbell"
+	EquipmentBodyweight Equipment = "bodyweight"
+	EquipmentCable      Equipment = "cable"
+	EquipmentDumbbell   Equipment = "dumbbell"
+	EquipmentKettlebell Equipment = "kettlebell"
+	EquipmentMachine    Equipment = "machine"
+	EquipmentOther      Equipment = "other"
+)
+
+// Defines values for ExportFormat.
+const (
+	Csv   ExportFormat = "csv"
+	Jsonl ExportFormat = "jsonl"
+	Xlsx  ExportFormat = "xlsx"
+)
+
+// Defines values for Frequency.
+const (
+	Daily   Frequency = "daily"
+	Monthly Frequency = "monthly"
+	Weekly  Frequency = "weekly"
+)
+
 // Defines values for MuscleGroup.
 const (
 	Arms      MuscleGroup = "arms"
@@ -30,6 +72,45 @@ const (
 	Shoulders MuscleGroup = "shoulders"
 )
 
+// Defines values for OccurrenceScope.
+const (
+	Following OccurrenceScope = "following"
+	This      OccurrenceScope = "this"
+)
+
+// Defines values for OneRepMaxFormula.
+const (
+	Brzycki  OneRepMaxFormula = "brzycki"
+	Epley    OneRepMaxFormula = "epley"
+	Lombardi OneRepMaxFormula = "lombardi"
+)
+
+// Defines values for PersonalRecordKind.
+const (
+	Estimated1rm PersonalRecordKind = "estimated_1rm"
+	Reps         PersonalRecordKind = "reps"
+	Volume       PersonalRecordKind = "volume"
+	Weight       PersonalRecordKind = "weight"
+)
+
+// Defines values for ReportCompareTo.
+const (
+	Previous     ReportCompareTo = "previous"
+	PreviousYear ReportCompareTo = "previous_year"
+)
+
+// Defines values for ReportUnit.
+const (
+	ReportUnitKg  ReportUnit = "kg"
+	ReportUnitLbs ReportUnit = "lbs"
+)
+
+// Defines values for Role.
+const (
+	Admin Role = "admin"
+	User  Role = "user"
+)
+
 // Defines values for SuccessCode.
 const (
 	CREATED SuccessCode = "CREATED"
@@ -37,11 +118,29 @@ const (
 	UPDATE  SuccessCode = "UPDATE"
 )
 
+// Defines values for VolumeBucket.
+const (
+	Day   VolumeBucket = "day"
+	Month VolumeBucket = "month"
+	Week  VolumeBucket = "week"
+)
+
+// Defines values for Weekday.
+const (
+	FR Weekday = "FR"
+	MO Weekday = "MO"
+	SA Weekday = "SA"
+	SU Weekday = "SU"
+	TH Weekday = "TH"
+	TU Weekday = "TU"
+	WE Weekday = "WE"
+)
+
 // Defines values for WeightUnit.
 const (
-	Kg    WeightUnit = "kg"
-	Lbs   WeightUnit = "lbs"
-	Other WeightUnit = "other"
+	WeightUnitKg    WeightUnit = "kg"
+	WeightUnitLbs   WeightUnit = "lbs"
+	WeightUnitOther WeightUnit = "other"
 )
 
 // Defines values for WorkoutPlanStatus.
@@ -57,11 +156,171 @@ const (
 	Desc ListWorkoutPlansParamsSort = "desc"
 )
 
+// AccessToken defines model for AccessToken.
+type AccessToken struct {
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// ExpiresAt null for a token that does not expire
+	ExpiresAt *time.Time `json:"expiresAt"`
+	Id        *int64     `json:"id,omitempty"`
+
+	// LastUsedAt null until the token is used, updated at most once a minute
+	LastUsedAt *time.Time          `json:"lastUsedAt"`
+	Name       *string             `json:"name,omitempty"`
+	Scopes     *[]AccessTokenScope `json:"scopes,omitempty"`
+
+	// Token the token to send, only returned when it is created
+	Token *string `json:"token,omitempty"`
+}
+
+// AccessTokenScope `workouts:*` covers workout plans, performed sets, schedules and templates.
+// `exercises:*` covers the exercise list and custom exercises.
+// `reports:read` covers the progress, volume and personal record reports.
+type AccessTokenScope string
+
+// AccountDeletion defines model for AccountDeletion.
+type AccountDeletion struct {
+	// DeleteAt When the data is deleted unless the deletion is cancelled before
+	DeleteAt *time.Time `json:"deleteAt,omitempty"`
+}
+
+// AddExercisePlan defines model for AddExercisePlan.
+type AddExercisePlan struct {
+	ExercisePlan CreateExercisePlan `json:"exercisePlan"`
+
+	// Position 1-based position to insert at, defaults to the end
+	Position *int `json:"position,omitempty"`
+}
+
+// AdherencePeriod defines model for AdherencePeriod.
+type AdherencePeriod struct {
+	Completed *int                `json:"completed,omitempty"`
+	Missed    *int                `json:"missed,omitempty"`
+	Pending   *int                `json:"pending,omitempty"`
+	Rate      *float64            `json:"rate"`
+	Scheduled *int                `json:"scheduled,omitempty"`
+	Start     *openapi_types.Date `json:"start,omitempty"`
+}
+
+// AdherenceTotals defines model for AdherenceTotals.
+type AdherenceTotals struct {
+	Completed *int `json:"completed,omitempty"`
+	Missed    *int `json:"missed,omitempty"`
+	Pending   *int `json:"pending,omitempty"`
+
+	// Rate completed ÷ scheduled, null when nothing was scheduled
+	Rate *float64 `json:"rate"`
+
+	// Scheduled every workout plan of the period, whatever its status
+	Scheduled *int `json:"scheduled,omitempty"`
+}
+
+// AdminUser defines model for AdminUser.
+type AdminUser struct {
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// DeletionDueAt When the account gets deleted, only set while the user waits for it
+	DeletionDueAt *time.Time           `json:"deletionDueAt,omitempty"`
+	Disabled      *bool                `json:"disabled,omitempty"`
+	Email         *openapi_types.Email `json:"email,omitempty"`
+	EmailVerified *bool                `json:"emailVerified,omitempty"`
+	Id            *int64               `json:"id,omitempty"`
+	Name          *string              `json:"name,omitempty"`
+	Role          *Role                `json:"role,omitempty"`
+}
+
+// BalancePair defines model for BalancePair.
+type BalancePair string
+
+// BalanceRatio defines model for BalanceRatio.
+type BalanceRatio struct {
+	// Imbalanced the ratio is above maxRatio or below 1 ÷ maxRatio, or only one side was trained
+	Imbalanced    *bool        `json:"imbalanced,omitempty"`
+	LeftSets      *int         `json:"leftSets,omitempty"`
+	MaxRatio      *float64     `json:"maxRatio,omitempty"`
+	Name          *BalancePair `json:"name,omitempty"`
+	PreviousRatio *float64     `json:"previousRatio"`
+
+	// Ratio leftSets ÷ rightSets, null when the right side has no sets
+	Ratio     *float64 `json:"ratio"`
+	RightSets *int     `json:"rightSets,omitempty"`
+}
+
+// ChangePasswordRequest defines model for ChangePasswordRequest.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+// ComparedPeriod defines model for ComparedPeriod.
+type ComparedPeriod struct {
+	CompareTo *ReportCompareTo `json:"compareTo,omitempty"`
+	From      *time.Time       `json:"from,omitempty"`
+	To        *time.Time       `json:"to,omitempty"`
+}
+
 // CompleteWorkoutPlan defines model for CompleteWorkoutPlan.
 type CompleteWorkoutPlan struct {
 	Comment *string `json:"comment"`
 }
 
+// ConsistencyComparison compares the adherence of the report, which covers whole weeks
+type ConsistencyComparison struct {
+	CompareTo *ReportCompareTo `json:"compareTo,omitempty"`
+	Completed *Delta           `json:"completed,omitempty"`
+	From      *time.Time       `json:"from,omitempty"`
+	Missed    *Delta           `json:"missed,omitempty"`
+	Rate      *Delta           `json:"rate,omitempty"`
+	Scheduled *Delta           `json:"scheduled,omitempty"`
+	To        *time.Time       `json:"to,omitempty"`
+}
+
+// ConsistencyDay defines model for ConsistencyDay.
+type ConsistencyDay struct {
+	Completed *int                `json:"completed,omitempty"`
+	Date      *openapi_types.Date `json:"date,omitempty"`
+}
+
+// ConsistencyReport defines model for ConsistencyReport.
+type ConsistencyReport struct {
+	Adherence  *AdherenceTotals       `json:"adherence,omitempty"`
+	Comparison *ConsistencyComparison `json:"comparison,omitempty"`
+
+	// CurrentStreak weeks in a row up to the last one that reached the weekly target
+	CurrentStreak *int              `json:"currentStreak,omitempty"`
+	Days          *[]ConsistencyDay `json:"days,omitempty"`
+	From          *time.Time        `json:"from,omitempty"`
+	LongestStreak *int              `json:"longestStreak,omitempty"`
+
+	// Months the first and last month can be cut by the range
+	Months       *[]AdherencePeriod `json:"months,omitempty"`
+	TimeZone     *string            `json:"timeZone,omitempty"`
+	To           *time.Time         `json:"to,omitempty"`
+	WeeklyTarget *int               `json:"weeklyTarget,omitempty"`
+	Weeks        *[]ConsistencyWeek `json:"weeks,omitempty"`
+}
+
+// ConsistencyWeek defines model for ConsistencyWeek.
+type ConsistencyWeek struct {
+	Completed *int                `json:"completed,omitempty"`
+	Missed    *int                `json:"missed,omitempty"`
+	Pending   *int                `json:"pending,omitempty"`
+	Rate      *float64            `json:"rate"`
+	Scheduled *int                `json:"scheduled,omitempty"`
+	Start     *openapi_types.Date `json:"start,omitempty"`
+
+	// TargetMet the week has at least weeklyTarget completed workouts
+	TargetMet *bool `json:"targetMet,omitempty"`
+}
+
+// CreateAccessToken defines model for CreateAccessToken.
+type CreateAccessToken struct {
+	// ExpiresAt the token does not expire when not set
+	ExpiresAt *time.Time         `json:"expiresAt,omitempty"`
+	Name      string             `json:"name"`
+	Scopes    []AccessTokenScope `json:"scopes"`
+}
+
 // CreateExercisePlan defines model for CreateExercisePlan.
 type CreateExercisePlan struct {
 	ExerciseId  *int64      `json:"exerciseId,omitempty"`
@@ -71,12 +330,57 @@ type CreateExercisePlan struct {
 	Weights     *float32    `json:"weights,omitempty"`
 }
 
+// CreatePerformedSet defines model for CreatePerformedSet.
+type CreatePerformedSet struct {
+	// CompletedAt Defaults to the time the set is logged.
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	Repetitions int        `json:"repetitions"`
+	Rpe         *float32   `json:"rpe"`
+
+	// SetNumber Defaults to the next set number of the exercise plan.
+	SetNumber  *int       `json:"setNumber,omitempty"`
+	WeightUnit WeightUnit `json:"weightUnit"`
+	Weights    float32    `json:"weights"`
+}
+
 // CreateWorkoutPlan defines model for CreateWorkoutPlan.
 type CreateWorkoutPlan struct {
 	ExercisePlans *[]CreateExercisePlan `json:"exercisePlans,omitempty"`
 	ScheduledDate *time.Time            `json:"scheduledDate,omitempty"`
 }
 
+// CreateWorkoutSchedule defines model for CreateWorkoutSchedule.
+type CreateWorkoutSchedule struct {
+	ExercisePlans *[]CreateExercisePlan `json:"exercisePlans,omitempty"`
+	Recurrence    RecurrenceRule        `json:"recurrence"`
+}
+
+// CreateWorkoutTemplate defines model for CreateWorkoutTemplate.
+type CreateWorkoutTemplate struct {
+	Description   *string              `json:"description,omitempty"`
+	ExercisePlans []CreateExercisePlan `json:"exercisePlans"`
+	Name          string               `json:"name"`
+}
+
+// DeleteAccount defines model for DeleteAccount.
+type DeleteAccount struct {
+	// Code Code from the authenticator app or a recovery code, required with two-factor authentication
+	Code     *string `json:"code,omitempty"`
+	Password string  `json:"password"`
+}
+
+// Delta a value of the compared period and how much the report differs from it
+type Delta struct {
+	Change *float64 `json:"change,omitempty"`
+
+	// Percent change in percent of previous, null when previous is 0
+	Percent  *float64 `json:"percent"`
+	Previous *float64 `json:"previous,omitempty"`
+}
+
+// Equipment defines model for Equipment.
+type Equipment string
+
 // Error defines model for Error.
 type Error struct {
 	// Code A machine-readable error code.
@@ -88,16 +392,33 @@ type Error struct {
 
 // Exercise defines model for Exercise.
 type Exercise struct {
+	// ArchivedAt set when a custom exercise was deleted while still in use
+	ArchivedAt  *time.Time   `json:"archivedAt,omitempty"`
 	Description *string      `json:"description,omitempty"`
+	Equipment   *Equipment   `json:"equipment,omitempty"`
 	Id          *int64       `json:"id,omitempty"`
 	MuscleGroup *MuscleGroup `json:"muscleGroup,omitempty"`
 	Name        *string      `json:"name,omitempty"`
+
+	// OwnerId owner of a custom exercise, not set for global exercises
+	OwnerId *int64 `json:"ownerId,omitempty"`
+}
+
+// ExerciseInput defines model for ExerciseInput.
+type ExerciseInput struct {
+	Description *string     `json:"description,omitempty"`
+	Equipment   *Equipment  `json:"equipment,omitempty"`
+	MuscleGroup MuscleGroup `json:"muscleGroup"`
+	Name        string      `json:"name"`
 }
 
 // ExercisePlan defines model for ExercisePlan.
 type ExercisePlan struct {
-	ExerciseId    *int64      `json:"exerciseId,omitempty"`
-	Id            *int64      `json:"id,omitempty"`
+	ExerciseId *int64 `json:"exerciseId,omitempty"`
+	Id         *int64 `json:"id,omitempty"`
+
+	// Position 1-based order of the exercise plan inside its workout plan
+	Position      *int        `json:"position,omitempty"`
 	Repetitions   *int        `json:"repetitions,omitempty"`
 	Sets          *int        `json:"sets,omitempty"`
 	WeightUnit    *WeightUnit `json:"weightUnit,omitempty"`
@@ -105,13 +426,294 @@ type ExercisePlan struct {
 	WorkoutPlanId *int64      `json:"workoutPlanId,omitempty"`
 }
 
+// ExerciseVolume defines model for ExerciseVolume.
+type ExerciseVolume struct {
+	ExerciseId  *int64       `json:"exerciseId,omitempty"`
+	MuscleGroup *MuscleGroup `json:"muscleGroup,omitempty"`
+	Name        *string      `json:"name,omitempty"`
+	Repetitions *int         `json:"repetitions,omitempty"`
+	Sets        *int         `json:"sets,omitempty"`
+	Volume      *float64     `json:"volume,omitempty"`
+}
+
+// ExportFormat defines model for ExportFormat.
+type ExportFormat string
+
+// ExportedExercisePlan defines model for ExportedExercisePlan.
+type ExportedExercisePlan struct {
+	ExerciseId   *int64       `json:"exerciseId,omitempty"`
+	ExerciseName *string      `json:"exerciseName,omitempty"`
+	Id           *int64       `json:"id,omitempty"`
+	MuscleGroup  *MuscleGroup `json:"muscleGroup,omitempty"`
+
+	// Position 1-based order of the exercise plan inside its workout plan
+	Position    *int        `json:"position,omitempty"`
+	Repetitions *int        `json:"repetitions,omitempty"`
+	Sets        *int        `json:"sets,omitempty"`
+	WeightUnit  *WeightUnit `json:"weightUnit,omitempty"`
+	Weights     *float32    `json:"weights,omitempty"`
+}
+
+// ExportedWorkout a line of the jsonl export
+type ExportedWorkout struct {
+	Comment       *string                 `json:"comment"`
+	CreatedAt     *time.Time              `json:"createdAt,omitempty"`
+	ExercisePlans *[]ExportedExercisePlan `json:"exercisePlans,omitempty"`
+	Id            *int64                  `json:"id,omitempty"`
+
+	// ScheduledDate in the user's time zone
+	ScheduledDate *time.Time         `json:"scheduledDate,omitempty"`
+	Status        *WorkoutPlanStatus `json:"status,omitempty"`
+	UpdatedAt     *time.Time         `json:"updatedAt,omitempty"`
+}
+
+// Frequency defines model for Frequency.
+type Frequency string
+
+// InstantiateWorkoutTemplate defines model for InstantiateWorkoutTemplate.
+type InstantiateWorkoutTemplate struct {
+	ScheduledDate time.Time `json:"scheduledDate"`
+}
+
+// JWK Public key in the JSON Web Key format (RFC 7517).
+type JWK struct {
+	Alg *string `json:"alg,omitempty"`
+	Crv *string `json:"crv,omitempty"`
+	E   *string `json:"e,omitempty"`
+	Kid *string `json:"kid,omitempty"`
+	Kty *string `json:"kty,omitempty"`
+	N   *string `json:"n,omitempty"`
+	Use *string `json:"use,omitempty"`
+	X   *string `json:"x,omitempty"`
+	Y   *string `json:"y,omitempty"`
+}
+
+// JWKSet defines model for JWKSet.
+type JWKSet struct {
+	Keys *[]JWK `json:"keys,omitempty"`
+}
+
+// MissedWorkoutsJob defines model for MissedWorkoutsJob.
+type MissedWorkoutsJob struct {
+	// Cutoff pending workout plans scheduled before this time are marked as missed
+	Cutoff *time.Time `json:"cutoff,omitempty"`
+	Marked *int64     `json:"marked,omitempty"`
+}
+
+// MoveExercisePlan defines model for MoveExercisePlan.
+type MoveExercisePlan struct {
+	// Position 1-based position to move to
+	Position int `json:"position"`
+}
+
+// MuscleBalanceReport defines model for MuscleBalanceReport.
+type MuscleBalanceReport struct {
+	Comparison   *ComparedPeriod       `json:"comparison,omitempty"`
+	From         *time.Time            `json:"from,omitempty"`
+	MuscleGroups *[]MuscleGroupBalance `json:"muscleGroups,omitempty"`
+	Ratios       *[]BalanceRatio       `json:"ratios,omitempty"`
+	To           *time.Time            `json:"to,omitempty"`
+	Unit         *ReportUnit           `json:"unit,omitempty"`
+}
+
 // MuscleGroup defines model for MuscleGroup.
 type MuscleGroup string
 
+// MuscleGroupBalance defines model for MuscleGroupBalance.
+type MuscleGroupBalance struct {
+	Current      *VolumeTotals `json:"current,omitempty"`
+	MuscleGroup  *MuscleGroup  `json:"muscleGroup,omitempty"`
+	Previous     *VolumeTotals `json:"previous,omitempty"`
+	SetsChange   *Delta        `json:"setsChange,omitempty"`
+	VolumeChange *Delta        `json:"volumeChange,omitempty"`
+}
+
+// MuscleGroupVolume defines model for MuscleGroupVolume.
+type MuscleGroupVolume struct {
+	MuscleGroup *MuscleGroup `json:"muscleGroup,omitempty"`
+	Repetitions *int         `json:"repetitions,omitempty"`
+	Sets        *int         `json:"sets,omitempty"`
+	Volume      *float64     `json:"volume,omitempty"`
+}
+
+// OccurrenceScope defines model for OccurrenceScope.
+type OccurrenceScope string
+
+// OneRepMaxFormula epley is weight x (1 + reps / 30), brzycki weight x 36 / (37 - reps) and lombardi weight x reps^0.1
+type OneRepMaxFormula string
+
+// PasswordResetRequest defines model for PasswordResetRequest.
+type PasswordResetRequest struct {
+	Email openapi_types.Email `json:"email"`
+}
+
+// PerformedSet defines model for PerformedSet.
+type PerformedSet struct {
+	CompletedAt    *time.Time `json:"completedAt,omitempty"`
+	ExercisePlanId *int64     `json:"exercisePlanId,omitempty"`
+	Id             *int64     `json:"id,omitempty"`
+	Repetitions    *int       `json:"repetitions,omitempty"`
+
+	// Rpe Rate of perceived exertion, from 1 to 10.
+	Rpe        *float32    `json:"rpe"`
+	SetNumber  *int        `json:"setNumber,omitempty"`
+	WeightUnit *WeightUnit `json:"weightUnit,omitempty"`
+	Weights    *float32    `json:"weights,omitempty"`
+}
+
+// PersonalRecord defines model for PersonalRecord.
+type PersonalRecord struct {
+	AchievedAt *time.Time          `json:"achievedAt,omitempty"`
+	ExerciseId *int64              `json:"exerciseId,omitempty"`
+	Id         *int64              `json:"id,omitempty"`
+	Kind       *PersonalRecordKind `json:"kind,omitempty"`
+
+	// PreviousValue record that was beaten, null for the first record
+	PreviousValue *float64 `json:"previousValue"`
+
+	// Value kg for weight, estimated_1rm and volume, a count for reps
+	Value *float64 `json:"value,omitempty"`
+
+	// WeightKg weight the reps were done at, only set for reps records
+	WeightKg      *float64 `json:"weightKg"`
+	WorkoutPlanId *int64   `json:"workoutPlanId,omitempty"`
+}
+
+// PersonalRecordKind weight is the heaviest set, reps the most reps at one weight, estimated_1rm the best Epley estimate and volume the total weight lifted in a workout
+type PersonalRecordKind string
+
 // Progress defines model for Progress.
 type Progress struct {
-	CompletedWorkouts *int64 `json:"completedWorkouts,omitempty"`
-	TotalWorkouts     *int64 `json:"totalWorkouts,omitempty"`
+	Comparison        *ProgressComparison `json:"comparison,omitempty"`
+	CompletedWorkouts *int64              `json:"completedWorkouts,omitempty"`
+
+	// From null when every workout counts
+	From          *time.Time `json:"from"`
+	To            *time.Time `json:"to"`
+	TotalWorkouts *int64     `json:"totalWorkouts,omitempty"`
+}
+
+// ProgressComparison defines model for ProgressComparison.
+type ProgressComparison struct {
+	CompareTo         *ReportCompareTo `json:"compareTo,omitempty"`
+	CompletedWorkouts *Delta           `json:"completedWorkouts,omitempty"`
+	From              *time.Time       `json:"from,omitempty"`
+	To                *time.Time       `json:"to,omitempty"`
+	TotalWorkouts     *Delta           `json:"totalWorkouts,omitempty"`
+}
+
+// RecurrenceRule RRULE-style recurrence. Either until or count must be set.
+type RecurrenceRule struct {
+	Count     *int      `json:"count"`
+	Frequency Frequency `json:"frequency"`
+
+	// Interval Repeat every interval days, weeks or months.
+	Interval  int        `json:"interval"`
+	StartDate time.Time  `json:"startDate"`
+	Until     *time.Time `json:"until"`
+
+	// Weekdays Days of a weekly series. Defaults to the weekday of the start date.
+	Weekdays *[]Weekday `json:"weekdays,omitempty"`
+}
+
+// RefreshTokenRequest defines model for RefreshTokenRequest.
+type RefreshTokenRequest struct {
+	RefreshToken UserToken `json:"refreshToken"`
+}
+
+// ReportCompareTo previous is the period of the same length right before the report, previous_year the same dates a year earlier
+type ReportCompareTo string
+
+// ReportUnit defines model for ReportUnit.
+type ReportUnit string
+
+// ResendVerificationRequest defines model for ResendVerificationRequest.
+type ResendVerificationRequest struct {
+	Email openapi_types.Email `json:"email"`
+}
+
+// ResetPasswordRequest defines model for ResetPasswordRequest.
+type ResetPasswordRequest struct {
+	NewPassword string `json:"newPassword"`
+
+	// Token Token from the reset email
+	Token string `json:"token"`
+}
+
+// Role defines model for Role.
+type Role string
+
+// SaveWorkoutAsTemplate defines model for SaveWorkoutAsTemplate.
+type SaveWorkoutAsTemplate struct {
+	Description *string `json:"description,omitempty"`
+	Name        string  `json:"name"`
+}
+
+// Session defines model for Session.
+type Session struct {
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// Current Whether this is the session of the request
+	Current   *bool   `json:"current,omitempty"`
+	Id        *string `json:"id,omitempty"`
+	IpAddress *string `json:"ipAddress,omitempty"`
+
+	// LastJti ID of the latest access token issued for the session
+	LastJti *string `json:"lastJti,omitempty"`
+
+	// LastSeenAt Time of the login or of the latest token refresh
+	LastSeenAt *time.Time `json:"lastSeenAt,omitempty"`
+	UserAgent  *string    `json:"userAgent,omitempty"`
+}
+
+// StrengthComparison defines model for StrengthComparison.
+type StrengthComparison struct {
+	BestEstimatedOneRepMax *Delta           `json:"bestEstimatedOneRepMax,omitempty"`
+	CompareTo              *ReportCompareTo `json:"compareTo,omitempty"`
+	From                   *time.Time       `json:"from,omitempty"`
+	To                     *time.Time       `json:"to,omitempty"`
+	Workouts               *Delta           `json:"workouts,omitempty"`
+}
+
+// StrengthPoint defines model for StrengthPoint.
+type StrengthPoint struct {
+	Date               *time.Time `json:"date,omitempty"`
+	EstimatedOneRepMax *float64   `json:"estimatedOneRepMax,omitempty"`
+	Repetitions        *int       `json:"repetitions,omitempty"`
+
+	// RollingBest highest estimate of the four weeks up to this workout
+	RollingBest *float64 `json:"rollingBest,omitempty"`
+
+	// Weight weight of the set the estimate comes from
+	Weight        *float64 `json:"weight,omitempty"`
+	WorkoutPlanId *int64   `json:"workoutPlanId,omitempty"`
+}
+
+// StrengthReport defines model for StrengthReport.
+type StrengthReport struct {
+	Best       *StrengthPoint      `json:"best,omitempty"`
+	Comparison *StrengthComparison `json:"comparison,omitempty"`
+	ExerciseId *int64              `json:"exerciseId,omitempty"`
+	Formula    *OneRepMaxFormula   `json:"formula,omitempty"`
+	From       *time.Time          `json:"from,omitempty"`
+	Name       *string             `json:"name,omitempty"`
+	Points     *[]StrengthPoint    `json:"points,omitempty"`
+	To         *time.Time          `json:"to,omitempty"`
+	Trend      *StrengthTrend      `json:"trend,omitempty"`
+	Unit       *ReportUnit         `json:"unit,omitempty"`
+}
+
+// StrengthTrend defines model for StrengthTrend.
+type StrengthTrend struct {
+	// ChangePercent change of the line from the first to the last of those workouts
+	ChangePercent *float64 `json:"changePercent,omitempty"`
+
+	// Points workouts the line is fitted through
+	Points *int `json:"points,omitempty"`
+
+	// SlopePerWeek change of the estimate per week, in the unit of the report
+	SlopePerWeek *float64 `json:"slopePerWeek,omitempty"`
+	Weeks        *int     `json:"weeks,omitempty"`
 }
 
 // Success defines model for Success.
@@ -129,6 +731,52 @@ type Success struct {
 // SuccessCode A machine-readable error code.
 type SuccessCode string
 
+// SystemStats defines model for SystemStats.
+type SystemStats struct {
+	ActiveSessions *int `json:"activeSessions,omitempty"`
+	Admins         *int `json:"admins,omitempty"`
+
+	// CatalogExercises global exercises, archived ones included
+	CatalogExercises  *int `json:"catalogExercises,omitempty"`
+	CompletedWorkouts *int `json:"completedWorkouts,omitempty"`
+	CustomExercises   *int `json:"customExercises,omitempty"`
+	DisabledUsers     *int `json:"disabledUsers,omitempty"`
+	PerformedSets     *int `json:"performedSets,omitempty"`
+	Users             *int `json:"users,omitempty"`
+	VerifiedUsers     *int `json:"verifiedUsers,omitempty"`
+	WorkoutPlans      *int `json:"workoutPlans,omitempty"`
+}
+
+// TwoFactorCodeRequest defines model for TwoFactorCodeRequest.
+type TwoFactorCodeRequest struct {
+	// Code Code from the authenticator app or a recovery code
+	Code string `json:"code"`
+}
+
+// TwoFactorEnrollment defines model for TwoFactorEnrollment.
+type TwoFactorEnrollment struct {
+	// OtpauthUri URI to show as a QR code
+	OtpauthUri *string `json:"otpauthUri,omitempty"`
+
+	// Secret Base32 secret for manual entry
+	Secret *string `json:"secret,omitempty"`
+}
+
+// TwoFactorLoginRequest defines model for TwoFactorLoginRequest.
+type TwoFactorLoginRequest struct {
+	// ChallengeToken Token from the login response
+	ChallengeToken string `json:"challengeToken"`
+
+	// Code Code from the authenticator app or a recovery code
+	Code string `json:"code"`
+}
+
+// UpdateAccessToken defines model for UpdateAccessToken.
+type UpdateAccessToken struct {
+	Name   string             `json:"name"`
+	Scopes []AccessTokenScope `json:"scopes"`
+}
+
 // UpdateExercisePlan defines model for UpdateExercisePlan.
 type UpdateExercisePlan struct {
 	Id          *int64      `json:"id,omitempty"`
@@ -138,17 +786,58 @@ type UpdateExercisePlan struct {
 	Weights     *float32    `json:"weights,omitempty"`
 }
 
+// UpdatePerformedSet defines model for UpdatePerformedSet.
+type UpdatePerformedSet struct {
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	Repetitions int        `json:"repetitions"`
+
+	// Rpe Replaced like the other fields, leaving it out clears the RPE.
+	Rpe        *float32   `json:"rpe"`
+	WeightUnit WeightUnit `json:"weightUnit"`
+	Weights    float32    `json:"weights"`
+}
+
+// UpdateScheduleOccurrence defines model for UpdateScheduleOccurrence.
+type UpdateScheduleOccurrence struct {
+	// ExercisePlans Replaces the exercise plans of the edited occurrences when set.
+	ExercisePlans *[]CreateExercisePlan `json:"exercisePlans,omitempty"`
+	ScheduledDate *time.Time            `json:"scheduledDate,omitempty"`
+	Scope         OccurrenceScope       `json:"scope"`
+}
+
+// UpdateUserRole defines model for UpdateUserRole.
+type UpdateUserRole struct {
+	Role Role `json:"role"`
+}
+
+// UpdateWorkoutTemplate defines model for UpdateWorkoutTemplate.
+type UpdateWorkoutTemplate struct {
+	Description *string `json:"description,omitempty"`
+
+	// ExercisePlans Replaces the exercise plans of the template when set.
+	ExercisePlans *[]CreateExercisePlan `json:"exercisePlans,omitempty"`
+	Name          *string               `json:"name,omitempty"`
+}
+
 // UserLogin defines model for UserLogin.
 type UserLogin struct {
 	Email    openapi_types.Email `json:"email"`
 	Password string              `json:"password"`
 }
 
+// UserPreferences defines model for UserPreferences.
+type UserPreferences struct {
+	PreferredUnit ReportUnit `json:"preferredUnit"`
+
+	// TimeZone IANA time zone such as Europe/Berlin, UTC for new users. the stored zone is kept when not set
+	TimeZone *string `json:"timeZone,omitempty"`
+}
+
 // UserSignup defines model for UserSignup.
 type UserSignup struct {
 	Email    openapi_types.Email `json:"email"`
 	Name     string              `json:"name"`
-	Password *string             `json:"password,omitempty"`
+	Password string              `json:"password"`
 }
 
 // UserStatus defines model for UserStatus.
@@ -162,6 +851,59 @@ type UserStatus struct {
 // UserToken defines model for UserToken.
 type UserToken = string
 
+// VerifyEmailRequest defines model for VerifyEmailRequest.
+type VerifyEmailRequest struct {
+	// Token Token from the verification email
+	Token string `json:"token"`
+}
+
+// VolumeBucket defines model for VolumeBucket.
+type VolumeBucket string
+
+// VolumeComparison defines model for VolumeComparison.
+type VolumeComparison struct {
+	CompareTo   *ReportCompareTo `json:"compareTo,omitempty"`
+	From        *time.Time       `json:"from,omitempty"`
+	Repetitions *Delta           `json:"repetitions,omitempty"`
+	Sets        *Delta           `json:"sets,omitempty"`
+	To          *time.Time       `json:"to,omitempty"`
+	Volume      *Delta           `json:"volume,omitempty"`
+}
+
+// VolumePeriod defines model for VolumePeriod.
+type VolumePeriod struct {
+	Exercises    *[]ExerciseVolume    `json:"exercises,omitempty"`
+	MuscleGroups *[]MuscleGroupVolume `json:"muscleGroups,omitempty"`
+	Repetitions  *int                 `json:"repetitions,omitempty"`
+	Sets         *int                 `json:"sets,omitempty"`
+	Start        *time.Time           `json:"start,omitempty"`
+	Volume       *float64             `json:"volume,omitempty"`
+}
+
+// VolumeReport defines model for VolumeReport.
+type VolumeReport struct {
+	Bucket     *VolumeBucket     `json:"bucket,omitempty"`
+	Comparison *VolumeComparison `json:"comparison,omitempty"`
+	From       *time.Time        `json:"from,omitempty"`
+
+	// Periods periods without completed workouts are left out
+	Periods  *[]VolumePeriod `json:"periods,omitempty"`
+	TimeZone *string         `json:"timeZone,omitempty"`
+	To       *time.Time      `json:"to,omitempty"`
+	Totals   *VolumeTotals   `json:"totals,omitempty"`
+	Unit     *ReportUnit     `json:"unit,omitempty"`
+}
+
+// VolumeTotals defines model for VolumeTotals.
+type VolumeTotals struct {
+	Repetitions *int     `json:"repetitions,omitempty"`
+	Sets        *int     `json:"sets,omitempty"`
+	Volume      *float64 `json:"volume,omitempty"`
+}
+
+// Weekday defines model for Weekday.
+type Weekday string
+
 // WeightUnit defines model for WeightUnit.
 type WeightUnit string
 
@@ -180,18 +922,54 @@ type WorkoutPlan struct {
 // WorkoutPlanStatus defines model for WorkoutPlanStatus.
 type WorkoutPlanStatus string
 
+// WorkoutSchedule defines model for WorkoutSchedule.
+type WorkoutSchedule struct {
+	CancelledAt  *time.Time      `json:"cancelledAt"`
+	CreatedAt    *time.Time      `json:"createdAt,omitempty"`
+	Id           *int64          `json:"id,omitempty"`
+	Recurrence   *RecurrenceRule `json:"recurrence,omitempty"`
+	Rrule        *string         `json:"rrule,omitempty"`
+	UpdatedAt    *time.Time      `json:"updatedAt,omitempty"`
+	UserId       *int64          `json:"userId,omitempty"`
+	WorkoutPlans *[]WorkoutPlan  `json:"workoutPlans,omitempty"`
+}
+
+// WorkoutTemplate defines model for WorkoutTemplate.
+type WorkoutTemplate struct {
+	CreatedAt   *time.Time `json:"createdAt,omitempty"`
+	Description *string    `json:"description"`
+
+	// ExercisePlans Exercise plans in the order they are performed.
+	ExercisePlans *[]CreateExercisePlan `json:"exercisePlans,omitempty"`
+	Id            *int64                `json:"id,omitempty"`
+	Name          *string               `json:"name,omitempty"`
+	UpdatedAt     *time.Time            `json:"updatedAt,omitempty"`
+	UserId        *int64                `json:"userId,omitempty"`
+}
+
 // Forbidden defines model for Forbidden.
 type Forbidden = Error
 
+// ForeignKeyViolation defines model for ForeignKeyViolation.
+type ForeignKeyViolation = Error
+
 // InvalidInput defines model for InvalidInput.
 type InvalidInput = Error
 
 // NotFound defines model for NotFound.
 type NotFound = Error
 
+// TooManyRequests defines model for TooManyRequests.
+type TooManyRequests = Error
+
 // Unathorited defines model for Unathorited.
 type Unathorited = Error
 
+// CancelSchedule defines model for CancelSchedule.
+type CancelSchedule struct {
+	From *time.Time `json:"from"`
+}
+
 // ScheduleWorkoutPlan defines model for ScheduleWorkoutPlan.
 type ScheduleWorkoutPlan struct {
 	ScheduledDate *time.Time `json:"scheduledDate,omitempty"`
@@ -202,103 +980,2275 @@ type UpdateExercisePlans struct {
 	ExercisePlans *[]UpdateExercisePlan `json:"exercisePlans,omitempty"`
 }
 
-// ListWorkoutPlansParams defines parameters for ListWorkoutPlans.
-type ListWorkoutPlansParams struct {
-	// Status Filter workout plans by status
-	Status *WorkoutPlanStatus `form:"status,omitempty" json:"status,omitempty"`
+// ListUsersParams defines parameters for ListUsers.
+type ListUsersParams struct {
+	// Email case-insensitive search in the email address
+	Email *string `form:"email,omitempty" json:"email,omitempty"`
 
-	// Sort Sort the results by scheduled data and time
-	Sort ListWorkoutPlansParamsSort `form:"sort" json:"sort"`
+	// Limit page size
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset number of users to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
 }
 
-// ListWorkoutPlansParamsSort defines parameters for ListWorkoutPlans.
-type ListWorkoutPlansParamsSort string
+// ListExercisesParams defines parameters for ListExercises.
+type ListExercisesParams struct {
+	// Q case-insensitive name search, matches prefix, substring and similar spellings
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
 
-// ScheduleWorkoutPlanbyIdJSONBody defines parameters for ScheduleWorkoutPlanbyId.
-type ScheduleWorkoutPlanbyIdJSONBody struct {
-	ScheduledDate *time.Time `json:"scheduledDate,omitempty"`
+	// MuscleGroup only exercises of one of these muscle groups, repeat the parameter for several values
+	MuscleGroup *[]MuscleGroup `form:"muscleGroup,omitempty" json:"muscleGroup,omitempty"`
+
+	// Equipment only exercises using one of these equipment, repeat the parameter for several values
+	Equipment *[]Equipment `form:"equipment,omitempty" json:"equipment,omitempty"`
+
+	// Cursor nextCursor of the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit page size
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
-// UpdateExercisePlansInWorkoutPlanJSONBody defines parameters for UpdateExercisePlansInWorkoutPlan.
-type UpdateExercisePlansInWorkoutPlanJSONBody struct {
-	ExercisePlans *[]UpdateExercisePlan `json:"exercisePlans,omitempty"`
+// ExportWorkoutsParams defines parameters for ExportWorkouts.
+type ExportWorkoutsParams struct {
+	// Format file format of the download
+	Format ExportFormat `form:"format" json:"format"`
+
+	// From only workout plans scheduled from then on, as an RFC 3339 date-time or a date in the user's time zone
+	From *string `form:"from,omitempty" json:"from,omitempty"`
+
+	// To only workout plans scheduled before then, as an RFC 3339 date-time or a date in the user's time zone
+	To *string `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ReportConsistencyParams defines parameters for ReportConsistency.
+type ReportConsistencyParams struct {
+	// From start of the range, inclusive, as an RFC 3339 date-time or a date in the user's time zone. 52 weeks before to when not set
+	From *string `form:"from,omitempty" json:"from,omitempty"`
+
+	// To end of the range, exclusive, as an RFC 3339 date-time or a date in the user's time zone. the end of the current week when not set, at most two years after from
+	To *string `form:"to,omitempty" json:"to,omitempty"`
+
+	// CompareTo period to compare with, no comparison when not set
+	CompareTo *ReportCompareTo `form:"compareTo,omitempty" json:"compareTo,omitempty"`
+
+	// WeeklyTarget completed workouts a week needs to count for a streak
+	WeeklyTarget *int `form:"weeklyTarget,omitempty" json:"weeklyTarget,omitempty"`
+}
+
+// ReportStrengthParams defines parameters for ReportStrength.
+type ReportStrengthParams struct {
+	// From start of the range, inclusive, as an RFC 3339 date-time or a date in the user's time zone. 52 weeks before to when not set
+	From *string `form:"from,omitempty" json:"from,omitempty"`
+
+	// To end of the range, exclusive, as an RFC 3339 date-time or a date in the user's time zone. now when not set, at most two years after from
+	To *string `form:"to,omitempty" json:"to,omitempty"`
+
+	// CompareTo period to compare with, no comparison when not set
+	CompareTo *ReportCompareTo  `form:"compareTo,omitempty" json:"compareTo,omitempty"`
+	Formula   *OneRepMaxFormula `form:"formula,omitempty" json:"formula,omitempty"`
+
+	// Unit unit of the weights, the preferred unit of the user when not set
+	Unit *ReportUnit `form:"unit,omitempty" json:"unit,omitempty"`
+
+	// TrendWeeks weeks before to the trend covers
+	TrendWeeks *int `form:"trendWeeks,omitempty" json:"trendWeeks,omitempty"`
 }
 
-// LoginUserJSONRequestBody defines body for LoginUser for application/json ContentType.
-type LoginUserJSONRequestBody = UserLogin
+// ReportMuscleBalanceParams defines parameters for ReportMuscleBalance.
+type ReportMuscleBalanceParams struct {
+	// From start of the range, inclusive, as an RFC 3339 date-time or a date in the user's time zone. four weeks before to when not set
+	From *string `form:"from,omitempty" json:"from,omitempty"`
+
+	// To end of the range, exclusive, as an RFC 3339 date-time or a date in the user's time zone. now when not set, at most one year after from
+	To *string `form:"to,omitempty" json:"to,omitempty"`
+
+	// CompareTo period to compare with
+	CompareTo *ReportCompareTo `form:"compareTo,omitempty" json:"compareTo,omitempty"`
+
+	// Unit unit of the volume, the preferred unit of the user when not set
+	Unit *ReportUnit `form:"unit,omitempty" json:"unit,omitempty"`
+}
+
+// ReportPersonalRecordsParams defines parameters for ReportPersonalRecords.
+type ReportPersonalRecordsParams struct {
+	// ExerciseId only records of this exercise
+	ExerciseId *int64 `form:"exerciseId,omitempty" json:"exerciseId,omitempty"`
+}
+
+// ReportProgressParams defines parameters for ReportProgress.
+type ReportProgressParams struct {
+	// From start of the range, inclusive, as an RFC 3339 date-time or a date in the user's time zone. every workout counts when neither from, to nor compareTo is set, otherwise it is required
+	From *string `form:"from,omitempty" json:"from,omitempty"`
+
+	// To end of the range, exclusive, as an RFC 3339 date-time or a date in the user's time zone. now when not set
+	To *string `form:"to,omitempty" json:"to,omitempty"`
+
+	// CompareTo period to compare with, no comparison when not set
+	CompareTo *ReportCompareTo `form:"compareTo,omitempty" json:"compareTo,omitempty"`
+}
+
+// ReportVolumeParams defines parameters for ReportVolume.
+type ReportVolumeParams struct {
+	// From start of the range, inclusive, as an RFC 3339 date-time or a date in the user's time zone
+	From string `form:"from" json:"from"`
+
+	// To end of the range, exclusive, as an RFC 3339 date-time or a date in the user's time zone. at most two years after from
+	To string `form:"to" json:"to"`
+
+	// CompareTo period to compare with, no comparison when not set
+	CompareTo *ReportCompareTo `form:"compareTo,omitempty" json:"compareTo,omitempty"`
+
+	// Bucket period length, periods start at midnight in the user's time zone and weeks on monday
+	Bucket *VolumeBucket `form:"bucket,omitempty" json:"bucket,omitempty"`
+
+	// Unit unit of the volume, the preferred unit of the user when not set
+	Unit *ReportUnit `form:"unit,omitempty" json:"unit,omitempty"`
+}
+
+// CancelScheduleJSONBody defines parameters for CancelSchedule.
+type CancelScheduleJSONBody struct {
+	From *time.Time `json:"from"`
+}
+
+// ListWorkoutPlansParams defines parameters for ListWorkoutPlans.
+type ListWorkoutPlansParams struct {
+	// Status Filter workout plans by status
+	Status *WorkoutPlanStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Sort Sort the results by scheduled data and time
+	Sort ListWorkoutPlansParamsSort `form:"sort" json:"sort"`
+}
+
+// ListWorkoutPlansParamsSort defines parameters for ListWorkoutPlans.
+type ListWorkoutPlansParamsSort string
+
+// ScheduleWorkoutPlanByIdJSONBody defines parameters for ScheduleWorkoutPlanById.
+type ScheduleWorkoutPlanByIdJSONBody struct {
+	ScheduledDate *time.Time `json:"scheduledDate,omitempty"`
+}
+
+// UpdateExercisePlansInWorkoutPlanJSONBody defines parameters for UpdateExercisePlansInWorkoutPlan.
+type UpdateExercisePlansInWorkoutPlanJSONBody struct {
+	ExercisePlans *[]UpdateExercisePlan `json:"exercisePlans,omitempty"`
+}
+
+// CreateCatalogExerciseJSONRequestBody defines body for CreateCatalogExercise for application/json ContentType.
+type CreateCatalogExerciseJSONRequestBody = ExerciseInput
+
+// UpdateCatalogExerciseJSONRequestBody defines body for UpdateCatalogExercise for application/json ContentType.
+type UpdateCatalogExerciseJSONRequestBody = ExerciseInput
+
+// UpdateUserRoleJSONRequestBody defines body for UpdateUserRole for application/json ContentType.
+type UpdateUserRoleJSONRequestBody = UpdateUserRole
+
+// CreateExerciseJSONRequestBody defines body for CreateExercise for application/json ContentType.
+type CreateExerciseJSONRequestBody = ExerciseInput
+
+// UpdateExerciseJSONRequestBody defines body for UpdateExercise for application/json ContentType.
+type UpdateExerciseJSONRequestBody = ExerciseInput
+
+// CreateScheduleJSONRequestBody defines body for CreateSchedule for application/json ContentType.
+type CreateScheduleJSONRequestBody = CreateWorkoutSchedule
+
+// PreviewScheduleJSONRequestBody defines body for PreviewSchedule for application/json ContentType.
+type PreviewScheduleJSONRequestBody = RecurrenceRule
+
+// CancelScheduleJSONRequestBody defines body for CancelSchedule for application/json ContentType.
+type CancelScheduleJSONRequestBody CancelScheduleJSONBody
+
+// UpdateScheduleOccurrenceJSONRequestBody defines body for UpdateScheduleOccurrence for application/json ContentType.
+type UpdateScheduleOccurrenceJSONRequestBody = UpdateScheduleOccurrence
+
+// CreateTemplateJSONRequestBody defines body for CreateTemplate for application/json ContentType.
+type CreateTemplateJSONRequestBody = CreateWorkoutTemplate
+
+// UpdateTemplateJSONRequestBody defines body for UpdateTemplate for application/json ContentType.
+type UpdateTemplateJSONRequestBody = UpdateWorkoutTemplate
+
+// InstantiateTemplateJSONRequestBody defines body for InstantiateTemplate for application/json ContentType.
+type InstantiateTemplateJSONRequestBody = InstantiateWorkoutTemplate
+
+// DeleteUserJSONRequestBody defines body for DeleteUser for application/json ContentType.
+type DeleteUserJSONRequestBody = DeleteAccount
+
+// DisableTwoFactorJSONRequestBody defines body for DisableTwoFactor for application/json ContentType.
+type DisableTwoFactorJSONRequestBody = TwoFactorCodeRequest
+
+// ConfirmTwoFactorJSONRequestBody defines body for ConfirmTwoFactor for application/json ContentType.
+type ConfirmTwoFactorJSONRequestBody = TwoFactorCodeRequest
+
+// CancelUserDeletionJSONRequestBody defines body for CancelUserDeletion for application/json ContentType.
+type CancelUserDeletionJSONRequestBody = UserLogin
+
+// LoginUserJSONRequestBody defines body for LoginUser for application/json ContentType.
+type LoginUserJSONRequestBody = UserLogin
+
+// CompleteTwoFactorLoginJSONRequestBody defines body for CompleteTwoFactorLogin for application/json ContentType.
+type CompleteTwoFactorLoginJSONRequestBody = TwoFactorLoginRequest
+
+// ChangeUserPasswordJSONRequestBody defines body for ChangeUserPassword for application/json ContentType.
+type ChangeUserPasswordJSONRequestBody = ChangePasswordRequest
+
+// ResetUserPasswordJSONRequestBody defines body for ResetUserPassword for application/json ContentType.
+type ResetUserPasswordJSONRequestBody = ResetPasswordRequest
+
+// RequestPasswordResetJSONRequestBody defines body for RequestPasswordReset for application/json ContentType.
+type RequestPasswordResetJSONRequestBody = PasswordResetRequest
+
+// UpdateUserPreferencesJSONRequestBody defines body for UpdateUserPreferences for application/json ContentType.
+type UpdateUserPreferencesJSONRequestBody = UserPreferences
+
+// SignupUserJSONRequestBody defines body for SignupUser for application/json ContentType.
+type SignupUserJSONRequestBody = UserSignup
+
+// RefreshUserTokenJSONRequestBody defines body for RefreshUserToken for application/json ContentType.
+type RefreshUserTokenJSONRequestBody = RefreshTokenRequest
+
+// CreateAccessTokenJSONRequestBody defines body for CreateAccessToken for application/json ContentType.
+type CreateAccessTokenJSONRequestBody = CreateAccessToken
+
+// UpdateAccessTokenJSONRequestBody defines body for UpdateAccessToken for application/json ContentType.
+type UpdateAccessTokenJSONRequestBody = UpdateAccessToken
+
+// VerifyUserEmailJSONRequestBody defines body for VerifyUserEmail for application/json ContentType.
+type VerifyUserEmailJSONRequestBody = VerifyEmailRequest
+
+// ResendVerificationEmailJSONRequestBody defines body for ResendVerificationEmail for application/json ContentType.
+type ResendVerificationEmailJSONRequestBody = ResendVerificationRequest
+
+// CreateWorkoutPlanJSONRequestBody defines body for CreateWorkoutPlan for application/json ContentType.
+type CreateWorkoutPlanJSONRequestBody = CreateWorkoutPlan
+
+// CompleteWorkoutPlanByIdJSONRequestBody defines body for CompleteWorkoutPlanById for application/json ContentType.
+type CompleteWorkoutPlanByIdJSONRequestBody = CompleteWorkoutPlan
+
+// AddExercisePlanJSONRequestBody defines body for AddExercisePlan for application/json ContentType.
+type AddExercisePlanJSONRequestBody = AddExercisePlan
+
+// MoveExercisePlanJSONRequestBody defines body for MoveExercisePlan for application/json ContentType.
+type MoveExercisePlanJSONRequestBody = MoveExercisePlan
+
+// LogPerformedSetJSONRequestBody defines body for LogPerformedSet for application/json ContentType.
+type LogPerformedSetJSONRequestBody = CreatePerformedSet
+
+// UpdatePerformedSetJSONRequestBody defines body for UpdatePerformedSet for application/json ContentType.
+type UpdatePerformedSetJSONRequestBody = UpdatePerformedSet
+
+// SaveWorkoutAsTemplateJSONRequestBody defines body for SaveWorkoutAsTemplate for application/json ContentType.
+type SaveWorkoutAsTemplateJSONRequestBody = SaveWorkoutAsTemplate
+
+// ScheduleWorkoutPlanByIdJSONRequestBody defines body for ScheduleWorkoutPlanById for application/json ContentType.
+type ScheduleWorkoutPlanByIdJSONRequestBody ScheduleWorkoutPlanByIdJSONBody
+
+// UpdateExercisePlansInWorkoutPlanJSONRequestBody defines body for UpdateExercisePlansInWorkoutPlan for application/json ContentType.
+type UpdateExercisePlansInWorkoutPlanJSONRequestBody UpdateExercisePlansInWorkoutPlanJSONBody
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Public keys access tokens can be verified with.
+	// (GET /.well-known/jwks.json)
+	GetJwks(w http.ResponseWriter, r *http.Request)
+	// create a global exercise
+	// (POST /admin/exercises)
+	CreateCatalogExercise(w http.ResponseWriter, r *http.Request)
+	// delete a global exercise
+	// (DELETE /admin/exercises/{exerciseId})
+	DeleteCatalogExercise(w http.ResponseWriter, r *http.Request, exerciseId int64)
+	// update a global exercise
+	// (PUT /admin/exercises/{exerciseId})
+	UpdateCatalogExercise(w http.ResponseWriter, r *http.Request, exerciseId int64)
+	// get system statistics
+	// (GET /admin/stats)
+	GetSystemStats(w http.ResponseWriter, r *http.Request)
+	// list users
+	// (GET /admin/users)
+	ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams)
+	// disable a user
+	// (PUT /admin/users/{userId}/disable)
+	DisableUser(w http.ResponseWriter, r *http.Request, userId int64)
+	// enable a user
+	// (PUT /admin/users/{userId}/enable)
+	EnableUser(w http.ResponseWriter, r *http.Request, userId int64)
+	// change the role of a user
+	// (PUT /admin/users/{userId}/role)
+	UpdateUserRole(w http.ResponseWriter, r *http.Request, userId int64)
+	// unlock the login of a user
+	// (POST /admin/users/{userId}/unlock)
+	UnlockUserAccount(w http.ResponseWriter, r *http.Request, userId int64)
+	// Search exercises
+	// (GET /exercises)
+	ListExercises(w http.ResponseWriter, r *http.Request, params ListExercisesParams)
+	// create a custom exercise
+	// (POST /exercises)
+	CreateExercise(w http.ResponseWriter, r *http.Request)
+	// delete a custom exercise
+	// (DELETE /exercises/{exerciseId})
+	DeleteExercise(w http.ResponseWriter, r *http.Request, exerciseId int64)
+	// get an exercise by a specific id
+	// (GET /exercises/{exerciseId})
+	GetExerciseById(w http.ResponseWriter, r *http.Request, exerciseId int64)
+	// update a custom exercise
+	// (PUT /exercises/{exerciseId})
+	UpdateExercise(w http.ResponseWriter, r *http.Request, exerciseId int64)
+	// download the workout history
+	// (GET /export/workouts)
+	ExportWorkouts(w http.ResponseWriter, r *http.Request, params ExportWorkoutsParams)
+	// mark overdue workout plans as missed
+	// (POST /jobs/missed-workouts)
+	TriggerMissedWorkouts(w http.ResponseWriter, r *http.Request)
+	// workout streaks and adherence
+	// (GET /report/consistency)
+	ReportConsistency(w http.ResponseWriter, r *http.Request, params ReportConsistencyParams)
+	// estimated one rep max over time
+	// (GET /report/exercises/{exerciseId}/strength)
+	ReportStrength(w http.ResponseWriter, r *http.Request, exerciseId int64, params ReportStrengthParams)
+	// balance between muscle groups
+	// (GET /report/muscle-balance)
+	ReportMuscleBalance(w http.ResponseWriter, r *http.Request, params ReportMuscleBalanceParams)
+	// list personal records
+	// (GET /report/personal-records)
+	ReportPersonalRecords(w http.ResponseWriter, r *http.Request, params ReportPersonalRecordsParams)
+	// generate report on workout
+	// (GET /report/progress)
+	ReportProgress(w http.ResponseWriter, r *http.Request, params ReportProgressParams)
+	// training volume over time
+	// (GET /report/volume)
+	ReportVolume(w http.ResponseWriter, r *http.Request, params ReportVolumeParams)
+	// list workout schedules
+	// (GET /schedules)
+	ListSchedules(w http.ResponseWriter, r *http.Request)
+	// create a workout schedule
+	// (POST /schedules)
+	CreateSchedule(w http.ResponseWriter, r *http.Request)
+	// preview a workout schedule
+	// (POST /schedules/preview)
+	PreviewSchedule(w http.ResponseWriter, r *http.Request)
+	// get a workout schedule by a specific id
+	// (GET /schedules/{scheduleId})
+	GetScheduleById(w http.ResponseWriter, r *http.Request, scheduleId int64)
+	// cancel a workout schedule
+	// (PUT /schedules/{scheduleId}/cancel)
+	CancelSchedule(w http.ResponseWriter, r *http.Request, scheduleId int64)
+	// edit an occurrence of a workout schedule
+	// (PUT /schedules/{scheduleId}/occurrences/{workoutId})
+	UpdateScheduleOccurrence(w http.ResponseWriter, r *http.Request, scheduleId int64, workoutId int64)
+	// list workout templates
+	// (GET /templates)
+	ListTemplates(w http.ResponseWriter, r *http.Request)
+	// create a workout template
+	// (POST /templates)
+	CreateTemplate(w http.ResponseWriter, r *http.Request)
+	// delete a workout template by a specific id
+	// (DELETE /templates/{templateId})
+	DeleteTemplateById(w http.ResponseWriter, r *http.Request, templateId int64)
+	// get a workout template by a specific id
+	// (GET /templates/{templateId})
+	GetTemplateById(w http.ResponseWriter, r *http.Request, templateId int64)
+	// update a workout template
+	// (PUT /templates/{templateId})
+	UpdateTemplate(w http.ResponseWriter, r *http.Request, templateId int64)
+	// create a workout plan from a template
+	// (POST /templates/{templateId}/instantiate)
+	InstantiateTemplate(w http.ResponseWriter, r *http.Request, templateId int64)
+	// Delete the account.
+	// (DELETE /user)
+	DeleteUser(w http.ResponseWriter, r *http.Request)
+	// Disable two-factor authentication.
+	// (DELETE /user/2fa)
+	DisableTwoFactor(w http.ResponseWriter, r *http.Request)
+	// Enable two-factor authentication with the first code of the enrolled secret.
+	// (POST /user/2fa/confirm)
+	ConfirmTwoFactor(w http.ResponseWriter, r *http.Request)
+	// Start enrolling a TOTP authenticator.
+	// (POST /user/2fa/enroll)
+	EnrollTwoFactor(w http.ResponseWriter, r *http.Request)
+	// Cancel the deletion of the account.
+	// (POST /user/deletion/cancel)
+	CancelUserDeletion(w http.ResponseWriter, r *http.Request)
+	// Authenticate user and get an access token.
+	// (POST /user/login)
+	LoginUser(w http.ResponseWriter, r *http.Request)
+	// Finish a login with a two-factor code.
+	// (POST /user/login/2fa)
+	CompleteTwoFactorLogin(w http.ResponseWriter, r *http.Request)
+	// Logs out current logged in user.
+	// (POST /user/logout)
+	LogoutUser(w http.ResponseWriter, r *http.Request)
+	// Change the password of the user.
+	// (PUT /user/password)
+	ChangeUserPassword(w http.ResponseWriter, r *http.Request)
+	// Set a new password with a reset token.
+	// (POST /user/password/reset)
+	ResetUserPassword(w http.ResponseWriter, r *http.Request)
+	// Ask for a password reset email.
+	// (POST /user/password/reset/request)
+	RequestPasswordReset(w http.ResponseWriter, r *http.Request)
+	// update user preferences
+	// (PUT /user/preferences)
+	UpdateUserPreferences(w http.ResponseWriter, r *http.Request)
+	// Log out everywhere.
+	// (DELETE /user/sessions)
+	RevokeAllUserSessions(w http.ResponseWriter, r *http.Request)
+	// List the active logins of the user.
+	// (GET /user/sessions)
+	ListUserSessions(w http.ResponseWriter, r *http.Request)
+	// Revoke one session of the user.
+	// (DELETE /user/sessions/{sessionId})
+	RevokeUserSession(w http.ResponseWriter, r *http.Request, sessionId string)
+	// Register a new user.
+	// (POST /user/signup)
+	SignupUser(w http.ResponseWriter, r *http.Request)
+	// Get user information.
+	// (GET /user/status)
+	GetUserStatus(w http.ResponseWriter, r *http.Request)
+	// Exchange a refresh token for a new access token.
+	// (POST /user/token/refresh)
+	RefreshUserToken(w http.ResponseWriter, r *http.Request)
+	// List the personal access tokens of the user.
+	// (GET /user/tokens)
+	ListAccessTokens(w http.ResponseWriter, r *http.Request)
+	// Create a personal access token.
+	// (POST /user/tokens)
+	CreateAccessToken(w http.ResponseWriter, r *http.Request)
+	// Delete a personal access token.
+	// (DELETE /user/tokens/{tokenId})
+	DeleteAccessToken(w http.ResponseWriter, r *http.Request, tokenId int64)
+	// Get a personal access token.
+	// (GET /user/tokens/{tokenId})
+	GetAccessToken(w http.ResponseWriter, r *http.Request, tokenId int64)
+	// Update a personal access token.
+	// (PUT /user/tokens/{tokenId})
+	UpdateAccessToken(w http.ResponseWriter, r *http.Request, tokenId int64)
+	// Confirm the email address with the token from the verification email.
+	// (POST /user/verify-email)
+	VerifyUserEmail(w http.ResponseWriter, r *http.Request)
+	// Send a new verification email.
+	// (POST /user/verify-email/resend)
+	ResendVerificationEmail(w http.ResponseWriter, r *http.Request)
+	// List workout plans
+	// (GET /workouts)
+	ListWorkoutPlans(w http.ResponseWriter, r *http.Request, params ListWorkoutPlansParams)
+	// create a workout plan
+	// (POST /workouts)
+	CreateWorkoutPlan(w http.ResponseWriter, r *http.Request)
+	// delete a workout plan by a specific id
+	// (DELETE /workouts/{workoutId})
+	DeleteWorkoutPlanById(w http.ResponseWriter, r *http.Request, workoutId int64)
+	// get a workout plan by a specific id
+	// (GET /workouts/{workoutId})
+	GetWorkoutPlanById(w http.ResponseWriter, r *http.Request, workoutId int64)
+	// complete a workout plan by a specific id
+	// (PUT /workouts/{workoutId}/complete)
+	CompleteWorkoutPlanById(w http.ResponseWriter, r *http.Request, workoutId int64)
+	// add an exercise plan
+	// (POST /workouts/{workoutId}/exercise-plans)
+	AddExercisePlan(w http.ResponseWriter, r *http.Request, workoutId int64)
+	// remove an exercise plan
+	// (DELETE /workouts/{workoutId}/exercise-plans/{exercisePlanId})
+	RemoveExercisePlan(w http.ResponseWriter, r *http.Request, workoutId int64, exercisePlanId int64)
+	// move an exercise plan
+	// (PUT /workouts/{workoutId}/exercise-plans/{exercisePlanId}/move)
+	MoveExercisePlan(w http.ResponseWriter, r *http.Request, workoutId int64, exercisePlanId int64)
+	// list performed sets of an exercise plan
+	// (GET /workouts/{workoutId}/exercise-plans/{exercisePlanId}/sets)
+	ListPerformedSets(w http.ResponseWriter, r *http.Request, workoutId int64, exercisePlanId int64)
+	// log a performed set
+	// (POST /workouts/{workoutId}/exercise-plans/{exercisePlanId}/sets)
+	LogPerformedSet(w http.ResponseWriter, r *http.Request, workoutId int64, exercisePlanId int64)
+	// delete a performed set
+	// (DELETE /workouts/{workoutId}/exercise-plans/{exercisePlanId}/sets/{setId})
+	DeletePerformedSet(w http.ResponseWriter, r *http.Request, workoutId int64, exercisePlanId int64, setId int64)
+	// update a performed set
+	// (PUT /workouts/{workoutId}/exercise-plans/{exercisePlanId}/sets/{setId})
+	UpdatePerformedSet(w http.ResponseWriter, r *http.Request, workoutId int64, exercisePlanId int64, setId int64)
+	// save a workout plan as a template
+	// (POST /workouts/{workoutId}/save-as-template)
+	SaveWorkoutAsTemplate(w http.ResponseWriter, r *http.Request, workoutId int64)
+	// schedule a workout plan by a specific id
+	// (PUT /workouts/{workoutId}/schedule)
+	ScheduleWorkoutPlanById(w http.ResponseWriter, r *http.Request, workoutId int64)
+	// update exercise plans
+	// (PUT /workouts/{workoutId}/update-exercise-plans)
+	UpdateExercisePlansInWorkoutPlan(w http.ResponseWriter, r *http.Request, workoutId int64)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetJwks operation middleware
+func (siw *ServerInterfaceWrapper) GetJwks(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetJwks(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateCatalogExercise operation middleware
+func (siw *ServerInterfaceWrapper) CreateCatalogExercise(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateCatalogExercise(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteCatalogExercise operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCatalogExercise(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "exerciseId" -------------
+	var exerciseId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "exerciseId", r.PathValue("exerciseId"), &exerciseId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "exerciseId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteCatalogExercise(w, r, exerciseId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateCatalogExercise operation middleware
+func (siw *ServerInterfaceWrapper) UpdateCatalogExercise(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "exerciseId" -------------
+	var exerciseId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "exerciseId", r.PathValue("exerciseId"), &exerciseId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "exerciseId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateCatalogExercise(w, r, exerciseId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSystemStats operation middleware
+func (siw *ServerInterfaceWrapper) GetSystemStats(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSystemStats(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListUsers operation middleware
+func (siw *ServerInterfaceWrapper) ListUsers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListUsersParams
+
+	// ------------- Optional query parameter "email" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "email", r.URL.Query(), &params.Email)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUsers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DisableUser operation middleware
+func (siw *ServerInterfaceWrapper) DisableUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", r.PathValue("userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DisableUser(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// EnableUser operation middleware
+func (siw *ServerInterfaceWrapper) EnableUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", r.PathValue("userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EnableUser(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateUserRole operation middleware
+func (siw *ServerInterfaceWrapper) UpdateUserRole(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", r.PathValue("userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateUserRole(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UnlockUserAccount operation middleware
+func (siw *ServerInterfaceWrapper) UnlockUserAccount(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", r.PathValue("userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UnlockUserAccount(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListExercises operation middleware
+func (siw *ServerInterfaceWrapper) ListExercises(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListExercisesParams
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "muscleGroup" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "muscleGroup", r.URL.Query(), &params.MuscleGroup)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "muscleGroup", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "equipment" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "equipment", r.URL.Query(), &params.Equipment)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "equipment", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListExercises(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateExercise operation middleware
+func (siw *ServerInterfaceWrapper) CreateExercise(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateExercise(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteExercise operation middleware
+func (siw *ServerInterfaceWrapper) DeleteExercise(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "exerciseId" -------------
+	var exerciseId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "exerciseId", r.PathValue("exerciseId"), &exerciseId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "exerciseId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteExercise(w, r, exerciseId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetExerciseById operation middleware
+func (siw *ServerInterfaceWrapper) GetExerciseById(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "exerciseId" -------------
+	var exerciseId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "exerciseId", r.PathValue("exerciseId"), &exerciseId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "exerciseId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetExerciseById(w, r, exerciseId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateExercise operation middleware
+func (siw *ServerInterfaceWrapper) UpdateExercise(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "exerciseId" -------------
+	var exerciseId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "exerciseId", r.PathValue("exerciseId"), &exerciseId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "exerciseId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateExercise(w, r, exerciseId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ExportWorkouts operation middleware
+func (siw *ServerInterfaceWrapper) ExportWorkouts(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportWorkoutsParams
+
+	// ------------- Required query parameter "format" -------------
+
+	if paramValue := r.URL.Query().Get("format"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "format"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportWorkouts(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// TriggerMissedWorkouts operation middleware
+func (siw *ServerInterfaceWrapper) TriggerMissedWorkouts(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.TriggerMissedWorkouts(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReportConsistency operation middleware
+func (siw *ServerInterfaceWrapper) ReportConsistency(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReportConsistencyParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "compareTo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "compareTo", r.URL.Query(), &params.CompareTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "compareTo", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "weeklyTarget" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "weeklyTarget", r.URL.Query(), &params.WeeklyTarget)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "weeklyTarget", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReportConsistency(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReportStrength operation middleware
+func (siw *ServerInterfaceWrapper) ReportStrength(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "exerciseId" -------------
+	var exerciseId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "exerciseId", r.PathValue("exerciseId"), &exerciseId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "exerciseId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReportStrengthParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "compareTo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "compareTo", r.URL.Query(), &params.CompareTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "compareTo", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "formula" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "formula", r.URL.Query(), &params.Formula)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "formula", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "unit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "unit", r.URL.Query(), &params.Unit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "unit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "trendWeeks" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "trendWeeks", r.URL.Query(), &params.TrendWeeks)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "trendWeeks", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReportStrength(w, r, exerciseId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReportMuscleBalance operation middleware
+func (siw *ServerInterfaceWrapper) ReportMuscleBalance(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReportMuscleBalanceParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "compareTo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "compareTo", r.URL.Query(), &params.CompareTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "compareTo", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "unit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "unit", r.URL.Query(), &params.Unit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "unit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReportMuscleBalance(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReportPersonalRecords operation middleware
+func (siw *ServerInterfaceWrapper) ReportPersonalRecords(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReportPersonalRecordsParams
+
+	// ------------- Optional query parameter "exerciseId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "exerciseId", r.URL.Query(), &params.ExerciseId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "exerciseId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReportPersonalRecords(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReportProgress operation middleware
+func (siw *ServerInterfaceWrapper) ReportProgress(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReportProgressParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "compareTo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "compareTo", r.URL.Query(), &params.CompareTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "compareTo", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReportProgress(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReportVolume operation middleware
+func (siw *ServerInterfaceWrapper) ReportVolume(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReportVolumeParams
+
+	// ------------- Required query parameter "from" -------------
+
+	if paramValue := r.URL.Query().Get("from"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "from"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Required query parameter "to" -------------
+
+	if paramValue := r.URL.Query().Get("to"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "to"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "compareTo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "compareTo", r.URL.Query(), &params.CompareTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "compareTo", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "bucket" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "bucket", r.URL.Query(), &params.Bucket)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "bucket", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "unit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "unit", r.URL.Query(), &params.Unit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "unit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReportVolume(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListSchedules operation middleware
+func (siw *ServerInterfaceWrapper) ListSchedules(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListSchedules(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateSchedule operation middleware
+func (siw *ServerInterfaceWrapper) CreateSchedule(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateSchedule(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PreviewSchedule operation middleware
+func (siw *ServerInterfaceWrapper) PreviewSchedule(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PreviewSchedule(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetScheduleById operation middleware
+func (siw *ServerInterfaceWrapper) GetScheduleById(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "scheduleId" -------------
+	var scheduleId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "scheduleId", r.PathValue("scheduleId"), &scheduleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scheduleId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetScheduleById(w, r, scheduleId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelSchedule operation middleware
+func (siw *ServerInterfaceWrapper) CancelSchedule(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "scheduleId" -------------
+	var scheduleId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "scheduleId", r.PathValue("scheduleId"), &scheduleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scheduleId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelSchedule(w, r, scheduleId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateScheduleOccurrence operation middleware
+func (siw *ServerInterfaceWrapper) UpdateScheduleOccurrence(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "scheduleId" -------------
+	var scheduleId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "scheduleId", r.PathValue("scheduleId"), &scheduleId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "scheduleId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "workoutId" -------------
+	var workoutId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "workoutId", r.PathValue("workoutId"), &workoutId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "workoutId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateScheduleOccurrence(w, r, scheduleId, workoutId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListTemplates operation middleware
+func (siw *ServerInterfaceWrapper) ListTemplates(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTemplates(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateTemplate operation middleware
+func (siw *ServerInterfaceWrapper) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateTemplate(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteTemplateById operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTemplateById(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "templateId" -------------
+	var templateId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "templateId", r.PathValue("templateId"), &templateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "templateId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteTemplateById(w, r, templateId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTemplateById operation middleware
+func (siw *ServerInterfaceWrapper) GetTemplateById(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "templateId" -------------
+	var templateId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "templateId", r.PathValue("templateId"), &templateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "templateId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTemplateById(w, r, templateId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateTemplate operation middleware
+func (siw *ServerInterfaceWrapper) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "templateId" -------------
+	var templateId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "templateId", r.PathValue("templateId"), &templateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "templateId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateTemplate(w, r, templateId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// InstantiateTemplate operation middleware
+func (siw *ServerInterfaceWrapper) InstantiateTemplate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "templateId" -------------
+	var templateId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "templateId", r.PathValue("templateId"), &templateId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "templateId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.InstantiateTemplate(w, r, templateId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteUser operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUser(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DisableTwoFactor operation middleware
+func (siw *ServerInterfaceWrapper) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DisableTwoFactor(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ConfirmTwoFactor operation middleware
+func (siw *ServerInterfaceWrapper) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ConfirmTwoFactor(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// EnrollTwoFactor operation middleware
+func (siw *ServerInterfaceWrapper) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EnrollTwoFactor(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelUserDeletion operation middleware
+func (siw *ServerInterfaceWrapper) CancelUserDeletion(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelUserDeletion(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LoginUser operation middleware
+func (siw *ServerInterfaceWrapper) LoginUser(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LoginUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CompleteTwoFactorLogin operation middleware
+func (siw *ServerInterfaceWrapper) CompleteTwoFactorLogin(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CompleteTwoFactorLogin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LogoutUser operation middleware
+func (siw *ServerInterfaceWrapper) LogoutUser(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LogoutUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ChangeUserPassword operation middleware
+func (siw *ServerInterfaceWrapper) ChangeUserPassword(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ChangeUserPassword(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ResetUserPassword operation middleware
+func (siw *ServerInterfaceWrapper) ResetUserPassword(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResetUserPassword(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RequestPasswordReset operation middleware
+func (siw *ServerInterfaceWrapper) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RequestPasswordReset(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateUserPreferences operation middleware
+func (siw *ServerInterfaceWrapper) UpdateUserPreferences(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateUserPreferences(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeAllUserSessions operation middleware
+func (siw *ServerInterfaceWrapper) RevokeAllUserSessions(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeAllUserSessions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListUserSessions operation middleware
+func (siw *ServerInterfaceWrapper) ListUserSessions(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUserSessions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeUserSession operation middleware
+func (siw *ServerInterfaceWrapper) RevokeUserSession(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "sessionId" -------------
+	var sessionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sessionId", r.PathValue("sessionId"), &sessionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeUserSession(w, r, sessionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SignupUser operation middleware
+func (siw *ServerInterfaceWrapper) SignupUser(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SignupUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUserStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetUserStatus(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUserStatus(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RefreshUserToken operation middleware
+func (siw *ServerInterfaceWrapper) RefreshUserToken(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RefreshUserToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListAccessTokens operation middleware
+func (siw *ServerInterfaceWrapper) ListAccessTokens(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAccessTokens(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateAccessToken operation middleware
+func (siw *ServerInterfaceWrapper) CreateAccessToken(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateAccessToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAccessToken operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAccessToken(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tokenId" -------------
+	var tokenId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tokenId", r.PathValue("tokenId"), &tokenId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tokenId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAccessToken(w, r, tokenId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAccessToken operation middleware
+func (siw *ServerInterfaceWrapper) GetAccessToken(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tokenId" -------------
+	var tokenId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tokenId", r.PathValue("tokenId"), &tokenId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tokenId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAccessToken(w, r, tokenId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateAccessToken operation middleware
+func (siw *ServerInterfaceWrapper) UpdateAccessToken(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tokenId" -------------
+	var tokenId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tokenId", r.PathValue("tokenId"), &tokenId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tokenId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateAccessToken(w, r, tokenId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// VerifyUserEmail operation middleware
+func (siw *ServerInterfaceWrapper) VerifyUserEmail(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.VerifyUserEmail(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ResendVerificationEmail operation middleware
+func (siw *ServerInterfaceWrapper) ResendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResendVerificationEmail(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListWorkoutPlans operation middleware
+func (siw *ServerInterfaceWrapper) ListWorkoutPlans(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWorkoutPlansParams
+
+	// ------------- Optional query parameter "status" -------------
 
-// SignupUserJSONRequestBody defines body for SignupUser for application/json ContentType.
-type SignupUserJSONRequestBody = UserSignup
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
 
-// CreateWorkoutPlanJSONRequestBody defines body for CreateWorkoutPlan for application/json ContentType.
-type CreateWorkoutPlanJSONRequestBody = CreateWorkoutPlan
+	// ------------- Required query parameter "sort" -------------
 
-// CompleteWorkoutPlanbyIdJSONRequestBody defines body for CompleteWorkoutPlanbyId for application/json ContentType.
-type CompleteWorkoutPlanbyIdJSONRequestBody = CompleteWorkoutPlan
+	if paramValue := r.URL.Query().Get("sort"); paramValue != "" {
 
-// ScheduleWorkoutPlanbyIdJSONRequestBody defines body for ScheduleWorkoutPlanbyId for application/json ContentType.
-type ScheduleWorkoutPlanbyIdJSONRequestBody ScheduleWorkoutPlanbyIdJSONBody
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "sort"})
+		return
+	}
 
-// UpdateExercisePlansInWorkoutPlanJSONRequestBody defines body for UpdateExercisePlansInWorkoutPlan for application/json ContentType.
-type UpdateExercisePlansInWorkoutPlanJSONRequestBody UpdateExercisePlansInWorkoutPlanJSONBody
+	err = runtime.BindQueryParameter("form", true, true, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Get all exercises
-	// (GET /exercises)
-	ListExercises(w http.ResponseWriter, r *http.Request)
-	// get an exercise by a specific id
-	// (GET /exercises/{exerciseId})
-	GetExerciseById(w http.ResponseWriter, r *http.Request, exerciseId int64)
-	// generate report on workout
-	// (GET /report/progress)
-	ReportProgress(w http.ResponseWriter, r *http.Request)
-	// Authenticate user and get an access token.
-	// (POST /user/login)
-	LoginUser(w http.ResponseWriter, r *http.Request)
-	// Logs out current logged in user.
-	// (POST /user/logout)
-	LogoutUser(w http.ResponseWriter, r *http.Request)
-	// Register a new user.
-	// (POST /user/signup)
-	SignupUser(w http.ResponseWriter, r *http.Request)
-	// Get user information.
-	// (GET /user/status)
-	GetUserStatus(w http.ResponseWriter, r *http.Request)
-	// List workout plans
-	// (GET /workouts)
-	ListWorkoutPlans(w http.ResponseWriter, r *http.Request, params ListWorkoutPlansParams)
-	// create a workout plan
-	// (POST /workouts)
-	CreateWorkoutPlan(w http.ResponseWriter, r *http.Request)
-	// delete a workout plan by a specific id
-	// (DELETE /workouts/{workoutId})
-	DeleteWorkoutPlanbyId(w http.ResponseWriter, r *http.Request, workoutId int64)
-	// get a workout plan by a specific id
-	// (GET /workouts/{workoutId})
-	GetWorkoutPlanbyId(w http.ResponseWriter, r *http.Request, workoutId int64)
-	// complete a workout plan by a specific id
-	// (PUT /workouts/{workoutId}/complete)
-	CompleteWorkoutPlanbyId(w http.ResponseWriter, r *http.Request, workoutId int64)
-	// schedule a workout plan by a specific id
-	// (PUT /workouts/{workoutId}/schedule)
-	ScheduleWorkoutPlanbyId(w http.ResponseWriter, r *http.Request, workoutId int64)
-	// update exercise plans
-	// (PUT /workouts/{workoutId}/update-exercise-plans)
-	UpdateExercisePlansInWorkoutPlan(w http.ResponseWriter, r *http.Request, workoutId int64)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWorkoutPlans(w, r, params)
+	}))
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type MiddlewareFunc func(http.Handler) http.Handler
+	handler.ServeHTTP(w, r)
+}
 
-// ListExercises operation middleware
-func (siw *ServerInterfaceWrapper) ListExercises(w http.ResponseWriter, r *http.Request) {
+// CreateWorkoutPlan operation middleware
+func (siw *ServerInterfaceWrapper) CreateWorkoutPlan(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -307,7 +3257,7 @@ func (siw *ServerInterfaceWrapper) ListExercises(w http.ResponseWriter, r *http.
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListExercises(w, r)
+		siw.Handler.CreateWorkoutPlan(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -317,17 +3267,17 @@ func (siw *ServerInterfaceWrapper) ListExercises(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// GetExerciseById operation middleware
-func (siw *ServerInterfaceWrapper) GetExerciseById(w http.ResponseWriter, r *http.Request) {
+// DeleteWorkoutPlanById operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWorkoutPlanById(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "exerciseId" -------------
-	var exerciseId int64
+	// ------------- Path parameter "workoutId" -------------
+	var workoutId int64
 
-	err = runtime.BindStyledParameterWithOptions("simple", "exerciseId", r.PathValue("exerciseId"), &exerciseId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "workoutId", r.PathValue("workoutId"), &workoutId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "exerciseId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "workoutId", Err: err})
 		return
 	}
 
@@ -338,7 +3288,7 @@ func (siw *ServerInterfaceWrapper) GetExerciseById(w http.ResponseWriter, r *htt
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetExerciseById(w, r, exerciseId)
+		siw.Handler.DeleteWorkoutPlanById(w, r, workoutId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -348,8 +3298,19 @@ func (siw *ServerInterfaceWrapper) GetExerciseById(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// ReportProgress operation middleware
-func (siw *ServerInterfaceWrapper) ReportProgress(w http.ResponseWriter, r *http.Request) {
+// GetWorkoutPlanById operation middleware
+func (siw *ServerInterfaceWrapper) GetWorkoutPlanById(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "workoutId" -------------
+	var workoutId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "workoutId", r.PathValue("workoutId"), &workoutId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "workoutId", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -358,7 +3319,7 @@ func (siw *ServerInterfaceWrapper) ReportProgress(w http.ResponseWriter, r *http
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReportProgress(w, r)
+		siw.Handler.GetWorkoutPlanById(w, r, workoutId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -368,11 +3329,28 @@ func (siw *ServerInterfaceWrapper) ReportProgress(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// LoginUser operation middleware
-func (siw *ServerInterfaceWrapper) LoginUser(w http.ResponseWriter, r *http.Request) {
+// CompleteWorkoutPlanById operation middleware
+func (siw *ServerInterfaceWrapper) CompleteWorkoutPlanById(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "workoutId" -------------
+	var workoutId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "workoutId", r.PathValue("workoutId"), &workoutId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "workoutId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.LoginUser(w, r)
+		siw.Handler.CompleteWorkoutPlanById(w, r, workoutId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -382,8 +3360,19 @@ func (siw *ServerInterfaceWrapper) LoginUser(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// LogoutUser operation middleware
-func (siw *ServerInterfaceWrapper) LogoutUser(w http.ResponseWriter, r *http.Request) {
+// AddExercisePlan operation middleware
+func (siw *ServerInterfaceWrapper) AddExercisePlan(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "workoutId" -------------
+	var workoutId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "workoutId", r.PathValue("workoutId"), &workoutId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "workoutId", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -392,7 +3381,7 @@ func (siw *ServerInterfaceWrapper) LogoutUser(w http.ResponseWriter, r *http.Req
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.LogoutUser(w, r)
+		siw.Handler.AddExercisePlan(w, r, workoutId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -402,22 +3391,28 @@ func (siw *ServerInterfaceWrapper) LogoutUser(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// SignupUser operation middleware
-func (siw *ServerInterfaceWrapper) SignupUser(w http.ResponseWriter, r *http.Request) {
+// RemoveExercisePlan operation middleware
+func (siw *ServerInterfaceWrapper) RemoveExercisePlan(w http.ResponseWriter, r *http.Request) {
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SignupUser(w, r)
-	}))
+	var err error
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	// ------------- Path parameter "workoutId" -------------
+	var workoutId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "workoutId", r.PathValue("workoutId"), &workoutId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "workoutId", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r)
-}
+	// ------------- Path parameter "exercisePlanId" -------------
+	var exercisePlanId int64
 
-// GetUserStatus operation middleware
-func (siw *ServerInterfaceWrapper) GetUserStatus(w http.ResponseWriter, r *http.Request) {
+	err = runtime.BindStyledParameterWithOptions("simple", "exercisePlanId", r.PathValue("exercisePlanId"), &exercisePlanId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "exercisePlanId", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -426,7 +3421,7 @@ func (siw *ServerInterfaceWrapper) GetUserStatus(w http.ResponseWriter, r *http.
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUserStatus(w, r)
+		siw.Handler.RemoveExercisePlan(w, r, workoutId, exercisePlanId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -436,45 +3431,77 @@ func (siw *ServerInterfaceWrapper) GetUserStatus(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// ListWorkoutPlans operation middleware
-func (siw *ServerInterfaceWrapper) ListWorkoutPlans(w http.ResponseWriter, r *http.Request) {
+// MoveExercisePlan operation middleware
+func (siw *ServerInterfaceWrapper) MoveExercisePlan(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
+	// ------------- Path parameter "workoutId" -------------
+	var workoutId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "workoutId", r.PathValue("workoutId"), &workoutId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "workoutId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "exercisePlanId" -------------
+	var exercisePlanId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "exercisePlanId", r.PathValue("exercisePlanId"), &exercisePlanId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "exercisePlanId", Err: err})
+		return
+	}
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	r = r.WithContext(ctx)
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListWorkoutPlansParams
-
-	// ------------- Optional query parameter "status" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MoveExercisePlan(w, r, workoutId, exercisePlanId)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// ------------- Required query parameter "sort" -------------
+	handler.ServeHTTP(w, r)
+}
 
-	if paramValue := r.URL.Query().Get("sort"); paramValue != "" {
+// ListPerformedSets operation middleware
+func (siw *ServerInterfaceWrapper) ListPerformedSets(w http.ResponseWriter, r *http.Request) {
 
-	} else {
-		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "sort"})
+	var err error
+
+	// ------------- Path parameter "workoutId" -------------
+	var workoutId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "workoutId", r.PathValue("workoutId"), &workoutId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "workoutId", Err: err})
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "sort", r.URL.Query(), &params.Sort)
+	// ------------- Path parameter "exercisePlanId" -------------
+	var exercisePlanId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "exercisePlanId", r.PathValue("exercisePlanId"), &exercisePlanId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "exercisePlanId", Err: err})
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListWorkoutPlans(w, r, params)
+		siw.Handler.ListPerformedSets(w, r, workoutId, exercisePlanId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -484,8 +3511,28 @@ func (siw *ServerInterfaceWrapper) ListWorkoutPlans(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// CreateWorkoutPlan operation middleware
-func (siw *ServerInterfaceWrapper) CreateWorkoutPlan(w http.ResponseWriter, r *http.Request) {
+// LogPerformedSet operation middleware
+func (siw *ServerInterfaceWrapper) LogPerformedSet(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "workoutId" -------------
+	var workoutId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "workoutId", r.PathValue("workoutId"), &workoutId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "workoutId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "exercisePlanId" -------------
+	var exercisePlanId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "exercisePlanId", r.PathValue("exercisePlanId"), &exercisePlanId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "exercisePlanId", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -494,7 +3541,7 @@ func (siw *ServerInterfaceWrapper) CreateWorkoutPlan(w http.ResponseWriter, r *h
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateWorkoutPlan(w, r)
+		siw.Handler.LogPerformedSet(w, r, workoutId, exercisePlanId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -504,8 +3551,8 @@ func (siw *ServerInterfaceWrapper) CreateWorkoutPlan(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteWorkoutPlanbyId operation middleware
-func (siw *ServerInterfaceWrapper) DeleteWorkoutPlanbyId(w http.ResponseWriter, r *http.Request) {
+// DeletePerformedSet operation middleware
+func (siw *ServerInterfaceWrapper) DeletePerformedSet(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -518,6 +3565,24 @@ func (siw *ServerInterfaceWrapper) DeleteWorkoutPlanbyId(w http.ResponseWriter,
 		return
 	}
 
+	// ------------- Path parameter "exercisePlanId" -------------
+	var exercisePlanId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "exercisePlanId", r.PathValue("exercisePlanId"), &exercisePlanId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "exercisePlanId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "setId" -------------
+	var setId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "setId", r.PathValue("setId"), &setId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "setId", Err: err})
+		return
+	}
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
@@ -525,7 +3590,7 @@ func (siw *ServerInterfaceWrapper) DeleteWorkoutPlanbyId(w http.ResponseWriter,
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteWorkoutPlanbyId(w, r, workoutId)
+		siw.Handler.DeletePerformedSet(w, r, workoutId, exercisePlanId, setId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -535,8 +3600,8 @@ func (siw *ServerInterfaceWrapper) DeleteWorkoutPlanbyId(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// GetWorkoutPlanbyId operation middleware
-func (siw *ServerInterfaceWrapper) GetWorkoutPlanbyId(w http.ResponseWriter, r *http.Request) {
+// UpdatePerformedSet operation middleware
+func (siw *ServerInterfaceWrapper) UpdatePerformedSet(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -549,6 +3614,24 @@ func (siw *ServerInterfaceWrapper) GetWorkoutPlanbyId(w http.ResponseWriter, r *
 		return
 	}
 
+	// ------------- Path parameter "exercisePlanId" -------------
+	var exercisePlanId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "exercisePlanId", r.PathValue("exercisePlanId"), &exercisePlanId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "exercisePlanId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "setId" -------------
+	var setId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "setId", r.PathValue("setId"), &setId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "setId", Err: err})
+		return
+	}
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
@@ -556,7 +3639,7 @@ func (siw *ServerInterfaceWrapper) GetWorkoutPlanbyId(w http.ResponseWriter, r *
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetWorkoutPlanbyId(w, r, workoutId)
+		siw.Handler.UpdatePerformedSet(w, r, workoutId, exercisePlanId, setId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -566,8 +3649,8 @@ func (siw *ServerInterfaceWrapper) GetWorkoutPlanbyId(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// CompleteWorkoutPlanbyId operation middleware
-func (siw *ServerInterfaceWrapper) CompleteWorkoutPlanbyId(w http.ResponseWriter, r *http.Request) {
+// SaveWorkoutAsTemplate operation middleware
+func (siw *ServerInterfaceWrapper) SaveWorkoutAsTemplate(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -587,7 +3670,7 @@ func (siw *ServerInterfaceWrapper) CompleteWorkoutPlanbyId(w http.ResponseWriter
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CompleteWorkoutPlanbyId(w, r, workoutId)
+		siw.Handler.SaveWorkoutAsTemplate(w, r, workoutId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -597,8 +3680,8 @@ func (siw *ServerInterfaceWrapper) CompleteWorkoutPlanbyId(w http.ResponseWriter
 	handler.ServeHTTP(w, r)
 }
 
-// ScheduleWorkoutPlanbyId operation middleware
-func (siw *ServerInterfaceWrapper) ScheduleWorkoutPlanbyId(w http.ResponseWriter, r *http.Request) {
+// ScheduleWorkoutPlanById operation middleware
+func (siw *ServerInterfaceWrapper) ScheduleWorkoutPlanById(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -618,7 +3701,7 @@ func (siw *ServerInterfaceWrapper) ScheduleWorkoutPlanbyId(w http.ResponseWriter
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ScheduleWorkoutPlanbyId(w, r, workoutId)
+		siw.Handler.ScheduleWorkoutPlanById(w, r, workoutId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -779,19 +3862,80 @@ func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.H
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	m.HandleFunc("GET "+options.BaseURL+"/.well-known/jwks.json", wrapper.GetJwks)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/exercises", wrapper.CreateCatalogExercise)
+	m.HandleFunc("DELETE "+options.BaseURL+"/admin/exercises/{exerciseId}", wrapper.DeleteCatalogExercise)
+	m.HandleFunc("PUT "+options.BaseURL+"/admin/exercises/{exerciseId}", wrapper.UpdateCatalogExercise)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/stats", wrapper.GetSystemStats)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/users", wrapper.ListUsers)
+	m.HandleFunc("PUT "+options.BaseURL+"/admin/users/{userId}/disable", wrapper.DisableUser)
+	m.HandleFunc("PUT "+options.BaseURL+"/admin/users/{userId}/enable", wrapper.EnableUser)
+	m.HandleFunc("PUT "+options.BaseURL+"/admin/users/{userId}/role", wrapper.UpdateUserRole)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/users/{userId}/unlock", wrapper.UnlockUserAccount)
 	m.HandleFunc("GET "+options.BaseURL+"/exercises", wrapper.ListExercises)
+	m.HandleFunc("POST "+options.BaseURL+"/exercises", wrapper.CreateExercise)
+	m.HandleFunc("DELETE "+options.BaseURL+"/exercises/{exerciseId}", wrapper.DeleteExercise)
 	m.HandleFunc("GET "+options.BaseURL+"/exercises/{exerciseId}", wrapper.GetExerciseById)
+	m.HandleFunc("PUT "+options.BaseURL+"/exercises/{exerciseId}", wrapper.UpdateExercise)
+	m.HandleFunc("GET "+options.BaseURL+"/export/workouts", wrapper.ExportWorkouts)
+	m.HandleFunc("POST "+options.BaseURL+"/jobs/missed-workouts", wrapper.TriggerMissedWorkouts)
+	m.HandleFunc("GET "+options.BaseURL+"/report/consistency", wrapper.ReportConsistency)
+	m.HandleFunc("GET "+options.BaseURL+"/report/exercises/{exerciseId}/strength", wrapper.ReportStrength)
+	m.HandleFunc("GET "+options.BaseURL+"/report/muscle-balance", wrapper.ReportMuscleBalance)
+	m.HandleFunc("GET "+options.BaseURL+"/report/personal-records", wrapper.ReportPersonalRecords)
 	m.HandleFunc("GET "+options.BaseURL+"/report/progress", wrapper.ReportProgress)
+	m.HandleFunc("GET "+options.BaseURL+"/report/volume", wrapper.ReportVolume)
+	m.HandleFunc("GET "+options.BaseURL+"/schedules", wrapper.ListSchedules)
+	m.HandleFunc("POST "+options.BaseURL+"/schedules", wrapper.CreateSchedule)
+	m.HandleFunc("POST "+options.BaseURL+"/schedules/preview", wrapper.PreviewSchedule)
+	m.HandleFunc("GET "+options.BaseURL+"/schedules/{scheduleId}", wrapper.GetScheduleById)
+	m.HandleFunc("PUT "+options.BaseURL+"/schedules/{scheduleId}/cancel", wrapper.CancelSchedule)
+	m.HandleFunc("PUT "+options.BaseURL+"/schedules/{scheduleId}/occurrences/{workoutId}", wrapper.UpdateScheduleOccurrence)
+	m.HandleFunc("GET "+options.BaseURL+"/templates", wrapper.ListTemplates)
+	m.HandleFunc("POST "+options.BaseURL+"/templates", wrapper.CreateTemplate)
+	m.HandleFunc("DELETE "+options.BaseURL+"/templates/{templateId}", wrapper.DeleteTemplateById)
+	m.HandleFunc("GET "+options.BaseURL+"/templates/{templateId}", wrapper.GetTemplateById)
+	m.HandleFunc("PUT "+options.BaseURL+"/templates/{templateId}", wrapper.UpdateTemplate)
+	m.HandleFunc("POST "+options.BaseURL+"/templates/{templateId}/instantiate", wrapper.InstantiateTemplate)
+	m.HandleFunc("DELETE "+options.BaseURL+"/user", wrapper.DeleteUser)
+	m.HandleFunc("DELETE "+options.BaseURL+"/user/2fa", wrapper.DisableTwoFactor)
+	m.HandleFunc("POST "+options.BaseURL+"/user/2fa/confirm", wrapper.ConfirmTwoFactor)
+	m.HandleFunc("POST "+options.BaseURL+"/user/2fa/enroll", wrapper.EnrollTwoFactor)
+	m.HandleFunc("POST "+options.BaseURL+"/user/deletion/cancel", wrapper.CancelUserDeletion)
 	m.HandleFunc("POST "+options.BaseURL+"/user/login", wrapper.LoginUser)
+	m.HandleFunc("POST "+options.BaseURL+"/user/login/2fa", wrapper.CompleteTwoFactorLogin)
 	m.HandleFunc("POST "+options.BaseURL+"/user/logout", wrapper.LogoutUser)
+	m.HandleFunc("PUT "+options.BaseURL+"/user/password", wrapper.ChangeUserPassword)
+	m.HandleFunc("POST "+options.BaseURL+"/user/password/reset", wrapper.ResetUserPassword)
+	m.HandleFunc("POST "+options.BaseURL+"/user/password/reset/request", wrapper.RequestPasswordReset)
+	m.HandleFunc("PUT "+options.BaseURL+"/user/preferences", wrapper.UpdateUserPreferences)
+	m.HandleFunc("DELETE "+options.BaseURL+"/user/sessions", wrapper.RevokeAllUserSessions)
+	m.HandleFunc("GET "+options.BaseURL+"/user/sessions", wrapper.ListUserSessions)
+	m.HandleFunc("DELETE "+options.BaseURL+"/user/sessions/{sessionId}", wrapper.RevokeUserSession)
 	m.HandleFunc("POST "+options.BaseURL+"/user/signup", wrapper.SignupUser)
 	m.HandleFunc("GET "+options.BaseURL+"/user/status", wrapper.GetUserStatus)
+	m.HandleFunc("POST "+options.BaseURL+"/user/token/refresh", wrapper.RefreshUserToken)
+	m.HandleFunc("GET "+options.BaseURL+"/user/tokens", wrapper.ListAccessTokens)
+	m.HandleFunc("POST "+options.BaseURL+"/user/tokens", wrapper.CreateAccessToken)
+	m.HandleFunc("DELETE "+options.BaseURL+"/user/tokens/{tokenId}", wrapper.DeleteAccessToken)
+	m.HandleFunc("GET "+options.BaseURL+"/user/tokens/{tokenId}", wrapper.GetAccessToken)
+	m.HandleFunc("PUT "+options.BaseURL+"/user/tokens/{tokenId}", wrapper.UpdateAccessToken)
+	m.HandleFunc("POST "+options.BaseURL+"/user/verify-email", wrapper.VerifyUserEmail)
+	m.HandleFunc("POST "+options.BaseURL+"/user/verify-email/resend", wrapper.ResendVerificationEmail)
 	m.HandleFunc("GET "+options.BaseURL+"/workouts", wrapper.ListWorkoutPlans)
 	m.HandleFunc("POST "+options.BaseURL+"/workouts", wrapper.CreateWorkoutPlan)
-	m.HandleFunc("DELETE "+options.BaseURL+"/workouts/{workoutId}", wrapper.DeleteWorkoutPlanbyId)
-	m.HandleFunc("GET "+options.BaseURL+"/workouts/{workoutId}", wrapper.GetWorkoutPlanbyId)
-	m.HandleFunc("PUT "+options.BaseURL+"/workouts/{workoutId}/complete", wrapper.CompleteWorkoutPlanbyId)
-	m.HandleFunc("PUT "+options.BaseURL+"/workouts/{workoutId}/schedule", wrapper.ScheduleWorkoutPlanbyId)
+	m.HandleFunc("DELETE "+options.BaseURL+"/workouts/{workoutId}", wrapper.DeleteWorkoutPlanById)
+	m.HandleFunc("GET "+options.BaseURL+"/workouts/{workoutId}", wrapper.GetWorkoutPlanById)
+	m.HandleFunc("PUT "+options.BaseURL+"/workouts/{workoutId}/complete", wrapper.CompleteWorkoutPlanById)
+	m.HandleFunc("POST "+options.BaseURL+"/workouts/{workoutId}/exercise-plans", wrapper.AddExercisePlan)
+	m.HandleFunc("DELETE "+options.BaseURL+"/workouts/{workoutId}/exercise-plans/{exercisePlanId}", wrapper.RemoveExercisePlan)
+	m.HandleFunc("PUT "+options.BaseURL+"/workouts/{workoutId}/exercise-plans/{exercisePlanId}/move", wrapper.MoveExercisePlan)
+	m.HandleFunc("GET "+options.BaseURL+"/workouts/{workoutId}/exercise-plans/{exercisePlanId}/sets", wrapper.ListPerformedSets)
+	m.HandleFunc("POST "+options.BaseURL+"/workouts/{workoutId}/exercise-plans/{exercisePlanId}/sets", wrapper.LogPerformedSet)
+	m.HandleFunc("DELETE "+options.BaseURL+"/workouts/{workoutId}/exercise-plans/{exercisePlanId}/sets/{setId}", wrapper.DeletePerformedSet)
+	m.HandleFunc("PUT "+options.BaseURL+"/workouts/{workoutId}/exercise-plans/{exercisePlanId}/sets/{setId}", wrapper.UpdatePerformedSet)
+	m.HandleFunc("POST "+options.BaseURL+"/workouts/{workoutId}/save-as-template", wrapper.SaveWorkoutAsTemplate)
+	m.HandleFunc("PUT "+options.BaseURL+"/workouts/{workoutId}/schedule", wrapper.ScheduleWorkoutPlanById)
 	m.HandleFunc("PUT "+options.BaseURL+"/workouts/{workoutId}/update-exercise-plans", wrapper.UpdateExercisePlansInWorkoutPlan)
 
 	return m